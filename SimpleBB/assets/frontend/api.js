@@ -1155,11 +1155,12 @@ class Forum {
 }
 
 class Message {
-	constructor(id, threadId, text, textChecksum, creator, editor) {
+	constructor(id, threadId, text, textChecksum, textHtml, creator, editor) {
 		this.Id = id;
 		this.ThreadId = threadId;
 		this.Text = text;
 		this.TextChecksum = textChecksum;
+		this.TextHtml = textHtml;
 		this.Creator = creator;
 		this.Editor = editor;
 	}
@@ -1548,7 +1549,7 @@ function jsonToMessage(x) {
 	if (x == null) return null;
 	let creator = jsonToEventParameters(x.creator);
 	let editor = jsonToOptionalEventParameters(x.editor);
-	return new Message(x.id, x.threadId, x.text, x.textChecksum, creator, editor);
+	return new Message(x.id, x.threadId, x.text, x.textChecksum, x.textHtml, creator, editor);
 }
 
 function jsonToMessages(x) {
@@ -1939,6 +1940,15 @@ function processMessageText(msgText) {
 	return txt;
 }
 
+// getMessageHtml returns the message text rendered and sanitised by the
+// server. Messages which have not been rendered are shown as a plain text.
+function getMessageHtml(message) {
+	if ((message.TextHtml === undefined) || (message.TextHtml === null) || (message.TextHtml.length === 0)) {
+		return processMessageText(message.Text);
+	}
+	return message.TextHtml;
+}
+
 function preparePageNumber(pageCount) {
 	// Repair the page count.
 	if ((pageCount === undefined) || (pageCount === 0)) {
@@ -1792,7 +1792,7 @@ async function drawMessage(p, message) {
 	divMsgBody.id = "messageBody_" + message.Id;
 	ml = SectionMarginDelta * 2;
 	divMsgBody.style.cssText = "margin-left: " + ml + "px";
-	divMsgBody.innerHTML = processMessageText(message.Text);
+	divMsgBody.innerHTML = getMessageHtml(message);
 	p.appendChild(divMsgBody);
 }
 
@@ -1827,7 +1827,7 @@ async function drawThreadMessages(p, thread, messagesMap) {
 		divMsgBody.id = "messageBody_" + message.Id;
 		ml = SectionMarginDelta * 2;
 		divMsgBody.style.cssText = "margin-left: " + ml + "px";
-		divMsgBody.innerHTML = processMessageText(message.Text);
+		divMsgBody.innerHTML = getMessageHtml(message);
 		p.appendChild(divMsgBody);
 	}
 }
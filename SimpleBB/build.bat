@@ -20,6 +20,7 @@ SET smtp_folder=SMTP
 SET tool_folder=tool
 SET argon2_tool_folder=Argon2
 SET jwt_tool_folder=MakeJWToken
+SET archive_tool_folder=BoardArchive
 SET assets_folder=assets
 SET frontend_assets_folder=frontend
 
@@ -170,4 +171,12 @@ IF %Errorlevel% NEQ 0 EXIT /b %Errorlevel%
 MOVE "%jwt_tool_folder%.exe" ".\..\..\%build_dir%\%tool_folder%\"
 CD ".\..\..\"
 
+:: 8.3. Archive tool.
+ECHO 8.3. Archive tool
+CD "%tool_folder%\%archive_tool_folder%"
+go build
+IF %Errorlevel% NEQ 0 EXIT /b %Errorlevel%
+MOVE "%archive_tool_folder%.exe" ".\..\..\%build_dir%\%tool_folder%\"
+CD ".\..\..\"
+
 ECHO SUCCESSFUL BUILD
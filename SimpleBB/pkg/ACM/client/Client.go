@@ -25,6 +25,9 @@ const (
 	FuncGetListOfAllUsersOnPage    = "GetListOfAllUsersOnPage"
 	FuncIsUserLoggedIn             = "IsUserLoggedIn"
 
+	// Password reset.
+	FuncResetPassword = "ResetPassword"
+
 	// Various actions.
 	FuncChangePassword = "ChangePassword"
 	FuncChangeEmail    = "ChangeEmail"
@@ -32,6 +35,8 @@ const (
 
 	// User properties.
 	FuncGetUserName        = "GetUserName"
+	FuncGetUserIdsByNames  = "GetUserIdsByNames"
+	FuncGetUserEmailS      = "GetUserEmailS"
 	FuncGetUserRoles       = "GetUserRoles"
 	FuncViewUserParameters = "ViewUserParameters"
 	FuncSetUserRoleAuthor  = "SetUserRoleAuthor"
@@ -39,11 +44,32 @@ const (
 	FuncSetUserRoleReader  = "SetUserRoleReader"
 	FuncGetSelfRoles       = "GetSelfRoles"
 
+	// User permissions.
+	FuncGrantPermission    = "GrantPermission"
+	FuncRevokePermission   = "RevokePermission"
+	FuncGetUserPermissions = "GetUserPermissions"
+
 	// User banning.
 	FuncBanUser   = "BanUser"
 	FuncUnbanUser = "UnbanUser"
 
+	// Two-factor authentication.
+	FuncStartTotpEnrolment          = "StartTotpEnrolment"
+	FuncConfirmTotpEnrolment        = "ConfirmTotpEnrolment"
+	FuncDisableSelfTotp             = "DisableSelfTotp"
+	FuncGetSelfTotpStatus           = "GetSelfTotpStatus"
+	FuncRegenerateTotpRecoveryCodes = "RegenerateTotpRecoveryCodes"
+	FuncResetUserTotp               = "ResetUserTotp"
+
+	// Audit log.
+	FuncAddAuditRecordS = "AddAuditRecordS"
+	FuncGetAuditLog     = "GetAuditLog"
+
+	// Web token keys.
+	FuncGetJwks = "GetJwks"
+
 	// Other.
+	FuncGetDKey            = "GetDKey"
 	FuncShowDiagnosticData = cc.FuncShowDiagnosticData
 	FuncTest               = "Test"
 )
@@ -51,6 +51,11 @@ func (dbo *DatabaseObject) initTableNames() {
 		PasswordChanges:    dbo.prefixTableName(TablePasswordChanges),
 		EmailChanges:       dbo.prefixTableName(TableEmailChanges),
 		LogEvents:          dbo.prefixTableName(TableLogEvents),
+		TotpSecrets:        dbo.prefixTableName(TableTotpSecrets),
+		TotpRecoveryCodes:  dbo.prefixTableName(TableTotpRecoveryCodes),
+		PasswordResets:     dbo.prefixTableName(TablePasswordResets),
+		Permissions:        dbo.prefixTableName(TablePermissions),
+		AuditLog:           dbo.prefixTableName(TableAuditLog),
 	}
 }
 
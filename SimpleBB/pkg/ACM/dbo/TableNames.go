@@ -9,6 +9,11 @@ const (
 	TablePasswordChanges    = "PasswordChanges"
 	TableEmailChanges       = "EmailChanges"
 	TableLogEvents          = "LogEvents"
+	TableTotpSecrets        = "TotpSecrets"
+	TableTotpRecoveryCodes  = "TotpRecoveryCodes"
+	TablePasswordResets     = "PasswordResets"
+	TablePermissions        = "Permissions"
+	TableAuditLog           = "AuditLog"
 )
 
 type TableNames struct {
@@ -20,4 +25,9 @@ type TableNames struct {
 	PasswordChanges    string
 	EmailChanges       string
 	LogEvents          string
+	TotpSecrets        string
+	TotpRecoveryCodes  string
+	PasswordResets     string
+	Permissions        string
+	AuditLog           string
 }
@@ -7,6 +7,8 @@ import (
 	base22 "github.com/vault-thirteen/SimpleBB/pkg/common/interfaces/base2"
 	"github.com/vault-thirteen/SimpleBB/pkg/common/interfaces/derived1"
 	"github.com/vault-thirteen/SimpleBB/pkg/common/interfaces/derived2"
+	"github.com/vault-thirteen/SimpleBB/pkg/common/models/AuditLog"
+	"github.com/vault-thirteen/SimpleBB/pkg/common/models/Permission"
 	"github.com/vault-thirteen/SimpleBB/pkg/common/models/UserRoles"
 	base2 "github.com/vault-thirteen/SimpleBB/pkg/common/models/base"
 	"github.com/vault-thirteen/SimpleBB/pkg/common/models/complex/User"
@@ -61,6 +63,16 @@ func (dbo *DatabaseObject) AttachVerificationCodeToPreSession(userId base2.Id, r
 	return dbo2.CheckRowsAffected(result, 1)
 }
 
+func (dbo *DatabaseObject) AttachVerificationCodeToPwdReset(userId base2.Id, requestId simple.RequestId, code simple.VerificationCode) (err error) {
+	var result sql.Result
+	result, err = dbo.PreparedStatement(DbPsid_AttachVerificationCodeToPwdReset).Exec(code, requestId, userId)
+	if err != nil {
+		return err
+	}
+
+	return dbo2.CheckRowsAffected(result, 1)
+}
+
 func (dbo *DatabaseObject) CheckVerificationCodeForLogIn(requestId simple.RequestId, code simple.VerificationCode) (ok bool, err error) {
 	row := dbo.PreparedStatement(DbPsid_CheckVerificationCodeForLogIn).QueryRow(requestId, code)
 
@@ -93,6 +105,22 @@ func (dbo *DatabaseObject) CheckVerificationCodeForPwdChange(requestId simple.Re
 	return true, nil
 }
 
+func (dbo *DatabaseObject) CheckVerificationCodeForPwdReset(requestId simple.RequestId, code simple.VerificationCode) (ok bool, err error) {
+	row := dbo.PreparedStatement(DbPsid_CheckVerificationCodeForPwdReset).QueryRow(requestId, code)
+
+	var n int
+	n, err = cms.NewNonNullValueFromScannableSource[int](row)
+	if err != nil {
+		return false, err
+	}
+
+	if n != 1 {
+		return false, nil
+	}
+
+	return true, nil
+}
+
 func (dbo *DatabaseObject) CheckVerificationCodeForPreReg(email simple.Email, code simple.VerificationCode) (ok bool, err error) {
 	row := dbo.PreparedStatement(DbPsid_CheckVerificationCodeForPreReg).QueryRow(email, code)
 
@@ -136,6 +164,18 @@ func (dbo *DatabaseObject) CountAllUsers() (n base2.Count, err error) {
 	return n, nil
 }
 
+func (dbo *DatabaseObject) CountAuditRecords(f *al.Filter) (n base2.Count, err error) {
+	query, args := dbo.dbQuery_CountAuditRecords(f)
+	row := dbo.DatabaseObject.DB().QueryRow(query, args...)
+
+	n, err = cms.NewNonNullValueFromScannableSource[base2.Count](row)
+	if err != nil {
+		return dbo2.CountOnError, err
+	}
+
+	return n, nil
+}
+
 func (dbo *DatabaseObject) CountRegistrationsReadyForApproval() (n base2.Count, err error) {
 	row := dbo.PreparedStatement(DbPsid_CountRegistrationsReadyForApproval).QueryRow()
 
@@ -180,6 +220,17 @@ func (dbo *DatabaseObject) CountPasswordChangesByUserId(userId base2.Id) (n base
 	return n, nil
 }
 
+func (dbo *DatabaseObject) CountPasswordResetsByUserId(userId base2.Id) (n base2.Count, err error) {
+	row := dbo.PreparedStatement(DbPsid_CountPasswordResetsByUserId).QueryRow(userId)
+
+	n, err = cms.NewNonNullValueFromScannableSource[base2.Count](row)
+	if err != nil {
+		return dbo2.CountOnError, err
+	}
+
+	return n, nil
+}
+
 func (dbo *DatabaseObject) CountPreSessionsByUserEmail(email simple.Email) (n base2.Count, err error) {
 	row := dbo.PreparedStatement(DbPsid_CountPreSessionsByUserEmail).QueryRow(email)
 
@@ -213,6 +264,17 @@ func (dbo *DatabaseObject) CountSessionsByUserId(userId base2.Id) (n base2.Count
 	return n, nil
 }
 
+func (dbo *DatabaseObject) CountTotpRecoveryCodes(userId base2.Id) (n base2.Count, err error) {
+	row := dbo.PreparedStatement(DbPsid_CountTotpRecoveryCodes).QueryRow(userId)
+
+	n, err = cms.NewNonNullValueFromScannableSource[base2.Count](row)
+	if err != nil {
+		return dbo2.CountOnError, err
+	}
+
+	return n, nil
+}
+
 func (dbo *DatabaseObject) CountUsersWithEmailAbleToLogIn(email simple.Email) (n base2.Count, err error) {
 	row := dbo.PreparedStatement(DbPsid_CountUsersWithEmailAbleToLogIn).QueryRow(email)
 
@@ -288,6 +350,16 @@ func (dbo *DatabaseObject) CreatePasswordChangeRequest(pcr *am.PasswordChange) (
 	return dbo2.CheckRowsAffected(result, 1)
 }
 
+func (dbo *DatabaseObject) CreatePasswordResetRequest(pr *am.PasswordReset) (err error) {
+	var result sql.Result
+	result, err = dbo.PreparedStatement(DbPsid_CreatePasswordResetRequest).Exec(pr.UserId, pr.RequestId, pr.UserIPAB, pr.CaptchaId)
+	if err != nil {
+		return err
+	}
+
+	return dbo2.CheckRowsAffected(result, 1)
+}
+
 func (dbo *DatabaseObject) CreatePreSession(userId base2.Id, requestId simple.RequestId, userIPAB net.IP, pwdSalt []byte, isCaptchaRequired base2.Flag, captchaId *simple.CaptchaId) (err error) {
 	var result sql.Result
 	result, err = dbo.PreparedStatement(DbPsid_CreatePreSession).Exec(userId, requestId, userIPAB, pwdSalt, isCaptchaRequired, captchaId)
@@ -308,6 +380,11 @@ func (dbo *DatabaseObject) CreateSession(userId base2.Id, userIPAB net.IP) (last
 	return dbo2.CheckRowsAffectedAndGetLastInsertedId(result, 1)
 }
 
+func (dbo *DatabaseObject) CreateTotpSecret(userId base2.Id, secret []byte) (err error) {
+	_, err = dbo.PreparedStatement(DbPsid_CreateTotpSecret).Exec(userId, secret)
+	return err
+}
+
 func (dbo *DatabaseObject) DeleteAbandonedPreSessions() (err error) {
 	timeBorder := time.Now().Add(-time.Duration(dbo.sp.PreSessionExpirationTime) * time.Second)
 
@@ -341,6 +418,16 @@ func (dbo *DatabaseObject) DeletePasswordChangeByRequestId(requestId simple.Requ
 	return dbo2.CheckRowsAffected(result, 1)
 }
 
+func (dbo *DatabaseObject) DeletePasswordResetByRequestId(requestId simple.RequestId) (err error) {
+	var result sql.Result
+	result, err = dbo.PreparedStatement(DbPsid_DeletePasswordResetByRequestId).Exec(requestId)
+	if err != nil {
+		return err
+	}
+
+	return dbo2.CheckRowsAffected(result, 1)
+}
+
 func (dbo *DatabaseObject) DeletePreRegUserIfNotApprovedByEmail(email simple.Email) (err error) {
 	var result sql.Result
 	result, err = dbo.PreparedStatement(DbPsid_DeletePreRegUserIfNotApprovedByEmail).Exec(email)
@@ -381,6 +468,26 @@ func (dbo *DatabaseObject) DeleteSessionByUserId(userId base2.Id) (err error) {
 	return dbo2.CheckRowsAffected(result, 1)
 }
 
+func (dbo *DatabaseObject) DeleteTotpRecoveryCodes(userId base2.Id) (err error) {
+	_, err = dbo.PreparedStatement(DbPsid_DeleteTotpRecoveryCodes).Exec(userId)
+	return err
+}
+
+func (dbo *DatabaseObject) DeleteTotpSecret(userId base2.Id) (err error) {
+	_, err = dbo.PreparedStatement(DbPsid_DeleteTotpSecret).Exec(userId)
+	return err
+}
+
+func (dbo *DatabaseObject) EnableTotp(userId base2.Id, lastUsedStep int64) (err error) {
+	var result sql.Result
+	result, err = dbo.PreparedStatement(DbPsid_EnableTotp).Exec(lastUsedStep, userId)
+	if err != nil {
+		return err
+	}
+
+	return dbo2.CheckRowsAffected(result, 1)
+}
+
 func (dbo *DatabaseObject) GetEmailChangeByRequestId(requestId simple.RequestId) (ecr *am.EmailChange, err error) {
 	row := dbo.PreparedStatement(DbPsid_GetEmailChangeByRequestId).QueryRow(requestId)
 	return am.NewEmailChangeFromScannableSource(row)
@@ -476,6 +583,28 @@ func (dbo *DatabaseObject) GetPasswordChangeByRequestId(requestId simple.Request
 	return am.NewPasswordChangeFromScannableSource(row)
 }
 
+func (dbo *DatabaseObject) GetPasswordResetByRequestId(requestId simple.RequestId) (pr *am.PasswordReset, err error) {
+	row := dbo.PreparedStatement(DbPsid_GetPasswordResetByRequestId).QueryRow(requestId)
+	return am.NewPasswordResetFromScannableSource(row)
+}
+
+func (dbo *DatabaseObject) GetPermissionsByUserId(userId base2.Id) (grants []*perm.Grant, err error) {
+	var rows *sql.Rows
+	rows, err = dbo.PreparedStatement(DbPsid_GetPermissionsByUserId).Query(userId)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		derr := rows.Close()
+		if derr != nil {
+			err = ae.Combine(err, derr)
+		}
+	}()
+
+	return perm.NewGrantArrayFromRows(rows)
+}
+
 func (dbo *DatabaseObject) GetPreSessionByRequestId(requestId simple.RequestId) (preSession *am.PreSession, err error) {
 	row := dbo.PreparedStatement(DbPsid_GetPreSessionByRequestId).QueryRow(requestId)
 	return am.NewPreSessionFromScannableSource(row)
@@ -486,6 +615,11 @@ func (dbo *DatabaseObject) GetSessionByUserId(userId base2.Id) (session *am.Sess
 	return am.NewSessionFromScannableSource(row)
 }
 
+func (dbo *DatabaseObject) GetTotpSecret(userId base2.Id) (ts *am.TotpSecret, err error) {
+	row := dbo.PreparedStatement(DbPsid_GetTotpSecret).QueryRow(userId)
+	return am.NewTotpSecretFromScannableSource(row)
+}
+
 func (dbo *DatabaseObject) GetUserNameById(userId base2.Id) (userName *simple.Name, err error) {
 	row := dbo.PreparedStatement(DbPsid_GetUserNameById).QueryRow(userId)
 	return cms.NewValueFromScannableSource[simple.Name](row)
@@ -507,6 +641,11 @@ func (dbo *DatabaseObject) GetUserIdByEmail(email simple.Email) (userId base2.Id
 	return userId, nil
 }
 
+func (dbo *DatabaseObject) GetUserIdByName(name simple.Name) (userId *base2.Id, err error) {
+	row := dbo.PreparedStatement(DbPsid_GetUserIdByName).QueryRow(name)
+	return cms.NewValueFromScannableSource[base2.Id](row)
+}
+
 func (dbo *DatabaseObject) GetUserLastBadActionTimeById(userId base2.Id) (lastBadActionTime *time.Time, err error) {
 	var user = u.NewUser()
 	var uParams = user.GetUserParameters()
@@ -541,6 +680,35 @@ func (dbo *DatabaseObject) GetUserRolesById(userId base2.Id) (roles *ur.UserRole
 	return ur.NewUserRolesFromScannableSource(row)
 }
 
+// GrantPermission grants a permission to a user. Granting of an existing
+// grant is not an error.
+func (dbo *DatabaseObject) GrantPermission(g *perm.Grant) (err error) {
+	_, err = dbo.PreparedStatement(DbPsid_GrantPermission).Exec(g.UserId, g.Permission, g.ScopeType, g.ScopeId, g.GrantorUserId)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (dbo *DatabaseObject) InsertAuditRecord(r *al.Record) (err error) {
+	var result sql.Result
+	result, err = dbo.PreparedStatement(DbPsid_InsertAuditRecord).Exec(
+		r.ActorUserId,
+		r.ActorIPAB,
+		r.Action,
+		r.TargetType,
+		r.TargetId,
+		r.ValueBefore,
+		r.ValueAfter,
+	)
+	if err != nil {
+		return err
+	}
+
+	return dbo2.CheckRowsAffected(result, 1)
+}
+
 func (dbo *DatabaseObject) InsertPreRegisteredUser(email simple.Email) (err error) {
 	var result sql.Result
 	result, err = dbo.PreparedStatement(DbPsid_InsertPreRegisteredUser).Exec(email)
@@ -551,6 +719,42 @@ func (dbo *DatabaseObject) InsertPreRegisteredUser(email simple.Email) (err erro
 	return dbo2.CheckRowsAffected(result, 1)
 }
 
+func (dbo *DatabaseObject) InsertTotpRecoveryCodes(userId base2.Id, codeHashes []string) (err error) {
+	for _, codeHash := range codeHashes {
+		var result sql.Result
+		result, err = dbo.PreparedStatement(DbPsid_InsertTotpRecoveryCode).Exec(userId, codeHash)
+		if err != nil {
+			return err
+		}
+
+		err = dbo2.CheckRowsAffected(result, 1)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (dbo *DatabaseObject) ReadAuditRecords(f *al.Filter, pageNumber base2.Count, pageSize base2.Count) (records []*al.Record, err error) {
+	query, args := dbo.dbQuery_ReadAuditRecords(f, pageNumber, pageSize)
+
+	var rows *sql.Rows
+	rows, err = dbo.DatabaseObject.DB().Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		derr := rows.Close()
+		if derr != nil {
+			err = ae.Combine(err, derr)
+		}
+	}()
+
+	return al.NewRecordArrayFromRows(rows)
+}
+
 func (dbo *DatabaseObject) RegisterPreRegUser(email simple.Email) (err error) {
 	// Part 1.
 	var result sql.Result
@@ -565,6 +769,17 @@ func (dbo *DatabaseObject) RegisterPreRegUser(email simple.Email) (err error) {
 	}
 
 	// Part 2.
+	result, err = dbo.PreparedStatement(DbPsid_GrantPermissionByEmail).Exec(perm.Permission_Read, perm.ScopeType_Global, email)
+	if err != nil {
+		return err
+	}
+
+	err = dbo2.CheckRowsAffected(result, 1)
+	if err != nil {
+		return err
+	}
+
+	// Part 3.
 	result, err = dbo.PreparedStatement(DbPsid_RegisterPreRegUserP2).Exec(email)
 	if err != nil {
 		return err
@@ -583,6 +798,17 @@ func (dbo *DatabaseObject) RejectRegistrationRequest(id base2.Id) (err error) {
 	return dbo2.CheckRowsAffected(result, 1)
 }
 
+// RevokePermission revokes a permission from a user. Revoking of a missing
+// grant is not an error.
+func (dbo *DatabaseObject) RevokePermission(g *perm.Grant) (err error) {
+	_, err = dbo.PreparedStatement(DbPsid_RevokePermission).Exec(g.UserId, g.Permission, g.ScopeType, g.ScopeId)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (dbo *DatabaseObject) SaveIncident(module derived1.IModule, incidentType derived1.IIncidentType, email simple.Email, userIPAB net.IP) (err error) {
 	var result sql.Result
 	result, err = dbo.PreparedStatement(DbPsid_SaveIncident).Exec(module, incidentType, email, userIPAB)
@@ -652,6 +878,36 @@ func (dbo *DatabaseObject) SetPasswordChangeVFlags(userId base2.Id, requestId si
 	return dbo2.CheckRowsAffected(result, 1)
 }
 
+func (dbo *DatabaseObject) SetPasswordResetCaptchaFlag(userId base2.Id, requestId simple.RequestId, isVerifiedByCaptcha base2.Flag) (err error) {
+	var result sql.Result
+	result, err = dbo.PreparedStatement(DbPsid_SetPasswordResetCaptchaFlag).Exec(isVerifiedByCaptcha, requestId, userId)
+	if err != nil {
+		return err
+	}
+
+	return dbo2.CheckRowsAffected(result, 1)
+}
+
+func (dbo *DatabaseObject) SetPasswordResetEmailSendStatus(userId base2.Id, requestId simple.RequestId, emailSendStatus base2.Flag) (err error) {
+	var result sql.Result
+	result, err = dbo.PreparedStatement(DbPsid_SetPasswordResetEmailSendStatus).Exec(emailSendStatus, requestId, userId)
+	if err != nil {
+		return err
+	}
+
+	return dbo2.CheckRowsAffected(result, 1)
+}
+
+func (dbo *DatabaseObject) SetPasswordResetVerificationFlag(userId base2.Id, requestId simple.RequestId, isVerifiedByEmail base2.Flag) (err error) {
+	var result sql.Result
+	result, err = dbo.PreparedStatement(DbPsid_SetPasswordResetVerificationFlag).Exec(isVerifiedByEmail, requestId, userId)
+	if err != nil {
+		return err
+	}
+
+	return dbo2.CheckRowsAffected(result, 1)
+}
+
 func (dbo *DatabaseObject) SetPreRegUserData(email simple.Email, code simple.VerificationCode, name simple.Name, password []byte) (err error) {
 	var result sql.Result
 	result, err = dbo.PreparedStatement(DbPsid_SetPreRegUserData).Exec(name, password, email, code)
@@ -732,9 +988,9 @@ func (dbo *DatabaseObject) SetUserPassword(userId base2.Id, email simple.Email,
 	return dbo2.CheckRowsAffected(result, 1)
 }
 
-func (dbo *DatabaseObject) SetUserRoleAuthor(userId base2.Id, isRoleEnabled base2.Flag) (err error) {
+func (dbo *DatabaseObject) SetUserRoleCanLogIn(userId base2.Id, isRoleEnabled base2.Flag) (err error) {
 	var result sql.Result
-	result, err = dbo.PreparedStatement(DbPsid_SetUserRoleAuthor).Exec(isRoleEnabled, userId)
+	result, err = dbo.PreparedStatement(DbPsid_SetUserRoleCanLogIn).Exec(isRoleEnabled, userId)
 	if err != nil {
 		return err
 	}
@@ -742,9 +998,9 @@ func (dbo *DatabaseObject) SetUserRoleAuthor(userId base2.Id, isRoleEnabled base
 	return dbo2.CheckRowsAffected(result, 1)
 }
 
-func (dbo *DatabaseObject) SetUserRoleCanLogIn(userId base2.Id, isRoleEnabled base2.Flag) (err error) {
+func (dbo *DatabaseObject) UpdatePasswordResetRequestId(userId base2.Id, requestIdOld simple.RequestId, requestIdNew simple.RequestId) (err error) {
 	var result sql.Result
-	result, err = dbo.PreparedStatement(DbPsid_SetUserRoleCanLogIn).Exec(isRoleEnabled, userId)
+	result, err = dbo.PreparedStatement(DbPsid_UpdatePasswordResetRequestId).Exec(requestIdNew, requestIdOld, userId)
 	if err != nil {
 		return err
 	}
@@ -752,9 +1008,9 @@ func (dbo *DatabaseObject) SetUserRoleCanLogIn(userId base2.Id, isRoleEnabled ba
 	return dbo2.CheckRowsAffected(result, 1)
 }
 
-func (dbo *DatabaseObject) SetUserRoleReader(userId base2.Id, isRoleEnabled base2.Flag) (err error) {
+func (dbo *DatabaseObject) UpdatePreSessionRequestId(userId base2.Id, requestIdOld simple.RequestId, requestIdNew simple.RequestId) (err error) {
 	var result sql.Result
-	result, err = dbo.PreparedStatement(DbPsid_SetUserRoleReader).Exec(isRoleEnabled, userId)
+	result, err = dbo.PreparedStatement(DbPsid_UpdatePreSessionRequestId).Exec(requestIdNew, requestIdOld, userId)
 	if err != nil {
 		return err
 	}
@@ -762,24 +1018,23 @@ func (dbo *DatabaseObject) SetUserRoleReader(userId base2.Id, isRoleEnabled base
 	return dbo2.CheckRowsAffected(result, 1)
 }
 
-func (dbo *DatabaseObject) SetUserRoleWriter(userId base2.Id, isRoleEnabled base2.Flag) (err error) {
+// UpdateTotpLastUsedStep saves the time period of an accepted password. If a
+// newer period has already been saved, nothing is changed and false is
+// returned, which means that the password is used repeatedly.
+func (dbo *DatabaseObject) UpdateTotpLastUsedStep(userId base2.Id, step int64) (ok bool, err error) {
 	var result sql.Result
-	result, err = dbo.PreparedStatement(DbPsid_SetUserRoleWriter).Exec(isRoleEnabled, userId)
+	result, err = dbo.PreparedStatement(DbPsid_UpdateTotpLastUsedStep).Exec(step, userId, step)
 	if err != nil {
-		return err
+		return false, err
 	}
 
-	return dbo2.CheckRowsAffected(result, 1)
-}
-
-func (dbo *DatabaseObject) UpdatePreSessionRequestId(userId base2.Id, requestIdOld simple.RequestId, requestIdNew simple.RequestId) (err error) {
-	var result sql.Result
-	result, err = dbo.PreparedStatement(DbPsid_UpdatePreSessionRequestId).Exec(requestIdNew, requestIdOld, userId)
+	var ra int64
+	ra, err = result.RowsAffected()
 	if err != nil {
-		return err
+		return false, err
 	}
 
-	return dbo2.CheckRowsAffected(result, 1)
+	return ra == 1, nil
 }
 
 func (dbo *DatabaseObject) UpdateUserBanTime(userId base2.Id) (err error) {
@@ -812,6 +1067,24 @@ func (dbo *DatabaseObject) UpdateUserLastBadLogInTimeByEmail(email simple.Email)
 	return dbo2.CheckRowsAffected(result, 1)
 }
 
+// UseTotpRecoveryCode deletes the recovery code, so that it can be used only
+// once. False is returned when the code is not found.
+func (dbo *DatabaseObject) UseTotpRecoveryCode(userId base2.Id, codeHash string) (ok bool, err error) {
+	var result sql.Result
+	result, err = dbo.PreparedStatement(DbPsid_UseTotpRecoveryCode).Exec(userId, codeHash)
+	if err != nil {
+		return false, err
+	}
+
+	var ra int64
+	ra, err = result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return ra == 1, nil
+}
+
 func (dbo *DatabaseObject) ViewUserParametersById(userId base2.Id) (userParameters base22.IUserParameters, err error) {
 	row := dbo.PreparedStatement(DbPsid_GetUserParametersById).QueryRow(userId)
 	return up.NewUserParametersFromScannableSource(row)
@@ -48,9 +48,9 @@ const (
 	DbPsid_CountSessionsByUserId                  = 37
 	DbPsid_GetUserRolesById                       = 38
 	DbPsid_GetUserParametersById                  = 39
-	DbPsid_SetUserRoleAuthor                      = 40
-	DbPsid_SetUserRoleWriter                      = 41
-	DbPsid_SetUserRoleReader                      = 42
+	DbPsid_GrantPermission                        = 40
+	DbPsid_RevokePermission                       = 41
+	DbPsid_GetPermissionsByUserId                 = 42
 	DbPsid_SetUserRoleCanLogIn                    = 43
 	DbPsid_DeleteSessionByUserId                  = 44
 	DbPsid_UpdateUserBanTime                      = 45
@@ -84,6 +84,30 @@ const (
 	DbPsid_GetListOfLoggedUsersOnPage             = 73
 	DbPsid_CountLoggedUsers                       = 74
 	DbPsid_GetListOfAllUsers                      = 75
+	DbPsid_CreateTotpSecret                       = 76
+	DbPsid_GetTotpSecret                          = 77
+	DbPsid_EnableTotp                             = 78
+	DbPsid_UpdateTotpLastUsedStep                 = 79
+	DbPsid_DeleteTotpSecret                       = 80
+	DbPsid_InsertTotpRecoveryCode                 = 81
+	DbPsid_UseTotpRecoveryCode                    = 82
+	DbPsid_DeleteTotpRecoveryCodes                = 83
+	DbPsid_CountTotpRecoveryCodes                 = 84
+	DbPsid_ClearPasswordResetsTable               = 85
+	DbPsid_CountPasswordResetsByUserId            = 86
+	DbPsid_CreatePasswordResetRequest             = 87
+	DbPsid_GetPasswordResetByRequestId            = 88
+	DbPsid_DeletePasswordResetByRequestId         = 89
+	DbPsid_SetPasswordResetCaptchaFlag            = 90
+	DbPsid_UpdatePasswordResetRequestId           = 91
+	DbPsid_AttachVerificationCodeToPwdReset       = 92
+	DbPsid_SetPasswordResetEmailSendStatus        = 93
+	DbPsid_CheckVerificationCodeForPwdReset       = 94
+	DbPsid_SetPasswordResetVerificationFlag       = 95
+	DbPsid_GrantPermissionByEmail                 = 96
+	DbPsid_GetUserIdByName                        = 97
+	DbPsid_InsertAuditRecord                      = 98
+	DbPsid_ClearAuditLog                          = 99
 )
 
 func (dbo *DatabaseObject) makePreparedStatementQueryStrings() (qs []string) {
@@ -123,7 +147,7 @@ func (dbo *DatabaseObject) makePreparedStatementQueryStrings() (qs []string) {
 	qs = append(qs, q)
 
 	// 8.
-	q = fmt.Sprintf(`INSERT INTO %s (PreRegTime, Email, NAME, PASSWORD, ApprovalTime, RegTime, IsReader, CanLogIn) SELECT PreRegTime, Email, NAME, PASSWORD, ApprovalTime, Now(), TRUE, TRUE FROM %s AS pru WHERE pru.Email = ? AND pru.IsApproved IS TRUE;`, dbo.tableNames.Users, dbo.tableNames.PreRegisteredUsers)
+	q = fmt.Sprintf(`INSERT INTO %s (PreRegTime, Email, NAME, PASSWORD, ApprovalTime, RegTime, CanLogIn) SELECT PreRegTime, Email, NAME, PASSWORD, ApprovalTime, Now(), TRUE FROM %s AS pru WHERE pru.Email = ? AND pru.IsApproved IS TRUE;`, dbo.tableNames.Users, dbo.tableNames.PreRegisteredUsers)
 	qs = append(qs, q)
 
 	// 9.
@@ -215,7 +239,7 @@ func (dbo *DatabaseObject) makePreparedStatementQueryStrings() (qs []string) {
 	qs = append(qs, q)
 
 	// 31.
-	q = fmt.Sprintf(`SELECT Id, PreRegTime, Email, Name, ApprovalTime, RegTime, IsAuthor, IsWriter, IsReader, CanLogIn, LastBadLogInTime, BanTime, LastBadActionTime FROM %s WHERE Id = ?;`, dbo.tableNames.Users)
+	q = fmt.Sprintf(`SELECT Id, PreRegTime, Email, Name, ApprovalTime, RegTime, CanLogIn, LastBadLogInTime, BanTime, LastBadActionTime FROM %s WHERE Id = ?;`, dbo.tableNames.Users)
 	qs = append(qs, q)
 
 	// 32.
@@ -243,23 +267,23 @@ func (dbo *DatabaseObject) makePreparedStatementQueryStrings() (qs []string) {
 	qs = append(qs, q)
 
 	// 38.
-	q = fmt.Sprintf(`SELECT IsAuthor, IsWriter, IsReader, CanLogIn FROM %s WHERE Id = ?;`, dbo.tableNames.Users)
+	q = fmt.Sprintf(`SELECT CanLogIn FROM %s WHERE Id = ?;`, dbo.tableNames.Users)
 	qs = append(qs, q)
 
 	// 39.
-	q = fmt.Sprintf(`SELECT Id, PreRegTime, Email, Name, ApprovalTime, RegTime, IsAuthor, IsWriter, IsReader, CanLogIn, LastBadLogInTime, BanTime, LastBadActionTime FROM %s WHERE Id = ?;`, dbo.tableNames.Users)
+	q = fmt.Sprintf(`SELECT Id, PreRegTime, Email, Name, ApprovalTime, RegTime, CanLogIn, LastBadLogInTime, BanTime, LastBadActionTime FROM %s WHERE Id = ?;`, dbo.tableNames.Users)
 	qs = append(qs, q)
 
 	// 40.
-	q = fmt.Sprintf(`UPDATE %s SET IsAuthor = ? WHERE Id = ?;`, dbo.tableNames.Users)
+	q = fmt.Sprintf(`INSERT IGNORE INTO %s (UserId, Permission, ScopeType, ScopeId, GrantorUserId) VALUES (?, ?, ?, ?, ?);`, dbo.tableNames.Permissions)
 	qs = append(qs, q)
 
 	// 41.
-	q = fmt.Sprintf(`UPDATE %s SET IsWriter = ? WHERE Id = ?;`, dbo.tableNames.Users)
+	q = fmt.Sprintf(`DELETE FROM %s WHERE UserId = ? AND Permission = ? AND ScopeType = ? AND ScopeId = ?;`, dbo.tableNames.Permissions)
 	qs = append(qs, q)
 
 	// 42.
-	q = fmt.Sprintf(`UPDATE %s SET IsReader = ? WHERE Id = ?;`, dbo.tableNames.Users)
+	q = fmt.Sprintf(`SELECT Id, UserId, Permission, ScopeType, ScopeId, GrantorUserId, TimeOfCreation FROM %s WHERE UserId = ? ORDER BY Id;`, dbo.tableNames.Permissions)
 	qs = append(qs, q)
 
 	// 43.
@@ -394,6 +418,102 @@ func (dbo *DatabaseObject) makePreparedStatementQueryStrings() (qs []string) {
 	q = fmt.Sprintf(`SELECT Id FROM %s ORDER BY Id;`, dbo.tableNames.Users)
 	qs = append(qs, q)
 
+	// 76.
+	q = fmt.Sprintf(`INSERT INTO %s (UserId, Secret) VALUES (?, ?) ON DUPLICATE KEY UPDATE Secret = VALUES(Secret), IsEnabled = FALSE, TimeOfCreation = NOW(), LastUsedStep = 0;`, dbo.tableNames.TotpSecrets)
+	qs = append(qs, q)
+
+	// 77.
+	q = fmt.Sprintf(`SELECT UserId, Secret, IsEnabled, TimeOfCreation, LastUsedStep FROM %s WHERE UserId = ?;`, dbo.tableNames.TotpSecrets)
+	qs = append(qs, q)
+
+	// 78.
+	q = fmt.Sprintf(`UPDATE %s SET IsEnabled = TRUE, LastUsedStep = ? WHERE UserId = ? AND IsEnabled = FALSE;`, dbo.tableNames.TotpSecrets)
+	qs = append(qs, q)
+
+	// 79.
+	q = fmt.Sprintf(`UPDATE %s SET LastUsedStep = ? WHERE UserId = ? AND LastUsedStep < ?;`, dbo.tableNames.TotpSecrets)
+	qs = append(qs, q)
+
+	// 80.
+	q = fmt.Sprintf(`DELETE FROM %s WHERE UserId = ?;`, dbo.tableNames.TotpSecrets)
+	qs = append(qs, q)
+
+	// 81.
+	q = fmt.Sprintf(`INSERT INTO %s (UserId, CodeHash) VALUES (?, ?);`, dbo.tableNames.TotpRecoveryCodes)
+	qs = append(qs, q)
+
+	// 82.
+	q = fmt.Sprintf(`DELETE FROM %s WHERE UserId = ? AND CodeHash = ?;`, dbo.tableNames.TotpRecoveryCodes)
+	qs = append(qs, q)
+
+	// 83.
+	q = fmt.Sprintf(`DELETE FROM %s WHERE UserId = ?;`, dbo.tableNames.TotpRecoveryCodes)
+	qs = append(qs, q)
+
+	// 84.
+	q = fmt.Sprintf(`SELECT COUNT(Id) FROM %s WHERE UserId = ?;`, dbo.tableNames.TotpRecoveryCodes)
+	qs = append(qs, q)
+
+	// 85.
+	q = fmt.Sprintf(`DELETE FROM %s WHERE TimeOfCreation < ?;`, dbo.tableNames.PasswordResets)
+	qs = append(qs, q)
+
+	// 86.
+	q = fmt.Sprintf(`SELECT COUNT(Id) FROM %s WHERE UserId = ?;`, dbo.tableNames.PasswordResets)
+	qs = append(qs, q)
+
+	// 87.
+	q = fmt.Sprintf(`INSERT INTO %s (UserId, RequestId, UserIPAB, CaptchaId) VALUES (?, ?, ?, ?);`, dbo.tableNames.PasswordResets)
+	qs = append(qs, q)
+
+	// 88.
+	q = fmt.Sprintf(`SELECT Id, UserId, TimeOfCreation, RequestId, UserIPAB, CaptchaId, IsVerifiedByCaptcha, VerificationCode, IsEmailSent, IsVerifiedByEmail FROM %s WHERE RequestId = ?;`, dbo.tableNames.PasswordResets)
+	qs = append(qs, q)
+
+	// 89.
+	q = fmt.Sprintf(`DELETE FROM %s WHERE RequestId = ?;`, dbo.tableNames.PasswordResets)
+	qs = append(qs, q)
+
+	// 90.
+	q = fmt.Sprintf(`UPDATE %s SET IsVerifiedByCaptcha = ? WHERE RequestId = ? AND UserId = ?;`, dbo.tableNames.PasswordResets)
+	qs = append(qs, q)
+
+	// 91.
+	q = fmt.Sprintf(`UPDATE %s SET RequestId = ? WHERE RequestId = ? AND UserId = ?;`, dbo.tableNames.PasswordResets)
+	qs = append(qs, q)
+
+	// 92.
+	q = fmt.Sprintf(`UPDATE %s SET VerificationCode = ? WHERE RequestId = ? AND UserId = ?;`, dbo.tableNames.PasswordResets)
+	qs = append(qs, q)
+
+	// 93.
+	q = fmt.Sprintf(`UPDATE %s SET IsEmailSent = ? WHERE RequestId = ? AND UserId = ?;`, dbo.tableNames.PasswordResets)
+	qs = append(qs, q)
+
+	// 94.
+	q = fmt.Sprintf(`SELECT COUNT(Id) FROM %s WHERE RequestId = ? AND VerificationCode = ? AND IsEmailSent IS TRUE AND IsVerifiedByCaptcha IS TRUE AND IsVerifiedByEmail IS FALSE;`, dbo.tableNames.PasswordResets)
+	qs = append(qs, q)
+
+	// 95.
+	q = fmt.Sprintf(`UPDATE %s SET IsVerifiedByEmail = ? WHERE RequestId = ? AND UserId = ? AND IsEmailSent IS TRUE;`, dbo.tableNames.PasswordResets)
+	qs = append(qs, q)
+
+	// 96.
+	q = fmt.Sprintf(`INSERT INTO %s (UserId, Permission, ScopeType, ScopeId) SELECT Id, ?, ?, 0 FROM %s WHERE Email = ?;`, dbo.tableNames.Permissions, dbo.tableNames.Users)
+	qs = append(qs, q)
+
+	// 97.
+	q = fmt.Sprintf(`SELECT Id FROM %s WHERE Name = ?;`, dbo.tableNames.Users)
+	qs = append(qs, q)
+
+	// 98.
+	q = fmt.Sprintf(`INSERT INTO %s (ActorUserId, ActorIPAB, Action, TargetType, TargetId, ValueBefore, ValueAfter) VALUES (?, ?, ?, ?, ?, ?, ?);`, dbo.tableNames.AuditLog)
+	qs = append(qs, q)
+
+	// 99.
+	q = fmt.Sprintf(`DELETE FROM %s WHERE Time < ?;`, dbo.tableNames.AuditLog)
+	qs = append(qs, q)
+
 	return qs
 }
 
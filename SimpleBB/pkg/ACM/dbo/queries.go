@@ -0,0 +1,62 @@
+package dbo
+
+import (
+	"github.com/vault-thirteen/SimpleBB/pkg/common/models/AuditLog"
+	base2 "github.com/vault-thirteen/SimpleBB/pkg/common/models/base"
+)
+
+// dbQuery_AuditLogCondition composes the 'WHERE' part of a query reading the
+// audit log.
+func dbQuery_AuditLogCondition(f *al.Filter) (condition string, args []any) {
+	args = make([]any, 0, 6)
+	condition = `TRUE`
+
+	if f.ActorUserId != nil {
+		condition += ` AND ActorUserId = ?`
+		args = append(args, *f.ActorUserId)
+	}
+
+	if f.Action != nil {
+		condition += ` AND Action = ?`
+		args = append(args, *f.Action)
+	}
+
+	if f.TargetType != nil {
+		condition += ` AND TargetType = ?`
+		args = append(args, *f.TargetType)
+	}
+
+	if f.TargetId != nil {
+		condition += ` AND TargetId = ?`
+		args = append(args, *f.TargetId)
+	}
+
+	if f.FromTime != nil {
+		condition += ` AND Time >= ?`
+		args = append(args, *f.FromTime)
+	}
+
+	if f.ToTime != nil {
+		condition += ` AND Time <= ?`
+		args = append(args, *f.ToTime)
+	}
+
+	return condition, args
+}
+
+// dbQuery_ReadAuditRecords composes a query which reads records of the audit
+// log on a page. Newer records go first.
+func (dbo *DatabaseObject) dbQuery_ReadAuditRecords(f *al.Filter, pageNumber base2.Count, pageSize base2.Count) (query string, args []any) {
+	var condition string
+	condition, args = dbQuery_AuditLogCondition(f)
+	args = append(args, pageSize, pageSize*(pageNumber-1))
+
+	return `SELECT Id, Time, ActorUserId, ActorIPAB, Action, TargetType, TargetId, ValueBefore, ValueAfter FROM ` + dbo.tableNames.AuditLog + ` WHERE ` + condition + ` ORDER BY Id DESC LIMIT ? OFFSET ?;`, args
+}
+
+func (dbo *DatabaseObject) dbQuery_CountAuditRecords(f *al.Filter) (query string, args []any) {
+	var condition string
+	condition, args = dbQuery_AuditLogCondition(f)
+
+	return `SELECT COUNT(Id) FROM ` + dbo.tableNames.AuditLog + ` WHERE ` + condition + `;`, args
+}
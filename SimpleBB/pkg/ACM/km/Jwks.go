@@ -0,0 +1,79 @@
+package km
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"errors"
+	"math/big"
+)
+
+// JSON web keys are described in RFC 7517, their parameters for different
+// types of keys are described in RFC 7518 and RFC 8037.
+
+const (
+	JwkKeyType_RSA = "RSA"
+	JwkKeyType_EC  = "EC"
+	JwkKeyType_OKP = "OKP"
+	JwkCurve_P256  = "P-256"
+	JwkCurve_Ed    = "Ed25519"
+	JwkUse_Sig     = "sig"
+
+	// Size of a coordinate of the P-256 curve in bytes.
+	P256CoordinateSize = 32
+)
+
+// Jwk is a public key in the JSON web key format.
+type Jwk struct {
+	KeyType string `json:"kty"`
+	KeyId   string `json:"kid"`
+	Use     string `json:"use"`
+	Alg     string `json:"alg"`
+
+	// RSA key.
+	N string `json:"n,omitempty"`
+	E string `json:"e,omitempty"`
+
+	// Elliptic curve key.
+	Curve string `json:"crv,omitempty"`
+	X     string `json:"x,omitempty"`
+	Y     string `json:"y,omitempty"`
+}
+
+// Jwks is a set of JSON web keys.
+type Jwks struct {
+	Keys []*Jwk `json:"keys"`
+}
+
+func NewJwk(key *Key) (jwk *Jwk, err error) {
+	jwk = &Jwk{
+		KeyId: key.Id,
+		Use:   JwkUse_Sig,
+		Alg:   key.Alg,
+	}
+
+	switch pk := key.publicKey.(type) {
+	case *rsa.PublicKey:
+		jwk.KeyType = JwkKeyType_RSA
+		jwk.N = encodeBase64Url(pk.N.Bytes())
+		jwk.E = encodeBase64Url(big.NewInt(int64(pk.E)).Bytes())
+	case *ecdsa.PublicKey:
+		jwk.KeyType = JwkKeyType_EC
+		jwk.Curve = JwkCurve_P256
+		jwk.X = encodeBase64Url(pk.X.FillBytes(make([]byte, P256CoordinateSize)))
+		jwk.Y = encodeBase64Url(pk.Y.FillBytes(make([]byte, P256CoordinateSize)))
+	case ed25519.PublicKey:
+		jwk.KeyType = JwkKeyType_OKP
+		jwk.Curve = JwkCurve_Ed
+		jwk.X = encodeBase64Url(pk)
+	default:
+		return nil, errors.New(ErrTypeCast)
+	}
+
+	return jwk, nil
+}
+
+func encodeBase64Url(data []byte) string {
+	return base64.RawURLEncoding.EncodeToString(data)
+}
@@ -0,0 +1,232 @@
+package km
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"github.com/vault-thirteen/SimpleBB/pkg/common/models/simple"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	// RsaKeySize is the size of generated RSA keys in bits. Keys created
+	// manually are larger, but generation of such keys takes too long.
+	RsaKeySize = 4096
+
+	// Key ID is made of the time of creation and the signing method, e.g.
+	// '1700000000_RS512'. Files of a key are named using its ID.
+	KeyIdSeparator       = "_"
+	PrivateKeyFileSuffix = "_private.pem"
+	PublicKeyFileSuffix  = "_public.pem"
+
+	PemBlockType_PrivateKey = "PRIVATE KEY"
+	PemBlockType_PublicKey  = "PUBLIC KEY"
+
+	PrivateKeyFilePermissions = 0600
+	PublicKeyFilePermissions  = 0644
+)
+
+const (
+	ErrFKeyIdIsNotValid = "key ID is not valid: %v"
+)
+
+// Key is a key of the key ring.
+type Key struct {
+	Id             string
+	Alg            string
+	TimeOfCreation time.Time
+
+	method jwt.SigningMethod
+
+	// Private key is not set for keys used only for verification.
+	privateKey crypto.Signer
+	publicKey  crypto.PublicKey
+}
+
+func getSigningMethod(signingMethodName string) jwt.SigningMethod {
+	switch signingMethodName {
+	case TokenAlg_PS512:
+		return jwt.SigningMethodPS512
+	case TokenAlg_RS512:
+		return jwt.SigningMethodRS512
+	case TokenAlg_ES256:
+		return jwt.SigningMethodES256
+	case TokenAlg_EdDSA:
+		return jwt.SigningMethodEdDSA
+	default:
+		return nil
+	}
+}
+
+func makeKeyId(signingMethodName string, timeOfCreation time.Time) string {
+	return strconv.FormatInt(timeOfCreation.Unix(), 10) + KeyIdSeparator + signingMethodName
+}
+
+func parseKeyId(keyId string) (signingMethodName string, timeOfCreation time.Time, err error) {
+	parts := strings.Split(keyId, KeyIdSeparator)
+	if len(parts) != 2 {
+		return "", time.Time{}, fmt.Errorf(ErrFKeyIdIsNotValid, keyId)
+	}
+
+	var unixTime int64
+	unixTime, err = strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf(ErrFKeyIdIsNotValid, keyId)
+	}
+
+	if !IsSigningMethodSupported(parts[1]) {
+		return "", time.Time{}, fmt.Errorf(ErrFKeyIdIsNotValid, keyId)
+	}
+
+	return parts[1], time.Unix(unixTime, 0), nil
+}
+
+func generateKey(signingMethodName string, timeOfCreation time.Time) (key *Key, err error) {
+	key = &Key{
+		Id:             makeKeyId(signingMethodName, timeOfCreation),
+		Alg:            signingMethodName,
+		TimeOfCreation: time.Unix(timeOfCreation.Unix(), 0),
+		method:         getSigningMethod(signingMethodName),
+	}
+
+	switch signingMethodName {
+	case TokenAlg_PS512, TokenAlg_RS512:
+		key.privateKey, err = rsa.GenerateKey(rand.Reader, RsaKeySize)
+	case TokenAlg_ES256:
+		key.privateKey, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	case TokenAlg_EdDSA:
+		_, key.privateKey, err = ed25519.GenerateKey(rand.Reader)
+	default:
+		return nil, errors.New(ErrSigningMethodIsNotSupported)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	key.publicKey = key.privateKey.Public()
+
+	return key, nil
+}
+
+// loadKeys reads all the keys stored in the folder. A key must have a public
+// key file, while a private key file is optional.
+func loadKeys(folder simple.Path) (keys []*Key, err error) {
+	err = os.MkdirAll(folder.ToString(), 0755)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []os.DirEntry
+	entries, err = os.ReadDir(folder.ToString())
+	if err != nil {
+		return nil, err
+	}
+
+	keys = make([]*Key, 0)
+	var key *Key
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), PublicKeyFileSuffix) {
+			continue
+		}
+
+		key, err = loadKey(folder, strings.TrimSuffix(entry.Name(), PublicKeyFileSuffix))
+		if err != nil {
+			return nil, err
+		}
+
+		keys = append(keys, key)
+	}
+
+	return keys, nil
+}
+
+func loadKey(folder simple.Path, keyId string) (key *Key, err error) {
+	key = &Key{Id: keyId}
+
+	key.Alg, key.TimeOfCreation, err = parseKeyId(keyId)
+	if err != nil {
+		return nil, err
+	}
+
+	key.method = getSigningMethod(key.Alg)
+
+	var data []byte
+	data, err = os.ReadFile(filepath.Join(folder.ToString(), keyId+PublicKeyFileSuffix))
+	if err != nil {
+		return nil, err
+	}
+
+	key.publicKey, err = parsePublicKey(data, key.Alg)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err = os.ReadFile(filepath.Join(folder.ToString(), keyId+PrivateKeyFileSuffix))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return key, nil
+		}
+
+		return nil, err
+	}
+
+	key.privateKey, err = parsePrivateKey(data, key.Alg)
+	if err != nil {
+		return nil, err
+	}
+
+	return key, nil
+}
+
+func saveKey(folder simple.Path, key *Key) (err error) {
+	var der []byte
+	der, err = x509.MarshalPKCS8PrivateKey(key.privateKey)
+	if err != nil {
+		return err
+	}
+
+	privateKeyData := pem.EncodeToMemory(&pem.Block{Type: PemBlockType_PrivateKey, Bytes: der})
+
+	der, err = x509.MarshalPKIXPublicKey(key.publicKey)
+	if err != nil {
+		return err
+	}
+
+	publicKeyData := pem.EncodeToMemory(&pem.Block{Type: PemBlockType_PublicKey, Bytes: der})
+
+	// Private key is written first, because keys without a public key file
+	// are ignored.
+	err = os.WriteFile(filepath.Join(folder.ToString(), key.Id+PrivateKeyFileSuffix), privateKeyData, PrivateKeyFilePermissions)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(folder.ToString(), key.Id+PublicKeyFileSuffix), publicKeyData, PublicKeyFilePermissions)
+}
+
+func deleteKey(folder simple.Path, key *Key) (err error) {
+	err = os.Remove(filepath.Join(folder.ToString(), key.Id+PublicKeyFileSuffix))
+	if err != nil {
+		return err
+	}
+
+	err = os.Remove(filepath.Join(folder.ToString(), key.Id+PrivateKeyFileSuffix))
+	if (err != nil) && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	return nil
+}
@@ -1,12 +1,13 @@
 package km
 
 import (
-	"crypto/rsa"
 	"errors"
 	"fmt"
 	cmb "github.com/vault-thirteen/SimpleBB/pkg/common/models/base"
 	"github.com/vault-thirteen/SimpleBB/pkg/common/models/simple"
-	"strings"
+	"sort"
+	"sync"
+	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 )
@@ -15,8 +16,11 @@ const (
 	WebTokenField_UserId    = "userId"
 	WebTokenField_SessionId = "sessionId"
 	TokenHeader_Alg         = "alg"
+	TokenHeader_Kid         = "kid"
 	TokenAlg_PS512          = "PS512" // RSA-PSS.
 	TokenAlg_RS512          = "RS512" // RSA.
+	TokenAlg_ES256          = "ES256" // ECDSA using P-256 curve.
+	TokenAlg_EdDSA          = "EdDSA" // Ed25519.
 )
 
 const (
@@ -26,37 +30,72 @@ const (
 	ErrFUnsupportedSigningMethod   = "unsupported signing method: %s"
 	ErrFUnexpectedSigningMethod    = "unexpected signing method: %v"
 	ErrTypeCast                    = "type cast error"
+	ErrKeyRingFolderIsNotSet       = "key ring folder is not set"
+	ErrFKeyIsNotFound              = "key is not found: %v"
+	ErrSigningKeyIsNotSet          = "signing key is not set"
 )
 
+// KeyMaker is a key ring for JSON web tokens.
+//
+// Tokens are signed by the active key, which is the newest key of the
+// configured signing method. Each token has an ID of its key in the 'kid'
+// header, so that tokens signed by older keys remain valid after rotation.
+// An old key is forgotten when all the sessions which might have been started
+// with it are expired. Tokens without a key ID were made before the key ring
+// was introduced, they are verified with the legacy key, if it is set.
 type KeyMaker struct {
-	privateKey        *rsa.PrivateKey
-	publicKey         *rsa.PublicKey
-	signingMethod     jwt.SigningMethod
+	guard             sync.RWMutex
+	folder            simple.Path
 	signingMethodName string
+	rotationPeriod    time.Duration
+	retentionPeriod   time.Duration
+
+	// Keys are sorted by the time of creation, the newest key is the last.
+	keys      []*Key
+	activeKey *Key
+	legacyKey *Key
 }
 
-func New(signingMethodName string, privateKeyFilePath simple.Path, publicKeyFilePath simple.Path) (km *KeyMaker, err error) {
-	var signingMethod jwt.SigningMethod
-	switch signingMethodName {
-	case TokenAlg_PS512:
-		signingMethod = jwt.SigningMethodPS512
-	case TokenAlg_RS512:
-		signingMethod = jwt.SigningMethodRS512
-	default:
+// New creates a key ring stored in the folder. Keys are rotated when they
+// become older than the rotation period. Zero rotation period disables
+// rotation. Retired keys are kept for verification during the retention
+// period, which should be equal to the maximal duration of a session. Legacy
+// public key is optional.
+func New(
+	signingMethodName string,
+	folder simple.Path,
+	rotationPeriod time.Duration,
+	retentionPeriod time.Duration,
+	legacyPublicKeyFilePath simple.Path,
+) (km *KeyMaker, err error) {
+	if !IsSigningMethodSupported(signingMethodName) {
 		return nil, errors.New(ErrSigningMethodIsNotSupported)
 	}
 
+	if len(folder) == 0 {
+		return nil, errors.New(ErrKeyRingFolderIsNotSet)
+	}
+
 	km = &KeyMaker{
-		signingMethod:     signingMethod,
-		signingMethodName: strings.ToUpper(signingMethodName),
+		folder:            folder,
+		signingMethodName: signingMethodName,
+		rotationPeriod:    rotationPeriod,
+		retentionPeriod:   retentionPeriod,
 	}
 
-	km.privateKey, err = getPrivateKey(privateKeyFilePath, signingMethodName)
+	if len(legacyPublicKeyFilePath) > 0 {
+		km.legacyKey, err = getLegacyKey(legacyPublicKeyFilePath)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	km.keys, err = loadKeys(folder)
 	if err != nil {
 		return nil, err
 	}
 
-	km.publicKey, err = getPublicKey(publicKeyFilePath, signingMethodName)
+	_, err = km.Rotate(time.Now())
 	if err != nil {
 		return nil, err
 	}
@@ -64,16 +103,144 @@ func New(signingMethodName string, privateKeyFilePath simple.Path, publicKeyFile
 	return km, nil
 }
 
+func IsSigningMethodSupported(signingMethodName string) bool {
+	switch signingMethodName {
+	case TokenAlg_PS512,
+		TokenAlg_RS512,
+		TokenAlg_ES256,
+		TokenAlg_EdDSA:
+		return true
+
+	default:
+		return false
+	}
+}
+
+// Rotate creates a new active key when it is needed and forgets keys which
+// are not needed any more. This method must be called periodically.
+func (km *KeyMaker) Rotate(now time.Time) (isRotated bool, err error) {
+	km.guard.Lock()
+	defer km.guard.Unlock()
+
+	km.activeKey = km.findActiveKey()
+
+	if (km.activeKey == nil) ||
+		((km.rotationPeriod > 0) && (now.Sub(km.activeKey.TimeOfCreation) >= km.rotationPeriod)) {
+		var key *Key
+		key, err = generateKey(km.signingMethodName, now)
+		if err != nil {
+			return false, err
+		}
+
+		err = saveKey(km.folder, key)
+		if err != nil {
+			return false, err
+		}
+
+		km.keys = append(km.keys, key)
+		km.activeKey = key
+		isRotated = true
+	}
+
+	err = km.forgetRetiredKeys(now)
+	if err != nil {
+		return isRotated, err
+	}
+
+	return isRotated, nil
+}
+
+// findActiveKey returns the newest key of the configured signing method
+// having a private key.
+func (km *KeyMaker) findActiveKey() (key *Key) {
+	for i := len(km.keys) - 1; i >= 0; i-- {
+		if (km.keys[i].Alg == km.signingMethodName) && (km.keys[i].privateKey != nil) {
+			return km.keys[i]
+		}
+	}
+
+	return nil
+}
+
+// forgetRetiredKeys deletes keys which were replaced by newer keys longer
+// than the retention period ago.
+func (km *KeyMaker) forgetRetiredKeys(now time.Time) (err error) {
+	sort.Slice(km.keys, func(i, j int) bool {
+		return km.keys[i].TimeOfCreation.Before(km.keys[j].TimeOfCreation)
+	})
+
+	keys := make([]*Key, 0, len(km.keys))
+	for i, key := range km.keys {
+		if (key == km.activeKey) || (i == len(km.keys)-1) {
+			keys = append(keys, key)
+			continue
+		}
+
+		timeOfRetirement := km.keys[i+1].TimeOfCreation
+		if now.Sub(timeOfRetirement) < km.retentionPeriod {
+			keys = append(keys, key)
+			continue
+		}
+
+		err = deleteKey(km.folder, key)
+		if err != nil {
+			return err
+		}
+	}
+
+	km.keys = keys
+	return nil
+}
+
+func (km *KeyMaker) findKey(keyId string) (key *Key) {
+	for _, k := range km.keys {
+		if k.Id == keyId {
+			return k
+		}
+	}
+
+	return nil
+}
+
+// GetJwks returns public keys of the key ring. The legacy key is not
+// published, because it has no ID.
+func (km *KeyMaker) GetJwks() (jwks *Jwks, err error) {
+	km.guard.RLock()
+	defer km.guard.RUnlock()
+
+	jwks = &Jwks{Keys: make([]*Jwk, 0, len(km.keys))}
+
+	var jwk *Jwk
+	for _, key := range km.keys {
+		jwk, err = NewJwk(key)
+		if err != nil {
+			return nil, err
+		}
+
+		jwks.Keys = append(jwks.Keys, jwk)
+	}
+
+	return jwks, nil
+}
+
 func (km *KeyMaker) MakeJWToken(userId cmb.Id, sessionId cmb.Id) (tokenString simple.WebTokenString, err error) {
+	km.guard.RLock()
+	defer km.guard.RUnlock()
+
+	if km.activeKey == nil {
+		return "", errors.New(ErrSigningKeyIsNotSet)
+	}
+
 	claims := jwt.MapClaims{
 		WebTokenField_UserId:    userId,
 		WebTokenField_SessionId: sessionId,
 	}
 
-	token := jwt.NewWithClaims(km.signingMethod, claims, nil)
+	token := jwt.NewWithClaims(km.activeKey.method, claims, nil)
+	token.Header[TokenHeader_Kid] = km.activeKey.Id
 
 	var s string
-	s, err = token.SignedString(km.privateKey)
+	s, err = token.SignedString(km.activeKey.privateKey)
 	if err != nil {
 		return "", err
 	}
@@ -83,30 +250,7 @@ func (km *KeyMaker) MakeJWToken(userId cmb.Id, sessionId cmb.Id) (tokenString si
 
 func (km *KeyMaker) ValidateToken(tokenString simple.WebTokenString) (userId cmb.Id, sessionId cmb.Id, err error) {
 	var token *jwt.Token
-	token, err = jwt.Parse(tokenString.ToString(), func(token *jwt.Token) (interface{}, error) {
-		if strings.ToUpper(token.Method.Alg()) != km.signingMethodName {
-			return nil, fmt.Errorf(ErrFUnsupportedSigningMethod, token.Method.Alg())
-		}
-
-		tmp := token.Header[TokenHeader_Alg]
-		algString := tmp.(string)
-		if strings.ToUpper(algString) != km.signingMethodName {
-			return nil, fmt.Errorf(ErrFUnsupportedSigningMethod, token.Header[TokenHeader_Alg])
-		}
-
-		var ok bool
-		switch km.signingMethodName {
-		case TokenAlg_PS512:
-			_, ok = token.Method.(*jwt.SigningMethodRSAPSS)
-		case TokenAlg_RS512:
-			_, ok = token.Method.(*jwt.SigningMethodRSA)
-		}
-		if !ok {
-			return nil, fmt.Errorf(ErrFUnexpectedSigningMethod, token.Header[TokenHeader_Alg])
-		}
-
-		return km.publicKey, nil
-	})
+	token, err = jwt.Parse(tokenString.ToString(), km.getVerificationKey)
 	if err != nil {
 		return 0, 0, err
 	}
@@ -146,3 +290,35 @@ func (km *KeyMaker) ValidateToken(tokenString simple.WebTokenString) (userId cmb
 
 	return cmb.Id(userIdFloat64), cmb.Id(sessionIdFloat64), nil
 }
+
+// getVerificationKey selects a key by the 'kid' header of the token.
+func (km *KeyMaker) getVerificationKey(token *jwt.Token) (any, error) {
+	km.guard.RLock()
+	defer km.guard.RUnlock()
+
+	var key *Key
+	kidIfc, ok := token.Header[TokenHeader_Kid]
+	if !ok {
+		key = km.legacyKey
+	} else {
+		var kid string
+		kid, ok = kidIfc.(string)
+		if !ok {
+			return nil, errors.New(ErrTokenIsBroken)
+		}
+
+		key = km.findKey(kid)
+	}
+
+	if key == nil {
+		return nil, fmt.Errorf(ErrFKeyIsNotFound, kidIfc)
+	}
+
+	// Method of the token is taken from its 'alg' header. Each signing method
+	// is registered only once, so the algorithm name identifies the method.
+	if token.Method.Alg() != key.Alg {
+		return nil, fmt.Errorf(ErrFUnexpectedSigningMethod, token.Header[TokenHeader_Alg])
+	}
+
+	return key.publicKey, nil
+}
@@ -0,0 +1,134 @@
+package km
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	cmb "github.com/vault-thirteen/SimpleBB/pkg/common/models/base"
+	"github.com/vault-thirteen/SimpleBB/pkg/common/models/simple"
+	"github.com/vault-thirteen/auxie/tester"
+)
+
+func Test_KeyMaker_Rotate(t *testing.T) {
+	aTest := tester.New(t)
+	folder := simple.Path(t.TempDir())
+
+	km, err := New(TokenAlg_EdDSA, folder, time.Hour, 2*time.Hour, "")
+	aTest.MustBeNoError(err)
+	aTest.MustBeEqual(len(km.keys), 1)
+
+	oldToken, err := km.MakeJWToken(1, 2)
+	aTest.MustBeNoError(err)
+
+	// The active key is still young.
+	t0 := km.activeKey.TimeOfCreation
+	isRotated, err := km.Rotate(t0.Add(30 * time.Minute))
+	aTest.MustBeNoError(err)
+	aTest.MustBeEqual(isRotated, false)
+
+	// Old tokens remain valid after rotation.
+	isRotated, err = km.Rotate(t0.Add(time.Hour))
+	aTest.MustBeNoError(err)
+	aTest.MustBeEqual(isRotated, true)
+	aTest.MustBeEqual(len(km.keys), 2)
+
+	userId, sessionId, err := km.ValidateToken(oldToken)
+	aTest.MustBeNoError(err)
+	aTest.MustBeEqual(userId, cmb.Id(1))
+	aTest.MustBeEqual(sessionId, cmb.Id(2))
+
+	newToken, err := km.MakeJWToken(3, 4)
+	aTest.MustBeNoError(err)
+	aTest.MustBeEqual(getKid(t, newToken), km.activeKey.Id)
+
+	// Keys are restored from files.
+	km2, err := New(TokenAlg_EdDSA, folder, time.Hour, 2*time.Hour, "")
+	aTest.MustBeNoError(err)
+	aTest.MustBeEqual(len(km2.keys), 2)
+	aTest.MustBeEqual(km2.activeKey.Id, km.activeKey.Id)
+	_, _, err = km2.ValidateToken(oldToken)
+	aTest.MustBeNoError(err)
+
+	// The first key is forgotten after the retention period, while the
+	// second key has just been retired.
+	isRotated, err = km.Rotate(t0.Add(3*time.Hour + time.Minute))
+	aTest.MustBeNoError(err)
+	aTest.MustBeEqual(isRotated, true)
+	aTest.MustBeEqual(len(km.keys), 2)
+	_, _, err = km.ValidateToken(oldToken)
+	aTest.MustBeAnError(err)
+	_, _, err = km.ValidateToken(newToken)
+	aTest.MustBeNoError(err)
+}
+
+func Test_KeyMaker_SigningMethods(t *testing.T) {
+	aTest := tester.New(t)
+	folder := simple.Path(t.TempDir())
+
+	var tokens = map[string]simple.WebTokenString{}
+	for _, alg := range []string{TokenAlg_ES256, TokenAlg_EdDSA, TokenAlg_RS512} {
+		km, err := New(alg, folder, 0, time.Hour, "")
+		aTest.MustBeNoError(err)
+		aTest.MustBeEqual(km.activeKey.Alg, alg)
+
+		tokens[alg], err = km.MakeJWToken(1, 2)
+		aTest.MustBeNoError(err)
+	}
+
+	// A change of the signing method is a rotation too.
+	km, err := New(TokenAlg_RS512, folder, 0, time.Hour, "")
+	aTest.MustBeNoError(err)
+	aTest.MustBeEqual(len(km.keys), 3)
+	for _, token := range tokens {
+		_, _, err = km.ValidateToken(token)
+		aTest.MustBeNoError(err)
+	}
+
+	jwks, err := km.GetJwks()
+	aTest.MustBeNoError(err)
+	aTest.MustBeEqual(len(jwks.Keys), 3)
+	var keyTypes = map[string]string{}
+	for _, jwk := range jwks.Keys {
+		keyTypes[jwk.Alg] = jwk.KeyType
+	}
+	aTest.MustBeEqual(keyTypes, map[string]string{
+		TokenAlg_ES256: JwkKeyType_EC,
+		TokenAlg_EdDSA: JwkKeyType_OKP,
+		TokenAlg_RS512: JwkKeyType_RSA,
+	})
+
+	_, err = New("HS256", folder, 0, time.Hour, "")
+	aTest.MustBeAnError(err)
+}
+
+func Test_KeyMaker_ValidateToken(t *testing.T) {
+	aTest := tester.New(t)
+
+	km, err := New(TokenAlg_EdDSA, simple.Path(t.TempDir()), 0, time.Hour, "")
+	aTest.MustBeNoError(err)
+
+	// Token of an unknown key.
+	other, err := New(TokenAlg_EdDSA, simple.Path(t.TempDir()), 0, time.Hour, "")
+	aTest.MustBeNoError(err)
+	token, err := other.MakeJWToken(1, 2)
+	aTest.MustBeNoError(err)
+	_, _, err = km.ValidateToken(token)
+	aTest.MustBeAnError(err)
+
+	// Token without a key ID, while the legacy key is not set.
+	legacyToken := jwt.NewWithClaims(jwt.SigningMethodEdDSA, jwt.MapClaims{WebTokenField_UserId: 1, WebTokenField_SessionId: 2})
+	s, err := legacyToken.SignedString(km.activeKey.privateKey)
+	aTest.MustBeNoError(err)
+	_, _, err = km.ValidateToken(simple.WebTokenString(s))
+	aTest.MustBeAnError(err)
+}
+
+func getKid(t *testing.T, tokenString simple.WebTokenString) string {
+	token, _, err := jwt.NewParser().ParseUnverified(tokenString.ToString(), jwt.MapClaims{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return token.Header[TokenHeader_Kid].(string)
+}
@@ -1,6 +1,10 @@
 package km
 
 import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
 	"crypto/rsa"
 	"crypto/x509"
 	"encoding/pem"
@@ -19,75 +23,105 @@ import (
 //if err != nil {
 //	return nil, err
 //}
+//
+// Keys of the key ring are ordinary RSA keys, which are used with the PSS
+// padding when the PS512 method is selected, so this bug does not affect
+// them.
 
 const (
 	ErrRsaPssGoLanguageWhatAShame = "this stupid Go language does not support parsing RSA-PSS keys, what a shame"
+	ErrPemBlockIsNotFound         = "PEM block is not found"
+	ErrKeyDoesNotFitMethod        = "key does not fit signing method"
 )
 
-// This function is bad, but Golang is ever worse.
-func getPrivateKey(privateKeyFilePath cm.Path, signingMethodName string) (pk *rsa.PrivateKey, err error) {
+// getLegacyKey reads the public key which was used before the key ring was
+// introduced. Only RSA keys could be used then, because of the bug described
+// above.
+func getLegacyKey(publicKeyFilePath cm.Path) (key *Key, err error) {
 	var keyFileData []byte
-	keyFileData, err = os.ReadFile(privateKeyFilePath.ToString())
+	keyFileData, err = os.ReadFile(publicKeyFilePath.ToString())
 	if err != nil {
 		return nil, err
 	}
 
-	block, _ := pem.Decode(keyFileData)
+	key = &Key{
+		Alg:    TokenAlg_RS512,
+		method: getSigningMethod(TokenAlg_RS512),
+	}
+
+	key.publicKey, err = parsePublicKey(keyFileData, TokenAlg_RS512)
+	if err != nil {
+		return nil, err
+	}
+
+	return key, nil
+}
+
+func parsePrivateKey(data []byte, signingMethodName string) (pk crypto.Signer, err error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New(ErrPemBlockIsNotFound)
+	}
+
 	var anyKey any
+	anyKey, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
 	var ok bool
+	pk, ok = anyKey.(crypto.Signer)
+	if !ok {
+		return nil, errors.New(ErrTypeCast)
+	}
 
-	switch signingMethodName {
-	case TokenAlg_PS512:
-		return nil, errors.New(ErrRsaPssGoLanguageWhatAShame)
-	case TokenAlg_RS512:
-		{
-			anyKey, err = x509.ParsePKCS8PrivateKey(block.Bytes)
-			if err != nil {
-				return nil, err
-			}
-
-			pk, ok = anyKey.(*rsa.PrivateKey)
-			if !ok {
-				return nil, errors.New(ErrTypeCast)
-			}
-
-			return pk, nil
-		}
-	default:
-		return nil, errors.New(ErrSigningMethodIsNotSupported)
+	err = checkKeyType(pk.Public(), signingMethodName)
+	if err != nil {
+		return nil, err
 	}
+
+	return pk, nil
 }
 
-// This function is bad, but Golang is ever worse.
-func getPublicKey(publicKeyFilePath cm.Path, signingMethodName string) (pk *rsa.PublicKey, err error) {
-	var keyFileData []byte
-	keyFileData, err = os.ReadFile(publicKeyFilePath.ToString())
+func parsePublicKey(data []byte, signingMethodName string) (pk crypto.PublicKey, err error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New(ErrPemBlockIsNotFound)
+	}
+
+	pk, err = x509.ParsePKIXPublicKey(block.Bytes)
 	if err != nil {
 		return nil, err
 	}
 
-	block, _ := pem.Decode(keyFileData)
-	var anyKey any
-	var ok bool
+	err = checkKeyType(pk, signingMethodName)
+	if err != nil {
+		return nil, err
+	}
+
+	return pk, nil
+}
 
+// checkKeyType ensures that a key of a wrong type is not used with the
+// signing method.
+func checkKeyType(pk crypto.PublicKey, signingMethodName string) (err error) {
+	var ok bool
 	switch signingMethodName {
-	case TokenAlg_PS512:
-		return nil, errors.New(ErrRsaPssGoLanguageWhatAShame)
-	case TokenAlg_RS512:
-		{
-			anyKey, err = x509.ParsePKIXPublicKey(block.Bytes)
-			if err != nil {
-				return nil, err
-			}
-
-			pk, ok = anyKey.(*rsa.PublicKey)
-			if !ok {
-				return nil, errors.New(ErrTypeCast)
-			}
-
-			return pk, nil
-		}
+	case TokenAlg_PS512, TokenAlg_RS512:
+		_, ok = pk.(*rsa.PublicKey)
+	case TokenAlg_ES256:
+		var ecKey *ecdsa.PublicKey
+		ecKey, ok = pk.(*ecdsa.PublicKey)
+		ok = ok && (ecKey.Curve == elliptic.P256())
+	case TokenAlg_EdDSA:
+		_, ok = pk.(ed25519.PublicKey)
 	default:
-		return nil, errors.New(ErrSigningMethodIsNotSupported)
+		return errors.New(ErrSigningMethodIsNotSupported)
+	}
+
+	if !ok {
+		return errors.New(ErrKeyDoesNotFitMethod)
 	}
+
+	return nil
 }
@@ -0,0 +1,60 @@
+package models
+
+import (
+	"database/sql"
+	"errors"
+	cmi "github.com/vault-thirteen/SimpleBB/pkg/common/interfaces/base1"
+	base2 "github.com/vault-thirteen/SimpleBB/pkg/common/models/base"
+	"github.com/vault-thirteen/SimpleBB/pkg/common/models/simple"
+	"net"
+	"time"
+)
+
+// PasswordReset is a request to set a new password of a user who has
+// forgotten the old one. Captcha is always required here, because a user is
+// not authorised.
+type PasswordReset struct {
+	Id             base2.Id
+	UserId         base2.Id
+	TimeOfCreation time.Time
+	RequestId      *simple.RequestId
+
+	// IP address of a user. B = Byte array.
+	UserIPAB net.IP
+
+	CaptchaId           *simple.CaptchaId
+	IsVerifiedByCaptcha base2.Flag
+	VerificationCode    *simple.VerificationCode
+	IsEmailSent         base2.Flag
+	IsVerifiedByEmail   base2.Flag
+}
+
+func NewPasswordReset() (pr *PasswordReset) {
+	return &PasswordReset{}
+}
+
+func NewPasswordResetFromScannableSource(src cmi.IScannable) (pr *PasswordReset, err error) {
+	pr = NewPasswordReset()
+
+	err = src.Scan(
+		&pr.Id,
+		&pr.UserId,
+		&pr.TimeOfCreation,
+		&pr.RequestId,
+		&pr.UserIPAB,
+		&pr.CaptchaId,
+		&pr.IsVerifiedByCaptcha,
+		&pr.VerificationCode,
+		&pr.IsEmailSent,
+		&pr.IsVerifiedByEmail,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		} else {
+			return nil, err
+		}
+	}
+
+	return pr, nil
+}
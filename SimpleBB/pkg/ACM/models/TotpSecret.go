@@ -0,0 +1,48 @@
+package models
+
+import (
+	"database/sql"
+	"errors"
+	cmi "github.com/vault-thirteen/SimpleBB/pkg/common/interfaces/base1"
+	base2 "github.com/vault-thirteen/SimpleBB/pkg/common/models/base"
+	"time"
+)
+
+// TotpSecret is a shared secret of a user for time-based one-time passwords.
+// The secret is not enabled until the user confirms the enrolment with a
+// valid password.
+type TotpSecret struct {
+	UserId         base2.Id
+	Secret         []byte
+	IsEnabled      base2.Flag
+	TimeOfCreation time.Time
+
+	// Number of the last time period whose password was accepted. It is used
+	// to prevent a repeated use of the same password.
+	LastUsedStep int64
+}
+
+func NewTotpSecret() (ts *TotpSecret) {
+	return &TotpSecret{}
+}
+
+func NewTotpSecretFromScannableSource(src cmi.IScannable) (ts *TotpSecret, err error) {
+	ts = NewTotpSecret()
+
+	err = src.Scan(
+		&ts.UserId,
+		&ts.Secret,
+		&ts.IsEnabled,
+		&ts.TimeOfCreation,
+		&ts.LastUsedStep,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		} else {
+			return nil, err
+		}
+	}
+
+	return ts, nil
+}
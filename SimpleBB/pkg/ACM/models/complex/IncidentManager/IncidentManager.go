@@ -73,6 +73,7 @@ func initBlockTimePerIncidentType(blockTimePerIncident *s.BlockTimePerIncident)
 	blockTimePerIncidentType[it.IncidentType_PasswordChangeHacking] = blockTimePerIncident.PasswordChangeHacking
 	blockTimePerIncidentType[it.IncidentType_EmailChangeHacking] = blockTimePerIncident.EmailChangeHacking
 	blockTimePerIncidentType[it.IncidentType_FakeIPA] = blockTimePerIncident.FakeIPA
+	blockTimePerIncidentType[it.IncidentType_PasswordResetHacking] = blockTimePerIncident.PasswordResetHacking
 
 	return blockTimePerIncidentType
 }
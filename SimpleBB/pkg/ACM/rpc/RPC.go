@@ -1,8 +1,12 @@
 package rpc
 
 import (
+	"github.com/vault-thirteen/SimpleBB/pkg/ACM/km"
 	"github.com/vault-thirteen/SimpleBB/pkg/ACM/models"
 	"github.com/vault-thirteen/SimpleBB/pkg/common/interfaces/derived1"
+	"github.com/vault-thirteen/SimpleBB/pkg/common/models/AuditLog"
+	ck "github.com/vault-thirteen/SimpleBB/pkg/common/models/CaptchaKind"
+	"github.com/vault-thirteen/SimpleBB/pkg/common/models/Permission"
 	base2 "github.com/vault-thirteen/SimpleBB/pkg/common/models/base"
 	rpc2 "github.com/vault-thirteen/SimpleBB/pkg/common/models/rpc"
 	"github.com/vault-thirteen/SimpleBB/pkg/common/models/simple"
@@ -13,6 +17,22 @@ import (
 type PingParams = rpc2.PingParams
 type PingResult = rpc2.PingResult
 
+// Captcha.
+
+// CaptchaChallenge is a captcha task shown to a user. An image is requested
+// separately by the captcha ID, tasks of other kinds are returned as is.
+type CaptchaChallenge struct {
+	Kind ck.CaptchaKind `json:"kind"`
+
+	// Text question.
+	Question string `json:"question,omitempty"`
+
+	// Proof of work. The answer is a nonce such that the SHA-256 hash of the
+	// challenge followed by the nonce starts with 'difficulty' zero bits.
+	Challenge  string `json:"challenge,omitempty"`
+	Difficulty uint   `json:"difficulty,omitempty"`
+}
+
 // User registration.
 
 type RegisterUserParams struct {
@@ -82,6 +102,12 @@ type LogUserInParams struct {
 	// Is used on steps 2 and 3.
 	RequestId simple.RequestId `json:"requestId"`
 
+	// Kind of captcha.
+	// This field is optional and may be used on step 1. When it is not set,
+	// an image is used. Other kinds are an accessible fallback and must be
+	// allowed by settings.
+	CaptchaKind ck.CaptchaKind `json:"captchaKind"`
+
 	// Captcha answer.
 	// This field is optional and may be used on step 2.
 	CaptchaAnswer simple.CaptchaAnswer `json:"captchaAnswer"`
@@ -93,6 +119,15 @@ type LogUserInParams struct {
 	// Verification Code.
 	// Is used on step 3.
 	VerificationCode simple.VerificationCode `json:"verificationCode"`
+
+	// Time-based one-time password from an authenticator application.
+	// Is used on step 3 when the user has enabled two-factor authentication.
+	TotpCode simple.TotpCode `json:"totpCode"`
+
+	// Recovery code.
+	// This field is optional and may be used on step 3 instead of a one-time
+	// password when the authenticator application is lost.
+	RecoveryCode simple.RecoveryCode `json:"recoveryCode"`
 }
 type LogUserInResult struct {
 	rpc2.CommonResult
@@ -106,6 +141,11 @@ type LogUserInResult struct {
 	// Captcha parameters.
 	IsCaptchaNeeded base2.Flag        `json:"isCaptchaNeeded"`
 	CaptchaId       *simple.CaptchaId `json:"captchaId"`
+	Captcha         *CaptchaChallenge `json:"captcha,omitempty"`
+
+	// Codes required on step 3.
+	IsEmailCodeNeeded base2.Flag `json:"isEmailCodeNeeded"`
+	IsTotpNeeded      base2.Flag `json:"isTotpNeeded"`
 
 	// JWT key maker.
 	IsWebTokenSet  base2.Flag            `json:"isWebTokenSet"`
@@ -169,6 +209,55 @@ type IsUserLoggedInResult struct {
 	IsUserLoggedIn base2.Flag `json:"isUserLoggedIn"`
 }
 
+// Password reset.
+
+type ResetPasswordParams struct {
+	rpc2.CommonParams
+
+	// Step number.
+	StepN simple.StepNumber `json:"stepN"`
+
+	// E-mail address.
+	// It is used on all steps.
+	Email simple.Email `json:"email"`
+
+	// Request ID.
+	// It protects password resets from being hi-jacked.
+	// Is used on steps 2 and 3.
+	RequestId simple.RequestId `json:"requestId"`
+
+	// Kind of captcha.
+	// This field is optional and may be used on step 1. When it is not set,
+	// an image is used. Other kinds are an accessible fallback and must be
+	// allowed by settings.
+	CaptchaKind ck.CaptchaKind `json:"captchaKind"`
+
+	// Captcha answer.
+	// Is used on step 2.
+	CaptchaAnswer simple.CaptchaAnswer `json:"captchaAnswer"`
+
+	// Verification Code.
+	// Is used on step 3.
+	VerificationCode simple.VerificationCode `json:"verificationCode"`
+
+	// New password.
+	// Is used on step 3.
+	NewPassword simple.Password `json:"newPassword"`
+}
+type ResetPasswordResult struct {
+	rpc2.CommonResult
+	rpc2.Success
+
+	// Next required step. If set to zero, no further step is required.
+	NextStep simple.StepNumber `json:"nextStep"`
+
+	RequestId simple.RequestId `json:"requestId"`
+
+	// Captcha is always required.
+	CaptchaId simple.CaptchaId  `json:"captchaId"`
+	Captcha   *CaptchaChallenge `json:"captcha,omitempty"`
+}
+
 // Various actions.
 
 type ChangePasswordParams struct {
@@ -194,6 +283,12 @@ type ChangePasswordParams struct {
 	// Is used on step 2.
 	VerificationCode simple.VerificationCode `json:"verificationCode"`
 
+	// Kind of captcha.
+	// This field is optional and may be used on step 1. When it is not set,
+	// an image is used. Other kinds are an accessible fallback and must be
+	// allowed by settings.
+	CaptchaKind ck.CaptchaKind `json:"captchaKind"`
+
 	// Captcha answer.
 	// This field is optional and may be used on step 2.
 	CaptchaAnswer simple.CaptchaAnswer `json:"captchaAnswer"`
@@ -209,8 +304,9 @@ type ChangePasswordResult struct {
 	AuthDataBytes rpc2.AuthChallengeData `json:"authDataBytes"`
 
 	// Captcha parameters.
-	IsCaptchaNeeded base2.Flag       `json:"isCaptchaNeeded"`
-	CaptchaId       simple.CaptchaId `json:"captchaId"`
+	IsCaptchaNeeded base2.Flag        `json:"isCaptchaNeeded"`
+	CaptchaId       simple.CaptchaId  `json:"captchaId"`
+	Captcha         *CaptchaChallenge `json:"captcha,omitempty"`
 }
 
 type ChangeEmailParams struct {
@@ -240,6 +336,12 @@ type ChangeEmailParams struct {
 	// Is used on step 2.
 	VerificationCodeNew simple.VerificationCode `json:"verificationCodeNew"`
 
+	// Kind of captcha.
+	// This field is optional and may be used on step 1. When it is not set,
+	// an image is used. Other kinds are an accessible fallback and must be
+	// allowed by settings.
+	CaptchaKind ck.CaptchaKind `json:"captchaKind"`
+
 	// Captcha answer.
 	// This field is optional and may be used on step 2.
 	CaptchaAnswer simple.CaptchaAnswer `json:"captchaAnswer"`
@@ -255,8 +357,9 @@ type ChangeEmailResult struct {
 	AuthDataBytes rpc2.AuthChallengeData `json:"authDataBytes"`
 
 	// Captcha parameters.
-	IsCaptchaNeeded base2.Flag       `json:"isCaptchaNeeded"`
-	CaptchaId       simple.CaptchaId `json:"captchaId"`
+	IsCaptchaNeeded base2.Flag        `json:"isCaptchaNeeded"`
+	CaptchaId       simple.CaptchaId  `json:"captchaId"`
+	Captcha         *CaptchaChallenge `json:"captcha,omitempty"`
 }
 
 type GetUserSessionParams struct {
@@ -280,6 +383,29 @@ type GetUserNameResult struct {
 	User derived1.IUser `json:"user"`
 }
 
+type GetUserIdsByNamesParams struct {
+	rpc2.CommonParams
+	UserNames []simple.Name `json:"userNames"`
+}
+type GetUserIdsByNamesResult struct {
+	rpc2.CommonResult
+
+	// Identifiers of users by their names. Names of non-existent users are
+	// skipped.
+	UserIds map[simple.Name]base2.Id `json:"userIds"`
+}
+
+type GetUserEmailSParams struct {
+	rpc2.CommonParams
+	rpc2.DKeyParams
+	UserId base2.Id `json:"userId"`
+}
+type GetUserEmailSResult struct {
+	rpc2.CommonResult
+	UserId base2.Id     `json:"userId"`
+	Email  simple.Email `json:"email"`
+}
+
 type GetUserRolesParams struct {
 	rpc2.CommonParams
 	UserId base2.Id `json:"userId"`
@@ -315,6 +441,24 @@ type GetSelfRolesResult struct {
 	User derived1.IUser `json:"user"`
 }
 
+// User permissions.
+
+type GrantPermissionParams = PermissionCommonParams
+type GrantPermissionResult = PermissionCommonResult
+
+type RevokePermissionParams = PermissionCommonParams
+type RevokePermissionResult = PermissionCommonResult
+
+type GetUserPermissionsParams struct {
+	rpc2.CommonParams
+	UserId base2.Id `json:"userId"`
+}
+type GetUserPermissionsResult struct {
+	rpc2.CommonResult
+	UserId base2.Id      `json:"userId"`
+	Grants []*perm.Grant `json:"grants"`
+}
+
 // User banning.
 
 type BanUserParams struct {
@@ -329,12 +473,117 @@ type UnbanUserParams struct {
 }
 type UnbanUserResult = rpc2.CommonResultWithSuccess
 
+// Two-factor authentication.
+
+type StartTotpEnrolmentParams struct {
+	rpc2.CommonParams
+}
+type StartTotpEnrolmentResult struct {
+	rpc2.CommonResult
+
+	// Shared secret encoded with Base32 for manual input.
+	Secret base2.Text `json:"secret"`
+
+	// URI to be shown as a QR code.
+	ProvisioningUri base2.Text `json:"provisioningUri"`
+}
+
+type ConfirmTotpEnrolmentParams struct {
+	rpc2.CommonParams
+	TotpCode simple.TotpCode `json:"totpCode"`
+}
+type ConfirmTotpEnrolmentResult struct {
+	rpc2.CommonResult
+
+	// Recovery codes are shown only once.
+	RecoveryCodes []simple.RecoveryCode `json:"recoveryCodes"`
+}
+
+type DisableSelfTotpParams struct {
+	rpc2.CommonParams
+
+	// Either a one-time password or a recovery code is required.
+	TotpCode     simple.TotpCode     `json:"totpCode"`
+	RecoveryCode simple.RecoveryCode `json:"recoveryCode"`
+}
+type DisableSelfTotpResult = rpc2.CommonResultWithSuccess
+
+type GetSelfTotpStatusParams struct {
+	rpc2.CommonParams
+}
+type GetSelfTotpStatusResult struct {
+	rpc2.CommonResult
+	IsTotpEnabled      base2.Flag  `json:"isTotpEnabled"`
+	RecoveryCodesCount base2.Count `json:"recoveryCodesCount"`
+}
+
+type RegenerateTotpRecoveryCodesParams struct {
+	rpc2.CommonParams
+	TotpCode simple.TotpCode `json:"totpCode"`
+}
+type RegenerateTotpRecoveryCodesResult struct {
+	rpc2.CommonResult
+	RecoveryCodes []simple.RecoveryCode `json:"recoveryCodes"`
+}
+
+type ResetUserTotpParams struct {
+	rpc2.CommonParams
+	UserId base2.Id `json:"userId"`
+}
+type ResetUserTotpResult = rpc2.CommonResultWithSuccess
+
+// Audit log.
+
+type AddAuditRecordSParams struct {
+	rpc2.CommonParams
+	rpc2.DKeyParams
+
+	// Actor is the user who performed the action in another module.
+	ActorUserId base2.Id    `json:"actorUserId"`
+	ActorIPA    simple.IPAS `json:"actorIPA"`
+
+	Action      al.Action     `json:"action"`
+	TargetType  al.TargetType `json:"targetType"`
+	TargetId    base2.Id      `json:"targetId"`
+	ValueBefore *base2.Text   `json:"valueBefore"`
+	ValueAfter  *base2.Text   `json:"valueAfter"`
+}
+type AddAuditRecordSResult = rpc2.CommonResultWithSuccess
+
+type GetAuditLogParams struct {
+	rpc2.CommonParams
+	Filter al.Filter   `json:"filter"`
+	Page   base2.Count `json:"page"`
+}
+type GetAuditLogResult struct {
+	rpc2.CommonResult
+	Records  []*al.Record   `json:"records"`
+	PageData *rpc2.PageData `json:"pageData,omitempty"`
+}
+
+// Web token keys.
+
+type GetJwksParams struct{}
+type GetJwksResult struct {
+	rpc2.CommonResult
+	Keys []*km.Jwk `json:"keys"`
+}
+
 // Other.
 
+type GetDKeyParams struct {
+	rpc2.CommonParams
+}
+type GetDKeyResult struct {
+	rpc2.CommonResult
+	DKey base2.Text `json:"dKey"`
+}
+
 type ShowDiagnosticDataParams struct{}
 type ShowDiagnosticDataResult struct {
 	rpc2.CommonResult
 	rpc2.RequestsCount
+	rpc2.ScheduledTasks
 }
 
 type TestParams struct{}
@@ -350,3 +599,12 @@ type SetUserRoleCommonParams struct {
 	IsRoleEnabled base2.Flag `json:"isRoleEnabled"`
 }
 type SetUserRoleCommonResult = rpc2.CommonResultWithSuccess
+
+type PermissionCommonParams struct {
+	rpc2.CommonParams
+	UserId     base2.Id        `json:"userId"`
+	Permission perm.Permission `json:"permission"`
+	ScopeType  perm.ScopeType  `json:"scopeType"`
+	ScopeId    base2.Id        `json:"scopeId"`
+}
+type PermissionCommonResult = rpc2.CommonResultWithSuccess
@@ -41,18 +41,34 @@ func (srv *Server) initRpc() (err error) {
 		srv.GetListOfAllUsers,
 		srv.GetListOfAllUsersOnPage,
 		srv.IsUserLoggedIn,
+		srv.ResetPassword,
 		srv.ChangePassword,
 		srv.ChangeEmail,
 		srv.GetUserSession,
 		srv.GetUserName,
+		srv.GetUserIdsByNames,
+		srv.GetUserEmailS,
 		srv.GetUserRoles,
 		srv.ViewUserParameters,
 		srv.SetUserRoleAuthor,
 		srv.SetUserRoleWriter,
 		srv.SetUserRoleReader,
 		srv.GetSelfRoles,
+		srv.GrantPermission,
+		srv.RevokePermission,
+		srv.GetUserPermissions,
 		srv.BanUser,
 		srv.UnbanUser,
+		srv.StartTotpEnrolment,
+		srv.ConfirmTotpEnrolment,
+		srv.DisableSelfTotp,
+		srv.GetSelfTotpStatus,
+		srv.RegenerateTotpRecoveryCodes,
+		srv.ResetUserTotp,
+		srv.AddAuditRecordS,
+		srv.GetAuditLog,
+		srv.GetJwks,
+		srv.GetDKey,
 		srv.ShowDiagnosticData,
 		srv.Test,
 	}
@@ -274,6 +290,24 @@ func (srv *Server) IsUserLoggedIn(params *json.RawMessage, _ *jrm1.ResponseMetaD
 	return r, nil
 }
 
+// Password reset.
+
+func (srv *Server) ResetPassword(params *json.RawMessage, _ *jrm1.ResponseMetaData) (result any, re *jrm1.RpcError) {
+	var p *am.ResetPasswordParams
+	re = jrm1.ParseParameters(params, &p)
+	if re != nil {
+		return nil, re
+	}
+
+	var r *am.ResetPasswordResult
+	r, re = srv.resetPassword(p)
+	if re != nil {
+		return nil, re
+	}
+
+	return r, nil
+}
+
 // Various actions.
 
 func (srv *Server) ChangePassword(params *json.RawMessage, _ *jrm1.ResponseMetaData) (result any, re *jrm1.RpcError) {
@@ -342,6 +376,38 @@ func (srv *Server) GetUserName(params *json.RawMessage, _ *jrm1.ResponseMetaData
 	return r, nil
 }
 
+func (srv *Server) GetUserIdsByNames(params *json.RawMessage, _ *jrm1.ResponseMetaData) (result any, re *jrm1.RpcError) {
+	var p *am.GetUserIdsByNamesParams
+	re = jrm1.ParseParameters(params, &p)
+	if re != nil {
+		return nil, re
+	}
+
+	var r *am.GetUserIdsByNamesResult
+	r, re = srv.getUserIdsByNames(p)
+	if re != nil {
+		return nil, re
+	}
+
+	return r, nil
+}
+
+func (srv *Server) GetUserEmailS(params *json.RawMessage, _ *jrm1.ResponseMetaData) (result any, re *jrm1.RpcError) {
+	var p *am.GetUserEmailSParams
+	re = jrm1.ParseParameters(params, &p)
+	if re != nil {
+		return nil, re
+	}
+
+	var r *am.GetUserEmailSResult
+	r, re = srv.getUserEmailS(p)
+	if re != nil {
+		return nil, re
+	}
+
+	return r, nil
+}
+
 func (srv *Server) GetUserRoles(params *json.RawMessage, _ *jrm1.ResponseMetaData) (result any, re *jrm1.RpcError) {
 	var p *am.GetUserRolesParams
 	re = jrm1.ParseParameters(params, &p)
@@ -438,6 +504,56 @@ func (srv *Server) GetSelfRoles(params *json.RawMessage, _ *jrm1.ResponseMetaDat
 	return r, nil
 }
 
+// User permissions.
+
+func (srv *Server) GrantPermission(params *json.RawMessage, _ *jrm1.ResponseMetaData) (result any, re *jrm1.RpcError) {
+	var p *am.GrantPermissionParams
+	re = jrm1.ParseParameters(params, &p)
+	if re != nil {
+		return nil, re
+	}
+
+	var r *am.GrantPermissionResult
+	r, re = srv.grantPermission(p)
+	if re != nil {
+		return nil, re
+	}
+
+	return r, nil
+}
+
+func (srv *Server) RevokePermission(params *json.RawMessage, _ *jrm1.ResponseMetaData) (result any, re *jrm1.RpcError) {
+	var p *am.RevokePermissionParams
+	re = jrm1.ParseParameters(params, &p)
+	if re != nil {
+		return nil, re
+	}
+
+	var r *am.RevokePermissionResult
+	r, re = srv.revokePermission(p)
+	if re != nil {
+		return nil, re
+	}
+
+	return r, nil
+}
+
+func (srv *Server) GetUserPermissions(params *json.RawMessage, _ *jrm1.ResponseMetaData) (result any, re *jrm1.RpcError) {
+	var p *am.GetUserPermissionsParams
+	re = jrm1.ParseParameters(params, &p)
+	if re != nil {
+		return nil, re
+	}
+
+	var r *am.GetUserPermissionsResult
+	r, re = srv.getUserPermissions(p)
+	if re != nil {
+		return nil, re
+	}
+
+	return r, nil
+}
+
 // User banning.
 
 func (srv *Server) BanUser(params *json.RawMessage, _ *jrm1.ResponseMetaData) (result any, re *jrm1.RpcError) {
@@ -472,8 +588,174 @@ func (srv *Server) UnbanUser(params *json.RawMessage, _ *jrm1.ResponseMetaData)
 	return r, nil
 }
 
+// Two-factor authentication.
+
+func (srv *Server) StartTotpEnrolment(params *json.RawMessage, _ *jrm1.ResponseMetaData) (result any, re *jrm1.RpcError) {
+	var p *am.StartTotpEnrolmentParams
+	re = jrm1.ParseParameters(params, &p)
+	if re != nil {
+		return nil, re
+	}
+
+	var r *am.StartTotpEnrolmentResult
+	r, re = srv.startTotpEnrolment(p)
+	if re != nil {
+		return nil, re
+	}
+
+	return r, nil
+}
+
+func (srv *Server) ConfirmTotpEnrolment(params *json.RawMessage, _ *jrm1.ResponseMetaData) (result any, re *jrm1.RpcError) {
+	var p *am.ConfirmTotpEnrolmentParams
+	re = jrm1.ParseParameters(params, &p)
+	if re != nil {
+		return nil, re
+	}
+
+	var r *am.ConfirmTotpEnrolmentResult
+	r, re = srv.confirmTotpEnrolment(p)
+	if re != nil {
+		return nil, re
+	}
+
+	return r, nil
+}
+
+func (srv *Server) DisableSelfTotp(params *json.RawMessage, _ *jrm1.ResponseMetaData) (result any, re *jrm1.RpcError) {
+	var p *am.DisableSelfTotpParams
+	re = jrm1.ParseParameters(params, &p)
+	if re != nil {
+		return nil, re
+	}
+
+	var r *am.DisableSelfTotpResult
+	r, re = srv.disableSelfTotp(p)
+	if re != nil {
+		return nil, re
+	}
+
+	return r, nil
+}
+
+func (srv *Server) GetSelfTotpStatus(params *json.RawMessage, _ *jrm1.ResponseMetaData) (result any, re *jrm1.RpcError) {
+	var p *am.GetSelfTotpStatusParams
+	re = jrm1.ParseParameters(params, &p)
+	if re != nil {
+		return nil, re
+	}
+
+	var r *am.GetSelfTotpStatusResult
+	r, re = srv.getSelfTotpStatus(p)
+	if re != nil {
+		return nil, re
+	}
+
+	return r, nil
+}
+
+func (srv *Server) RegenerateTotpRecoveryCodes(params *json.RawMessage, _ *jrm1.ResponseMetaData) (result any, re *jrm1.RpcError) {
+	var p *am.RegenerateTotpRecoveryCodesParams
+	re = jrm1.ParseParameters(params, &p)
+	if re != nil {
+		return nil, re
+	}
+
+	var r *am.RegenerateTotpRecoveryCodesResult
+	r, re = srv.regenerateTotpRecoveryCodes(p)
+	if re != nil {
+		return nil, re
+	}
+
+	return r, nil
+}
+
+func (srv *Server) ResetUserTotp(params *json.RawMessage, _ *jrm1.ResponseMetaData) (result any, re *jrm1.RpcError) {
+	var p *am.ResetUserTotpParams
+	re = jrm1.ParseParameters(params, &p)
+	if re != nil {
+		return nil, re
+	}
+
+	var r *am.ResetUserTotpResult
+	r, re = srv.resetUserTotp(p)
+	if re != nil {
+		return nil, re
+	}
+
+	return r, nil
+}
+
+// Audit log.
+
+func (srv *Server) AddAuditRecordS(params *json.RawMessage, _ *jrm1.ResponseMetaData) (result any, re *jrm1.RpcError) {
+	var p *am.AddAuditRecordSParams
+	re = jrm1.ParseParameters(params, &p)
+	if re != nil {
+		return nil, re
+	}
+
+	var r *am.AddAuditRecordSResult
+	r, re = srv.addAuditRecordS(p)
+	if re != nil {
+		return nil, re
+	}
+
+	return r, nil
+}
+
+func (srv *Server) GetAuditLog(params *json.RawMessage, _ *jrm1.ResponseMetaData) (result any, re *jrm1.RpcError) {
+	var p *am.GetAuditLogParams
+	re = jrm1.ParseParameters(params, &p)
+	if re != nil {
+		return nil, re
+	}
+
+	var r *am.GetAuditLogResult
+	r, re = srv.getAuditLog(p)
+	if re != nil {
+		return nil, re
+	}
+
+	return r, nil
+}
+
+// Web token keys.
+
+func (srv *Server) GetJwks(params *json.RawMessage, _ *jrm1.ResponseMetaData) (result any, re *jrm1.RpcError) {
+	var p *am.GetJwksParams
+	re = jrm1.ParseParameters(params, &p)
+	if re != nil {
+		return nil, re
+	}
+
+	var r *am.GetJwksResult
+	r, re = srv.getJwks()
+	if re != nil {
+		return nil, re
+	}
+
+	return r, nil
+}
+
 // Other.
 
+func (srv *Server) GetDKey(params *json.RawMessage, _ *jrm1.ResponseMetaData) (result any, re *jrm1.RpcError) {
+	var p *am.GetDKeyParams
+	re = jrm1.ParseParameters(params, &p)
+	if re != nil {
+		return nil, re
+	}
+
+	var r *am.GetDKeyResult
+	r, re = srv.getDKey(p)
+	if re != nil {
+		return nil, re
+	}
+
+	return r, nil
+}
+
 func (srv *Server) ShowDiagnosticData(params *json.RawMessage, _ *jrm1.ResponseMetaData) (result any, re *jrm1.RpcError) {
 	var p *am.ShowDiagnosticDataParams
 	re = jrm1.ParseParameters(params, &p)
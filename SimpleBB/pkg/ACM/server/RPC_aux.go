@@ -6,9 +6,15 @@ import (
 	"errors"
 	"fmt"
 	"github.com/vault-thirteen/SimpleBB/pkg/ACM/models"
+	rpc2 "github.com/vault-thirteen/SimpleBB/pkg/ACM/rpc"
+	"github.com/vault-thirteen/SimpleBB/pkg/ACM/totp"
 	rm "github.com/vault-thirteen/SimpleBB/pkg/RCS/rpc"
 	sm "github.com/vault-thirteen/SimpleBB/pkg/SMTP/rpc"
 	"github.com/vault-thirteen/SimpleBB/pkg/common/interfaces/derived1"
+	"github.com/vault-thirteen/SimpleBB/pkg/common/models/AuditLog"
+	ck "github.com/vault-thirteen/SimpleBB/pkg/common/models/CaptchaKind"
+	"github.com/vault-thirteen/SimpleBB/pkg/common/models/Permission"
+	"github.com/vault-thirteen/SimpleBB/pkg/common/models/UserRoles"
 	base2 "github.com/vault-thirteen/SimpleBB/pkg/common/models/base"
 	"github.com/vault-thirteen/SimpleBB/pkg/common/models/complex/EnumValue"
 	cm "github.com/vault-thirteen/SimpleBB/pkg/common/models/complex/IncidentType"
@@ -18,14 +24,15 @@ import (
 	server2 "github.com/vault-thirteen/SimpleBB/pkg/common/models/server"
 	"github.com/vault-thirteen/SimpleBB/pkg/common/models/simple"
 	"log"
+	"net"
 	"net/mail"
+	"slices"
 	"time"
 
 	bpp "github.com/vault-thirteen/BytePackedPassword"
 	jrm1 "github.com/vault-thirteen/JSON-RPC-M1"
 	rc "github.com/vault-thirteen/SimpleBB/pkg/RCS/client"
 	sc "github.com/vault-thirteen/SimpleBB/pkg/SMTP/client"
-	num "github.com/vault-thirteen/auxie/number"
 )
 
 // Auxiliary functions used in RPC functions.
@@ -34,6 +41,12 @@ const (
 	ErrAuthData = "authorisation data error"
 )
 
+const (
+	// UserNamesPerRequestMax is the maximal number of user names resolved by
+	// a single request.
+	UserNamesPerRequestMax = 100
+)
+
 // logError logs error if debug mode is enabled.
 func (srv *Server) logError(err error) {
 	if err == nil {
@@ -106,6 +119,16 @@ func (srv *Server) mustBeNoAuthToken(auth *cmr.Auth) (re *jrm1.RpcError) {
 	return nil
 }
 
+// mustBeNoAuth ensures that authorisation is not used. This check is used by
+// functions called by other modules.
+func (srv *Server) mustBeNoAuth(auth *cmr.Auth) (re *jrm1.RpcError) {
+	if auth != nil {
+		return jrm1.NewRpcErrorByUser(server2.RpcErrorCode_Permission, server2.RpcErrorMsg_Permission, nil)
+	}
+
+	return nil
+}
+
 // mustBeAnAuthToken ensures that an authorisation token is present and is
 // valid. If the token is absent or invalid, an error is returned and the caller
 // of this function must stop and return this error. User data is returned when
@@ -163,10 +186,12 @@ func (srv *Server) getUserDataByAuthToken(authToken simple.WebTokenString) (user
 		return nil, errors.New(ErrAuthData)
 	}
 
-	// Attach special user roles from settings.
 	roles := userData.GetUser().GetUserParameters().GetRoles()
-	roles.IsModerator = srv.isUserModerator(userData.GetUser().GetUserParameters().GetId())
-	roles.IsAdministrator = srv.isUserAdministrator(userData.GetUser().GetUserParameters().GetId())
+	err = srv.attachUserRoles(userId, roles)
+	if err != nil {
+		srv.processDatabaseError(err)
+		return nil, err
+	}
 
 	uParams := userData.GetUser().GetUserParameters()
 	uParams.SetRoles(roles)
@@ -191,20 +216,36 @@ func (srv *Server) getUserDataByAuthToken(authToken simple.WebTokenString) (user
 
 // Other functions.
 
-func (srv *Server) checkCaptcha(captchaId simple.CaptchaId, answer simple.CaptchaAnswer) (result *rm.CheckCaptchaResult, re *jrm1.RpcError) {
-	var params = rm.CheckCaptchaParams{TaskId: captchaId.ToString()}
-	var err error
+// attachUserRoles attaches grants of permissions and special user roles from
+// settings to user roles.
+func (srv *Server) attachUserRoles(userId base2.Id, roles *ur.UserRoles) (err error) {
+	var grants []*perm.Grant
+	grants, err = srv.dbo.GetPermissionsByUserId(userId)
+	if err != nil {
+		return err
+	}
 
+	roles.SetGrants(grants)
+	roles.IsModerator = srv.isUserModerator(userId)
+	roles.IsAdministrator = srv.isUserAdministrator(userId)
+
+	return nil
+}
+
+func (srv *Server) checkCaptcha(captchaId simple.CaptchaId, answer simple.CaptchaAnswer) (result *rm.CheckCaptchaResult, re *jrm1.RpcError) {
 	if len(answer) == 0 {
 		return nil, jrm1.NewRpcErrorByUser(server2.RpcErrorCode_Captcha, server2.RpcErrorMsg_Captcha, nil)
 	}
 
-	params.Value, err = num.ParseUint(answer.ToString())
-	if err != nil {
-		return nil, jrm1.NewRpcErrorByUser(server2.RpcErrorCode_Captcha, server2.RpcErrorMsg_Captcha, nil)
+	// The answer is passed as is, its format depends on the kind of captcha
+	// which was issued. RCS finds the kind by the task ID.
+	var params = rm.CheckCaptchaParams{
+		TaskId: captchaId.ToString(),
+		Answer: answer.ToString(),
 	}
 
 	result = new(rm.CheckCaptchaResult)
+	var err error
 	re, err = srv.rcsServiceClient.MakeRequest(context.Background(), rc.FuncCheckCaptcha, params, result)
 	if err != nil {
 		srv.logError(err)
@@ -244,8 +285,54 @@ func (srv *Server) checkPassword(userId base2.Id, salt []byte, userKey []byte) (
 	return ok, nil
 }
 
-func (srv *Server) createCaptcha() (result *rm.CreateCaptchaResult, re *jrm1.RpcError) {
-	var params = rm.CreateCaptchaParams{}
+// checkTotp checks either a one-time password or a recovery code of the user.
+// An accepted password can not be used again, an accepted recovery code is
+// deleted.
+func (srv *Server) checkTotp(ts *models.TotpSecret, totpCode simple.TotpCode, recoveryCode simple.RecoveryCode) (ok bool, re *jrm1.RpcError) {
+	var err error
+	if len(totpCode) > 0 {
+		step, isValid := totp.CheckCode(ts.Secret, totpCode.ToString(), time.Now(), ts.LastUsedStep)
+		if !isValid {
+			return false, nil
+		}
+
+		ok, err = srv.dbo.UpdateTotpLastUsedStep(ts.UserId, step)
+		if err != nil {
+			return false, srv.databaseError(err)
+		}
+
+		return ok, nil
+	}
+
+	if len(recoveryCode) > 0 {
+		ok, err = srv.dbo.UseTotpRecoveryCode(ts.UserId, totp.HashRecoveryCode(recoveryCode.ToString()))
+		if err != nil {
+			return false, srv.databaseError(err)
+		}
+
+		return ok, nil
+	}
+
+	return false, nil
+}
+
+// createCaptcha creates a captcha of the requested kind. When the kind is not
+// set, an image is created. Other kinds are issued only when they are allowed
+// as an accessible fallback by settings.
+func (srv *Server) createCaptcha(kind ck.CaptchaKind) (result *rm.CreateCaptchaResult, re *jrm1.RpcError) {
+	if kind == 0 {
+		kind = ck.CaptchaKind_Image
+	}
+
+	if !ck.IsCaptchaKindValid(kind) {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_CaptchaKindIsNotValid, RpcErrorMsg_CaptchaKindIsNotValid, nil)
+	}
+
+	if !srv.isCaptchaKindAllowed(kind) {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_CaptchaKindIsNotAllowed, RpcErrorMsg_CaptchaKindIsNotAllowed, nil)
+	}
+
+	var params = rm.CreateCaptchaParams{Kind: kind}
 
 	result = new(rm.CreateCaptchaResult)
 	var err error
@@ -265,6 +352,56 @@ func (srv *Server) createCaptcha() (result *rm.CreateCaptchaResult, re *jrm1.Rpc
 	return result, nil
 }
 
+// isCaptchaKindAllowed checks whether users may request captcha of the kind.
+// Image is always allowed.
+func (srv *Server) isCaptchaKindAllowed(kind ck.CaptchaKind) bool {
+	if kind == ck.CaptchaKind_Image {
+		return true
+	}
+
+	return slices.Contains(srv.settings.SystemSettings.AccessibleCaptchaKinds, kind)
+}
+
+// newCaptchaChallenge prepares a created captcha for a user.
+func newCaptchaChallenge(ccr *rm.CreateCaptchaResult) (cc *rpc2.CaptchaChallenge) {
+	return &rpc2.CaptchaChallenge{
+		Kind:       ccr.Kind,
+		Question:   ccr.Question,
+		Challenge:  ccr.Challenge,
+		Difficulty: ccr.Difficulty,
+	}
+}
+
+// createRecoveryCodes replaces recovery codes of the user with new ones.
+// Only hashes of the codes are stored, so the codes are shown to the user
+// only once.
+func (srv *Server) createRecoveryCodes(userId base2.Id) (codes []simple.RecoveryCode, re *jrm1.RpcError) {
+	codes = make([]simple.RecoveryCode, 0, totp.RecoveryCodesCount)
+	codeHashes := make([]string, 0, totp.RecoveryCodesCount)
+
+	for i := 0; i < totp.RecoveryCodesCount; i++ {
+		s, err := srv.rcg.CreatePassword()
+		if err != nil {
+			return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_RecoveryCodeGenerator, RpcErrorMsg_RecoveryCodeGenerator, nil)
+		}
+
+		codes = append(codes, simple.RecoveryCode(*s))
+		codeHashes = append(codeHashes, totp.HashRecoveryCode(*s))
+	}
+
+	err := srv.dbo.DeleteTotpRecoveryCodes(userId)
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+
+	err = srv.dbo.InsertTotpRecoveryCodes(userId, codeHashes)
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+
+	return codes, nil
+}
+
 func (srv *Server) createRequestIdForLogIn() (rid *simple.RequestId, re *jrm1.RpcError) {
 	s, err := srv.ridg.CreatePassword()
 	if err != nil {
@@ -283,6 +420,15 @@ func (srv *Server) createRequestIdForPasswordChange() (rid *simple.RequestId, re
 	return (*simple.RequestId)(s), nil
 }
 
+func (srv *Server) createRequestIdForPasswordReset() (rid *simple.RequestId, re *jrm1.RpcError) {
+	s, err := srv.ridg.CreatePassword()
+	if err != nil {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_RequestIdGenerator, RpcErrorMsg_RequestIdGenerator, nil)
+	}
+
+	return (*simple.RequestId)(s), nil
+}
+
 func (srv *Server) createVerificationCode() (vc *simple.VerificationCode, re *jrm1.RpcError) {
 	var err error
 	var s *string
@@ -345,6 +491,48 @@ func (srv *Server) isCaptchaNeededForPasswordChange(userId base2.Id) (isCaptchaN
 	return false, nil
 }
 
+// deleteTotp disables two-factor authentication of the user.
+func (srv *Server) deleteTotp(userId base2.Id) (re *jrm1.RpcError) {
+	err := srv.dbo.DeleteTotpRecoveryCodes(userId)
+	if err != nil {
+		return srv.databaseError(err)
+	}
+
+	err = srv.dbo.DeleteTotpSecret(userId)
+	if err != nil {
+		return srv.databaseError(err)
+	}
+
+	return nil
+}
+
+// getEnabledTotpSecret returns the TOTP secret of the user when the user has
+// two-factor authentication enabled, otherwise it returns null.
+func (srv *Server) getEnabledTotpSecret(userId base2.Id) (ts *models.TotpSecret, re *jrm1.RpcError) {
+	var err error
+	ts, err = srv.dbo.GetTotpSecret(userId)
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+
+	if (ts == nil) || (!ts.IsEnabled) {
+		return nil, nil
+	}
+
+	return ts, nil
+}
+
+// isEmailCodeNeededForLogIn tells whether a verification code must be sent by
+// e-mail to a user logging in. The ts parameter is the enabled TOTP secret of
+// the user or null.
+func (srv *Server) isEmailCodeNeededForLogIn(ts *models.TotpSecret) (isEmailCodeNeeded base2.Flag) {
+	if ts == nil {
+		return true
+	}
+
+	return !srv.settings.SystemSettings.IsTotpReplacingEmailCode
+}
+
 func (srv *Server) isEmailOfUserValid(email simple.Email) (re *jrm1.RpcError) {
 	_, err := mail.ParseAddress(email.ToString())
 	if err != nil {
@@ -364,6 +552,20 @@ func isPasswordAllowed(password simple.Password) (re *jrm1.RpcError) {
 	return nil
 }
 
+func checkPermissionParams(p *rpc2.PermissionCommonParams) (re *jrm1.RpcError) {
+	if p.UserId == 0 {
+		return jrm1.NewRpcErrorByUser(RpcErrorCode_UserIdIsNotSet, RpcErrorMsg_UserIdIsNotSet, nil)
+	}
+	if !perm.IsPermissionValid(p.Permission) {
+		return jrm1.NewRpcErrorByUser(RpcErrorCode_PermissionIsNotValid, RpcErrorMsg_PermissionIsNotValid, nil)
+	}
+	if !perm.IsScopeValid(p.ScopeType, p.ScopeId) {
+		return jrm1.NewRpcErrorByUser(RpcErrorCode_ScopeIsNotValid, RpcErrorMsg_ScopeIsNotValid, nil)
+	}
+
+	return nil
+}
+
 func (srv *Server) isUserAdministrator(userId base2.Id) (isAdministrator base2.Flag) {
 	// While system has only few administrators, the simple array look-up is
 	// faster than access to a map.
@@ -377,6 +579,71 @@ func (srv *Server) isUserAdministrator(userId base2.Id) (isAdministrator base2.F
 	return false
 }
 
+// setPermission grants or revokes a permission of an existing user. The
+// previous state of the grant is returned for the audit log.
+func (srv *Server) setPermission(g *perm.Grant, isGranted bool) (wasGranted base2.Flag, re *jrm1.RpcError) {
+	roles, err := srv.dbo.GetUserRolesById(g.UserId)
+	if err != nil {
+		return false, srv.databaseError(err)
+	}
+	if roles == nil {
+		return false, jrm1.NewRpcErrorByUser(RpcErrorCode_UserIsNotFound, RpcErrorMsg_UserIsNotFound, nil)
+	}
+
+	var grants []*perm.Grant
+	grants, err = srv.dbo.GetPermissionsByUserId(g.UserId)
+	if err != nil {
+		return false, srv.databaseError(err)
+	}
+
+	for _, x := range grants {
+		if (x.Permission == g.Permission) && (x.ScopeType == g.ScopeType) && (x.ScopeId == g.ScopeId) {
+			wasGranted = true
+			break
+		}
+	}
+
+	if isGranted {
+		err = srv.dbo.GrantPermission(g)
+	} else {
+		err = srv.dbo.RevokePermission(g)
+	}
+	if err != nil {
+		return false, srv.databaseError(err)
+	}
+
+	return wasGranted, nil
+}
+
+// audit saves a record of a privileged action into the audit log. This
+// function must be called while the database is locked for writing.
+func (srv *Server) audit(actorUserId base2.Id, actorIPAB net.IP, action al.Action, targetType al.TargetType, targetId base2.Id, valueBefore *base2.Text, valueAfter *base2.Text) (re *jrm1.RpcError) {
+	err := srv.dbo.InsertAuditRecord(&al.Record{
+		ActorUserId: actorUserId,
+		ActorIPAB:   actorIPAB,
+		Action:      action,
+		TargetType:  targetType,
+		TargetId:    targetId,
+		ValueBefore: valueBefore,
+		ValueAfter:  valueAfter,
+	})
+	if err != nil {
+		return srv.databaseError(err)
+	}
+
+	return nil
+}
+
+// auditA saves a record of an action performed by the caller of an RPC
+// function. The action is already done when it is audited, so a failure to
+// save the record is logged and is not returned to the caller.
+func (srv *Server) auditA(ud derived1.IUserData, auth *cmr.Auth, action al.Action, targetType al.TargetType, targetId base2.Id, valueBefore *base2.Text, valueAfter *base2.Text) {
+	re := srv.audit(ud.GetUser().GetUserParameters().GetId(), auth.UserIPAB, action, targetType, targetId, valueBefore, valueAfter)
+	if re != nil {
+		srv.logError(re.AsError())
+	}
+}
+
 func (srv *Server) isUserModerator(userId base2.Id) (isModerator base2.Flag) {
 	// While system has only few moderators, the simple array look-up is
 	// faster than access to a map.
@@ -434,6 +701,13 @@ func (srv *Server) sendVerificationCodeForPwdChange(email simple.Email, code sim
 	return srv.sendEmailMessage(params)
 }
 
+func (srv *Server) sendVerificationCodeForPwdReset(email simple.Email, code simple.VerificationCode) (re *jrm1.RpcError) {
+	var subject = base2.Text(fmt.Sprintf(srv.settings.MessageSettings.SubjectTemplateForRegVCode.ToString(), srv.settings.SystemSettings.SiteName))
+	var msg = base2.Text(fmt.Sprintf(srv.settings.MessageSettings.BodyTemplateForPwdReset.ToString(), code))
+	var params = sm.SendMessageParams{Recipient: email, Subject: subject, Message: msg}
+	return srv.sendEmailMessage(params)
+}
+
 func (srv *Server) sendGreetingAfterReg(email simple.Email) (re *jrm1.RpcError) {
 	var subject = base2.Text(fmt.Sprintf(srv.settings.MessageSettings.SubjectTemplateForReg.ToString(), srv.settings.SystemSettings.SiteName))
 	var msg = base2.Text(fmt.Sprintf(srv.settings.MessageSettings.BodyTemplateForReg.ToString(), srv.settings.SystemSettings.SiteName))
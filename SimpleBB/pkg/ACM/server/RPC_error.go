@@ -51,6 +51,27 @@ const (
 	RpcErrorCode_UserNameIsNotFound                 = 39
 	RpcErrorCode_EmailAddressIsNotSet               = 40
 	RpcErrorCode_CaptchaIdIsNotSet                  = 41
+	RpcErrorCode_TotpIsAlreadyEnabled               = 42
+	RpcErrorCode_TotpIsNotEnabled                   = 43
+	RpcErrorCode_TotpEnrolmentIsNotStarted          = 44
+	RpcErrorCode_TotpCodeIsNotSet                   = 45
+	RpcErrorCode_TotpCodeIsWrong                    = 46
+	RpcErrorCode_TotpSecretGenerator                = 47
+	RpcErrorCode_RecoveryCodeGenerator              = 48
+	RpcErrorCode_UserAlreadyStartedToResetPassword  = 49
+	RpcErrorCode_PasswordResetIsNotFound            = 50
+	RpcErrorCode_JWKS                               = 51
+	RpcErrorCode_PermissionIsNotValid               = 52
+	RpcErrorCode_ScopeIsNotValid                    = 53
+	RpcErrorCode_UserNamesAreNotSet                 = 54
+	RpcErrorCode_TooManyUserNames                   = 55
+	RpcErrorCode_AuditActionIsNotValid              = 56
+	RpcErrorCode_AuditTargetIsNotValid              = 57
+	RpcErrorCode_AuditValueIsTooLong                = 58
+	RpcErrorCode_AuditFilterIsNotValid              = 59
+	RpcErrorCode_AuditActorIsNotValid               = 60
+	RpcErrorCode_CaptchaKindIsNotValid              = 61
+	RpcErrorCode_CaptchaKindIsNotAllowed            = 62
 )
 
 // Messages.
@@ -96,6 +117,27 @@ const (
 	RpcErrorMsg_UserNameIsNotFound                 = "user name is not found"
 	RpcErrorMsg_EmailAddressIsNotSet               = "email address is not set"
 	RpcErrorMsg_CaptchaIdIsNotSet                  = "captcha ID is not set"
+	RpcErrorMsg_TotpIsAlreadyEnabled               = "two-factor authentication is already enabled"
+	RpcErrorMsg_TotpIsNotEnabled                   = "two-factor authentication is not enabled"
+	RpcErrorMsg_TotpEnrolmentIsNotStarted          = "two-factor authentication enrolment is not started"
+	RpcErrorMsg_TotpCodeIsNotSet                   = "one-time password is not set"
+	RpcErrorMsg_TotpCodeIsWrong                    = "one-time password is wrong"
+	RpcErrorMsg_TotpSecretGenerator                = "one-time password secret generator error"
+	RpcErrorMsg_RecoveryCodeGenerator              = "recovery code generator error"
+	RpcErrorMsg_UserAlreadyStartedToResetPassword  = "user has already started to reset password"
+	RpcErrorMsg_PasswordResetIsNotFound            = "request for password reset is not found"
+	RpcErrorMsgF_JWKS                              = "JWKS error: %s" // Template.
+	RpcErrorMsg_PermissionIsNotValid               = "permission is not valid"
+	RpcErrorMsg_ScopeIsNotValid                    = "scope is not valid"
+	RpcErrorMsg_UserNamesAreNotSet                 = "user names are not set"
+	RpcErrorMsg_TooManyUserNames                   = "too many user names"
+	RpcErrorMsg_AuditActionIsNotValid              = "audited action is not valid"
+	RpcErrorMsg_AuditTargetIsNotValid              = "target of audited action is not valid"
+	RpcErrorMsg_AuditValueIsTooLong                = "value of audited action is too long"
+	RpcErrorMsg_AuditFilterIsNotValid              = "filter of audit log is not valid"
+	RpcErrorMsg_AuditActorIsNotValid               = "actor of audited action is not valid"
+	RpcErrorMsg_CaptchaKindIsNotValid              = "captcha kind is not valid"
+	RpcErrorMsg_CaptchaKindIsNotAllowed            = "captcha kind is not allowed"
 )
 
 // Unique HTTP status codes used in the map:
@@ -147,5 +189,26 @@ func GetMapOfHttpStatusCodesByRpcErrorCodes() map[int]int {
 		RpcErrorCode_UserNameIsNotFound:                 http.StatusNotFound,
 		RpcErrorCode_EmailAddressIsNotSet:               http.StatusBadRequest,
 		RpcErrorCode_CaptchaIdIsNotSet:                  http.StatusInternalServerError,
+		RpcErrorCode_TotpIsAlreadyEnabled:               http.StatusConflict,
+		RpcErrorCode_TotpIsNotEnabled:                   http.StatusNotFound,
+		RpcErrorCode_TotpEnrolmentIsNotStarted:          http.StatusNotFound,
+		RpcErrorCode_TotpCodeIsNotSet:                   http.StatusBadRequest,
+		RpcErrorCode_TotpCodeIsWrong:                    http.StatusForbidden,
+		RpcErrorCode_TotpSecretGenerator:                http.StatusInternalServerError,
+		RpcErrorCode_RecoveryCodeGenerator:              http.StatusInternalServerError,
+		RpcErrorCode_UserAlreadyStartedToResetPassword:  http.StatusForbidden,
+		RpcErrorCode_PasswordResetIsNotFound:            http.StatusNotFound,
+		RpcErrorCode_JWKS:                               http.StatusInternalServerError,
+		RpcErrorCode_PermissionIsNotValid:               http.StatusBadRequest,
+		RpcErrorCode_ScopeIsNotValid:                    http.StatusBadRequest,
+		RpcErrorCode_UserNamesAreNotSet:                 http.StatusBadRequest,
+		RpcErrorCode_TooManyUserNames:                   http.StatusBadRequest,
+		RpcErrorCode_AuditActionIsNotValid:              http.StatusBadRequest,
+		RpcErrorCode_AuditTargetIsNotValid:              http.StatusBadRequest,
+		RpcErrorCode_AuditValueIsTooLong:                http.StatusBadRequest,
+		RpcErrorCode_AuditFilterIsNotValid:              http.StatusBadRequest,
+		RpcErrorCode_AuditActorIsNotValid:               http.StatusBadRequest,
+		RpcErrorCode_CaptchaKindIsNotValid:              http.StatusBadRequest,
+		RpcErrorCode_CaptchaKindIsNotAllowed:            http.StatusForbidden,
 	}
 }
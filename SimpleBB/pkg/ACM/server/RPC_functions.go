@@ -6,6 +6,8 @@ import (
 	rm "github.com/vault-thirteen/SimpleBB/pkg/RCS/rpc"
 	base22 "github.com/vault-thirteen/SimpleBB/pkg/common/interfaces/base2"
 	"github.com/vault-thirteen/SimpleBB/pkg/common/interfaces/derived1"
+	"github.com/vault-thirteen/SimpleBB/pkg/common/models/AuditLog"
+	"github.com/vault-thirteen/SimpleBB/pkg/common/models/Permission"
 	"github.com/vault-thirteen/SimpleBB/pkg/common/models/UserRoles"
 	base2 "github.com/vault-thirteen/SimpleBB/pkg/common/models/base"
 	"github.com/vault-thirteen/SimpleBB/pkg/common/models/complex/EnumValue"
@@ -13,6 +15,7 @@ import (
 	le "github.com/vault-thirteen/SimpleBB/pkg/common/models/complex/LogEvent"
 	"github.com/vault-thirteen/SimpleBB/pkg/common/models/complex/LogEventType"
 	"github.com/vault-thirteen/SimpleBB/pkg/common/models/complex/User"
+	cn "github.com/vault-thirteen/SimpleBB/pkg/common/models/net"
 	rpc3 "github.com/vault-thirteen/SimpleBB/pkg/common/models/rpc"
 	c "github.com/vault-thirteen/SimpleBB/pkg/common/models/server"
 	"github.com/vault-thirteen/SimpleBB/pkg/common/models/simple"
@@ -21,6 +24,7 @@ import (
 	bpp "github.com/vault-thirteen/BytePackedPassword"
 	jrm1 "github.com/vault-thirteen/JSON-RPC-M1"
 	am "github.com/vault-thirteen/SimpleBB/pkg/ACM/models"
+	"github.com/vault-thirteen/SimpleBB/pkg/ACM/totp"
 )
 
 // RPC functions.
@@ -328,6 +332,8 @@ func (srv *Server) rejectRegistrationRequest(p *rpc2.RejectRegistrationRequestPa
 		return nil, srv.databaseError(err)
 	}
 
+	srv.auditA(thisUserData, p.Auth, al.Action_RejectRegistration, al.TargetType_Registration, p.RegistrationRequestId, nil, nil)
+
 	result = &rpc2.RejectRegistrationRequestResult{
 		Success: rpc3.Success{
 			OK: true,
@@ -373,6 +379,14 @@ func (srv *Server) approveAndRegisterUser(p *rpc2.ApproveAndRegisterUserParams)
 		return nil, srv.databaseError(err)
 	}
 
+	var userId base2.Id
+	userId, err = srv.dbo.GetUserIdByEmail(p.Email)
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+
+	srv.auditA(thisUserData, p.Auth, al.Action_ApproveRegistration, al.TargetType_User, userId, nil, al.TextValue(p.Email))
+
 	re = srv.sendGreetingAfterReg(p.Email)
 	if re != nil {
 		return nil, re
@@ -502,7 +516,7 @@ func (srv *Server) logUserInStep1(p *rpc2.LogUserInParams) (result *rpc2.LogUser
 	var captchaData *rm.CreateCaptchaResult
 	var captchaId *simple.CaptchaId
 	if isCaptchaNeeded {
-		captchaData, re = srv.createCaptcha()
+		captchaData, re = srv.createCaptcha(p.CaptchaKind)
 		if re != nil {
 			return nil, re
 		}
@@ -519,6 +533,7 @@ func (srv *Server) logUserInStep1(p *rpc2.LogUserInParams) (result *rpc2.LogUser
 
 	if isCaptchaNeeded {
 		result.CaptchaId = captchaId
+		result.Captcha = newCaptchaChallenge(captchaData)
 	} else {
 		result.IsCaptchaNeeded = false
 	}
@@ -730,29 +745,45 @@ func (srv *Server) logUserInStep2(p *rpc2.LogUserInParams) (result *rpc2.LogUser
 		return nil, srv.databaseError(err)
 	}
 
-	// Verification by E-mail.
-	var verificationCode *simple.VerificationCode
-	verificationCode, re = srv.createVerificationCode()
+	// Two-factor authentication.
+	var ts *am.TotpSecret
+	ts, re = srv.getEnabledTotpSecret(userId)
 	if re != nil {
 		return nil, re
 	}
 
-	err = srv.dbo.AttachVerificationCodeToPreSession(userId, *step3requestId, *verificationCode)
-	if err != nil {
-		return nil, srv.databaseError(err)
-	}
+	isEmailCodeNeeded := srv.isEmailCodeNeededForLogIn(ts)
 
-	re = srv.sendVerificationCodeForLogIn(p.Email, *verificationCode)
-	if re != nil {
-		return nil, re
-	}
+	// Verification by E-mail.
+	if isEmailCodeNeeded {
+		var verificationCode *simple.VerificationCode
+		verificationCode, re = srv.createVerificationCode()
+		if re != nil {
+			return nil, re
+		}
 
-	err = srv.dbo.SetPreSessionEmailSendStatus(userId, *step3requestId, true)
-	if err != nil {
-		return nil, srv.databaseError(err)
+		err = srv.dbo.AttachVerificationCodeToPreSession(userId, *step3requestId, *verificationCode)
+		if err != nil {
+			return nil, srv.databaseError(err)
+		}
+
+		re = srv.sendVerificationCodeForLogIn(p.Email, *verificationCode)
+		if re != nil {
+			return nil, re
+		}
+
+		err = srv.dbo.SetPreSessionEmailSendStatus(userId, *step3requestId, true)
+		if err != nil {
+			return nil, srv.databaseError(err)
+		}
 	}
 
-	result = &rpc2.LogUserInResult{NextStep: 3, RequestId: *step3requestId}
+	result = &rpc2.LogUserInResult{
+		NextStep:          3,
+		RequestId:         *step3requestId,
+		IsEmailCodeNeeded: isEmailCodeNeeded,
+		IsTotpNeeded:      ts != nil,
+	}
 
 	return result, nil
 }
@@ -773,7 +804,7 @@ func (srv *Server) logUserInStep3(p *rpc2.LogUserInParams) (result *rpc2.LogUser
 		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_RequestIdIsNotSet, RpcErrorMsg_RequestIdIsNotSet, nil)
 	}
 
-	if len(p.VerificationCode) == 0 {
+	if (len(p.VerificationCode) == 0) && (len(p.TotpCode) == 0) && (len(p.RecoveryCode) == 0) {
 		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_VerificationCodeIsNotSet, RpcErrorMsg_VerificationCodeIsNotSet, nil)
 	}
 
@@ -875,29 +906,72 @@ func (srv *Server) logUserInStep3(p *rpc2.LogUserInParams) (result *rpc2.LogUser
 		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_UserPreSessionIsNotFound, RpcErrorMsg_UserPreSessionIsNotFound, nil)
 	}
 
-	// Check the verification code.
-	var ok bool
-	ok, err = srv.dbo.CheckVerificationCodeForLogIn(p.RequestId, p.VerificationCode)
-	if err != nil {
-		return nil, srv.databaseError(err)
+	// Two-factor authentication.
+	var ts *am.TotpSecret
+	ts, re = srv.getEnabledTotpSecret(userId)
+	if re != nil {
+		return nil, re
 	}
 
-	if !ok {
-		// Verification code can not be guessed.
-		srv.incidentManager.ReportIncident(ev.NewEnumValue(cm.IncidentType_VerificationCodeMismatch), p.Email, p.Auth.UserIPAB)
+	isEmailCodeNeeded := srv.isEmailCodeNeededForLogIn(ts)
 
-		err = srv.dbo.UpdateUserLastBadLogInTimeByEmail(p.Email)
+	if isEmailCodeNeeded && (len(p.VerificationCode) == 0) {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_VerificationCodeIsNotSet, RpcErrorMsg_VerificationCodeIsNotSet, nil)
+	}
+	if (ts != nil) && (len(p.TotpCode) == 0) && (len(p.RecoveryCode) == 0) {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_TotpCodeIsNotSet, RpcErrorMsg_TotpCodeIsNotSet, nil)
+	}
+
+	// Check the verification code.
+	var ok bool
+	if isEmailCodeNeeded {
+		ok, err = srv.dbo.CheckVerificationCodeForLogIn(p.RequestId, p.VerificationCode)
 		if err != nil {
 			return nil, srv.databaseError(err)
 		}
 
-		// Delete the pre-session on error to avoid brute force checks.
-		err = srv.dbo.DeletePreSessionByRequestId(preSession.RequestId)
-		if err != nil {
-			return nil, srv.databaseError(err)
+		if !ok {
+			// Verification code can not be guessed.
+			srv.incidentManager.ReportIncident(ev.NewEnumValue(cm.IncidentType_VerificationCodeMismatch), p.Email, p.Auth.UserIPAB)
+
+			err = srv.dbo.UpdateUserLastBadLogInTimeByEmail(p.Email)
+			if err != nil {
+				return nil, srv.databaseError(err)
+			}
+
+			// Delete the pre-session on error to avoid brute force checks.
+			err = srv.dbo.DeletePreSessionByRequestId(preSession.RequestId)
+			if err != nil {
+				return nil, srv.databaseError(err)
+			}
+
+			return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_VerificationCodeIsWrong, RpcErrorMsg_VerificationCodeIsWrong, nil)
 		}
+	}
 
-		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_VerificationCodeIsWrong, RpcErrorMsg_VerificationCodeIsWrong, nil)
+	// Check the one-time password.
+	if ts != nil {
+		ok, re = srv.checkTotp(ts, p.TotpCode, p.RecoveryCode)
+		if re != nil {
+			return nil, re
+		}
+
+		if !ok {
+			srv.incidentManager.ReportIncident(ev.NewEnumValue(cm.IncidentType_VerificationCodeMismatch), p.Email, p.Auth.UserIPAB)
+
+			err = srv.dbo.UpdateUserLastBadLogInTimeByEmail(p.Email)
+			if err != nil {
+				return nil, srv.databaseError(err)
+			}
+
+			// Delete the pre-session on error to avoid brute force checks.
+			err = srv.dbo.DeletePreSessionByRequestId(preSession.RequestId)
+			if err != nil {
+				return nil, srv.databaseError(err)
+			}
+
+			return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_TotpCodeIsWrong, RpcErrorMsg_TotpCodeIsWrong, nil)
+		}
 	}
 
 	// Set verification flags.
@@ -1032,6 +1106,8 @@ func (srv *Server) logUserOutA(p *rpc2.LogUserOutAParams) (result *rpc2.LogUserO
 		return nil, srv.databaseError(err)
 	}
 
+	srv.auditA(callerData, p.Auth, al.Action_LogUserOut, al.TargetType_User, p.UserId, nil, nil)
+
 	result = &rpc2.LogUserOutAResult{
 		Success: rpc3.Success{
 			OK: true,
@@ -1208,287 +1284,347 @@ func (srv *Server) isUserLoggedIn(p *rpc2.IsUserLoggedInParams) (result *rpc2.Is
 	return result, nil
 }
 
-// Various actions.
-
-func (srv *Server) changePassword(p *rpc2.ChangePasswordParams) (result *rpc2.ChangePasswordResult, re *jrm1.RpcError) {
-	srv.dbo.LockForWriting()
-	defer srv.dbo.UnlockAfterWriting()
+// Password reset.
 
-	var thisUserData derived1.IUserData
-	thisUserData, re = srv.mustBeAnAuthToken(p.Auth)
+func (srv *Server) resetPassword(p *rpc2.ResetPasswordParams) (result *rpc2.ResetPasswordResult, re *jrm1.RpcError) {
+	re = srv.mustBeNoAuthToken(p.Auth)
 	if re != nil {
 		return nil, re
 	}
 
+	srv.dbo.LockForWriting()
+	defer srv.dbo.UnlockAfterWriting()
+
 	switch p.StepN {
 	case 1:
-		return srv.changePasswordStep1(p, thisUserData)
+		return srv.resetPasswordStep1(p)
 	case 2:
-		return srv.changePasswordStep2(p, thisUserData)
+		return srv.resetPasswordStep2(p)
+	case 3:
+		return srv.resetPasswordStep3(p)
 	default:
 		// Step is not supported.
 		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_StepIsUnknown, RpcErrorMsg_StepIsUnknown, nil)
 	}
 }
 
-func (srv *Server) changePasswordStep1(p *rpc2.ChangePasswordParams, ud derived1.IUserData) (result *rpc2.ChangePasswordResult, re *jrm1.RpcError) {
-	// Is new password set ?
-	if len(p.NewPassword) == 0 {
-		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_NewPasswordIsNotSet, RpcErrorMsg_NewPasswordIsNotSet, nil)
+func (srv *Server) resetPasswordStep1(p *rpc2.ResetPasswordParams) (result *rpc2.ResetPasswordResult, re *jrm1.RpcError) {
+	// Check parameters.
+	if len(p.Email) == 0 {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_EmailAddressIsNotSet, RpcErrorMsg_EmailAddressIsNotSet, nil)
 	}
 
-	var pc = &am.PasswordChange{
-		UserId:   ud.GetUser().GetUserParameters().GetId(),
-		UserIPAB: p.Auth.UserIPAB,
+	// Is e-mail address valid ?
+	re = srv.isEmailOfUserValid(p.Email)
+	if re != nil {
+		return nil, re
 	}
 
-	// Check for duplicate request.
-	passwordChangesCount, err := srv.dbo.CountPasswordChangesByUserId(ud.GetUser().GetUserParameters().GetId())
+	usersCount, err := srv.dbo.CountUsersWithEmailAbleToLogIn(p.Email)
 	if err != nil {
 		return nil, srv.databaseError(err)
 	}
 
-	if passwordChangesCount > 0 {
-		srv.incidentManager.ReportIncident(ev.NewEnumValue(cm.IncidentType_PasswordChangeHacking), ud.GetUser().GetUserParameters().GetEmail(), p.Auth.UserIPAB)
-
-		err = srv.dbo.UpdateUserLastBadActionTimeById(ud.GetUser().GetUserParameters().GetId())
-		if err != nil {
-			return nil, srv.databaseError(err)
-		}
-
-		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_UserAlreadyStartedToChangePassword, RpcErrorMsg_UserAlreadyStartedToChangePassword, nil)
+	canUserLogIn := usersCount == 1
+	if !canUserLogIn {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_UserCanNotLogIn, RpcErrorMsg_UserCanNotLogIn, nil)
 	}
 
-	// Check the new password.
-	re = isPasswordAllowed(p.NewPassword)
-	if re != nil {
-		return nil, re
+	var userId base2.Id
+	userId, err = srv.dbo.GetUserIdByEmail(p.Email)
+	if err != nil {
+		return nil, srv.databaseError(err)
 	}
 
-	pc.NewPasswordBytes, err = bpp.PackSymbols([]rune(p.NewPassword))
+	// Check for duplicate request.
+	//
+	// Requests are deleted only by the scheduler when they expire, so a user
+	// may reset the password only once per the expiration period.
+	var passwordResetsCount base2.Count
+	passwordResetsCount, err = srv.dbo.CountPasswordResetsByUserId(userId)
 	if err != nil {
-		// This error is very unlikely to happen.
-		// So if it occurs, then it is an anomaly.
-		srv.logError(err)
-		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_BPP_PackSymbols, fmt.Sprintf(RpcErrorMsgF_BPP_PackSymbols, err.Error()), nil)
+		return nil, srv.databaseError(err)
 	}
 
-	if len(pc.NewPasswordBytes) > int(srv.settings.SystemSettings.UserPasswordMaxLenInBytes) {
-		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_PasswordIsTooLong, RpcErrorMsg_PasswordIsTooLong, nil)
-	}
+	if passwordResetsCount > 0 {
+		srv.incidentManager.ReportIncident(ev.NewEnumValue(cm.IncidentType_PasswordResetHacking), p.Email, p.Auth.UserIPAB)
 
-	// Request ID.
-	pc.RequestId, re = srv.createRequestIdForPasswordChange()
-	if re != nil {
-		return nil, re
+		err = srv.dbo.UpdateUserLastBadActionTimeById(userId)
+		if err != nil {
+			return nil, srv.databaseError(err)
+		}
+
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_UserAlreadyStartedToResetPassword, RpcErrorMsg_UserAlreadyStartedToResetPassword, nil)
 	}
 
-	// Password salt.
-	pc.AuthDataBytes, err = bpp.GenerateRandomSalt()
-	if err != nil {
-		srv.logError(err)
-		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_BPP_GenerateRandomSalt, RpcErrorMsg_BPP_GenerateRandomSalt, nil)
+	var pr = &am.PasswordReset{
+		UserId:   userId,
+		UserIPAB: p.Auth.UserIPAB,
 	}
 
-	// Verification code.
-	pc.VerificationCode, re = srv.createVerificationCode()
+	// Request ID.
+	pr.RequestId, re = srv.createRequestIdForPasswordReset()
 	if re != nil {
 		return nil, re
 	}
 
-	re = srv.sendVerificationCodeForPwdChange(ud.GetUser().GetUserParameters().GetEmail(), *pc.VerificationCode)
+	// Captcha is always required, otherwise anybody would be able to send
+	// e-mail messages to users.
+	var captchaData *rm.CreateCaptchaResult
+	captchaData, re = srv.createCaptcha(p.CaptchaKind)
 	if re != nil {
 		return nil, re
 	}
 
-	// Captcha.
-	pc.IsCaptchaRequired, err = srv.isCaptchaNeededForPasswordChange(ud.GetUser().GetUserParameters().GetId())
-	if err != nil {
-		return nil, srv.databaseError(err)
-	}
-
-	var captchaData *rm.CreateCaptchaResult
-	if pc.IsCaptchaRequired {
-		captchaData, re = srv.createCaptcha()
-		if re != nil {
-			return nil, re
-		}
-
-		pc.CaptchaId = (*simple.CaptchaId)(&captchaData.TaskId)
-	}
+	pr.CaptchaId = (*simple.CaptchaId)(&captchaData.TaskId)
 
 	// Save the request.
-	err = srv.dbo.CreatePasswordChangeRequest(pc)
+	err = srv.dbo.CreatePasswordResetRequest(pr)
 	if err != nil {
 		return nil, srv.databaseError(err)
 	}
 
 	// Response.
-	result = &rpc2.ChangePasswordResult{
-		NextStep:      2,
-		RequestId:     *pc.RequestId,
-		AuthDataBytes: pc.AuthDataBytes,
-	}
-
-	if pc.IsCaptchaRequired {
-		result.IsCaptchaNeeded = true
-		result.CaptchaId = *pc.CaptchaId
-	} else {
-		result.IsCaptchaNeeded = false
+	result = &rpc2.ResetPasswordResult{
+		NextStep:  2,
+		RequestId: *pr.RequestId,
+		CaptchaId: *pr.CaptchaId,
+		Captcha:   newCaptchaChallenge(captchaData),
 	}
 
 	return result, nil
 }
 
-func (srv *Server) changePasswordStep2(p *rpc2.ChangePasswordParams, ud derived1.IUserData) (result *rpc2.ChangePasswordResult, re *jrm1.RpcError) {
-	// Check input parameters.
+func (srv *Server) resetPasswordStep2(p *rpc2.ResetPasswordParams) (result *rpc2.ResetPasswordResult, re *jrm1.RpcError) {
+	// Check parameters.
+	if len(p.Email) == 0 {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_EmailAddressIsNotSet, RpcErrorMsg_EmailAddressIsNotSet, nil)
+	}
+
+	// Is e-mail address valid ?
+	re = srv.isEmailOfUserValid(p.Email)
+	if re != nil {
+		return nil, re
+	}
+
 	if len(p.RequestId) == 0 {
 		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_RequestIdIsNotSet, RpcErrorMsg_RequestIdIsNotSet, nil)
 	}
-	if len(p.AuthChallengeResponse) == 0 {
-		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_AuthChallengeResponseIsNotSet, RpcErrorMsg_AuthChallengeResponseIsNotSet, nil)
-	}
-	if len(p.VerificationCode) == 0 {
-		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_VerificationCodeIsNotSet, RpcErrorMsg_VerificationCodeIsNotSet, nil)
+
+	if len(p.CaptchaAnswer) == 0 {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_CaptchaAnswerIsNotSet, RpcErrorMsg_CaptchaAnswerIsNotSet, nil)
 	}
 
-	// Check for duplicate request.
-	passwordChangesCount, err := srv.dbo.CountPasswordChangesByUserId(ud.GetUser().GetUserParameters().GetId())
+	usersCount, err := srv.dbo.CountUsersWithEmailAbleToLogIn(p.Email)
 	if err != nil {
 		return nil, srv.databaseError(err)
 	}
 
-	if passwordChangesCount != 1 {
-		srv.incidentManager.ReportIncident(ev.NewEnumValue(cm.IncidentType_PasswordChangeHacking), ud.GetUser().GetUserParameters().GetEmail(), p.Auth.UserIPAB)
-
-		err = srv.dbo.UpdateUserLastBadActionTimeById(ud.GetUser().GetUserParameters().GetId())
-		if err != nil {
-			return nil, srv.databaseError(err)
-		}
+	canUserLogIn := usersCount == 1
+	if !canUserLogIn {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_UserCanNotLogIn, RpcErrorMsg_UserCanNotLogIn, nil)
+	}
 
-		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_UserAlreadyStartedToChangePassword, RpcErrorMsg_UserAlreadyStartedToChangePassword, nil)
+	var userId base2.Id
+	userId, err = srv.dbo.GetUserIdByEmail(p.Email)
+	if err != nil {
+		return nil, srv.databaseError(err)
 	}
 
-	// Get the request for password change.
-	var pcr *am.PasswordChange
-	pcr, err = srv.dbo.GetPasswordChangeByRequestId(p.RequestId)
+	// Get the request for password reset.
+	var pr *am.PasswordReset
+	pr, err = srv.dbo.GetPasswordResetByRequestId(p.RequestId)
 	if err != nil {
 		return nil, srv.databaseError(err)
 	}
-	if pcr == nil {
-		// Request Id code can not be guessed.
-		srv.incidentManager.ReportIncident(ev.NewEnumValue(cm.IncidentType_PasswordChangeHacking), ud.GetUser().GetUserParameters().GetEmail(), p.Auth.UserIPAB)
 
-		err = srv.dbo.UpdateUserLastBadActionTimeById(ud.GetUser().GetUserParameters().GetId())
+	// Request Id code can not be guessed. The captcha step can not be passed
+	// twice, otherwise it would be possible to send many e-mail messages.
+	if (pr == nil) ||
+		(pr.UserId != userId) ||
+		(pr.RequestId == nil) ||
+		(pr.CaptchaId == nil) ||
+		(bool(pr.IsVerifiedByCaptcha)) ||
+		(!p.Auth.UserIPAB.Equal(pr.UserIPAB)) {
+		srv.incidentManager.ReportIncident(ev.NewEnumValue(cm.IncidentType_PasswordResetHacking), p.Email, p.Auth.UserIPAB)
+
+		err = srv.dbo.UpdateUserLastBadActionTimeById(userId)
 		if err != nil {
 			return nil, srv.databaseError(err)
 		}
 
-		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_PasswordChangeIsNotFound, RpcErrorMsg_PasswordChangeIsNotFound, nil)
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_PasswordResetIsNotFound, RpcErrorMsg_PasswordResetIsNotFound, nil)
 	}
 
-	// Check the Request ID (indirectly).
-	if (pcr.UserId != ud.GetUser().GetUserParameters().GetId()) || (pcr.RequestId == nil) {
-		srv.incidentManager.ReportIncident(ev.NewEnumValue(cm.IncidentType_PasswordChangeHacking), ud.GetUser().GetUserParameters().GetEmail(), p.Auth.UserIPAB)
+	// Check the captcha answer.
+	var ccr *rm.CheckCaptchaResult
+	ccr, re = srv.checkCaptcha(*pr.CaptchaId, p.CaptchaAnswer)
+	if re != nil {
+		return nil, re
+	}
 
-		err = srv.dbo.UpdateUserLastBadActionTimeById(ud.GetUser().GetUserParameters().GetId())
+	if !ccr.IsSuccess {
+		srv.incidentManager.ReportIncident(ev.NewEnumValue(cm.IncidentType_CaptchaAnswerMismatch), p.Email, p.Auth.UserIPAB)
+
+		err = srv.dbo.UpdateUserLastBadActionTimeById(userId)
 		if err != nil {
 			return nil, srv.databaseError(err)
 		}
 
-		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_PasswordChangeIsNotFound, RpcErrorMsg_PasswordChangeIsNotFound, nil)
-	}
-
-	// Check the IP address.
-	if !p.Auth.UserIPAB.Equal(pcr.UserIPAB) {
-		srv.incidentManager.ReportIncident(ev.NewEnumValue(cm.IncidentType_PasswordChangeHacking), ud.GetUser().GetUserParameters().GetEmail(), p.Auth.UserIPAB)
-
-		err = srv.dbo.UpdateUserLastBadActionTimeById(ud.GetUser().GetUserParameters().GetId())
+		// When captcha guess is wrong, we delete the password reset request
+		// to start the process from the first step.
+		err = srv.dbo.DeletePasswordResetByRequestId(*pr.RequestId)
 		if err != nil {
 			return nil, srv.databaseError(err)
 		}
 
-		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_PasswordChangeIsNotFound, RpcErrorMsg_PasswordChangeIsNotFound, nil)
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_CaptchaAnswerIsWrong, RpcErrorMsg_CaptchaAnswerIsWrong, nil)
 	}
 
-	// Check the captcha answer if needed.
-	var ccr *rm.CheckCaptchaResult
-	if pcr.IsCaptchaRequired {
-		if len(p.CaptchaAnswer) == 0 {
-			return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_CaptchaAnswerIsNotSet, RpcErrorMsg_CaptchaAnswerIsNotSet, nil)
-		}
-		if pcr.CaptchaId == nil {
-			return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_CaptchaIdIsNotSet, RpcErrorMsg_CaptchaIdIsNotSet, nil)
-		}
+	err = srv.dbo.SetPasswordResetCaptchaFlag(userId, *pr.RequestId, true)
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
 
-		// Check captcha answer.
-		ccr, re = srv.checkCaptcha(*pcr.CaptchaId, p.CaptchaAnswer)
-		if re != nil {
-			return nil, re
-		}
+	// Create a new Request ID for the next step.
+	var step3requestId *simple.RequestId
+	step3requestId, re = srv.createRequestIdForPasswordReset()
+	if re != nil {
+		return nil, re
+	}
 
-		if !ccr.IsSuccess {
-			srv.incidentManager.ReportIncident(ev.NewEnumValue(cm.IncidentType_CaptchaAnswerMismatch), ud.GetUser().GetUserParameters().GetEmail(), p.Auth.UserIPAB)
+	err = srv.dbo.UpdatePasswordResetRequestId(userId, *pr.RequestId, *step3requestId)
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
 
-			err = srv.dbo.UpdateUserLastBadActionTimeById(ud.GetUser().GetUserParameters().GetId())
-			if err != nil {
-				return nil, srv.databaseError(err)
-			}
+	// Verification by E-mail.
+	var verificationCode *simple.VerificationCode
+	verificationCode, re = srv.createVerificationCode()
+	if re != nil {
+		return nil, re
+	}
 
-			// When captcha guess is wrong, we delete the password change
-			// request to start the process from the first step.
-			err = srv.dbo.DeletePasswordChangeByRequestId(*pcr.RequestId)
-			if err != nil {
-				return nil, srv.databaseError(err)
-			}
+	err = srv.dbo.AttachVerificationCodeToPwdReset(userId, *step3requestId, *verificationCode)
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
 
-			return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_CaptchaAnswerIsWrong, RpcErrorMsg_CaptchaAnswerIsWrong, nil)
-		}
+	re = srv.sendVerificationCodeForPwdReset(p.Email, *verificationCode)
+	if re != nil {
+		return nil, re
 	}
 
-	// Check the password.
-	var ok bool
-	ok, re = srv.checkPassword(ud.GetUser().GetUserParameters().GetId(), pcr.AuthDataBytes, p.AuthChallengeResponse)
+	err = srv.dbo.SetPasswordResetEmailSendStatus(userId, *step3requestId, true)
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+
+	result = &rpc2.ResetPasswordResult{NextStep: 3, RequestId: *step3requestId}
+
+	return result, nil
+}
+
+func (srv *Server) resetPasswordStep3(p *rpc2.ResetPasswordParams) (result *rpc2.ResetPasswordResult, re *jrm1.RpcError) {
+	// Check parameters.
+	if len(p.Email) == 0 {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_EmailAddressIsNotSet, RpcErrorMsg_EmailAddressIsNotSet, nil)
+	}
+
+	// Is e-mail address valid ?
+	re = srv.isEmailOfUserValid(p.Email)
 	if re != nil {
 		return nil, re
 	}
 
-	if !ok {
-		srv.incidentManager.ReportIncident(ev.NewEnumValue(cm.IncidentType_PasswordMismatch), ud.GetUser().GetUserParameters().GetEmail(), p.Auth.UserIPAB)
+	if len(p.RequestId) == 0 {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_RequestIdIsNotSet, RpcErrorMsg_RequestIdIsNotSet, nil)
+	}
 
-		err = srv.dbo.UpdateUserLastBadActionTimeById(ud.GetUser().GetUserParameters().GetId())
-		if err != nil {
-			return nil, srv.databaseError(err)
-		}
+	if len(p.VerificationCode) == 0 {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_VerificationCodeIsNotSet, RpcErrorMsg_VerificationCodeIsNotSet, nil)
+	}
 
-		// When password is wrong, we delete the password change request to
-		// start the process from the first step.
-		err = srv.dbo.DeletePasswordChangeByRequestId(*pcr.RequestId)
+	if len(p.NewPassword) == 0 {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_NewPasswordIsNotSet, RpcErrorMsg_NewPasswordIsNotSet, nil)
+	}
+
+	// Check the new password.
+	re = isPasswordAllowed(p.NewPassword)
+	if re != nil {
+		return nil, re
+	}
+
+	newPasswordBytes, err := bpp.PackSymbols([]rune(p.NewPassword))
+	if err != nil {
+		// This error is very unlikely to happen.
+		// So if it occurs, then it is an anomaly.
+		srv.logError(err)
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_BPP_PackSymbols, fmt.Sprintf(RpcErrorMsgF_BPP_PackSymbols, err.Error()), nil)
+	}
+
+	if len(newPasswordBytes) > int(srv.settings.SystemSettings.UserPasswordMaxLenInBytes) {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_PasswordIsTooLong, RpcErrorMsg_PasswordIsTooLong, nil)
+	}
+
+	var usersCount base2.Count
+	usersCount, err = srv.dbo.CountUsersWithEmailAbleToLogIn(p.Email)
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+
+	canUserLogIn := usersCount == 1
+	if !canUserLogIn {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_UserCanNotLogIn, RpcErrorMsg_UserCanNotLogIn, nil)
+	}
+
+	var userId base2.Id
+	userId, err = srv.dbo.GetUserIdByEmail(p.Email)
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+
+	// Get the request for password reset.
+	var pr *am.PasswordReset
+	pr, err = srv.dbo.GetPasswordResetByRequestId(p.RequestId)
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+
+	// Request Id code can not be guessed.
+	if (pr == nil) ||
+		(pr.UserId != userId) ||
+		(pr.RequestId == nil) ||
+		(!p.Auth.UserIPAB.Equal(pr.UserIPAB)) {
+		srv.incidentManager.ReportIncident(ev.NewEnumValue(cm.IncidentType_PasswordResetHacking), p.Email, p.Auth.UserIPAB)
+
+		err = srv.dbo.UpdateUserLastBadActionTimeById(userId)
 		if err != nil {
 			return nil, srv.databaseError(err)
 		}
 
-		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_PasswordIsWrong, RpcErrorMsg_PasswordIsWrong, nil)
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_PasswordResetIsNotFound, RpcErrorMsg_PasswordResetIsNotFound, nil)
 	}
 
 	// Check the verification code.
-	ok, err = srv.dbo.CheckVerificationCodeForPwdChange(p.RequestId, p.VerificationCode)
+	var ok bool
+	ok, err = srv.dbo.CheckVerificationCodeForPwdReset(p.RequestId, p.VerificationCode)
 	if err != nil {
 		return nil, srv.databaseError(err)
 	}
 
 	if !ok {
 		// Verification code can not be guessed.
-		srv.incidentManager.ReportIncident(ev.NewEnumValue(cm.IncidentType_VerificationCodeMismatch), ud.GetUser().GetUserParameters().GetEmail(), p.Auth.UserIPAB)
+		srv.incidentManager.ReportIncident(ev.NewEnumValue(cm.IncidentType_VerificationCodeMismatch), p.Email, p.Auth.UserIPAB)
 
-		err = srv.dbo.UpdateUserLastBadActionTimeById(ud.GetUser().GetUserParameters().GetId())
+		err = srv.dbo.UpdateUserLastBadActionTimeById(userId)
 		if err != nil {
 			return nil, srv.databaseError(err)
 		}
 
-		// Delete the password change request on error to avoid brute force
+		// Delete the password reset request on error to avoid brute force
 		// checks.
-		err = srv.dbo.DeletePasswordChangeByRequestId(*pcr.RequestId)
+		err = srv.dbo.DeletePasswordResetByRequestId(*pr.RequestId)
 		if err != nil {
 			return nil, srv.databaseError(err)
 		}
@@ -1496,39 +1632,38 @@ func (srv *Server) changePasswordStep2(p *rpc2.ChangePasswordParams, ud derived1
 		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_VerificationCodeIsWrong, RpcErrorMsg_VerificationCodeIsWrong, nil)
 	}
 
-	// Update request's flags.
-	pcvf := &am.PasswordChangeVerificationFlags{
-		IsVerifiedByPassword: true,
-		IsVerifiedByEmail:    true,
-	}
-	if pcr.IsCaptchaRequired {
-		x := true
-		pcvf.IsVerifiedByCaptcha = (*base2.Flag)(&x)
-	}
-
-	err = srv.dbo.SetPasswordChangeVFlags(ud.GetUser().GetUserParameters().GetId(), *pcr.RequestId, pcvf)
+	err = srv.dbo.SetPasswordResetVerificationFlag(userId, *pr.RequestId, true)
 	if err != nil {
 		return nil, srv.databaseError(err)
 	}
 
 	// Change the password.
-	err = srv.dbo.SetUserPassword(ud.GetUser().GetUserParameters().GetId(), ud.GetUser().GetUserParameters().GetEmail(), pcr.NewPasswordBytes)
+	err = srv.dbo.SetUserPassword(userId, p.Email, newPasswordBytes)
 	if err != nil {
 		return nil, srv.databaseError(err)
 	}
 
-	// Close the session to force re-logging.
-	err = srv.dbo.DeleteSession(ud.GetSession().Id, ud.GetUser().GetUserParameters().GetId(), p.Auth.UserIPAB)
+	// Close all sessions of the user, because the old password may be known
+	// to somebody else.
+	var sessionsCount base2.Count
+	sessionsCount, err = srv.dbo.CountSessionsByUserId(userId)
 	if err != nil {
 		return nil, srv.databaseError(err)
 	}
 
-	// N.B.: We do not immediately delete the request for password change here
+	if sessionsCount > 0 {
+		err = srv.dbo.DeleteSessionByUserId(userId)
+		if err != nil {
+			return nil, srv.databaseError(err)
+		}
+	}
+
+	// N.B.: We do not immediately delete the request for password reset here
 	// to avoid spamming with these requests. It will be deleted later by the
 	// scheduler.
 
 	// Response.
-	result = &rpc2.ChangePasswordResult{
+	result = &rpc2.ResetPasswordResult{
 		Success: rpc3.Success{
 			OK: true,
 		},
@@ -1537,7 +1672,9 @@ func (srv *Server) changePasswordStep2(p *rpc2.ChangePasswordParams, ud derived1
 	return result, nil
 }
 
-func (srv *Server) changeEmail(p *rpc2.ChangeEmailParams) (result *rpc2.ChangeEmailResult, re *jrm1.RpcError) {
+// Various actions.
+
+func (srv *Server) changePassword(p *rpc2.ChangePasswordParams) (result *rpc2.ChangePasswordResult, re *jrm1.RpcError) {
 	srv.dbo.LockForWriting()
 	defer srv.dbo.UnlockAfterWriting()
 
@@ -1549,130 +1686,118 @@ func (srv *Server) changeEmail(p *rpc2.ChangeEmailParams) (result *rpc2.ChangeEm
 
 	switch p.StepN {
 	case 1:
-		return srv.changeEmailStep1(p, thisUserData)
+		return srv.changePasswordStep1(p, thisUserData)
 	case 2:
-		return srv.changeEmailStep2(p, thisUserData)
+		return srv.changePasswordStep2(p, thisUserData)
 	default:
 		// Step is not supported.
 		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_StepIsUnknown, RpcErrorMsg_StepIsUnknown, nil)
 	}
 }
 
-func (srv *Server) changeEmailStep1(p *rpc2.ChangeEmailParams, ud derived1.IUserData) (result *rpc2.ChangeEmailResult, re *jrm1.RpcError) {
-	// Is new e-mail address set ?
-	if len(p.NewEmail) == 0 {
-		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_NewEmailIsNotSet, RpcErrorMsg_NewEmailIsNotSet, nil)
+func (srv *Server) changePasswordStep1(p *rpc2.ChangePasswordParams, ud derived1.IUserData) (result *rpc2.ChangePasswordResult, re *jrm1.RpcError) {
+	// Is new password set ?
+	if len(p.NewPassword) == 0 {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_NewPasswordIsNotSet, RpcErrorMsg_NewPasswordIsNotSet, nil)
+	}
+
+	var pc = &am.PasswordChange{
+		UserId:   ud.GetUser().GetUserParameters().GetId(),
+		UserIPAB: p.Auth.UserIPAB,
 	}
 
 	// Check for duplicate request.
-	emailChangesCount, err := srv.dbo.CountEmailChangesByUserId(ud.GetUser().GetUserParameters().GetId())
+	passwordChangesCount, err := srv.dbo.CountPasswordChangesByUserId(ud.GetUser().GetUserParameters().GetId())
 	if err != nil {
 		return nil, srv.databaseError(err)
 	}
 
-	if emailChangesCount > 0 {
-		srv.incidentManager.ReportIncident(ev.NewEnumValue(cm.IncidentType_EmailChangeHacking), ud.GetUser().GetUserParameters().GetEmail(), p.Auth.UserIPAB)
+	if passwordChangesCount > 0 {
+		srv.incidentManager.ReportIncident(ev.NewEnumValue(cm.IncidentType_PasswordChangeHacking), ud.GetUser().GetUserParameters().GetEmail(), p.Auth.UserIPAB)
 
 		err = srv.dbo.UpdateUserLastBadActionTimeById(ud.GetUser().GetUserParameters().GetId())
 		if err != nil {
 			return nil, srv.databaseError(err)
 		}
 
-		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_UserAlreadyStartedToChangeEmail, RpcErrorMsg_UserAlreadyStartedToChangeEmail, nil)
-	}
-
-	var ec = &am.EmailChange{
-		UserId:   ud.GetUser().GetUserParameters().GetId(),
-		UserIPAB: p.Auth.UserIPAB,
-		NewEmail: p.NewEmail,
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_UserAlreadyStartedToChangePassword, RpcErrorMsg_UserAlreadyStartedToChangePassword, nil)
 	}
 
-	// Check the new e-mail address.
-	// Is e-mail address valid ?
-	re = srv.isEmailOfUserValid(ec.NewEmail)
+	// Check the new password.
+	re = isPasswordAllowed(p.NewPassword)
 	if re != nil {
 		return nil, re
 	}
 
-	// Check that the new e-mail address is not used.
-	var usersCount base2.Count
-	usersCount, err = srv.dbo.CountUsersWithEmail(ec.NewEmail)
+	pc.NewPasswordBytes, err = bpp.PackSymbols([]rune(p.NewPassword))
 	if err != nil {
-		return nil, srv.databaseError(err)
+		// This error is very unlikely to happen.
+		// So if it occurs, then it is an anomaly.
+		srv.logError(err)
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_BPP_PackSymbols, fmt.Sprintf(RpcErrorMsgF_BPP_PackSymbols, err.Error()), nil)
 	}
 
-	isAddrFree := usersCount == 0
-	if !isAddrFree {
-		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_EmailAddressIsUsed, RpcErrorMsg_EmailAddressIsUsed, nil)
+	if len(pc.NewPasswordBytes) > int(srv.settings.SystemSettings.UserPasswordMaxLenInBytes) {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_PasswordIsTooLong, RpcErrorMsg_PasswordIsTooLong, nil)
 	}
 
 	// Request ID.
-	ec.RequestId, re = srv.createRequestIdForPasswordChange()
+	pc.RequestId, re = srv.createRequestIdForPasswordChange()
 	if re != nil {
 		return nil, re
 	}
 
 	// Password salt.
-	ec.AuthDataBytes, err = bpp.GenerateRandomSalt()
+	pc.AuthDataBytes, err = bpp.GenerateRandomSalt()
 	if err != nil {
 		srv.logError(err)
 		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_BPP_GenerateRandomSalt, RpcErrorMsg_BPP_GenerateRandomSalt, nil)
 	}
 
-	// Verification code for the old e-mail address.
-	ec.VerificationCodeOld, re = srv.createVerificationCode()
-	if re != nil {
-		return nil, re
-	}
-
-	re = srv.sendVerificationCodeForEmailChange(ud.GetUser().GetUserParameters().GetEmail(), *ec.VerificationCodeOld)
-	if re != nil {
-		return nil, re
-	}
-
-	// Verification code for the new e-mail address.
-	ec.VerificationCodeNew, re = srv.createVerificationCode()
+	// Verification code.
+	pc.VerificationCode, re = srv.createVerificationCode()
 	if re != nil {
 		return nil, re
 	}
 
-	re = srv.sendVerificationCodeForEmailChange(ec.NewEmail, *ec.VerificationCodeNew)
+	re = srv.sendVerificationCodeForPwdChange(ud.GetUser().GetUserParameters().GetEmail(), *pc.VerificationCode)
 	if re != nil {
 		return nil, re
 	}
 
 	// Captcha.
-	ec.IsCaptchaRequired, err = srv.isCaptchaNeededForEmailChange(ud.GetUser().GetUserParameters().GetId())
+	pc.IsCaptchaRequired, err = srv.isCaptchaNeededForPasswordChange(ud.GetUser().GetUserParameters().GetId())
 	if err != nil {
 		return nil, srv.databaseError(err)
 	}
 
 	var captchaData *rm.CreateCaptchaResult
-	if ec.IsCaptchaRequired {
-		captchaData, re = srv.createCaptcha()
+	if pc.IsCaptchaRequired {
+		captchaData, re = srv.createCaptcha(p.CaptchaKind)
 		if re != nil {
 			return nil, re
 		}
 
-		ec.CaptchaId = (*simple.CaptchaId)(&captchaData.TaskId)
+		pc.CaptchaId = (*simple.CaptchaId)(&captchaData.TaskId)
 	}
 
 	// Save the request.
-	err = srv.dbo.CreateEmailChangeRequest(ec)
+	err = srv.dbo.CreatePasswordChangeRequest(pc)
 	if err != nil {
 		return nil, srv.databaseError(err)
 	}
 
 	// Response.
-	result = &rpc2.ChangeEmailResult{
+	result = &rpc2.ChangePasswordResult{
 		NextStep:      2,
-		RequestId:     *ec.RequestId,
-		AuthDataBytes: ec.AuthDataBytes,
+		RequestId:     *pc.RequestId,
+		AuthDataBytes: pc.AuthDataBytes,
 	}
 
-	if ec.IsCaptchaRequired {
+	if pc.IsCaptchaRequired {
 		result.IsCaptchaNeeded = true
-		result.CaptchaId = *ec.CaptchaId
+		result.CaptchaId = *pc.CaptchaId
+		result.Captcha = newCaptchaChallenge(captchaData)
 	} else {
 		result.IsCaptchaNeeded = false
 	}
@@ -1680,7 +1805,7 @@ func (srv *Server) changeEmailStep1(p *rpc2.ChangeEmailParams, ud derived1.IUser
 	return result, nil
 }
 
-func (srv *Server) changeEmailStep2(p *rpc2.ChangeEmailParams, ud derived1.IUserData) (result *rpc2.ChangeEmailResult, re *jrm1.RpcError) {
+func (srv *Server) changePasswordStep2(p *rpc2.ChangePasswordParams, ud derived1.IUserData) (result *rpc2.ChangePasswordResult, re *jrm1.RpcError) {
 	// Check input parameters.
 	if len(p.RequestId) == 0 {
 		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_RequestIdIsNotSet, RpcErrorMsg_RequestIdIsNotSet, nil)
@@ -1688,84 +1813,81 @@ func (srv *Server) changeEmailStep2(p *rpc2.ChangeEmailParams, ud derived1.IUser
 	if len(p.AuthChallengeResponse) == 0 {
 		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_AuthChallengeResponseIsNotSet, RpcErrorMsg_AuthChallengeResponseIsNotSet, nil)
 	}
-	if len(p.VerificationCodeOld) == 0 {
-		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_VerificationCodeIsNotSet, RpcErrorMsg_VerificationCodeIsNotSet, nil)
-	}
-	if len(p.VerificationCodeNew) == 0 {
+	if len(p.VerificationCode) == 0 {
 		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_VerificationCodeIsNotSet, RpcErrorMsg_VerificationCodeIsNotSet, nil)
 	}
 
 	// Check for duplicate request.
-	emailChangesCount, err := srv.dbo.CountEmailChangesByUserId(ud.GetUser().GetUserParameters().GetId())
+	passwordChangesCount, err := srv.dbo.CountPasswordChangesByUserId(ud.GetUser().GetUserParameters().GetId())
 	if err != nil {
 		return nil, srv.databaseError(err)
 	}
 
-	if emailChangesCount != 1 {
-		srv.incidentManager.ReportIncident(ev.NewEnumValue(cm.IncidentType_EmailChangeHacking), ud.GetUser().GetUserParameters().GetEmail(), p.Auth.UserIPAB)
+	if passwordChangesCount != 1 {
+		srv.incidentManager.ReportIncident(ev.NewEnumValue(cm.IncidentType_PasswordChangeHacking), ud.GetUser().GetUserParameters().GetEmail(), p.Auth.UserIPAB)
 
 		err = srv.dbo.UpdateUserLastBadActionTimeById(ud.GetUser().GetUserParameters().GetId())
 		if err != nil {
 			return nil, srv.databaseError(err)
 		}
 
-		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_UserAlreadyStartedToChangeEmail, RpcErrorMsg_UserAlreadyStartedToChangeEmail, nil)
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_UserAlreadyStartedToChangePassword, RpcErrorMsg_UserAlreadyStartedToChangePassword, nil)
 	}
 
-	// Get the request for e-mail address change.
-	var ecr *am.EmailChange
-	ecr, err = srv.dbo.GetEmailChangeByRequestId(p.RequestId)
+	// Get the request for password change.
+	var pcr *am.PasswordChange
+	pcr, err = srv.dbo.GetPasswordChangeByRequestId(p.RequestId)
 	if err != nil {
 		return nil, srv.databaseError(err)
 	}
-	if ecr == nil {
+	if pcr == nil {
 		// Request Id code can not be guessed.
-		srv.incidentManager.ReportIncident(ev.NewEnumValue(cm.IncidentType_EmailChangeHacking), ud.GetUser().GetUserParameters().GetEmail(), p.Auth.UserIPAB)
+		srv.incidentManager.ReportIncident(ev.NewEnumValue(cm.IncidentType_PasswordChangeHacking), ud.GetUser().GetUserParameters().GetEmail(), p.Auth.UserIPAB)
 
 		err = srv.dbo.UpdateUserLastBadActionTimeById(ud.GetUser().GetUserParameters().GetId())
 		if err != nil {
 			return nil, srv.databaseError(err)
 		}
 
-		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_EmailChangeIsNotFound, RpcErrorMsg_EmailChangeIsNotFound, nil)
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_PasswordChangeIsNotFound, RpcErrorMsg_PasswordChangeIsNotFound, nil)
 	}
 
 	// Check the Request ID (indirectly).
-	if (ecr.UserId != ud.GetUser().GetUserParameters().GetId()) || (ecr.RequestId == nil) {
-		srv.incidentManager.ReportIncident(ev.NewEnumValue(cm.IncidentType_EmailChangeHacking), ud.GetUser().GetUserParameters().GetEmail(), p.Auth.UserIPAB)
+	if (pcr.UserId != ud.GetUser().GetUserParameters().GetId()) || (pcr.RequestId == nil) {
+		srv.incidentManager.ReportIncident(ev.NewEnumValue(cm.IncidentType_PasswordChangeHacking), ud.GetUser().GetUserParameters().GetEmail(), p.Auth.UserIPAB)
 
 		err = srv.dbo.UpdateUserLastBadActionTimeById(ud.GetUser().GetUserParameters().GetId())
 		if err != nil {
 			return nil, srv.databaseError(err)
 		}
 
-		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_EmailChangeIsNotFound, RpcErrorMsg_EmailChangeIsNotFound, nil)
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_PasswordChangeIsNotFound, RpcErrorMsg_PasswordChangeIsNotFound, nil)
 	}
 
 	// Check the IP address.
-	if !p.Auth.UserIPAB.Equal(ecr.UserIPAB) {
-		srv.incidentManager.ReportIncident(ev.NewEnumValue(cm.IncidentType_EmailChangeHacking), ud.GetUser().GetUserParameters().GetEmail(), p.Auth.UserIPAB)
+	if !p.Auth.UserIPAB.Equal(pcr.UserIPAB) {
+		srv.incidentManager.ReportIncident(ev.NewEnumValue(cm.IncidentType_PasswordChangeHacking), ud.GetUser().GetUserParameters().GetEmail(), p.Auth.UserIPAB)
 
 		err = srv.dbo.UpdateUserLastBadActionTimeById(ud.GetUser().GetUserParameters().GetId())
 		if err != nil {
 			return nil, srv.databaseError(err)
 		}
 
-		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_EmailChangeIsNotFound, RpcErrorMsg_EmailChangeIsNotFound, nil)
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_PasswordChangeIsNotFound, RpcErrorMsg_PasswordChangeIsNotFound, nil)
 	}
 
 	// Check the captcha answer if needed.
 	var ccr *rm.CheckCaptchaResult
-	if ecr.IsCaptchaRequired {
+	if pcr.IsCaptchaRequired {
 		if len(p.CaptchaAnswer) == 0 {
 			return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_CaptchaAnswerIsNotSet, RpcErrorMsg_CaptchaAnswerIsNotSet, nil)
 		}
-		if ecr.CaptchaId == nil {
+		if pcr.CaptchaId == nil {
 			return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_CaptchaIdIsNotSet, RpcErrorMsg_CaptchaIdIsNotSet, nil)
 		}
 
 		// Check captcha answer.
-		ccr, re = srv.checkCaptcha(*ecr.CaptchaId, p.CaptchaAnswer)
+		ccr, re = srv.checkCaptcha(*pcr.CaptchaId, p.CaptchaAnswer)
 		if re != nil {
 			return nil, re
 		}
@@ -1778,9 +1900,9 @@ func (srv *Server) changeEmailStep2(p *rpc2.ChangeEmailParams, ud derived1.IUser
 				return nil, srv.databaseError(err)
 			}
 
-			// When captcha guess is wrong, we delete the e-mail address change
+			// When captcha guess is wrong, we delete the password change
 			// request to start the process from the first step.
-			err = srv.dbo.DeleteEmailChangeByRequestId(*ecr.RequestId)
+			err = srv.dbo.DeletePasswordChangeByRequestId(*pcr.RequestId)
 			if err != nil {
 				return nil, srv.databaseError(err)
 			}
@@ -1791,7 +1913,7 @@ func (srv *Server) changeEmailStep2(p *rpc2.ChangeEmailParams, ud derived1.IUser
 
 	// Check the password.
 	var ok bool
-	ok, re = srv.checkPassword(ud.GetUser().GetUserParameters().GetId(), ecr.AuthDataBytes, p.AuthChallengeResponse)
+	ok, re = srv.checkPassword(ud.GetUser().GetUserParameters().GetId(), pcr.AuthDataBytes, p.AuthChallengeResponse)
 	if re != nil {
 		return nil, re
 	}
@@ -1806,7 +1928,7 @@ func (srv *Server) changeEmailStep2(p *rpc2.ChangeEmailParams, ud derived1.IUser
 
 		// When password is wrong, we delete the password change request to
 		// start the process from the first step.
-		err = srv.dbo.DeleteEmailChangeByRequestId(*ecr.RequestId)
+		err = srv.dbo.DeletePasswordChangeByRequestId(*pcr.RequestId)
 		if err != nil {
 			return nil, srv.databaseError(err)
 		}
@@ -1814,14 +1936,14 @@ func (srv *Server) changeEmailStep2(p *rpc2.ChangeEmailParams, ud derived1.IUser
 		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_PasswordIsWrong, RpcErrorMsg_PasswordIsWrong, nil)
 	}
 
-	// Check the verification codes.
-	ok, err = srv.dbo.CheckVerificationCodesForEmailChange(p.RequestId, p.VerificationCodeOld, p.VerificationCodeNew)
+	// Check the verification code.
+	ok, err = srv.dbo.CheckVerificationCodeForPwdChange(p.RequestId, p.VerificationCode)
 	if err != nil {
 		return nil, srv.databaseError(err)
 	}
 
 	if !ok {
-		// Verification codes can not be guessed.
+		// Verification code can not be guessed.
 		srv.incidentManager.ReportIncident(ev.NewEnumValue(cm.IncidentType_VerificationCodeMismatch), ud.GetUser().GetUserParameters().GetEmail(), p.Auth.UserIPAB)
 
 		err = srv.dbo.UpdateUserLastBadActionTimeById(ud.GetUser().GetUserParameters().GetId())
@@ -1829,9 +1951,9 @@ func (srv *Server) changeEmailStep2(p *rpc2.ChangeEmailParams, ud derived1.IUser
 			return nil, srv.databaseError(err)
 		}
 
-		// Delete the e-mail address change request on error to avoid brute
-		// force checks.
-		err = srv.dbo.DeleteEmailChangeByRequestId(*ecr.RequestId)
+		// Delete the password change request on error to avoid brute force
+		// checks.
+		err = srv.dbo.DeletePasswordChangeByRequestId(*pcr.RequestId)
 		if err != nil {
 			return nil, srv.databaseError(err)
 		}
@@ -1840,23 +1962,22 @@ func (srv *Server) changeEmailStep2(p *rpc2.ChangeEmailParams, ud derived1.IUser
 	}
 
 	// Update request's flags.
-	ecvf := &am.EmailChangeVerificationFlags{
+	pcvf := &am.PasswordChangeVerificationFlags{
 		IsVerifiedByPassword: true,
-		IsVerifiedByOldEmail: true,
-		IsVerifiedByNewEmail: true,
+		IsVerifiedByEmail:    true,
 	}
-	if ecr.IsCaptchaRequired {
+	if pcr.IsCaptchaRequired {
 		x := true
-		ecvf.IsVerifiedByCaptcha = (*base2.Flag)(&x)
+		pcvf.IsVerifiedByCaptcha = (*base2.Flag)(&x)
 	}
 
-	err = srv.dbo.SetEmailChangeVFlags(ud.GetUser().GetUserParameters().GetId(), *ecr.RequestId, ecvf)
+	err = srv.dbo.SetPasswordChangeVFlags(ud.GetUser().GetUserParameters().GetId(), *pcr.RequestId, pcvf)
 	if err != nil {
 		return nil, srv.databaseError(err)
 	}
 
-	// Change the e-mail address.
-	err = srv.dbo.SetUserEmail(ud.GetUser().GetUserParameters().GetId(), ud.GetUser().GetUserParameters().GetEmail(), ecr.NewEmail)
+	// Change the password.
+	err = srv.dbo.SetUserPassword(ud.GetUser().GetUserParameters().GetId(), ud.GetUser().GetUserParameters().GetEmail(), pcr.NewPasswordBytes)
 	if err != nil {
 		return nil, srv.databaseError(err)
 	}
@@ -1867,12 +1988,12 @@ func (srv *Server) changeEmailStep2(p *rpc2.ChangeEmailParams, ud derived1.IUser
 		return nil, srv.databaseError(err)
 	}
 
-	// N.B.: We do not immediately delete the request for e-mail address change
-	// here to avoid spamming with these requests. It will be deleted later by
-	// the scheduler.
+	// N.B.: We do not immediately delete the request for password change here
+	// to avoid spamming with these requests. It will be deleted later by the
+	// scheduler.
 
 	// Response.
-	result = &rpc2.ChangeEmailResult{
+	result = &rpc2.ChangePasswordResult{
 		Success: rpc3.Success{
 			OK: true,
 		},
@@ -1881,136 +2002,955 @@ func (srv *Server) changeEmailStep2(p *rpc2.ChangeEmailParams, ud derived1.IUser
 	return result, nil
 }
 
-func (srv *Server) getUserSession(p *rpc2.GetUserSessionParams) (result *rpc2.GetUserSessionResult, re *jrm1.RpcError) {
-	// Check parameters.
-	if p.UserId == 0 {
-		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_UserIdIsNotSet, RpcErrorMsg_UserIdIsNotSet, nil)
-	}
-
-	srv.dbo.LockForReading()
-	defer srv.dbo.UnlockAfterReading()
+func (srv *Server) changeEmail(p *rpc2.ChangeEmailParams) (result *rpc2.ChangeEmailResult, re *jrm1.RpcError) {
+	srv.dbo.LockForWriting()
+	defer srv.dbo.UnlockAfterWriting()
 
-	var callerData derived1.IUserData
-	callerData, re = srv.mustBeAnAuthToken(p.Auth)
+	var thisUserData derived1.IUserData
+	thisUserData, re = srv.mustBeAnAuthToken(p.Auth)
 	if re != nil {
 		return nil, re
 	}
 
-	// Check permissions.
-	if !callerData.GetUser().GetUserParameters().GetRoles().IsAdministrator {
-		srv.incidentManager.ReportIncident(ev.NewEnumValue(cm.IncidentType_IllegalAccessAttempt), "", p.Auth.UserIPAB)
-		return nil, jrm1.NewRpcErrorByUser(c.RpcErrorCode_Permission, c.RpcErrorMsg_Permission, nil)
+	switch p.StepN {
+	case 1:
+		return srv.changeEmailStep1(p, thisUserData)
+	case 2:
+		return srv.changeEmailStep2(p, thisUserData)
+	default:
+		// Step is not supported.
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_StepIsUnknown, RpcErrorMsg_StepIsUnknown, nil)
 	}
+}
 
-	var err error
-	var session *am.Session
-	session, err = srv.dbo.GetSessionByUserId(p.UserId)
+func (srv *Server) changeEmailStep1(p *rpc2.ChangeEmailParams, ud derived1.IUserData) (result *rpc2.ChangeEmailResult, re *jrm1.RpcError) {
+	// Is new e-mail address set ?
+	if len(p.NewEmail) == 0 {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_NewEmailIsNotSet, RpcErrorMsg_NewEmailIsNotSet, nil)
+	}
+
+	// Check for duplicate request.
+	emailChangesCount, err := srv.dbo.CountEmailChangesByUserId(ud.GetUser().GetUserParameters().GetId())
 	if err != nil {
 		return nil, srv.databaseError(err)
 	}
 
-	if session == nil {
-		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_SessionIsNotFound, RpcErrorMsg_SessionIsNotFound, nil)
-	}
+	if emailChangesCount > 0 {
+		srv.incidentManager.ReportIncident(ev.NewEnumValue(cm.IncidentType_EmailChangeHacking), ud.GetUser().GetUserParameters().GetEmail(), p.Auth.UserIPAB)
 
-	user := u.NewUser()
-	userParams := user.GetUserParameters()
-	userParams.SetId(p.UserId)
-	user.SetUserParameters(userParams)
+		err = srv.dbo.UpdateUserLastBadActionTimeById(ud.GetUser().GetUserParameters().GetId())
+		if err != nil {
+			return nil, srv.databaseError(err)
+		}
 
-	result = &rpc2.GetUserSessionResult{
-		User:    user,
-		Session: session,
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_UserAlreadyStartedToChangeEmail, RpcErrorMsg_UserAlreadyStartedToChangeEmail, nil)
 	}
 
-	return result, nil
-}
-
-// User properties.
-
-func (srv *Server) getUserName(p *rpc2.GetUserNameParams) (result *rpc2.GetUserNameResult, re *jrm1.RpcError) {
-	if p.UserId == 0 {
-		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_UserIdIsNotSet, RpcErrorMsg_UserIdIsNotSet, nil)
+	var ec = &am.EmailChange{
+		UserId:   ud.GetUser().GetUserParameters().GetId(),
+		UserIPAB: p.Auth.UserIPAB,
+		NewEmail: p.NewEmail,
 	}
 
-	srv.dbo.LockForReading()
-	defer srv.dbo.UnlockAfterReading()
-
-	_, re = srv.mustBeAnAuthToken(p.Auth)
+	// Check the new e-mail address.
+	// Is e-mail address valid ?
+	re = srv.isEmailOfUserValid(ec.NewEmail)
 	if re != nil {
 		return nil, re
 	}
 
-	var err error
-	var userName *simple.Name
-	userName, err = srv.dbo.GetUserNameById(p.UserId)
+	// Check that the new e-mail address is not used.
+	var usersCount base2.Count
+	usersCount, err = srv.dbo.CountUsersWithEmail(ec.NewEmail)
 	if err != nil {
 		return nil, srv.databaseError(err)
 	}
-	if userName == nil {
-		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_UserNameIsNotFound, RpcErrorMsg_UserNameIsNotFound, p.UserId)
+
+	isAddrFree := usersCount == 0
+	if !isAddrFree {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_EmailAddressIsUsed, RpcErrorMsg_EmailAddressIsUsed, nil)
 	}
 
-	user := u.NewUser()
-	userParams := user.GetUserParameters()
-	userParams.SetId(p.UserId)
-	userParams.SetName(*userName)
-	user.SetUserParameters(userParams)
+	// Request ID.
+	ec.RequestId, re = srv.createRequestIdForPasswordChange()
+	if re != nil {
+		return nil, re
+	}
 
-	result = &rpc2.GetUserNameResult{
-		User: user,
+	// Password salt.
+	ec.AuthDataBytes, err = bpp.GenerateRandomSalt()
+	if err != nil {
+		srv.logError(err)
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_BPP_GenerateRandomSalt, RpcErrorMsg_BPP_GenerateRandomSalt, nil)
 	}
 
-	return result, nil
-}
+	// Verification code for the old e-mail address.
+	ec.VerificationCodeOld, re = srv.createVerificationCode()
+	if re != nil {
+		return nil, re
+	}
 
-func (srv *Server) getUserRoles(p *rpc2.GetUserRolesParams) (result *rpc2.GetUserRolesResult, re *jrm1.RpcError) {
-	if p.UserId == 0 {
-		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_UserIdIsNotSet, RpcErrorMsg_UserIdIsNotSet, nil)
+	re = srv.sendVerificationCodeForEmailChange(ud.GetUser().GetUserParameters().GetEmail(), *ec.VerificationCodeOld)
+	if re != nil {
+		return nil, re
 	}
 
-	srv.dbo.LockForReading()
-	defer srv.dbo.UnlockAfterReading()
+	// Verification code for the new e-mail address.
+	ec.VerificationCodeNew, re = srv.createVerificationCode()
+	if re != nil {
+		return nil, re
+	}
 
-	_, re = srv.mustBeAnAuthToken(p.Auth)
+	re = srv.sendVerificationCodeForEmailChange(ec.NewEmail, *ec.VerificationCodeNew)
 	if re != nil {
 		return nil, re
 	}
 
-	var err error
-	var roles *ur.UserRoles
-	roles, err = srv.dbo.GetUserRolesById(p.UserId)
+	// Captcha.
+	ec.IsCaptchaRequired, err = srv.isCaptchaNeededForEmailChange(ud.GetUser().GetUserParameters().GetId())
 	if err != nil {
 		return nil, srv.databaseError(err)
 	}
-	if roles == nil {
-		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_UserIsNotFound, RpcErrorMsg_UserIsNotFound, nil)
+
+	var captchaData *rm.CreateCaptchaResult
+	if ec.IsCaptchaRequired {
+		captchaData, re = srv.createCaptcha(p.CaptchaKind)
+		if re != nil {
+			return nil, re
+		}
+
+		ec.CaptchaId = (*simple.CaptchaId)(&captchaData.TaskId)
+	}
+
+	// Save the request.
+	err = srv.dbo.CreateEmailChangeRequest(ec)
+	if err != nil {
+		return nil, srv.databaseError(err)
 	}
 
-	roles.IsAdministrator = srv.isUserAdministrator(p.UserId)
-	roles.IsModerator = srv.isUserModerator(p.UserId)
+	// Response.
+	result = &rpc2.ChangeEmailResult{
+		NextStep:      2,
+		RequestId:     *ec.RequestId,
+		AuthDataBytes: ec.AuthDataBytes,
+	}
 
-	user := u.NewUser()
-	userParams := user.GetUserParameters()
-	userParams.SetId(p.UserId)
-	userParams.SetRoles(roles)
-	user.SetUserParameters(userParams)
+	if ec.IsCaptchaRequired {
+		result.IsCaptchaNeeded = true
+		result.CaptchaId = *ec.CaptchaId
+		result.Captcha = newCaptchaChallenge(captchaData)
+	} else {
+		result.IsCaptchaNeeded = false
+	}
 
-	result = &rpc2.GetUserRolesResult{
-		User: user,
+	return result, nil
+}
+
+func (srv *Server) changeEmailStep2(p *rpc2.ChangeEmailParams, ud derived1.IUserData) (result *rpc2.ChangeEmailResult, re *jrm1.RpcError) {
+	// Check input parameters.
+	if len(p.RequestId) == 0 {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_RequestIdIsNotSet, RpcErrorMsg_RequestIdIsNotSet, nil)
 	}
+	if len(p.AuthChallengeResponse) == 0 {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_AuthChallengeResponseIsNotSet, RpcErrorMsg_AuthChallengeResponseIsNotSet, nil)
+	}
+	if len(p.VerificationCodeOld) == 0 {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_VerificationCodeIsNotSet, RpcErrorMsg_VerificationCodeIsNotSet, nil)
+	}
+	if len(p.VerificationCodeNew) == 0 {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_VerificationCodeIsNotSet, RpcErrorMsg_VerificationCodeIsNotSet, nil)
+	}
+
+	// Check for duplicate request.
+	emailChangesCount, err := srv.dbo.CountEmailChangesByUserId(ud.GetUser().GetUserParameters().GetId())
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+
+	if emailChangesCount != 1 {
+		srv.incidentManager.ReportIncident(ev.NewEnumValue(cm.IncidentType_EmailChangeHacking), ud.GetUser().GetUserParameters().GetEmail(), p.Auth.UserIPAB)
+
+		err = srv.dbo.UpdateUserLastBadActionTimeById(ud.GetUser().GetUserParameters().GetId())
+		if err != nil {
+			return nil, srv.databaseError(err)
+		}
+
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_UserAlreadyStartedToChangeEmail, RpcErrorMsg_UserAlreadyStartedToChangeEmail, nil)
+	}
+
+	// Get the request for e-mail address change.
+	var ecr *am.EmailChange
+	ecr, err = srv.dbo.GetEmailChangeByRequestId(p.RequestId)
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+	if ecr == nil {
+		// Request Id code can not be guessed.
+		srv.incidentManager.ReportIncident(ev.NewEnumValue(cm.IncidentType_EmailChangeHacking), ud.GetUser().GetUserParameters().GetEmail(), p.Auth.UserIPAB)
+
+		err = srv.dbo.UpdateUserLastBadActionTimeById(ud.GetUser().GetUserParameters().GetId())
+		if err != nil {
+			return nil, srv.databaseError(err)
+		}
+
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_EmailChangeIsNotFound, RpcErrorMsg_EmailChangeIsNotFound, nil)
+	}
+
+	// Check the Request ID (indirectly).
+	if (ecr.UserId != ud.GetUser().GetUserParameters().GetId()) || (ecr.RequestId == nil) {
+		srv.incidentManager.ReportIncident(ev.NewEnumValue(cm.IncidentType_EmailChangeHacking), ud.GetUser().GetUserParameters().GetEmail(), p.Auth.UserIPAB)
+
+		err = srv.dbo.UpdateUserLastBadActionTimeById(ud.GetUser().GetUserParameters().GetId())
+		if err != nil {
+			return nil, srv.databaseError(err)
+		}
+
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_EmailChangeIsNotFound, RpcErrorMsg_EmailChangeIsNotFound, nil)
+	}
+
+	// Check the IP address.
+	if !p.Auth.UserIPAB.Equal(ecr.UserIPAB) {
+		srv.incidentManager.ReportIncident(ev.NewEnumValue(cm.IncidentType_EmailChangeHacking), ud.GetUser().GetUserParameters().GetEmail(), p.Auth.UserIPAB)
+
+		err = srv.dbo.UpdateUserLastBadActionTimeById(ud.GetUser().GetUserParameters().GetId())
+		if err != nil {
+			return nil, srv.databaseError(err)
+		}
+
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_EmailChangeIsNotFound, RpcErrorMsg_EmailChangeIsNotFound, nil)
+	}
+
+	// Check the captcha answer if needed.
+	var ccr *rm.CheckCaptchaResult
+	if ecr.IsCaptchaRequired {
+		if len(p.CaptchaAnswer) == 0 {
+			return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_CaptchaAnswerIsNotSet, RpcErrorMsg_CaptchaAnswerIsNotSet, nil)
+		}
+		if ecr.CaptchaId == nil {
+			return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_CaptchaIdIsNotSet, RpcErrorMsg_CaptchaIdIsNotSet, nil)
+		}
+
+		// Check captcha answer.
+		ccr, re = srv.checkCaptcha(*ecr.CaptchaId, p.CaptchaAnswer)
+		if re != nil {
+			return nil, re
+		}
+
+		if !ccr.IsSuccess {
+			srv.incidentManager.ReportIncident(ev.NewEnumValue(cm.IncidentType_CaptchaAnswerMismatch), ud.GetUser().GetUserParameters().GetEmail(), p.Auth.UserIPAB)
+
+			err = srv.dbo.UpdateUserLastBadActionTimeById(ud.GetUser().GetUserParameters().GetId())
+			if err != nil {
+				return nil, srv.databaseError(err)
+			}
+
+			// When captcha guess is wrong, we delete the e-mail address change
+			// request to start the process from the first step.
+			err = srv.dbo.DeleteEmailChangeByRequestId(*ecr.RequestId)
+			if err != nil {
+				return nil, srv.databaseError(err)
+			}
+
+			return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_CaptchaAnswerIsWrong, RpcErrorMsg_CaptchaAnswerIsWrong, nil)
+		}
+	}
+
+	// Check the password.
+	var ok bool
+	ok, re = srv.checkPassword(ud.GetUser().GetUserParameters().GetId(), ecr.AuthDataBytes, p.AuthChallengeResponse)
+	if re != nil {
+		return nil, re
+	}
+
+	if !ok {
+		srv.incidentManager.ReportIncident(ev.NewEnumValue(cm.IncidentType_PasswordMismatch), ud.GetUser().GetUserParameters().GetEmail(), p.Auth.UserIPAB)
+
+		err = srv.dbo.UpdateUserLastBadActionTimeById(ud.GetUser().GetUserParameters().GetId())
+		if err != nil {
+			return nil, srv.databaseError(err)
+		}
+
+		// When password is wrong, we delete the password change request to
+		// start the process from the first step.
+		err = srv.dbo.DeleteEmailChangeByRequestId(*ecr.RequestId)
+		if err != nil {
+			return nil, srv.databaseError(err)
+		}
+
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_PasswordIsWrong, RpcErrorMsg_PasswordIsWrong, nil)
+	}
+
+	// Check the verification codes.
+	ok, err = srv.dbo.CheckVerificationCodesForEmailChange(p.RequestId, p.VerificationCodeOld, p.VerificationCodeNew)
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+
+	if !ok {
+		// Verification codes can not be guessed.
+		srv.incidentManager.ReportIncident(ev.NewEnumValue(cm.IncidentType_VerificationCodeMismatch), ud.GetUser().GetUserParameters().GetEmail(), p.Auth.UserIPAB)
+
+		err = srv.dbo.UpdateUserLastBadActionTimeById(ud.GetUser().GetUserParameters().GetId())
+		if err != nil {
+			return nil, srv.databaseError(err)
+		}
+
+		// Delete the e-mail address change request on error to avoid brute
+		// force checks.
+		err = srv.dbo.DeleteEmailChangeByRequestId(*ecr.RequestId)
+		if err != nil {
+			return nil, srv.databaseError(err)
+		}
+
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_VerificationCodeIsWrong, RpcErrorMsg_VerificationCodeIsWrong, nil)
+	}
+
+	// Update request's flags.
+	ecvf := &am.EmailChangeVerificationFlags{
+		IsVerifiedByPassword: true,
+		IsVerifiedByOldEmail: true,
+		IsVerifiedByNewEmail: true,
+	}
+	if ecr.IsCaptchaRequired {
+		x := true
+		ecvf.IsVerifiedByCaptcha = (*base2.Flag)(&x)
+	}
+
+	err = srv.dbo.SetEmailChangeVFlags(ud.GetUser().GetUserParameters().GetId(), *ecr.RequestId, ecvf)
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+
+	// Change the e-mail address.
+	err = srv.dbo.SetUserEmail(ud.GetUser().GetUserParameters().GetId(), ud.GetUser().GetUserParameters().GetEmail(), ecr.NewEmail)
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+
+	// Close the session to force re-logging.
+	err = srv.dbo.DeleteSession(ud.GetSession().Id, ud.GetUser().GetUserParameters().GetId(), p.Auth.UserIPAB)
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+
+	// N.B.: We do not immediately delete the request for e-mail address change
+	// here to avoid spamming with these requests. It will be deleted later by
+	// the scheduler.
+
+	// Response.
+	result = &rpc2.ChangeEmailResult{
+		Success: rpc3.Success{
+			OK: true,
+		},
+		NextStep: 0,
+	}
+	return result, nil
+}
+
+func (srv *Server) getUserSession(p *rpc2.GetUserSessionParams) (result *rpc2.GetUserSessionResult, re *jrm1.RpcError) {
+	// Check parameters.
+	if p.UserId == 0 {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_UserIdIsNotSet, RpcErrorMsg_UserIdIsNotSet, nil)
+	}
+
+	srv.dbo.LockForReading()
+	defer srv.dbo.UnlockAfterReading()
+
+	var callerData derived1.IUserData
+	callerData, re = srv.mustBeAnAuthToken(p.Auth)
+	if re != nil {
+		return nil, re
+	}
+
+	// Check permissions.
+	if !callerData.GetUser().GetUserParameters().GetRoles().IsAdministrator {
+		srv.incidentManager.ReportIncident(ev.NewEnumValue(cm.IncidentType_IllegalAccessAttempt), "", p.Auth.UserIPAB)
+		return nil, jrm1.NewRpcErrorByUser(c.RpcErrorCode_Permission, c.RpcErrorMsg_Permission, nil)
+	}
+
+	var err error
+	var session *am.Session
+	session, err = srv.dbo.GetSessionByUserId(p.UserId)
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+
+	if session == nil {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_SessionIsNotFound, RpcErrorMsg_SessionIsNotFound, nil)
+	}
+
+	user := u.NewUser()
+	userParams := user.GetUserParameters()
+	userParams.SetId(p.UserId)
+	user.SetUserParameters(userParams)
+
+	result = &rpc2.GetUserSessionResult{
+		User:    user,
+		Session: session,
+	}
+
+	return result, nil
+}
+
+// User properties.
+
+func (srv *Server) getUserName(p *rpc2.GetUserNameParams) (result *rpc2.GetUserNameResult, re *jrm1.RpcError) {
+	if p.UserId == 0 {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_UserIdIsNotSet, RpcErrorMsg_UserIdIsNotSet, nil)
+	}
+
+	srv.dbo.LockForReading()
+	defer srv.dbo.UnlockAfterReading()
+
+	_, re = srv.mustBeAnAuthToken(p.Auth)
+	if re != nil {
+		return nil, re
+	}
+
+	var err error
+	var userName *simple.Name
+	userName, err = srv.dbo.GetUserNameById(p.UserId)
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+	if userName == nil {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_UserNameIsNotFound, RpcErrorMsg_UserNameIsNotFound, p.UserId)
+	}
+
+	user := u.NewUser()
+	userParams := user.GetUserParameters()
+	userParams.SetId(p.UserId)
+	userParams.SetName(*userName)
+	user.SetUserParameters(userParams)
+
+	result = &rpc2.GetUserNameResult{
+		User: user,
+	}
+
+	return result, nil
+}
+
+func (srv *Server) getUserIdsByNames(p *rpc2.GetUserIdsByNamesParams) (result *rpc2.GetUserIdsByNamesResult, re *jrm1.RpcError) {
+	if len(p.UserNames) == 0 {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_UserNamesAreNotSet, RpcErrorMsg_UserNamesAreNotSet, nil)
+	}
+
+	if len(p.UserNames) > UserNamesPerRequestMax {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_TooManyUserNames, RpcErrorMsg_TooManyUserNames, nil)
+	}
+
+	srv.dbo.LockForReading()
+	defer srv.dbo.UnlockAfterReading()
+
+	_, re = srv.mustBeAnAuthToken(p.Auth)
+	if re != nil {
+		return nil, re
+	}
+
+	result = &rpc2.GetUserIdsByNamesResult{
+		UserIds: make(map[simple.Name]base2.Id),
+	}
+
+	var err error
+	var userId *base2.Id
+	for _, userName := range p.UserNames {
+		if _, isKnown := result.UserIds[userName]; isKnown {
+			continue
+		}
+
+		userId, err = srv.dbo.GetUserIdByName(userName)
+		if err != nil {
+			return nil, srv.databaseError(err)
+		}
+		if userId == nil {
+			continue
+		}
+
+		result.UserIds[userName] = *userId
+	}
+
+	return result, nil
+}
+
+// getUserEmailS returns an e-mail address of a user. This function is used by
+// other modules to send e-mail messages to users.
+func (srv *Server) getUserEmailS(p *rpc2.GetUserEmailSParams) (result *rpc2.GetUserEmailSResult, re *jrm1.RpcError) {
+	if p.UserId == 0 {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_UserIdIsNotSet, RpcErrorMsg_UserIdIsNotSet, nil)
+	}
+
+	re = srv.mustBeNoAuth(p.Auth)
+	if re != nil {
+		return nil, re
+	}
+
+	// Check the DKey.
+	if !srv.dKeyI.CheckString(p.DKey.ToString()) {
+		return nil, jrm1.NewRpcErrorByUser(c.RpcErrorCode_Permission, c.RpcErrorMsg_Permission, nil)
+	}
+
+	srv.dbo.LockForReading()
+	defer srv.dbo.UnlockAfterReading()
+
+	user, err := srv.dbo.GetUserById(p.UserId)
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+	if user == nil {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_UserIsNotFound, RpcErrorMsg_UserIsNotFound, nil)
+	}
+
+	result = &rpc2.GetUserEmailSResult{
+		UserId: p.UserId,
+		Email:  user.GetUserParameters().GetEmail(),
+	}
+
+	return result, nil
+}
+
+func (srv *Server) getUserRoles(p *rpc2.GetUserRolesParams) (result *rpc2.GetUserRolesResult, re *jrm1.RpcError) {
+	if p.UserId == 0 {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_UserIdIsNotSet, RpcErrorMsg_UserIdIsNotSet, nil)
+	}
+
+	srv.dbo.LockForReading()
+	defer srv.dbo.UnlockAfterReading()
+
+	_, re = srv.mustBeAnAuthToken(p.Auth)
+	if re != nil {
+		return nil, re
+	}
+
+	var err error
+	var roles *ur.UserRoles
+	roles, err = srv.dbo.GetUserRolesById(p.UserId)
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+	if roles == nil {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_UserIsNotFound, RpcErrorMsg_UserIsNotFound, nil)
+	}
+
+	err = srv.attachUserRoles(p.UserId, roles)
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+
+	user := u.NewUser()
+	userParams := user.GetUserParameters()
+	userParams.SetId(p.UserId)
+	userParams.SetRoles(roles)
+	user.SetUserParameters(userParams)
+
+	result = &rpc2.GetUserRolesResult{
+		User: user,
+	}
+
+	return result, nil
+}
+
+func (srv *Server) viewUserParameters(p *rpc2.ViewUserParametersParams) (result *rpc2.ViewUserParametersResult, re *jrm1.RpcError) {
+	// Check parameters.
+	if p.UserId == 0 {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_UserIdIsNotSet, RpcErrorMsg_UserIdIsNotSet, nil)
+	}
+
+	srv.dbo.LockForReading()
+	defer srv.dbo.UnlockAfterReading()
+
+	var thisUserData derived1.IUserData
+	thisUserData, re = srv.mustBeAnAuthToken(p.Auth)
+	if re != nil {
+		return nil, re
+	}
+
+	// Check permissions.
+	if !thisUserData.GetUser().GetUserParameters().GetRoles().IsAdministrator {
+		srv.incidentManager.ReportIncident(ev.NewEnumValue(cm.IncidentType_IllegalAccessAttempt), "", p.Auth.UserIPAB)
+		return nil, jrm1.NewRpcErrorByUser(c.RpcErrorCode_Permission, c.RpcErrorMsg_Permission, nil)
+	}
+
+	var err error
+	var userParameters base22.IUserParameters
+	userParameters, err = srv.dbo.ViewUserParametersById(p.UserId)
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+	if userParameters == nil {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_UserIsNotFound, RpcErrorMsg_UserIsNotFound, nil)
+	}
+
+	roles := userParameters.GetRoles()
+	err = srv.attachUserRoles(p.UserId, roles)
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+	userParameters.SetRoles(roles)
+
+	user := u.NewUser()
+	user.SetUserParameters(userParameters)
+
+	result = &rpc2.ViewUserParametersResult{
+		User: user,
+	}
+
+	return result, nil
+}
+
+func (srv *Server) setUserRoleAuthor(p *rpc2.SetUserRoleAuthorParams) (result *rpc2.SetUserRoleAuthorResult, re *jrm1.RpcError) {
+	// Check parameters.
+	if p.UserId == 0 {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_UserIdIsNotSet, RpcErrorMsg_UserIdIsNotSet, nil)
+	}
+
+	srv.dbo.LockForWriting()
+	defer srv.dbo.UnlockAfterWriting()
+
+	var thisUserData derived1.IUserData
+	thisUserData, re = srv.mustBeAnAuthToken(p.Auth)
+	if re != nil {
+		return nil, re
+	}
+
+	// Check permissions.
+	if !thisUserData.GetUser().GetUserParameters().GetRoles().IsAdministrator {
+		srv.incidentManager.ReportIncident(ev.NewEnumValue(cm.IncidentType_IllegalAccessAttempt), "", p.Auth.UserIPAB)
+		return nil, jrm1.NewRpcErrorByUser(c.RpcErrorCode_Permission, c.RpcErrorMsg_Permission, nil)
+	}
+
+	// Roles are global permissions now.
+	var wasGranted base2.Flag
+	wasGranted, re = srv.setPermission(&perm.Grant{
+		UserId:        p.UserId,
+		Permission:    perm.Permission_CreateThread,
+		ScopeType:     perm.ScopeType_Global,
+		GrantorUserId: thisUserData.GetUser().GetUserParameters().GetId(),
+	}, p.IsRoleEnabled.AsBool())
+	if re != nil {
+		return nil, re
+	}
+
+	srv.auditA(thisUserData, p.Auth, al.Action_SetUserRoleAuthor, al.TargetType_User, p.UserId, al.FlagValue(wasGranted), al.FlagValue(p.IsRoleEnabled))
+
+	result = &rpc2.SetUserRoleAuthorResult{
+		Success: rpc3.Success{
+			OK: true,
+		},
+	}
+	return result, nil
+}
+
+func (srv *Server) setUserRoleWriter(p *rpc2.SetUserRoleWriterParams) (result *rpc2.SetUserRoleWriterResult, re *jrm1.RpcError) {
+	// Check parameters.
+	if p.UserId == 0 {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_UserIdIsNotSet, RpcErrorMsg_UserIdIsNotSet, nil)
+	}
+
+	srv.dbo.LockForWriting()
+	defer srv.dbo.UnlockAfterWriting()
+
+	var thisUserData derived1.IUserData
+	thisUserData, re = srv.mustBeAnAuthToken(p.Auth)
+	if re != nil {
+		return nil, re
+	}
+
+	// Check permissions.
+	if !thisUserData.GetUser().GetUserParameters().GetRoles().IsAdministrator {
+		srv.incidentManager.ReportIncident(ev.NewEnumValue(cm.IncidentType_IllegalAccessAttempt), "", p.Auth.UserIPAB)
+		return nil, jrm1.NewRpcErrorByUser(c.RpcErrorCode_Permission, c.RpcErrorMsg_Permission, nil)
+	}
+
+	// Roles are global permissions now.
+	var wasGranted base2.Flag
+	wasGranted, re = srv.setPermission(&perm.Grant{
+		UserId:        p.UserId,
+		Permission:    perm.Permission_Write,
+		ScopeType:     perm.ScopeType_Global,
+		GrantorUserId: thisUserData.GetUser().GetUserParameters().GetId(),
+	}, p.IsRoleEnabled.AsBool())
+	if re != nil {
+		return nil, re
+	}
+
+	srv.auditA(thisUserData, p.Auth, al.Action_SetUserRoleWriter, al.TargetType_User, p.UserId, al.FlagValue(wasGranted), al.FlagValue(p.IsRoleEnabled))
+
+	result = &rpc2.SetUserRoleWriterResult{
+		Success: rpc3.Success{
+			OK: true,
+		},
+	}
+	return result, nil
+}
+
+func (srv *Server) setUserRoleReader(p *rpc2.SetUserRoleReaderParams) (result *rpc2.SetUserRoleReaderResult, re *jrm1.RpcError) {
+	// Check parameters.
+	if p.UserId == 0 {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_UserIdIsNotSet, RpcErrorMsg_UserIdIsNotSet, nil)
+	}
+
+	srv.dbo.LockForWriting()
+	defer srv.dbo.UnlockAfterWriting()
+
+	var thisUserData derived1.IUserData
+	thisUserData, re = srv.mustBeAnAuthToken(p.Auth)
+	if re != nil {
+		return nil, re
+	}
+
+	// Check permissions.
+	if !thisUserData.GetUser().GetUserParameters().GetRoles().IsAdministrator {
+		srv.incidentManager.ReportIncident(ev.NewEnumValue(cm.IncidentType_IllegalAccessAttempt), "", p.Auth.UserIPAB)
+		return nil, jrm1.NewRpcErrorByUser(c.RpcErrorCode_Permission, c.RpcErrorMsg_Permission, nil)
+	}
+
+	// Roles are global permissions now.
+	var wasGranted base2.Flag
+	wasGranted, re = srv.setPermission(&perm.Grant{
+		UserId:        p.UserId,
+		Permission:    perm.Permission_Read,
+		ScopeType:     perm.ScopeType_Global,
+		GrantorUserId: thisUserData.GetUser().GetUserParameters().GetId(),
+	}, p.IsRoleEnabled.AsBool())
+	if re != nil {
+		return nil, re
+	}
+
+	srv.auditA(thisUserData, p.Auth, al.Action_SetUserRoleReader, al.TargetType_User, p.UserId, al.FlagValue(wasGranted), al.FlagValue(p.IsRoleEnabled))
+
+	result = &rpc2.SetUserRoleReaderResult{
+		Success: rpc3.Success{
+			OK: true,
+		},
+	}
+	return result, nil
+}
+
+func (srv *Server) getSelfRoles(p *rpc2.GetSelfRolesParams) (result *rpc2.GetSelfRolesResult, re *jrm1.RpcError) {
+	srv.dbo.LockForReading()
+	defer srv.dbo.UnlockAfterReading()
+
+	var thisUserData derived1.IUserData
+	thisUserData, re = srv.mustBeAnAuthToken(p.Auth)
+	if re != nil {
+		return nil, re
+	}
+
+	user := u.NewUser()
+	user.SetUserParameters(thisUserData.GetUser().GetUserParameters())
+
+	result = &rpc2.GetSelfRolesResult{
+		User: user,
+	}
+
+	return result, nil
+}
+
+// User permissions.
+
+func (srv *Server) grantPermission(p *rpc2.GrantPermissionParams) (result *rpc2.GrantPermissionResult, re *jrm1.RpcError) {
+	// Check parameters.
+	re = checkPermissionParams(p)
+	if re != nil {
+		return nil, re
+	}
+
+	srv.dbo.LockForWriting()
+	defer srv.dbo.UnlockAfterWriting()
+
+	var thisUserData derived1.IUserData
+	thisUserData, re = srv.mustBeAnAuthToken(p.Auth)
+	if re != nil {
+		return nil, re
+	}
+
+	// Check permissions.
+	if !thisUserData.GetUser().GetUserParameters().GetRoles().IsAdministrator {
+		srv.incidentManager.ReportIncident(ev.NewEnumValue(cm.IncidentType_IllegalAccessAttempt), "", p.Auth.UserIPAB)
+		return nil, jrm1.NewRpcErrorByUser(c.RpcErrorCode_Permission, c.RpcErrorMsg_Permission, nil)
+	}
+
+	var wasGranted base2.Flag
+	wasGranted, re = srv.setPermission(&perm.Grant{
+		UserId:        p.UserId,
+		Permission:    p.Permission,
+		ScopeType:     p.ScopeType,
+		ScopeId:       p.ScopeId,
+		GrantorUserId: thisUserData.GetUser().GetUserParameters().GetId(),
+	}, true)
+	if re != nil {
+		return nil, re
+	}
+
+	var valueBefore *base2.Text
+	if wasGranted {
+		valueBefore = al.GrantValue(p.Permission, p.ScopeType, p.ScopeId)
+	}
+
+	srv.auditA(thisUserData, p.Auth, al.Action_GrantPermission, al.TargetType_User, p.UserId, valueBefore, al.GrantValue(p.Permission, p.ScopeType, p.ScopeId))
+
+	result = &rpc2.GrantPermissionResult{
+		Success: rpc3.Success{
+			OK: true,
+		},
+	}
+	return result, nil
+}
+
+func (srv *Server) revokePermission(p *rpc2.RevokePermissionParams) (result *rpc2.RevokePermissionResult, re *jrm1.RpcError) {
+	// Check parameters.
+	re = checkPermissionParams(p)
+	if re != nil {
+		return nil, re
+	}
+
+	srv.dbo.LockForWriting()
+	defer srv.dbo.UnlockAfterWriting()
+
+	var thisUserData derived1.IUserData
+	thisUserData, re = srv.mustBeAnAuthToken(p.Auth)
+	if re != nil {
+		return nil, re
+	}
+
+	// Check permissions.
+	if !thisUserData.GetUser().GetUserParameters().GetRoles().IsAdministrator {
+		srv.incidentManager.ReportIncident(ev.NewEnumValue(cm.IncidentType_IllegalAccessAttempt), "", p.Auth.UserIPAB)
+		return nil, jrm1.NewRpcErrorByUser(c.RpcErrorCode_Permission, c.RpcErrorMsg_Permission, nil)
+	}
+
+	var wasGranted base2.Flag
+	wasGranted, re = srv.setPermission(&perm.Grant{
+		UserId:     p.UserId,
+		Permission: p.Permission,
+		ScopeType:  p.ScopeType,
+		ScopeId:    p.ScopeId,
+	}, false)
+	if re != nil {
+		return nil, re
+	}
+
+	var valueBefore *base2.Text
+	if wasGranted {
+		valueBefore = al.GrantValue(p.Permission, p.ScopeType, p.ScopeId)
+	}
+
+	srv.auditA(thisUserData, p.Auth, al.Action_RevokePermission, al.TargetType_User, p.UserId, valueBefore, nil)
+
+	result = &rpc2.RevokePermissionResult{
+		Success: rpc3.Success{
+			OK: true,
+		},
+	}
+	return result, nil
+}
+
+func (srv *Server) getUserPermissions(p *rpc2.GetUserPermissionsParams) (result *rpc2.GetUserPermissionsResult, re *jrm1.RpcError) {
+	// Check parameters.
+	if p.UserId == 0 {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_UserIdIsNotSet, RpcErrorMsg_UserIdIsNotSet, nil)
+	}
+
+	srv.dbo.LockForReading()
+	defer srv.dbo.UnlockAfterReading()
+
+	var thisUserData derived1.IUserData
+	thisUserData, re = srv.mustBeAnAuthToken(p.Auth)
+	if re != nil {
+		return nil, re
+	}
+
+	// Check permissions.
+	if !thisUserData.GetUser().GetUserParameters().GetRoles().IsAdministrator {
+		srv.incidentManager.ReportIncident(ev.NewEnumValue(cm.IncidentType_IllegalAccessAttempt), "", p.Auth.UserIPAB)
+		return nil, jrm1.NewRpcErrorByUser(c.RpcErrorCode_Permission, c.RpcErrorMsg_Permission, nil)
+	}
+
+	grants, err := srv.dbo.GetPermissionsByUserId(p.UserId)
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+
+	result = &rpc2.GetUserPermissionsResult{
+		UserId: p.UserId,
+		Grants: grants,
+	}
+
+	return result, nil
+}
+
+// User banning.
+
+func (srv *Server) banUser(p *rpc2.BanUserParams) (result *rpc2.BanUserResult, re *jrm1.RpcError) {
+	// Check parameters.
+	if p.UserId == 0 {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_UserIdIsNotSet, RpcErrorMsg_UserIdIsNotSet, nil)
+	}
+
+	srv.dbo.LockForWriting()
+	defer srv.dbo.UnlockAfterWriting()
+
+	var thisUserData derived1.IUserData
+	thisUserData, re = srv.mustBeAnAuthToken(p.Auth)
+	if re != nil {
+		return nil, re
+	}
+
+	// Check permissions.
+	if !thisUserData.GetUser().GetUserParameters().GetRoles().IsModerator {
+		srv.incidentManager.ReportIncident(ev.NewEnumValue(cm.IncidentType_IllegalAccessAttempt), "", p.Auth.UserIPAB)
+		return nil, jrm1.NewRpcErrorByUser(c.RpcErrorCode_Permission, c.RpcErrorMsg_Permission, nil)
+	}
+
+	roles, err := srv.dbo.GetUserRolesById(p.UserId)
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+	if roles == nil {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_UserIsNotFound, RpcErrorMsg_UserIsNotFound, p.UserId)
+	}
+
+	err = srv.dbo.SetUserRoleCanLogIn(p.UserId, false)
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+
+	err = srv.dbo.UpdateUserBanTime(p.UserId)
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+
+	var sessionsCount base2.Count
+	sessionsCount, err = srv.dbo.CountSessionsByUserId(p.UserId)
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+
+	if sessionsCount > 0 {
+		err = srv.dbo.DeleteSessionByUserId(p.UserId)
+		if err != nil {
+			return nil, srv.databaseError(err)
+		}
+	}
+
+	srv.auditA(thisUserData, p.Auth, al.Action_BanUser, al.TargetType_User, p.UserId, al.FlagValue(roles.CanLogIn), al.FlagValue(false))
 
+	result = &rpc2.BanUserResult{
+		Success: rpc3.Success{
+			OK: true,
+		},
+	}
 	return result, nil
 }
 
-func (srv *Server) viewUserParameters(p *rpc2.ViewUserParametersParams) (result *rpc2.ViewUserParametersResult, re *jrm1.RpcError) {
+func (srv *Server) unbanUser(p *rpc2.UnbanUserParams) (result *rpc2.UnbanUserResult, re *jrm1.RpcError) {
 	// Check parameters.
 	if p.UserId == 0 {
 		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_UserIdIsNotSet, RpcErrorMsg_UserIdIsNotSet, nil)
 	}
 
-	srv.dbo.LockForReading()
-	defer srv.dbo.UnlockAfterReading()
+	srv.dbo.LockForWriting()
+	defer srv.dbo.UnlockAfterWriting()
 
 	var thisUserData derived1.IUserData
 	thisUserData, re = srv.mustBeAnAuthToken(p.Auth)
@@ -2019,42 +2959,37 @@ func (srv *Server) viewUserParameters(p *rpc2.ViewUserParametersParams) (result
 	}
 
 	// Check permissions.
-	if !thisUserData.GetUser().GetUserParameters().GetRoles().IsAdministrator {
+	if !thisUserData.GetUser().GetUserParameters().GetRoles().IsModerator {
 		srv.incidentManager.ReportIncident(ev.NewEnumValue(cm.IncidentType_IllegalAccessAttempt), "", p.Auth.UserIPAB)
 		return nil, jrm1.NewRpcErrorByUser(c.RpcErrorCode_Permission, c.RpcErrorMsg_Permission, nil)
 	}
 
-	var err error
-	var userParameters base22.IUserParameters
-	userParameters, err = srv.dbo.ViewUserParametersById(p.UserId)
+	roles, err := srv.dbo.GetUserRolesById(p.UserId)
 	if err != nil {
 		return nil, srv.databaseError(err)
 	}
-	if userParameters == nil {
-		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_UserIsNotFound, RpcErrorMsg_UserIsNotFound, nil)
+	if roles == nil {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_UserIsNotFound, RpcErrorMsg_UserIsNotFound, p.UserId)
 	}
 
-	roles := userParameters.GetRoles()
-	roles.IsModerator = srv.isUserModerator(p.UserId)
-	roles.IsAdministrator = srv.isUserAdministrator(p.UserId)
-	userParameters.SetRoles(roles)
+	err = srv.dbo.SetUserRoleCanLogIn(p.UserId, true)
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
 
-	user := u.NewUser()
-	user.SetUserParameters(userParameters)
+	srv.auditA(thisUserData, p.Auth, al.Action_UnbanUser, al.TargetType_User, p.UserId, al.FlagValue(roles.CanLogIn), al.FlagValue(true))
 
-	result = &rpc2.ViewUserParametersResult{
-		User: user,
+	result = &rpc2.UnbanUserResult{
+		Success: rpc3.Success{
+			OK: true,
+		},
 	}
-
 	return result, nil
 }
 
-func (srv *Server) setUserRoleAuthor(p *rpc2.SetUserRoleAuthorParams) (result *rpc2.SetUserRoleAuthorResult, re *jrm1.RpcError) {
-	// Check parameters.
-	if p.UserId == 0 {
-		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_UserIdIsNotSet, RpcErrorMsg_UserIdIsNotSet, nil)
-	}
+// Two-factor authentication.
 
+func (srv *Server) startTotpEnrolment(p *rpc2.StartTotpEnrolmentParams) (result *rpc2.StartTotpEnrolmentResult, re *jrm1.RpcError) {
 	srv.dbo.LockForWriting()
 	defer srv.dbo.UnlockAfterWriting()
 
@@ -2064,29 +2999,42 @@ func (srv *Server) setUserRoleAuthor(p *rpc2.SetUserRoleAuthorParams) (result *r
 		return nil, re
 	}
 
-	// Check permissions.
-	if !thisUserData.GetUser().GetUserParameters().GetRoles().IsAdministrator {
-		srv.incidentManager.ReportIncident(ev.NewEnumValue(cm.IncidentType_IllegalAccessAttempt), "", p.Auth.UserIPAB)
-		return nil, jrm1.NewRpcErrorByUser(c.RpcErrorCode_Permission, c.RpcErrorMsg_Permission, nil)
+	userParameters := thisUserData.GetUser().GetUserParameters()
+
+	var ts *am.TotpSecret
+	ts, re = srv.getEnabledTotpSecret(userParameters.GetId())
+	if re != nil {
+		return nil, re
+	}
+	if ts != nil {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_TotpIsAlreadyEnabled, RpcErrorMsg_TotpIsAlreadyEnabled, nil)
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		srv.logError(err)
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_TotpSecretGenerator, RpcErrorMsg_TotpSecretGenerator, nil)
 	}
 
-	err := srv.dbo.SetUserRoleAuthor(p.UserId, p.IsRoleEnabled)
+	// An unconfirmed secret is replaced, so that a user may start the
+	// enrolment again if the previous attempt was not finished.
+	err = srv.dbo.CreateTotpSecret(userParameters.GetId(), secret)
 	if err != nil {
 		return nil, srv.databaseError(err)
 	}
 
-	result = &rpc2.SetUserRoleAuthorResult{
-		Success: rpc3.Success{
-			OK: true,
-		},
+	result = &rpc2.StartTotpEnrolmentResult{
+		Secret:          base2.Text(totp.EncodeSecret(secret)),
+		ProvisioningUri: base2.Text(totp.MakeProvisioningUri(srv.settings.SystemSettings.SiteName.ToString(), userParameters.GetEmail().ToString(), secret)),
 	}
+
 	return result, nil
 }
 
-func (srv *Server) setUserRoleWriter(p *rpc2.SetUserRoleWriterParams) (result *rpc2.SetUserRoleWriterResult, re *jrm1.RpcError) {
+func (srv *Server) confirmTotpEnrolment(p *rpc2.ConfirmTotpEnrolmentParams) (result *rpc2.ConfirmTotpEnrolmentResult, re *jrm1.RpcError) {
 	// Check parameters.
-	if p.UserId == 0 {
-		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_UserIdIsNotSet, RpcErrorMsg_UserIdIsNotSet, nil)
+	if len(p.TotpCode) == 0 {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_TotpCodeIsNotSet, RpcErrorMsg_TotpCodeIsNotSet, nil)
 	}
 
 	srv.dbo.LockForWriting()
@@ -2098,29 +3046,47 @@ func (srv *Server) setUserRoleWriter(p *rpc2.SetUserRoleWriterParams) (result *r
 		return nil, re
 	}
 
-	// Check permissions.
-	if !thisUserData.GetUser().GetUserParameters().GetRoles().IsAdministrator {
-		srv.incidentManager.ReportIncident(ev.NewEnumValue(cm.IncidentType_IllegalAccessAttempt), "", p.Auth.UserIPAB)
-		return nil, jrm1.NewRpcErrorByUser(c.RpcErrorCode_Permission, c.RpcErrorMsg_Permission, nil)
+	userId := thisUserData.GetUser().GetUserParameters().GetId()
+
+	ts, err := srv.dbo.GetTotpSecret(userId)
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+	if ts == nil {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_TotpEnrolmentIsNotStarted, RpcErrorMsg_TotpEnrolmentIsNotStarted, nil)
+	}
+	if ts.IsEnabled {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_TotpIsAlreadyEnabled, RpcErrorMsg_TotpIsAlreadyEnabled, nil)
 	}
 
-	err := srv.dbo.SetUserRoleWriter(p.UserId, p.IsRoleEnabled)
+	// The password proves that the authenticator application has the secret.
+	step, ok := totp.CheckCode(ts.Secret, p.TotpCode.ToString(), time.Now(), ts.LastUsedStep)
+	if !ok {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_TotpCodeIsWrong, RpcErrorMsg_TotpCodeIsWrong, nil)
+	}
+
+	err = srv.dbo.EnableTotp(userId, step)
 	if err != nil {
 		return nil, srv.databaseError(err)
 	}
 
-	result = &rpc2.SetUserRoleWriterResult{
-		Success: rpc3.Success{
-			OK: true,
-		},
+	var recoveryCodes []simple.RecoveryCode
+	recoveryCodes, re = srv.createRecoveryCodes(userId)
+	if re != nil {
+		return nil, re
+	}
+
+	result = &rpc2.ConfirmTotpEnrolmentResult{
+		RecoveryCodes: recoveryCodes,
 	}
+
 	return result, nil
 }
 
-func (srv *Server) setUserRoleReader(p *rpc2.SetUserRoleReaderParams) (result *rpc2.SetUserRoleReaderResult, re *jrm1.RpcError) {
+func (srv *Server) disableSelfTotp(p *rpc2.DisableSelfTotpParams) (result *rpc2.DisableSelfTotpResult, re *jrm1.RpcError) {
 	// Check parameters.
-	if p.UserId == 0 {
-		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_UserIdIsNotSet, RpcErrorMsg_UserIdIsNotSet, nil)
+	if (len(p.TotpCode) == 0) && (len(p.RecoveryCode) == 0) {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_TotpCodeIsNotSet, RpcErrorMsg_TotpCodeIsNotSet, nil)
 	}
 
 	srv.dbo.LockForWriting()
@@ -2132,18 +3098,40 @@ func (srv *Server) setUserRoleReader(p *rpc2.SetUserRoleReaderParams) (result *r
 		return nil, re
 	}
 
-	// Check permissions.
-	if !thisUserData.GetUser().GetUserParameters().GetRoles().IsAdministrator {
-		srv.incidentManager.ReportIncident(ev.NewEnumValue(cm.IncidentType_IllegalAccessAttempt), "", p.Auth.UserIPAB)
-		return nil, jrm1.NewRpcErrorByUser(c.RpcErrorCode_Permission, c.RpcErrorMsg_Permission, nil)
+	userParameters := thisUserData.GetUser().GetUserParameters()
+
+	var ts *am.TotpSecret
+	ts, re = srv.getEnabledTotpSecret(userParameters.GetId())
+	if re != nil {
+		return nil, re
+	}
+	if ts == nil {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_TotpIsNotEnabled, RpcErrorMsg_TotpIsNotEnabled, nil)
 	}
 
-	err := srv.dbo.SetUserRoleReader(p.UserId, p.IsRoleEnabled)
-	if err != nil {
-		return nil, srv.databaseError(err)
+	var ok bool
+	ok, re = srv.checkTotp(ts, p.TotpCode, p.RecoveryCode)
+	if re != nil {
+		return nil, re
 	}
 
-	result = &rpc2.SetUserRoleReaderResult{
+	if !ok {
+		srv.incidentManager.ReportIncident(ev.NewEnumValue(cm.IncidentType_VerificationCodeMismatch), userParameters.GetEmail(), p.Auth.UserIPAB)
+
+		err := srv.dbo.UpdateUserLastBadActionTimeById(userParameters.GetId())
+		if err != nil {
+			return nil, srv.databaseError(err)
+		}
+
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_TotpCodeIsWrong, RpcErrorMsg_TotpCodeIsWrong, nil)
+	}
+
+	re = srv.deleteTotp(userParameters.GetId())
+	if re != nil {
+		return nil, re
+	}
+
+	result = &rpc2.DisableSelfTotpResult{
 		Success: rpc3.Success{
 			OK: true,
 		},
@@ -2151,7 +3139,7 @@ func (srv *Server) setUserRoleReader(p *rpc2.SetUserRoleReaderParams) (result *r
 	return result, nil
 }
 
-func (srv *Server) getSelfRoles(p *rpc2.GetSelfRolesParams) (result *rpc2.GetSelfRolesResult, re *jrm1.RpcError) {
+func (srv *Server) getSelfTotpStatus(p *rpc2.GetSelfTotpStatusParams) (result *rpc2.GetSelfTotpStatusResult, re *jrm1.RpcError) {
 	srv.dbo.LockForReading()
 	defer srv.dbo.UnlockAfterReading()
 
@@ -2161,19 +3149,88 @@ func (srv *Server) getSelfRoles(p *rpc2.GetSelfRolesParams) (result *rpc2.GetSel
 		return nil, re
 	}
 
-	user := u.NewUser()
-	user.SetUserParameters(thisUserData.GetUser().GetUserParameters())
+	userId := thisUserData.GetUser().GetUserParameters().GetId()
 
-	result = &rpc2.GetSelfRolesResult{
-		User: user,
+	var ts *am.TotpSecret
+	ts, re = srv.getEnabledTotpSecret(userId)
+	if re != nil {
+		return nil, re
+	}
+
+	result = &rpc2.GetSelfTotpStatusResult{
+		IsTotpEnabled: ts != nil,
+	}
+
+	if ts != nil {
+		var err error
+		result.RecoveryCodesCount, err = srv.dbo.CountTotpRecoveryCodes(userId)
+		if err != nil {
+			return nil, srv.databaseError(err)
+		}
 	}
 
 	return result, nil
 }
 
-// User banning.
+func (srv *Server) regenerateTotpRecoveryCodes(p *rpc2.RegenerateTotpRecoveryCodesParams) (result *rpc2.RegenerateTotpRecoveryCodesResult, re *jrm1.RpcError) {
+	// Check parameters.
+	if len(p.TotpCode) == 0 {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_TotpCodeIsNotSet, RpcErrorMsg_TotpCodeIsNotSet, nil)
+	}
 
-func (srv *Server) banUser(p *rpc2.BanUserParams) (result *rpc2.BanUserResult, re *jrm1.RpcError) {
+	srv.dbo.LockForWriting()
+	defer srv.dbo.UnlockAfterWriting()
+
+	var thisUserData derived1.IUserData
+	thisUserData, re = srv.mustBeAnAuthToken(p.Auth)
+	if re != nil {
+		return nil, re
+	}
+
+	userParameters := thisUserData.GetUser().GetUserParameters()
+
+	var ts *am.TotpSecret
+	ts, re = srv.getEnabledTotpSecret(userParameters.GetId())
+	if re != nil {
+		return nil, re
+	}
+	if ts == nil {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_TotpIsNotEnabled, RpcErrorMsg_TotpIsNotEnabled, nil)
+	}
+
+	// Recovery codes can not be used here, otherwise a stolen recovery code
+	// would give all the other codes.
+	var ok bool
+	ok, re = srv.checkTotp(ts, p.TotpCode, "")
+	if re != nil {
+		return nil, re
+	}
+
+	if !ok {
+		srv.incidentManager.ReportIncident(ev.NewEnumValue(cm.IncidentType_VerificationCodeMismatch), userParameters.GetEmail(), p.Auth.UserIPAB)
+
+		err := srv.dbo.UpdateUserLastBadActionTimeById(userParameters.GetId())
+		if err != nil {
+			return nil, srv.databaseError(err)
+		}
+
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_TotpCodeIsWrong, RpcErrorMsg_TotpCodeIsWrong, nil)
+	}
+
+	var recoveryCodes []simple.RecoveryCode
+	recoveryCodes, re = srv.createRecoveryCodes(userParameters.GetId())
+	if re != nil {
+		return nil, re
+	}
+
+	result = &rpc2.RegenerateTotpRecoveryCodesResult{
+		RecoveryCodes: recoveryCodes,
+	}
+
+	return result, nil
+}
+
+func (srv *Server) resetUserTotp(p *rpc2.ResetUserTotpParams) (result *rpc2.ResetUserTotpResult, re *jrm1.RpcError) {
 	// Check parameters.
 	if p.UserId == 0 {
 		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_UserIdIsNotSet, RpcErrorMsg_UserIdIsNotSet, nil)
@@ -2189,35 +3246,27 @@ func (srv *Server) banUser(p *rpc2.BanUserParams) (result *rpc2.BanUserResult, r
 	}
 
 	// Check permissions.
-	if !thisUserData.GetUser().GetUserParameters().GetRoles().IsModerator {
+	if !thisUserData.GetUser().GetUserParameters().GetRoles().IsAdministrator {
 		srv.incidentManager.ReportIncident(ev.NewEnumValue(cm.IncidentType_IllegalAccessAttempt), "", p.Auth.UserIPAB)
 		return nil, jrm1.NewRpcErrorByUser(c.RpcErrorCode_Permission, c.RpcErrorMsg_Permission, nil)
 	}
 
-	err := srv.dbo.SetUserRoleCanLogIn(p.UserId, false)
+	ts, err := srv.dbo.GetTotpSecret(p.UserId)
 	if err != nil {
 		return nil, srv.databaseError(err)
 	}
-
-	err = srv.dbo.UpdateUserBanTime(p.UserId)
-	if err != nil {
-		return nil, srv.databaseError(err)
+	if ts == nil {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_TotpIsNotEnabled, RpcErrorMsg_TotpIsNotEnabled, nil)
 	}
 
-	var sessionsCount base2.Count
-	sessionsCount, err = srv.dbo.CountSessionsByUserId(p.UserId)
-	if err != nil {
-		return nil, srv.databaseError(err)
+	re = srv.deleteTotp(p.UserId)
+	if re != nil {
+		return nil, re
 	}
 
-	if sessionsCount > 0 {
-		err = srv.dbo.DeleteSessionByUserId(p.UserId)
-		if err != nil {
-			return nil, srv.databaseError(err)
-		}
-	}
+	srv.auditA(thisUserData, p.Auth, al.Action_ResetUserTotp, al.TargetType_User, p.UserId, al.FlagValue(true), al.FlagValue(false))
 
-	result = &rpc2.BanUserResult{
+	result = &rpc2.ResetUserTotpResult{
 		Success: rpc3.Success{
 			OK: true,
 		},
@@ -2225,15 +3274,72 @@ func (srv *Server) banUser(p *rpc2.BanUserParams) (result *rpc2.BanUserResult, r
 	return result, nil
 }
 
-func (srv *Server) unbanUser(p *rpc2.UnbanUserParams) (result *rpc2.UnbanUserResult, re *jrm1.RpcError) {
+// Audit log.
+
+// addAuditRecordS saves a record of a privileged action performed in another
+// module.
+func (srv *Server) addAuditRecordS(p *rpc2.AddAuditRecordSParams) (result *rpc2.AddAuditRecordSResult, re *jrm1.RpcError) {
 	// Check parameters.
-	if p.UserId == 0 {
+	if p.ActorUserId == 0 {
 		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_UserIdIsNotSet, RpcErrorMsg_UserIdIsNotSet, nil)
 	}
 
+	if !al.IsActionValid(p.Action) {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_AuditActionIsNotValid, RpcErrorMsg_AuditActionIsNotValid, nil)
+	}
+
+	if !al.IsTargetValid(p.TargetType, p.TargetId) {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_AuditTargetIsNotValid, RpcErrorMsg_AuditTargetIsNotValid, nil)
+	}
+
+	if !al.IsValueValid(p.ValueBefore) || !al.IsValueValid(p.ValueAfter) {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_AuditValueIsTooLong, RpcErrorMsg_AuditValueIsTooLong, nil)
+	}
+
+	re = srv.mustBeNoAuth(p.Auth)
+	if re != nil {
+		return nil, re
+	}
+
+	// Check the DKey.
+	if !srv.dKeyI.CheckString(p.DKey.ToString()) {
+		return nil, jrm1.NewRpcErrorByUser(c.RpcErrorCode_Permission, c.RpcErrorMsg_Permission, nil)
+	}
+
+	actorIPAB, err := cn.ParseIPA(p.ActorIPA)
+	if err != nil {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_AuditActorIsNotValid, RpcErrorMsg_AuditActorIsNotValid, nil)
+	}
+
 	srv.dbo.LockForWriting()
 	defer srv.dbo.UnlockAfterWriting()
 
+	re = srv.audit(p.ActorUserId, actorIPAB, p.Action, p.TargetType, p.TargetId, p.ValueBefore, p.ValueAfter)
+	if re != nil {
+		return nil, re
+	}
+
+	result = &rpc2.AddAuditRecordSResult{
+		Success: rpc3.Success{
+			OK: true,
+		},
+	}
+	return result, nil
+}
+
+func (srv *Server) getAuditLog(p *rpc2.GetAuditLogParams) (result *rpc2.GetAuditLogResult, re *jrm1.RpcError) {
+	// Check parameters.
+	if p.Page == 0 {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_PageIsNotSet, RpcErrorMsg_PageIsNotSet, nil)
+	}
+
+	if !p.Filter.IsValid() {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_AuditFilterIsNotValid, RpcErrorMsg_AuditFilterIsNotValid, nil)
+	}
+
+	srv.dbo.LockForReading()
+	defer srv.dbo.UnlockAfterReading()
+
 	var thisUserData derived1.IUserData
 	thisUserData, re = srv.mustBeAnAuthToken(p.Auth)
 	if re != nil {
@@ -2241,26 +3347,69 @@ func (srv *Server) unbanUser(p *rpc2.UnbanUserParams) (result *rpc2.UnbanUserRes
 	}
 
 	// Check permissions.
-	if !thisUserData.GetUser().GetUserParameters().GetRoles().IsModerator {
+	if !thisUserData.GetUser().GetUserParameters().GetRoles().IsAdministrator {
 		srv.incidentManager.ReportIncident(ev.NewEnumValue(cm.IncidentType_IllegalAccessAttempt), "", p.Auth.UserIPAB)
 		return nil, jrm1.NewRpcErrorByUser(c.RpcErrorCode_Permission, c.RpcErrorMsg_Permission, nil)
 	}
 
-	err := srv.dbo.SetUserRoleCanLogIn(p.UserId, true)
+	recordsCount, err := srv.dbo.CountAuditRecords(&p.Filter)
 	if err != nil {
 		return nil, srv.databaseError(err)
 	}
 
-	result = &rpc2.UnbanUserResult{
-		Success: rpc3.Success{
-			OK: true,
+	var records []*al.Record
+	records, err = srv.dbo.ReadAuditRecords(&p.Filter, p.Page, srv.settings.SystemSettings.PageSize)
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+
+	result = &rpc2.GetAuditLogResult{
+		Records: records,
+		PageData: &rpc3.PageData{
+			PageNumber:  p.Page,
+			TotalPages:  base2.CalculateTotalPages(recordsCount, srv.settings.SystemSettings.PageSize),
+			PageSize:    srv.settings.SystemSettings.PageSize,
+			ItemsOnPage: base2.Count(len(records)),
+			TotalItems:  recordsCount,
 		},
 	}
+
+	return result, nil
+}
+
+// Web token keys.
+
+// getJwks returns public keys which are used to verify web tokens. The keys
+// are public, so this function may be called without authorisation.
+func (srv *Server) getJwks() (result *rpc2.GetJwksResult, re *jrm1.RpcError) {
+	jwks, err := srv.jwtkm.GetJwks()
+	if err != nil {
+		srv.logError(err)
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_JWKS, fmt.Sprintf(RpcErrorMsgF_JWKS, err.Error()), nil)
+	}
+
+	result = &rpc2.GetJwksResult{
+		Keys: jwks.Keys,
+	}
+
 	return result, nil
 }
 
 // Other.
 
+func (srv *Server) getDKey(p *rpc2.GetDKeyParams) (result *rpc2.GetDKeyResult, re *jrm1.RpcError) {
+	re = srv.mustBeNoAuth(p.Auth)
+	if re != nil {
+		return nil, re
+	}
+
+	result = &rpc2.GetDKeyResult{
+		DKey: base2.Text(srv.dKeyI.GetString()),
+	}
+
+	return result, nil
+}
+
 func (srv *Server) showDiagnosticData() (result *rpc2.ShowDiagnosticDataResult, re *jrm1.RpcError) {
 	trc, src := srv.js.GetRequestsCount()
 
@@ -2269,6 +3418,9 @@ func (srv *Server) showDiagnosticData() (result *rpc2.ShowDiagnosticDataResult,
 			TotalRequestsCount:      base2.Text(trc),
 			SuccessfulRequestsCount: base2.Text(src),
 		},
+		ScheduledTasks: rpc3.ScheduledTasks{
+			ScheduledTasks: srv.scheduler.GetTaskStatuses(),
+		},
 	}
 
 	return result, nil
@@ -9,12 +9,12 @@ import (
 	"github.com/vault-thirteen/SimpleBB/pkg/common/interfaces/base1"
 	"github.com/vault-thirteen/SimpleBB/pkg/common/interfaces/derived2"
 	cc "github.com/vault-thirteen/SimpleBB/pkg/common/models/Client"
+	"github.com/vault-thirteen/SimpleBB/pkg/common/models/DKey"
 	cm "github.com/vault-thirteen/SimpleBB/pkg/common/models/Scheduler"
 	"github.com/vault-thirteen/SimpleBB/pkg/common/models/app"
 	"github.com/vault-thirteen/SimpleBB/pkg/common/models/avm"
 	server2 "github.com/vault-thirteen/SimpleBB/pkg/common/models/server"
 	cset "github.com/vault-thirteen/SimpleBB/pkg/common/models/settings"
-	"github.com/vault-thirteen/SimpleBB/pkg/common/models/simple"
 	"log"
 	"net"
 	"net/http"
@@ -27,6 +27,7 @@ import (
 	"github.com/vault-thirteen/SimpleBB/pkg/ACM/dbo"
 	"github.com/vault-thirteen/SimpleBB/pkg/ACM/km"
 	as "github.com/vault-thirteen/SimpleBB/pkg/ACM/settings"
+	"github.com/vault-thirteen/SimpleBB/pkg/ACM/totp"
 	rp "github.com/vault-thirteen/auxie/rpofs"
 )
 
@@ -67,12 +68,18 @@ type Server struct {
 	// Generator of request IDs.
 	ridg *rp.Generator
 
+	// Generator of recovery codes for two-factor authentication.
+	rcg *rp.Generator
+
 	// JWT key maker.
 	jwtkm *km.KeyMaker
 
 	// Incident manager.
 	incidentManager derived2.IIncidentManager
 
+	// Internal DKeys.
+	dKeyI *dk.DKey
+
 	// Scheduler.
 	scheduler *cm.Scheduler
 }
@@ -133,6 +140,11 @@ func NewServer(s base.ISettings) (srv *Server, err error) {
 		return nil, err
 	}
 
+	err = srv.initRecoveryCodeGenerator()
+	if err != nil {
+		return nil, err
+	}
+
 	err = srv.initJwtKeyMaker()
 	if err != nil {
 		return nil, err
@@ -148,7 +160,15 @@ func NewServer(s base.ISettings) (srv *Server, err error) {
 		return nil, err
 	}
 
-	srv.initScheduler()
+	err = srv.initKeys()
+	if err != nil {
+		return nil, err
+	}
+
+	err = srv.initScheduler()
+	if err != nil {
+		return nil, err
+	}
 
 	return srv, nil
 }
@@ -413,11 +433,24 @@ func (srv *Server) initRequestIdGenerator() (err error) {
 	return nil
 }
 
+func (srv *Server) initRecoveryCodeGenerator() (err error) {
+	symbols := c.MakeSymbolsNumbersAndCapitalLatinLetters()
+
+	srv.rcg, err = rp.NewGenerator(totp.RecoveryCodeLength, symbols)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (srv *Server) initJwtKeyMaker() (err error) {
 	srv.jwtkm, err = km.New(
 		srv.settings.JWTSettings.SigningMethod,
-		srv.settings.JWTSettings.PrivateKeyFilePath,
-		srv.settings.JWTSettings.PublicKeyFilePath,
+		srv.settings.JWTSettings.KeyRingFolder,
+		time.Duration(srv.settings.JWTSettings.KeyRotationPeriod)*time.Second,
+		time.Duration(srv.settings.SystemSettings.SessionMaxDuration)*time.Second,
+		srv.settings.JWTSettings.LegacyPublicKeyFilePath,
 	)
 	if err != nil {
 		return err
@@ -434,14 +467,32 @@ func (srv *Server) initIncidentManager() (err error) {
 	return nil
 }
 
-func (srv *Server) initScheduler() {
-	funcs60 := []simple.ScheduledFn{
-		srv.clearPreRegUsersTable,
-		srv.clearPasswordChangesTable,
-		srv.clearEmailChangesTable,
-		srv.clearSessions,
+func (srv *Server) initKeys() (err error) {
+	srv.dKeyI, err = dk.NewDKey(int(srv.settings.SystemSettings.DKeySize))
+	if err != nil {
+		return err
 	}
-	srv.scheduler = cm.NewScheduler(srv, funcs60, nil, nil)
+
+	return nil
+}
+
+func (srv *Server) initScheduler() (err error) {
+	tasks := []cm.Task{
+		{Name: "clearPreRegUsersTable", Schedule: "@every 1m", Fn: srv.clearPreRegUsersTable, Timeout: time.Minute},
+		{Name: "clearPasswordChangesTable", Schedule: "@every 1m", Fn: srv.clearPasswordChangesTable, Timeout: time.Minute},
+		{Name: "clearEmailChangesTable", Schedule: "@every 1m", Fn: srv.clearEmailChangesTable, Timeout: time.Minute},
+		{Name: "clearPasswordResetsTable", Schedule: "@every 1m", Fn: srv.clearPasswordResetsTable, Timeout: time.Minute},
+		{Name: "clearSessions", Schedule: "@every 1m", Fn: srv.clearSessions, Timeout: time.Minute},
+		{Name: "clearAuditLog", Schedule: "@every 1h", Fn: srv.clearAuditLog, Timeout: time.Minute},
+		{Name: "rotateJwtKeys", Schedule: "@every 1h", Fn: srv.rotateJwtKeys, Timeout: time.Minute},
+	}
+
+	srv.scheduler, err = cm.NewScheduler(srv, tasks)
+	if err != nil {
+		return err
+	}
+
+	return nil
 }
 
 func (srv *Server) ReportStart() {
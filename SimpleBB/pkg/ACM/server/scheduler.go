@@ -1,18 +1,19 @@
 package server
 
 import (
+	"context"
 	"time"
 
 	"github.com/vault-thirteen/SimpleBB/pkg/ACM/dbo"
 )
 
-func (srv *Server) clearPreRegUsersTable() (err error) {
+func (srv *Server) clearPreRegUsersTable(ctx context.Context) (err error) {
 	srv.dbo.LockForWriting()
 	defer srv.dbo.UnlockAfterWriting()
 
 	timeBorder := time.Now().Add(-time.Duration(srv.settings.SystemSettings.PreRegUserExpirationTime) * time.Second)
 
-	_, err = srv.dbo.GetPreparedStatementByIndex(dbo.DbPsid_ClearPreRegUsersTable).Exec(timeBorder)
+	_, err = srv.dbo.GetPreparedStatementByIndex(dbo.DbPsid_ClearPreRegUsersTable).ExecContext(ctx, timeBorder)
 	if err != nil {
 		return err
 	}
@@ -20,13 +21,13 @@ func (srv *Server) clearPreRegUsersTable() (err error) {
 	return nil
 }
 
-func (srv *Server) clearPasswordChangesTable() (err error) {
+func (srv *Server) clearPasswordChangesTable(ctx context.Context) (err error) {
 	srv.dbo.LockForWriting()
 	defer srv.dbo.UnlockAfterWriting()
 
 	timeBorder := time.Now().Add(-time.Duration(srv.settings.SystemSettings.PasswordChangeExpirationTime) * time.Second)
 
-	_, err = srv.dbo.GetPreparedStatementByIndex(dbo.DbPsid_ClearPasswordChangesTable).Exec(timeBorder)
+	_, err = srv.dbo.GetPreparedStatementByIndex(dbo.DbPsid_ClearPasswordChangesTable).ExecContext(ctx, timeBorder)
 	if err != nil {
 		return err
 	}
@@ -34,13 +35,13 @@ func (srv *Server) clearPasswordChangesTable() (err error) {
 	return nil
 }
 
-func (srv *Server) clearEmailChangesTable() (err error) {
+func (srv *Server) clearEmailChangesTable(ctx context.Context) (err error) {
 	srv.dbo.LockForWriting()
 	defer srv.dbo.UnlockAfterWriting()
 
 	timeBorder := time.Now().Add(-time.Duration(srv.settings.SystemSettings.EmailChangeExpirationTime) * time.Second)
 
-	_, err = srv.dbo.GetPreparedStatementByIndex(dbo.DbPsid_ClearEmailChangesTable).Exec(timeBorder)
+	_, err = srv.dbo.GetPreparedStatementByIndex(dbo.DbPsid_ClearEmailChangesTable).ExecContext(ctx, timeBorder)
 	if err != nil {
 		return err
 	}
@@ -48,13 +49,50 @@ func (srv *Server) clearEmailChangesTable() (err error) {
 	return nil
 }
 
-func (srv *Server) clearSessions() (err error) {
+func (srv *Server) clearPasswordResetsTable(ctx context.Context) (err error) {
+	srv.dbo.LockForWriting()
+	defer srv.dbo.UnlockAfterWriting()
+
+	timeBorder := time.Now().Add(-time.Duration(srv.settings.SystemSettings.PasswordResetExpirationTime) * time.Second)
+
+	_, err = srv.dbo.GetPreparedStatementByIndex(dbo.DbPsid_ClearPasswordResetsTable).ExecContext(ctx, timeBorder)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (srv *Server) clearSessions(ctx context.Context) (err error) {
 	srv.dbo.LockForWriting()
 	defer srv.dbo.UnlockAfterWriting()
 
 	timeBorder := time.Now().Add(-time.Duration(srv.settings.SystemSettings.SessionMaxDuration) * time.Second)
 
-	_, err = srv.dbo.GetPreparedStatementByIndex(dbo.DbPsid_ClearSessions).Exec(timeBorder)
+	_, err = srv.dbo.GetPreparedStatementByIndex(dbo.DbPsid_ClearSessions).ExecContext(ctx, timeBorder)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (srv *Server) clearAuditLog(ctx context.Context) (err error) {
+	srv.dbo.LockForWriting()
+	defer srv.dbo.UnlockAfterWriting()
+
+	timeBorder := time.Now().AddDate(0, 0, -srv.settings.SystemSettings.AuditLogRetentionDays.AsInt())
+
+	_, err = srv.dbo.GetPreparedStatementByIndex(dbo.DbPsid_ClearAuditLog).ExecContext(ctx, timeBorder)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (srv *Server) rotateJwtKeys(_ context.Context) (err error) {
+	_, err = srv.jwtkm.Rotate(time.Now())
 	if err != nil {
 		return err
 	}
@@ -2,21 +2,31 @@ package s
 
 import (
 	"errors"
+	base2 "github.com/vault-thirteen/SimpleBB/pkg/common/models/base"
 	c "github.com/vault-thirteen/SimpleBB/pkg/common/models/server"
 	cm "github.com/vault-thirteen/SimpleBB/pkg/common/models/simple"
 )
 
 // JWTSettings are settings for JSON web tokens.
 type JWTSettings struct {
-	PrivateKeyFilePath cm.Path `json:"privateKeyFilePath"`
-	PublicKeyFilePath  cm.Path `json:"publicKeyFilePath"`
-	SigningMethod      string  `json:"signingMethod"`
+	// Folder where keys of the key ring are stored.
+	KeyRingFolder cm.Path `json:"keyRingFolder"`
+
+	// Signing method of new keys.
+	SigningMethod string `json:"signingMethod"`
+
+	// Period of key rotation in seconds. Zero period disables rotation.
+	KeyRotationPeriod base2.Count `json:"keyRotationPeriod"`
+
+	// Public key used before the key ring was introduced. Tokens without a
+	// key ID are verified with this key. This setting is optional.
+	LegacyPublicKeyFilePath cm.Path `json:"legacyPublicKeyFilePath"`
 }
 
 func (s JWTSettings) Check() (err error) {
-	if (len(s.PrivateKeyFilePath) == 0) ||
-		(len(s.PublicKeyFilePath) == 0) ||
-		(len(s.SigningMethod) == 0) {
+	if (len(s.KeyRingFolder) == 0) ||
+		(len(s.SigningMethod) == 0) ||
+		(s.KeyRotationPeriod < 0) {
 		return errors.New(c.MsgJwtSettingError)
 	}
 
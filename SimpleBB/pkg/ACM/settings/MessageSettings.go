@@ -15,6 +15,7 @@ type MessageSettings struct {
 	BodyTemplateForLogIn       cmb.Text `json:"bodyTemplateForLogIn"`
 	BodyTemplateForPwdChange   cmb.Text `json:"bodyTemplateForPwdChange"`
 	BodyTemplateForEmailChange cmb.Text `json:"bodyTemplateForEmailChange"`
+	BodyTemplateForPwdReset    cmb.Text `json:"bodyTemplateForPwdReset"`
 }
 
 func (s MessageSettings) Check() (err error) {
@@ -24,7 +25,8 @@ func (s MessageSettings) Check() (err error) {
 		(len(s.BodyTemplateForReg) == 0) ||
 		(len(s.BodyTemplateForLogIn) == 0) ||
 		(len(s.BodyTemplateForPwdChange) == 0) ||
-		(len(s.BodyTemplateForEmailChange) == 0) {
+		(len(s.BodyTemplateForEmailChange) == 0) ||
+		(len(s.BodyTemplateForPwdReset) == 0) {
 		return errors.New(c.MsgMessageSettingError)
 	}
 
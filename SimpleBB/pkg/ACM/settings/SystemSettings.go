@@ -2,6 +2,7 @@ package s
 
 import (
 	"errors"
+	ck "github.com/vault-thirteen/SimpleBB/pkg/common/models/CaptchaKind"
 	base2 "github.com/vault-thirteen/SimpleBB/pkg/common/models/base"
 	c "github.com/vault-thirteen/SimpleBB/pkg/common/models/server"
 )
@@ -21,8 +22,24 @@ type SystemSettings struct {
 	SessionMaxDuration           base2.Count `json:"sessionMaxDuration"`
 	PasswordChangeExpirationTime base2.Count `json:"passwordChangeExpirationTime"`
 	EmailChangeExpirationTime    base2.Count `json:"emailChangeExpirationTime"`
+	PasswordResetExpirationTime  base2.Count `json:"passwordResetExpirationTime"`
 	ActionTryTimeout             base2.Count `json:"actionTryTimeout"`
 	PageSize                     base2.Count `json:"pageSize"`
+	DKeySize                     base2.Count `json:"dKeySize"`
+
+	// AuditLogRetentionDays is the number of days during which records of
+	// the audit log are kept. Older records are deleted by the scheduler.
+	AuditLogRetentionDays base2.Count `json:"auditLogRetentionDays"`
+
+	// When enabled, users having two-factor authentication log in with a
+	// one-time password only, without a verification code sent by e-mail.
+	IsTotpReplacingEmailCode base2.Flag `json:"isTotpReplacingEmailCode"`
+
+	// Kinds of captcha which users may request instead of an image when they
+	// can not see it. These kinds are weaker than the image, so the list is
+	// empty by default and only images are issued. Each kind must also be
+	// enabled in the captcha service.
+	AccessibleCaptchaKinds []ck.CaptchaKind `json:"accessibleCaptchaKinds"`
 
 	// This setting must be synchronised with settings of the Gateway module.
 	IsTableOfIncidentsUsed base2.Flag `json:"isTableOfIncidentsUsed"`
@@ -45,6 +62,7 @@ type BlockTimePerIncident struct {
 	PasswordChangeHacking    base2.Count `json:"passwordChangeHacking"`    // 8.
 	EmailChangeHacking       base2.Count `json:"emailChangeHacking"`       // 9.
 	FakeIPA                  base2.Count `json:"fakeIPA"`                  // 10.
+	PasswordResetHacking     base2.Count `json:"passwordResetHacking"`     // 13.
 }
 
 func (s SystemSettings) Check() (err error) {
@@ -60,11 +78,20 @@ func (s SystemSettings) Check() (err error) {
 		(s.SessionMaxDuration == 0) ||
 		(s.PasswordChangeExpirationTime == 0) ||
 		(s.EmailChangeExpirationTime == 0) ||
+		(s.PasswordResetExpirationTime == 0) ||
 		(s.ActionTryTimeout == 0) ||
-		(s.PageSize == 0) {
+		(s.PageSize == 0) ||
+		(s.DKeySize == 0) ||
+		(s.AuditLogRetentionDays == 0) {
 		return errors.New(c.MsgSystemSettingError)
 	}
 
+	for _, kind := range s.AccessibleCaptchaKinds {
+		if !ck.IsCaptchaKindValid(kind) {
+			return errors.New(c.MsgSystemSettingError)
+		}
+	}
+
 	// Firewall.
 	if s.IsTableOfIncidentsUsed {
 		if (s.BlockTimePerIncident.IllegalAccessAttempt == 0) ||
@@ -76,7 +103,8 @@ func (s SystemSettings) Check() (err error) {
 			(s.BlockTimePerIncident.PasswordMismatch == 0) ||
 			(s.BlockTimePerIncident.PasswordChangeHacking == 0) ||
 			(s.BlockTimePerIncident.EmailChangeHacking == 0) ||
-			(s.BlockTimePerIncident.FakeIPA == 0) {
+			(s.BlockTimePerIncident.FakeIPA == 0) ||
+			(s.BlockTimePerIncident.PasswordResetHacking == 0) {
 			return errors.New(c.MsgSystemSettingError)
 		}
 	}
@@ -0,0 +1,141 @@
+package totp
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// This package implements time-based one-time passwords described in RFC
+// 6238. Parameters are the ones supported by all the popular authenticator
+// applications: HMAC-SHA1, 6 digits and a period of 30 seconds.
+
+const (
+	// SecretSize is the size of a shared secret in bytes. RFC 4226 recommends
+	// 160 bits.
+	SecretSize = 20
+
+	Digits    = 6
+	PeriodSec = 30
+
+	// Skew is the number of periods before and after the current one which
+	// are also accepted, since clocks of devices are never exact.
+	Skew = 1
+
+	// RecoveryCodesCount is the number of recovery codes given to a user.
+	// Each recovery code may be used only once instead of a one-time password.
+	RecoveryCodesCount = 10
+	RecoveryCodeLength = 10
+
+	ProvisioningUriScheme = "otpauth"
+	ProvisioningUriType   = "totp"
+	Algorithm             = "SHA1"
+)
+
+var secretEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// GenerateSecret creates a random shared secret.
+func GenerateSecret() (secret []byte, err error) {
+	secret = make([]byte, SecretSize)
+
+	_, err = rand.Read(secret)
+	if err != nil {
+		return nil, err
+	}
+
+	return secret, nil
+}
+
+// EncodeSecret converts the secret into a text which is typed by a user when
+// the QR code can not be scanned.
+func EncodeSecret(secret []byte) string {
+	return secretEncoding.EncodeToString(secret)
+}
+
+// MakeProvisioningUri creates a 'otpauth://' URI which is shown to a user as
+// a QR code. The issuer is the name of the site and the account is the name
+// of the user on the site.
+func MakeProvisioningUri(issuer string, account string, secret []byte) string {
+	q := url.Values{}
+	q.Set("secret", EncodeSecret(secret))
+	q.Set("issuer", issuer)
+	q.Set("algorithm", Algorithm)
+	q.Set("digits", fmt.Sprint(Digits))
+	q.Set("period", fmt.Sprint(PeriodSec))
+
+	u := url.URL{
+		Scheme:   ProvisioningUriScheme,
+		Host:     ProvisioningUriType,
+		Path:     "/" + issuer + ":" + account,
+		RawQuery: q.Encode(),
+	}
+
+	return u.String()
+}
+
+// TimeStep returns the number of the period containing the time.
+func TimeStep(t time.Time) int64 {
+	return t.Unix() / PeriodSec
+}
+
+// GenerateCode creates a one-time password for the period.
+func GenerateCode(secret []byte, step int64) string {
+	var msg [8]byte
+	binary.BigEndian.PutUint64(msg[:], uint64(step))
+
+	mac := hmac.New(sha1.New, secret)
+	mac.Write(msg[:])
+	sum := mac.Sum(nil)
+
+	// Dynamic truncation, RFC 4226, section 5.3.
+	offset := sum[len(sum)-1] & 0x0F
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7FFFFFFF
+
+	var mod uint32 = 1
+	for i := 0; i < Digits; i++ {
+		mod *= 10
+	}
+
+	return fmt.Sprintf("%0*d", Digits, value%mod)
+}
+
+// CheckCode checks the one-time password at the time. Periods which are not
+// newer than the last used period are rejected, so that an intercepted
+// password can not be used twice. When the password is accepted, its period
+// is returned to be saved as the last used one.
+func CheckCode(secret []byte, code string, t time.Time, lastUsedStep int64) (step int64, ok bool) {
+	code = strings.TrimSpace(code)
+	if len(code) != Digits {
+		return 0, false
+	}
+
+	current := TimeStep(t)
+	for s := current - Skew; s <= current+Skew; s++ {
+		if s <= lastUsedStep {
+			continue
+		}
+
+		if subtle.ConstantTimeCompare([]byte(GenerateCode(secret, s)), []byte(code)) == 1 {
+			return s, true
+		}
+	}
+
+	return 0, false
+}
+
+// HashRecoveryCode returns a hash of the recovery code under which it is
+// stored. Recovery codes are random, so a fast hash is enough here.
+func HashRecoveryCode(code string) string {
+	code = strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(code), "-", ""))
+	sum := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,78 @@
+package totp
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/vault-thirteen/auxie/tester"
+)
+
+// Test vectors are taken from RFC 6238, Appendix B. The RFC uses 8 digits,
+// so only the last 6 digits are compared.
+func Test_GenerateCode(t *testing.T) {
+	aTest := tester.New(t)
+	secret := []byte("12345678901234567890")
+
+	type TestCase struct {
+		Time         int64
+		ExpectedCode string
+	}
+	var tests = []TestCase{
+		{Time: 59, ExpectedCode: "287082"},
+		{Time: 1111111109, ExpectedCode: "081804"},
+		{Time: 1111111111, ExpectedCode: "050471"},
+		{Time: 1234567890, ExpectedCode: "005924"},
+		{Time: 2000000000, ExpectedCode: "279037"},
+		{Time: 20000000000, ExpectedCode: "353130"},
+	}
+
+	for _, test := range tests {
+		aTest.MustBeEqual(GenerateCode(secret, TimeStep(time.Unix(test.Time, 0))), test.ExpectedCode)
+	}
+}
+
+func Test_CheckCode(t *testing.T) {
+	aTest := tester.New(t)
+	secret := []byte("12345678901234567890")
+	now := time.Unix(1234567890, 0)
+	code := GenerateCode(secret, TimeStep(now))
+
+	step, ok := CheckCode(secret, code, now, 0)
+	aTest.MustBeEqual(ok, true)
+	aTest.MustBeEqual(step, TimeStep(now))
+
+	// A clock of a device is late.
+	_, ok = CheckCode(secret, code, now.Add(PeriodSec*time.Second), 0)
+	aTest.MustBeEqual(ok, true)
+
+	// Too late.
+	_, ok = CheckCode(secret, code, now.Add(2*PeriodSec*time.Second), 0)
+	aTest.MustBeEqual(ok, false)
+
+	// The password has already been used.
+	_, ok = CheckCode(secret, code, now, step)
+	aTest.MustBeEqual(ok, false)
+
+	_, ok = CheckCode(secret, "12345", now, 0)
+	aTest.MustBeEqual(ok, false)
+}
+
+func Test_MakeProvisioningUri(t *testing.T) {
+	aTest := tester.New(t)
+
+	s := MakeProvisioningUri("Test Site", "john@example.org", []byte("12345678901234567890"))
+	u, err := url.Parse(s)
+	aTest.MustBeNoError(err)
+	aTest.MustBeEqual(u.Scheme, "otpauth")
+	aTest.MustBeEqual(u.Host, "totp")
+	aTest.MustBeEqual(u.Path, "/Test Site:john@example.org")
+	aTest.MustBeEqual(u.Query().Get("secret"), "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ")
+	aTest.MustBeEqual(u.Query().Get("issuer"), "Test Site")
+}
+
+func Test_HashRecoveryCode(t *testing.T) {
+	aTest := tester.New(t)
+
+	aTest.MustBeEqual(HashRecoveryCode("ab12-cd34"), HashRecoveryCode(" AB12CD34 "))
+}
@@ -12,6 +12,16 @@ const (
 	// IP address list.
 	FuncBlockIPAddress     = "BlockIPAddress"
 	FuncIsIPAddressBlocked = "IsIPAddressBlocked"
+	FuncUnblockIPAddress   = "UnblockIPAddress"
+	FuncListIPBlocks       = "ListIPBlocks"
+
+	// Permanent lists of IP addresses.
+	FuncAddIPListEntry    = "AddIPListEntry"
+	FuncDeleteIPListEntry = "DeleteIPListEntry"
+	FuncListIPListEntries = "ListIPListEntries"
+
+	// Notifications.
+	FuncPublishNotification = "PublishNotification"
 
 	// Other.
 	FuncShowDiagnosticData = cc.FuncShowDiagnosticData
@@ -39,7 +39,9 @@ func (dbo *DatabaseObject) Init() (err error) {
 
 func (dbo *DatabaseObject) initTableNames() {
 	dbo.tableNames = &TableNames{
-		IPAddresses: dbo.prefixTableName(TableIPAddresses),
+		IPBlocks:       dbo.prefixTableName(TableIPBlocks),
+		IPListEntries:  dbo.prefixTableName(TableIPListEntries),
+		SubnetOffences: dbo.prefixTableName(TableSubnetOffences),
 	}
 }
 
@@ -1,9 +1,13 @@
 package dbo
 
 const (
-	TableIPAddresses = "IPAddresses"
+	TableIPBlocks       = "IPBlocks"
+	TableIPListEntries  = "IPListEntries"
+	TableSubnetOffences = "SubnetOffences"
 )
 
 type TableNames struct {
-	IPAddresses string
+	IPBlocks       string
+	IPListEntries  string
+	SubnetOffences string
 }
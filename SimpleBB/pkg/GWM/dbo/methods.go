@@ -8,10 +8,47 @@ import (
 	dbo2 "github.com/vault-thirteen/SimpleBB/pkg/common/models/dbo"
 	cms "github.com/vault-thirteen/SimpleBB/pkg/common/models/sql"
 	"net"
+
+	gm "github.com/vault-thirteen/SimpleBB/pkg/GWM/models"
+	ae "github.com/vault-thirteen/auxie/errors"
 )
 
+func (dbo *DatabaseObject) AddIPListEntry(listType gm.IPListType, cidr string, firstIPAB net.IP, lastIPAB net.IP, comment cmb.Text) (err error) {
+	var result sql.Result
+	result, err = dbo.PreparedStatement(DbPsid_AddIPListEntry).Exec(listType, cidr, firstIPAB, lastIPAB, comment)
+	if err != nil {
+		return err
+	}
+
+	return dbo2.CheckRowsAffected(result, 1)
+}
+
+func (dbo *DatabaseObject) CountBlocks() (n cmb.Count, err error) {
+	row := dbo.PreparedStatement(DbPsid_CountBlocks).QueryRow()
+
+	n, err = cms.NewNonNullValueFromScannableSource[cmb.Count](row)
+	if err != nil {
+		return dbo2.CountOnError, err
+	}
+
+	return n, nil
+}
+
+func (dbo *DatabaseObject) CountBlocksByCidr(cidr string) (n cmb.Count, err error) {
+	row := dbo.PreparedStatement(DbPsid_CountBlocksByCidr).QueryRow(cidr)
+
+	n, err = cms.NewNonNullValueFromScannableSource[cmb.Count](row)
+	if err != nil {
+		return dbo2.CountOnError, err
+	}
+
+	return n, nil
+}
+
+// CountBlocksByIPAddress counts active blocks of all ranges containing the IP
+// address.
 func (dbo *DatabaseObject) CountBlocksByIPAddress(ipa net.IP) (n cmb.Count, err error) {
-	row := dbo.PreparedStatement(DbPsid_CountBlocksByIPAddress).QueryRow(ipa)
+	row := dbo.PreparedStatement(DbPsid_CountBlocksByIPAddress).QueryRow(ipa.To16())
 
 	n, err = cms.NewNonNullValueFromScannableSource[cmb.Count](row)
 	if err != nil {
@@ -21,9 +58,20 @@ func (dbo *DatabaseObject) CountBlocksByIPAddress(ipa net.IP) (n cmb.Count, err
 	return n, nil
 }
 
-func (dbo *DatabaseObject) InsertBlock(ipa net.IP, durationSec cmb.Count) (err error) {
+func (dbo *DatabaseObject) CountIPListEntriesByCidr(listType gm.IPListType, cidr string) (n cmb.Count, err error) {
+	row := dbo.PreparedStatement(DbPsid_CountIPListEntriesByCidr).QueryRow(listType, cidr)
+
+	n, err = cms.NewNonNullValueFromScannableSource[cmb.Count](row)
+	if err != nil {
+		return dbo2.CountOnError, err
+	}
+
+	return n, nil
+}
+
+func (dbo *DatabaseObject) DeleteBlockByCidr(cidr string) (err error) {
 	var result sql.Result
-	result, err = dbo.PreparedStatement(DbPsid_AddBlock).Exec(ipa, durationSec)
+	result, err = dbo.PreparedStatement(DbPsid_DeleteBlockByCidr).Exec(cidr)
 	if err != nil {
 		return err
 	}
@@ -31,12 +79,102 @@ func (dbo *DatabaseObject) InsertBlock(ipa net.IP, durationSec cmb.Count) (err e
 	return dbo2.CheckRowsAffected(result, 1)
 }
 
-func (dbo *DatabaseObject) IncreaseBlockDuration(ipa net.IP, deltaDurationSec cmb.Count) (err error) {
+func (dbo *DatabaseObject) DeleteIPListEntry(listType gm.IPListType, cidr string) (err error) {
 	var result sql.Result
-	result, err = dbo.PreparedStatement(DbPsid_IncreaseBlockDuration).Exec(deltaDurationSec, ipa)
+	result, err = dbo.PreparedStatement(DbPsid_DeleteIPListEntry).Exec(listType, cidr)
 	if err != nil {
 		return err
 	}
 
 	return dbo2.CheckRowsAffected(result, 1)
 }
+
+// GetIPListTypeByIPAddress returns the type of list containing the IP
+// address. When the address is in both lists, the allow-list is returned.
+// Zero is returned when the address is in none of the lists.
+func (dbo *DatabaseObject) GetIPListTypeByIPAddress(ipa net.IP) (listType gm.IPListType, err error) {
+	row := dbo.PreparedStatement(DbPsid_GetIPListTypeByIPAddress).QueryRow(ipa.To16())
+
+	listType, err = cms.NewNonNullValueFromScannableSource[gm.IPListType](row)
+	if err != nil {
+		return 0, err
+	}
+
+	return listType, nil
+}
+
+func (dbo *DatabaseObject) GetSubnetOffencesCount(subnet string) (n cmb.Count, err error) {
+	row := dbo.PreparedStatement(DbPsid_GetSubnetOffencesCount).QueryRow(subnet)
+
+	n, err = cms.NewNonNullValueFromScannableSource[cmb.Count](row)
+	if err != nil {
+		return dbo2.CountOnError, err
+	}
+
+	return n, nil
+}
+
+func (dbo *DatabaseObject) IncreaseBlockDuration(cidr string, deltaDurationSec cmb.Count) (err error) {
+	var result sql.Result
+	result, err = dbo.PreparedStatement(DbPsid_IncreaseBlockDuration).Exec(deltaDurationSec, cidr)
+	if err != nil {
+		return err
+	}
+
+	return dbo2.CheckRowsAffected(result, 1)
+}
+
+func (dbo *DatabaseObject) InsertBlock(cidr string, firstIPAB net.IP, lastIPAB net.IP, durationSec cmb.Count) (err error) {
+	var result sql.Result
+	result, err = dbo.PreparedStatement(DbPsid_AddBlock).Exec(cidr, firstIPAB, lastIPAB, durationSec)
+	if err != nil {
+		return err
+	}
+
+	return dbo2.CheckRowsAffected(result, 1)
+}
+
+func (dbo *DatabaseObject) ReadBlocksOnPage(pageNumber cmb.Count, pageSize cmb.Count) (blocks []gm.IPBlock, err error) {
+	var rows *sql.Rows
+	rows, err = dbo.PreparedStatement(DbPsid_ReadBlocksOnPage).Query(pageSize, (pageNumber-1)*pageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		derr := rows.Close()
+		if derr != nil {
+			err = ae.Combine(err, derr)
+		}
+	}()
+
+	return gm.NewIPBlockArrayFromRows(rows)
+}
+
+func (dbo *DatabaseObject) ReadIPListEntries() (entries []gm.IPListEntry, err error) {
+	var rows *sql.Rows
+	rows, err = dbo.PreparedStatement(DbPsid_ReadIPListEntries).Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		derr := rows.Close()
+		if derr != nil {
+			err = ae.Combine(err, derr)
+		}
+	}()
+
+	return gm.NewIPListEntryArrayFromRows(rows)
+}
+
+// RegisterSubnetOffence increases the counter of offences of the subnet.
+// The counter is created when it does not exist.
+func (dbo *DatabaseObject) RegisterSubnetOffence(subnet string) (err error) {
+	_, err = dbo.PreparedStatement(DbPsid_RegisterSubnetOffence).Exec(subnet)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
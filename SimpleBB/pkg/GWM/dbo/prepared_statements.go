@@ -7,10 +7,22 @@ import (
 
 // Indices of prepared statements.
 const (
-	DbPsid_CountBlocksByIPAddress = 0
-	DbPsid_AddBlock               = 1
-	DbPsid_IncreaseBlockDuration  = 2
-	DbPsid_ClearIPAddresses       = 3
+	DbPsid_CountBlocksByIPAddress   = 0
+	DbPsid_AddBlock                 = 1
+	DbPsid_IncreaseBlockDuration    = 2
+	DbPsid_ClearIPBlocks            = 3
+	DbPsid_CountBlocksByCidr        = 4
+	DbPsid_CountBlocks              = 5
+	DbPsid_ReadBlocksOnPage         = 6
+	DbPsid_DeleteBlockByCidr        = 7
+	DbPsid_AddIPListEntry           = 8
+	DbPsid_DeleteIPListEntry        = 9
+	DbPsid_ReadIPListEntries        = 10
+	DbPsid_GetIPListTypeByIPAddress = 11
+	DbPsid_RegisterSubnetOffence    = 12
+	DbPsid_GetSubnetOffencesCount   = 13
+	DbPsid_ClearSubnetOffences      = 14
+	DbPsid_CountIPListEntriesByCidr = 15
 )
 
 func (dbo *DatabaseObject) makePreparedStatementQueryStrings() (qs []string) {
@@ -18,19 +30,67 @@ func (dbo *DatabaseObject) makePreparedStatementQueryStrings() (qs []string) {
 	qs = make([]string, 0)
 
 	// 0.
-	q = fmt.Sprintf(`SELECT COUNT(Id) FROM %s WHERE UserIPAB = ?;`, dbo.tableNames.IPAddresses)
+	q = fmt.Sprintf(`SELECT COUNT(Id) FROM %s WHERE (? BETWEEN FirstIPAB AND LastIPAB) AND (ExpirationTime > Now());`, dbo.tableNames.IPBlocks)
 	qs = append(qs, q)
 
 	// 1.
-	q = fmt.Sprintf(`INSERT INTO %s (UserIPAB, TIME) VALUES (?, DATE_ADD(Now(), INTERVAL ? SECOND));`, dbo.tableNames.IPAddresses)
+	q = fmt.Sprintf(`INSERT INTO %s (Cidr, FirstIPAB, LastIPAB, ExpirationTime) VALUES (?, ?, ?, DATE_ADD(Now(), INTERVAL ? SECOND));`, dbo.tableNames.IPBlocks)
 	qs = append(qs, q)
 
 	// 2.
-	q = fmt.Sprintf(`UPDATE %s SET TIME = DATE_ADD(TIME, INTERVAL ? SECOND) WHERE UserIPAB = ?;`, dbo.tableNames.IPAddresses)
+	q = fmt.Sprintf(`UPDATE %s SET ExpirationTime = DATE_ADD(GREATEST(ExpirationTime, Now()), INTERVAL ? SECOND) WHERE Cidr = ?;`, dbo.tableNames.IPBlocks)
 	qs = append(qs, q)
 
 	// 3.
-	q = fmt.Sprintf(`DELETE FROM %s WHERE TIME < Now();`, dbo.tableNames.IPAddresses)
+	q = fmt.Sprintf(`DELETE FROM %s WHERE ExpirationTime < Now();`, dbo.tableNames.IPBlocks)
+	qs = append(qs, q)
+
+	// 4.
+	q = fmt.Sprintf(`SELECT COUNT(Id) FROM %s WHERE Cidr = ?;`, dbo.tableNames.IPBlocks)
+	qs = append(qs, q)
+
+	// 5.
+	q = fmt.Sprintf(`SELECT COUNT(Id) FROM %s;`, dbo.tableNames.IPBlocks)
+	qs = append(qs, q)
+
+	// 6.
+	q = fmt.Sprintf(`SELECT Id, Cidr, TimeOfCreation, ExpirationTime FROM %s ORDER BY Id DESC LIMIT ? OFFSET ?;`, dbo.tableNames.IPBlocks)
+	qs = append(qs, q)
+
+	// 7.
+	q = fmt.Sprintf(`DELETE FROM %s WHERE Cidr = ?;`, dbo.tableNames.IPBlocks)
+	qs = append(qs, q)
+
+	// 8.
+	q = fmt.Sprintf(`INSERT INTO %s (ListType, Cidr, FirstIPAB, LastIPAB, Comment) VALUES (?, ?, ?, ?, ?);`, dbo.tableNames.IPListEntries)
+	qs = append(qs, q)
+
+	// 9.
+	q = fmt.Sprintf(`DELETE FROM %s WHERE ListType = ? AND Cidr = ?;`, dbo.tableNames.IPListEntries)
+	qs = append(qs, q)
+
+	// 10.
+	q = fmt.Sprintf(`SELECT Id, ListType, Cidr, Comment, TimeOfCreation FROM %s ORDER BY ListType, Id;`, dbo.tableNames.IPListEntries)
+	qs = append(qs, q)
+
+	// 11.
+	q = fmt.Sprintf(`SELECT COALESCE(MIN(ListType), 0) FROM %s WHERE ? BETWEEN FirstIPAB AND LastIPAB;`, dbo.tableNames.IPListEntries)
+	qs = append(qs, q)
+
+	// 12.
+	q = fmt.Sprintf(`INSERT INTO %s (Subnet) VALUES (?) ON DUPLICATE KEY UPDATE OffencesCount = OffencesCount + 1, LastOffenceTime = Now();`, dbo.tableNames.SubnetOffences)
+	qs = append(qs, q)
+
+	// 13.
+	q = fmt.Sprintf(`SELECT OffencesCount FROM %s WHERE Subnet = ?;`, dbo.tableNames.SubnetOffences)
+	qs = append(qs, q)
+
+	// 14.
+	q = fmt.Sprintf(`DELETE FROM %s WHERE LastOffenceTime < DATE_SUB(Now(), INTERVAL ? SECOND);`, dbo.tableNames.SubnetOffences)
+	qs = append(qs, q)
+
+	// 15.
+	q = fmt.Sprintf(`SELECT COUNT(Id) FROM %s WHERE ListType = ? AND Cidr = ?;`, dbo.tableNames.IPListEntries)
 	qs = append(qs, q)
 
 	return qs
@@ -0,0 +1,75 @@
+package models
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/vault-thirteen/SimpleBB/pkg/common/interfaces/base1"
+	cmb "github.com/vault-thirteen/SimpleBB/pkg/common/models/base"
+	"time"
+)
+
+// IPBlock is a temporary block of a range of IP addresses. A block of a
+// single address is a range of one address.
+type IPBlock struct {
+	Id cmb.Id `json:"id"`
+
+	// Range of blocked addresses in the CIDR notation.
+	Cidr string `json:"cidr"`
+
+	TimeOfCreation time.Time `json:"timeOfCreation"`
+	ExpirationTime time.Time `json:"expirationTime"`
+}
+
+func NewIPBlock() (ib *IPBlock) {
+	return &IPBlock{}
+}
+
+func NewIPBlockFromScannableSource(src base.IScannable) (ib *IPBlock, err error) {
+	ib = NewIPBlock()
+
+	err = src.Scan(
+		&ib.Id,
+		&ib.Cidr,
+		&ib.TimeOfCreation,
+		&ib.ExpirationTime,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		} else {
+			return nil, err
+		}
+	}
+
+	return ib, nil
+}
+
+func NewIPBlockArrayFromRows(rows base.IScannableSequence) (ibs []IPBlock, err error) {
+	ibs = []IPBlock{}
+	var ib *IPBlock
+
+	for rows.Next() {
+		ib, err = NewIPBlockFromScannableSource(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		ibs = append(ibs, *ib)
+	}
+
+	return ibs, nil
+}
+
+// EscalateBlockTime doubles the block time for each previous offence of a
+// subnet. The result does not exceed the maximum block time.
+func EscalateBlockTime(blockTimeSec cmb.Count, offencesCount cmb.Count, maxBlockTimeSec cmb.Count) cmb.Count {
+	for i := cmb.Count(1); (i < offencesCount) && (blockTimeSec < maxBlockTimeSec); i++ {
+		blockTimeSec *= 2
+	}
+
+	if blockTimeSec > maxBlockTimeSec {
+		return maxBlockTimeSec
+	}
+
+	return blockTimeSec
+}
@@ -0,0 +1,18 @@
+package models
+
+import (
+	"testing"
+
+	cmb "github.com/vault-thirteen/SimpleBB/pkg/common/models/base"
+	"github.com/vault-thirteen/auxie/tester"
+)
+
+func Test_EscalateBlockTime(t *testing.T) {
+	aTest := tester.New(t)
+
+	aTest.MustBeEqual(EscalateBlockTime(60, 1, 3600), cmb.Count(60))
+	aTest.MustBeEqual(EscalateBlockTime(60, 2, 3600), cmb.Count(120))
+	aTest.MustBeEqual(EscalateBlockTime(60, 4, 3600), cmb.Count(480))
+	aTest.MustBeEqual(EscalateBlockTime(60, 100, 3600), cmb.Count(3600))
+	aTest.MustBeEqual(EscalateBlockTime(7200, 1, 3600), cmb.Count(3600))
+}
@@ -0,0 +1,80 @@
+package models
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/vault-thirteen/SimpleBB/pkg/common/interfaces/base1"
+	cmb "github.com/vault-thirteen/SimpleBB/pkg/common/models/base"
+	"time"
+)
+
+// IPListType is a type of permanent list of IP addresses.
+type IPListType = cmb.Count
+
+const (
+	// IPListType_Allow is a list of addresses which are never blocked.
+	IPListType_Allow = 1
+
+	// IPListType_Deny is a list of addresses which are always blocked.
+	IPListType_Deny = 2
+
+	IPListTypeMax = IPListType_Deny
+)
+
+// IPListEntry is a range of IP addresses in a permanent list. When an
+// address is in both lists, the allow-list wins.
+type IPListEntry struct {
+	Id       cmb.Id     `json:"id"`
+	ListType IPListType `json:"listType"`
+
+	// Range of addresses in the CIDR notation.
+	Cidr string `json:"cidr"`
+
+	Comment        cmb.Text  `json:"comment"`
+	TimeOfCreation time.Time `json:"timeOfCreation"`
+}
+
+func IsIPListTypeValid(lt IPListType) bool {
+	return (lt >= IPListType_Allow) && (lt <= IPListTypeMax)
+}
+
+func NewIPListEntry() (ile *IPListEntry) {
+	return &IPListEntry{}
+}
+
+func NewIPListEntryFromScannableSource(src base.IScannable) (ile *IPListEntry, err error) {
+	ile = NewIPListEntry()
+
+	err = src.Scan(
+		&ile.Id,
+		&ile.ListType,
+		&ile.Cidr,
+		&ile.Comment,
+		&ile.TimeOfCreation,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		} else {
+			return nil, err
+		}
+	}
+
+	return ile, nil
+}
+
+func NewIPListEntryArrayFromRows(rows base.IScannableSequence) (iles []IPListEntry, err error) {
+	iles = []IPListEntry{}
+	var ile *IPListEntry
+
+	for rows.Next() {
+		ile, err = NewIPListEntryFromScannableSource(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		iles = append(iles, *ile)
+	}
+
+	return iles, nil
+}
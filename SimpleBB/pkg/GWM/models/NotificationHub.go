@@ -0,0 +1,150 @@
+package models
+
+import (
+	"errors"
+	nm "github.com/vault-thirteen/SimpleBB/pkg/NM/models"
+	base2 "github.com/vault-thirteen/SimpleBB/pkg/common/models/base"
+	"sync"
+)
+
+const (
+	// NotificationStreamBufferSize is the number of notifications which may
+	// wait for delivery in a single stream. When a stream can not keep up,
+	// it is closed and the client must reconnect using the 'Last-Event-ID'.
+	NotificationStreamBufferSize = 16
+
+	// NotificationStreamsPerUserMax is the maximal number of simultaneous
+	// streams of a single user, i.e. the number of open browser tabs.
+	NotificationStreamsPerUserMax = 16
+)
+
+const (
+	ErrNotificationHubIsClosed = "notification hub is closed"
+	ErrTooManyStreams          = "too many streams"
+)
+
+// NotificationStream is a subscription of a single client connection to
+// notifications of a user.
+type NotificationStream struct {
+	UserId base2.Id
+
+	// Channel with notifications. It is closed by the hub when the stream is
+	// dropped, or when the hub itself is closed.
+	C chan *nm.Notification
+}
+
+// NotificationHub distributes notifications among all the streams of their
+// recipients.
+type NotificationHub struct {
+	guard         sync.Mutex
+	isClosed      bool
+	streamsByUser map[base2.Id]map[*NotificationStream]bool
+}
+
+func NewNotificationHub() (nh *NotificationHub) {
+	return &NotificationHub{
+		streamsByUser: make(map[base2.Id]map[*NotificationStream]bool),
+	}
+}
+
+// Subscribe creates a new stream for the user.
+func (nh *NotificationHub) Subscribe(userId base2.Id) (ns *NotificationStream, err error) {
+	nh.guard.Lock()
+	defer nh.guard.Unlock()
+
+	if nh.isClosed {
+		return nil, errors.New(ErrNotificationHubIsClosed)
+	}
+
+	streams, ok := nh.streamsByUser[userId]
+	if !ok {
+		streams = make(map[*NotificationStream]bool)
+		nh.streamsByUser[userId] = streams
+	}
+
+	if len(streams) >= NotificationStreamsPerUserMax {
+		return nil, errors.New(ErrTooManyStreams)
+	}
+
+	ns = &NotificationStream{
+		UserId: userId,
+		C:      make(chan *nm.Notification, NotificationStreamBufferSize),
+	}
+	streams[ns] = true
+
+	return ns, nil
+}
+
+// Unsubscribe removes the stream from the hub. It is safe to unsubscribe a
+// stream which has already been dropped by the hub.
+func (nh *NotificationHub) Unsubscribe(ns *NotificationStream) {
+	nh.guard.Lock()
+	defer nh.guard.Unlock()
+
+	nh.dropStream(ns)
+}
+
+// Publish sends the notification to all streams of its recipient and returns
+// the number of streams which have received it. Streams which are not able to
+// receive the notification immediately are dropped.
+func (nh *NotificationHub) Publish(n *nm.Notification) (streamsCount base2.Count) {
+	nh.guard.Lock()
+	defer nh.guard.Unlock()
+
+	for ns := range nh.streamsByUser[n.UserId] {
+		select {
+		case ns.C <- n:
+			streamsCount++
+		default:
+			nh.dropStream(ns)
+		}
+	}
+
+	return streamsCount
+}
+
+// CountStreams returns the number of active streams of the user.
+func (nh *NotificationHub) CountStreams(userId base2.Id) (n base2.Count) {
+	nh.guard.Lock()
+	defer nh.guard.Unlock()
+
+	return base2.Count(len(nh.streamsByUser[userId]))
+}
+
+// Close drops all the streams and forbids new subscriptions.
+func (nh *NotificationHub) Close() {
+	nh.guard.Lock()
+	defer nh.guard.Unlock()
+
+	if nh.isClosed {
+		return
+	}
+
+	for _, streams := range nh.streamsByUser {
+		for ns := range streams {
+			nh.dropStream(ns)
+		}
+	}
+
+	nh.isClosed = true
+}
+
+// dropStream closes the stream and removes it from the hub.
+// The caller must hold the lock.
+func (nh *NotificationHub) dropStream(ns *NotificationStream) {
+	streams, ok := nh.streamsByUser[ns.UserId]
+	if !ok {
+		return
+	}
+
+	if !streams[ns] {
+		return
+	}
+
+	close(ns.C)
+	delete(streams, ns)
+
+	if len(streams) == 0 {
+		delete(nh.streamsByUser, ns.UserId)
+	}
+}
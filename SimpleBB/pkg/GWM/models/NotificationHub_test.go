@@ -0,0 +1,85 @@
+package models
+
+import (
+	"testing"
+
+	nm "github.com/vault-thirteen/SimpleBB/pkg/NM/models"
+	base2 "github.com/vault-thirteen/SimpleBB/pkg/common/models/base"
+	"github.com/vault-thirteen/auxie/tester"
+)
+
+func Test_NotificationHub_Publish(t *testing.T) {
+	aTest := tester.New(t)
+	nh := NewNotificationHub()
+
+	// Two tabs of one user and a tab of another user.
+	tab1, err := nh.Subscribe(1)
+	aTest.MustBeNoError(err)
+	tab2, err := nh.Subscribe(1)
+	aTest.MustBeNoError(err)
+	tab3, err := nh.Subscribe(2)
+	aTest.MustBeNoError(err)
+	aTest.MustBeEqual(nh.CountStreams(1), base2.Count(2))
+
+	n := &nm.Notification{Id: 10, UserId: 1, Text: "Hello"}
+	aTest.MustBeEqual(nh.Publish(n), base2.Count(2))
+	aTest.MustBeEqual(<-tab1.C, n)
+	aTest.MustBeEqual(<-tab2.C, n)
+	aTest.MustBeEqual(len(tab3.C), 0)
+
+	// Nobody listens.
+	aTest.MustBeEqual(nh.Publish(&nm.Notification{Id: 11, UserId: 3}), base2.Count(0))
+
+	// Unsubscription closes the stream, the second one is harmless.
+	nh.Unsubscribe(tab1)
+	nh.Unsubscribe(tab1)
+	_, ok := <-tab1.C
+	aTest.MustBeEqual(ok, false)
+	aTest.MustBeEqual(nh.CountStreams(1), base2.Count(1))
+}
+
+func Test_NotificationHub_SlowStream(t *testing.T) {
+	aTest := tester.New(t)
+	nh := NewNotificationHub()
+
+	ns, err := nh.Subscribe(1)
+	aTest.MustBeNoError(err)
+
+	for i := 1; i <= NotificationStreamBufferSize; i++ {
+		aTest.MustBeEqual(nh.Publish(&nm.Notification{Id: base2.Id(i), UserId: 1}), base2.Count(1))
+	}
+
+	// The buffer is full, so the stream is dropped.
+	aTest.MustBeEqual(nh.Publish(&nm.Notification{Id: 100, UserId: 1}), base2.Count(0))
+	aTest.MustBeEqual(nh.CountStreams(1), base2.Count(0))
+
+	// Buffered notifications are still readable.
+	var count int
+	for range ns.C {
+		count++
+	}
+	aTest.MustBeEqual(count, NotificationStreamBufferSize)
+}
+
+func Test_NotificationHub_Limits(t *testing.T) {
+	aTest := tester.New(t)
+	nh := NewNotificationHub()
+
+	for i := 0; i < NotificationStreamsPerUserMax; i++ {
+		_, err := nh.Subscribe(1)
+		aTest.MustBeNoError(err)
+	}
+	_, err := nh.Subscribe(1)
+	aTest.MustBeAnError(err)
+
+	ns, err := nh.Subscribe(2)
+	aTest.MustBeNoError(err)
+
+	nh.Close()
+	_, ok := <-ns.C
+	aTest.MustBeEqual(ok, false)
+	aTest.MustBeEqual(nh.CountStreams(1), base2.Count(0))
+
+	_, err = nh.Subscribe(2)
+	aTest.MustBeAnError(err)
+}
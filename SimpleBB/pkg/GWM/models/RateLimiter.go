@@ -0,0 +1,96 @@
+package models
+
+import (
+	base2 "github.com/vault-thirteen/SimpleBB/pkg/common/models/base"
+	"math"
+	"sync"
+	"time"
+)
+
+// tokenBucket is a bucket of tokens which is refilled at a constant rate.
+// Tokens are counted lazily, i.e. the bucket is refilled when it is used.
+type tokenBucket struct {
+	tokens     float64
+	capacity   float64
+	refillRate float64 // Tokens per second.
+	lastTime   time.Time
+}
+
+func (tb *tokenBucket) refill(now time.Time) {
+	elapsed := now.Sub(tb.lastTime).Seconds()
+	if elapsed > 0 {
+		tb.tokens = math.Min(tb.capacity, tb.tokens+elapsed*tb.refillRate)
+		tb.lastTime = now
+	}
+}
+
+// RateLimiter is a set of token buckets identified by keys. Buckets are
+// created when they are used for the first time.
+type RateLimiter struct {
+	guard   sync.Mutex
+	buckets map[string]*tokenBucket
+}
+
+func NewRateLimiter() (rl *RateLimiter) {
+	return &RateLimiter{
+		buckets: make(map[string]*tokenBucket),
+	}
+}
+
+// Take takes a token from the bucket. When the bucket is empty, no token is
+// taken and the time after which a token will be available is returned. The
+// time is rounded up to whole seconds. A change of the limit is applied to an
+// existing bucket.
+func (rl *RateLimiter) Take(key string, capacity base2.Count, refillPerMinute base2.Count, now time.Time) (ok bool, retryAfter time.Duration) {
+	rl.guard.Lock()
+	defer rl.guard.Unlock()
+
+	tb, exists := rl.buckets[key]
+	if !exists {
+		tb = &tokenBucket{
+			tokens:   float64(capacity),
+			lastTime: now,
+		}
+		rl.buckets[key] = tb
+	}
+
+	tb.capacity = float64(capacity)
+	tb.refillRate = float64(refillPerMinute) / 60
+	tb.refill(now)
+
+	if tb.tokens >= 1 {
+		tb.tokens--
+		return true, 0
+	}
+
+	if tb.refillRate <= 0 {
+		return false, time.Duration(math.MaxInt64)
+	}
+
+	// Waiting time is rounded up to whole seconds, while errors of floating
+	// point arithmetic are rounded off.
+	seconds := math.Ceil(math.Round((1-tb.tokens)/tb.refillRate*1000) / 1000)
+	return false, time.Duration(seconds) * time.Second
+}
+
+// Clear removes full buckets. A full bucket is not different from a bucket
+// which does not exist.
+func (rl *RateLimiter) Clear(now time.Time) {
+	rl.guard.Lock()
+	defer rl.guard.Unlock()
+
+	for key, tb := range rl.buckets {
+		tb.refill(now)
+		if tb.tokens >= tb.capacity {
+			delete(rl.buckets, key)
+		}
+	}
+}
+
+// GetBucketsCount returns the number of existing buckets.
+func (rl *RateLimiter) GetBucketsCount() (n base2.Count) {
+	rl.guard.Lock()
+	defer rl.guard.Unlock()
+
+	return base2.Count(len(rl.buckets))
+}
@@ -0,0 +1,44 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/vault-thirteen/auxie/tester"
+)
+
+func Test_RateLimiter_Take(t *testing.T) {
+	aTest := tester.New(t)
+	rl := NewRateLimiter()
+	t0 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// A burst of three requests, then one request per 10 seconds.
+	for i := 0; i < 3; i++ {
+		ok, _ := rl.Take("a", 3, 6, t0)
+		aTest.MustBeEqual(ok, true)
+	}
+
+	ok, retryAfter := rl.Take("a", 3, 6, t0)
+	aTest.MustBeEqual(ok, false)
+	aTest.MustBeEqual(retryAfter, 10*time.Second)
+
+	// Buckets are independent.
+	ok, _ = rl.Take("b", 3, 6, t0)
+	aTest.MustBeEqual(ok, true)
+
+	ok, retryAfter = rl.Take("a", 3, 6, t0.Add(4*time.Second))
+	aTest.MustBeEqual(ok, false)
+	aTest.MustBeEqual(retryAfter, 6*time.Second)
+
+	ok, _ = rl.Take("a", 3, 6, t0.Add(10*time.Second))
+	aTest.MustBeEqual(ok, true)
+	ok, _ = rl.Take("a", 3, 6, t0.Add(10*time.Second))
+	aTest.MustBeEqual(ok, false)
+
+	// Full buckets are removed.
+	aTest.MustBeEqual(rl.GetBucketsCount().AsInt(), 2)
+	rl.Clear(t0.Add(10 * time.Second))
+	aTest.MustBeEqual(rl.GetBucketsCount().AsInt(), 1)
+	rl.Clear(t0.Add(time.Minute))
+	aTest.MustBeEqual(rl.GetBucketsCount().AsInt(), 0)
+}
@@ -0,0 +1,59 @@
+package models
+
+import (
+	base2 "github.com/vault-thirteen/SimpleBB/pkg/common/models/base"
+	"sync"
+	"time"
+)
+
+// CachedRole is a role of a user which is known for some time.
+type CachedRole struct {
+	UserId         base2.Id
+	Role           string
+	ExpirationTime time.Time
+}
+
+// RoleCache stores roles of users by their authorisation tokens, so that
+// roles are not requested for each request of a user.
+type RoleCache struct {
+	guard sync.Mutex
+	roles map[string]CachedRole
+}
+
+func NewRoleCache() (rc *RoleCache) {
+	return &RoleCache{
+		roles: make(map[string]CachedRole),
+	}
+}
+
+// Get returns a role of the token unless it has expired.
+func (rc *RoleCache) Get(token string, now time.Time) (cr CachedRole, ok bool) {
+	rc.guard.Lock()
+	defer rc.guard.Unlock()
+
+	cr, ok = rc.roles[token]
+	if !ok || now.After(cr.ExpirationTime) {
+		return CachedRole{}, false
+	}
+
+	return cr, true
+}
+
+func (rc *RoleCache) Set(token string, cr CachedRole) {
+	rc.guard.Lock()
+	defer rc.guard.Unlock()
+
+	rc.roles[token] = cr
+}
+
+// Clear removes expired roles.
+func (rc *RoleCache) Clear(now time.Time) {
+	rc.guard.Lock()
+	defer rc.guard.Unlock()
+
+	for token, cr := range rc.roles {
+		if now.After(cr.ExpirationTime) {
+			delete(rc.roles, token)
+		}
+	}
+}
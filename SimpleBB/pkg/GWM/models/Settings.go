@@ -16,6 +16,8 @@ type Settings struct {
 	PageSize                  base2.Count `json:"pageSize"`
 	ApiFolder                 cm.Path     `json:"apiFolder"`
 	PublicSettingsFileName    cm.Path     `json:"publicSettingsFileName"`
+	EventsFolder              cm.Path     `json:"eventsFolder"`
+	AttachmentsFolder         cm.Path     `json:"attachmentsFolder"`
 	IsFrontEndEnabled         base2.Flag  `json:"isFrontEndEnabled"`
 	FrontEndStaticFilesFolder cm.Path     `json:"frontEndStaticFilesFolder"`
 	NotificationCountLimit    base2.Count `json:"notificationCountLimit"`
@@ -1,6 +1,8 @@
 package rpc
 
 import (
+	gm "github.com/vault-thirteen/SimpleBB/pkg/GWM/models"
+	nm "github.com/vault-thirteen/SimpleBB/pkg/NM/models"
 	base2 "github.com/vault-thirteen/SimpleBB/pkg/common/models/base"
 	rpc2 "github.com/vault-thirteen/SimpleBB/pkg/common/models/rpc"
 	cm "github.com/vault-thirteen/SimpleBB/pkg/common/models/simple"
@@ -22,7 +24,10 @@ type BlockIPAddressParams struct {
 	// Block time in seconds.
 	// This is a period for which the specified IP address will be blocked. If
 	// for some reason a record with the specified IP address already exists,
-	// this time will be added to an already existing value.
+	// this time will be added to an already existing value. The time is
+	// doubled for each recent block in the subnet of the address, and when
+	// there are too many of them, the whole subnet is blocked. Addresses of
+	// the allow-list are never blocked.
 	BlockTimeSec base2.Count `json:"blockTimeSec"`
 }
 type BlockIPAddressResult = rpc2.CommonResultWithSuccess
@@ -39,10 +44,79 @@ type IsIPAddressBlockedResult struct {
 	IsBlocked base2.Flag `json:"isBlocked"`
 }
 
+type UnblockIPAddressParams struct {
+	rpc2.CommonParams
+
+	// Blocked range of IP addresses in the CIDR notation. A single IP address
+	// is also accepted.
+	Cidr string `json:"cidr"`
+}
+type UnblockIPAddressResult = rpc2.CommonResultWithSuccess
+
+type ListIPBlocksParams struct {
+	rpc2.CommonParams
+
+	Page base2.Count `json:"page"`
+}
+type ListIPBlocksResult struct {
+	rpc2.CommonResult
+
+	IPBlocks []gm.IPBlock   `json:"ipBlocks"`
+	PageData *rpc2.PageData `json:"pageData,omitempty"`
+}
+
+// Permanent lists of IP addresses.
+
+type AddIPListEntryParams struct {
+	rpc2.CommonParams
+
+	ListType gm.IPListType `json:"listType"`
+
+	// Range of IP addresses in the CIDR notation. A single IP address is also
+	// accepted.
+	Cidr    string     `json:"cidr"`
+	Comment base2.Text `json:"comment"`
+}
+type AddIPListEntryResult = rpc2.CommonResultWithSuccess
+
+type DeleteIPListEntryParams struct {
+	rpc2.CommonParams
+
+	ListType gm.IPListType `json:"listType"`
+	Cidr     string        `json:"cidr"`
+}
+type DeleteIPListEntryResult = rpc2.CommonResultWithSuccess
+
+type ListIPListEntriesParams struct {
+	rpc2.CommonParams
+}
+type ListIPListEntriesResult struct {
+	rpc2.CommonResult
+
+	IPListEntries []gm.IPListEntry `json:"ipListEntries"`
+}
+
+// Notifications.
+
+type PublishNotificationParams struct {
+	rpc2.CommonParams
+
+	// Notification which is sent to all active event streams of its
+	// recipient.
+	Notification *nm.Notification `json:"notification"`
+}
+type PublishNotificationResult struct {
+	rpc2.CommonResult
+
+	// Number of event streams which have received the notification.
+	StreamsCount base2.Count `json:"streamsCount"`
+}
+
 // Other.
 
 type ShowDiagnosticDataParams struct{}
 type ShowDiagnosticDataResult struct {
 	rpc2.CommonResult
 	rpc2.RequestsCount
+	rpc2.ScheduledTasks
 }
@@ -31,6 +31,12 @@ func (srv *Server) initRpc() (err error) {
 		srv.Ping,
 		srv.BlockIPAddress,
 		srv.IsIPAddressBlocked,
+		srv.UnblockIPAddress,
+		srv.ListIPBlocks,
+		srv.AddIPListEntry,
+		srv.DeleteIPListEntry,
+		srv.ListIPListEntries,
+		srv.PublishNotification,
 		srv.ShowDiagnosticData,
 	}
 
@@ -89,6 +95,106 @@ func (srv *Server) IsIPAddressBlocked(params *json.RawMessage, _ *jrm1.ResponseM
 	return r, nil
 }
 
+func (srv *Server) UnblockIPAddress(params *json.RawMessage, _ *jrm1.ResponseMetaData) (result any, re *jrm1.RpcError) {
+	var p *gm.UnblockIPAddressParams
+	re = jrm1.ParseParameters(params, &p)
+	if re != nil {
+		return nil, re
+	}
+
+	var r *gm.UnblockIPAddressResult
+	r, re = srv.unblockIPAddress(p)
+	if re != nil {
+		return nil, re
+	}
+
+	return r, nil
+}
+
+func (srv *Server) ListIPBlocks(params *json.RawMessage, _ *jrm1.ResponseMetaData) (result any, re *jrm1.RpcError) {
+	var p *gm.ListIPBlocksParams
+	re = jrm1.ParseParameters(params, &p)
+	if re != nil {
+		return nil, re
+	}
+
+	var r *gm.ListIPBlocksResult
+	r, re = srv.listIPBlocks(p)
+	if re != nil {
+		return nil, re
+	}
+
+	return r, nil
+}
+
+// Permanent lists of IP addresses.
+
+func (srv *Server) AddIPListEntry(params *json.RawMessage, _ *jrm1.ResponseMetaData) (result any, re *jrm1.RpcError) {
+	var p *gm.AddIPListEntryParams
+	re = jrm1.ParseParameters(params, &p)
+	if re != nil {
+		return nil, re
+	}
+
+	var r *gm.AddIPListEntryResult
+	r, re = srv.addIPListEntry(p)
+	if re != nil {
+		return nil, re
+	}
+
+	return r, nil
+}
+
+func (srv *Server) DeleteIPListEntry(params *json.RawMessage, _ *jrm1.ResponseMetaData) (result any, re *jrm1.RpcError) {
+	var p *gm.DeleteIPListEntryParams
+	re = jrm1.ParseParameters(params, &p)
+	if re != nil {
+		return nil, re
+	}
+
+	var r *gm.DeleteIPListEntryResult
+	r, re = srv.deleteIPListEntry(p)
+	if re != nil {
+		return nil, re
+	}
+
+	return r, nil
+}
+
+func (srv *Server) ListIPListEntries(params *json.RawMessage, _ *jrm1.ResponseMetaData) (result any, re *jrm1.RpcError) {
+	var p *gm.ListIPListEntriesParams
+	re = jrm1.ParseParameters(params, &p)
+	if re != nil {
+		return nil, re
+	}
+
+	var r *gm.ListIPListEntriesResult
+	r, re = srv.listIPListEntries(p)
+	if re != nil {
+		return nil, re
+	}
+
+	return r, nil
+}
+
+// Notifications.
+
+func (srv *Server) PublishNotification(params *json.RawMessage, _ *jrm1.ResponseMetaData) (result any, re *jrm1.RpcError) {
+	var p *gm.PublishNotificationParams
+	re = jrm1.ParseParameters(params, &p)
+	if re != nil {
+		return nil, re
+	}
+
+	var r *gm.PublishNotificationResult
+	r, re = srv.publishNotification(p)
+	if re != nil {
+		return nil, re
+	}
+
+	return r, nil
+}
+
 // Other.
 
 func (srv *Server) ShowDiagnosticData(params *json.RawMessage, _ *jrm1.ResponseMetaData) (result any, re *jrm1.RpcError) {
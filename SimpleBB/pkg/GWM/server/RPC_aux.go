@@ -2,8 +2,12 @@ package server
 
 import (
 	"fmt"
+	gm "github.com/vault-thirteen/SimpleBB/pkg/GWM/models"
+	cmb "github.com/vault-thirteen/SimpleBB/pkg/common/models/base"
+	cn "github.com/vault-thirteen/SimpleBB/pkg/common/models/net"
 	server2 "github.com/vault-thirteen/SimpleBB/pkg/common/models/server"
 	"log"
+	"net"
 
 	jrm1 "github.com/vault-thirteen/JSON-RPC-M1"
 )
@@ -42,3 +46,109 @@ func (srv *Server) databaseError(err error) (re *jrm1.RpcError) {
 	srv.processDatabaseError(err)
 	return jrm1.NewRpcErrorByUser(server2.RpcErrorCode_Database, server2.RpcErrorMsg_Database, err)
 }
+
+// parseCidrParameter parses a range of IP addresses set in parameters of an
+// RPC request.
+func parseCidrParameter(cidr string) (ipNet *net.IPNet, re *jrm1.RpcError) {
+	if len(cidr) == 0 {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_CidrIsNotSet, RpcErrorMsg_CidrIsNotSet, nil)
+	}
+
+	ipNet, err := cn.ParseCidr(cidr)
+	if err != nil {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_CidrIsWrong, RpcErrorMsg_CidrIsWrong, nil)
+	}
+
+	return ipNet, nil
+}
+
+// checkIPAddressBlock checks whether the IP address is blocked. Addresses of
+// the allow-list are never blocked, addresses of the deny-list are always
+// blocked, other addresses are blocked while any range containing them is
+// blocked.
+func (srv *Server) checkIPAddressBlock(ipa net.IP) (isBlocked bool, err error) {
+	var listType gm.IPListType
+	listType, err = srv.dbo.GetIPListTypeByIPAddress(ipa)
+	if err != nil {
+		return false, err
+	}
+
+	switch listType {
+	case gm.IPListType_Allow:
+		return false, nil
+	case gm.IPListType_Deny:
+		return true, nil
+	}
+
+	var n cmb.Count
+	n, err = srv.dbo.CountBlocksByIPAddress(ipa)
+	if err != nil {
+		return false, err
+	}
+
+	return n > 0, nil
+}
+
+// blockOffender blocks the IP address and registers an offence of its
+// subnet. Block time is escalated by the number of recent offences in the
+// subnet. When the subnet has too many offences, it is blocked as a whole.
+func (srv *Server) blockOffender(ipa net.IP, blockTimeSec cmb.Count) (err error) {
+	var listType gm.IPListType
+	listType, err = srv.dbo.GetIPListTypeByIPAddress(ipa)
+	if err != nil {
+		return err
+	}
+
+	if listType == gm.IPListType_Allow {
+		return nil
+	}
+
+	ss := srv.settings.GetSystemSettings()
+	subnet := cn.GetSubnet(ipa, ss.GetIPv4SubnetPrefixLength().AsInt(), ss.GetIPv6SubnetPrefixLength().AsInt())
+
+	err = srv.dbo.RegisterSubnetOffence(subnet.String())
+	if err != nil {
+		return err
+	}
+
+	var offencesCount cmb.Count
+	offencesCount, err = srv.dbo.GetSubnetOffencesCount(subnet.String())
+	if err != nil {
+		return err
+	}
+
+	blockTimeSec = gm.EscalateBlockTime(blockTimeSec, offencesCount, ss.GetMaxBlockTimeSec())
+
+	err = srv.addBlock(cn.GetSubnet(ipa, cn.IPv4BitsCount, cn.IPv6BitsCount), blockTimeSec)
+	if err != nil {
+		return err
+	}
+
+	if offencesCount >= ss.GetSubnetBlockThreshold() {
+		err = srv.addBlock(subnet, blockTimeSec)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// addBlock blocks a range of IP addresses. If the range is already blocked,
+// the block time is added to the existing block.
+func (srv *Server) addBlock(ipNet *net.IPNet, blockTimeSec cmb.Count) (err error) {
+	cidr := ipNet.String()
+
+	var n cmb.Count
+	n, err = srv.dbo.CountBlocksByCidr(cidr)
+	if err != nil {
+		return err
+	}
+
+	if n > 0 {
+		return srv.dbo.IncreaseBlockDuration(cidr, blockTimeSec)
+	}
+
+	firstIPAB, lastIPAB := cn.GetIPRange(ipNet)
+	return srv.dbo.InsertBlock(cidr, firstIPAB, lastIPAB, blockTimeSec)
+}
@@ -1,19 +1,60 @@
 package server
 
+import (
+	"net/http"
+)
+
 // RPC errors.
 
 // Error codes must not exceed 999.
 
 // Codes.
 const (
-	RpcErrorCode_FirewallIsDisabled = 1
-	RpcErrorCode_IPAddressIsNotSet  = 2
-	RpcErrorCode_BlockTimeIsNotSet  = 3
+	RpcErrorCode_FirewallIsDisabled    = 1
+	RpcErrorCode_IPAddressIsNotSet     = 2
+	RpcErrorCode_BlockTimeIsNotSet     = 3
+	RpcErrorCode_NotificationIsNotSet  = 4
+	RpcErrorCode_CidrIsNotSet          = 5
+	RpcErrorCode_CidrIsWrong           = 6
+	RpcErrorCode_BlockIsNotFound       = 7
+	RpcErrorCode_PageIsNotSet          = 8
+	RpcErrorCode_IPListTypeIsWrong     = 9
+	RpcErrorCode_IPListEntryExists     = 10
+	RpcErrorCode_IPListEntryIsNotFound = 11
 )
 
 // Messages.
 const (
-	RpcErrorMsg_FirewallIsDisabled = "Firewall is disabled"
-	RpcErrorMsg_IPAddressIsNotSet  = "IP address is not set"
-	RpcErrorMsg_BlockTimeIsNotSet  = "Block time is not set"
+	RpcErrorMsg_FirewallIsDisabled    = "Firewall is disabled"
+	RpcErrorMsg_IPAddressIsNotSet     = "IP address is not set"
+	RpcErrorMsg_BlockTimeIsNotSet     = "Block time is not set"
+	RpcErrorMsg_NotificationIsNotSet  = "Notification is not set"
+	RpcErrorMsg_CidrIsNotSet          = "CIDR is not set"
+	RpcErrorMsg_CidrIsWrong           = "CIDR is wrong"
+	RpcErrorMsg_BlockIsNotFound       = "Block is not found"
+	RpcErrorMsg_PageIsNotSet          = "Page is not set"
+	RpcErrorMsg_IPListTypeIsWrong     = "IP list type is wrong"
+	RpcErrorMsg_IPListEntryExists     = "IP list entry already exists"
+	RpcErrorMsg_IPListEntryIsNotFound = "IP list entry is not found"
 )
+
+// Unique HTTP status codes used in the map:
+// - 400 (Bad request);
+// - 404 (Not found);
+// - 409 (Conflict);
+// - 503 (Service unavailable).
+func GetMapOfHttpStatusCodesByRpcErrorCodes() map[int]int {
+	return map[int]int{
+		RpcErrorCode_FirewallIsDisabled:    http.StatusServiceUnavailable,
+		RpcErrorCode_IPAddressIsNotSet:     http.StatusBadRequest,
+		RpcErrorCode_BlockTimeIsNotSet:     http.StatusBadRequest,
+		RpcErrorCode_NotificationIsNotSet:  http.StatusBadRequest,
+		RpcErrorCode_CidrIsNotSet:          http.StatusBadRequest,
+		RpcErrorCode_CidrIsWrong:           http.StatusBadRequest,
+		RpcErrorCode_BlockIsNotFound:       http.StatusNotFound,
+		RpcErrorCode_PageIsNotSet:          http.StatusBadRequest,
+		RpcErrorCode_IPListTypeIsWrong:     http.StatusBadRequest,
+		RpcErrorCode_IPListEntryExists:     http.StatusConflict,
+		RpcErrorCode_IPListEntryIsNotFound: http.StatusNotFound,
+	}
+}
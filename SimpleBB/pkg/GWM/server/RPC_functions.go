@@ -2,11 +2,13 @@ package server
 
 import (
 	jrm1 "github.com/vault-thirteen/JSON-RPC-M1"
+	"github.com/vault-thirteen/SimpleBB/pkg/GWM/models"
 	gm "github.com/vault-thirteen/SimpleBB/pkg/GWM/rpc"
 	base2 "github.com/vault-thirteen/SimpleBB/pkg/common/models/base"
 	cn "github.com/vault-thirteen/SimpleBB/pkg/common/models/net"
 	rpc2 "github.com/vault-thirteen/SimpleBB/pkg/common/models/rpc"
 	c "github.com/vault-thirteen/SimpleBB/pkg/common/models/server"
+	"net"
 )
 
 // RPC functions.
@@ -34,26 +36,11 @@ func (srv *Server) blockIPAddress(p *gm.BlockIPAddressParams) (result *gm.BlockI
 	srv.dbo.LockForWriting()
 	defer srv.dbo.UnlockAfterWriting()
 
-	// Search for an existing record.
-	var n base2.Count
-	n, err = srv.dbo.CountBlocksByIPAddress(userIPAB)
+	err = srv.blockOffender(userIPAB, p.BlockTimeSec)
 	if err != nil {
 		return nil, srv.databaseError(err)
 	}
 
-	// Insert a new record when none is found, or update an existing block.
-	if n == 0 {
-		err = srv.dbo.InsertBlock(userIPAB, p.BlockTimeSec)
-		if err != nil {
-			return nil, srv.databaseError(err)
-		}
-	} else {
-		err = srv.dbo.IncreaseBlockDuration(userIPAB, p.BlockTimeSec)
-		if err != nil {
-			return nil, srv.databaseError(err)
-		}
-	}
-
 	result = &gm.BlockIPAddressResult{
 		Success: rpc2.Success{
 			OK: true,
@@ -81,14 +68,214 @@ func (srv *Server) isIPAddressBlocked(p *gm.IsIPAddressBlockedParams) (result *g
 	srv.dbo.LockForReading()
 	defer srv.dbo.UnlockAfterReading()
 
-	// Search for an existing record.
-	var n base2.Count
-	n, err = srv.dbo.CountBlocksByIPAddress(userIPAB)
+	var isBlocked bool
+	isBlocked, err = srv.checkIPAddressBlock(userIPAB)
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+
+	return &gm.IsIPAddressBlockedResult{IsBlocked: base2.Flag(isBlocked)}, nil
+}
+
+// unblockIPAddress lifts a block of a range of IP addresses. Blocks of
+// smaller or larger ranges containing the same addresses are not lifted.
+func (srv *Server) unblockIPAddress(p *gm.UnblockIPAddressParams) (result *gm.UnblockIPAddressResult, re *jrm1.RpcError) {
+	if !srv.settings.GetSystemSettings().GetIsFirewallUsed() {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_FirewallIsDisabled, RpcErrorMsg_FirewallIsDisabled, nil)
+	}
+
+	// Check parameters.
+	var ipNet *net.IPNet
+	ipNet, re = parseCidrParameter(p.Cidr)
+	if re != nil {
+		return nil, re
+	}
+
+	cidr := ipNet.String()
+
+	srv.dbo.LockForWriting()
+	defer srv.dbo.UnlockAfterWriting()
+
+	n, err := srv.dbo.CountBlocksByCidr(cidr)
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+
+	if n == 0 {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_BlockIsNotFound, RpcErrorMsg_BlockIsNotFound, nil)
+	}
+
+	err = srv.dbo.DeleteBlockByCidr(cidr)
 	if err != nil {
 		return nil, srv.databaseError(err)
 	}
 
-	return &gm.IsIPAddressBlockedResult{IsBlocked: n > 0}, nil
+	result = &gm.UnblockIPAddressResult{
+		Success: rpc2.Success{
+			OK: true,
+		},
+	}
+	return result, nil
+}
+
+func (srv *Server) listIPBlocks(p *gm.ListIPBlocksParams) (result *gm.ListIPBlocksResult, re *jrm1.RpcError) {
+	if !srv.settings.GetSystemSettings().GetIsFirewallUsed() {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_FirewallIsDisabled, RpcErrorMsg_FirewallIsDisabled, nil)
+	}
+
+	// Check parameters.
+	if p.Page == 0 {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_PageIsNotSet, RpcErrorMsg_PageIsNotSet, nil)
+	}
+
+	pageSize := srv.settings.GetSystemSettings().GetPageSize()
+
+	srv.dbo.LockForReading()
+	defer srv.dbo.UnlockAfterReading()
+
+	blocks, err := srv.dbo.ReadBlocksOnPage(p.Page, pageSize)
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+
+	var allBlocksCount base2.Count
+	allBlocksCount, err = srv.dbo.CountBlocks()
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+
+	result = &gm.ListIPBlocksResult{
+		IPBlocks: blocks,
+		PageData: &rpc2.PageData{
+			PageNumber:  p.Page,
+			TotalPages:  base2.CalculateTotalPages(allBlocksCount, pageSize),
+			PageSize:    pageSize,
+			ItemsOnPage: base2.Count(len(blocks)),
+			TotalItems:  allBlocksCount,
+		},
+	}
+	return result, nil
+}
+
+func (srv *Server) addIPListEntry(p *gm.AddIPListEntryParams) (result *gm.AddIPListEntryResult, re *jrm1.RpcError) {
+	if !srv.settings.GetSystemSettings().GetIsFirewallUsed() {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_FirewallIsDisabled, RpcErrorMsg_FirewallIsDisabled, nil)
+	}
+
+	// Check parameters.
+	if !models.IsIPListTypeValid(p.ListType) {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_IPListTypeIsWrong, RpcErrorMsg_IPListTypeIsWrong, nil)
+	}
+
+	var ipNet *net.IPNet
+	ipNet, re = parseCidrParameter(p.Cidr)
+	if re != nil {
+		return nil, re
+	}
+
+	cidr := ipNet.String()
+	firstIPAB, lastIPAB := cn.GetIPRange(ipNet)
+
+	srv.dbo.LockForWriting()
+	defer srv.dbo.UnlockAfterWriting()
+
+	n, err := srv.dbo.CountIPListEntriesByCidr(p.ListType, cidr)
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+
+	if n > 0 {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_IPListEntryExists, RpcErrorMsg_IPListEntryExists, nil)
+	}
+
+	err = srv.dbo.AddIPListEntry(p.ListType, cidr, firstIPAB, lastIPAB, p.Comment)
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+
+	result = &gm.AddIPListEntryResult{
+		Success: rpc2.Success{
+			OK: true,
+		},
+	}
+	return result, nil
+}
+
+func (srv *Server) deleteIPListEntry(p *gm.DeleteIPListEntryParams) (result *gm.DeleteIPListEntryResult, re *jrm1.RpcError) {
+	if !srv.settings.GetSystemSettings().GetIsFirewallUsed() {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_FirewallIsDisabled, RpcErrorMsg_FirewallIsDisabled, nil)
+	}
+
+	// Check parameters.
+	if !models.IsIPListTypeValid(p.ListType) {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_IPListTypeIsWrong, RpcErrorMsg_IPListTypeIsWrong, nil)
+	}
+
+	var ipNet *net.IPNet
+	ipNet, re = parseCidrParameter(p.Cidr)
+	if re != nil {
+		return nil, re
+	}
+
+	cidr := ipNet.String()
+
+	srv.dbo.LockForWriting()
+	defer srv.dbo.UnlockAfterWriting()
+
+	n, err := srv.dbo.CountIPListEntriesByCidr(p.ListType, cidr)
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+
+	if n == 0 {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_IPListEntryIsNotFound, RpcErrorMsg_IPListEntryIsNotFound, nil)
+	}
+
+	err = srv.dbo.DeleteIPListEntry(p.ListType, cidr)
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+
+	result = &gm.DeleteIPListEntryResult{
+		Success: rpc2.Success{
+			OK: true,
+		},
+	}
+	return result, nil
+}
+
+func (srv *Server) listIPListEntries(_ *gm.ListIPListEntriesParams) (result *gm.ListIPListEntriesResult, re *jrm1.RpcError) {
+	if !srv.settings.GetSystemSettings().GetIsFirewallUsed() {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_FirewallIsDisabled, RpcErrorMsg_FirewallIsDisabled, nil)
+	}
+
+	srv.dbo.LockForReading()
+	defer srv.dbo.UnlockAfterReading()
+
+	entries, err := srv.dbo.ReadIPListEntries()
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+
+	result = &gm.ListIPListEntriesResult{
+		IPListEntries: entries,
+	}
+	return result, nil
+}
+
+func (srv *Server) publishNotification(p *gm.PublishNotificationParams) (result *gm.PublishNotificationResult, re *jrm1.RpcError) {
+	// Check parameters.
+	if (p.Notification == nil) ||
+		(p.Notification.Id == 0) ||
+		(p.Notification.UserId == 0) {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_NotificationIsNotSet, RpcErrorMsg_NotificationIsNotSet, nil)
+	}
+
+	result = &gm.PublishNotificationResult{
+		StreamsCount: srv.notificationHub.Publish(p.Notification),
+	}
+
+	return result, nil
 }
 
 func (srv *Server) showDiagnosticData() (result *gm.ShowDiagnosticDataResult, re *jrm1.RpcError) {
@@ -99,6 +286,9 @@ func (srv *Server) showDiagnosticData() (result *gm.ShowDiagnosticDataResult, re
 			TotalRequestsCount:      base2.Text(trc),
 			SuccessfulRequestsCount: base2.Text(src),
 		},
+		ScheduledTasks: rpc2.ScheduledTasks{
+			ScheduledTasks: srv.scheduler.GetTaskStatuses(),
+		},
 	}
 
 	return result, nil
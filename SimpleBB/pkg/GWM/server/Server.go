@@ -37,7 +37,10 @@ import (
 	gs "github.com/vault-thirteen/SimpleBB/pkg/GWM/settings"
 )
 
-const ErrUrlIsTooShort = "URL is too short"
+const (
+	ErrUrlIsTooShort             = "URL is too short"
+	ErrFUnknownRateLimitedAction = "unknown rate-limited action: %v"
+)
 
 type Server struct {
 	// Settings.
@@ -65,6 +68,9 @@ type Server struct {
 	dbErrors    *chan error
 	ssp         *avm.SSP
 
+	// Address ranges of trusted reverse proxies.
+	trustedProxies []*net.IPNet
+
 	// Database Object.
 	dbo *dbo.DatabaseObject
 
@@ -81,6 +87,7 @@ type Server struct {
 	// Mapping of HTTP status codes by RPC error code for various services.
 	commonHttpStatusCodesByRpcErrorCode map[int]int
 	acmHttpStatusCodesByRpcErrorCode    map[int]int
+	gwmHttpStatusCodesByRpcErrorCode    map[int]int
 	mmHttpStatusCodesByRpcErrorCode     map[int]int
 	nmHttpStatusCodesByRpcErrorCode     map[int]int
 	smHttpStatusCodesByRpcErrorCode     map[int]int
@@ -93,6 +100,13 @@ type Server struct {
 
 	// Scheduler.
 	scheduler *cm.Scheduler
+
+	// Streams of notifications.
+	notificationHub *models.NotificationHub
+
+	// Rate limiting of the public API.
+	rateLimiter *models.RateLimiter
+	roleCache   *models.RoleCache
 }
 
 func NewServer(s base.ISettings) (srv *Server, err error) {
@@ -124,6 +138,11 @@ func NewServer(s base.ISettings) (srv *Server, err error) {
 		fmt.Println(server2.MsgFirewallIsDisabled)
 	}
 
+	err = srv.initTrustedProxies()
+	if err != nil {
+		return nil, err
+	}
+
 	// RPC server.
 	err = srv.initRpc()
 	if err != nil {
@@ -155,6 +174,11 @@ func NewServer(s base.ISettings) (srv *Server, err error) {
 		return nil, err
 	}
 
+	err = srv.initRateLimiter()
+	if err != nil {
+		return nil, err
+	}
+
 	err = srv.createClientsForExternalServices()
 	if err != nil {
 		return nil, err
@@ -177,7 +201,12 @@ func NewServer(s base.ISettings) (srv *Server, err error) {
 		}
 	}
 
-	srv.initScheduler()
+	err = srv.initScheduler()
+	if err != nil {
+		return nil, err
+	}
+
+	srv.notificationHub = models.NewNotificationHub()
 
 	return srv, nil
 }
@@ -239,6 +268,10 @@ func (srv *Server) Stop() (err error) {
 		return err
 	}
 
+	// Event streams are never idle, so they must be closed before the
+	// shutdown of the HTTPS server.
+	srv.notificationHub.Close()
+
 	ctxExt, cfExt := context.WithTimeout(context.Background(), time.Minute)
 	defer cfExt()
 	err = srv.httpServerExt.Shutdown(ctxExt)
@@ -348,12 +381,16 @@ func (srv *Server) httpRouterInt(rw http.ResponseWriter, req *http.Request) {
 
 // HTTP router for external requests.
 func (srv *Server) httpRouterExt(rw http.ResponseWriter, req *http.Request) {
+	clientIPA, err := srv.getClientIPAddress(req)
+	if err != nil {
+		srv.processInternalServerError(rw, err)
+		return
+	}
+
 	// Firewall (optional).
-	var clientIPA simple.IPAS
 	if srv.settings.GetSystemSettings().GetIsFirewallUsed() {
 		var ok bool
-		var err error
-		ok, clientIPA, err = srv.isIPAddressAllowed(req)
+		ok, err = srv.isIPAddressAllowed(clientIPA)
 		if err != nil {
 			srv.processInternalServerError(rw, err)
 			return
@@ -383,7 +420,6 @@ func (srv *Server) httpRouterExt(rw http.ResponseWriter, req *http.Request) {
 		}
 	}
 
-	var err error
 	urlParts := cn.SplitUrlPath(req.URL.Path)
 	if len(urlParts) < 1 {
 		err = errors.New(ErrUrlIsTooShort)
@@ -405,6 +441,14 @@ func (srv *Server) httpRouterExt(rw http.ResponseWriter, req *http.Request) {
 		srv.handleCaptcha(rw, req)
 		return
 
+	case srv.settings.GetSystemSettings().GetEventsFolder(): // <- /events
+		srv.handleEvents(rw, req, clientIPA)
+		return
+
+	case srv.settings.GetSystemSettings().GetAttachmentsFolder(): // <- /attachments
+		srv.handleAttachment(rw, req, clientIPA)
+		return
+
 	case srv.settings.GetSystemSettings().GetFrontEndStaticFilesFolder(): // <- /fe
 		if !isFrontEndEnabled {
 			srv.respondNotFound(rw)
@@ -418,9 +462,27 @@ func (srv *Server) httpRouterExt(rw http.ResponseWriter, req *http.Request) {
 	return
 }
 
+func (srv *Server) initTrustedProxies() (err error) {
+	tps := srv.settings.GetSystemSettings().GetTrustedProxies()
+	srv.trustedProxies = make([]*net.IPNet, 0, len(tps))
+
+	var ipNet *net.IPNet
+	for _, tp := range tps {
+		ipNet, err = cn.ParseCidr(tp)
+		if err != nil {
+			return err
+		}
+
+		srv.trustedProxies = append(srv.trustedProxies, ipNet)
+	}
+
+	return nil
+}
+
 func (srv *Server) initStatusCodeMapper() (err error) {
 	srv.commonHttpStatusCodesByRpcErrorCode = server2.GetMapOfHttpStatusCodesByRpcErrorCodes()
 	srv.acmHttpStatusCodesByRpcErrorCode = a.GetMapOfHttpStatusCodesByRpcErrorCodes()
+	srv.gwmHttpStatusCodesByRpcErrorCode = GetMapOfHttpStatusCodesByRpcErrorCodes()
 	srv.mmHttpStatusCodesByRpcErrorCode = m.GetMapOfHttpStatusCodesByRpcErrorCodes()
 	srv.nmHttpStatusCodesByRpcErrorCode = n.GetMapOfHttpStatusCodesByRpcErrorCodes()
 	srv.smHttpStatusCodesByRpcErrorCode = s.GetMapOfHttpStatusCodesByRpcErrorCodes()
@@ -442,6 +504,8 @@ func (srv *Server) initPublicSettings() (err error) {
 		PageSize:                  srv.settings.GetSystemSettings().GetPageSize(),
 		ApiFolder:                 srv.settings.GetSystemSettings().GetApiFolder(),
 		PublicSettingsFileName:    srv.settings.GetSystemSettings().GetPublicSettingsFileName(),
+		EventsFolder:              srv.settings.GetSystemSettings().GetEventsFolder(),
+		AttachmentsFolder:         srv.settings.GetSystemSettings().GetAttachmentsFolder(),
 		IsFrontEndEnabled:         srv.settings.GetSystemSettings().GetIsFrontEndEnabled(),
 		FrontEndStaticFilesFolder: srv.settings.GetSystemSettings().GetFrontEndStaticFilesFolder(),
 		NotificationCountLimit:    srv.settings.GetSystemSettings().GetNotificationCountLimit(),
@@ -649,6 +713,7 @@ func (srv *Server) initApiFunctions() (err error) {
 		ApiFunctionName_GetListOfAllUsers,
 		ApiFunctionName_GetListOfAllUsersOnPage,
 		ApiFunctionName_IsUserLoggedIn,
+		ApiFunctionName_ResetPassword,
 		ApiFunctionName_ChangePassword,
 		ApiFunctionName_ChangeEmail,
 		ApiFunctionName_GetUserSession,
@@ -657,14 +722,25 @@ func (srv *Server) initApiFunctions() (err error) {
 		ApiFunctionName_SetUserRoleAuthor,
 		ApiFunctionName_SetUserRoleWriter,
 		ApiFunctionName_SetUserRoleReader,
+		ApiFunctionName_GrantPermission,
+		ApiFunctionName_RevokePermission,
+		ApiFunctionName_GetUserPermissions,
 		ApiFunctionName_GetSelfRoles,
 		ApiFunctionName_BanUser,
 		ApiFunctionName_UnbanUser,
+		ApiFunctionName_StartTotpEnrolment,
+		ApiFunctionName_ConfirmTotpEnrolment,
+		ApiFunctionName_DisableSelfTotp,
+		ApiFunctionName_GetSelfTotpStatus,
+		ApiFunctionName_RegenerateTotpRecoveryCodes,
+		ApiFunctionName_ResetUserTotp,
+		ApiFunctionName_GetAuditLog,
 
 		// MM.
 		ApiFunctionName_AddSection,
 		ApiFunctionName_ChangeSectionName,
 		ApiFunctionName_ChangeSectionParent,
+		ApiFunctionName_ChangeSectionPrivacy,
 		ApiFunctionName_GetSection,
 		ApiFunctionName_MoveSectionUp,
 		ApiFunctionName_MoveSectionDown,
@@ -672,6 +748,7 @@ func (srv *Server) initApiFunctions() (err error) {
 		ApiFunctionName_AddForum,
 		ApiFunctionName_ChangeForumName,
 		ApiFunctionName_ChangeForumSection,
+		ApiFunctionName_ChangeForumReadOnly,
 		ApiFunctionName_GetForum,
 		ApiFunctionName_MoveForumUp,
 		ApiFunctionName_MoveForumDown,
@@ -684,17 +761,49 @@ func (srv *Server) initApiFunctions() (err error) {
 		ApiFunctionName_MoveThreadUp,
 		ApiFunctionName_MoveThreadDown,
 		ApiFunctionName_DeleteThread,
+		ApiFunctionName_LockThread,
+		ApiFunctionName_UnlockThread,
+		ApiFunctionName_ChangeThreadPinned,
+		ApiFunctionName_ChangeThreadAnnouncement,
 		ApiFunctionName_AddMessage,
 		ApiFunctionName_ChangeMessageText,
 		ApiFunctionName_ChangeMessageThread,
 		ApiFunctionName_GetMessage,
 		ApiFunctionName_GetLatestMessageOfThread,
 		ApiFunctionName_DeleteMessage,
+		ApiFunctionName_ListMessageRevisions,
+		ApiFunctionName_GetMessageRevision,
+		ApiFunctionName_RestoreMessageRevision,
 		ApiFunctionName_ListThreadAndMessages,
 		ApiFunctionName_ListThreadAndMessagesOnPage,
 		ApiFunctionName_ListForumAndThreads,
 		ApiFunctionName_ListForumAndThreadsOnPage,
 		ApiFunctionName_ListSectionsAndForums,
+		ApiFunctionName_SearchMessages,
+		ApiFunctionName_SearchThreads,
+		ApiFunctionName_StartConversation,
+		ApiFunctionName_SendPrivateMessage,
+		ApiFunctionName_ListConversations,
+		ApiFunctionName_ReadConversation,
+		ApiFunctionName_DeleteConversation,
+		ApiFunctionName_CountUnreadPrivateMessages,
+		ApiFunctionName_BlockUser,
+		ApiFunctionName_UnblockUser,
+		ApiFunctionName_ListBlockedUsers,
+		ApiFunctionName_GetPoll,
+		ApiFunctionName_VoteInPoll,
+		ApiFunctionName_ChangePollVote,
+		ApiFunctionName_ListReactions,
+		ApiFunctionName_AddMessageReaction,
+		ApiFunctionName_RemoveMessageReaction,
+		ApiFunctionName_GetUserReputation,
+		ApiFunctionName_ListTrash,
+		ApiFunctionName_RestoreFromTrash,
+		ApiFunctionName_RepairDatabaseConsistency,
+		ApiFunctionName_ReportMessage,
+		ApiFunctionName_ListModerationQueue,
+		ApiFunctionName_ResolveMessageReports,
+		ApiFunctionName_ListModerationDecisions,
 
 		// NM.
 		ApiFunctionName_AddNotification,
@@ -710,6 +819,13 @@ func (srv *Server) initApiFunctions() (err error) {
 		ApiFunctionName_GetResourceValue,
 		ApiFunctionName_GetListOfAllResourcesOnPage,
 		ApiFunctionName_DeleteResource,
+		ApiFunctionName_AddNotificationTemplate,
+		ApiFunctionName_GetNotificationTemplate,
+		ApiFunctionName_ListNotificationTemplates,
+		ApiFunctionName_ChangeNotificationTemplate,
+		ApiFunctionName_DeleteNotificationTemplate,
+		ApiFunctionName_SetSelfLanguage,
+		ApiFunctionName_GetSelfLanguage,
 
 		// SM.
 		ApiFunctionName_AddSubscription,
@@ -722,6 +838,20 @@ func (srv *Server) initApiFunctions() (err error) {
 		ApiFunctionName_GetUserSubscriptionsOnPage,
 		ApiFunctionName_DeleteSelfSubscription,
 		ApiFunctionName_DeleteSubscription,
+		ApiFunctionName_AddSelfForumSubscription,
+		ApiFunctionName_DeleteSelfForumSubscription,
+		ApiFunctionName_AddSelfSectionSubscription,
+		ApiFunctionName_DeleteSelfSectionSubscription,
+		ApiFunctionName_GetSelfForumAndSectionSubscriptions,
+		ApiFunctionName_SetSelfDeliveryMode,
+		ApiFunctionName_GetSelfDeliveryMode,
+
+		// GWM.
+		ApiFunctionName_UnblockIPAddress,
+		ApiFunctionName_ListIPBlocks,
+		ApiFunctionName_AddIPListEntry,
+		ApiFunctionName_DeleteIPListEntry,
+		ApiFunctionName_ListIPListEntries,
 	}
 
 	srv.apiHandlers = map[string]api.RequestHandler{
@@ -738,6 +868,7 @@ func (srv *Server) initApiFunctions() (err error) {
 		ApiFunctionName_GetListOfAllUsers:                      srv.GetListOfAllUsers,
 		ApiFunctionName_GetListOfAllUsersOnPage:                srv.GetListOfAllUsersOnPage,
 		ApiFunctionName_IsUserLoggedIn:                         srv.IsUserLoggedIn,
+		ApiFunctionName_ResetPassword:                          srv.ResetPassword,
 		ApiFunctionName_ChangePassword:                         srv.ChangePassword,
 		ApiFunctionName_ChangeEmail:                            srv.ChangeEmail,
 		ApiFunctionName_GetUserSession:                         srv.GetUserSession,
@@ -747,14 +878,25 @@ func (srv *Server) initApiFunctions() (err error) {
 		ApiFunctionName_SetUserRoleAuthor:                      srv.SetUserRoleAuthor,
 		ApiFunctionName_SetUserRoleWriter:                      srv.SetUserRoleWriter,
 		ApiFunctionName_SetUserRoleReader:                      srv.SetUserRoleReader,
+		ApiFunctionName_GrantPermission:                        srv.GrantPermission,
+		ApiFunctionName_RevokePermission:                       srv.RevokePermission,
+		ApiFunctionName_GetUserPermissions:                     srv.GetUserPermissions,
 		ApiFunctionName_GetSelfRoles:                           srv.GetSelfRoles,
 		ApiFunctionName_BanUser:                                srv.BanUser,
 		ApiFunctionName_UnbanUser:                              srv.UnbanUser,
+		ApiFunctionName_StartTotpEnrolment:                     srv.StartTotpEnrolment,
+		ApiFunctionName_ConfirmTotpEnrolment:                   srv.ConfirmTotpEnrolment,
+		ApiFunctionName_DisableSelfTotp:                        srv.DisableSelfTotp,
+		ApiFunctionName_GetSelfTotpStatus:                      srv.GetSelfTotpStatus,
+		ApiFunctionName_RegenerateTotpRecoveryCodes:            srv.RegenerateTotpRecoveryCodes,
+		ApiFunctionName_ResetUserTotp:                          srv.ResetUserTotp,
+		ApiFunctionName_GetAuditLog:                            srv.GetAuditLog,
 
 		// MM.
 		ApiFunctionName_AddSection:                  srv.AddSection,
 		ApiFunctionName_ChangeSectionName:           srv.ChangeSectionName,
 		ApiFunctionName_ChangeSectionParent:         srv.ChangeSectionParent,
+		ApiFunctionName_ChangeSectionPrivacy:        srv.ChangeSectionPrivacy,
 		ApiFunctionName_GetSection:                  srv.GetSection,
 		ApiFunctionName_MoveSectionUp:               srv.MoveSectionUp,
 		ApiFunctionName_MoveSectionDown:             srv.MoveSectionDown,
@@ -762,6 +904,7 @@ func (srv *Server) initApiFunctions() (err error) {
 		ApiFunctionName_AddForum:                    srv.AddForum,
 		ApiFunctionName_ChangeForumName:             srv.ChangeForumName,
 		ApiFunctionName_ChangeForumSection:          srv.ChangeForumSection,
+		ApiFunctionName_ChangeForumReadOnly:         srv.ChangeForumReadOnly,
 		ApiFunctionName_GetForum:                    srv.GetForum,
 		ApiFunctionName_MoveForumUp:                 srv.MoveForumUp,
 		ApiFunctionName_MoveForumDown:               srv.MoveForumDown,
@@ -774,17 +917,49 @@ func (srv *Server) initApiFunctions() (err error) {
 		ApiFunctionName_MoveThreadUp:                srv.MoveThreadUp,
 		ApiFunctionName_MoveThreadDown:              srv.MoveThreadDown,
 		ApiFunctionName_DeleteThread:                srv.DeleteThread,
+		ApiFunctionName_LockThread:                  srv.LockThread,
+		ApiFunctionName_UnlockThread:                srv.UnlockThread,
+		ApiFunctionName_ChangeThreadPinned:          srv.ChangeThreadPinned,
+		ApiFunctionName_ChangeThreadAnnouncement:    srv.ChangeThreadAnnouncement,
 		ApiFunctionName_AddMessage:                  srv.AddMessage,
 		ApiFunctionName_ChangeMessageText:           srv.ChangeMessageText,
 		ApiFunctionName_ChangeMessageThread:         srv.ChangeMessageThread,
 		ApiFunctionName_GetMessage:                  srv.GetMessage,
 		ApiFunctionName_GetLatestMessageOfThread:    srv.GetLatestMessageOfThread,
 		ApiFunctionName_DeleteMessage:               srv.DeleteMessage,
+		ApiFunctionName_ListMessageRevisions:        srv.ListMessageRevisions,
+		ApiFunctionName_GetMessageRevision:          srv.GetMessageRevision,
+		ApiFunctionName_RestoreMessageRevision:      srv.RestoreMessageRevision,
 		ApiFunctionName_ListThreadAndMessages:       srv.ListThreadAndMessages,
 		ApiFunctionName_ListThreadAndMessagesOnPage: srv.ListThreadAndMessagesOnPage,
 		ApiFunctionName_ListForumAndThreads:         srv.ListForumAndThreads,
 		ApiFunctionName_ListForumAndThreadsOnPage:   srv.ListForumAndThreadsOnPage,
 		ApiFunctionName_ListSectionsAndForums:       srv.ListSectionsAndForums,
+		ApiFunctionName_SearchMessages:              srv.SearchMessages,
+		ApiFunctionName_SearchThreads:               srv.SearchThreads,
+		ApiFunctionName_StartConversation:           srv.StartConversation,
+		ApiFunctionName_SendPrivateMessage:          srv.SendPrivateMessage,
+		ApiFunctionName_ListConversations:           srv.ListConversations,
+		ApiFunctionName_ReadConversation:            srv.ReadConversation,
+		ApiFunctionName_DeleteConversation:          srv.DeleteConversation,
+		ApiFunctionName_CountUnreadPrivateMessages:  srv.CountUnreadPrivateMessages,
+		ApiFunctionName_BlockUser:                   srv.BlockUser,
+		ApiFunctionName_UnblockUser:                 srv.UnblockUser,
+		ApiFunctionName_ListBlockedUsers:            srv.ListBlockedUsers,
+		ApiFunctionName_GetPoll:                     srv.GetPoll,
+		ApiFunctionName_VoteInPoll:                  srv.VoteInPoll,
+		ApiFunctionName_ChangePollVote:              srv.ChangePollVote,
+		ApiFunctionName_ListReactions:               srv.ListReactions,
+		ApiFunctionName_AddMessageReaction:          srv.AddMessageReaction,
+		ApiFunctionName_RemoveMessageReaction:       srv.RemoveMessageReaction,
+		ApiFunctionName_GetUserReputation:           srv.GetUserReputation,
+		ApiFunctionName_ListTrash:                   srv.ListTrash,
+		ApiFunctionName_RestoreFromTrash:            srv.RestoreFromTrash,
+		ApiFunctionName_RepairDatabaseConsistency:   srv.RepairDatabaseConsistency,
+		ApiFunctionName_ReportMessage:               srv.ReportMessage,
+		ApiFunctionName_ListModerationQueue:         srv.ListModerationQueue,
+		ApiFunctionName_ResolveMessageReports:       srv.ResolveMessageReports,
+		ApiFunctionName_ListModerationDecisions:     srv.ListModerationDecisions,
 
 		// NM.
 		ApiFunctionName_AddNotification:             srv.AddNotification,
@@ -800,28 +975,77 @@ func (srv *Server) initApiFunctions() (err error) {
 		ApiFunctionName_GetResourceValue:            srv.GetResourceValue,
 		ApiFunctionName_GetListOfAllResourcesOnPage: srv.GetListOfAllResourcesOnPage,
 		ApiFunctionName_DeleteResource:              srv.DeleteResource,
+		ApiFunctionName_AddNotificationTemplate:     srv.AddNotificationTemplate,
+		ApiFunctionName_GetNotificationTemplate:     srv.GetNotificationTemplate,
+		ApiFunctionName_ListNotificationTemplates:   srv.ListNotificationTemplates,
+		ApiFunctionName_ChangeNotificationTemplate:  srv.ChangeNotificationTemplate,
+		ApiFunctionName_DeleteNotificationTemplate:  srv.DeleteNotificationTemplate,
+		ApiFunctionName_SetSelfLanguage:             srv.SetSelfLanguage,
+		ApiFunctionName_GetSelfLanguage:             srv.GetSelfLanguage,
 
 		// SM.
-		ApiFunctionName_AddSubscription:            srv.AddSubscription,
-		ApiFunctionName_IsSelfSubscribed:           srv.IsSelfSubscribed,
-		ApiFunctionName_IsUserSubscribed:           srv.IsUserSubscribed,
-		ApiFunctionName_CountSelfSubscriptions:     srv.CountSelfSubscriptions,
-		ApiFunctionName_GetSelfSubscriptions:       srv.GetSelfSubscriptions,
-		ApiFunctionName_GetSelfSubscriptionsOnPage: srv.GetSelfSubscriptionsOnPage,
-		ApiFunctionName_GetUserSubscriptions:       srv.GetUserSubscriptions,
-		ApiFunctionName_GetUserSubscriptionsOnPage: srv.GetUserSubscriptionsOnPage,
-		ApiFunctionName_DeleteSubscription:         srv.DeleteSubscription,
-		ApiFunctionName_DeleteSelfSubscription:     srv.DeleteSelfSubscription,
+		ApiFunctionName_AddSubscription:                     srv.AddSubscription,
+		ApiFunctionName_IsSelfSubscribed:                    srv.IsSelfSubscribed,
+		ApiFunctionName_IsUserSubscribed:                    srv.IsUserSubscribed,
+		ApiFunctionName_CountSelfSubscriptions:              srv.CountSelfSubscriptions,
+		ApiFunctionName_GetSelfSubscriptions:                srv.GetSelfSubscriptions,
+		ApiFunctionName_GetSelfSubscriptionsOnPage:          srv.GetSelfSubscriptionsOnPage,
+		ApiFunctionName_GetUserSubscriptions:                srv.GetUserSubscriptions,
+		ApiFunctionName_GetUserSubscriptionsOnPage:          srv.GetUserSubscriptionsOnPage,
+		ApiFunctionName_DeleteSubscription:                  srv.DeleteSubscription,
+		ApiFunctionName_AddSelfForumSubscription:            srv.AddSelfForumSubscription,
+		ApiFunctionName_DeleteSelfForumSubscription:         srv.DeleteSelfForumSubscription,
+		ApiFunctionName_AddSelfSectionSubscription:          srv.AddSelfSectionSubscription,
+		ApiFunctionName_DeleteSelfSectionSubscription:       srv.DeleteSelfSectionSubscription,
+		ApiFunctionName_GetSelfForumAndSectionSubscriptions: srv.GetSelfForumAndSectionSubscriptions,
+		ApiFunctionName_SetSelfDeliveryMode:                 srv.SetSelfDeliveryMode,
+		ApiFunctionName_GetSelfDeliveryMode:                 srv.GetSelfDeliveryMode,
+		ApiFunctionName_DeleteSelfSubscription:              srv.DeleteSelfSubscription,
+
+		// GWM.
+		ApiFunctionName_UnblockIPAddress:  srv.UnblockIPAddressApi,
+		ApiFunctionName_ListIPBlocks:      srv.ListIPBlocksApi,
+		ApiFunctionName_AddIPListEntry:    srv.AddIPListEntryApi,
+		ApiFunctionName_DeleteIPListEntry: srv.DeleteIPListEntryApi,
+		ApiFunctionName_ListIPListEntries: srv.ListIPListEntriesApi,
+	}
+
+	return nil
+}
+
+// initRateLimiter prepares rate limiting. Limits of actions must refer to
+// existing API functions or to downloads of attached files.
+func (srv *Server) initRateLimiter() (err error) {
+	for action := range srv.settings.GetRateLimitSettings().Actions {
+		if action == ActionName_GetAttachment {
+			continue
+		}
+
+		_, ok := srv.apiHandlers[action]
+		if !ok {
+			return fmt.Errorf(ErrFUnknownRateLimitedAction, action)
+		}
 	}
 
+	srv.rateLimiter = models.NewRateLimiter()
+	srv.roleCache = models.NewRoleCache()
+
 	return nil
 }
 
-func (srv *Server) initScheduler() {
-	funcs60 := []simple.ScheduledFn{
-		srv.clearIPAddresses,
+func (srv *Server) initScheduler() (err error) {
+	tasks := []cm.Task{
+		{Name: "clearIPBlocks", Schedule: "@every 1m", Fn: srv.clearIPBlocks, Timeout: time.Minute},
+		{Name: "clearSubnetOffences", Schedule: "@every 1m", Fn: srv.clearSubnetOffences, Timeout: time.Minute},
+		{Name: "clearRateLimits", Schedule: "@every 1m", Fn: srv.clearRateLimits, Timeout: time.Minute},
 	}
-	srv.scheduler = cm.NewScheduler(srv, funcs60, nil, nil)
+
+	srv.scheduler, err = cm.NewScheduler(srv, tasks)
+	if err != nil {
+		return err
+	}
+
+	return nil
 }
 
 func (srv *Server) ReportStart() {
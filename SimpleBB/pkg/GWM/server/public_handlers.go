@@ -1,16 +1,33 @@
 package server
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
+	ac "github.com/vault-thirteen/SimpleBB/pkg/ACM/client"
 	am "github.com/vault-thirteen/SimpleBB/pkg/ACM/rpc"
 	api2 "github.com/vault-thirteen/SimpleBB/pkg/GWM/api"
+	mc "github.com/vault-thirteen/SimpleBB/pkg/MM/client"
+	mm "github.com/vault-thirteen/SimpleBB/pkg/MM/rpc"
+	nc "github.com/vault-thirteen/SimpleBB/pkg/NM/client"
+	nmm "github.com/vault-thirteen/SimpleBB/pkg/NM/models"
+	nm "github.com/vault-thirteen/SimpleBB/pkg/NM/rpc"
+	"github.com/vault-thirteen/SimpleBB/pkg/common/models/app"
+	cmb "github.com/vault-thirteen/SimpleBB/pkg/common/models/base"
 	ch "github.com/vault-thirteen/SimpleBB/pkg/common/models/http"
+	cn "github.com/vault-thirteen/SimpleBB/pkg/common/models/net"
 	cmr "github.com/vault-thirteen/SimpleBB/pkg/common/models/rpc"
 	"github.com/vault-thirteen/SimpleBB/pkg/common/models/simple"
 	"io"
 	"log"
+	"mime"
 	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	jrm1 "github.com/vault-thirteen/JSON-RPC-M1"
 
 	"github.com/vault-thirteen/SimpleBB/pkg/GWM/models"
 	s "github.com/vault-thirteen/SimpleBB/pkg/GWM/settings"
@@ -32,6 +49,7 @@ const (
 	ApiFunctionName_GetListOfAllUsers                      = "getListOfAllUsers"
 	ApiFunctionName_GetListOfAllUsersOnPage                = "getListOfAllUsersOnPage"
 	ApiFunctionName_IsUserLoggedIn                         = "isUserLoggedIn"
+	ApiFunctionName_ResetPassword                          = "resetPassword"
 	ApiFunctionName_ChangePassword                         = "changePassword"
 	ApiFunctionName_ChangeEmail                            = "changeEmail"
 	ApiFunctionName_GetUserSession                         = "getUserSession"
@@ -41,14 +59,25 @@ const (
 	ApiFunctionName_SetUserRoleAuthor                      = "setUserRoleAuthor"
 	ApiFunctionName_SetUserRoleWriter                      = "setUserRoleWriter"
 	ApiFunctionName_SetUserRoleReader                      = "setUserRoleReader"
+	ApiFunctionName_GrantPermission                        = "grantPermission"
+	ApiFunctionName_RevokePermission                       = "revokePermission"
+	ApiFunctionName_GetUserPermissions                     = "getUserPermissions"
 	ApiFunctionName_GetSelfRoles                           = "getSelfRoles"
 	ApiFunctionName_BanUser                                = "banUser"
 	ApiFunctionName_UnbanUser                              = "unbanUser"
+	ApiFunctionName_StartTotpEnrolment                     = "startTotpEnrolment"
+	ApiFunctionName_ConfirmTotpEnrolment                   = "confirmTotpEnrolment"
+	ApiFunctionName_DisableSelfTotp                        = "disableSelfTotp"
+	ApiFunctionName_GetSelfTotpStatus                      = "getSelfTotpStatus"
+	ApiFunctionName_RegenerateTotpRecoveryCodes            = "regenerateTotpRecoveryCodes"
+	ApiFunctionName_ResetUserTotp                          = "resetUserTotp"
+	ApiFunctionName_GetAuditLog                            = "getAuditLog"
 
 	// MM.
 	ApiFunctionName_AddSection                  = "addSection"
 	ApiFunctionName_ChangeSectionName           = "changeSectionName"
 	ApiFunctionName_ChangeSectionParent         = "changeSectionParent"
+	ApiFunctionName_ChangeSectionPrivacy        = "changeSectionPrivacy"
 	ApiFunctionName_GetSection                  = "getSection"
 	ApiFunctionName_MoveSectionUp               = "moveSectionUp"
 	ApiFunctionName_MoveSectionDown             = "moveSectionDown"
@@ -56,6 +85,7 @@ const (
 	ApiFunctionName_AddForum                    = "addForum"
 	ApiFunctionName_ChangeForumName             = "changeForumName"
 	ApiFunctionName_ChangeForumSection          = "changeForumSection"
+	ApiFunctionName_ChangeForumReadOnly         = "changeForumReadOnly"
 	ApiFunctionName_GetForum                    = "getForum"
 	ApiFunctionName_MoveForumUp                 = "moveForumUp"
 	ApiFunctionName_MoveForumDown               = "moveForumDown"
@@ -68,17 +98,49 @@ const (
 	ApiFunctionName_MoveThreadUp                = "moveThreadUp"
 	ApiFunctionName_MoveThreadDown              = "moveThreadDown"
 	ApiFunctionName_DeleteThread                = "deleteThread"
+	ApiFunctionName_LockThread                  = "lockThread"
+	ApiFunctionName_UnlockThread                = "unlockThread"
+	ApiFunctionName_ChangeThreadPinned          = "changeThreadPinned"
+	ApiFunctionName_ChangeThreadAnnouncement    = "changeThreadAnnouncement"
 	ApiFunctionName_AddMessage                  = "addMessage"
 	ApiFunctionName_ChangeMessageText           = "changeMessageText"
 	ApiFunctionName_ChangeMessageThread         = "changeMessageThread"
 	ApiFunctionName_GetMessage                  = "getMessage"
 	ApiFunctionName_GetLatestMessageOfThread    = "getLatestMessageOfThread"
 	ApiFunctionName_DeleteMessage               = "deleteMessage"
+	ApiFunctionName_ListMessageRevisions        = "listMessageRevisions"
+	ApiFunctionName_GetMessageRevision          = "getMessageRevision"
+	ApiFunctionName_RestoreMessageRevision      = "restoreMessageRevision"
 	ApiFunctionName_ListThreadAndMessages       = "listThreadAndMessages"
 	ApiFunctionName_ListThreadAndMessagesOnPage = "listThreadAndMessagesOnPage"
 	ApiFunctionName_ListForumAndThreads         = "listForumAndThreads"
 	ApiFunctionName_ListForumAndThreadsOnPage   = "listForumAndThreadsOnPage"
 	ApiFunctionName_ListSectionsAndForums       = "listSectionsAndForums"
+	ApiFunctionName_SearchMessages              = "searchMessages"
+	ApiFunctionName_SearchThreads               = "searchThreads"
+	ApiFunctionName_StartConversation           = "startConversation"
+	ApiFunctionName_SendPrivateMessage          = "sendPrivateMessage"
+	ApiFunctionName_ListConversations           = "listConversations"
+	ApiFunctionName_ReadConversation            = "readConversation"
+	ApiFunctionName_DeleteConversation          = "deleteConversation"
+	ApiFunctionName_CountUnreadPrivateMessages  = "countUnreadPrivateMessages"
+	ApiFunctionName_BlockUser                   = "blockUser"
+	ApiFunctionName_UnblockUser                 = "unblockUser"
+	ApiFunctionName_ListBlockedUsers            = "listBlockedUsers"
+	ApiFunctionName_GetPoll                     = "getPoll"
+	ApiFunctionName_VoteInPoll                  = "voteInPoll"
+	ApiFunctionName_ChangePollVote              = "changePollVote"
+	ApiFunctionName_ListReactions               = "listReactions"
+	ApiFunctionName_AddMessageReaction          = "addMessageReaction"
+	ApiFunctionName_RemoveMessageReaction       = "removeMessageReaction"
+	ApiFunctionName_GetUserReputation           = "getUserReputation"
+	ApiFunctionName_ListTrash                   = "listTrash"
+	ApiFunctionName_RestoreFromTrash            = "restoreFromTrash"
+	ApiFunctionName_RepairDatabaseConsistency   = "repairDatabaseConsistency"
+	ApiFunctionName_ReportMessage               = "reportMessage"
+	ApiFunctionName_ListModerationQueue         = "listModerationQueue"
+	ApiFunctionName_ResolveMessageReports       = "resolveMessageReports"
+	ApiFunctionName_ListModerationDecisions     = "listModerationDecisions"
 
 	// NM.
 	ApiFunctionName_AddNotification             = "addNotification"
@@ -94,18 +156,59 @@ const (
 	ApiFunctionName_GetResourceValue            = "getResourceValue"
 	ApiFunctionName_GetListOfAllResourcesOnPage = "getListOfAllResourcesOnPage"
 	ApiFunctionName_DeleteResource              = "deleteResource"
+	ApiFunctionName_AddNotificationTemplate     = "addNotificationTemplate"
+	ApiFunctionName_GetNotificationTemplate     = "getNotificationTemplate"
+	ApiFunctionName_ListNotificationTemplates   = "listNotificationTemplates"
+	ApiFunctionName_ChangeNotificationTemplate  = "changeNotificationTemplate"
+	ApiFunctionName_DeleteNotificationTemplate  = "deleteNotificationTemplate"
+	ApiFunctionName_SetSelfLanguage             = "setSelfLanguage"
+	ApiFunctionName_GetSelfLanguage             = "getSelfLanguage"
 
 	// SM.
-	ApiFunctionName_AddSubscription            = "addSubscription"
-	ApiFunctionName_IsSelfSubscribed           = "isSelfSubscribed"
-	ApiFunctionName_IsUserSubscribed           = "isUserSubscribed"
-	ApiFunctionName_CountSelfSubscriptions     = "countSelfSubscriptions"
-	ApiFunctionName_GetSelfSubscriptions       = "getSelfSubscriptions"
-	ApiFunctionName_GetSelfSubscriptionsOnPage = "getSelfSubscriptionsOnPage"
-	ApiFunctionName_GetUserSubscriptions       = "getUserSubscriptions"
-	ApiFunctionName_GetUserSubscriptionsOnPage = "getUserSubscriptionsOnPage"
-	ApiFunctionName_DeleteSelfSubscription     = "deleteSelfSubscription"
-	ApiFunctionName_DeleteSubscription         = "deleteSubscription"
+	ApiFunctionName_AddSubscription                     = "addSubscription"
+	ApiFunctionName_IsSelfSubscribed                    = "isSelfSubscribed"
+	ApiFunctionName_IsUserSubscribed                    = "isUserSubscribed"
+	ApiFunctionName_CountSelfSubscriptions              = "countSelfSubscriptions"
+	ApiFunctionName_GetSelfSubscriptions                = "getSelfSubscriptions"
+	ApiFunctionName_GetSelfSubscriptionsOnPage          = "getSelfSubscriptionsOnPage"
+	ApiFunctionName_GetUserSubscriptions                = "getUserSubscriptions"
+	ApiFunctionName_GetUserSubscriptionsOnPage          = "getUserSubscriptionsOnPage"
+	ApiFunctionName_DeleteSelfSubscription              = "deleteSelfSubscription"
+	ApiFunctionName_DeleteSubscription                  = "deleteSubscription"
+	ApiFunctionName_AddSelfForumSubscription            = "addSelfForumSubscription"
+	ApiFunctionName_DeleteSelfForumSubscription         = "deleteSelfForumSubscription"
+	ApiFunctionName_AddSelfSectionSubscription          = "addSelfSectionSubscription"
+	ApiFunctionName_DeleteSelfSectionSubscription       = "deleteSelfSectionSubscription"
+	ApiFunctionName_GetSelfForumAndSectionSubscriptions = "getSelfForumAndSectionSubscriptions"
+	ApiFunctionName_SetSelfDeliveryMode                 = "setSelfDeliveryMode"
+	ApiFunctionName_GetSelfDeliveryMode                 = "getSelfDeliveryMode"
+
+	// GWM.
+	ApiFunctionName_UnblockIPAddress  = "unblockIPAddress"
+	ApiFunctionName_ListIPBlocks      = "listIPBlocks"
+	ApiFunctionName_AddIPListEntry    = "addIPListEntry"
+	ApiFunctionName_DeleteIPListEntry = "deleteIPListEntry"
+	ApiFunctionName_ListIPListEntries = "listIPListEntries"
+)
+
+// Server-sent events.
+const (
+	EventName_Notification = "notification"
+
+	// Comments are sent periodically to keep idle streams alive in proxies.
+	EventStreamKeepAlivePeriodSec = 30
+)
+
+// Attached files.
+const (
+	// Last part of URL path of a thumbnail, i.e. '/attachments/{id}/thumbnail'.
+	AttachmentUrlPart_Thumbnail = "thumbnail"
+
+	// Name of the action used by rate limits of downloads.
+	ActionName_GetAttachment = "getAttachment"
+
+	// Types of files which are shown by browsers instead of being saved.
+	ContentTypePrefix_Image = "image/"
 )
 
 func (srv *Server) handlePublicSettings(rw http.ResponseWriter, req *http.Request) {
@@ -190,6 +293,15 @@ func (srv *Server) handlePublicApi(rw http.ResponseWriter, req *http.Request, cl
 		return
 	}
 
+	// Rate limits (optional).
+	if srv.settings.GetRateLimitSettings().IsEnabled {
+		retryAfter := srv.checkRateLimits(*arwoa.Action, clientIPA, token)
+		if retryAfter > 0 {
+			srv.processRateLimitError(rw, retryAfter)
+			return
+		}
+	}
+
 	var ar = &api2.Request{
 		Action:     arwoa.Action,
 		Parameters: arwoa.Parameters,
@@ -215,6 +327,252 @@ func (srv *Server) handleCaptcha(rw http.ResponseWriter, req *http.Request) {
 	srv.rcsProxy.ServeHTTP(rw, req)
 }
 
+// handleAttachment sends a file attached to a message. A file is requested
+// as '/attachments/{id}', a thumbnail of an image is requested as
+// '/attachments/{id}/thumbnail'. Permission to read the message is checked by
+// the message module.
+func (srv *Server) handleAttachment(rw http.ResponseWriter, req *http.Request, clientIPA simple.IPAS) {
+	if req.Method != http.MethodGet {
+		srv.respondMethodNotAllowed(rw)
+		return
+	}
+
+	urlParts := cn.SplitUrlPath(req.URL.Path)
+	if (len(urlParts) < 2) || (len(urlParts) > 3) {
+		srv.respondNotFound(rw)
+		return
+	}
+
+	attachmentId, err := strconv.ParseUint(urlParts[1], 10, 64)
+	if (err != nil) || (attachmentId == 0) {
+		srv.respondBadRequest(rw)
+		return
+	}
+
+	var isThumbnail = false
+	if len(urlParts) == 3 {
+		if urlParts[2] != AttachmentUrlPart_Thumbnail {
+			srv.respondNotFound(rw)
+			return
+		}
+		isThumbnail = true
+	}
+
+	var token *simple.WebTokenString
+	token, err = simple.GetToken(req)
+	if err != nil {
+		srv.respondBadRequest(rw)
+		return
+	}
+
+	if token == nil {
+		srv.respondForbidden(rw)
+		return
+	}
+
+	// Rate limits (optional).
+	if srv.settings.GetRateLimitSettings().IsEnabled {
+		retryAfter := srv.checkRateLimits(ActionName_GetAttachment, clientIPA, token)
+		if retryAfter > 0 {
+			srv.processRateLimitError(rw, retryAfter)
+			return
+		}
+	}
+
+	var params = mm.GetAttachmentParams{
+		CommonParams: cmr.CommonParams{
+			Auth: &cmr.Auth{
+				UserIPA: clientIPA,
+				Token:   *token,
+			},
+		},
+		AttachmentId: cmb.Id(attachmentId),
+		IsThumbnail:  cmb.Flag(isThumbnail),
+	}
+
+	var result = new(mm.GetAttachmentResult)
+	var re *jrm1.RpcError
+	re, err = srv.mmServiceClient.MakeRequest(context.Background(), mc.FuncGetAttachment, params, result)
+	if err != nil {
+		srv.processInternalServerError(rw, err)
+		return
+	}
+	if re != nil {
+		srv.processRpcError(app.ModuleId_MM, re, rw)
+		return
+	}
+
+	// Images are shown by browsers, other files are saved.
+	var disposition = ch.ContentDisposition_Attachment
+	if strings.HasPrefix(result.ContentType, ContentTypePrefix_Image) {
+		disposition = ch.ContentDisposition_Inline
+	}
+
+	if srv.settings.GetSystemSettings().GetIsDeveloperMode() {
+		rw.Header().Set(header.HttpHeaderAccessControlAllowOrigin, srv.settings.GetSystemSettings().GetDevModeHttpHeaderAccessControlAllowOrigin())
+	}
+	rw.Header().Set(header.HttpHeaderContentType, result.ContentType)
+	rw.Header().Set(ch.HttpHeaderContentDisposition, mime.FormatMediaType(disposition, map[string]string{"filename": result.Attachment.Name.ToString()}))
+	rw.Header().Set(ch.HttpHeaderXContentTypeOptions, ch.ContentTypeOptions_NoSniff)
+	rw.Header().Set(ch.HttpHeaderCacheControl, ch.CacheControl_Private)
+	rw.WriteHeader(http.StatusOK)
+
+	_, err = rw.Write(result.Data)
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+}
+
+// handleEvents streams new notifications of the logged-in user as server-sent
+// events. Every open tab of the user has its own stream. When a client
+// reconnects with the 'Last-Event-ID' header, unread notifications which were
+// missed are sent first.
+func (srv *Server) handleEvents(rw http.ResponseWriter, req *http.Request, clientIPA simple.IPAS) {
+	if req.Method != http.MethodGet {
+		srv.respondMethodNotAllowed(rw)
+		return
+	}
+
+	flusher, ok := rw.(http.Flusher)
+	if !ok {
+		srv.processInternalServerError(rw, errors.New(ErrStreamingIsNotSupported))
+		return
+	}
+
+	var lastEventId cmb.Id
+	lastEventIdStr := req.Header.Get(ch.HttpHeaderLastEventId)
+	if len(lastEventIdStr) > 0 {
+		x, err := strconv.ParseUint(lastEventIdStr, 10, 64)
+		if err != nil {
+			srv.respondBadRequest(rw)
+			return
+		}
+		lastEventId = cmb.Id(x)
+	}
+
+	token, err := simple.GetToken(req)
+	if err != nil {
+		srv.respondBadRequest(rw)
+		return
+	}
+
+	if token == nil {
+		srv.respondForbidden(rw)
+		return
+	}
+
+	var auth = &cmr.Auth{
+		UserIPA: clientIPA,
+		Token:   *token,
+	}
+
+	// Identify the user.
+	var userRoles = new(am.GetSelfRolesResult)
+	var re *jrm1.RpcError
+	re, err = srv.acmServiceClient.MakeRequest(context.Background(), ac.FuncGetSelfRoles, am.GetSelfRolesParams{CommonParams: cmr.CommonParams{Auth: auth}}, userRoles)
+	if err != nil {
+		srv.processInternalServerError(rw, err)
+		return
+	}
+	if re != nil {
+		srv.processRpcError(app.ModuleId_ACM, re, rw)
+		return
+	}
+
+	if !userRoles.User.GetUserParameters().GetRoles().CanLogIn {
+		srv.respondForbidden(rw)
+		return
+	}
+
+	// Subscription is made before reading missed notifications, so that
+	// nothing is lost in between. Duplicates are filtered by identifier.
+	var ns *models.NotificationStream
+	ns, err = srv.notificationHub.Subscribe(userRoles.User.GetUserParameters().GetId())
+	if err != nil {
+		srv.respondWithPlainText(rw, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+	defer srv.notificationHub.Unsubscribe(ns)
+
+	var missedNotifications []nmm.Notification
+	if lastEventId > 0 {
+		var result = new(nm.GetUnreadNotificationsResult)
+		re, err = srv.nmServiceClient.MakeRequest(context.Background(), nc.FuncGetUnreadNotifications, nm.GetUnreadNotificationsParams{CommonParams: cmr.CommonParams{Auth: auth}}, result)
+		if err != nil {
+			srv.processInternalServerError(rw, err)
+			return
+		}
+		if re != nil {
+			srv.processRpcError(app.ModuleId_NM, re, rw)
+			return
+		}
+
+		missedNotifications = result.Notifications
+		sort.Slice(missedNotifications, func(i, j int) bool {
+			return missedNotifications[i].Id < missedNotifications[j].Id
+		})
+	}
+
+	if srv.settings.GetSystemSettings().GetIsDeveloperMode() {
+		rw.Header().Set(header.HttpHeaderAccessControlAllowOrigin, srv.settings.GetSystemSettings().GetDevModeHttpHeaderAccessControlAllowOrigin())
+	}
+	rw.Header().Set(header.HttpHeaderContentType, ch.ContentType_EventStream)
+	rw.Header().Set(ch.HttpHeaderCacheControl, ch.CacheControl_NoCache)
+	rw.WriteHeader(http.StatusOK)
+
+	for i := range missedNotifications {
+		if missedNotifications[i].Id <= lastEventId {
+			continue
+		}
+
+		err = srv.writeNotificationEvent(rw, &missedNotifications[i])
+		if err != nil {
+			srv.logError(err)
+			return
+		}
+		lastEventId = missedNotifications[i].Id
+	}
+	flusher.Flush()
+
+	ticker := time.NewTicker(time.Second * EventStreamKeepAlivePeriodSec)
+	defer ticker.Stop()
+
+	var n *nmm.Notification
+	for {
+		select {
+		case <-req.Context().Done():
+			return
+
+		case n, ok = <-ns.C:
+			if !ok {
+				// The stream was dropped by the hub.
+				return
+			}
+
+			if n.Id <= lastEventId {
+				continue
+			}
+
+			err = srv.writeNotificationEvent(rw, n)
+			if err != nil {
+				srv.logError(err)
+				return
+			}
+			lastEventId = n.Id
+
+		case <-ticker.C:
+			_, err = rw.Write([]byte(":\n\n"))
+			if err != nil {
+				srv.logError(err)
+				return
+			}
+		}
+
+		flusher.Flush()
+	}
+}
+
 // handleFrontEnd serves static files for the front end part.
 func (srv *Server) handleFrontEnd(rw http.ResponseWriter, req *http.Request, clientIPA simple.IPAS) {
 	// While the number of cases is less than 10..20, the "switch" branching
@@ -1,17 +1,39 @@
 package server
 
 import (
+	"context"
 	"github.com/vault-thirteen/SimpleBB/pkg/GWM/dbo"
+	"time"
 )
 
-func (srv *Server) clearIPAddresses() (err error) {
+func (srv *Server) clearIPBlocks(ctx context.Context) (err error) {
 	srv.dbo.LockForWriting()
 	defer srv.dbo.UnlockAfterWriting()
 
-	_, err = srv.dbo.GetPreparedStatementByIndex(dbo.DbPsid_ClearIPAddresses).Exec()
+	_, err = srv.dbo.GetPreparedStatementByIndex(dbo.DbPsid_ClearIPBlocks).ExecContext(ctx)
 	if err != nil {
 		return err
 	}
 
 	return nil
 }
+
+func (srv *Server) clearSubnetOffences(ctx context.Context) (err error) {
+	srv.dbo.LockForWriting()
+	defer srv.dbo.UnlockAfterWriting()
+
+	_, err = srv.dbo.GetPreparedStatementByIndex(dbo.DbPsid_ClearSubnetOffences).ExecContext(ctx, srv.settings.GetSystemSettings().GetOffenceMemoryPeriodSec())
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (srv *Server) clearRateLimits(_ context.Context) (err error) {
+	now := time.Now()
+	srv.rateLimiter.Clear(now)
+	srv.roleCache.Clear(now)
+
+	return nil
+}
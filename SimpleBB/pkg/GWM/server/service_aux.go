@@ -1,51 +1,60 @@
 package server
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	am "github.com/vault-thirteen/SimpleBB/pkg/ACM/rpc"
+	api2 "github.com/vault-thirteen/SimpleBB/pkg/GWM/api"
+	"github.com/vault-thirteen/SimpleBB/pkg/GWM/models"
 	"github.com/vault-thirteen/SimpleBB/pkg/common/models/app"
-	cmb "github.com/vault-thirteen/SimpleBB/pkg/common/models/base"
 	cm "github.com/vault-thirteen/SimpleBB/pkg/common/models/complex/ClientIPAddressSource"
 	cn "github.com/vault-thirteen/SimpleBB/pkg/common/models/net"
+	cmr "github.com/vault-thirteen/SimpleBB/pkg/common/models/rpc"
 	"github.com/vault-thirteen/SimpleBB/pkg/common/models/simple"
 	"net"
 	"net/http"
+	"strings"
+	"time"
 
+	jrm1 "github.com/vault-thirteen/JSON-RPC-M1"
+	ac "github.com/vault-thirteen/SimpleBB/pkg/ACM/client"
 	s "github.com/vault-thirteen/SimpleBB/pkg/GWM/settings"
 	hh "github.com/vault-thirteen/auxie/http-helper"
 )
 
 // Auxiliary functions used in service functions.
 
+// Prefixes of keys of token buckets.
 const (
-	ErrFUnknownRpcErrorCode = "unknown RPC error code: %v"
-	ErrTypeCast             = "type cast error"
+	RateLimitKeyPrefix_IPAddress = "ipa:"
+	RateLimitKeyPrefix_Guest     = "guest:"
+	RateLimitKeyPrefix_User      = "user:"
+	RateLimitKeyPrefix_Action    = "action:"
 )
 
-func (srv *Server) isIPAddressAllowed(req *http.Request) (ok bool, clientIPA simple.IPAS, err error) {
-	clientIPA, err = srv.getClientIPAddress(req)
-	if err != nil {
-		return false, "", err
-	}
+const (
+	ErrFUnknownRpcErrorCode    = "unknown RPC error code: %v"
+	ErrTypeCast                = "type cast error"
+	ErrStreamingIsNotSupported = "streaming is not supported"
+)
 
+func (srv *Server) isIPAddressAllowed(clientIPA simple.IPAS) (ok bool, err error) {
 	var ipa net.IP
 	ipa, err = cn.ParseIPA(clientIPA)
 	if err != nil {
-		return false, "", err
+		return false, err
 	}
 
-	var n cmb.Count
-	n, err = srv.dbo.CountBlocksByIPAddress(ipa)
+	var isBlocked bool
+	isBlocked, err = srv.checkIPAddressBlock(ipa)
 	if err != nil {
 		re := srv.databaseError(err)
-		return false, "", re.AsError()
-	}
-
-	if n != 0 {
-		return false, clientIPA, nil
+		return false, re.AsError()
 	}
 
-	return true, clientIPA, nil
+	return !isBlocked, nil
 }
 
 func (srv *Server) getClientIPAddress(req *http.Request) (cipa simple.IPAS, err error) {
@@ -61,6 +70,10 @@ func (srv *Server) getClientIPAddress(req *http.Request) (cipa simple.IPAS, err
 		return simple.IPAS(host), nil
 
 	case cm.ClientIPAddressSource_CustomHeader:
+		if len(srv.trustedProxies) > 0 {
+			return srv.getClientIPAddressBehindTrustedProxies(req)
+		}
+
 		host, err = hh.GetSingleHttpHeader(req, srv.settings.GetSystemSettings().GetClientIPAddressHeader())
 		if err != nil {
 			return "", err
@@ -73,6 +86,42 @@ func (srv *Server) getClientIPAddress(req *http.Request) (cipa simple.IPAS, err
 	}
 }
 
+// getClientIPAddressBehindTrustedProxies takes the client's IP address from
+// the custom HTTP header only when the request comes from a trusted proxy.
+// Requests coming directly from clients may have a forged header, so the
+// address of the peer is used for them.
+func (srv *Server) getClientIPAddressBehindTrustedProxies(req *http.Request) (cipa simple.IPAS, err error) {
+	var host string
+	host, _, err = cn.SplitHostPort(req.RemoteAddr)
+	if err != nil {
+		return "", err
+	}
+
+	var peerIPA net.IP
+	peerIPA, err = cn.ParseIPA(simple.IPAS(host))
+	if err != nil {
+		return "", err
+	}
+
+	if !cn.IsIPAInList(peerIPA, srv.trustedProxies) {
+		return simple.IPAS(host), nil
+	}
+
+	// Several headers are treated as a single list.
+	headerValues := req.Header.Values(srv.settings.GetSystemSettings().GetClientIPAddressHeader())
+	if len(headerValues) == 0 {
+		return simple.IPAS(host), nil
+	}
+
+	var ipa net.IP
+	ipa, err = cn.GetClientIPAFromForwardedList(strings.Join(headerValues, ","), srv.trustedProxies)
+	if err != nil {
+		return "", err
+	}
+
+	return simple.IPAS(ipa.String()), nil
+}
+
 func (srv *Server) getHttpStatusCodeByRpcErrorCode(moduleId int, rpcErrorCode int) (httpStatusCode int, err error) {
 	var ok bool
 
@@ -88,6 +137,12 @@ func (srv *Server) getHttpStatusCodeByRpcErrorCode(moduleId int, rpcErrorCode in
 			return httpStatusCode, nil
 		}
 
+	case app.ModuleId_GWM:
+		httpStatusCode, ok = srv.gwmHttpStatusCodesByRpcErrorCode[rpcErrorCode]
+		if ok {
+			return httpStatusCode, nil
+		}
+
 	case app.ModuleId_MM:
 		httpStatusCode, ok = srv.mmHttpStatusCodesByRpcErrorCode[rpcErrorCode]
 		if ok {
@@ -109,3 +164,145 @@ func (srv *Server) getHttpStatusCodeByRpcErrorCode(moduleId int, rpcErrorCode in
 
 	return 0, fmt.Errorf(ErrFUnknownRpcErrorCode, rpcErrorCode)
 }
+
+// checkRateLimits takes tokens from all the buckets of the request. When any
+// of the buckets is empty, the request must be rejected and the time after
+// which it may be repeated is returned. Guests are identified by their IP
+// addresses, logged-in users are identified by their IDs.
+func (srv *Server) checkRateLimits(action string, clientIPA simple.IPAS, token *simple.WebTokenString) (retryAfter time.Duration) {
+	rls := srv.settings.GetRateLimitSettings()
+	now := time.Now()
+
+	// IP address.
+	ok, retryAfter := srv.takeRateLimitToken(RateLimitKeyPrefix_IPAddress+clientIPA.ToString(), rls.IPAddress, now)
+	if !ok {
+		return retryAfter
+	}
+
+	var cr = models.CachedRole{Role: s.RateLimitRole_Guest}
+	if token != nil {
+		cr = srv.getRateLimitRole(clientIPA, *token, now)
+	}
+
+	var userKey string
+	if cr.UserId == 0 {
+		userKey = RateLimitKeyPrefix_Guest + clientIPA.ToString()
+	} else {
+		userKey = RateLimitKeyPrefix_User + cr.UserId.ToString()
+	}
+
+	// User.
+	ok, retryAfter = srv.takeRateLimitToken(userKey, rls.User.GetLimit(cr.Role), now)
+	if !ok {
+		return retryAfter
+	}
+
+	// Action.
+	rlbr, isLimited := rls.Actions[action]
+	if !isLimited {
+		return 0
+	}
+
+	ok, retryAfter = srv.takeRateLimitToken(RateLimitKeyPrefix_Action+action+":"+userKey, rlbr.GetLimit(cr.Role), now)
+	if !ok {
+		return retryAfter
+	}
+
+	return 0
+}
+
+func (srv *Server) takeRateLimitToken(key string, rl s.RateLimit, now time.Time) (ok bool, retryAfter time.Duration) {
+	if rl.IsUnlimited() {
+		return true, 0
+	}
+
+	return srv.rateLimiter.Take(key, rl.Capacity, rl.RefillPerMinute, now)
+}
+
+// getRateLimitRole returns a role of the user used in rate limits. Roles are
+// cached for some time. When roles can not be received, e.g. when the token
+// is not valid, the user is treated as a guest.
+func (srv *Server) getRateLimitRole(clientIPA simple.IPAS, token simple.WebTokenString, now time.Time) (cr models.CachedRole) {
+	var ok bool
+	cr, ok = srv.roleCache.Get(token.ToString(), now)
+	if ok {
+		return cr
+	}
+
+	ttl := time.Duration(srv.settings.GetRateLimitSettings().RoleCacheTtlSec.AsInt()) * time.Second
+	cr = models.CachedRole{
+		Role:           s.RateLimitRole_Guest,
+		ExpirationTime: now.Add(ttl),
+	}
+
+	action := ApiFunctionName_GetSelfRoles
+	var params json.RawMessage = []byte("{}")
+	var ar = &api2.Request{
+		Action:     &action,
+		Parameters: &params,
+		Authorisation: &cmr.Auth{
+			UserIPA: clientIPA,
+			Token:   token,
+		},
+	}
+
+	response, err := srv.getSelfRoles(ar)
+	if err != nil {
+		srv.logError(err)
+		srv.roleCache.Set(token.ToString(), cr)
+		return cr
+	}
+
+	var result *am.GetSelfRolesResult
+	result, ok = response.Result.(*am.GetSelfRolesResult)
+	if !ok {
+		srv.logError(errors.New(ErrTypeCast))
+		return cr
+	}
+
+	cr.UserId = result.User.GetUserParameters().GetId()
+
+	roles := result.User.GetUserParameters().GetRoles()
+	switch {
+	case roles.IsAdministrator.AsBool():
+		cr.Role = s.RateLimitRole_Administrator
+	case roles.IsModerator.AsBool():
+		cr.Role = s.RateLimitRole_Moderator
+	case roles.CanLogIn.AsBool():
+		cr.Role = s.RateLimitRole_User
+	}
+
+	srv.roleCache.Set(token.ToString(), cr)
+	return cr
+}
+
+// mustBeAnAdministrator checks that the author of the request is an
+// administrator. Functions of the gateway module do not check roles
+// themselves, so this check is made before calling them. When the check
+// fails, the response is sent and false is returned.
+func (srv *Server) mustBeAnAdministrator(ar *api2.Request, hrw http.ResponseWriter) (ok bool) {
+	if ar.Authorisation == nil {
+		srv.respondForbidden(hrw)
+		return false
+	}
+
+	var params = am.GetSelfRolesParams{CommonParams: cmr.CommonParams{Auth: ar.Authorisation}}
+	var result = new(am.GetSelfRolesResult)
+	var re *jrm1.RpcError
+	re, err := srv.acmServiceClient.MakeRequest(context.Background(), ac.FuncGetSelfRoles, params, result)
+	if err != nil {
+		srv.processInternalServerError(hrw, err)
+		return false
+	}
+	if re != nil {
+		srv.processRpcError(app.ModuleId_ACM, re, hrw)
+		return false
+	}
+
+	if !result.User.GetUserParameters().GetRoles().IsAdministrator.AsBool() {
+		srv.respondForbidden(hrw)
+		return false
+	}
+
+	return true
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	am "github.com/vault-thirteen/SimpleBB/pkg/ACM/rpc"
 	api2 "github.com/vault-thirteen/SimpleBB/pkg/GWM/api"
+	gm "github.com/vault-thirteen/SimpleBB/pkg/GWM/rpc"
 	mm "github.com/vault-thirteen/SimpleBB/pkg/MM/rpc"
 	nm "github.com/vault-thirteen/SimpleBB/pkg/NM/rpc"
 	sm "github.com/vault-thirteen/SimpleBB/pkg/SM/rpc"
@@ -36,6 +37,9 @@ import (
 //
 //	4. The 'ChangeEmail' function has additional code which:
 //		4.1. clears a token.
+//
+//	5. The 'ResetPassword' function has additional code which:
+//		5.1. ignores a token.
 
 // Service functions.
 
@@ -465,6 +469,39 @@ func (srv *Server) IsUserLoggedIn(ar *api2.Request, _ *http.Request, hrw http.Re
 	return
 }
 
+func (srv *Server) ResetPassword(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
+	var err error
+	var params am.ResetPasswordParams
+	err = json.Unmarshal(*ar.Parameters, &params)
+	if err != nil {
+		srv.respondBadRequest(hrw)
+		return
+	}
+
+	params.CommonParams = cmr.CommonParams{Auth: ar.Authorisation}
+
+	// A user who has forgotten the password may still have an outdated
+	// token, so we ignore it. [5.1]
+	params.CommonParams.Auth.Token = ""
+
+	var result = new(am.ResetPasswordResult)
+	var re *jrm1.RpcError
+	re, err = srv.acmServiceClient.MakeRequest(context.Background(), ac.FuncResetPassword, params, result)
+	if err != nil {
+		srv.processInternalServerError(hrw, err)
+		return
+	}
+	if re != nil {
+		srv.processRpcError(app.ModuleId_ACM, re, hrw)
+		return
+	}
+
+	result.CommonResult.Clear()
+	var response = &api2.Response{Action: ar.Action, Result: result}
+	srv.respondWithJsonObject(hrw, response)
+	return
+}
+
 func (srv *Server) ChangePassword(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
 	var err error
 	var params am.ChangePasswordParams
@@ -784,6 +821,93 @@ func (srv *Server) SetUserRoleReader(ar *api2.Request, _ *http.Request, hrw http
 	return
 }
 
+func (srv *Server) GrantPermission(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
+	var err error
+	var params am.GrantPermissionParams
+	err = json.Unmarshal(*ar.Parameters, &params)
+	if err != nil {
+		srv.respondBadRequest(hrw)
+		return
+	}
+
+	params.CommonParams = cmr.CommonParams{Auth: ar.Authorisation}
+
+	var result = new(am.GrantPermissionResult)
+	var re *jrm1.RpcError
+	re, err = srv.acmServiceClient.MakeRequest(context.Background(), ac.FuncGrantPermission, params, result)
+	if err != nil {
+		srv.processInternalServerError(hrw, err)
+		return
+	}
+	if re != nil {
+		srv.processRpcError(app.ModuleId_ACM, re, hrw)
+		return
+	}
+
+	result.CommonResult.Clear()
+	var response = &api2.Response{Action: ar.Action, Result: result}
+	srv.respondWithJsonObject(hrw, response)
+	return
+}
+
+func (srv *Server) RevokePermission(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
+	var err error
+	var params am.RevokePermissionParams
+	err = json.Unmarshal(*ar.Parameters, &params)
+	if err != nil {
+		srv.respondBadRequest(hrw)
+		return
+	}
+
+	params.CommonParams = cmr.CommonParams{Auth: ar.Authorisation}
+
+	var result = new(am.RevokePermissionResult)
+	var re *jrm1.RpcError
+	re, err = srv.acmServiceClient.MakeRequest(context.Background(), ac.FuncRevokePermission, params, result)
+	if err != nil {
+		srv.processInternalServerError(hrw, err)
+		return
+	}
+	if re != nil {
+		srv.processRpcError(app.ModuleId_ACM, re, hrw)
+		return
+	}
+
+	result.CommonResult.Clear()
+	var response = &api2.Response{Action: ar.Action, Result: result}
+	srv.respondWithJsonObject(hrw, response)
+	return
+}
+
+func (srv *Server) GetUserPermissions(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
+	var err error
+	var params am.GetUserPermissionsParams
+	err = json.Unmarshal(*ar.Parameters, &params)
+	if err != nil {
+		srv.respondBadRequest(hrw)
+		return
+	}
+
+	params.CommonParams = cmr.CommonParams{Auth: ar.Authorisation}
+
+	var result = new(am.GetUserPermissionsResult)
+	var re *jrm1.RpcError
+	re, err = srv.acmServiceClient.MakeRequest(context.Background(), ac.FuncGetUserPermissions, params, result)
+	if err != nil {
+		srv.processInternalServerError(hrw, err)
+		return
+	}
+	if re != nil {
+		srv.processRpcError(app.ModuleId_ACM, re, hrw)
+		return
+	}
+
+	result.CommonResult.Clear()
+	var response = &api2.Response{Action: ar.Action, Result: result}
+	srv.respondWithJsonObject(hrw, response)
+	return
+}
+
 // GetSelfRoles is a normal version of 'GetSelfRoles' RPC request for public
 // usage. For internal purposes, use its internal variant – 'getSelfRoles'.
 func (srv *Server) GetSelfRoles(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
@@ -919,11 +1043,9 @@ func (srv *Server) UnbanUser(ar *api2.Request, _ *http.Request, hrw http.Respons
 	return
 }
 
-// MM.
-
-func (srv *Server) AddSection(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
+func (srv *Server) StartTotpEnrolment(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
 	var err error
-	var params mm.AddSectionParams
+	var params am.StartTotpEnrolmentParams
 	err = json.Unmarshal(*ar.Parameters, &params)
 	if err != nil {
 		srv.respondBadRequest(hrw)
@@ -932,15 +1054,15 @@ func (srv *Server) AddSection(ar *api2.Request, _ *http.Request, hrw http.Respon
 
 	params.CommonParams = cmr.CommonParams{Auth: ar.Authorisation}
 
-	var result = new(mm.AddSectionResult)
+	var result = new(am.StartTotpEnrolmentResult)
 	var re *jrm1.RpcError
-	re, err = srv.mmServiceClient.MakeRequest(context.Background(), mc.FuncAddSection, params, result)
+	re, err = srv.acmServiceClient.MakeRequest(context.Background(), ac.FuncStartTotpEnrolment, params, result)
 	if err != nil {
 		srv.processInternalServerError(hrw, err)
 		return
 	}
 	if re != nil {
-		srv.processRpcError(app.ModuleId_MM, re, hrw)
+		srv.processRpcError(app.ModuleId_ACM, re, hrw)
 		return
 	}
 
@@ -950,9 +1072,9 @@ func (srv *Server) AddSection(ar *api2.Request, _ *http.Request, hrw http.Respon
 	return
 }
 
-func (srv *Server) ChangeSectionName(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
+func (srv *Server) ConfirmTotpEnrolment(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
 	var err error
-	var params mm.ChangeSectionNameParams
+	var params am.ConfirmTotpEnrolmentParams
 	err = json.Unmarshal(*ar.Parameters, &params)
 	if err != nil {
 		srv.respondBadRequest(hrw)
@@ -961,15 +1083,15 @@ func (srv *Server) ChangeSectionName(ar *api2.Request, _ *http.Request, hrw http
 
 	params.CommonParams = cmr.CommonParams{Auth: ar.Authorisation}
 
-	var result = new(mm.ChangeSectionNameResult)
+	var result = new(am.ConfirmTotpEnrolmentResult)
 	var re *jrm1.RpcError
-	re, err = srv.mmServiceClient.MakeRequest(context.Background(), mc.FuncChangeSectionName, params, result)
+	re, err = srv.acmServiceClient.MakeRequest(context.Background(), ac.FuncConfirmTotpEnrolment, params, result)
 	if err != nil {
 		srv.processInternalServerError(hrw, err)
 		return
 	}
 	if re != nil {
-		srv.processRpcError(app.ModuleId_MM, re, hrw)
+		srv.processRpcError(app.ModuleId_ACM, re, hrw)
 		return
 	}
 
@@ -979,9 +1101,9 @@ func (srv *Server) ChangeSectionName(ar *api2.Request, _ *http.Request, hrw http
 	return
 }
 
-func (srv *Server) ChangeSectionParent(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
+func (srv *Server) DisableSelfTotp(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
 	var err error
-	var params mm.ChangeSectionParentParams
+	var params am.DisableSelfTotpParams
 	err = json.Unmarshal(*ar.Parameters, &params)
 	if err != nil {
 		srv.respondBadRequest(hrw)
@@ -990,15 +1112,15 @@ func (srv *Server) ChangeSectionParent(ar *api2.Request, _ *http.Request, hrw ht
 
 	params.CommonParams = cmr.CommonParams{Auth: ar.Authorisation}
 
-	var result = new(mm.ChangeSectionParentResult)
+	var result = new(am.DisableSelfTotpResult)
 	var re *jrm1.RpcError
-	re, err = srv.mmServiceClient.MakeRequest(context.Background(), mc.FuncChangeSectionParent, params, result)
+	re, err = srv.acmServiceClient.MakeRequest(context.Background(), ac.FuncDisableSelfTotp, params, result)
 	if err != nil {
 		srv.processInternalServerError(hrw, err)
 		return
 	}
 	if re != nil {
-		srv.processRpcError(app.ModuleId_MM, re, hrw)
+		srv.processRpcError(app.ModuleId_ACM, re, hrw)
 		return
 	}
 
@@ -1008,9 +1130,9 @@ func (srv *Server) ChangeSectionParent(ar *api2.Request, _ *http.Request, hrw ht
 	return
 }
 
-func (srv *Server) GetSection(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
+func (srv *Server) GetSelfTotpStatus(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
 	var err error
-	var params mm.GetSectionParams
+	var params am.GetSelfTotpStatusParams
 	err = json.Unmarshal(*ar.Parameters, &params)
 	if err != nil {
 		srv.respondBadRequest(hrw)
@@ -1019,15 +1141,15 @@ func (srv *Server) GetSection(ar *api2.Request, _ *http.Request, hrw http.Respon
 
 	params.CommonParams = cmr.CommonParams{Auth: ar.Authorisation}
 
-	var result = new(mm.GetSectionResult)
+	var result = new(am.GetSelfTotpStatusResult)
 	var re *jrm1.RpcError
-	re, err = srv.mmServiceClient.MakeRequest(context.Background(), mc.FuncGetSection, params, result)
+	re, err = srv.acmServiceClient.MakeRequest(context.Background(), ac.FuncGetSelfTotpStatus, params, result)
 	if err != nil {
 		srv.processInternalServerError(hrw, err)
 		return
 	}
 	if re != nil {
-		srv.processRpcError(app.ModuleId_MM, re, hrw)
+		srv.processRpcError(app.ModuleId_ACM, re, hrw)
 		return
 	}
 
@@ -1037,9 +1159,9 @@ func (srv *Server) GetSection(ar *api2.Request, _ *http.Request, hrw http.Respon
 	return
 }
 
-func (srv *Server) MoveSectionUp(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
+func (srv *Server) RegenerateTotpRecoveryCodes(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
 	var err error
-	var params mm.MoveSectionUpParams
+	var params am.RegenerateTotpRecoveryCodesParams
 	err = json.Unmarshal(*ar.Parameters, &params)
 	if err != nil {
 		srv.respondBadRequest(hrw)
@@ -1048,15 +1170,15 @@ func (srv *Server) MoveSectionUp(ar *api2.Request, _ *http.Request, hrw http.Res
 
 	params.CommonParams = cmr.CommonParams{Auth: ar.Authorisation}
 
-	var result = new(mm.MoveSectionUpResult)
+	var result = new(am.RegenerateTotpRecoveryCodesResult)
 	var re *jrm1.RpcError
-	re, err = srv.mmServiceClient.MakeRequest(context.Background(), mc.FuncMoveSectionUp, params, result)
+	re, err = srv.acmServiceClient.MakeRequest(context.Background(), ac.FuncRegenerateTotpRecoveryCodes, params, result)
 	if err != nil {
 		srv.processInternalServerError(hrw, err)
 		return
 	}
 	if re != nil {
-		srv.processRpcError(app.ModuleId_MM, re, hrw)
+		srv.processRpcError(app.ModuleId_ACM, re, hrw)
 		return
 	}
 
@@ -1066,9 +1188,9 @@ func (srv *Server) MoveSectionUp(ar *api2.Request, _ *http.Request, hrw http.Res
 	return
 }
 
-func (srv *Server) MoveSectionDown(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
+func (srv *Server) ResetUserTotp(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
 	var err error
-	var params mm.MoveSectionDownParams
+	var params am.ResetUserTotpParams
 	err = json.Unmarshal(*ar.Parameters, &params)
 	if err != nil {
 		srv.respondBadRequest(hrw)
@@ -1077,15 +1199,15 @@ func (srv *Server) MoveSectionDown(ar *api2.Request, _ *http.Request, hrw http.R
 
 	params.CommonParams = cmr.CommonParams{Auth: ar.Authorisation}
 
-	var result = new(mm.MoveSectionDownResult)
+	var result = new(am.ResetUserTotpResult)
 	var re *jrm1.RpcError
-	re, err = srv.mmServiceClient.MakeRequest(context.Background(), mc.FuncMoveSectionDown, params, result)
+	re, err = srv.acmServiceClient.MakeRequest(context.Background(), ac.FuncResetUserTotp, params, result)
 	if err != nil {
 		srv.processInternalServerError(hrw, err)
 		return
 	}
 	if re != nil {
-		srv.processRpcError(app.ModuleId_MM, re, hrw)
+		srv.processRpcError(app.ModuleId_ACM, re, hrw)
 		return
 	}
 
@@ -1095,9 +1217,9 @@ func (srv *Server) MoveSectionDown(ar *api2.Request, _ *http.Request, hrw http.R
 	return
 }
 
-func (srv *Server) DeleteSection(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
+func (srv *Server) GetAuditLog(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
 	var err error
-	var params mm.DeleteSectionParams
+	var params am.GetAuditLogParams
 	err = json.Unmarshal(*ar.Parameters, &params)
 	if err != nil {
 		srv.respondBadRequest(hrw)
@@ -1106,15 +1228,15 @@ func (srv *Server) DeleteSection(ar *api2.Request, _ *http.Request, hrw http.Res
 
 	params.CommonParams = cmr.CommonParams{Auth: ar.Authorisation}
 
-	var result = new(mm.DeleteSectionResult)
+	var result = new(am.GetAuditLogResult)
 	var re *jrm1.RpcError
-	re, err = srv.mmServiceClient.MakeRequest(context.Background(), mc.FuncDeleteSection, params, result)
+	re, err = srv.acmServiceClient.MakeRequest(context.Background(), ac.FuncGetAuditLog, params, result)
 	if err != nil {
 		srv.processInternalServerError(hrw, err)
 		return
 	}
 	if re != nil {
-		srv.processRpcError(app.ModuleId_MM, re, hrw)
+		srv.processRpcError(app.ModuleId_ACM, re, hrw)
 		return
 	}
 
@@ -1124,9 +1246,11 @@ func (srv *Server) DeleteSection(ar *api2.Request, _ *http.Request, hrw http.Res
 	return
 }
 
-func (srv *Server) AddForum(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
+// MM.
+
+func (srv *Server) AddSection(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
 	var err error
-	var params mm.AddForumParams
+	var params mm.AddSectionParams
 	err = json.Unmarshal(*ar.Parameters, &params)
 	if err != nil {
 		srv.respondBadRequest(hrw)
@@ -1135,9 +1259,9 @@ func (srv *Server) AddForum(ar *api2.Request, _ *http.Request, hrw http.Response
 
 	params.CommonParams = cmr.CommonParams{Auth: ar.Authorisation}
 
-	var result = new(mm.AddForumResult)
+	var result = new(mm.AddSectionResult)
 	var re *jrm1.RpcError
-	re, err = srv.mmServiceClient.MakeRequest(context.Background(), mc.FuncAddForum, params, result)
+	re, err = srv.mmServiceClient.MakeRequest(context.Background(), mc.FuncAddSection, params, result)
 	if err != nil {
 		srv.processInternalServerError(hrw, err)
 		return
@@ -1153,9 +1277,9 @@ func (srv *Server) AddForum(ar *api2.Request, _ *http.Request, hrw http.Response
 	return
 }
 
-func (srv *Server) ChangeForumName(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
+func (srv *Server) ChangeSectionName(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
 	var err error
-	var params mm.ChangeForumNameParams
+	var params mm.ChangeSectionNameParams
 	err = json.Unmarshal(*ar.Parameters, &params)
 	if err != nil {
 		srv.respondBadRequest(hrw)
@@ -1164,9 +1288,9 @@ func (srv *Server) ChangeForumName(ar *api2.Request, _ *http.Request, hrw http.R
 
 	params.CommonParams = cmr.CommonParams{Auth: ar.Authorisation}
 
-	var result = new(mm.ChangeForumNameResult)
+	var result = new(mm.ChangeSectionNameResult)
 	var re *jrm1.RpcError
-	re, err = srv.mmServiceClient.MakeRequest(context.Background(), mc.FuncChangeForumName, params, result)
+	re, err = srv.mmServiceClient.MakeRequest(context.Background(), mc.FuncChangeSectionName, params, result)
 	if err != nil {
 		srv.processInternalServerError(hrw, err)
 		return
@@ -1182,9 +1306,9 @@ func (srv *Server) ChangeForumName(ar *api2.Request, _ *http.Request, hrw http.R
 	return
 }
 
-func (srv *Server) ChangeForumSection(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
+func (srv *Server) ChangeSectionParent(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
 	var err error
-	var params mm.ChangeForumSectionParams
+	var params mm.ChangeSectionParentParams
 	err = json.Unmarshal(*ar.Parameters, &params)
 	if err != nil {
 		srv.respondBadRequest(hrw)
@@ -1193,9 +1317,9 @@ func (srv *Server) ChangeForumSection(ar *api2.Request, _ *http.Request, hrw htt
 
 	params.CommonParams = cmr.CommonParams{Auth: ar.Authorisation}
 
-	var result = new(mm.ChangeForumSectionResult)
+	var result = new(mm.ChangeSectionParentResult)
 	var re *jrm1.RpcError
-	re, err = srv.mmServiceClient.MakeRequest(context.Background(), mc.FuncChangeForumSection, params, result)
+	re, err = srv.mmServiceClient.MakeRequest(context.Background(), mc.FuncChangeSectionParent, params, result)
 	if err != nil {
 		srv.processInternalServerError(hrw, err)
 		return
@@ -1211,9 +1335,9 @@ func (srv *Server) ChangeForumSection(ar *api2.Request, _ *http.Request, hrw htt
 	return
 }
 
-func (srv *Server) GetForum(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
+func (srv *Server) ChangeSectionPrivacy(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
 	var err error
-	var params mm.GetForumParams
+	var params mm.ChangeSectionPrivacyParams
 	err = json.Unmarshal(*ar.Parameters, &params)
 	if err != nil {
 		srv.respondBadRequest(hrw)
@@ -1222,9 +1346,9 @@ func (srv *Server) GetForum(ar *api2.Request, _ *http.Request, hrw http.Response
 
 	params.CommonParams = cmr.CommonParams{Auth: ar.Authorisation}
 
-	var result = new(mm.GetForumResult)
+	var result = new(mm.ChangeSectionPrivacyResult)
 	var re *jrm1.RpcError
-	re, err = srv.mmServiceClient.MakeRequest(context.Background(), mc.FuncGetForum, params, result)
+	re, err = srv.mmServiceClient.MakeRequest(context.Background(), mc.FuncChangeSectionPrivacy, params, result)
 	if err != nil {
 		srv.processInternalServerError(hrw, err)
 		return
@@ -1240,9 +1364,9 @@ func (srv *Server) GetForum(ar *api2.Request, _ *http.Request, hrw http.Response
 	return
 }
 
-func (srv *Server) MoveForumUp(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
+func (srv *Server) GetSection(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
 	var err error
-	var params mm.MoveForumUpParams
+	var params mm.GetSectionParams
 	err = json.Unmarshal(*ar.Parameters, &params)
 	if err != nil {
 		srv.respondBadRequest(hrw)
@@ -1251,9 +1375,9 @@ func (srv *Server) MoveForumUp(ar *api2.Request, _ *http.Request, hrw http.Respo
 
 	params.CommonParams = cmr.CommonParams{Auth: ar.Authorisation}
 
-	var result = new(mm.MoveForumUpResult)
+	var result = new(mm.GetSectionResult)
 	var re *jrm1.RpcError
-	re, err = srv.mmServiceClient.MakeRequest(context.Background(), mc.FuncMoveForumUp, params, result)
+	re, err = srv.mmServiceClient.MakeRequest(context.Background(), mc.FuncGetSection, params, result)
 	if err != nil {
 		srv.processInternalServerError(hrw, err)
 		return
@@ -1269,9 +1393,9 @@ func (srv *Server) MoveForumUp(ar *api2.Request, _ *http.Request, hrw http.Respo
 	return
 }
 
-func (srv *Server) MoveForumDown(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
+func (srv *Server) MoveSectionUp(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
 	var err error
-	var params mm.MoveForumDownParams
+	var params mm.MoveSectionUpParams
 	err = json.Unmarshal(*ar.Parameters, &params)
 	if err != nil {
 		srv.respondBadRequest(hrw)
@@ -1280,9 +1404,9 @@ func (srv *Server) MoveForumDown(ar *api2.Request, _ *http.Request, hrw http.Res
 
 	params.CommonParams = cmr.CommonParams{Auth: ar.Authorisation}
 
-	var result = new(mm.MoveForumDownResult)
+	var result = new(mm.MoveSectionUpResult)
 	var re *jrm1.RpcError
-	re, err = srv.mmServiceClient.MakeRequest(context.Background(), mc.FuncMoveForumDown, params, result)
+	re, err = srv.mmServiceClient.MakeRequest(context.Background(), mc.FuncMoveSectionUp, params, result)
 	if err != nil {
 		srv.processInternalServerError(hrw, err)
 		return
@@ -1298,9 +1422,9 @@ func (srv *Server) MoveForumDown(ar *api2.Request, _ *http.Request, hrw http.Res
 	return
 }
 
-func (srv *Server) DeleteForum(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
+func (srv *Server) MoveSectionDown(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
 	var err error
-	var params mm.DeleteForumParams
+	var params mm.MoveSectionDownParams
 	err = json.Unmarshal(*ar.Parameters, &params)
 	if err != nil {
 		srv.respondBadRequest(hrw)
@@ -1309,9 +1433,9 @@ func (srv *Server) DeleteForum(ar *api2.Request, _ *http.Request, hrw http.Respo
 
 	params.CommonParams = cmr.CommonParams{Auth: ar.Authorisation}
 
-	var result = new(mm.DeleteForumResult)
+	var result = new(mm.MoveSectionDownResult)
 	var re *jrm1.RpcError
-	re, err = srv.mmServiceClient.MakeRequest(context.Background(), mc.FuncDeleteForum, params, result)
+	re, err = srv.mmServiceClient.MakeRequest(context.Background(), mc.FuncMoveSectionDown, params, result)
 	if err != nil {
 		srv.processInternalServerError(hrw, err)
 		return
@@ -1327,9 +1451,9 @@ func (srv *Server) DeleteForum(ar *api2.Request, _ *http.Request, hrw http.Respo
 	return
 }
 
-func (srv *Server) AddThread(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
+func (srv *Server) DeleteSection(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
 	var err error
-	var params mm.AddThreadParams
+	var params mm.DeleteSectionParams
 	err = json.Unmarshal(*ar.Parameters, &params)
 	if err != nil {
 		srv.respondBadRequest(hrw)
@@ -1338,9 +1462,9 @@ func (srv *Server) AddThread(ar *api2.Request, _ *http.Request, hrw http.Respons
 
 	params.CommonParams = cmr.CommonParams{Auth: ar.Authorisation}
 
-	var result = new(mm.AddThreadResult)
+	var result = new(mm.DeleteSectionResult)
 	var re *jrm1.RpcError
-	re, err = srv.mmServiceClient.MakeRequest(context.Background(), mc.FuncAddThread, params, result)
+	re, err = srv.mmServiceClient.MakeRequest(context.Background(), mc.FuncDeleteSection, params, result)
 	if err != nil {
 		srv.processInternalServerError(hrw, err)
 		return
@@ -1356,9 +1480,9 @@ func (srv *Server) AddThread(ar *api2.Request, _ *http.Request, hrw http.Respons
 	return
 }
 
-func (srv *Server) ChangeThreadName(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
+func (srv *Server) AddForum(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
 	var err error
-	var params mm.ChangeThreadNameParams
+	var params mm.AddForumParams
 	err = json.Unmarshal(*ar.Parameters, &params)
 	if err != nil {
 		srv.respondBadRequest(hrw)
@@ -1367,9 +1491,9 @@ func (srv *Server) ChangeThreadName(ar *api2.Request, _ *http.Request, hrw http.
 
 	params.CommonParams = cmr.CommonParams{Auth: ar.Authorisation}
 
-	var result = new(mm.ChangeThreadNameResult)
+	var result = new(mm.AddForumResult)
 	var re *jrm1.RpcError
-	re, err = srv.mmServiceClient.MakeRequest(context.Background(), mc.FuncChangeThreadName, params, result)
+	re, err = srv.mmServiceClient.MakeRequest(context.Background(), mc.FuncAddForum, params, result)
 	if err != nil {
 		srv.processInternalServerError(hrw, err)
 		return
@@ -1385,9 +1509,9 @@ func (srv *Server) ChangeThreadName(ar *api2.Request, _ *http.Request, hrw http.
 	return
 }
 
-func (srv *Server) ChangeThreadForum(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
+func (srv *Server) ChangeForumName(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
 	var err error
-	var params mm.ChangeThreadForumParams
+	var params mm.ChangeForumNameParams
 	err = json.Unmarshal(*ar.Parameters, &params)
 	if err != nil {
 		srv.respondBadRequest(hrw)
@@ -1396,9 +1520,9 @@ func (srv *Server) ChangeThreadForum(ar *api2.Request, _ *http.Request, hrw http
 
 	params.CommonParams = cmr.CommonParams{Auth: ar.Authorisation}
 
-	var result = new(mm.ChangeThreadForumResult)
+	var result = new(mm.ChangeForumNameResult)
 	var re *jrm1.RpcError
-	re, err = srv.mmServiceClient.MakeRequest(context.Background(), mc.FuncChangeThreadForum, params, result)
+	re, err = srv.mmServiceClient.MakeRequest(context.Background(), mc.FuncChangeForumName, params, result)
 	if err != nil {
 		srv.processInternalServerError(hrw, err)
 		return
@@ -1414,9 +1538,9 @@ func (srv *Server) ChangeThreadForum(ar *api2.Request, _ *http.Request, hrw http
 	return
 }
 
-func (srv *Server) GetThread(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
+func (srv *Server) ChangeForumSection(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
 	var err error
-	var params mm.GetThreadParams
+	var params mm.ChangeForumSectionParams
 	err = json.Unmarshal(*ar.Parameters, &params)
 	if err != nil {
 		srv.respondBadRequest(hrw)
@@ -1425,9 +1549,9 @@ func (srv *Server) GetThread(ar *api2.Request, _ *http.Request, hrw http.Respons
 
 	params.CommonParams = cmr.CommonParams{Auth: ar.Authorisation}
 
-	var result = new(mm.GetThreadResult)
+	var result = new(mm.ChangeForumSectionResult)
 	var re *jrm1.RpcError
-	re, err = srv.mmServiceClient.MakeRequest(context.Background(), mc.FuncGetThread, params, result)
+	re, err = srv.mmServiceClient.MakeRequest(context.Background(), mc.FuncChangeForumSection, params, result)
 	if err != nil {
 		srv.processInternalServerError(hrw, err)
 		return
@@ -1443,9 +1567,9 @@ func (srv *Server) GetThread(ar *api2.Request, _ *http.Request, hrw http.Respons
 	return
 }
 
-func (srv *Server) GetThreadNamesByIds(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
+func (srv *Server) ChangeForumReadOnly(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
 	var err error
-	var params mm.GetThreadNamesByIdsParams
+	var params mm.ChangeForumReadOnlyParams
 	err = json.Unmarshal(*ar.Parameters, &params)
 	if err != nil {
 		srv.respondBadRequest(hrw)
@@ -1454,9 +1578,9 @@ func (srv *Server) GetThreadNamesByIds(ar *api2.Request, _ *http.Request, hrw ht
 
 	params.CommonParams = cmr.CommonParams{Auth: ar.Authorisation}
 
-	var result = new(mm.GetThreadNamesByIdsResult)
+	var result = new(mm.ChangeForumReadOnlyResult)
 	var re *jrm1.RpcError
-	re, err = srv.mmServiceClient.MakeRequest(context.Background(), mc.FuncGetThreadNamesByIds, params, result)
+	re, err = srv.mmServiceClient.MakeRequest(context.Background(), mc.FuncChangeForumReadOnly, params, result)
 	if err != nil {
 		srv.processInternalServerError(hrw, err)
 		return
@@ -1472,9 +1596,9 @@ func (srv *Server) GetThreadNamesByIds(ar *api2.Request, _ *http.Request, hrw ht
 	return
 }
 
-func (srv *Server) MoveThreadUp(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
+func (srv *Server) GetForum(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
 	var err error
-	var params mm.MoveThreadUpParams
+	var params mm.GetForumParams
 	err = json.Unmarshal(*ar.Parameters, &params)
 	if err != nil {
 		srv.respondBadRequest(hrw)
@@ -1483,9 +1607,9 @@ func (srv *Server) MoveThreadUp(ar *api2.Request, _ *http.Request, hrw http.Resp
 
 	params.CommonParams = cmr.CommonParams{Auth: ar.Authorisation}
 
-	var result = new(mm.MoveThreadUpResult)
+	var result = new(mm.GetForumResult)
 	var re *jrm1.RpcError
-	re, err = srv.mmServiceClient.MakeRequest(context.Background(), mc.FuncMoveThreadUp, params, result)
+	re, err = srv.mmServiceClient.MakeRequest(context.Background(), mc.FuncGetForum, params, result)
 	if err != nil {
 		srv.processInternalServerError(hrw, err)
 		return
@@ -1501,9 +1625,9 @@ func (srv *Server) MoveThreadUp(ar *api2.Request, _ *http.Request, hrw http.Resp
 	return
 }
 
-func (srv *Server) MoveThreadDown(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
+func (srv *Server) MoveForumUp(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
 	var err error
-	var params mm.MoveThreadDownParams
+	var params mm.MoveForumUpParams
 	err = json.Unmarshal(*ar.Parameters, &params)
 	if err != nil {
 		srv.respondBadRequest(hrw)
@@ -1512,9 +1636,9 @@ func (srv *Server) MoveThreadDown(ar *api2.Request, _ *http.Request, hrw http.Re
 
 	params.CommonParams = cmr.CommonParams{Auth: ar.Authorisation}
 
-	var result = new(mm.MoveThreadDownResult)
+	var result = new(mm.MoveForumUpResult)
 	var re *jrm1.RpcError
-	re, err = srv.mmServiceClient.MakeRequest(context.Background(), mc.FuncMoveThreadDown, params, result)
+	re, err = srv.mmServiceClient.MakeRequest(context.Background(), mc.FuncMoveForumUp, params, result)
 	if err != nil {
 		srv.processInternalServerError(hrw, err)
 		return
@@ -1530,9 +1654,9 @@ func (srv *Server) MoveThreadDown(ar *api2.Request, _ *http.Request, hrw http.Re
 	return
 }
 
-func (srv *Server) DeleteThread(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
+func (srv *Server) MoveForumDown(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
 	var err error
-	var params mm.DeleteThreadParams
+	var params mm.MoveForumDownParams
 	err = json.Unmarshal(*ar.Parameters, &params)
 	if err != nil {
 		srv.respondBadRequest(hrw)
@@ -1541,9 +1665,9 @@ func (srv *Server) DeleteThread(ar *api2.Request, _ *http.Request, hrw http.Resp
 
 	params.CommonParams = cmr.CommonParams{Auth: ar.Authorisation}
 
-	var result = new(mm.DeleteThreadResult)
+	var result = new(mm.MoveForumDownResult)
 	var re *jrm1.RpcError
-	re, err = srv.mmServiceClient.MakeRequest(context.Background(), mc.FuncDeleteThread, params, result)
+	re, err = srv.mmServiceClient.MakeRequest(context.Background(), mc.FuncMoveForumDown, params, result)
 	if err != nil {
 		srv.processInternalServerError(hrw, err)
 		return
@@ -1559,9 +1683,9 @@ func (srv *Server) DeleteThread(ar *api2.Request, _ *http.Request, hrw http.Resp
 	return
 }
 
-func (srv *Server) AddMessage(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
+func (srv *Server) DeleteForum(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
 	var err error
-	var params mm.AddMessageParams
+	var params mm.DeleteForumParams
 	err = json.Unmarshal(*ar.Parameters, &params)
 	if err != nil {
 		srv.respondBadRequest(hrw)
@@ -1570,9 +1694,9 @@ func (srv *Server) AddMessage(ar *api2.Request, _ *http.Request, hrw http.Respon
 
 	params.CommonParams = cmr.CommonParams{Auth: ar.Authorisation}
 
-	var result = new(mm.AddMessageResult)
+	var result = new(mm.DeleteForumResult)
 	var re *jrm1.RpcError
-	re, err = srv.mmServiceClient.MakeRequest(context.Background(), mc.FuncAddMessage, params, result)
+	re, err = srv.mmServiceClient.MakeRequest(context.Background(), mc.FuncDeleteForum, params, result)
 	if err != nil {
 		srv.processInternalServerError(hrw, err)
 		return
@@ -1588,9 +1712,9 @@ func (srv *Server) AddMessage(ar *api2.Request, _ *http.Request, hrw http.Respon
 	return
 }
 
-func (srv *Server) ChangeMessageText(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
+func (srv *Server) AddThread(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
 	var err error
-	var params mm.ChangeMessageTextParams
+	var params mm.AddThreadParams
 	err = json.Unmarshal(*ar.Parameters, &params)
 	if err != nil {
 		srv.respondBadRequest(hrw)
@@ -1599,9 +1723,9 @@ func (srv *Server) ChangeMessageText(ar *api2.Request, _ *http.Request, hrw http
 
 	params.CommonParams = cmr.CommonParams{Auth: ar.Authorisation}
 
-	var result = new(mm.ChangeMessageTextResult)
+	var result = new(mm.AddThreadResult)
 	var re *jrm1.RpcError
-	re, err = srv.mmServiceClient.MakeRequest(context.Background(), mc.FuncChangeMessageText, params, result)
+	re, err = srv.mmServiceClient.MakeRequest(context.Background(), mc.FuncAddThread, params, result)
 	if err != nil {
 		srv.processInternalServerError(hrw, err)
 		return
@@ -1617,9 +1741,9 @@ func (srv *Server) ChangeMessageText(ar *api2.Request, _ *http.Request, hrw http
 	return
 }
 
-func (srv *Server) ChangeMessageThread(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
+func (srv *Server) ChangeThreadName(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
 	var err error
-	var params mm.ChangeMessageThreadParams
+	var params mm.ChangeThreadNameParams
 	err = json.Unmarshal(*ar.Parameters, &params)
 	if err != nil {
 		srv.respondBadRequest(hrw)
@@ -1628,9 +1752,9 @@ func (srv *Server) ChangeMessageThread(ar *api2.Request, _ *http.Request, hrw ht
 
 	params.CommonParams = cmr.CommonParams{Auth: ar.Authorisation}
 
-	var result = new(mm.ChangeMessageThreadResult)
+	var result = new(mm.ChangeThreadNameResult)
 	var re *jrm1.RpcError
-	re, err = srv.mmServiceClient.MakeRequest(context.Background(), mc.FuncChangeMessageThread, params, result)
+	re, err = srv.mmServiceClient.MakeRequest(context.Background(), mc.FuncChangeThreadName, params, result)
 	if err != nil {
 		srv.processInternalServerError(hrw, err)
 		return
@@ -1646,9 +1770,9 @@ func (srv *Server) ChangeMessageThread(ar *api2.Request, _ *http.Request, hrw ht
 	return
 }
 
-func (srv *Server) GetMessage(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
+func (srv *Server) ChangeThreadForum(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
 	var err error
-	var params mm.GetMessageParams
+	var params mm.ChangeThreadForumParams
 	err = json.Unmarshal(*ar.Parameters, &params)
 	if err != nil {
 		srv.respondBadRequest(hrw)
@@ -1657,9 +1781,9 @@ func (srv *Server) GetMessage(ar *api2.Request, _ *http.Request, hrw http.Respon
 
 	params.CommonParams = cmr.CommonParams{Auth: ar.Authorisation}
 
-	var result = new(mm.GetMessageResult)
+	var result = new(mm.ChangeThreadForumResult)
 	var re *jrm1.RpcError
-	re, err = srv.mmServiceClient.MakeRequest(context.Background(), mc.FuncGetMessage, params, result)
+	re, err = srv.mmServiceClient.MakeRequest(context.Background(), mc.FuncChangeThreadForum, params, result)
 	if err != nil {
 		srv.processInternalServerError(hrw, err)
 		return
@@ -1675,9 +1799,9 @@ func (srv *Server) GetMessage(ar *api2.Request, _ *http.Request, hrw http.Respon
 	return
 }
 
-func (srv *Server) GetLatestMessageOfThread(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
+func (srv *Server) GetThread(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
 	var err error
-	var params mm.GetLatestMessageOfThreadParams
+	var params mm.GetThreadParams
 	err = json.Unmarshal(*ar.Parameters, &params)
 	if err != nil {
 		srv.respondBadRequest(hrw)
@@ -1686,9 +1810,9 @@ func (srv *Server) GetLatestMessageOfThread(ar *api2.Request, _ *http.Request, h
 
 	params.CommonParams = cmr.CommonParams{Auth: ar.Authorisation}
 
-	var result = new(mm.GetLatestMessageOfThreadResult)
+	var result = new(mm.GetThreadResult)
 	var re *jrm1.RpcError
-	re, err = srv.mmServiceClient.MakeRequest(context.Background(), mc.FuncGetLatestMessageOfThread, params, result)
+	re, err = srv.mmServiceClient.MakeRequest(context.Background(), mc.FuncGetThread, params, result)
 	if err != nil {
 		srv.processInternalServerError(hrw, err)
 		return
@@ -1704,9 +1828,9 @@ func (srv *Server) GetLatestMessageOfThread(ar *api2.Request, _ *http.Request, h
 	return
 }
 
-func (srv *Server) DeleteMessage(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
+func (srv *Server) GetThreadNamesByIds(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
 	var err error
-	var params mm.DeleteMessageParams
+	var params mm.GetThreadNamesByIdsParams
 	err = json.Unmarshal(*ar.Parameters, &params)
 	if err != nil {
 		srv.respondBadRequest(hrw)
@@ -1715,9 +1839,9 @@ func (srv *Server) DeleteMessage(ar *api2.Request, _ *http.Request, hrw http.Res
 
 	params.CommonParams = cmr.CommonParams{Auth: ar.Authorisation}
 
-	var result = new(mm.DeleteMessageResult)
+	var result = new(mm.GetThreadNamesByIdsResult)
 	var re *jrm1.RpcError
-	re, err = srv.mmServiceClient.MakeRequest(context.Background(), mc.FuncDeleteMessage, params, result)
+	re, err = srv.mmServiceClient.MakeRequest(context.Background(), mc.FuncGetThreadNamesByIds, params, result)
 	if err != nil {
 		srv.processInternalServerError(hrw, err)
 		return
@@ -1733,9 +1857,9 @@ func (srv *Server) DeleteMessage(ar *api2.Request, _ *http.Request, hrw http.Res
 	return
 }
 
-func (srv *Server) ListThreadAndMessages(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
+func (srv *Server) MoveThreadUp(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
 	var err error
-	var params mm.ListThreadAndMessagesParams
+	var params mm.MoveThreadUpParams
 	err = json.Unmarshal(*ar.Parameters, &params)
 	if err != nil {
 		srv.respondBadRequest(hrw)
@@ -1744,9 +1868,9 @@ func (srv *Server) ListThreadAndMessages(ar *api2.Request, _ *http.Request, hrw
 
 	params.CommonParams = cmr.CommonParams{Auth: ar.Authorisation}
 
-	var result = new(mm.ListThreadAndMessagesResult)
+	var result = new(mm.MoveThreadUpResult)
 	var re *jrm1.RpcError
-	re, err = srv.mmServiceClient.MakeRequest(context.Background(), mc.FuncListThreadAndMessages, params, result)
+	re, err = srv.mmServiceClient.MakeRequest(context.Background(), mc.FuncMoveThreadUp, params, result)
 	if err != nil {
 		srv.processInternalServerError(hrw, err)
 		return
@@ -1762,9 +1886,9 @@ func (srv *Server) ListThreadAndMessages(ar *api2.Request, _ *http.Request, hrw
 	return
 }
 
-func (srv *Server) ListThreadAndMessagesOnPage(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
+func (srv *Server) MoveThreadDown(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
 	var err error
-	var params mm.ListThreadAndMessagesOnPageParams
+	var params mm.MoveThreadDownParams
 	err = json.Unmarshal(*ar.Parameters, &params)
 	if err != nil {
 		srv.respondBadRequest(hrw)
@@ -1773,9 +1897,9 @@ func (srv *Server) ListThreadAndMessagesOnPage(ar *api2.Request, _ *http.Request
 
 	params.CommonParams = cmr.CommonParams{Auth: ar.Authorisation}
 
-	var result = new(mm.ListThreadAndMessagesOnPageResult)
+	var result = new(mm.MoveThreadDownResult)
 	var re *jrm1.RpcError
-	re, err = srv.mmServiceClient.MakeRequest(context.Background(), mc.FuncListThreadAndMessagesOnPage, params, result)
+	re, err = srv.mmServiceClient.MakeRequest(context.Background(), mc.FuncMoveThreadDown, params, result)
 	if err != nil {
 		srv.processInternalServerError(hrw, err)
 		return
@@ -1791,9 +1915,9 @@ func (srv *Server) ListThreadAndMessagesOnPage(ar *api2.Request, _ *http.Request
 	return
 }
 
-func (srv *Server) ListForumAndThreads(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
+func (srv *Server) DeleteThread(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
 	var err error
-	var params mm.ListForumAndThreadsParams
+	var params mm.DeleteThreadParams
 	err = json.Unmarshal(*ar.Parameters, &params)
 	if err != nil {
 		srv.respondBadRequest(hrw)
@@ -1802,9 +1926,9 @@ func (srv *Server) ListForumAndThreads(ar *api2.Request, _ *http.Request, hrw ht
 
 	params.CommonParams = cmr.CommonParams{Auth: ar.Authorisation}
 
-	var result = new(mm.ListForumAndThreadsResult)
+	var result = new(mm.DeleteThreadResult)
 	var re *jrm1.RpcError
-	re, err = srv.mmServiceClient.MakeRequest(context.Background(), mc.FuncListForumAndThreads, params, result)
+	re, err = srv.mmServiceClient.MakeRequest(context.Background(), mc.FuncDeleteThread, params, result)
 	if err != nil {
 		srv.processInternalServerError(hrw, err)
 		return
@@ -1820,9 +1944,9 @@ func (srv *Server) ListForumAndThreads(ar *api2.Request, _ *http.Request, hrw ht
 	return
 }
 
-func (srv *Server) ListForumAndThreadsOnPage(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
+func (srv *Server) LockThread(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
 	var err error
-	var params mm.ListForumAndThreadsOnPageParams
+	var params mm.LockThreadParams
 	err = json.Unmarshal(*ar.Parameters, &params)
 	if err != nil {
 		srv.respondBadRequest(hrw)
@@ -1831,9 +1955,9 @@ func (srv *Server) ListForumAndThreadsOnPage(ar *api2.Request, _ *http.Request,
 
 	params.CommonParams = cmr.CommonParams{Auth: ar.Authorisation}
 
-	var result = new(mm.ListForumAndThreadsOnPageResult)
+	var result = new(mm.LockThreadResult)
 	var re *jrm1.RpcError
-	re, err = srv.mmServiceClient.MakeRequest(context.Background(), mc.FuncListForumAndThreadsOnPage, params, result)
+	re, err = srv.mmServiceClient.MakeRequest(context.Background(), mc.FuncLockThread, params, result)
 	if err != nil {
 		srv.processInternalServerError(hrw, err)
 		return
@@ -1849,9 +1973,9 @@ func (srv *Server) ListForumAndThreadsOnPage(ar *api2.Request, _ *http.Request,
 	return
 }
 
-func (srv *Server) ListSectionsAndForums(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
+func (srv *Server) UnlockThread(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
 	var err error
-	var params mm.ListSectionsAndForumsParams
+	var params mm.UnlockThreadParams
 	err = json.Unmarshal(*ar.Parameters, &params)
 	if err != nil {
 		srv.respondBadRequest(hrw)
@@ -1860,9 +1984,9 @@ func (srv *Server) ListSectionsAndForums(ar *api2.Request, _ *http.Request, hrw
 
 	params.CommonParams = cmr.CommonParams{Auth: ar.Authorisation}
 
-	var result = new(mm.ListSectionsAndForumsResult)
+	var result = new(mm.UnlockThreadResult)
 	var re *jrm1.RpcError
-	re, err = srv.mmServiceClient.MakeRequest(context.Background(), mc.FuncListSectionsAndForums, params, result)
+	re, err = srv.mmServiceClient.MakeRequest(context.Background(), mc.FuncUnlockThread, params, result)
 	if err != nil {
 		srv.processInternalServerError(hrw, err)
 		return
@@ -1878,11 +2002,9 @@ func (srv *Server) ListSectionsAndForums(ar *api2.Request, _ *http.Request, hrw
 	return
 }
 
-// NM.
-
-func (srv *Server) AddNotification(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
+func (srv *Server) ChangeThreadPinned(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
 	var err error
-	var params nm.AddNotificationParams
+	var params mm.ChangeThreadPinnedParams
 	err = json.Unmarshal(*ar.Parameters, &params)
 	if err != nil {
 		srv.respondBadRequest(hrw)
@@ -1891,15 +2013,15 @@ func (srv *Server) AddNotification(ar *api2.Request, _ *http.Request, hrw http.R
 
 	params.CommonParams = cmr.CommonParams{Auth: ar.Authorisation}
 
-	var result = new(nm.AddNotificationResult)
+	var result = new(mm.ChangeThreadPinnedResult)
 	var re *jrm1.RpcError
-	re, err = srv.nmServiceClient.MakeRequest(context.Background(), nc.FuncAddNotification, params, result)
+	re, err = srv.mmServiceClient.MakeRequest(context.Background(), mc.FuncChangeThreadPinned, params, result)
 	if err != nil {
 		srv.processInternalServerError(hrw, err)
 		return
 	}
 	if re != nil {
-		srv.processRpcError(app.ModuleId_NM, re, hrw)
+		srv.processRpcError(app.ModuleId_MM, re, hrw)
 		return
 	}
 
@@ -1909,9 +2031,9 @@ func (srv *Server) AddNotification(ar *api2.Request, _ *http.Request, hrw http.R
 	return
 }
 
-func (srv *Server) GetNotification(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
+func (srv *Server) ChangeThreadAnnouncement(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
 	var err error
-	var params nm.GetNotificationParams
+	var params mm.ChangeThreadAnnouncementParams
 	err = json.Unmarshal(*ar.Parameters, &params)
 	if err != nil {
 		srv.respondBadRequest(hrw)
@@ -1920,15 +2042,15 @@ func (srv *Server) GetNotification(ar *api2.Request, _ *http.Request, hrw http.R
 
 	params.CommonParams = cmr.CommonParams{Auth: ar.Authorisation}
 
-	var result = new(nm.GetNotificationResult)
+	var result = new(mm.ChangeThreadAnnouncementResult)
 	var re *jrm1.RpcError
-	re, err = srv.nmServiceClient.MakeRequest(context.Background(), nc.FuncGetNotification, params, result)
+	re, err = srv.mmServiceClient.MakeRequest(context.Background(), mc.FuncChangeThreadAnnouncement, params, result)
 	if err != nil {
 		srv.processInternalServerError(hrw, err)
 		return
 	}
 	if re != nil {
-		srv.processRpcError(app.ModuleId_NM, re, hrw)
+		srv.processRpcError(app.ModuleId_MM, re, hrw)
 		return
 	}
 
@@ -1938,9 +2060,9 @@ func (srv *Server) GetNotification(ar *api2.Request, _ *http.Request, hrw http.R
 	return
 }
 
-func (srv *Server) GetNotifications(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
+func (srv *Server) AddMessage(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
 	var err error
-	var params nm.GetNotificationsParams
+	var params mm.AddMessageParams
 	err = json.Unmarshal(*ar.Parameters, &params)
 	if err != nil {
 		srv.respondBadRequest(hrw)
@@ -1949,15 +2071,15 @@ func (srv *Server) GetNotifications(ar *api2.Request, _ *http.Request, hrw http.
 
 	params.CommonParams = cmr.CommonParams{Auth: ar.Authorisation}
 
-	var result = new(nm.GetNotificationsResult)
+	var result = new(mm.AddMessageResult)
 	var re *jrm1.RpcError
-	re, err = srv.nmServiceClient.MakeRequest(context.Background(), nc.FuncGetNotifications, params, result)
+	re, err = srv.mmServiceClient.MakeRequest(context.Background(), mc.FuncAddMessage, params, result)
 	if err != nil {
 		srv.processInternalServerError(hrw, err)
 		return
 	}
 	if re != nil {
-		srv.processRpcError(app.ModuleId_NM, re, hrw)
+		srv.processRpcError(app.ModuleId_MM, re, hrw)
 		return
 	}
 
@@ -1967,9 +2089,9 @@ func (srv *Server) GetNotifications(ar *api2.Request, _ *http.Request, hrw http.
 	return
 }
 
-func (srv *Server) GetNotificationsOnPage(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
+func (srv *Server) ChangeMessageText(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
 	var err error
-	var params nm.GetNotificationsOnPageParams
+	var params mm.ChangeMessageTextParams
 	err = json.Unmarshal(*ar.Parameters, &params)
 	if err != nil {
 		srv.respondBadRequest(hrw)
@@ -1978,15 +2100,15 @@ func (srv *Server) GetNotificationsOnPage(ar *api2.Request, _ *http.Request, hrw
 
 	params.CommonParams = cmr.CommonParams{Auth: ar.Authorisation}
 
-	var result = new(nm.GetNotificationsOnPageResult)
+	var result = new(mm.ChangeMessageTextResult)
 	var re *jrm1.RpcError
-	re, err = srv.nmServiceClient.MakeRequest(context.Background(), nc.FuncGetNotificationsOnPage, params, result)
+	re, err = srv.mmServiceClient.MakeRequest(context.Background(), mc.FuncChangeMessageText, params, result)
 	if err != nil {
 		srv.processInternalServerError(hrw, err)
 		return
 	}
 	if re != nil {
-		srv.processRpcError(app.ModuleId_NM, re, hrw)
+		srv.processRpcError(app.ModuleId_MM, re, hrw)
 		return
 	}
 
@@ -1996,9 +2118,9 @@ func (srv *Server) GetNotificationsOnPage(ar *api2.Request, _ *http.Request, hrw
 	return
 }
 
-func (srv *Server) GetUnreadNotifications(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
+func (srv *Server) ChangeMessageThread(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
 	var err error
-	var params nm.GetUnreadNotificationsParams
+	var params mm.ChangeMessageThreadParams
 	err = json.Unmarshal(*ar.Parameters, &params)
 	if err != nil {
 		srv.respondBadRequest(hrw)
@@ -2007,15 +2129,15 @@ func (srv *Server) GetUnreadNotifications(ar *api2.Request, _ *http.Request, hrw
 
 	params.CommonParams = cmr.CommonParams{Auth: ar.Authorisation}
 
-	var result = new(nm.GetUnreadNotificationsResult)
+	var result = new(mm.ChangeMessageThreadResult)
 	var re *jrm1.RpcError
-	re, err = srv.nmServiceClient.MakeRequest(context.Background(), nc.FuncGetUnreadNotifications, params, result)
+	re, err = srv.mmServiceClient.MakeRequest(context.Background(), mc.FuncChangeMessageThread, params, result)
 	if err != nil {
 		srv.processInternalServerError(hrw, err)
 		return
 	}
 	if re != nil {
-		srv.processRpcError(app.ModuleId_NM, re, hrw)
+		srv.processRpcError(app.ModuleId_MM, re, hrw)
 		return
 	}
 
@@ -2025,9 +2147,9 @@ func (srv *Server) GetUnreadNotifications(ar *api2.Request, _ *http.Request, hrw
 	return
 }
 
-func (srv *Server) CountUnreadNotifications(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
+func (srv *Server) GetMessage(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
 	var err error
-	var params nm.CountUnreadNotificationsParams
+	var params mm.GetMessageParams
 	err = json.Unmarshal(*ar.Parameters, &params)
 	if err != nil {
 		srv.respondBadRequest(hrw)
@@ -2036,15 +2158,15 @@ func (srv *Server) CountUnreadNotifications(ar *api2.Request, _ *http.Request, h
 
 	params.CommonParams = cmr.CommonParams{Auth: ar.Authorisation}
 
-	var result = new(nm.CountUnreadNotificationsResult)
+	var result = new(mm.GetMessageResult)
 	var re *jrm1.RpcError
-	re, err = srv.nmServiceClient.MakeRequest(context.Background(), nc.FuncCountUnreadNotifications, params, result)
+	re, err = srv.mmServiceClient.MakeRequest(context.Background(), mc.FuncGetMessage, params, result)
 	if err != nil {
 		srv.processInternalServerError(hrw, err)
 		return
 	}
 	if re != nil {
-		srv.processRpcError(app.ModuleId_NM, re, hrw)
+		srv.processRpcError(app.ModuleId_MM, re, hrw)
 		return
 	}
 
@@ -2054,9 +2176,9 @@ func (srv *Server) CountUnreadNotifications(ar *api2.Request, _ *http.Request, h
 	return
 }
 
-func (srv *Server) MarkNotificationAsRead(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
+func (srv *Server) GetLatestMessageOfThread(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
 	var err error
-	var params nm.MarkNotificationAsReadParams
+	var params mm.GetLatestMessageOfThreadParams
 	err = json.Unmarshal(*ar.Parameters, &params)
 	if err != nil {
 		srv.respondBadRequest(hrw)
@@ -2065,15 +2187,15 @@ func (srv *Server) MarkNotificationAsRead(ar *api2.Request, _ *http.Request, hrw
 
 	params.CommonParams = cmr.CommonParams{Auth: ar.Authorisation}
 
-	var result = new(nm.MarkNotificationAsReadResult)
+	var result = new(mm.GetLatestMessageOfThreadResult)
 	var re *jrm1.RpcError
-	re, err = srv.nmServiceClient.MakeRequest(context.Background(), nc.FuncMarkNotificationAsRead, params, result)
+	re, err = srv.mmServiceClient.MakeRequest(context.Background(), mc.FuncGetLatestMessageOfThread, params, result)
 	if err != nil {
 		srv.processInternalServerError(hrw, err)
 		return
 	}
 	if re != nil {
-		srv.processRpcError(app.ModuleId_NM, re, hrw)
+		srv.processRpcError(app.ModuleId_MM, re, hrw)
 		return
 	}
 
@@ -2083,9 +2205,9 @@ func (srv *Server) MarkNotificationAsRead(ar *api2.Request, _ *http.Request, hrw
 	return
 }
 
-func (srv *Server) DeleteNotification(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
+func (srv *Server) DeleteMessage(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
 	var err error
-	var params nm.DeleteNotificationParams
+	var params mm.DeleteMessageParams
 	err = json.Unmarshal(*ar.Parameters, &params)
 	if err != nil {
 		srv.respondBadRequest(hrw)
@@ -2094,15 +2216,15 @@ func (srv *Server) DeleteNotification(ar *api2.Request, _ *http.Request, hrw htt
 
 	params.CommonParams = cmr.CommonParams{Auth: ar.Authorisation}
 
-	var result = new(nm.DeleteNotificationResult)
+	var result = new(mm.DeleteMessageResult)
 	var re *jrm1.RpcError
-	re, err = srv.nmServiceClient.MakeRequest(context.Background(), nc.FuncDeleteNotification, params, result)
+	re, err = srv.mmServiceClient.MakeRequest(context.Background(), mc.FuncDeleteMessage, params, result)
 	if err != nil {
 		srv.processInternalServerError(hrw, err)
 		return
 	}
 	if re != nil {
-		srv.processRpcError(app.ModuleId_NM, re, hrw)
+		srv.processRpcError(app.ModuleId_MM, re, hrw)
 		return
 	}
 
@@ -2112,9 +2234,9 @@ func (srv *Server) DeleteNotification(ar *api2.Request, _ *http.Request, hrw htt
 	return
 }
 
-func (srv *Server) AddResource(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
+func (srv *Server) ListMessageRevisions(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
 	var err error
-	var params nm.AddResourceParams
+	var params mm.ListMessageRevisionsParams
 	err = json.Unmarshal(*ar.Parameters, &params)
 	if err != nil {
 		srv.respondBadRequest(hrw)
@@ -2123,15 +2245,15 @@ func (srv *Server) AddResource(ar *api2.Request, _ *http.Request, hrw http.Respo
 
 	params.CommonParams = cmr.CommonParams{Auth: ar.Authorisation}
 
-	var result = new(nm.AddResourceResult)
+	var result = new(mm.ListMessageRevisionsResult)
 	var re *jrm1.RpcError
-	re, err = srv.nmServiceClient.MakeRequest(context.Background(), nc.FuncAddResource, params, result)
+	re, err = srv.mmServiceClient.MakeRequest(context.Background(), mc.FuncListMessageRevisions, params, result)
 	if err != nil {
 		srv.processInternalServerError(hrw, err)
 		return
 	}
 	if re != nil {
-		srv.processRpcError(app.ModuleId_NM, re, hrw)
+		srv.processRpcError(app.ModuleId_MM, re, hrw)
 		return
 	}
 
@@ -2141,9 +2263,9 @@ func (srv *Server) AddResource(ar *api2.Request, _ *http.Request, hrw http.Respo
 	return
 }
 
-func (srv *Server) GetResource(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
+func (srv *Server) GetMessageRevision(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
 	var err error
-	var params nm.GetResourceParams
+	var params mm.GetMessageRevisionParams
 	err = json.Unmarshal(*ar.Parameters, &params)
 	if err != nil {
 		srv.respondBadRequest(hrw)
@@ -2152,15 +2274,15 @@ func (srv *Server) GetResource(ar *api2.Request, _ *http.Request, hrw http.Respo
 
 	params.CommonParams = cmr.CommonParams{Auth: ar.Authorisation}
 
-	var result = new(nm.GetResourceResult)
+	var result = new(mm.GetMessageRevisionResult)
 	var re *jrm1.RpcError
-	re, err = srv.nmServiceClient.MakeRequest(context.Background(), nc.FuncGetResource, params, result)
+	re, err = srv.mmServiceClient.MakeRequest(context.Background(), mc.FuncGetMessageRevision, params, result)
 	if err != nil {
 		srv.processInternalServerError(hrw, err)
 		return
 	}
 	if re != nil {
-		srv.processRpcError(app.ModuleId_NM, re, hrw)
+		srv.processRpcError(app.ModuleId_MM, re, hrw)
 		return
 	}
 
@@ -2170,9 +2292,9 @@ func (srv *Server) GetResource(ar *api2.Request, _ *http.Request, hrw http.Respo
 	return
 }
 
-func (srv *Server) GetResourceValue(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
+func (srv *Server) RestoreMessageRevision(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
 	var err error
-	var params nm.GetResourceValueParams
+	var params mm.RestoreMessageRevisionParams
 	err = json.Unmarshal(*ar.Parameters, &params)
 	if err != nil {
 		srv.respondBadRequest(hrw)
@@ -2181,15 +2303,15 @@ func (srv *Server) GetResourceValue(ar *api2.Request, _ *http.Request, hrw http.
 
 	params.CommonParams = cmr.CommonParams{Auth: ar.Authorisation}
 
-	var result = new(nm.GetResourceValueResult)
+	var result = new(mm.RestoreMessageRevisionResult)
 	var re *jrm1.RpcError
-	re, err = srv.nmServiceClient.MakeRequest(context.Background(), nc.FuncGetResourceValue, params, result)
+	re, err = srv.mmServiceClient.MakeRequest(context.Background(), mc.FuncRestoreMessageRevision, params, result)
 	if err != nil {
 		srv.processInternalServerError(hrw, err)
 		return
 	}
 	if re != nil {
-		srv.processRpcError(app.ModuleId_NM, re, hrw)
+		srv.processRpcError(app.ModuleId_MM, re, hrw)
 		return
 	}
 
@@ -2199,9 +2321,9 @@ func (srv *Server) GetResourceValue(ar *api2.Request, _ *http.Request, hrw http.
 	return
 }
 
-func (srv *Server) GetListOfAllResourcesOnPage(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
+func (srv *Server) ListThreadAndMessages(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
 	var err error
-	var params nm.GetListOfAllResourcesOnPageParams
+	var params mm.ListThreadAndMessagesParams
 	err = json.Unmarshal(*ar.Parameters, &params)
 	if err != nil {
 		srv.respondBadRequest(hrw)
@@ -2210,15 +2332,15 @@ func (srv *Server) GetListOfAllResourcesOnPage(ar *api2.Request, _ *http.Request
 
 	params.CommonParams = cmr.CommonParams{Auth: ar.Authorisation}
 
-	var result = new(nm.GetListOfAllResourcesOnPageResult)
+	var result = new(mm.ListThreadAndMessagesResult)
 	var re *jrm1.RpcError
-	re, err = srv.nmServiceClient.MakeRequest(context.Background(), nc.FuncGetListOfAllResourcesOnPage, params, result)
+	re, err = srv.mmServiceClient.MakeRequest(context.Background(), mc.FuncListThreadAndMessages, params, result)
 	if err != nil {
 		srv.processInternalServerError(hrw, err)
 		return
 	}
 	if re != nil {
-		srv.processRpcError(app.ModuleId_NM, re, hrw)
+		srv.processRpcError(app.ModuleId_MM, re, hrw)
 		return
 	}
 
@@ -2228,9 +2350,9 @@ func (srv *Server) GetListOfAllResourcesOnPage(ar *api2.Request, _ *http.Request
 	return
 }
 
-func (srv *Server) DeleteResource(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
+func (srv *Server) ListThreadAndMessagesOnPage(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
 	var err error
-	var params nm.DeleteResourceParams
+	var params mm.ListThreadAndMessagesOnPageParams
 	err = json.Unmarshal(*ar.Parameters, &params)
 	if err != nil {
 		srv.respondBadRequest(hrw)
@@ -2239,15 +2361,15 @@ func (srv *Server) DeleteResource(ar *api2.Request, _ *http.Request, hrw http.Re
 
 	params.CommonParams = cmr.CommonParams{Auth: ar.Authorisation}
 
-	var result = new(nm.DeleteResourceResult)
+	var result = new(mm.ListThreadAndMessagesOnPageResult)
 	var re *jrm1.RpcError
-	re, err = srv.nmServiceClient.MakeRequest(context.Background(), nc.FuncDeleteResource, params, result)
+	re, err = srv.mmServiceClient.MakeRequest(context.Background(), mc.FuncListThreadAndMessagesOnPage, params, result)
 	if err != nil {
 		srv.processInternalServerError(hrw, err)
 		return
 	}
 	if re != nil {
-		srv.processRpcError(app.ModuleId_NM, re, hrw)
+		srv.processRpcError(app.ModuleId_MM, re, hrw)
 		return
 	}
 
@@ -2257,11 +2379,9 @@ func (srv *Server) DeleteResource(ar *api2.Request, _ *http.Request, hrw http.Re
 	return
 }
 
-// SM.
-
-func (srv *Server) AddSubscription(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
+func (srv *Server) ListForumAndThreads(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
 	var err error
-	var params sm.AddSubscriptionParams
+	var params mm.ListForumAndThreadsParams
 	err = json.Unmarshal(*ar.Parameters, &params)
 	if err != nil {
 		srv.respondBadRequest(hrw)
@@ -2270,15 +2390,15 @@ func (srv *Server) AddSubscription(ar *api2.Request, _ *http.Request, hrw http.R
 
 	params.CommonParams = cmr.CommonParams{Auth: ar.Authorisation}
 
-	var result = new(sm.AddSubscriptionResult)
+	var result = new(mm.ListForumAndThreadsResult)
 	var re *jrm1.RpcError
-	re, err = srv.smServiceClient.MakeRequest(context.Background(), sc.FuncAddSubscription, params, result)
+	re, err = srv.mmServiceClient.MakeRequest(context.Background(), mc.FuncListForumAndThreads, params, result)
 	if err != nil {
 		srv.processInternalServerError(hrw, err)
 		return
 	}
 	if re != nil {
-		srv.processRpcError(app.ModuleId_SM, re, hrw)
+		srv.processRpcError(app.ModuleId_MM, re, hrw)
 		return
 	}
 
@@ -2288,9 +2408,9 @@ func (srv *Server) AddSubscription(ar *api2.Request, _ *http.Request, hrw http.R
 	return
 }
 
-func (srv *Server) IsSelfSubscribed(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
+func (srv *Server) ListForumAndThreadsOnPage(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
 	var err error
-	var params sm.IsSelfSubscribedParams
+	var params mm.ListForumAndThreadsOnPageParams
 	err = json.Unmarshal(*ar.Parameters, &params)
 	if err != nil {
 		srv.respondBadRequest(hrw)
@@ -2299,15 +2419,15 @@ func (srv *Server) IsSelfSubscribed(ar *api2.Request, _ *http.Request, hrw http.
 
 	params.CommonParams = cmr.CommonParams{Auth: ar.Authorisation}
 
-	var result = new(sm.IsSelfSubscribedResult)
+	var result = new(mm.ListForumAndThreadsOnPageResult)
 	var re *jrm1.RpcError
-	re, err = srv.smServiceClient.MakeRequest(context.Background(), sc.FuncIsSelfSubscribed, params, result)
+	re, err = srv.mmServiceClient.MakeRequest(context.Background(), mc.FuncListForumAndThreadsOnPage, params, result)
 	if err != nil {
 		srv.processInternalServerError(hrw, err)
 		return
 	}
 	if re != nil {
-		srv.processRpcError(app.ModuleId_SM, re, hrw)
+		srv.processRpcError(app.ModuleId_MM, re, hrw)
 		return
 	}
 
@@ -2317,9 +2437,9 @@ func (srv *Server) IsSelfSubscribed(ar *api2.Request, _ *http.Request, hrw http.
 	return
 }
 
-func (srv *Server) IsUserSubscribed(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
+func (srv *Server) ListSectionsAndForums(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
 	var err error
-	var params sm.IsUserSubscribedParams
+	var params mm.ListSectionsAndForumsParams
 	err = json.Unmarshal(*ar.Parameters, &params)
 	if err != nil {
 		srv.respondBadRequest(hrw)
@@ -2328,15 +2448,15 @@ func (srv *Server) IsUserSubscribed(ar *api2.Request, _ *http.Request, hrw http.
 
 	params.CommonParams = cmr.CommonParams{Auth: ar.Authorisation}
 
-	var result = new(sm.IsUserSubscribedResult)
+	var result = new(mm.ListSectionsAndForumsResult)
 	var re *jrm1.RpcError
-	re, err = srv.smServiceClient.MakeRequest(context.Background(), sc.FuncIsUserSubscribed, params, result)
+	re, err = srv.mmServiceClient.MakeRequest(context.Background(), mc.FuncListSectionsAndForums, params, result)
 	if err != nil {
 		srv.processInternalServerError(hrw, err)
 		return
 	}
 	if re != nil {
-		srv.processRpcError(app.ModuleId_SM, re, hrw)
+		srv.processRpcError(app.ModuleId_MM, re, hrw)
 		return
 	}
 
@@ -2346,9 +2466,9 @@ func (srv *Server) IsUserSubscribed(ar *api2.Request, _ *http.Request, hrw http.
 	return
 }
 
-func (srv *Server) CountSelfSubscriptions(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
+func (srv *Server) SearchMessages(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
 	var err error
-	var params sm.CountSelfSubscriptionsParams
+	var params mm.SearchMessagesParams
 	err = json.Unmarshal(*ar.Parameters, &params)
 	if err != nil {
 		srv.respondBadRequest(hrw)
@@ -2357,15 +2477,15 @@ func (srv *Server) CountSelfSubscriptions(ar *api2.Request, _ *http.Request, hrw
 
 	params.CommonParams = cmr.CommonParams{Auth: ar.Authorisation}
 
-	var result = new(sm.CountSelfSubscriptionsResult)
+	var result = new(mm.SearchMessagesResult)
 	var re *jrm1.RpcError
-	re, err = srv.smServiceClient.MakeRequest(context.Background(), sc.FuncCountSelfSubscriptions, params, result)
+	re, err = srv.mmServiceClient.MakeRequest(context.Background(), mc.FuncSearchMessages, params, result)
 	if err != nil {
 		srv.processInternalServerError(hrw, err)
 		return
 	}
 	if re != nil {
-		srv.processRpcError(app.ModuleId_SM, re, hrw)
+		srv.processRpcError(app.ModuleId_MM, re, hrw)
 		return
 	}
 
@@ -2375,9 +2495,9 @@ func (srv *Server) CountSelfSubscriptions(ar *api2.Request, _ *http.Request, hrw
 	return
 }
 
-func (srv *Server) GetSelfSubscriptions(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
+func (srv *Server) SearchThreads(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
 	var err error
-	var params sm.GetSelfSubscriptionsParams
+	var params mm.SearchThreadsParams
 	err = json.Unmarshal(*ar.Parameters, &params)
 	if err != nil {
 		srv.respondBadRequest(hrw)
@@ -2386,15 +2506,15 @@ func (srv *Server) GetSelfSubscriptions(ar *api2.Request, _ *http.Request, hrw h
 
 	params.CommonParams = cmr.CommonParams{Auth: ar.Authorisation}
 
-	var result = new(sm.GetSelfSubscriptionsResult)
+	var result = new(mm.SearchThreadsResult)
 	var re *jrm1.RpcError
-	re, err = srv.smServiceClient.MakeRequest(context.Background(), sc.FuncGetSelfSubscriptions, params, result)
+	re, err = srv.mmServiceClient.MakeRequest(context.Background(), mc.FuncSearchThreads, params, result)
 	if err != nil {
 		srv.processInternalServerError(hrw, err)
 		return
 	}
 	if re != nil {
-		srv.processRpcError(app.ModuleId_SM, re, hrw)
+		srv.processRpcError(app.ModuleId_MM, re, hrw)
 		return
 	}
 
@@ -2404,9 +2524,9 @@ func (srv *Server) GetSelfSubscriptions(ar *api2.Request, _ *http.Request, hrw h
 	return
 }
 
-func (srv *Server) GetSelfSubscriptionsOnPage(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
+func (srv *Server) StartConversation(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
 	var err error
-	var params sm.GetSelfSubscriptionsOnPageParams
+	var params mm.StartConversationParams
 	err = json.Unmarshal(*ar.Parameters, &params)
 	if err != nil {
 		srv.respondBadRequest(hrw)
@@ -2415,15 +2535,15 @@ func (srv *Server) GetSelfSubscriptionsOnPage(ar *api2.Request, _ *http.Request,
 
 	params.CommonParams = cmr.CommonParams{Auth: ar.Authorisation}
 
-	var result = new(sm.GetSelfSubscriptionsOnPageResult)
+	var result = new(mm.StartConversationResult)
 	var re *jrm1.RpcError
-	re, err = srv.smServiceClient.MakeRequest(context.Background(), sc.FuncGetSelfSubscriptionsOnPage, params, result)
+	re, err = srv.mmServiceClient.MakeRequest(context.Background(), mc.FuncStartConversation, params, result)
 	if err != nil {
 		srv.processInternalServerError(hrw, err)
 		return
 	}
 	if re != nil {
-		srv.processRpcError(app.ModuleId_SM, re, hrw)
+		srv.processRpcError(app.ModuleId_MM, re, hrw)
 		return
 	}
 
@@ -2433,9 +2553,9 @@ func (srv *Server) GetSelfSubscriptionsOnPage(ar *api2.Request, _ *http.Request,
 	return
 }
 
-func (srv *Server) GetUserSubscriptions(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
+func (srv *Server) SendPrivateMessage(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
 	var err error
-	var params sm.GetUserSubscriptionsParams
+	var params mm.SendPrivateMessageParams
 	err = json.Unmarshal(*ar.Parameters, &params)
 	if err != nil {
 		srv.respondBadRequest(hrw)
@@ -2444,15 +2564,15 @@ func (srv *Server) GetUserSubscriptions(ar *api2.Request, _ *http.Request, hrw h
 
 	params.CommonParams = cmr.CommonParams{Auth: ar.Authorisation}
 
-	var result = new(sm.GetUserSubscriptionsResult)
+	var result = new(mm.SendPrivateMessageResult)
 	var re *jrm1.RpcError
-	re, err = srv.smServiceClient.MakeRequest(context.Background(), sc.FuncGetUserSubscriptions, params, result)
+	re, err = srv.mmServiceClient.MakeRequest(context.Background(), mc.FuncSendPrivateMessage, params, result)
 	if err != nil {
 		srv.processInternalServerError(hrw, err)
 		return
 	}
 	if re != nil {
-		srv.processRpcError(app.ModuleId_SM, re, hrw)
+		srv.processRpcError(app.ModuleId_MM, re, hrw)
 		return
 	}
 
@@ -2462,9 +2582,9 @@ func (srv *Server) GetUserSubscriptions(ar *api2.Request, _ *http.Request, hrw h
 	return
 }
 
-func (srv *Server) GetUserSubscriptionsOnPage(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
+func (srv *Server) ListConversations(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
 	var err error
-	var params sm.GetUserSubscriptionsOnPageParams
+	var params mm.ListConversationsParams
 	err = json.Unmarshal(*ar.Parameters, &params)
 	if err != nil {
 		srv.respondBadRequest(hrw)
@@ -2473,7 +2593,1403 @@ func (srv *Server) GetUserSubscriptionsOnPage(ar *api2.Request, _ *http.Request,
 
 	params.CommonParams = cmr.CommonParams{Auth: ar.Authorisation}
 
-	var result = new(sm.GetUserSubscriptionsOnPageResult)
+	var result = new(mm.ListConversationsResult)
+	var re *jrm1.RpcError
+	re, err = srv.mmServiceClient.MakeRequest(context.Background(), mc.FuncListConversations, params, result)
+	if err != nil {
+		srv.processInternalServerError(hrw, err)
+		return
+	}
+	if re != nil {
+		srv.processRpcError(app.ModuleId_MM, re, hrw)
+		return
+	}
+
+	result.CommonResult.Clear()
+	var response = &api2.Response{Action: ar.Action, Result: result}
+	srv.respondWithJsonObject(hrw, response)
+	return
+}
+
+func (srv *Server) ReadConversation(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
+	var err error
+	var params mm.ReadConversationParams
+	err = json.Unmarshal(*ar.Parameters, &params)
+	if err != nil {
+		srv.respondBadRequest(hrw)
+		return
+	}
+
+	params.CommonParams = cmr.CommonParams{Auth: ar.Authorisation}
+
+	var result = new(mm.ReadConversationResult)
+	var re *jrm1.RpcError
+	re, err = srv.mmServiceClient.MakeRequest(context.Background(), mc.FuncReadConversation, params, result)
+	if err != nil {
+		srv.processInternalServerError(hrw, err)
+		return
+	}
+	if re != nil {
+		srv.processRpcError(app.ModuleId_MM, re, hrw)
+		return
+	}
+
+	result.CommonResult.Clear()
+	var response = &api2.Response{Action: ar.Action, Result: result}
+	srv.respondWithJsonObject(hrw, response)
+	return
+}
+
+func (srv *Server) DeleteConversation(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
+	var err error
+	var params mm.DeleteConversationParams
+	err = json.Unmarshal(*ar.Parameters, &params)
+	if err != nil {
+		srv.respondBadRequest(hrw)
+		return
+	}
+
+	params.CommonParams = cmr.CommonParams{Auth: ar.Authorisation}
+
+	var result = new(mm.DeleteConversationResult)
+	var re *jrm1.RpcError
+	re, err = srv.mmServiceClient.MakeRequest(context.Background(), mc.FuncDeleteConversation, params, result)
+	if err != nil {
+		srv.processInternalServerError(hrw, err)
+		return
+	}
+	if re != nil {
+		srv.processRpcError(app.ModuleId_MM, re, hrw)
+		return
+	}
+
+	result.CommonResult.Clear()
+	var response = &api2.Response{Action: ar.Action, Result: result}
+	srv.respondWithJsonObject(hrw, response)
+	return
+}
+
+func (srv *Server) CountUnreadPrivateMessages(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
+	var err error
+	var params mm.CountUnreadPrivateMessagesParams
+	err = json.Unmarshal(*ar.Parameters, &params)
+	if err != nil {
+		srv.respondBadRequest(hrw)
+		return
+	}
+
+	params.CommonParams = cmr.CommonParams{Auth: ar.Authorisation}
+
+	var result = new(mm.CountUnreadPrivateMessagesResult)
+	var re *jrm1.RpcError
+	re, err = srv.mmServiceClient.MakeRequest(context.Background(), mc.FuncCountUnreadPrivateMessages, params, result)
+	if err != nil {
+		srv.processInternalServerError(hrw, err)
+		return
+	}
+	if re != nil {
+		srv.processRpcError(app.ModuleId_MM, re, hrw)
+		return
+	}
+
+	result.CommonResult.Clear()
+	var response = &api2.Response{Action: ar.Action, Result: result}
+	srv.respondWithJsonObject(hrw, response)
+	return
+}
+
+func (srv *Server) BlockUser(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
+	var err error
+	var params mm.BlockUserParams
+	err = json.Unmarshal(*ar.Parameters, &params)
+	if err != nil {
+		srv.respondBadRequest(hrw)
+		return
+	}
+
+	params.CommonParams = cmr.CommonParams{Auth: ar.Authorisation}
+
+	var result = new(mm.BlockUserResult)
+	var re *jrm1.RpcError
+	re, err = srv.mmServiceClient.MakeRequest(context.Background(), mc.FuncBlockUser, params, result)
+	if err != nil {
+		srv.processInternalServerError(hrw, err)
+		return
+	}
+	if re != nil {
+		srv.processRpcError(app.ModuleId_MM, re, hrw)
+		return
+	}
+
+	result.CommonResult.Clear()
+	var response = &api2.Response{Action: ar.Action, Result: result}
+	srv.respondWithJsonObject(hrw, response)
+	return
+}
+
+func (srv *Server) UnblockUser(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
+	var err error
+	var params mm.UnblockUserParams
+	err = json.Unmarshal(*ar.Parameters, &params)
+	if err != nil {
+		srv.respondBadRequest(hrw)
+		return
+	}
+
+	params.CommonParams = cmr.CommonParams{Auth: ar.Authorisation}
+
+	var result = new(mm.UnblockUserResult)
+	var re *jrm1.RpcError
+	re, err = srv.mmServiceClient.MakeRequest(context.Background(), mc.FuncUnblockUser, params, result)
+	if err != nil {
+		srv.processInternalServerError(hrw, err)
+		return
+	}
+	if re != nil {
+		srv.processRpcError(app.ModuleId_MM, re, hrw)
+		return
+	}
+
+	result.CommonResult.Clear()
+	var response = &api2.Response{Action: ar.Action, Result: result}
+	srv.respondWithJsonObject(hrw, response)
+	return
+}
+
+func (srv *Server) ListBlockedUsers(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
+	var err error
+	var params mm.ListBlockedUsersParams
+	err = json.Unmarshal(*ar.Parameters, &params)
+	if err != nil {
+		srv.respondBadRequest(hrw)
+		return
+	}
+
+	params.CommonParams = cmr.CommonParams{Auth: ar.Authorisation}
+
+	var result = new(mm.ListBlockedUsersResult)
+	var re *jrm1.RpcError
+	re, err = srv.mmServiceClient.MakeRequest(context.Background(), mc.FuncListBlockedUsers, params, result)
+	if err != nil {
+		srv.processInternalServerError(hrw, err)
+		return
+	}
+	if re != nil {
+		srv.processRpcError(app.ModuleId_MM, re, hrw)
+		return
+	}
+
+	result.CommonResult.Clear()
+	var response = &api2.Response{Action: ar.Action, Result: result}
+	srv.respondWithJsonObject(hrw, response)
+	return
+}
+
+func (srv *Server) GetPoll(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
+	var err error
+	var params mm.GetPollParams
+	err = json.Unmarshal(*ar.Parameters, &params)
+	if err != nil {
+		srv.respondBadRequest(hrw)
+		return
+	}
+
+	params.CommonParams = cmr.CommonParams{Auth: ar.Authorisation}
+
+	var result = new(mm.GetPollResult)
+	var re *jrm1.RpcError
+	re, err = srv.mmServiceClient.MakeRequest(context.Background(), mc.FuncGetPoll, params, result)
+	if err != nil {
+		srv.processInternalServerError(hrw, err)
+		return
+	}
+	if re != nil {
+		srv.processRpcError(app.ModuleId_MM, re, hrw)
+		return
+	}
+
+	result.CommonResult.Clear()
+	var response = &api2.Response{Action: ar.Action, Result: result}
+	srv.respondWithJsonObject(hrw, response)
+	return
+}
+
+func (srv *Server) VoteInPoll(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
+	var err error
+	var params mm.VoteInPollParams
+	err = json.Unmarshal(*ar.Parameters, &params)
+	if err != nil {
+		srv.respondBadRequest(hrw)
+		return
+	}
+
+	params.CommonParams = cmr.CommonParams{Auth: ar.Authorisation}
+
+	var result = new(mm.VoteInPollResult)
+	var re *jrm1.RpcError
+	re, err = srv.mmServiceClient.MakeRequest(context.Background(), mc.FuncVoteInPoll, params, result)
+	if err != nil {
+		srv.processInternalServerError(hrw, err)
+		return
+	}
+	if re != nil {
+		srv.processRpcError(app.ModuleId_MM, re, hrw)
+		return
+	}
+
+	result.CommonResult.Clear()
+	var response = &api2.Response{Action: ar.Action, Result: result}
+	srv.respondWithJsonObject(hrw, response)
+	return
+}
+
+func (srv *Server) ChangePollVote(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
+	var err error
+	var params mm.ChangePollVoteParams
+	err = json.Unmarshal(*ar.Parameters, &params)
+	if err != nil {
+		srv.respondBadRequest(hrw)
+		return
+	}
+
+	params.CommonParams = cmr.CommonParams{Auth: ar.Authorisation}
+
+	var result = new(mm.ChangePollVoteResult)
+	var re *jrm1.RpcError
+	re, err = srv.mmServiceClient.MakeRequest(context.Background(), mc.FuncChangePollVote, params, result)
+	if err != nil {
+		srv.processInternalServerError(hrw, err)
+		return
+	}
+	if re != nil {
+		srv.processRpcError(app.ModuleId_MM, re, hrw)
+		return
+	}
+
+	result.CommonResult.Clear()
+	var response = &api2.Response{Action: ar.Action, Result: result}
+	srv.respondWithJsonObject(hrw, response)
+	return
+}
+
+func (srv *Server) ListReactions(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
+	var err error
+	var params mm.ListReactionsParams
+	err = json.Unmarshal(*ar.Parameters, &params)
+	if err != nil {
+		srv.respondBadRequest(hrw)
+		return
+	}
+
+	params.CommonParams = cmr.CommonParams{Auth: ar.Authorisation}
+
+	var result = new(mm.ListReactionsResult)
+	var re *jrm1.RpcError
+	re, err = srv.mmServiceClient.MakeRequest(context.Background(), mc.FuncListReactions, params, result)
+	if err != nil {
+		srv.processInternalServerError(hrw, err)
+		return
+	}
+	if re != nil {
+		srv.processRpcError(app.ModuleId_MM, re, hrw)
+		return
+	}
+
+	result.CommonResult.Clear()
+	var response = &api2.Response{Action: ar.Action, Result: result}
+	srv.respondWithJsonObject(hrw, response)
+	return
+}
+
+func (srv *Server) AddMessageReaction(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
+	var err error
+	var params mm.AddMessageReactionParams
+	err = json.Unmarshal(*ar.Parameters, &params)
+	if err != nil {
+		srv.respondBadRequest(hrw)
+		return
+	}
+
+	params.CommonParams = cmr.CommonParams{Auth: ar.Authorisation}
+
+	var result = new(mm.AddMessageReactionResult)
+	var re *jrm1.RpcError
+	re, err = srv.mmServiceClient.MakeRequest(context.Background(), mc.FuncAddMessageReaction, params, result)
+	if err != nil {
+		srv.processInternalServerError(hrw, err)
+		return
+	}
+	if re != nil {
+		srv.processRpcError(app.ModuleId_MM, re, hrw)
+		return
+	}
+
+	result.CommonResult.Clear()
+	var response = &api2.Response{Action: ar.Action, Result: result}
+	srv.respondWithJsonObject(hrw, response)
+	return
+}
+
+func (srv *Server) RemoveMessageReaction(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
+	var err error
+	var params mm.RemoveMessageReactionParams
+	err = json.Unmarshal(*ar.Parameters, &params)
+	if err != nil {
+		srv.respondBadRequest(hrw)
+		return
+	}
+
+	params.CommonParams = cmr.CommonParams{Auth: ar.Authorisation}
+
+	var result = new(mm.RemoveMessageReactionResult)
+	var re *jrm1.RpcError
+	re, err = srv.mmServiceClient.MakeRequest(context.Background(), mc.FuncRemoveMessageReaction, params, result)
+	if err != nil {
+		srv.processInternalServerError(hrw, err)
+		return
+	}
+	if re != nil {
+		srv.processRpcError(app.ModuleId_MM, re, hrw)
+		return
+	}
+
+	result.CommonResult.Clear()
+	var response = &api2.Response{Action: ar.Action, Result: result}
+	srv.respondWithJsonObject(hrw, response)
+	return
+}
+
+func (srv *Server) GetUserReputation(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
+	var err error
+	var params mm.GetUserReputationParams
+	err = json.Unmarshal(*ar.Parameters, &params)
+	if err != nil {
+		srv.respondBadRequest(hrw)
+		return
+	}
+
+	params.CommonParams = cmr.CommonParams{Auth: ar.Authorisation}
+
+	var result = new(mm.GetUserReputationResult)
+	var re *jrm1.RpcError
+	re, err = srv.mmServiceClient.MakeRequest(context.Background(), mc.FuncGetUserReputation, params, result)
+	if err != nil {
+		srv.processInternalServerError(hrw, err)
+		return
+	}
+	if re != nil {
+		srv.processRpcError(app.ModuleId_MM, re, hrw)
+		return
+	}
+
+	result.CommonResult.Clear()
+	var response = &api2.Response{Action: ar.Action, Result: result}
+	srv.respondWithJsonObject(hrw, response)
+	return
+}
+
+func (srv *Server) ListTrash(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
+	var err error
+	var params mm.ListTrashParams
+	err = json.Unmarshal(*ar.Parameters, &params)
+	if err != nil {
+		srv.respondBadRequest(hrw)
+		return
+	}
+
+	params.CommonParams = cmr.CommonParams{Auth: ar.Authorisation}
+
+	var result = new(mm.ListTrashResult)
+	var re *jrm1.RpcError
+	re, err = srv.mmServiceClient.MakeRequest(context.Background(), mc.FuncListTrash, params, result)
+	if err != nil {
+		srv.processInternalServerError(hrw, err)
+		return
+	}
+	if re != nil {
+		srv.processRpcError(app.ModuleId_MM, re, hrw)
+		return
+	}
+
+	result.CommonResult.Clear()
+	var response = &api2.Response{Action: ar.Action, Result: result}
+	srv.respondWithJsonObject(hrw, response)
+	return
+}
+
+func (srv *Server) RestoreFromTrash(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
+	var err error
+	var params mm.RestoreFromTrashParams
+	err = json.Unmarshal(*ar.Parameters, &params)
+	if err != nil {
+		srv.respondBadRequest(hrw)
+		return
+	}
+
+	params.CommonParams = cmr.CommonParams{Auth: ar.Authorisation}
+
+	var result = new(mm.RestoreFromTrashResult)
+	var re *jrm1.RpcError
+	re, err = srv.mmServiceClient.MakeRequest(context.Background(), mc.FuncRestoreFromTrash, params, result)
+	if err != nil {
+		srv.processInternalServerError(hrw, err)
+		return
+	}
+	if re != nil {
+		srv.processRpcError(app.ModuleId_MM, re, hrw)
+		return
+	}
+
+	result.CommonResult.Clear()
+	var response = &api2.Response{Action: ar.Action, Result: result}
+	srv.respondWithJsonObject(hrw, response)
+	return
+}
+
+func (srv *Server) RepairDatabaseConsistency(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
+	var err error
+	var params mm.RepairDatabaseConsistencyParams
+	err = json.Unmarshal(*ar.Parameters, &params)
+	if err != nil {
+		srv.respondBadRequest(hrw)
+		return
+	}
+
+	params.CommonParams = cmr.CommonParams{Auth: ar.Authorisation}
+
+	var result = new(mm.RepairDatabaseConsistencyResult)
+	var re *jrm1.RpcError
+	re, err = srv.mmServiceClient.MakeRequest(context.Background(), mc.FuncRepairDatabaseConsistency, params, result)
+	if err != nil {
+		srv.processInternalServerError(hrw, err)
+		return
+	}
+	if re != nil {
+		srv.processRpcError(app.ModuleId_MM, re, hrw)
+		return
+	}
+
+	result.CommonResult.Clear()
+	var response = &api2.Response{Action: ar.Action, Result: result}
+	srv.respondWithJsonObject(hrw, response)
+	return
+}
+
+func (srv *Server) ReportMessage(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
+	var err error
+	var params mm.ReportMessageParams
+	err = json.Unmarshal(*ar.Parameters, &params)
+	if err != nil {
+		srv.respondBadRequest(hrw)
+		return
+	}
+
+	params.CommonParams = cmr.CommonParams{Auth: ar.Authorisation}
+
+	var result = new(mm.ReportMessageResult)
+	var re *jrm1.RpcError
+	re, err = srv.mmServiceClient.MakeRequest(context.Background(), mc.FuncReportMessage, params, result)
+	if err != nil {
+		srv.processInternalServerError(hrw, err)
+		return
+	}
+	if re != nil {
+		srv.processRpcError(app.ModuleId_MM, re, hrw)
+		return
+	}
+
+	result.CommonResult.Clear()
+	var response = &api2.Response{Action: ar.Action, Result: result}
+	srv.respondWithJsonObject(hrw, response)
+	return
+}
+
+func (srv *Server) ListModerationQueue(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
+	var err error
+	var params mm.ListModerationQueueParams
+	err = json.Unmarshal(*ar.Parameters, &params)
+	if err != nil {
+		srv.respondBadRequest(hrw)
+		return
+	}
+
+	params.CommonParams = cmr.CommonParams{Auth: ar.Authorisation}
+
+	var result = new(mm.ListModerationQueueResult)
+	var re *jrm1.RpcError
+	re, err = srv.mmServiceClient.MakeRequest(context.Background(), mc.FuncListModerationQueue, params, result)
+	if err != nil {
+		srv.processInternalServerError(hrw, err)
+		return
+	}
+	if re != nil {
+		srv.processRpcError(app.ModuleId_MM, re, hrw)
+		return
+	}
+
+	result.CommonResult.Clear()
+	var response = &api2.Response{Action: ar.Action, Result: result}
+	srv.respondWithJsonObject(hrw, response)
+	return
+}
+
+func (srv *Server) ResolveMessageReports(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
+	var err error
+	var params mm.ResolveMessageReportsParams
+	err = json.Unmarshal(*ar.Parameters, &params)
+	if err != nil {
+		srv.respondBadRequest(hrw)
+		return
+	}
+
+	params.CommonParams = cmr.CommonParams{Auth: ar.Authorisation}
+
+	var result = new(mm.ResolveMessageReportsResult)
+	var re *jrm1.RpcError
+	re, err = srv.mmServiceClient.MakeRequest(context.Background(), mc.FuncResolveMessageReports, params, result)
+	if err != nil {
+		srv.processInternalServerError(hrw, err)
+		return
+	}
+	if re != nil {
+		srv.processRpcError(app.ModuleId_MM, re, hrw)
+		return
+	}
+
+	result.CommonResult.Clear()
+	var response = &api2.Response{Action: ar.Action, Result: result}
+	srv.respondWithJsonObject(hrw, response)
+	return
+}
+
+func (srv *Server) ListModerationDecisions(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
+	var err error
+	var params mm.ListModerationDecisionsParams
+	err = json.Unmarshal(*ar.Parameters, &params)
+	if err != nil {
+		srv.respondBadRequest(hrw)
+		return
+	}
+
+	params.CommonParams = cmr.CommonParams{Auth: ar.Authorisation}
+
+	var result = new(mm.ListModerationDecisionsResult)
+	var re *jrm1.RpcError
+	re, err = srv.mmServiceClient.MakeRequest(context.Background(), mc.FuncListModerationDecisions, params, result)
+	if err != nil {
+		srv.processInternalServerError(hrw, err)
+		return
+	}
+	if re != nil {
+		srv.processRpcError(app.ModuleId_MM, re, hrw)
+		return
+	}
+
+	result.CommonResult.Clear()
+	var response = &api2.Response{Action: ar.Action, Result: result}
+	srv.respondWithJsonObject(hrw, response)
+	return
+}
+
+// NM.
+
+func (srv *Server) AddNotification(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
+	var err error
+	var params nm.AddNotificationParams
+	err = json.Unmarshal(*ar.Parameters, &params)
+	if err != nil {
+		srv.respondBadRequest(hrw)
+		return
+	}
+
+	params.CommonParams = cmr.CommonParams{Auth: ar.Authorisation}
+
+	var result = new(nm.AddNotificationResult)
+	var re *jrm1.RpcError
+	re, err = srv.nmServiceClient.MakeRequest(context.Background(), nc.FuncAddNotification, params, result)
+	if err != nil {
+		srv.processInternalServerError(hrw, err)
+		return
+	}
+	if re != nil {
+		srv.processRpcError(app.ModuleId_NM, re, hrw)
+		return
+	}
+
+	result.CommonResult.Clear()
+	var response = &api2.Response{Action: ar.Action, Result: result}
+	srv.respondWithJsonObject(hrw, response)
+	return
+}
+
+func (srv *Server) GetNotification(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
+	var err error
+	var params nm.GetNotificationParams
+	err = json.Unmarshal(*ar.Parameters, &params)
+	if err != nil {
+		srv.respondBadRequest(hrw)
+		return
+	}
+
+	params.CommonParams = cmr.CommonParams{Auth: ar.Authorisation}
+
+	var result = new(nm.GetNotificationResult)
+	var re *jrm1.RpcError
+	re, err = srv.nmServiceClient.MakeRequest(context.Background(), nc.FuncGetNotification, params, result)
+	if err != nil {
+		srv.processInternalServerError(hrw, err)
+		return
+	}
+	if re != nil {
+		srv.processRpcError(app.ModuleId_NM, re, hrw)
+		return
+	}
+
+	result.CommonResult.Clear()
+	var response = &api2.Response{Action: ar.Action, Result: result}
+	srv.respondWithJsonObject(hrw, response)
+	return
+}
+
+func (srv *Server) GetNotifications(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
+	var err error
+	var params nm.GetNotificationsParams
+	err = json.Unmarshal(*ar.Parameters, &params)
+	if err != nil {
+		srv.respondBadRequest(hrw)
+		return
+	}
+
+	params.CommonParams = cmr.CommonParams{Auth: ar.Authorisation}
+
+	var result = new(nm.GetNotificationsResult)
+	var re *jrm1.RpcError
+	re, err = srv.nmServiceClient.MakeRequest(context.Background(), nc.FuncGetNotifications, params, result)
+	if err != nil {
+		srv.processInternalServerError(hrw, err)
+		return
+	}
+	if re != nil {
+		srv.processRpcError(app.ModuleId_NM, re, hrw)
+		return
+	}
+
+	result.CommonResult.Clear()
+	var response = &api2.Response{Action: ar.Action, Result: result}
+	srv.respondWithJsonObject(hrw, response)
+	return
+}
+
+func (srv *Server) GetNotificationsOnPage(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
+	var err error
+	var params nm.GetNotificationsOnPageParams
+	err = json.Unmarshal(*ar.Parameters, &params)
+	if err != nil {
+		srv.respondBadRequest(hrw)
+		return
+	}
+
+	params.CommonParams = cmr.CommonParams{Auth: ar.Authorisation}
+
+	var result = new(nm.GetNotificationsOnPageResult)
+	var re *jrm1.RpcError
+	re, err = srv.nmServiceClient.MakeRequest(context.Background(), nc.FuncGetNotificationsOnPage, params, result)
+	if err != nil {
+		srv.processInternalServerError(hrw, err)
+		return
+	}
+	if re != nil {
+		srv.processRpcError(app.ModuleId_NM, re, hrw)
+		return
+	}
+
+	result.CommonResult.Clear()
+	var response = &api2.Response{Action: ar.Action, Result: result}
+	srv.respondWithJsonObject(hrw, response)
+	return
+}
+
+func (srv *Server) GetUnreadNotifications(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
+	var err error
+	var params nm.GetUnreadNotificationsParams
+	err = json.Unmarshal(*ar.Parameters, &params)
+	if err != nil {
+		srv.respondBadRequest(hrw)
+		return
+	}
+
+	params.CommonParams = cmr.CommonParams{Auth: ar.Authorisation}
+
+	var result = new(nm.GetUnreadNotificationsResult)
+	var re *jrm1.RpcError
+	re, err = srv.nmServiceClient.MakeRequest(context.Background(), nc.FuncGetUnreadNotifications, params, result)
+	if err != nil {
+		srv.processInternalServerError(hrw, err)
+		return
+	}
+	if re != nil {
+		srv.processRpcError(app.ModuleId_NM, re, hrw)
+		return
+	}
+
+	result.CommonResult.Clear()
+	var response = &api2.Response{Action: ar.Action, Result: result}
+	srv.respondWithJsonObject(hrw, response)
+	return
+}
+
+func (srv *Server) CountUnreadNotifications(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
+	var err error
+	var params nm.CountUnreadNotificationsParams
+	err = json.Unmarshal(*ar.Parameters, &params)
+	if err != nil {
+		srv.respondBadRequest(hrw)
+		return
+	}
+
+	params.CommonParams = cmr.CommonParams{Auth: ar.Authorisation}
+
+	var result = new(nm.CountUnreadNotificationsResult)
+	var re *jrm1.RpcError
+	re, err = srv.nmServiceClient.MakeRequest(context.Background(), nc.FuncCountUnreadNotifications, params, result)
+	if err != nil {
+		srv.processInternalServerError(hrw, err)
+		return
+	}
+	if re != nil {
+		srv.processRpcError(app.ModuleId_NM, re, hrw)
+		return
+	}
+
+	result.CommonResult.Clear()
+	var response = &api2.Response{Action: ar.Action, Result: result}
+	srv.respondWithJsonObject(hrw, response)
+	return
+}
+
+func (srv *Server) MarkNotificationAsRead(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
+	var err error
+	var params nm.MarkNotificationAsReadParams
+	err = json.Unmarshal(*ar.Parameters, &params)
+	if err != nil {
+		srv.respondBadRequest(hrw)
+		return
+	}
+
+	params.CommonParams = cmr.CommonParams{Auth: ar.Authorisation}
+
+	var result = new(nm.MarkNotificationAsReadResult)
+	var re *jrm1.RpcError
+	re, err = srv.nmServiceClient.MakeRequest(context.Background(), nc.FuncMarkNotificationAsRead, params, result)
+	if err != nil {
+		srv.processInternalServerError(hrw, err)
+		return
+	}
+	if re != nil {
+		srv.processRpcError(app.ModuleId_NM, re, hrw)
+		return
+	}
+
+	result.CommonResult.Clear()
+	var response = &api2.Response{Action: ar.Action, Result: result}
+	srv.respondWithJsonObject(hrw, response)
+	return
+}
+
+func (srv *Server) DeleteNotification(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
+	var err error
+	var params nm.DeleteNotificationParams
+	err = json.Unmarshal(*ar.Parameters, &params)
+	if err != nil {
+		srv.respondBadRequest(hrw)
+		return
+	}
+
+	params.CommonParams = cmr.CommonParams{Auth: ar.Authorisation}
+
+	var result = new(nm.DeleteNotificationResult)
+	var re *jrm1.RpcError
+	re, err = srv.nmServiceClient.MakeRequest(context.Background(), nc.FuncDeleteNotification, params, result)
+	if err != nil {
+		srv.processInternalServerError(hrw, err)
+		return
+	}
+	if re != nil {
+		srv.processRpcError(app.ModuleId_NM, re, hrw)
+		return
+	}
+
+	result.CommonResult.Clear()
+	var response = &api2.Response{Action: ar.Action, Result: result}
+	srv.respondWithJsonObject(hrw, response)
+	return
+}
+
+func (srv *Server) AddResource(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
+	var err error
+	var params nm.AddResourceParams
+	err = json.Unmarshal(*ar.Parameters, &params)
+	if err != nil {
+		srv.respondBadRequest(hrw)
+		return
+	}
+
+	params.CommonParams = cmr.CommonParams{Auth: ar.Authorisation}
+
+	var result = new(nm.AddResourceResult)
+	var re *jrm1.RpcError
+	re, err = srv.nmServiceClient.MakeRequest(context.Background(), nc.FuncAddResource, params, result)
+	if err != nil {
+		srv.processInternalServerError(hrw, err)
+		return
+	}
+	if re != nil {
+		srv.processRpcError(app.ModuleId_NM, re, hrw)
+		return
+	}
+
+	result.CommonResult.Clear()
+	var response = &api2.Response{Action: ar.Action, Result: result}
+	srv.respondWithJsonObject(hrw, response)
+	return
+}
+
+func (srv *Server) GetResource(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
+	var err error
+	var params nm.GetResourceParams
+	err = json.Unmarshal(*ar.Parameters, &params)
+	if err != nil {
+		srv.respondBadRequest(hrw)
+		return
+	}
+
+	params.CommonParams = cmr.CommonParams{Auth: ar.Authorisation}
+
+	var result = new(nm.GetResourceResult)
+	var re *jrm1.RpcError
+	re, err = srv.nmServiceClient.MakeRequest(context.Background(), nc.FuncGetResource, params, result)
+	if err != nil {
+		srv.processInternalServerError(hrw, err)
+		return
+	}
+	if re != nil {
+		srv.processRpcError(app.ModuleId_NM, re, hrw)
+		return
+	}
+
+	result.CommonResult.Clear()
+	var response = &api2.Response{Action: ar.Action, Result: result}
+	srv.respondWithJsonObject(hrw, response)
+	return
+}
+
+func (srv *Server) GetResourceValue(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
+	var err error
+	var params nm.GetResourceValueParams
+	err = json.Unmarshal(*ar.Parameters, &params)
+	if err != nil {
+		srv.respondBadRequest(hrw)
+		return
+	}
+
+	params.CommonParams = cmr.CommonParams{Auth: ar.Authorisation}
+
+	var result = new(nm.GetResourceValueResult)
+	var re *jrm1.RpcError
+	re, err = srv.nmServiceClient.MakeRequest(context.Background(), nc.FuncGetResourceValue, params, result)
+	if err != nil {
+		srv.processInternalServerError(hrw, err)
+		return
+	}
+	if re != nil {
+		srv.processRpcError(app.ModuleId_NM, re, hrw)
+		return
+	}
+
+	result.CommonResult.Clear()
+	var response = &api2.Response{Action: ar.Action, Result: result}
+	srv.respondWithJsonObject(hrw, response)
+	return
+}
+
+func (srv *Server) GetListOfAllResourcesOnPage(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
+	var err error
+	var params nm.GetListOfAllResourcesOnPageParams
+	err = json.Unmarshal(*ar.Parameters, &params)
+	if err != nil {
+		srv.respondBadRequest(hrw)
+		return
+	}
+
+	params.CommonParams = cmr.CommonParams{Auth: ar.Authorisation}
+
+	var result = new(nm.GetListOfAllResourcesOnPageResult)
+	var re *jrm1.RpcError
+	re, err = srv.nmServiceClient.MakeRequest(context.Background(), nc.FuncGetListOfAllResourcesOnPage, params, result)
+	if err != nil {
+		srv.processInternalServerError(hrw, err)
+		return
+	}
+	if re != nil {
+		srv.processRpcError(app.ModuleId_NM, re, hrw)
+		return
+	}
+
+	result.CommonResult.Clear()
+	var response = &api2.Response{Action: ar.Action, Result: result}
+	srv.respondWithJsonObject(hrw, response)
+	return
+}
+
+func (srv *Server) DeleteResource(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
+	var err error
+	var params nm.DeleteResourceParams
+	err = json.Unmarshal(*ar.Parameters, &params)
+	if err != nil {
+		srv.respondBadRequest(hrw)
+		return
+	}
+
+	params.CommonParams = cmr.CommonParams{Auth: ar.Authorisation}
+
+	var result = new(nm.DeleteResourceResult)
+	var re *jrm1.RpcError
+	re, err = srv.nmServiceClient.MakeRequest(context.Background(), nc.FuncDeleteResource, params, result)
+	if err != nil {
+		srv.processInternalServerError(hrw, err)
+		return
+	}
+	if re != nil {
+		srv.processRpcError(app.ModuleId_NM, re, hrw)
+		return
+	}
+
+	result.CommonResult.Clear()
+	var response = &api2.Response{Action: ar.Action, Result: result}
+	srv.respondWithJsonObject(hrw, response)
+	return
+}
+
+func (srv *Server) AddNotificationTemplate(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
+	var err error
+	var params nm.AddNotificationTemplateParams
+	err = json.Unmarshal(*ar.Parameters, &params)
+	if err != nil {
+		srv.respondBadRequest(hrw)
+		return
+	}
+
+	params.CommonParams = cmr.CommonParams{Auth: ar.Authorisation}
+
+	var result = new(nm.AddNotificationTemplateResult)
+	var re *jrm1.RpcError
+	re, err = srv.nmServiceClient.MakeRequest(context.Background(), nc.FuncAddNotificationTemplate, params, result)
+	if err != nil {
+		srv.processInternalServerError(hrw, err)
+		return
+	}
+	if re != nil {
+		srv.processRpcError(app.ModuleId_NM, re, hrw)
+		return
+	}
+
+	result.CommonResult.Clear()
+	var response = &api2.Response{Action: ar.Action, Result: result}
+	srv.respondWithJsonObject(hrw, response)
+	return
+}
+
+func (srv *Server) GetNotificationTemplate(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
+	var err error
+	var params nm.GetNotificationTemplateParams
+	err = json.Unmarshal(*ar.Parameters, &params)
+	if err != nil {
+		srv.respondBadRequest(hrw)
+		return
+	}
+
+	params.CommonParams = cmr.CommonParams{Auth: ar.Authorisation}
+
+	var result = new(nm.GetNotificationTemplateResult)
+	var re *jrm1.RpcError
+	re, err = srv.nmServiceClient.MakeRequest(context.Background(), nc.FuncGetNotificationTemplate, params, result)
+	if err != nil {
+		srv.processInternalServerError(hrw, err)
+		return
+	}
+	if re != nil {
+		srv.processRpcError(app.ModuleId_NM, re, hrw)
+		return
+	}
+
+	result.CommonResult.Clear()
+	var response = &api2.Response{Action: ar.Action, Result: result}
+	srv.respondWithJsonObject(hrw, response)
+	return
+}
+
+func (srv *Server) ListNotificationTemplates(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
+	var err error
+	var params nm.ListNotificationTemplatesParams
+	err = json.Unmarshal(*ar.Parameters, &params)
+	if err != nil {
+		srv.respondBadRequest(hrw)
+		return
+	}
+
+	params.CommonParams = cmr.CommonParams{Auth: ar.Authorisation}
+
+	var result = new(nm.ListNotificationTemplatesResult)
+	var re *jrm1.RpcError
+	re, err = srv.nmServiceClient.MakeRequest(context.Background(), nc.FuncListNotificationTemplates, params, result)
+	if err != nil {
+		srv.processInternalServerError(hrw, err)
+		return
+	}
+	if re != nil {
+		srv.processRpcError(app.ModuleId_NM, re, hrw)
+		return
+	}
+
+	result.CommonResult.Clear()
+	var response = &api2.Response{Action: ar.Action, Result: result}
+	srv.respondWithJsonObject(hrw, response)
+	return
+}
+
+func (srv *Server) ChangeNotificationTemplate(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
+	var err error
+	var params nm.ChangeNotificationTemplateParams
+	err = json.Unmarshal(*ar.Parameters, &params)
+	if err != nil {
+		srv.respondBadRequest(hrw)
+		return
+	}
+
+	params.CommonParams = cmr.CommonParams{Auth: ar.Authorisation}
+
+	var result = new(nm.ChangeNotificationTemplateResult)
+	var re *jrm1.RpcError
+	re, err = srv.nmServiceClient.MakeRequest(context.Background(), nc.FuncChangeNotificationTemplate, params, result)
+	if err != nil {
+		srv.processInternalServerError(hrw, err)
+		return
+	}
+	if re != nil {
+		srv.processRpcError(app.ModuleId_NM, re, hrw)
+		return
+	}
+
+	result.CommonResult.Clear()
+	var response = &api2.Response{Action: ar.Action, Result: result}
+	srv.respondWithJsonObject(hrw, response)
+	return
+}
+
+func (srv *Server) DeleteNotificationTemplate(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
+	var err error
+	var params nm.DeleteNotificationTemplateParams
+	err = json.Unmarshal(*ar.Parameters, &params)
+	if err != nil {
+		srv.respondBadRequest(hrw)
+		return
+	}
+
+	params.CommonParams = cmr.CommonParams{Auth: ar.Authorisation}
+
+	var result = new(nm.DeleteNotificationTemplateResult)
+	var re *jrm1.RpcError
+	re, err = srv.nmServiceClient.MakeRequest(context.Background(), nc.FuncDeleteNotificationTemplate, params, result)
+	if err != nil {
+		srv.processInternalServerError(hrw, err)
+		return
+	}
+	if re != nil {
+		srv.processRpcError(app.ModuleId_NM, re, hrw)
+		return
+	}
+
+	result.CommonResult.Clear()
+	var response = &api2.Response{Action: ar.Action, Result: result}
+	srv.respondWithJsonObject(hrw, response)
+	return
+}
+
+func (srv *Server) SetSelfLanguage(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
+	var err error
+	var params nm.SetSelfLanguageParams
+	err = json.Unmarshal(*ar.Parameters, &params)
+	if err != nil {
+		srv.respondBadRequest(hrw)
+		return
+	}
+
+	params.CommonParams = cmr.CommonParams{Auth: ar.Authorisation}
+
+	var result = new(nm.SetSelfLanguageResult)
+	var re *jrm1.RpcError
+	re, err = srv.nmServiceClient.MakeRequest(context.Background(), nc.FuncSetSelfLanguage, params, result)
+	if err != nil {
+		srv.processInternalServerError(hrw, err)
+		return
+	}
+	if re != nil {
+		srv.processRpcError(app.ModuleId_NM, re, hrw)
+		return
+	}
+
+	result.CommonResult.Clear()
+	var response = &api2.Response{Action: ar.Action, Result: result}
+	srv.respondWithJsonObject(hrw, response)
+	return
+}
+
+func (srv *Server) GetSelfLanguage(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
+	var err error
+	var params nm.GetSelfLanguageParams
+	err = json.Unmarshal(*ar.Parameters, &params)
+	if err != nil {
+		srv.respondBadRequest(hrw)
+		return
+	}
+
+	params.CommonParams = cmr.CommonParams{Auth: ar.Authorisation}
+
+	var result = new(nm.GetSelfLanguageResult)
+	var re *jrm1.RpcError
+	re, err = srv.nmServiceClient.MakeRequest(context.Background(), nc.FuncGetSelfLanguage, params, result)
+	if err != nil {
+		srv.processInternalServerError(hrw, err)
+		return
+	}
+	if re != nil {
+		srv.processRpcError(app.ModuleId_NM, re, hrw)
+		return
+	}
+
+	result.CommonResult.Clear()
+	var response = &api2.Response{Action: ar.Action, Result: result}
+	srv.respondWithJsonObject(hrw, response)
+	return
+}
+
+// SM.
+
+func (srv *Server) AddSubscription(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
+	var err error
+	var params sm.AddSubscriptionParams
+	err = json.Unmarshal(*ar.Parameters, &params)
+	if err != nil {
+		srv.respondBadRequest(hrw)
+		return
+	}
+
+	params.CommonParams = cmr.CommonParams{Auth: ar.Authorisation}
+
+	var result = new(sm.AddSubscriptionResult)
+	var re *jrm1.RpcError
+	re, err = srv.smServiceClient.MakeRequest(context.Background(), sc.FuncAddSubscription, params, result)
+	if err != nil {
+		srv.processInternalServerError(hrw, err)
+		return
+	}
+	if re != nil {
+		srv.processRpcError(app.ModuleId_SM, re, hrw)
+		return
+	}
+
+	result.CommonResult.Clear()
+	var response = &api2.Response{Action: ar.Action, Result: result}
+	srv.respondWithJsonObject(hrw, response)
+	return
+}
+
+func (srv *Server) IsSelfSubscribed(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
+	var err error
+	var params sm.IsSelfSubscribedParams
+	err = json.Unmarshal(*ar.Parameters, &params)
+	if err != nil {
+		srv.respondBadRequest(hrw)
+		return
+	}
+
+	params.CommonParams = cmr.CommonParams{Auth: ar.Authorisation}
+
+	var result = new(sm.IsSelfSubscribedResult)
+	var re *jrm1.RpcError
+	re, err = srv.smServiceClient.MakeRequest(context.Background(), sc.FuncIsSelfSubscribed, params, result)
+	if err != nil {
+		srv.processInternalServerError(hrw, err)
+		return
+	}
+	if re != nil {
+		srv.processRpcError(app.ModuleId_SM, re, hrw)
+		return
+	}
+
+	result.CommonResult.Clear()
+	var response = &api2.Response{Action: ar.Action, Result: result}
+	srv.respondWithJsonObject(hrw, response)
+	return
+}
+
+func (srv *Server) IsUserSubscribed(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
+	var err error
+	var params sm.IsUserSubscribedParams
+	err = json.Unmarshal(*ar.Parameters, &params)
+	if err != nil {
+		srv.respondBadRequest(hrw)
+		return
+	}
+
+	params.CommonParams = cmr.CommonParams{Auth: ar.Authorisation}
+
+	var result = new(sm.IsUserSubscribedResult)
+	var re *jrm1.RpcError
+	re, err = srv.smServiceClient.MakeRequest(context.Background(), sc.FuncIsUserSubscribed, params, result)
+	if err != nil {
+		srv.processInternalServerError(hrw, err)
+		return
+	}
+	if re != nil {
+		srv.processRpcError(app.ModuleId_SM, re, hrw)
+		return
+	}
+
+	result.CommonResult.Clear()
+	var response = &api2.Response{Action: ar.Action, Result: result}
+	srv.respondWithJsonObject(hrw, response)
+	return
+}
+
+func (srv *Server) CountSelfSubscriptions(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
+	var err error
+	var params sm.CountSelfSubscriptionsParams
+	err = json.Unmarshal(*ar.Parameters, &params)
+	if err != nil {
+		srv.respondBadRequest(hrw)
+		return
+	}
+
+	params.CommonParams = cmr.CommonParams{Auth: ar.Authorisation}
+
+	var result = new(sm.CountSelfSubscriptionsResult)
+	var re *jrm1.RpcError
+	re, err = srv.smServiceClient.MakeRequest(context.Background(), sc.FuncCountSelfSubscriptions, params, result)
+	if err != nil {
+		srv.processInternalServerError(hrw, err)
+		return
+	}
+	if re != nil {
+		srv.processRpcError(app.ModuleId_SM, re, hrw)
+		return
+	}
+
+	result.CommonResult.Clear()
+	var response = &api2.Response{Action: ar.Action, Result: result}
+	srv.respondWithJsonObject(hrw, response)
+	return
+}
+
+func (srv *Server) GetSelfSubscriptions(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
+	var err error
+	var params sm.GetSelfSubscriptionsParams
+	err = json.Unmarshal(*ar.Parameters, &params)
+	if err != nil {
+		srv.respondBadRequest(hrw)
+		return
+	}
+
+	params.CommonParams = cmr.CommonParams{Auth: ar.Authorisation}
+
+	var result = new(sm.GetSelfSubscriptionsResult)
+	var re *jrm1.RpcError
+	re, err = srv.smServiceClient.MakeRequest(context.Background(), sc.FuncGetSelfSubscriptions, params, result)
+	if err != nil {
+		srv.processInternalServerError(hrw, err)
+		return
+	}
+	if re != nil {
+		srv.processRpcError(app.ModuleId_SM, re, hrw)
+		return
+	}
+
+	result.CommonResult.Clear()
+	var response = &api2.Response{Action: ar.Action, Result: result}
+	srv.respondWithJsonObject(hrw, response)
+	return
+}
+
+func (srv *Server) GetSelfSubscriptionsOnPage(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
+	var err error
+	var params sm.GetSelfSubscriptionsOnPageParams
+	err = json.Unmarshal(*ar.Parameters, &params)
+	if err != nil {
+		srv.respondBadRequest(hrw)
+		return
+	}
+
+	params.CommonParams = cmr.CommonParams{Auth: ar.Authorisation}
+
+	var result = new(sm.GetSelfSubscriptionsOnPageResult)
+	var re *jrm1.RpcError
+	re, err = srv.smServiceClient.MakeRequest(context.Background(), sc.FuncGetSelfSubscriptionsOnPage, params, result)
+	if err != nil {
+		srv.processInternalServerError(hrw, err)
+		return
+	}
+	if re != nil {
+		srv.processRpcError(app.ModuleId_SM, re, hrw)
+		return
+	}
+
+	result.CommonResult.Clear()
+	var response = &api2.Response{Action: ar.Action, Result: result}
+	srv.respondWithJsonObject(hrw, response)
+	return
+}
+
+func (srv *Server) GetUserSubscriptions(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
+	var err error
+	var params sm.GetUserSubscriptionsParams
+	err = json.Unmarshal(*ar.Parameters, &params)
+	if err != nil {
+		srv.respondBadRequest(hrw)
+		return
+	}
+
+	params.CommonParams = cmr.CommonParams{Auth: ar.Authorisation}
+
+	var result = new(sm.GetUserSubscriptionsResult)
+	var re *jrm1.RpcError
+	re, err = srv.smServiceClient.MakeRequest(context.Background(), sc.FuncGetUserSubscriptions, params, result)
+	if err != nil {
+		srv.processInternalServerError(hrw, err)
+		return
+	}
+	if re != nil {
+		srv.processRpcError(app.ModuleId_SM, re, hrw)
+		return
+	}
+
+	result.CommonResult.Clear()
+	var response = &api2.Response{Action: ar.Action, Result: result}
+	srv.respondWithJsonObject(hrw, response)
+	return
+}
+
+func (srv *Server) GetUserSubscriptionsOnPage(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
+	var err error
+	var params sm.GetUserSubscriptionsOnPageParams
+	err = json.Unmarshal(*ar.Parameters, &params)
+	if err != nil {
+		srv.respondBadRequest(hrw)
+		return
+	}
+
+	params.CommonParams = cmr.CommonParams{Auth: ar.Authorisation}
+
+	var result = new(sm.GetUserSubscriptionsOnPageResult)
 	var re *jrm1.RpcError
 	re, err = srv.smServiceClient.MakeRequest(context.Background(), sc.FuncGetUserSubscriptionsOnPage, params, result)
 	if err != nil {
@@ -2491,9 +4007,67 @@ func (srv *Server) GetUserSubscriptionsOnPage(ar *api2.Request, _ *http.Request,
 	return
 }
 
-func (srv *Server) DeleteSelfSubscription(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
+func (srv *Server) DeleteSelfSubscription(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
+	var err error
+	var params sm.DeleteSelfSubscriptionParams
+	err = json.Unmarshal(*ar.Parameters, &params)
+	if err != nil {
+		srv.respondBadRequest(hrw)
+		return
+	}
+
+	params.CommonParams = cmr.CommonParams{Auth: ar.Authorisation}
+
+	var result = new(sm.DeleteSelfSubscriptionResult)
+	var re *jrm1.RpcError
+	re, err = srv.smServiceClient.MakeRequest(context.Background(), sc.FuncDeleteSelfSubscription, params, result)
+	if err != nil {
+		srv.processInternalServerError(hrw, err)
+		return
+	}
+	if re != nil {
+		srv.processRpcError(app.ModuleId_SM, re, hrw)
+		return
+	}
+
+	result.CommonResult.Clear()
+	var response = &api2.Response{Action: ar.Action, Result: result}
+	srv.respondWithJsonObject(hrw, response)
+	return
+}
+
+func (srv *Server) DeleteSubscription(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
+	var err error
+	var params sm.DeleteSubscriptionParams
+	err = json.Unmarshal(*ar.Parameters, &params)
+	if err != nil {
+		srv.respondBadRequest(hrw)
+		return
+	}
+
+	params.CommonParams = cmr.CommonParams{Auth: ar.Authorisation}
+
+	var result = new(sm.DeleteSubscriptionResult)
+	var re *jrm1.RpcError
+	re, err = srv.smServiceClient.MakeRequest(context.Background(), sc.FuncDeleteSubscription, params, result)
+	if err != nil {
+		srv.processInternalServerError(hrw, err)
+		return
+	}
+	if re != nil {
+		srv.processRpcError(app.ModuleId_SM, re, hrw)
+		return
+	}
+
+	result.CommonResult.Clear()
+	var response = &api2.Response{Action: ar.Action, Result: result}
+	srv.respondWithJsonObject(hrw, response)
+	return
+}
+
+func (srv *Server) AddSelfForumSubscription(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
 	var err error
-	var params sm.DeleteSelfSubscriptionParams
+	var params sm.AddSelfForumSubscriptionParams
 	err = json.Unmarshal(*ar.Parameters, &params)
 	if err != nil {
 		srv.respondBadRequest(hrw)
@@ -2502,9 +4076,9 @@ func (srv *Server) DeleteSelfSubscription(ar *api2.Request, _ *http.Request, hrw
 
 	params.CommonParams = cmr.CommonParams{Auth: ar.Authorisation}
 
-	var result = new(sm.DeleteSelfSubscriptionResult)
+	var result = new(sm.AddSelfForumSubscriptionResult)
 	var re *jrm1.RpcError
-	re, err = srv.smServiceClient.MakeRequest(context.Background(), sc.FuncDeleteSelfSubscription, params, result)
+	re, err = srv.smServiceClient.MakeRequest(context.Background(), sc.FuncAddSelfForumSubscription, params, result)
 	if err != nil {
 		srv.processInternalServerError(hrw, err)
 		return
@@ -2520,9 +4094,9 @@ func (srv *Server) DeleteSelfSubscription(ar *api2.Request, _ *http.Request, hrw
 	return
 }
 
-func (srv *Server) DeleteSubscription(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
+func (srv *Server) DeleteSelfForumSubscription(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
 	var err error
-	var params sm.DeleteSubscriptionParams
+	var params sm.DeleteSelfForumSubscriptionParams
 	err = json.Unmarshal(*ar.Parameters, &params)
 	if err != nil {
 		srv.respondBadRequest(hrw)
@@ -2531,9 +4105,154 @@ func (srv *Server) DeleteSubscription(ar *api2.Request, _ *http.Request, hrw htt
 
 	params.CommonParams = cmr.CommonParams{Auth: ar.Authorisation}
 
-	var result = new(sm.DeleteSubscriptionResult)
+	var result = new(sm.DeleteSelfForumSubscriptionResult)
 	var re *jrm1.RpcError
-	re, err = srv.smServiceClient.MakeRequest(context.Background(), sc.FuncDeleteSubscription, params, result)
+	re, err = srv.smServiceClient.MakeRequest(context.Background(), sc.FuncDeleteSelfForumSubscription, params, result)
+	if err != nil {
+		srv.processInternalServerError(hrw, err)
+		return
+	}
+	if re != nil {
+		srv.processRpcError(app.ModuleId_SM, re, hrw)
+		return
+	}
+
+	result.CommonResult.Clear()
+	var response = &api2.Response{Action: ar.Action, Result: result}
+	srv.respondWithJsonObject(hrw, response)
+	return
+}
+
+func (srv *Server) AddSelfSectionSubscription(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
+	var err error
+	var params sm.AddSelfSectionSubscriptionParams
+	err = json.Unmarshal(*ar.Parameters, &params)
+	if err != nil {
+		srv.respondBadRequest(hrw)
+		return
+	}
+
+	params.CommonParams = cmr.CommonParams{Auth: ar.Authorisation}
+
+	var result = new(sm.AddSelfSectionSubscriptionResult)
+	var re *jrm1.RpcError
+	re, err = srv.smServiceClient.MakeRequest(context.Background(), sc.FuncAddSelfSectionSubscription, params, result)
+	if err != nil {
+		srv.processInternalServerError(hrw, err)
+		return
+	}
+	if re != nil {
+		srv.processRpcError(app.ModuleId_SM, re, hrw)
+		return
+	}
+
+	result.CommonResult.Clear()
+	var response = &api2.Response{Action: ar.Action, Result: result}
+	srv.respondWithJsonObject(hrw, response)
+	return
+}
+
+func (srv *Server) DeleteSelfSectionSubscription(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
+	var err error
+	var params sm.DeleteSelfSectionSubscriptionParams
+	err = json.Unmarshal(*ar.Parameters, &params)
+	if err != nil {
+		srv.respondBadRequest(hrw)
+		return
+	}
+
+	params.CommonParams = cmr.CommonParams{Auth: ar.Authorisation}
+
+	var result = new(sm.DeleteSelfSectionSubscriptionResult)
+	var re *jrm1.RpcError
+	re, err = srv.smServiceClient.MakeRequest(context.Background(), sc.FuncDeleteSelfSectionSubscription, params, result)
+	if err != nil {
+		srv.processInternalServerError(hrw, err)
+		return
+	}
+	if re != nil {
+		srv.processRpcError(app.ModuleId_SM, re, hrw)
+		return
+	}
+
+	result.CommonResult.Clear()
+	var response = &api2.Response{Action: ar.Action, Result: result}
+	srv.respondWithJsonObject(hrw, response)
+	return
+}
+
+func (srv *Server) GetSelfForumAndSectionSubscriptions(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
+	var err error
+	var params sm.GetSelfForumAndSectionSubscriptionsParams
+	err = json.Unmarshal(*ar.Parameters, &params)
+	if err != nil {
+		srv.respondBadRequest(hrw)
+		return
+	}
+
+	params.CommonParams = cmr.CommonParams{Auth: ar.Authorisation}
+
+	var result = new(sm.GetSelfForumAndSectionSubscriptionsResult)
+	var re *jrm1.RpcError
+	re, err = srv.smServiceClient.MakeRequest(context.Background(), sc.FuncGetSelfForumAndSectionSubscriptions, params, result)
+	if err != nil {
+		srv.processInternalServerError(hrw, err)
+		return
+	}
+	if re != nil {
+		srv.processRpcError(app.ModuleId_SM, re, hrw)
+		return
+	}
+
+	result.CommonResult.Clear()
+	var response = &api2.Response{Action: ar.Action, Result: result}
+	srv.respondWithJsonObject(hrw, response)
+	return
+}
+
+func (srv *Server) SetSelfDeliveryMode(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
+	var err error
+	var params sm.SetSelfDeliveryModeParams
+	err = json.Unmarshal(*ar.Parameters, &params)
+	if err != nil {
+		srv.respondBadRequest(hrw)
+		return
+	}
+
+	params.CommonParams = cmr.CommonParams{Auth: ar.Authorisation}
+
+	var result = new(sm.SetSelfDeliveryModeResult)
+	var re *jrm1.RpcError
+	re, err = srv.smServiceClient.MakeRequest(context.Background(), sc.FuncSetSelfDeliveryMode, params, result)
+	if err != nil {
+		srv.processInternalServerError(hrw, err)
+		return
+	}
+	if re != nil {
+		srv.processRpcError(app.ModuleId_SM, re, hrw)
+		return
+	}
+
+	result.CommonResult.Clear()
+	var response = &api2.Response{Action: ar.Action, Result: result}
+	srv.respondWithJsonObject(hrw, response)
+	return
+}
+
+func (srv *Server) GetSelfDeliveryMode(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
+	var err error
+	var params sm.GetSelfDeliveryModeParams
+	err = json.Unmarshal(*ar.Parameters, &params)
+	if err != nil {
+		srv.respondBadRequest(hrw)
+		return
+	}
+
+	params.CommonParams = cmr.CommonParams{Auth: ar.Authorisation}
+
+	var result = new(sm.GetSelfDeliveryModeResult)
+	var re *jrm1.RpcError
+	re, err = srv.smServiceClient.MakeRequest(context.Background(), sc.FuncGetSelfDeliveryMode, params, result)
 	if err != nil {
 		srv.processInternalServerError(hrw, err)
 		return
@@ -2548,3 +4267,138 @@ func (srv *Server) DeleteSubscription(ar *api2.Request, _ *http.Request, hrw htt
 	srv.respondWithJsonObject(hrw, response)
 	return
 }
+
+func (srv *Server) UnblockIPAddressApi(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
+	var err error
+	var params gm.UnblockIPAddressParams
+	err = json.Unmarshal(*ar.Parameters, &params)
+	if err != nil {
+		srv.respondBadRequest(hrw)
+		return
+	}
+
+	if !srv.mustBeAnAdministrator(ar, hrw) {
+		return
+	}
+
+	var result *gm.UnblockIPAddressResult
+	var re *jrm1.RpcError
+	result, re = srv.unblockIPAddress(&params)
+	if re != nil {
+		srv.processRpcError(app.ModuleId_GWM, re, hrw)
+		return
+	}
+
+	result.CommonResult.Clear()
+	var response = &api2.Response{Action: ar.Action, Result: result}
+	srv.respondWithJsonObject(hrw, response)
+	return
+}
+
+func (srv *Server) ListIPBlocksApi(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
+	var err error
+	var params gm.ListIPBlocksParams
+	err = json.Unmarshal(*ar.Parameters, &params)
+	if err != nil {
+		srv.respondBadRequest(hrw)
+		return
+	}
+
+	if !srv.mustBeAnAdministrator(ar, hrw) {
+		return
+	}
+
+	var result *gm.ListIPBlocksResult
+	var re *jrm1.RpcError
+	result, re = srv.listIPBlocks(&params)
+	if re != nil {
+		srv.processRpcError(app.ModuleId_GWM, re, hrw)
+		return
+	}
+
+	result.CommonResult.Clear()
+	var response = &api2.Response{Action: ar.Action, Result: result}
+	srv.respondWithJsonObject(hrw, response)
+	return
+}
+
+func (srv *Server) AddIPListEntryApi(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
+	var err error
+	var params gm.AddIPListEntryParams
+	err = json.Unmarshal(*ar.Parameters, &params)
+	if err != nil {
+		srv.respondBadRequest(hrw)
+		return
+	}
+
+	if !srv.mustBeAnAdministrator(ar, hrw) {
+		return
+	}
+
+	var result *gm.AddIPListEntryResult
+	var re *jrm1.RpcError
+	result, re = srv.addIPListEntry(&params)
+	if re != nil {
+		srv.processRpcError(app.ModuleId_GWM, re, hrw)
+		return
+	}
+
+	result.CommonResult.Clear()
+	var response = &api2.Response{Action: ar.Action, Result: result}
+	srv.respondWithJsonObject(hrw, response)
+	return
+}
+
+func (srv *Server) DeleteIPListEntryApi(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
+	var err error
+	var params gm.DeleteIPListEntryParams
+	err = json.Unmarshal(*ar.Parameters, &params)
+	if err != nil {
+		srv.respondBadRequest(hrw)
+		return
+	}
+
+	if !srv.mustBeAnAdministrator(ar, hrw) {
+		return
+	}
+
+	var result *gm.DeleteIPListEntryResult
+	var re *jrm1.RpcError
+	result, re = srv.deleteIPListEntry(&params)
+	if re != nil {
+		srv.processRpcError(app.ModuleId_GWM, re, hrw)
+		return
+	}
+
+	result.CommonResult.Clear()
+	var response = &api2.Response{Action: ar.Action, Result: result}
+	srv.respondWithJsonObject(hrw, response)
+	return
+}
+
+func (srv *Server) ListIPListEntriesApi(ar *api2.Request, _ *http.Request, hrw http.ResponseWriter) {
+	var err error
+	var params gm.ListIPListEntriesParams
+	err = json.Unmarshal(*ar.Parameters, &params)
+	if err != nil {
+		srv.respondBadRequest(hrw)
+		return
+	}
+
+	if !srv.mustBeAnAdministrator(ar, hrw) {
+		return
+	}
+
+	var result *gm.ListIPListEntriesResult
+	var re *jrm1.RpcError
+	result, re = srv.listIPListEntries(&params)
+	if re != nil {
+		srv.processRpcError(app.ModuleId_GWM, re, hrw)
+		return
+	}
+
+	result.CommonResult.Clear()
+	var response = &api2.Response{Action: ar.Action, Result: result}
+	srv.respondWithJsonObject(hrw, response)
+	return
+}
@@ -2,10 +2,17 @@ package server
 
 import (
 	"encoding/json"
+	"fmt"
+	nmm "github.com/vault-thirteen/SimpleBB/pkg/NM/models"
+	"github.com/vault-thirteen/SimpleBB/pkg/common/models/app"
 	http2 "github.com/vault-thirteen/SimpleBB/pkg/common/models/http"
+	c "github.com/vault-thirteen/SimpleBB/pkg/common/models/server"
 	"github.com/vault-thirteen/SimpleBB/pkg/common/models/simple"
 	"log"
+	"math"
 	"net/http"
+	"strconv"
+	"time"
 
 	jrm1 "github.com/vault-thirteen/JSON-RPC-M1"
 	"github.com/vault-thirteen/auxie/header"
@@ -41,6 +48,16 @@ func (srv *Server) processRpcError(moduleId int, re *jrm1.RpcError, rw http.Resp
 	return
 }
 
+// processRateLimitError responds via HTTP to a request which has exceeded
+// its rate limit. The client is told when it may repeat the request.
+func (srv *Server) processRateLimitError(rw http.ResponseWriter, retryAfter time.Duration) {
+	retryAfterSec := int64(math.Ceil(retryAfter.Seconds()))
+	rw.Header().Set(http2.HttpHeaderRetryAfter, strconv.FormatInt(retryAfterSec, 10))
+
+	re := jrm1.NewRpcErrorByUser(c.RpcErrorCode_RateLimit, c.RpcErrorMsg_RateLimit, nil)
+	srv.processRpcError(app.ModuleId_GWM, re, rw)
+}
+
 // respondWithPlainText responds via HTTP with a simple text message.
 func (srv *Server) respondWithPlainText(rw http.ResponseWriter, text string, httpStatusCode int) {
 	if srv.settings.GetSystemSettings().GetIsDeveloperMode() {
@@ -70,6 +87,23 @@ func (srv *Server) respondWithJsonObject(rw http.ResponseWriter, obj any) {
 	}
 }
 
+// writeNotificationEvent writes the notification into the stream of
+// server-sent events.
+func (srv *Server) writeNotificationEvent(rw http.ResponseWriter, n *nmm.Notification) (err error) {
+	var data []byte
+	data, err = json.Marshal(n)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(rw, "id: %d\nevent: %s\ndata: %s\n\n", n.Id, EventName_Notification, data)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (srv *Server) respondBadRequest(rw http.ResponseWriter) {
 	if srv.settings.GetSystemSettings().GetIsDeveloperMode() {
 		rw.Header().Set(header.HttpHeaderAccessControlAllowOrigin, srv.settings.GetSystemSettings().GetDevModeHttpHeaderAccessControlAllowOrigin())
@@ -17,6 +17,7 @@ type ISettings interface {
 	GetIntHttpSettings() (ihs IntHttpSettings)
 	GetExtHttpsSettings() (ehs ExtHttpsSettings)
 	GetSystemSettings() (ss ISystemSettings)
+	GetRateLimitSettings() (rls RateLimitSettings)
 	SetDbSettings(ds DbSettings)
 	SetFilePath(filePath simple.Path)
 	SetVersionInfo(versionInfo *ver.Versioneer)
@@ -14,8 +14,14 @@ type ISystemSettings interface {
 	GetSiteName() base2.Text
 	GetSiteDomain() base2.Text
 	GetIsFirewallUsed() base2.Flag
+	GetIPv4SubnetPrefixLength() base2.Count
+	GetIPv6SubnetPrefixLength() base2.Count
+	GetSubnetBlockThreshold() base2.Count
+	GetMaxBlockTimeSec() base2.Count
+	GetOffenceMemoryPeriodSec() base2.Count
 	GetClientIPAddressSource() derived1.IClientIPAddressSource
 	GetClientIPAddressHeader() string
+	GetTrustedProxies() []string
 	GetCaptchaImgServerHost() string
 	GetCaptchaImgServerPort() uint16
 	GetCaptchaFolder() simple.Path
@@ -24,6 +30,8 @@ type ISystemSettings interface {
 	GetPageSize() base2.Count
 	GetApiFolder() simple.Path
 	GetPublicSettingsFileName() simple.Path
+	GetEventsFolder() simple.Path
+	GetAttachmentsFolder() simple.Path
 	GetIsFrontEndEnabled() base2.Flag
 	GetFrontEndStaticFilesFolder() simple.Path
 	GetFrontEndAssetsFolder() simple.Path
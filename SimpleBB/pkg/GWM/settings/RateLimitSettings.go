@@ -0,0 +1,121 @@
+package s
+
+import (
+	"errors"
+	base2 "github.com/vault-thirteen/SimpleBB/pkg/common/models/base"
+)
+
+const (
+	ErrRateLimitSettings = "rate limit settings error"
+)
+
+// Roles of users used in rate limits.
+const (
+	RateLimitRole_Default       = "default"
+	RateLimitRole_Guest         = "guest"
+	RateLimitRole_User          = "user"
+	RateLimitRole_Moderator     = "moderator"
+	RateLimitRole_Administrator = "administrator"
+)
+
+// RateLimit is a limit of a token bucket. Each request takes a token from
+// the bucket, and the bucket is refilled at a constant rate.
+type RateLimit struct {
+	// Capacity is the size of the bucket, i.e. a number of requests which
+	// may be done in a burst. Zero capacity means that there is no limit.
+	Capacity base2.Count `json:"capacity"`
+
+	// RefillPerMinute is a number of tokens added to the bucket each minute.
+	RefillPerMinute base2.Count `json:"refillPerMinute"`
+}
+
+func (rl RateLimit) IsUnlimited() bool {
+	return rl.Capacity == 0
+}
+
+// RateLimitsByRole are limits for users having different roles. The default
+// limit is used for roles which are not listed. When there is no default
+// limit, users of roles which are not listed are not limited.
+type RateLimitsByRole map[string]RateLimit
+
+// GetLimit returns a limit for the role.
+func (rlbr RateLimitsByRole) GetLimit(role string) (rl RateLimit) {
+	var ok bool
+	rl, ok = rlbr[role]
+	if ok {
+		return rl
+	}
+
+	return rlbr[RateLimitRole_Default]
+}
+
+// RateLimitSettings are settings of rate limiting of the public API.
+// Requests are counted in several token buckets at once. A request is
+// rejected when any of its buckets is empty.
+type RateLimitSettings struct {
+	IsEnabled base2.Flag `json:"isEnabled"`
+
+	// Period of caching of roles of users in seconds.
+	RoleCacheTtlSec base2.Count `json:"roleCacheTtlSec"`
+
+	// Limit of all requests coming from an IP address.
+	IPAddress RateLimit `json:"ipAddress"`
+
+	// Limits of all requests of a user. Guests are identified by their IP
+	// addresses.
+	User RateLimitsByRole `json:"user"`
+
+	// Limits of requests of a user per action. Keys are names of API
+	// functions.
+	Actions map[string]RateLimitsByRole `json:"actions"`
+}
+
+func (rls RateLimitSettings) Check() (err error) {
+	if !rls.IsEnabled {
+		return nil
+	}
+
+	if rls.RoleCacheTtlSec == 0 {
+		return errors.New(ErrRateLimitSettings)
+	}
+
+	if !rls.IPAddress.isValid() {
+		return errors.New(ErrRateLimitSettings)
+	}
+
+	if !rls.User.isValid() {
+		return errors.New(ErrRateLimitSettings)
+	}
+
+	for _, rlbr := range rls.Actions {
+		if !rlbr.isValid() {
+			return errors.New(ErrRateLimitSettings)
+		}
+	}
+
+	return nil
+}
+
+func (rl RateLimit) isValid() bool {
+	return rl.IsUnlimited() || (rl.RefillPerMinute > 0)
+}
+
+func (rlbr RateLimitsByRole) isValid() bool {
+	for role, rl := range rlbr {
+		switch role {
+		case RateLimitRole_Default,
+			RateLimitRole_Guest,
+			RateLimitRole_User,
+			RateLimitRole_Moderator,
+			RateLimitRole_Administrator:
+		default:
+			return false
+		}
+
+		if !rl.isValid() {
+			return false
+		}
+	}
+
+	return true
+}
@@ -29,6 +29,9 @@ type settings struct {
 	DbSettings       `json:"db"`
 	ISystemSettings  `json:"system"`
 
+	// Rate limits of the public API.
+	RateLimitSettings `json:"rateLimits"`
+
 	// External services.
 	AcmSettings s.ServiceClientSettings `json:"acm"`
 	MmSettings  s.ServiceClientSettings `json:"mm"`
@@ -109,6 +112,12 @@ func (stn *settings) Check() (err error) {
 		return err
 	}
 
+	// Rate limits.
+	err = stn.RateLimitSettings.Check()
+	if err != nil {
+		return err
+	}
+
 	// External services.
 	err = stn.AcmSettings.Check()
 	if err != nil {
@@ -147,6 +156,9 @@ func (stn *settings) GetDbSettings() (ds DbSettings) {
 func (stn *settings) GetIntHttpSettings() (ihs IntHttpSettings)   { return stn.IntHttpSettings }
 func (stn *settings) GetExtHttpsSettings() (ehs ExtHttpsSettings) { return stn.ExtHttpsSettings }
 func (stn *settings) GetSystemSettings() (ss ISystemSettings)     { return stn.ISystemSettings }
+func (stn *settings) GetRateLimitSettings() (rls RateLimitSettings) {
+	return stn.RateLimitSettings
+}
 func (stn *settings) SetDbSettings(ds DbSettings) {
 	stn.DbSettings = ds
 }
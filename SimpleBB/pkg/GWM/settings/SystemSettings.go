@@ -5,6 +5,7 @@ import (
 	"github.com/vault-thirteen/SimpleBB/pkg/common/interfaces/derived1"
 	base2 "github.com/vault-thirteen/SimpleBB/pkg/common/models/base"
 	cm "github.com/vault-thirteen/SimpleBB/pkg/common/models/complex/ClientIPAddressSource"
+	cn "github.com/vault-thirteen/SimpleBB/pkg/common/models/net"
 	c "github.com/vault-thirteen/SimpleBB/pkg/common/models/server"
 	"github.com/vault-thirteen/SimpleBB/pkg/common/models/simple"
 )
@@ -19,6 +20,19 @@ type systemSettings struct {
 	// Firewall.
 	IsFirewallUsed base2.Flag `json:"isFirewallUsed"`
 
+	// Blocks of addresses are escalated per subnet. Every block of an address
+	// is an offence of its subnet. Each offence doubles the time of the next
+	// block in the subnet up to the MaxBlockTimeSec limit. When the number of
+	// offences reaches the SubnetBlockThreshold, the whole subnet is blocked.
+	// Offences are forgotten when the subnet behaves well during the
+	// OffenceMemoryPeriodSec period. Size of a subnet is set by the length of
+	// its prefix, e.g. 24 bits for IPv4 and 64 bits for IPv6.
+	IPv4SubnetPrefixLength base2.Count `json:"ipv4SubnetPrefixLength"`
+	IPv6SubnetPrefixLength base2.Count `json:"ipv6SubnetPrefixLength"`
+	SubnetBlockThreshold   base2.Count `json:"subnetBlockThreshold"`
+	MaxBlockTimeSec        base2.Count `json:"maxBlockTimeSec"`
+	OffenceMemoryPeriodSec base2.Count `json:"offenceMemoryPeriodSec"`
+
 	// ClientIPAddressSource setting selects where to search for client's IP
 	// address. '1' means that IP address is taken directly from the client's
 	// address of the HTTP request; '2' means that IP address is taken from the
@@ -31,6 +45,13 @@ type systemSettings struct {
 	ClientIPAddressSource derived1.IClientIPAddressSource `json:"clientIPAddressSource"`
 	ClientIPAddressHeader string                          `json:"clientIPAddressHeader"`
 
+	// TrustedProxies is a list of address ranges of reverse proxies in the CIDR
+	// notation. When it is set, the custom HTTP header is used only in
+	// requests coming from these proxies, and addresses of the proxies are
+	// skipped in the header. When it is empty, the header is always used as
+	// is.
+	TrustedProxies []string `json:"trustedProxies"`
+
 	// Captcha.
 	CaptchaImgServerHost string      `json:"captchaImgServerHost"`
 	CaptchaImgServerPort uint16      `json:"captchaImgServerPort"`
@@ -44,6 +65,8 @@ type systemSettings struct {
 	// URL paths.
 	ApiFolder              simple.Path `json:"apiFolder"`
 	PublicSettingsFileName simple.Path `json:"publicSettingsFileName"`
+	EventsFolder           simple.Path `json:"eventsFolder"`
+	AttachmentsFolder      simple.Path `json:"attachmentsFolder"`
 
 	// Front end.
 	IsFrontEndEnabled         base2.Flag  `json:"isFrontEndEnabled"`
@@ -78,6 +101,8 @@ func (s systemSettings) Check() (err error) {
 		(s.PageSize == 0) ||
 		(len(s.ApiFolder) == 0) ||
 		(len(s.PublicSettingsFileName) == 0) ||
+		(len(s.EventsFolder) == 0) ||
+		(len(s.AttachmentsFolder) == 0) ||
 		(s.NotificationCountLimit == 0) {
 		return errors.New(c.MsgSystemSettingError)
 	}
@@ -95,6 +120,25 @@ func (s systemSettings) Check() (err error) {
 		}
 	}
 
+	for _, tp := range s.TrustedProxies {
+		_, err = cn.ParseCidr(tp)
+		if err != nil {
+			return errors.New(c.MsgSystemSettingError)
+		}
+	}
+
+	if s.IsFirewallUsed {
+		if (s.IPv4SubnetPrefixLength == 0) ||
+			(s.IPv4SubnetPrefixLength > cn.IPv4BitsCount) ||
+			(s.IPv6SubnetPrefixLength == 0) ||
+			(s.IPv6SubnetPrefixLength > cn.IPv6BitsCount) ||
+			(s.SubnetBlockThreshold == 0) ||
+			(s.MaxBlockTimeSec == 0) ||
+			(s.OffenceMemoryPeriodSec == 0) {
+			return errors.New(c.MsgSystemSettingError)
+		}
+	}
+
 	if s.IsDeveloperMode {
 		if len(s.DevModeHttpHeaderAccessControlAllowOrigin) == 0 {
 			return errors.New(c.MsgSystemSettingError)
@@ -105,14 +149,20 @@ func (s systemSettings) Check() (err error) {
 }
 
 // Emulated class members.
-func (s systemSettings) GetSettingsVersion() base2.Count { return s.SettingsVersion }
-func (s systemSettings) GetSiteName() base2.Text         { return s.SiteName }
-func (s systemSettings) GetSiteDomain() base2.Text       { return s.SiteDomain }
-func (s systemSettings) GetIsFirewallUsed() base2.Flag   { return s.IsFirewallUsed }
+func (s systemSettings) GetSettingsVersion() base2.Count        { return s.SettingsVersion }
+func (s systemSettings) GetSiteName() base2.Text                { return s.SiteName }
+func (s systemSettings) GetSiteDomain() base2.Text              { return s.SiteDomain }
+func (s systemSettings) GetIsFirewallUsed() base2.Flag          { return s.IsFirewallUsed }
+func (s systemSettings) GetIPv4SubnetPrefixLength() base2.Count { return s.IPv4SubnetPrefixLength }
+func (s systemSettings) GetIPv6SubnetPrefixLength() base2.Count { return s.IPv6SubnetPrefixLength }
+func (s systemSettings) GetSubnetBlockThreshold() base2.Count   { return s.SubnetBlockThreshold }
+func (s systemSettings) GetMaxBlockTimeSec() base2.Count        { return s.MaxBlockTimeSec }
+func (s systemSettings) GetOffenceMemoryPeriodSec() base2.Count { return s.OffenceMemoryPeriodSec }
 func (s systemSettings) GetClientIPAddressSource() derived1.IClientIPAddressSource {
 	return s.ClientIPAddressSource
 }
 func (s systemSettings) GetClientIPAddressHeader() string       { return s.ClientIPAddressHeader }
+func (s systemSettings) GetTrustedProxies() []string            { return s.TrustedProxies }
 func (s systemSettings) GetCaptchaImgServerHost() string        { return s.CaptchaImgServerHost }
 func (s systemSettings) GetCaptchaImgServerPort() uint16        { return s.CaptchaImgServerPort }
 func (s systemSettings) GetCaptchaFolder() simple.Path          { return s.CaptchaFolder }
@@ -121,6 +171,8 @@ func (s systemSettings) GetMessageEditTime() base2.Count        { return s.Messa
 func (s systemSettings) GetPageSize() base2.Count               { return s.PageSize }
 func (s systemSettings) GetApiFolder() simple.Path              { return s.ApiFolder }
 func (s systemSettings) GetPublicSettingsFileName() simple.Path { return s.PublicSettingsFileName }
+func (s systemSettings) GetEventsFolder() simple.Path           { return s.EventsFolder }
+func (s systemSettings) GetAttachmentsFolder() simple.Path      { return s.AttachmentsFolder }
 func (s systemSettings) GetIsFrontEndEnabled() base2.Flag       { return s.IsFrontEndEnabled }
 func (s systemSettings) GetFrontEndStaticFilesFolder() simple.Path {
 	return s.FrontEndStaticFilesFolder
@@ -0,0 +1,168 @@
+package blob
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+const (
+	ErrFolderIsNotSet = "folder is not set"
+	ErrHashIsNotValid = "hash is not valid"
+	ErrBlobIsNotFound = "blob is not found"
+	ErrBlobIsDamaged  = "blob is damaged"
+)
+
+const (
+	// HashLength is the length of a blob's hash in hexadecimal symbols.
+	HashLength = sha256.Size * 2
+
+	// Blobs are spread over two levels of sub-folders named by the first
+	// symbols of their hashes, so that no folder becomes too large.
+	SubFolderNameLength = 2
+
+	TempFileExt  = ".tmp"
+	FolderMode   = 0700
+	BlobFileMode = 0600
+)
+
+// BlobStore is a content-addressed storage of binary objects on a local
+// disk. Every blob is stored in a file named by the SHA-256 hash of its
+// contents, so that equal files are stored only once. Blob store does not
+// count references to blobs, it is done by its user.
+type BlobStore struct {
+	folder string
+}
+
+func NewBlobStore(folder string) (bs *BlobStore, err error) {
+	if len(folder) == 0 {
+		return nil, errors.New(ErrFolderIsNotSet)
+	}
+
+	err = os.MkdirAll(folder, FolderMode)
+	if err != nil {
+		return nil, err
+	}
+
+	return &BlobStore{folder: folder}, nil
+}
+
+// Hash returns the hash of data, i.e. the address of a blob.
+func Hash(data []byte) (hash string) {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// IsHashValid checks syntax of a hash. Only lower-case hexadecimal hashes of
+// correct length are accepted, so that a hash can not escape the folder.
+func IsHashValid(hash string) bool {
+	if len(hash) != HashLength {
+		return false
+	}
+
+	for _, r := range hash {
+		if !(((r >= '0') && (r <= '9')) || ((r >= 'a') && (r <= 'f'))) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Save stores data and returns its hash. Saving of an existing blob does
+// nothing. Data is written into a temporary file which then replaces the
+// blob's file, so that a crash never leaves a half-written blob.
+func (bs *BlobStore) Save(data []byte) (hash string, err error) {
+	hash = Hash(data)
+	filePath := bs.blobFilePath(hash)
+
+	_, err = os.Stat(filePath)
+	if err == nil {
+		return hash, nil
+	}
+	if !errors.Is(err, fs.ErrNotExist) {
+		return "", err
+	}
+
+	err = os.MkdirAll(filepath.Dir(filePath), FolderMode)
+	if err != nil {
+		return "", err
+	}
+
+	tmpFilePath := filePath + TempFileExt
+
+	var f *os.File
+	f, err = os.OpenFile(tmpFilePath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, BlobFileMode)
+	if err != nil {
+		return "", err
+	}
+
+	_, err = f.Write(data)
+	if err == nil {
+		err = f.Sync()
+	}
+
+	cerr := f.Close()
+	if err == nil {
+		err = cerr
+	}
+	if err != nil {
+		_ = os.Remove(tmpFilePath)
+		return "", err
+	}
+
+	err = os.Rename(tmpFilePath, filePath)
+	if err != nil {
+		return "", err
+	}
+
+	return hash, nil
+}
+
+// Read reads a blob. Contents of the blob are verified using its hash.
+func (bs *BlobStore) Read(hash string) (data []byte, err error) {
+	if !IsHashValid(hash) {
+		return nil, errors.New(ErrHashIsNotValid)
+	}
+
+	data, err = os.ReadFile(bs.blobFilePath(hash))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, errors.New(ErrBlobIsNotFound)
+		}
+		return nil, err
+	}
+
+	if Hash(data) != hash {
+		return nil, errors.New(ErrBlobIsDamaged)
+	}
+
+	return data, nil
+}
+
+// Delete deletes a blob. Deletion of a non-existent blob is not an error.
+func (bs *BlobStore) Delete(hash string) (err error) {
+	if !IsHashValid(hash) {
+		return errors.New(ErrHashIsNotValid)
+	}
+
+	err = os.Remove(bs.blobFilePath(hash))
+	if (err != nil) && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	return nil
+}
+
+// blobFilePath returns path to the file of a blob, e.g. 'ab/cd/abcd...'.
+func (bs *BlobStore) blobFilePath(hash string) string {
+	return filepath.Join(
+		bs.folder,
+		hash[0:SubFolderNameLength],
+		hash[SubFolderNameLength:SubFolderNameLength*2],
+		hash,
+	)
+}
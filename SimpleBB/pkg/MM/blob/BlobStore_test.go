@@ -0,0 +1,130 @@
+package blob
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/vault-thirteen/auxie/tester"
+)
+
+func Test_BlobStore(t *testing.T) {
+	aTest := tester.New(t)
+
+	folder := t.TempDir()
+	bs, err := NewBlobStore(folder)
+	aTest.MustBeNoError(err)
+
+	// Equal data is stored once.
+	data := []byte("Hello, World!")
+	hash, err := bs.Save(data)
+	aTest.MustBeNoError(err)
+	aTest.MustBeEqual(hash, Hash(data))
+	aTest.MustBeEqual(IsHashValid(hash), true)
+
+	hash2, err := bs.Save(data)
+	aTest.MustBeNoError(err)
+	aTest.MustBeEqual(hash2, hash)
+
+	var readData []byte
+	readData, err = bs.Read(hash)
+	aTest.MustBeNoError(err)
+	aTest.MustBeEqual(readData, data)
+
+	filePath := filepath.Join(folder, hash[0:2], hash[2:4], hash)
+	_, err = os.Stat(filePath)
+	aTest.MustBeNoError(err)
+
+	// Damaged blob is detected.
+	err = os.WriteFile(filePath, []byte("Bye!"), BlobFileMode)
+	aTest.MustBeNoError(err)
+	_, err = bs.Read(hash)
+	aTest.MustBeAnError(err)
+
+	// Deletion.
+	aTest.MustBeNoError(bs.Delete(hash))
+	aTest.MustBeNoError(bs.Delete(hash))
+	_, err = bs.Read(hash)
+	aTest.MustBeEqual(err.Error(), ErrBlobIsNotFound)
+
+	// Hash must not escape the folder.
+	_, err = bs.Read("../" + hash[3:])
+	aTest.MustBeEqual(err.Error(), ErrHashIsNotValid)
+	aTest.MustBeAnError(bs.Delete(strings.ToUpper(hash)))
+
+	_, err = NewBlobStore("")
+	aTest.MustBeAnError(err)
+}
+
+func Test_MakeThumbnail(t *testing.T) {
+	aTest := tester.New(t)
+
+	src := image.NewRGBA(image.Rect(0, 0, 400, 100))
+	for y := 0; y < 100; y++ {
+		for x := 0; x < 400; x++ {
+			src.SetRGBA(x, y, color.RGBA{R: 255, A: 255})
+		}
+	}
+
+	var buf bytes.Buffer
+	aTest.MustBeNoError(png.Encode(&buf, src))
+
+	thumbnail, err := MakeThumbnail(buf.Bytes(), 100)
+	aTest.MustBeNoError(err)
+
+	var img image.Image
+	img, err = png.Decode(bytes.NewReader(thumbnail))
+	aTest.MustBeNoError(err)
+	aTest.MustBeEqual(img.Bounds().Dx(), 100)
+	aTest.MustBeEqual(img.Bounds().Dy(), 25)
+
+	r, g, b, a := img.At(50, 10).RGBA()
+	aTest.MustBeEqual([]uint32{r >> 8, g >> 8, b >> 8, a >> 8}, []uint32{255, 0, 0, 255})
+
+	// Small images are not enlarged.
+	thumbnail, err = MakeThumbnail(buf.Bytes(), 1000)
+	aTest.MustBeNoError(err)
+	img, err = png.Decode(bytes.NewReader(thumbnail))
+	aTest.MustBeNoError(err)
+	aTest.MustBeEqual(img.Bounds().Dx(), 400)
+
+	_, err = MakeThumbnail([]byte("not an image"), 100)
+	aTest.MustBeAnError(err)
+
+	_, err = MakeThumbnail(buf.Bytes(), 0)
+	aTest.MustBeAnError(err)
+}
+
+func Test_thumbnailDimensions(t *testing.T) {
+	aTest := tester.New(t)
+
+	w, h := thumbnailDimensions(1000, 500, 200)
+	aTest.MustBeEqual([]int{w, h}, []int{200, 100})
+
+	w, h = thumbnailDimensions(500, 1000, 200)
+	aTest.MustBeEqual([]int{w, h}, []int{100, 200})
+
+	w, h = thumbnailDimensions(10000, 1, 200)
+	aTest.MustBeEqual([]int{w, h}, []int{200, 1})
+
+	w, h = thumbnailDimensions(150, 50, 200)
+	aTest.MustBeEqual([]int{w, h}, []int{150, 50})
+}
+
+func Test_DetectContentType(t *testing.T) {
+	aTest := tester.New(t)
+
+	var buf bytes.Buffer
+	aTest.MustBeNoError(png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 1, 1))))
+
+	aTest.MustBeEqual(DetectContentType(buf.Bytes()), "image/png")
+	aTest.MustBeEqual(DetectContentType([]byte("Hello, World!")), "text/plain")
+	aTest.MustBeEqual(DetectContentType([]byte("%PDF-1.7")), "application/pdf")
+	aTest.MustBeEqual(IsThumbnailSupported("image/png"), true)
+	aTest.MustBeEqual(IsThumbnailSupported("image/webp"), false)
+}
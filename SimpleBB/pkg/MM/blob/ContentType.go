@@ -0,0 +1,30 @@
+package blob
+
+import (
+	"mime"
+	"net/http"
+)
+
+// DetectContentType detects the MIME type of data by its contents. Parameters
+// of the type, such as a character set, are removed.
+func DetectContentType(data []byte) (contentType string) {
+	contentType = http.DetectContentType(data)
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return contentType
+	}
+
+	return mediaType
+}
+
+// IsThumbnailSupported checks whether thumbnails can be made for files of the
+// type.
+func IsThumbnailSupported(contentType string) bool {
+	switch contentType {
+	case "image/gif", "image/jpeg", "image/png":
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,135 @@
+package blob
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	_ "image/gif"
+	_ "image/jpeg"
+	"image/png"
+)
+
+const (
+	ErrThumbnailSizeIsNotValid = "thumbnail size is not valid"
+	ErrImageIsTooLarge         = "image is too large"
+)
+
+const (
+	// ThumbnailContentType is the type of all thumbnails.
+	ThumbnailContentType = "image/png"
+
+	// ImagePixelsMax is the maximal number of pixels in a decoded image. It
+	// protects the server from images which are small in a compressed form
+	// but take a lot of memory when decoded.
+	ImagePixelsMax = 50_000_000
+)
+
+// MakeThumbnail makes a thumbnail of an image. The image is scaled down to
+// fit into a square with the specified side keeping its proportions, small
+// images are not enlarged. Supported formats of images are GIF, JPEG and
+// PNG. Thumbnail is encoded as PNG.
+func MakeThumbnail(data []byte, size int) (thumbnail []byte, err error) {
+	if size <= 0 {
+		return nil, errors.New(ErrThumbnailSizeIsNotValid)
+	}
+
+	var cfg image.Config
+	cfg, _, err = image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	if (cfg.Width <= 0) || (cfg.Height <= 0) ||
+		(cfg.Width > ImagePixelsMax/cfg.Height) {
+		return nil, errors.New(ErrImageIsTooLarge)
+	}
+
+	var img image.Image
+	img, _, err = image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	w, h := thumbnailDimensions(img.Bounds().Dx(), img.Bounds().Dy(), size)
+
+	var buf bytes.Buffer
+	err = png.Encode(&buf, scaleDown(img, w, h))
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// thumbnailDimensions calculates dimensions of a thumbnail. The longest side
+// of an image becomes equal to the specified size, the other side is scaled
+// proportionally and is never shorter than one pixel.
+func thumbnailDimensions(width int, height int, size int) (w int, h int) {
+	if (width <= size) && (height <= size) {
+		return width, height
+	}
+
+	if width >= height {
+		w = size
+		h = height * size / width
+	} else {
+		h = size
+		w = width * size / height
+	}
+
+	if w < 1 {
+		w = 1
+	}
+	if h < 1 {
+		h = 1
+	}
+
+	return w, h
+}
+
+// scaleDown scales an image down by averaging all the source pixels covered
+// by each pixel of the result.
+func scaleDown(src image.Image, w int, h int) (dst *image.RGBA) {
+	sb := src.Bounds()
+	dst = image.NewRGBA(image.Rect(0, 0, w, h))
+
+	var x0, x1, y0, y1 int
+	var r, g, b, a, n uint64
+	for y := 0; y < h; y++ {
+		y0 = sb.Min.Y + y*sb.Dy()/h
+		y1 = sb.Min.Y + (y+1)*sb.Dy()/h
+		if y1 <= y0 {
+			y1 = y0 + 1
+		}
+
+		for x := 0; x < w; x++ {
+			x0 = sb.Min.X + x*sb.Dx()/w
+			x1 = sb.Min.X + (x+1)*sb.Dx()/w
+			if x1 <= x0 {
+				x1 = x0 + 1
+			}
+
+			r, g, b, a, n = 0, 0, 0, 0, 0
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					cr, cg, cb, ca := src.At(sx, sy).RGBA()
+					r += uint64(cr)
+					g += uint64(cg)
+					b += uint64(cb)
+					a += uint64(ca)
+					n++
+				}
+			}
+
+			dst.SetRGBA(x, y, color.RGBA{
+				R: uint8((r / n) >> 8),
+				G: uint8((g / n) >> 8),
+				B: uint8((b / n) >> 8),
+				A: uint8((a / n) >> 8),
+			})
+		}
+	}
+
+	return dst
+}
@@ -10,33 +10,42 @@ const (
 	FuncPing = cc.FuncPing
 
 	// Section.
-	FuncAddSection          = "AddSection"
-	FuncChangeSectionName   = "ChangeSectionName"
-	FuncChangeSectionParent = "ChangeSectionParent"
-	FuncGetSection          = "GetSection"
-	FuncMoveSectionUp       = "MoveSectionUp"
-	FuncMoveSectionDown     = "MoveSectionDown"
-	FuncDeleteSection       = "DeleteSection"
+	FuncAddSection           = "AddSection"
+	FuncChangeSectionName    = "ChangeSectionName"
+	FuncChangeSectionParent  = "ChangeSectionParent"
+	FuncChangeSectionPrivacy = "ChangeSectionPrivacy"
+	FuncGetSection           = "GetSection"
+	FuncMoveSectionUp        = "MoveSectionUp"
+	FuncMoveSectionDown      = "MoveSectionDown"
+	FuncDeleteSection        = "DeleteSection"
+	FuncSectionExistsS       = "SectionExistsS"
 
 	// Forum.
-	FuncAddForum           = "AddForum"
-	FuncChangeForumName    = "ChangeForumName"
-	FuncChangeForumSection = "ChangeForumSection"
-	FuncGetForum           = "GetForum"
-	FuncMoveForumUp        = "MoveForumUp"
-	FuncMoveForumDown      = "MoveForumDown"
-	FuncDeleteForum        = "DeleteForum"
+	FuncAddForum            = "AddForum"
+	FuncChangeForumName     = "ChangeForumName"
+	FuncChangeForumSection  = "ChangeForumSection"
+	FuncChangeForumReadOnly = "ChangeForumReadOnly"
+	FuncGetForum            = "GetForum"
+	FuncMoveForumUp         = "MoveForumUp"
+	FuncMoveForumDown       = "MoveForumDown"
+	FuncDeleteForum         = "DeleteForum"
+	FuncForumExistsS        = "ForumExistsS"
 
 	// Thread.
-	FuncAddThread           = "AddThread"
-	FuncChangeThreadName    = "ChangeThreadName"
-	FuncChangeThreadForum   = "ChangeThreadForum"
-	FuncGetThread           = "GetThread"
-	FuncGetThreadNamesByIds = "GetThreadNamesByIds"
-	FuncMoveThreadUp        = "MoveThreadUp"
-	FuncMoveThreadDown      = "MoveThreadDown"
-	FuncDeleteThread        = "DeleteThread"
-	FuncThreadExistsS       = "ThreadExistsS"
+	FuncAddThread                = "AddThread"
+	FuncChangeThreadName         = "ChangeThreadName"
+	FuncChangeThreadForum        = "ChangeThreadForum"
+	FuncGetThread                = "GetThread"
+	FuncGetThreadNamesByIds      = "GetThreadNamesByIds"
+	FuncMoveThreadUp             = "MoveThreadUp"
+	FuncMoveThreadDown           = "MoveThreadDown"
+	FuncDeleteThread             = "DeleteThread"
+	FuncLockThread               = "LockThread"
+	FuncUnlockThread             = "UnlockThread"
+	FuncChangeThreadPinned       = "ChangeThreadPinned"
+	FuncChangeThreadAnnouncement = "ChangeThreadAnnouncement"
+	FuncThreadExistsS            = "ThreadExistsS"
+	FuncGetThreadLocationS       = "GetThreadLocationS"
 
 	// Message.
 	FuncAddMessage               = "AddMessage"
@@ -45,6 +54,9 @@ const (
 	FuncGetMessage               = "GetMessage"
 	FuncGetLatestMessageOfThread = "GetLatestMessageOfThread"
 	FuncDeleteMessage            = "DeleteMessage"
+	FuncListMessageRevisions     = "ListMessageRevisions"
+	FuncGetMessageRevision       = "GetMessageRevision"
+	FuncRestoreMessageRevision   = "RestoreMessageRevision"
 
 	// Composite objects.
 	FuncListThreadAndMessages       = "ListThreadAndMessages"
@@ -53,6 +65,48 @@ const (
 	FuncListForumAndThreadsOnPage   = "ListForumAndThreadsOnPage"
 	FuncListSectionsAndForums       = "ListSectionsAndForums"
 
+	// Search.
+	FuncSearchMessages = "SearchMessages"
+	FuncSearchThreads  = "SearchThreads"
+
+	// Private messages.
+	FuncStartConversation          = "StartConversation"
+	FuncSendPrivateMessage         = "SendPrivateMessage"
+	FuncListConversations          = "ListConversations"
+	FuncReadConversation           = "ReadConversation"
+	FuncDeleteConversation         = "DeleteConversation"
+	FuncCountUnreadPrivateMessages = "CountUnreadPrivateMessages"
+	FuncBlockUser                  = "BlockUser"
+	FuncUnblockUser                = "UnblockUser"
+	FuncListBlockedUsers           = "ListBlockedUsers"
+
+	// Polls.
+	FuncGetPoll        = "GetPoll"
+	FuncVoteInPoll     = "VoteInPoll"
+	FuncChangePollVote = "ChangePollVote"
+
+	// Reactions.
+	FuncListReactions         = "ListReactions"
+	FuncAddMessageReaction    = "AddMessageReaction"
+	FuncRemoveMessageReaction = "RemoveMessageReaction"
+	FuncGetUserReputation     = "GetUserReputation"
+
+	// Attachments.
+	FuncGetAttachment = "GetAttachment"
+
+	// Trash.
+	FuncListTrash        = "ListTrash"
+	FuncRestoreFromTrash = "RestoreFromTrash"
+
+	// Consistency.
+	FuncRepairDatabaseConsistency = "RepairDatabaseConsistency"
+
+	// Moderation.
+	FuncReportMessage           = "ReportMessage"
+	FuncListModerationQueue     = "ListModerationQueue"
+	FuncResolveMessageReports   = "ResolveMessageReports"
+	FuncListModerationDecisions = "ListModerationDecisions"
+
 	// Other.
 	FuncGetDKey            = "GetDKey"
 	FuncShowDiagnosticData = cc.FuncShowDiagnosticData
@@ -43,6 +43,36 @@ func (dbo *DatabaseObject) initTableNames() {
 		Forums:   dbo.prefixTableName(TableForums),
 		Threads:  dbo.prefixTableName(TableThreads),
 		Messages: dbo.prefixTableName(TableMessages),
+
+		MessageWords: dbo.prefixTableName(TableMessageWords),
+		ThreadWords:  dbo.prefixTableName(TableThreadWords),
+
+		MessageRevisions: dbo.prefixTableName(TableMessageRevisions),
+
+		Conversations:       dbo.prefixTableName(TableConversations),
+		ConversationMembers: dbo.prefixTableName(TableConversationMembers),
+		PrivateMessages:     dbo.prefixTableName(TablePrivateMessages),
+		UserBlocks:          dbo.prefixTableName(TableUserBlocks),
+
+		MessageReactions: dbo.prefixTableName(TableMessageReactions),
+		UserReputations:  dbo.prefixTableName(TableUserReputations),
+
+		Polls:       dbo.prefixTableName(TablePolls),
+		PollOptions: dbo.prefixTableName(TablePollOptions),
+		PollVoters:  dbo.prefixTableName(TablePollVoters),
+		PollVotes:   dbo.prefixTableName(TablePollVotes),
+
+		Attachments: dbo.prefixTableName(TableAttachments),
+		Blobs:       dbo.prefixTableName(TableBlobs),
+
+		Trash:           dbo.prefixTableName(TableTrash),
+		DeletedSections: dbo.prefixTableName(TableDeletedSections),
+		DeletedForums:   dbo.prefixTableName(TableDeletedForums),
+		DeletedThreads:  dbo.prefixTableName(TableDeletedThreads),
+		DeletedMessages: dbo.prefixTableName(TableDeletedMessages),
+
+		MessageReports:      dbo.prefixTableName(TableMessageReports),
+		ModerationDecisions: dbo.prefixTableName(TableModerationDecisions),
 	}
 }
 
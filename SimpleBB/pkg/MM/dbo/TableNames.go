@@ -5,6 +5,36 @@ const (
 	TableForums   = "Forums"
 	TableThreads  = "Threads"
 	TableMessages = "Messages"
+
+	TableMessageWords = "MessageWords"
+	TableThreadWords  = "ThreadWords"
+
+	TableMessageRevisions = "MessageRevisions"
+
+	TableConversations       = "Conversations"
+	TableConversationMembers = "ConversationMembers"
+	TablePrivateMessages     = "PrivateMessages"
+	TableUserBlocks          = "UserBlocks"
+
+	TableMessageReactions = "MessageReactions"
+	TableUserReputations  = "UserReputations"
+
+	TablePolls       = "Polls"
+	TablePollOptions = "PollOptions"
+	TablePollVoters  = "PollVoters"
+	TablePollVotes   = "PollVotes"
+
+	TableAttachments = "Attachments"
+	TableBlobs       = "Blobs"
+
+	TableTrash           = "Trash"
+	TableDeletedSections = "DeletedSections"
+	TableDeletedForums   = "DeletedForums"
+	TableDeletedThreads  = "DeletedThreads"
+	TableDeletedMessages = "DeletedMessages"
+
+	TableMessageReports      = "MessageReports"
+	TableModerationDecisions = "ModerationDecisions"
 )
 
 type TableNames struct {
@@ -12,4 +42,34 @@ type TableNames struct {
 	Forums   string
 	Threads  string
 	Messages string
+
+	MessageWords string
+	ThreadWords  string
+
+	MessageRevisions string
+
+	Conversations       string
+	ConversationMembers string
+	PrivateMessages     string
+	UserBlocks          string
+
+	MessageReactions string
+	UserReputations  string
+
+	Polls       string
+	PollOptions string
+	PollVoters  string
+	PollVotes   string
+
+	Attachments string
+	Blobs       string
+
+	Trash           string
+	DeletedSections string
+	DeletedForums   string
+	DeletedThreads  string
+	DeletedMessages string
+
+	MessageReports      string
+	ModerationDecisions string
 }
@@ -21,6 +21,49 @@ import (
 	ae "github.com/vault-thirteen/auxie/errors"
 )
 
+// ChangeUserReputation adds a delta to the reputation of a user. The
+// reputation is created when it does not exist.
+func (dbo *DatabaseObject) ChangeUserReputation(userId base2.Id, delta base2.Count) (err error) {
+	_, err = dbo.DatabaseObject.PreparedStatement(DbPsid_ChangeUserReputation).Exec(userId, delta)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (dbo *DatabaseObject) ClosePollByThreadId(threadId base2.Id) (err error) {
+	var result sql.Result
+	result, err = dbo.DatabaseObject.PreparedStatement(DbPsid_ClosePollByThreadId).Exec(threadId)
+	if err != nil {
+		return err
+	}
+
+	return dbo2.CheckRowsAffected(result, 1)
+}
+
+func (dbo *DatabaseObject) CountConversationMembers(conversationId base2.Id) (n base2.Count, err error) {
+	row := dbo.DatabaseObject.PreparedStatement(DbPsid_CountConversationMembers).QueryRow(conversationId)
+
+	n, err = cms.NewNonNullValueFromScannableSource[base2.Count](row)
+	if err != nil {
+		return dbo2.CountOnError, err
+	}
+
+	return n, nil
+}
+
+func (dbo *DatabaseObject) CountConversationsOfUser(userId base2.Id) (n base2.Count, err error) {
+	row := dbo.DatabaseObject.PreparedStatement(DbPsid_CountConversationsOfUser).QueryRow(userId)
+
+	n, err = cms.NewNonNullValueFromScannableSource[base2.Count](row)
+	if err != nil {
+		return dbo2.CountOnError, err
+	}
+
+	return n, nil
+}
+
 func (dbo *DatabaseObject) CountForumsById(forumId base2.Id) (n base2.Count, err error) {
 	row := dbo.DatabaseObject.PreparedStatement(DbPsid_CountForumsById).QueryRow(forumId)
 
@@ -43,6 +86,87 @@ func (dbo *DatabaseObject) CountMessagesById(messageId base2.Id) (n base2.Count,
 	return n, nil
 }
 
+func (dbo *DatabaseObject) CountMessagesFound(sf *mm.SearchFilter) (n base2.Count, err error) {
+	query, args := dbo.dbQuery_CountMessagesFound(sf)
+	row := dbo.DatabaseObject.DB().QueryRow(query, args...)
+
+	n, err = cms.NewNonNullValueFromScannableSource[base2.Count](row)
+	if err != nil {
+		return dbo2.CountOnError, err
+	}
+
+	return n, nil
+}
+
+func (dbo *DatabaseObject) CountModerationDecisions() (n base2.Count, err error) {
+	row := dbo.DatabaseObject.PreparedStatement(DbPsid_CountModerationDecisions).QueryRow()
+
+	n, err = cms.NewNonNullValueFromScannableSource[base2.Count](row)
+	if err != nil {
+		return dbo2.CountOnError, err
+	}
+
+	return n, nil
+}
+
+// CountOpenReportsOfUser counts reports of the user about the message which
+// are still open.
+func (dbo *DatabaseObject) CountOpenReportsOfUser(messageId base2.Id, userId base2.Id) (n base2.Count, err error) {
+	row := dbo.DatabaseObject.PreparedStatement(DbPsid_CountOpenReportsOfUser).QueryRow(messageId, userId)
+
+	n, err = cms.NewNonNullValueFromScannableSource[base2.Count](row)
+	if err != nil {
+		return dbo2.CountOnError, err
+	}
+
+	return n, nil
+}
+
+func (dbo *DatabaseObject) CountPollVoter(pollId base2.Id, userId base2.Id) (n base2.Count, err error) {
+	row := dbo.DatabaseObject.PreparedStatement(DbPsid_CountPollVoter).QueryRow(pollId, userId)
+
+	n, err = cms.NewNonNullValueFromScannableSource[base2.Count](row)
+	if err != nil {
+		return dbo2.CountOnError, err
+	}
+
+	return n, nil
+}
+
+func (dbo *DatabaseObject) CountPollVoters(pollId base2.Id) (n base2.Count, err error) {
+	row := dbo.DatabaseObject.PreparedStatement(DbPsid_CountPollVoters).QueryRow(pollId)
+
+	n, err = cms.NewNonNullValueFromScannableSource[base2.Count](row)
+	if err != nil {
+		return dbo2.CountOnError, err
+	}
+
+	return n, nil
+}
+
+func (dbo *DatabaseObject) CountPrivateMessages(conversationId base2.Id) (n base2.Count, err error) {
+	row := dbo.DatabaseObject.PreparedStatement(DbPsid_CountPrivateMessages).QueryRow(conversationId)
+
+	n, err = cms.NewNonNullValueFromScannableSource[base2.Count](row)
+	if err != nil {
+		return dbo2.CountOnError, err
+	}
+
+	return n, nil
+}
+
+// CountReportedMessages counts existing messages having open reports.
+func (dbo *DatabaseObject) CountReportedMessages() (n base2.Count, err error) {
+	row := dbo.DatabaseObject.PreparedStatement(DbPsid_CountReportedMessages).QueryRow()
+
+	n, err = cms.NewNonNullValueFromScannableSource[base2.Count](row)
+	if err != nil {
+		return dbo2.CountOnError, err
+	}
+
+	return n, nil
+}
+
 func (dbo *DatabaseObject) CountRootSections() (n base2.Count, err error) {
 	row := dbo.DatabaseObject.PreparedStatement(DbPsid_CountRootSections).QueryRow()
 
@@ -76,6 +200,90 @@ func (dbo *DatabaseObject) CountThreadsById(threadId base2.Id) (n base2.Count, e
 	return n, nil
 }
 
+func (dbo *DatabaseObject) CountThreadsFound(sf *mm.SearchFilter) (n base2.Count, err error) {
+	query, args := dbo.dbQuery_CountThreadsFound(sf)
+	row := dbo.DatabaseObject.DB().QueryRow(query, args...)
+
+	n, err = cms.NewNonNullValueFromScannableSource[base2.Count](row)
+	if err != nil {
+		return dbo2.CountOnError, err
+	}
+
+	return n, nil
+}
+
+func (dbo *DatabaseObject) CountTrashItems() (n base2.Count, err error) {
+	row := dbo.DatabaseObject.PreparedStatement(DbPsid_CountTrashItems).QueryRow()
+
+	n, err = cms.NewNonNullValueFromScannableSource[base2.Count](row)
+	if err != nil {
+		return dbo2.CountOnError, err
+	}
+
+	return n, nil
+}
+
+func (dbo *DatabaseObject) CountUnreadPrivateMessages(userId base2.Id) (n base2.Count, err error) {
+	row := dbo.DatabaseObject.PreparedStatement(DbPsid_CountUnreadPrivateMessages).QueryRow(userId)
+
+	n, err = cms.NewNonNullValueFromScannableSource[base2.Count](row)
+	if err != nil {
+		return dbo2.CountOnError, err
+	}
+
+	return n, nil
+}
+
+func (dbo *DatabaseObject) CountUserBlocks(userId base2.Id, blockedUserId base2.Id) (n base2.Count, err error) {
+	row := dbo.DatabaseObject.PreparedStatement(DbPsid_CountUserBlocks).QueryRow(userId, blockedUserId)
+
+	n, err = cms.NewNonNullValueFromScannableSource[base2.Count](row)
+	if err != nil {
+		return dbo2.CountOnError, err
+	}
+
+	return n, nil
+}
+
+func (dbo *DatabaseObject) DeleteAttachmentsByMessageId(messageId base2.Id) (err error) {
+	_, err = dbo.DatabaseObject.PreparedStatement(DbPsid_DeleteAttachmentsByMessageId).Exec(messageId)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (dbo *DatabaseObject) DeleteBlob(hash string) (err error) {
+	var result sql.Result
+	result, err = dbo.DatabaseObject.PreparedStatement(DbPsid_DeleteBlob).Exec(hash)
+	if err != nil {
+		return err
+	}
+
+	return dbo2.CheckRowsAffected(result, 1)
+}
+
+func (dbo *DatabaseObject) DeleteConversationById(conversationId base2.Id) (err error) {
+	var result sql.Result
+	result, err = dbo.DatabaseObject.PreparedStatement(DbPsid_DeleteConversationById).Exec(conversationId)
+	if err != nil {
+		return err
+	}
+
+	return dbo2.CheckRowsAffected(result, 1)
+}
+
+func (dbo *DatabaseObject) DeleteConversationMember(conversationId base2.Id, userId base2.Id) (err error) {
+	var result sql.Result
+	result, err = dbo.DatabaseObject.PreparedStatement(DbPsid_DeleteConversationMember).Exec(conversationId, userId)
+	if err != nil {
+		return err
+	}
+
+	return dbo2.CheckRowsAffected(result, 1)
+}
+
 func (dbo *DatabaseObject) DeleteForumById(forumId base2.Id) (err error) {
 	var result sql.Result
 	result, err = dbo.DatabaseObject.PreparedStatement(DbPsid_DeleteForumById).Exec(forumId)
@@ -86,6 +294,16 @@ func (dbo *DatabaseObject) DeleteForumById(forumId base2.Id) (err error) {
 	return dbo2.CheckRowsAffected(result, 1)
 }
 
+func (dbo *DatabaseObject) DeleteForumFromTrash(forumId base2.Id) (err error) {
+	var result sql.Result
+	result, err = dbo.DatabaseObject.PreparedStatement(DbPsid_DeleteForumFromTrash).Exec(forumId)
+	if err != nil {
+		return err
+	}
+
+	return dbo2.CheckRowsAffected(result, 1)
+}
+
 func (dbo *DatabaseObject) DeleteMessageById(messageId base2.Id) (err error) {
 	var result sql.Result
 	result, err = dbo.DatabaseObject.PreparedStatement(DbPsid_DeleteMessageById).Exec(messageId)
@@ -96,9 +314,9 @@ func (dbo *DatabaseObject) DeleteMessageById(messageId base2.Id) (err error) {
 	return dbo2.CheckRowsAffected(result, 1)
 }
 
-func (dbo *DatabaseObject) DeleteSectionById(sectionId base2.Id) (err error) {
+func (dbo *DatabaseObject) DeleteMessageFromTrash(messageId base2.Id) (err error) {
 	var result sql.Result
-	result, err = dbo.DatabaseObject.PreparedStatement(DbPsid_DeleteSectionById).Exec(sectionId)
+	result, err = dbo.DatabaseObject.PreparedStatement(DbPsid_DeleteMessageFromTrash).Exec(messageId)
 	if err != nil {
 		return err
 	}
@@ -106,9 +324,9 @@ func (dbo *DatabaseObject) DeleteSectionById(sectionId base2.Id) (err error) {
 	return dbo2.CheckRowsAffected(result, 1)
 }
 
-func (dbo *DatabaseObject) DeleteThreadById(threadId base2.Id) (err error) {
+func (dbo *DatabaseObject) DeleteMessageReaction(messageId base2.Id, userId base2.Id, emoji string) (err error) {
 	var result sql.Result
-	result, err = dbo.DatabaseObject.PreparedStatement(DbPsid_DeleteThreadById).Exec(threadId)
+	result, err = dbo.DatabaseObject.PreparedStatement(DbPsid_DeleteMessageReaction).Exec(messageId, userId, emoji)
 	if err != nil {
 		return err
 	}
@@ -116,182 +334,1157 @@ func (dbo *DatabaseObject) DeleteThreadById(threadId base2.Id) (err error) {
 	return dbo2.CheckRowsAffected(result, 1)
 }
 
-func (dbo *DatabaseObject) GetForumById(forumId base2.Id) (forum derived2.IForum, err error) {
-	row := dbo.DatabaseObject.PreparedStatement(DbPsid_GetForumById).QueryRow(forumId)
-
-	forum, err = complex2.NewForumFromScannableSource(row)
+func (dbo *DatabaseObject) DeleteMessageReactionsByMessageId(messageId base2.Id) (err error) {
+	_, err = dbo.DatabaseObject.PreparedStatement(DbPsid_DeleteMessageReactions).Exec(messageId)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	return forum, nil
+	return nil
 }
 
-func (dbo *DatabaseObject) GetForumSectionById(forumId base2.Id) (sectionId base2.Id, err error) {
-	row := dbo.DatabaseObject.PreparedStatement(DbPsid_GetForumSectionById).QueryRow(forumId)
-
-	sectionId, err = cms.NewNonNullValueFromScannableSource[base2.Id](row)
+func (dbo *DatabaseObject) DeleteMessageRevisionsByMessageId(messageId base2.Id) (err error) {
+	_, err = dbo.DatabaseObject.PreparedStatement(DbPsid_DeleteMessageRevisions).Exec(messageId)
 	if err != nil {
-		return dbo2.IdOnError, err
+		return err
 	}
 
-	return sectionId, nil
+	return nil
 }
 
-func (dbo *DatabaseObject) GetForumThreadsById(forumId base2.Id) (threads *ul.UidList, err error) {
-	threads = ul.New()
-	err = dbo.DatabaseObject.PreparedStatement(DbPsid_GetForumThreadsById).QueryRow(forumId).Scan(threads)
+func (dbo *DatabaseObject) DeleteMessageWordsById(messageId base2.Id) (err error) {
+	_, err = dbo.DatabaseObject.PreparedStatement(DbPsid_DeleteMessageWordsById).Exec(messageId)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	return threads, nil
+	return nil
 }
 
-func (dbo *DatabaseObject) GetMessageById(messageId base2.Id) (message derived2.IMessage, err error) {
-	row := dbo.DatabaseObject.PreparedStatement(DbPsid_GetMessageById).QueryRow(messageId)
-
-	message, err = m.NewMessageFromScannableSource(row)
+// DeleteOpenReportsByMessageId deletes open reports about a message. Resolved
+// reports are kept together with moderation decisions.
+func (dbo *DatabaseObject) DeleteOpenReportsByMessageId(messageId base2.Id) (err error) {
+	_, err = dbo.DatabaseObject.PreparedStatement(DbPsid_DeleteOpenReportsByMessageId).Exec(messageId)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	return message, nil
+	return nil
 }
 
-func (dbo *DatabaseObject) GetMessageCreatorAndTimeById(messageId base2.Id) (creatorUserId base2.Id, ToC time.Time, ToE *time.Time, err error) {
-	// N.B.: ToC can not be null, but ToE can be null !
-	err = dbo.DatabaseObject.PreparedStatement(DbPsid_GetMessageCreatorAndTimeById).QueryRow(messageId).Scan(&creatorUserId, &ToC, &ToE)
+func (dbo *DatabaseObject) DeletePollById(pollId base2.Id) (err error) {
+	var result sql.Result
+	result, err = dbo.DatabaseObject.PreparedStatement(DbPsid_DeletePollById).Exec(pollId)
 	if err != nil {
-		return dbo2.IdOnError, time.Time{}, nil, err
+		return err
 	}
 
-	return creatorUserId, ToC, ToE, nil
+	return dbo2.CheckRowsAffected(result, 1)
 }
 
-func (dbo *DatabaseObject) GetMessageThreadById(messageId base2.Id) (threadId base2.Id, err error) {
-	row := dbo.DatabaseObject.PreparedStatement(DbPsid_GetMessageThreadById).QueryRow(messageId)
+func (dbo *DatabaseObject) DeletePollOptions(pollId base2.Id) (err error) {
+	_, err = dbo.DatabaseObject.PreparedStatement(DbPsid_DeletePollOptions).Exec(pollId)
+	if err != nil {
+		return err
+	}
 
-	threadId, err = cms.NewNonNullValueFromScannableSource[base2.Id](row)
+	return nil
+}
+
+func (dbo *DatabaseObject) DeletePollVoters(pollId base2.Id) (err error) {
+	_, err = dbo.DatabaseObject.PreparedStatement(DbPsid_DeletePollVoters).Exec(pollId)
 	if err != nil {
-		return dbo2.IdOnError, err
+		return err
 	}
 
-	return threadId, nil
+	return nil
 }
 
-func (dbo *DatabaseObject) GetSectionById(sectionId base2.Id) (section derived2.ISection, err error) {
-	row := dbo.DatabaseObject.PreparedStatement(DbPsid_GetSectionById).QueryRow(sectionId)
+func (dbo *DatabaseObject) DeletePollVotes(pollId base2.Id) (err error) {
+	_, err = dbo.DatabaseObject.PreparedStatement(DbPsid_DeletePollVotes).Exec(pollId)
+	if err != nil {
+		return err
+	}
 
-	section, err = s.NewSectionFromScannableSource(row)
+	return nil
+}
+
+func (dbo *DatabaseObject) DeletePollVotesOfUser(pollId base2.Id, userId base2.Id) (err error) {
+	_, err = dbo.DatabaseObject.PreparedStatement(DbPsid_DeletePollVotesOfUser).Exec(pollId, userId)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	return section, nil
+	return nil
 }
 
-func (dbo *DatabaseObject) GetSectionChildTypeById(sectionId base2.Id) (childType derived1.ISectionChildType, err error) {
-	row := dbo.DatabaseObject.PreparedStatement(DbPsid_GetSectionChildTypeById).QueryRow(sectionId)
+func (dbo *DatabaseObject) DeletePrivateMessagesByConversationId(conversationId base2.Id) (err error) {
+	_, err = dbo.DatabaseObject.PreparedStatement(DbPsid_DeletePrivateMessages).Exec(conversationId)
+	if err != nil {
+		return err
+	}
 
-	childType, err = cms.NewNonNullValueFromScannableSource[derived1.ISectionChildType](row)
+	return nil
+}
+
+func (dbo *DatabaseObject) DeleteSectionById(sectionId base2.Id) (err error) {
+	var result sql.Result
+	result, err = dbo.DatabaseObject.PreparedStatement(DbPsid_DeleteSectionById).Exec(sectionId)
 	if err != nil {
-		return childType, err
+		return err
 	}
 
-	return childType, nil
+	return dbo2.CheckRowsAffected(result, 1)
 }
 
-func (dbo *DatabaseObject) GetSectionChildrenById(sectionId base2.Id) (children *ul.UidList, err error) {
-	children = ul.New()
-	err = dbo.DatabaseObject.PreparedStatement(DbPsid_GetSectionChildrenById).QueryRow(sectionId).Scan(children)
+func (dbo *DatabaseObject) DeleteSectionFromTrash(sectionId base2.Id) (err error) {
+	var result sql.Result
+	result, err = dbo.DatabaseObject.PreparedStatement(DbPsid_DeleteSectionFromTrash).Exec(sectionId)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	return children, nil
+	return dbo2.CheckRowsAffected(result, 1)
 }
 
-func (dbo *DatabaseObject) GetSectionParentById(sectionId base2.Id) (parent *base2.Id, err error) {
-	row := dbo.DatabaseObject.PreparedStatement(DbPsid_GetSectionParentById).QueryRow(sectionId)
-	return cms.NewValueFromScannableSource[base2.Id](row)
+func (dbo *DatabaseObject) DeleteThreadById(threadId base2.Id) (err error) {
+	var result sql.Result
+	result, err = dbo.DatabaseObject.PreparedStatement(DbPsid_DeleteThreadById).Exec(threadId)
+	if err != nil {
+		return err
+	}
+
+	return dbo2.CheckRowsAffected(result, 1)
+}
+
+func (dbo *DatabaseObject) DeleteThreadFromTrash(threadId base2.Id) (err error) {
+	var result sql.Result
+	result, err = dbo.DatabaseObject.PreparedStatement(DbPsid_DeleteThreadFromTrash).Exec(threadId)
+	if err != nil {
+		return err
+	}
+
+	return dbo2.CheckRowsAffected(result, 1)
+}
+
+func (dbo *DatabaseObject) DeleteThreadWordsById(threadId base2.Id) (err error) {
+	_, err = dbo.DatabaseObject.PreparedStatement(DbPsid_DeleteThreadWordsById).Exec(threadId)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (dbo *DatabaseObject) DeleteTrashItemById(trashItemId base2.Id) (err error) {
+	var result sql.Result
+	result, err = dbo.DatabaseObject.PreparedStatement(DbPsid_DeleteTrashItemById).Exec(trashItemId)
+	if err != nil {
+		return err
+	}
+
+	return dbo2.CheckRowsAffected(result, 1)
+}
+
+// DeleteUserBlock unblocks a user. Unblocking of a user who is not blocked is
+// not an error.
+func (dbo *DatabaseObject) DeleteUserBlock(userId base2.Id, blockedUserId base2.Id) (err error) {
+	_, err = dbo.DatabaseObject.PreparedStatement(DbPsid_DeleteUserBlock).Exec(userId, blockedUserId)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetAttachmentById reads an attachment. Attachments of deleted messages are
+// not returned.
+func (dbo *DatabaseObject) GetAttachmentById(attachmentId base2.Id) (attachment *mm.Attachment, err error) {
+	row := dbo.DatabaseObject.PreparedStatement(DbPsid_GetAttachmentById).QueryRow(attachmentId)
+
+	attachment, err = mm.NewAttachmentFromScannableSource(row)
+	if err != nil {
+		return nil, err
+	}
+
+	return attachment, nil
+}
+
+// GetConversationById reads a conversation on behalf of its member. Nothing
+// is returned when the user is not a member of the conversation.
+func (dbo *DatabaseObject) GetConversationById(userId base2.Id, conversationId base2.Id) (conversation *mm.Conversation, err error) {
+	row := dbo.DatabaseObject.PreparedStatement(DbPsid_GetConversationById).QueryRow(userId, conversationId)
+
+	conversation, err = mm.NewConversationFromScannableSource(row)
+	if err != nil {
+		return nil, err
+	}
+
+	return conversation, nil
+}
+
+func (dbo *DatabaseObject) GetForumById(forumId base2.Id) (forum derived2.IForum, err error) {
+	row := dbo.DatabaseObject.PreparedStatement(DbPsid_GetForumById).QueryRow(forumId)
+
+	forum, err = complex2.NewForumFromScannableSource(row)
+	if err != nil {
+		return nil, err
+	}
+
+	return forum, nil
+}
+
+func (dbo *DatabaseObject) GetForumSectionById(forumId base2.Id) (sectionId base2.Id, err error) {
+	row := dbo.DatabaseObject.PreparedStatement(DbPsid_GetForumSectionById).QueryRow(forumId)
+
+	sectionId, err = cms.NewNonNullValueFromScannableSource[base2.Id](row)
+	if err != nil {
+		return dbo2.IdOnError, err
+	}
+
+	return sectionId, nil
+}
+
+func (dbo *DatabaseObject) GetForumThreadsById(forumId base2.Id) (threads *ul.UidList, err error) {
+	threads = ul.New()
+	err = dbo.DatabaseObject.PreparedStatement(DbPsid_GetForumThreadsById).QueryRow(forumId).Scan(threads)
+	if err != nil {
+		return nil, err
+	}
+
+	return threads, nil
+}
+
+func (dbo *DatabaseObject) GetMessageById(messageId base2.Id) (message derived2.IMessage, err error) {
+	row := dbo.DatabaseObject.PreparedStatement(DbPsid_GetMessageById).QueryRow(messageId)
+
+	message, err = m.NewMessageFromScannableSource(row)
+	if err != nil {
+		return nil, err
+	}
+
+	return message, nil
+}
+
+func (dbo *DatabaseObject) GetMessageCreatorAndTimeById(messageId base2.Id) (creatorUserId base2.Id, ToC time.Time, ToE *time.Time, err error) {
+	// N.B.: ToC can not be null, but ToE can be null !
+	err = dbo.DatabaseObject.PreparedStatement(DbPsid_GetMessageCreatorAndTimeById).QueryRow(messageId).Scan(&creatorUserId, &ToC, &ToE)
+	if err != nil {
+		return dbo2.IdOnError, time.Time{}, nil, err
+	}
+
+	return creatorUserId, ToC, ToE, nil
+}
+
+func (dbo *DatabaseObject) GetMessageReaction(messageId base2.Id, userId base2.Id, emoji string) (reaction *mm.MessageReaction, err error) {
+	row := dbo.DatabaseObject.PreparedStatement(DbPsid_GetMessageReaction).QueryRow(messageId, userId, emoji)
+
+	reaction, err = mm.NewMessageReactionFromScannableSource(row)
+	if err != nil {
+		return nil, err
+	}
+
+	return reaction, nil
+}
+
+func (dbo *DatabaseObject) GetMessageRevisionById(revisionId base2.Id) (revision *mm.MessageRevision, err error) {
+	row := dbo.DatabaseObject.PreparedStatement(DbPsid_GetMessageRevisionById).QueryRow(revisionId)
+
+	revision, err = mm.NewMessageRevisionFromScannableSource(row)
+	if err != nil {
+		return nil, err
+	}
+
+	return revision, nil
+}
+
+func (dbo *DatabaseObject) GetMessageThreadById(messageId base2.Id) (threadId base2.Id, err error) {
+	row := dbo.DatabaseObject.PreparedStatement(DbPsid_GetMessageThreadById).QueryRow(messageId)
+
+	threadId, err = cms.NewNonNullValueFromScannableSource[base2.Id](row)
+	if err != nil {
+		return dbo2.IdOnError, err
+	}
+
+	return threadId, nil
+}
+
+// GetPollByThreadId reads a poll of a thread without its options. Null is
+// returned when the thread has no poll.
+func (dbo *DatabaseObject) GetPollByThreadId(threadId base2.Id) (poll *mm.Poll, err error) {
+	row := dbo.DatabaseObject.PreparedStatement(DbPsid_GetPollByThreadId).QueryRow(threadId)
+
+	poll, err = mm.NewPollFromScannableSource(row)
+	if err != nil {
+		return nil, err
+	}
+
+	return poll, nil
+}
+
+func (dbo *DatabaseObject) GetSectionById(sectionId base2.Id) (section derived2.ISection, err error) {
+	row := dbo.DatabaseObject.PreparedStatement(DbPsid_GetSectionById).QueryRow(sectionId)
+
+	section, err = s.NewSectionFromScannableSource(row)
+	if err != nil {
+		return nil, err
+	}
+
+	return section, nil
+}
+
+func (dbo *DatabaseObject) GetSectionChildTypeById(sectionId base2.Id) (childType derived1.ISectionChildType, err error) {
+	row := dbo.DatabaseObject.PreparedStatement(DbPsid_GetSectionChildTypeById).QueryRow(sectionId)
+
+	childType, err = cms.NewNonNullValueFromScannableSource[derived1.ISectionChildType](row)
+	if err != nil {
+		return childType, err
+	}
+
+	return childType, nil
+}
+
+func (dbo *DatabaseObject) GetSectionChildrenById(sectionId base2.Id) (children *ul.UidList, err error) {
+	children = ul.New()
+	err = dbo.DatabaseObject.PreparedStatement(DbPsid_GetSectionChildrenById).QueryRow(sectionId).Scan(children)
+	if err != nil {
+		return nil, err
+	}
+
+	return children, nil
+}
+
+func (dbo *DatabaseObject) GetSectionParentById(sectionId base2.Id) (parent *base2.Id, err error) {
+	row := dbo.DatabaseObject.PreparedStatement(DbPsid_GetSectionParentById).QueryRow(sectionId)
+	return cms.NewValueFromScannableSource[base2.Id](row)
+}
+
+func (dbo *DatabaseObject) GetThreadById(threadId base2.Id) (thread derived2.IThread, err error) {
+	row := dbo.DatabaseObject.PreparedStatement(DbPsid_GetThreadByIdM).QueryRow(threadId)
+
+	thread, err = t.NewThreadFromScannableSource(row)
+	if err != nil {
+		return nil, err
+	}
+
+	return thread, nil
+}
+
+func (dbo *DatabaseObject) GetThreadForumById(threadId base2.Id) (forumId base2.Id, err error) {
+	row := dbo.DatabaseObject.PreparedStatement(DbPsid_GetThreadForumById).QueryRow(threadId)
+
+	forumId, err = cms.NewNonNullValueFromScannableSource[base2.Id](row)
+	if err != nil {
+		return dbo2.IdOnError, err
+	}
+
+	return forumId, nil
+}
+
+func (dbo *DatabaseObject) GetThreadMessagesById(threadId base2.Id) (messages *ul.UidList, err error) {
+	messages = ul.New()
+	err = dbo.DatabaseObject.PreparedStatement(DbPsid_GetThreadMessagesById).QueryRow(threadId).Scan(messages)
+	if err != nil {
+		return nil, err
+	}
+
+	return messages, nil
+}
+
+func (dbo *DatabaseObject) GetTrashItemById(trashItemId base2.Id) (ti *mm.TrashItem, err error) {
+	row := dbo.DatabaseObject.PreparedStatement(DbPsid_GetTrashItemById).QueryRow(trashItemId)
+
+	ti, err = mm.NewTrashItemFromScannableSource(row)
+	if err != nil {
+		return nil, err
+	}
+
+	return ti, nil
+}
+
+// GetUserReputation reads the reputation of a user. Users who have not
+// received any reactions have zero reputation.
+func (dbo *DatabaseObject) GetUserReputation(userId base2.Id) (reputation base2.Count, err error) {
+	row := dbo.DatabaseObject.PreparedStatement(DbPsid_GetUserReputation).QueryRow(userId)
+
+	reputation, err = cms.NewNonNullValueFromScannableSource[base2.Count](row)
+	if err != nil {
+		return 0, err
+	}
+
+	return reputation, nil
+}
+
+func (dbo *DatabaseObject) InsertAttachment(messageId base2.Id, name base2.Text, contentType string, size base2.Count, blobHash string, thumbnailHash *string) (lastInsertedId base2.Id, err error) {
+	var result sql.Result
+	result, err = dbo.DatabaseObject.PreparedStatement(DbPsid_InsertAttachment).Exec(messageId, name, contentType, size, blobHash, thumbnailHash)
+	if err != nil {
+		return dbo2.LastInsertedIdOnError, err
+	}
+
+	return dbo2.CheckRowsAffectedAndGetLastInsertedId(result, 1)
+}
+
+// InsertBlob registers a blob. Registration of an existing blob does
+// nothing.
+func (dbo *DatabaseObject) InsertBlob(hash string, size base2.Count) (err error) {
+	_, err = dbo.DatabaseObject.PreparedStatement(DbPsid_InsertBlob).Exec(hash, size)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (dbo *DatabaseObject) InsertConversationMember(conversationId base2.Id, userId base2.Id) (err error) {
+	var result sql.Result
+	result, err = dbo.DatabaseObject.PreparedStatement(DbPsid_InsertConversationMember).Exec(conversationId, userId)
+	if err != nil {
+		return err
+	}
+
+	return dbo2.CheckRowsAffected(result, 1)
+}
+
+func (dbo *DatabaseObject) InsertMessageReaction(messageId base2.Id, userId base2.Id, emoji string, authorUserId base2.Id, weight base2.Count) (err error) {
+	var result sql.Result
+	result, err = dbo.DatabaseObject.PreparedStatement(DbPsid_InsertMessageReaction).Exec(messageId, userId, emoji, authorUserId, weight)
+	if err != nil {
+		return err
+	}
+
+	return dbo2.CheckRowsAffected(result, 1)
+}
+
+func (dbo *DatabaseObject) InsertMessageReport(mr *mm.MessageReport) (err error) {
+	var result sql.Result
+	result, err = dbo.DatabaseObject.PreparedStatement(DbPsid_InsertMessageReport).Exec(mr.MessageId, mr.ThreadId, mr.AuthorUserId, mr.ReporterUserId, mr.Reason, mr.Comment)
+	if err != nil {
+		return err
+	}
+
+	return dbo2.CheckRowsAffected(result, 1)
+}
+
+func (dbo *DatabaseObject) InsertMessageRevision(messageId base2.Id, text base2.Text, textChecksum []byte, editorUserId base2.Id, editorTime time.Time) (lastInsertedId base2.Id, err error) {
+	var result sql.Result
+	result, err = dbo.DatabaseObject.PreparedStatement(DbPsid_InsertMessageRevision).Exec(messageId, text, textChecksum, editorUserId, editorTime)
+	if err != nil {
+		return dbo2.LastInsertedIdOnError, err
+	}
+
+	return dbo2.CheckRowsAffectedAndGetLastInsertedId(result, 1)
+}
+
+func (dbo *DatabaseObject) InsertMessageWord(messageId base2.Id, word string, frequency base2.Count) (err error) {
+	_, err = dbo.DatabaseObject.PreparedStatement(DbPsid_InsertMessageWord).Exec(word, messageId, frequency)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (dbo *DatabaseObject) InsertModerationDecision(md *mm.ModerationDecision) (lastInsertedId base2.Id, err error) {
+	var result sql.Result
+	result, err = dbo.DatabaseObject.PreparedStatement(DbPsid_InsertModerationDecision).Exec(md.MessageId, md.ThreadId, md.AuthorUserId, md.Action, md.Comment, md.ReportsCount, md.ModeratorUserId)
+	if err != nil {
+		return dbo2.LastInsertedIdOnError, err
+	}
+
+	return dbo2.CheckRowsAffectedAndGetLastInsertedId(result, 1)
+}
+
+func (dbo *DatabaseObject) InsertNewConversation(subject cm.Name, creatorUserId base2.Id) (lastInsertedId base2.Id, err error) {
+	var result sql.Result
+	result, err = dbo.DatabaseObject.PreparedStatement(DbPsid_InsertNewConversation).Exec(subject, creatorUserId)
+	if err != nil {
+		return dbo2.LastInsertedIdOnError, err
+	}
+
+	return dbo2.CheckRowsAffectedAndGetLastInsertedId(result, 1)
+}
+
+func (dbo *DatabaseObject) InsertNewForum(sectionId base2.Id, name cm.Name, creatorUserId base2.Id) (lastInsertedId base2.Id, err error) {
+	var result sql.Result
+	result, err = dbo.DatabaseObject.PreparedStatement(DbPsid_InsertNewForum).Exec(sectionId, name, creatorUserId)
+	if err != nil {
+		return dbo2.LastInsertedIdOnError, err
+	}
+
+	return dbo2.CheckRowsAffectedAndGetLastInsertedId(result, 1)
+}
+
+func (dbo *DatabaseObject) InsertNewMessage(parentThread base2.Id, messageText base2.Text, textChecksum []byte, textHtml base2.Text, creatorUserId base2.Id) (lastInsertedId base2.Id, err error) {
+	var result sql.Result
+	result, err = dbo.DatabaseObject.PreparedStatement(DbPsid_InsertNewMessage).Exec(parentThread, messageText, textChecksum, textHtml, creatorUserId)
+	if err != nil {
+		return dbo2.LastInsertedIdOnError, err
+	}
+
+	return dbo2.CheckRowsAffectedAndGetLastInsertedId(result, 1)
+}
+
+func (dbo *DatabaseObject) InsertNewPrivateMessage(conversationId base2.Id, text base2.Text, creatorUserId base2.Id) (lastInsertedId base2.Id, err error) {
+	var result sql.Result
+	result, err = dbo.DatabaseObject.PreparedStatement(DbPsid_InsertNewPrivateMessage).Exec(conversationId, text, creatorUserId)
+	if err != nil {
+		return dbo2.LastInsertedIdOnError, err
+	}
+
+	return dbo2.CheckRowsAffectedAndGetLastInsertedId(result, 1)
+}
+
+func (dbo *DatabaseObject) InsertNewSection(parent *base2.Id, name cm.Name, creatorUserId base2.Id) (lastInsertedId base2.Id, err error) {
+	var result sql.Result
+	result, err = dbo.DatabaseObject.PreparedStatement(DbPsid_InsertNewSection).Exec(parent, name, creatorUserId)
+	if err != nil {
+		return dbo2.LastInsertedIdOnError, err
+	}
+
+	return dbo2.CheckRowsAffectedAndGetLastInsertedId(result, 1)
+}
+
+func (dbo *DatabaseObject) InsertNewThread(parentForum base2.Id, threadName cm.Name, creatorUserId base2.Id) (lastInsertedId base2.Id, err error) {
+	var result sql.Result
+	result, err = dbo.DatabaseObject.PreparedStatement(DbPsid_InsertNewThread).Exec(parentForum, threadName, creatorUserId)
+	if err != nil {
+		return dbo2.LastInsertedIdOnError, err
+	}
+
+	return dbo2.CheckRowsAffectedAndGetLastInsertedId(result, 1)
+}
+
+func (dbo *DatabaseObject) InsertPoll(threadId base2.Id, question base2.Text, isMultipleChoice base2.Flag, areResultsHidden base2.Flag, closingTime *time.Time) (lastInsertedId base2.Id, err error) {
+	var result sql.Result
+	result, err = dbo.DatabaseObject.PreparedStatement(DbPsid_InsertPoll).Exec(threadId, question, isMultipleChoice, areResultsHidden, closingTime)
+	if err != nil {
+		return dbo2.LastInsertedIdOnError, err
+	}
+
+	return dbo2.CheckRowsAffectedAndGetLastInsertedId(result, 1)
+}
+
+func (dbo *DatabaseObject) InsertPollOption(pollId base2.Id, text base2.Text) (lastInsertedId base2.Id, err error) {
+	var result sql.Result
+	result, err = dbo.DatabaseObject.PreparedStatement(DbPsid_InsertPollOption).Exec(pollId, text)
+	if err != nil {
+		return dbo2.LastInsertedIdOnError, err
+	}
+
+	return dbo2.CheckRowsAffectedAndGetLastInsertedId(result, 1)
+}
+
+func (dbo *DatabaseObject) InsertPollVote(pollId base2.Id, userId base2.Id, optionId base2.Id) (err error) {
+	var result sql.Result
+	result, err = dbo.DatabaseObject.PreparedStatement(DbPsid_InsertPollVote).Exec(pollId, userId, optionId)
+	if err != nil {
+		return err
+	}
+
+	return dbo2.CheckRowsAffected(result, 1)
+}
+
+// InsertPollVoter registers a voter of a poll. The database does not allow
+// to register a voter twice.
+func (dbo *DatabaseObject) InsertPollVoter(pollId base2.Id, userId base2.Id) (err error) {
+	var result sql.Result
+	result, err = dbo.DatabaseObject.PreparedStatement(DbPsid_InsertPollVoter).Exec(pollId, userId)
+	if err != nil {
+		return err
+	}
+
+	return dbo2.CheckRowsAffected(result, 1)
+}
+
+func (dbo *DatabaseObject) InsertThreadWord(threadId base2.Id, word string, frequency base2.Count) (err error) {
+	_, err = dbo.DatabaseObject.PreparedStatement(DbPsid_InsertThreadWord).Exec(word, threadId, frequency)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (dbo *DatabaseObject) InsertTrashItem(ti *mm.TrashItem) (err error) {
+	var result sql.Result
+	result, err = dbo.DatabaseObject.PreparedStatement(DbPsid_InsertTrashItem).Exec(ti.ObjectType, ti.ObjectId, ti.Name, ti.ParentId, ti.Position, ti.PreviousId, ti.DeletedBy)
+	if err != nil {
+		return err
+	}
+
+	return dbo2.CheckRowsAffected(result, 1)
+}
+
+// InsertUserBlock blocks a user. Blocking of an already blocked user is not
+// an error.
+func (dbo *DatabaseObject) InsertUserBlock(userId base2.Id, blockedUserId base2.Id) (err error) {
+	_, err = dbo.DatabaseObject.PreparedStatement(DbPsid_InsertUserBlock).Exec(userId, blockedUserId)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// MoveForumToTrash copies a forum into the table of deleted forums. The
+// original record must be deleted afterwards.
+func (dbo *DatabaseObject) MoveForumToTrash(forumId base2.Id) (err error) {
+	var result sql.Result
+	result, err = dbo.DatabaseObject.PreparedStatement(DbPsid_MoveForumToTrash).Exec(forumId)
+	if err != nil {
+		return err
+	}
+
+	return dbo2.CheckRowsAffected(result, 1)
+}
+
+// MoveMessageToTrash copies a message into the table of deleted messages. The
+// original record must be deleted afterwards.
+func (dbo *DatabaseObject) MoveMessageToTrash(messageId base2.Id) (err error) {
+	var result sql.Result
+	result, err = dbo.DatabaseObject.PreparedStatement(DbPsid_MoveMessageToTrash).Exec(messageId)
+	if err != nil {
+		return err
+	}
+
+	return dbo2.CheckRowsAffected(result, 1)
+}
+
+// MoveSectionToTrash copies a section into the table of deleted sections. The
+// original record must be deleted afterwards.
+func (dbo *DatabaseObject) MoveSectionToTrash(sectionId base2.Id) (err error) {
+	var result sql.Result
+	result, err = dbo.DatabaseObject.PreparedStatement(DbPsid_MoveSectionToTrash).Exec(sectionId)
+	if err != nil {
+		return err
+	}
+
+	return dbo2.CheckRowsAffected(result, 1)
+}
+
+// MoveThreadToTrash copies a thread into the table of deleted threads. The
+// original record must be deleted afterwards.
+func (dbo *DatabaseObject) MoveThreadToTrash(threadId base2.Id) (err error) {
+	var result sql.Result
+	result, err = dbo.DatabaseObject.PreparedStatement(DbPsid_MoveThreadToTrash).Exec(threadId)
+	if err != nil {
+		return err
+	}
+
+	return dbo2.CheckRowsAffected(result, 1)
+}
+
+// ReadAnnouncementsOfSection reads announcements made in other forums of the
+// section. The latest announcements go first.
+func (dbo *DatabaseObject) ReadAnnouncementsOfSection(sectionId base2.Id, exceptForumId base2.Id) (threads []derived2.IThread, err error) {
+	var rows *sql.Rows
+	rows, err = dbo.DatabaseObject.PreparedStatement(DbPsid_ReadAnnouncementsOfSection).Query(sectionId, exceptForumId)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		derr := rows.Close()
+		if derr != nil {
+			err = ae.Combine(err, derr)
+		}
+	}()
+
+	return t.NewThreadArrayFromRows(rows)
+}
+
+// ReadAttachmentsByMessageIds reads attachments of messages.
+func (dbo *DatabaseObject) ReadAttachmentsByMessageIds(messageIds *ul.UidList) (attachments []mm.Attachment, err error) {
+	if (messageIds == nil) || (messageIds.Size() == 0) {
+		return []mm.Attachment{}, nil
+	}
+
+	var query string
+	query, err = dbo.dbQuery_ReadAttachmentsByMessageIds(*messageIds)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows *sql.Rows
+	rows, err = dbo.DatabaseObject.DB().Query(query)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		derr := rows.Close()
+		if derr != nil {
+			err = ae.Combine(err, derr)
+		}
+	}()
+
+	return mm.NewAttachmentArrayFromRows(rows)
+}
+
+func (dbo *DatabaseObject) ReadBlockedUsers(userId base2.Id) (blockedUserIds []base2.Id, err error) {
+	var rows *sql.Rows
+	rows, err = dbo.DatabaseObject.PreparedStatement(DbPsid_ReadBlockedUsers).Query(userId)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		derr := rows.Close()
+		if derr != nil {
+			err = ae.Combine(err, derr)
+		}
+	}()
+
+	return cms.NewArrayFromScannableSource[base2.Id](rows)
+}
+
+func (dbo *DatabaseObject) ReadConversationsOfUserOnPage(userId base2.Id, pageNumber base2.Count, pageSize base2.Count) (conversations []mm.Conversation, err error) {
+	var rows *sql.Rows
+	rows, err = dbo.DatabaseObject.PreparedStatement(DbPsid_ReadConversationsOfUserOnPage).Query(userId, pageSize, (pageNumber-1)*pageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		derr := rows.Close()
+		if derr != nil {
+			err = ae.Combine(err, derr)
+		}
+	}()
+
+	return mm.NewConversationArrayFromRows(rows)
+}
+
+// ReadExpiredPollThreads reads IDs of threads having open polls whose closing
+// time has come.
+func (dbo *DatabaseObject) ReadExpiredPollThreads() (threadIds []base2.Id, err error) {
+	var rows *sql.Rows
+	rows, err = dbo.DatabaseObject.PreparedStatement(DbPsid_ReadExpiredPollThreads).Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		derr := rows.Close()
+		if derr != nil {
+			err = ae.Combine(err, derr)
+		}
+	}()
+
+	return cms.NewArrayFromScannableSource[base2.Id](rows)
+}
+
+// ReadExpiredTrashItems reads trash items which were deleted earlier than the
+// specified number of days ago.
+func (dbo *DatabaseObject) ReadExpiredTrashItems(retentionDays base2.Count) (tis []mm.TrashItem, err error) {
+	var rows *sql.Rows
+	rows, err = dbo.DatabaseObject.PreparedStatement(DbPsid_ReadExpiredTrashItems).Query(retentionDays)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		derr := rows.Close()
+		if derr != nil {
+			err = ae.Combine(err, derr)
+		}
+	}()
+
+	return mm.NewTrashItemArrayFromRows(rows)
+}
+
+func (dbo *DatabaseObject) ReadForums() (forums []derived2.IForum, err error) {
+	var rows *sql.Rows
+	rows, err = dbo.DatabaseObject.PreparedStatement(DbPsid_ReadForums).Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		derr := rows.Close()
+		if derr != nil {
+			err = ae.Combine(err, derr)
+		}
+	}()
+
+	return complex2.NewForumArrayFromRows(rows)
+}
+
+// ReadMessageLinks reads links of all messages. This function is slow and is
+// used for repair of the board's structure only.
+func (dbo *DatabaseObject) ReadMessageLinks() (messageLinks []mm.MessageLink, err error) {
+	var rows *sql.Rows
+	rows, err = dbo.DatabaseObject.PreparedStatement(DbPsid_ReadMessageLinks).Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		derr := rows.Close()
+		if derr != nil {
+			err = ae.Combine(err, derr)
+		}
+	}()
+
+	return mm.NewMessageLinkArrayFromRows(rows)
+}
+
+// ReadMessageReactionCountsById reads aggregated reactions of messages. The
+// reactions put by the user are marked.
+func (dbo *DatabaseObject) ReadMessageReactionCountsById(messageIds *ul.UidList, userId base2.Id) (mrcs []mm.MessageReactionCount, err error) {
+	if (messageIds == nil) || (messageIds.Size() == 0) {
+		return []mm.MessageReactionCount{}, nil
+	}
+
+	var query string
+	var args []any
+	query, args, err = dbo.dbQuery_ReadMessageReactionCountsById(*messageIds, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows *sql.Rows
+	rows, err = dbo.DatabaseObject.DB().Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		derr := rows.Close()
+		if derr != nil {
+			err = ae.Combine(err, derr)
+		}
+	}()
+
+	return mm.NewMessageReactionCountArrayFromRows(rows)
+}
+
+func (dbo *DatabaseObject) ReadMessageRevisionsByMessageId(messageId base2.Id) (revisions []mm.MessageRevision, err error) {
+	var rows *sql.Rows
+	rows, err = dbo.DatabaseObject.PreparedStatement(DbPsid_ReadMessageRevisions).Query(messageId)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		derr := rows.Close()
+		if derr != nil {
+			err = ae.Combine(err, derr)
+		}
+	}()
+
+	return mm.NewMessageRevisionArrayFromRows(rows)
+}
+
+func (dbo *DatabaseObject) ReadMessagesById(messageIds *ul.UidList) (messages []derived2.IMessage, err error) {
+	if messageIds == nil {
+		return []derived2.IMessage{}, nil
+	}
+
+	var query string
+	query, err = dbo.dbQuery_ReadMessagesById(*messageIds)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows *sql.Rows
+	rows, err = dbo.DatabaseObject.DB().Query(query)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		derr := rows.Close()
+		if derr != nil {
+			err = ae.Combine(err, derr)
+		}
+	}()
+
+	return m.NewMessageArrayFromRows(rows)
+}
+
+// ReadMessagesWithoutHtml reads texts of messages which have no rendered HTML.
+// Messages are read in the order of their IDs, starting after the specified
+// ID.
+func (dbo *DatabaseObject) ReadMessagesWithoutHtml(afterId base2.Id, limit base2.Count) (mts []mm.MessageText, err error) {
+	var rows *sql.Rows
+	rows, err = dbo.DatabaseObject.PreparedStatement(DbPsid_ReadMessagesWithoutHtml).Query(afterId, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		derr := rows.Close()
+		if derr != nil {
+			err = ae.Combine(err, derr)
+		}
+	}()
+
+	return mm.NewMessageTextArrayFromRows(rows)
+}
+
+// ReadMessagesWithoutWords reads texts of messages which have no words in the
+// search index. Messages are read in the order of their IDs, starting after
+// the specified ID.
+func (dbo *DatabaseObject) ReadMessagesWithoutWords(afterId base2.Id, limit base2.Count) (mts []mm.MessageText, err error) {
+	var rows *sql.Rows
+	rows, err = dbo.DatabaseObject.PreparedStatement(DbPsid_ReadMessagesWithoutWords).Query(afterId, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		derr := rows.Close()
+		if derr != nil {
+			err = ae.Combine(err, derr)
+		}
+	}()
+
+	return mm.NewMessageTextArrayFromRows(rows)
+}
+
+func (dbo *DatabaseObject) ReadMessageLinksById(messageIds *ul.UidList) (messageLinks []mm.MessageLink, err error) {
+	if messageIds == nil {
+		return []mm.MessageLink{}, nil
+	}
+
+	var query string
+	query, err = dbo.dbQuery_ReadMessageLinksById(*messageIds)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows *sql.Rows
+	rows, err = dbo.DatabaseObject.DB().Query(query)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		derr := rows.Close()
+		if derr != nil {
+			err = ae.Combine(err, derr)
+		}
+	}()
+
+	return mm.NewMessageLinkArrayFromRows(rows)
+}
+
+func (dbo *DatabaseObject) ReadModerationDecisionsOnPage(pageNumber base2.Count, pageSize base2.Count) (mds []mm.ModerationDecision, err error) {
+	var rows *sql.Rows
+	rows, err = dbo.DatabaseObject.PreparedStatement(DbPsid_ReadModerationDecisionsOnPage).Query(pageSize, (pageNumber-1)*pageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		derr := rows.Close()
+		if derr != nil {
+			err = ae.Combine(err, derr)
+		}
+	}()
+
+	return mm.NewModerationDecisionArrayFromRows(rows)
+}
+
+func (dbo *DatabaseObject) ReadOpenReportsByMessageId(messageId base2.Id) (mrs []mm.MessageReport, err error) {
+	var rows *sql.Rows
+	rows, err = dbo.DatabaseObject.PreparedStatement(DbPsid_ReadOpenReportsByMessageId).Query(messageId)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		derr := rows.Close()
+		if derr != nil {
+			err = ae.Combine(err, derr)
+		}
+	}()
+
+	return mm.NewMessageReportArrayFromRows(rows)
+}
+
+// ReadOpenReportsByMessageIds reads open reports about messages. Reports are
+// ordered as the messages in the list.
+func (dbo *DatabaseObject) ReadOpenReportsByMessageIds(messageIds *ul.UidList) (mrs []mm.MessageReport, err error) {
+	if (messageIds == nil) || (messageIds.Size() == 0) {
+		return []mm.MessageReport{}, nil
+	}
+
+	var query string
+	query, err = dbo.dbQuery_ReadOpenReportsByMessageIds(*messageIds)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows *sql.Rows
+	rows, err = dbo.DatabaseObject.DB().Query(query)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		derr := rows.Close()
+		if derr != nil {
+			err = ae.Combine(err, derr)
+		}
+	}()
+
+	return mm.NewMessageReportArrayFromRows(rows)
+}
+
+// ReadOrphanedBlobs reads hashes of blobs which are not used by any
+// attachment.
+func (dbo *DatabaseObject) ReadOrphanedBlobs() (hashes []string, err error) {
+	var rows *sql.Rows
+	rows, err = dbo.DatabaseObject.PreparedStatement(DbPsid_ReadOrphanedBlobs).Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		derr := rows.Close()
+		if derr != nil {
+			err = ae.Combine(err, derr)
+		}
+	}()
+
+	return cms.NewArrayFromScannableSource[string](rows)
+}
+
+// ReadPinnedThreadIds reads IDs of threads of the forum which are shown on
+// top, i.e. pinned threads and announcements.
+func (dbo *DatabaseObject) ReadPinnedThreadIds(forumId base2.Id) (threadIds []base2.Id, err error) {
+	var rows *sql.Rows
+	rows, err = dbo.DatabaseObject.PreparedStatement(DbPsid_ReadPinnedThreadIds).Query(forumId)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		derr := rows.Close()
+		if derr != nil {
+			err = ae.Combine(err, derr)
+		}
+	}()
+
+	return cms.NewArrayFromScannableSource[base2.Id](rows)
 }
 
-func (dbo *DatabaseObject) GetThreadById(threadId base2.Id) (thread derived2.IThread, err error) {
-	row := dbo.DatabaseObject.PreparedStatement(DbPsid_GetThreadByIdM).QueryRow(threadId)
-
-	thread, err = t.NewThreadFromScannableSource(row)
+func (dbo *DatabaseObject) ReadPollOptionResults(pollId base2.Id) (results []mm.PollOptionResult, err error) {
+	var rows *sql.Rows
+	rows, err = dbo.DatabaseObject.PreparedStatement(DbPsid_ReadPollOptionResults).Query(pollId)
 	if err != nil {
 		return nil, err
 	}
 
-	return thread, nil
-}
+	defer func() {
+		derr := rows.Close()
+		if derr != nil {
+			err = ae.Combine(err, derr)
+		}
+	}()
 
-func (dbo *DatabaseObject) GetThreadForumById(threadId base2.Id) (forumId base2.Id, err error) {
-	row := dbo.DatabaseObject.PreparedStatement(DbPsid_GetThreadForumById).QueryRow(threadId)
+	return mm.NewPollOptionResultArrayFromRows(rows)
+}
 
-	forumId, err = cms.NewNonNullValueFromScannableSource[base2.Id](row)
+func (dbo *DatabaseObject) ReadPollOptions(pollId base2.Id) (options []mm.PollOption, err error) {
+	var rows *sql.Rows
+	rows, err = dbo.DatabaseObject.PreparedStatement(DbPsid_ReadPollOptions).Query(pollId)
 	if err != nil {
-		return dbo2.IdOnError, err
+		return nil, err
 	}
 
-	return forumId, nil
+	defer func() {
+		derr := rows.Close()
+		if derr != nil {
+			err = ae.Combine(err, derr)
+		}
+	}()
+
+	return mm.NewPollOptionArrayFromRows(rows)
 }
 
-func (dbo *DatabaseObject) GetThreadMessagesById(threadId base2.Id) (messages *ul.UidList, err error) {
-	messages = ul.New()
-	err = dbo.DatabaseObject.PreparedStatement(DbPsid_GetThreadMessagesById).QueryRow(threadId).Scan(messages)
+func (dbo *DatabaseObject) ReadPollVotesOfUser(pollId base2.Id, userId base2.Id) (optionIds []base2.Id, err error) {
+	var rows *sql.Rows
+	rows, err = dbo.DatabaseObject.PreparedStatement(DbPsid_ReadPollVotesOfUser).Query(pollId, userId)
 	if err != nil {
 		return nil, err
 	}
 
-	return messages, nil
+	defer func() {
+		derr := rows.Close()
+		if derr != nil {
+			err = ae.Combine(err, derr)
+		}
+	}()
+
+	return cms.NewArrayFromScannableSource[base2.Id](rows)
 }
 
-func (dbo *DatabaseObject) InsertNewForum(sectionId base2.Id, name cm.Name, creatorUserId base2.Id) (lastInsertedId base2.Id, err error) {
-	var result sql.Result
-	result, err = dbo.DatabaseObject.PreparedStatement(DbPsid_InsertNewForum).Exec(sectionId, name, creatorUserId)
+func (dbo *DatabaseObject) ReadPrivateMessagesOnPage(conversationId base2.Id, pageNumber base2.Count, pageSize base2.Count) (messages []mm.PrivateMessage, err error) {
+	var rows *sql.Rows
+	rows, err = dbo.DatabaseObject.PreparedStatement(DbPsid_ReadPrivateMessagesOnPage).Query(conversationId, pageSize, (pageNumber-1)*pageSize)
 	if err != nil {
-		return dbo2.LastInsertedIdOnError, err
+		return nil, err
 	}
 
-	return dbo2.CheckRowsAffectedAndGetLastInsertedId(result, 1)
+	defer func() {
+		derr := rows.Close()
+		if derr != nil {
+			err = ae.Combine(err, derr)
+		}
+	}()
+
+	return mm.NewPrivateMessageArrayFromRows(rows)
 }
 
-func (dbo *DatabaseObject) InsertNewMessage(parentThread base2.Id, messageText base2.Text, textChecksum []byte, creatorUserId base2.Id) (lastInsertedId base2.Id, err error) {
-	var result sql.Result
-	result, err = dbo.DatabaseObject.PreparedStatement(DbPsid_InsertNewMessage).Exec(parentThread, messageText, textChecksum, creatorUserId)
+// ReadReportedMessageIdsOnPage reads IDs of existing messages having open
+// reports. Messages reported earlier go first.
+func (dbo *DatabaseObject) ReadReportedMessageIdsOnPage(pageNumber base2.Count, pageSize base2.Count) (messageIds *ul.UidList, err error) {
+	var rows *sql.Rows
+	rows, err = dbo.DatabaseObject.PreparedStatement(DbPsid_ReadReportedMessageIdsOnPage).Query(pageSize, (pageNumber-1)*pageSize)
 	if err != nil {
-		return dbo2.LastInsertedIdOnError, err
+		return nil, err
 	}
 
-	return dbo2.CheckRowsAffectedAndGetLastInsertedId(result, 1)
-}
+	defer func() {
+		derr := rows.Close()
+		if derr != nil {
+			err = ae.Combine(err, derr)
+		}
+	}()
 
-func (dbo *DatabaseObject) InsertNewSection(parent *base2.Id, name cm.Name, creatorUserId base2.Id) (lastInsertedId base2.Id, err error) {
-	var result sql.Result
-	result, err = dbo.DatabaseObject.PreparedStatement(DbPsid_InsertNewSection).Exec(parent, name, creatorUserId)
+	var ids []base2.Id
+	ids, err = cms.NewArrayFromScannableSource[base2.Id](rows)
 	if err != nil {
-		return dbo2.LastInsertedIdOnError, err
+		return nil, err
 	}
 
-	return dbo2.CheckRowsAffectedAndGetLastInsertedId(result, 1)
+	return ul.NewFromArray(ids)
 }
 
-func (dbo *DatabaseObject) InsertNewThread(parentForum base2.Id, threadName cm.Name, creatorUserId base2.Id) (lastInsertedId base2.Id, err error) {
-	var result sql.Result
-	result, err = dbo.DatabaseObject.PreparedStatement(DbPsid_InsertNewThread).Exec(parentForum, threadName, creatorUserId)
+func (dbo *DatabaseObject) ReadSections() (sections []derived2.ISection, err error) {
+	var rows *sql.Rows
+	rows, err = dbo.DatabaseObject.PreparedStatement(DbPsid_ReadSections).Query()
 	if err != nil {
-		return dbo2.LastInsertedIdOnError, err
+		return nil, err
 	}
 
-	return dbo2.CheckRowsAffectedAndGetLastInsertedId(result, 1)
+	defer func() {
+		derr := rows.Close()
+		if derr != nil {
+			err = ae.Combine(err, derr)
+		}
+	}()
+
+	return s.NewSectionArrayFromRows(rows)
 }
 
-func (dbo *DatabaseObject) ReadForums() (forums []derived2.IForum, err error) {
+func (dbo *DatabaseObject) ReadThreadLinks() (threadLinks []mm.ThreadLink, err error) {
 	var rows *sql.Rows
-	rows, err = dbo.DatabaseObject.PreparedStatement(DbPsid_ReadForums).Query()
+	rows, err = dbo.DatabaseObject.PreparedStatement(DbPsid_ReadThreadLinks).Query()
 	if err != nil {
 		return nil, err
 	}
@@ -303,22 +1496,18 @@ func (dbo *DatabaseObject) ReadForums() (forums []derived2.IForum, err error) {
 		}
 	}()
 
-	return complex2.NewForumArrayFromRows(rows)
+	return mm.NewThreadLinkArrayFromRows(rows)
 }
 
-func (dbo *DatabaseObject) ReadMessagesById(messageIds *ul.UidList) (messages []derived2.IMessage, err error) {
-	if messageIds == nil {
-		return []derived2.IMessage{}, nil
-	}
-
+func (dbo *DatabaseObject) ReadThreadNamesByIds(threadIds ul.UidList) (threadNames []cm.Name, err error) {
 	var query string
-	query, err = dbo.dbQuery_ReadMessagesById(*messageIds)
+	query, err = dbo.dbQuery_ReadThreadNamesByIds(threadIds)
 	if err != nil {
 		return nil, err
 	}
 
 	var rows *sql.Rows
-	rows, err = dbo.DatabaseObject.DB().Query(query)
+	rows, err = dbo.DB().Query(query)
 	if err != nil {
 		return nil, err
 	}
@@ -330,22 +1519,22 @@ func (dbo *DatabaseObject) ReadMessagesById(messageIds *ul.UidList) (messages []
 		}
 	}()
 
-	return m.NewMessageArrayFromRows(rows)
+	return cms.NewArrayFromScannableSource[cm.Name](rows)
 }
 
-func (dbo *DatabaseObject) ReadMessageLinksById(messageIds *ul.UidList) (messageLinks []mm.MessageLink, err error) {
-	if messageIds == nil {
-		return []mm.MessageLink{}, nil
+func (dbo *DatabaseObject) ReadThreadsById(threadIds *ul.UidList) (threads []derived2.IThread, err error) {
+	if threadIds == nil {
+		return []derived2.IThread{}, nil
 	}
 
 	var query string
-	query, err = dbo.dbQuery_ReadMessageLinksById(*messageIds)
+	query, err = dbo.dbQuery_ReadThreadsById(*threadIds)
 	if err != nil {
 		return nil, err
 	}
 
 	var rows *sql.Rows
-	rows, err = dbo.DatabaseObject.DB().Query(query)
+	rows, err = dbo.DB().Query(query)
 	if err != nil {
 		return nil, err
 	}
@@ -357,12 +1546,15 @@ func (dbo *DatabaseObject) ReadMessageLinksById(messageIds *ul.UidList) (message
 		}
 	}()
 
-	return mm.NewMessageLinkArrayFromRows(rows)
+	return t.NewThreadArrayFromRows(rows)
 }
 
-func (dbo *DatabaseObject) ReadSections() (sections []derived2.ISection, err error) {
+// ReadThreadsWithoutWords reads names of threads which have no words in the
+// search index. Threads are read in the order of their IDs, starting after the
+// specified ID.
+func (dbo *DatabaseObject) ReadThreadsWithoutWords(afterId base2.Id, limit base2.Count) (tns []mm.ThreadName, err error) {
 	var rows *sql.Rows
-	rows, err = dbo.DatabaseObject.PreparedStatement(DbPsid_ReadSections).Query()
+	rows, err = dbo.DatabaseObject.PreparedStatement(DbPsid_ReadThreadsWithoutWords).Query(afterId, limit)
 	if err != nil {
 		return nil, err
 	}
@@ -374,12 +1566,12 @@ func (dbo *DatabaseObject) ReadSections() (sections []derived2.ISection, err err
 		}
 	}()
 
-	return s.NewSectionArrayFromRows(rows)
+	return mm.NewThreadNameArrayFromRows(rows)
 }
 
-func (dbo *DatabaseObject) ReadThreadLinks() (threadLinks []mm.ThreadLink, err error) {
+func (dbo *DatabaseObject) ReadTrashItemsOnPage(pageNumber base2.Count, pageSize base2.Count) (tis []mm.TrashItem, err error) {
 	var rows *sql.Rows
-	rows, err = dbo.DatabaseObject.PreparedStatement(DbPsid_ReadThreadLinks).Query()
+	rows, err = dbo.DatabaseObject.PreparedStatement(DbPsid_ReadTrashItemsOnPage).Query(pageSize, (pageNumber-1)*pageSize)
 	if err != nil {
 		return nil, err
 	}
@@ -391,18 +1583,73 @@ func (dbo *DatabaseObject) ReadThreadLinks() (threadLinks []mm.ThreadLink, err e
 		}
 	}()
 
-	return mm.NewThreadLinkArrayFromRows(rows)
+	return mm.NewTrashItemArrayFromRows(rows)
 }
 
-func (dbo *DatabaseObject) ReadThreadNamesByIds(threadIds ul.UidList) (threadNames []cm.Name, err error) {
-	var query string
-	query, err = dbo.dbQuery_ReadThreadNamesByIds(threadIds)
+// ResolveMessageReports attaches a decision to open reports about a message.
+// Reports created after the last report seen by the moderator stay open.
+func (dbo *DatabaseObject) ResolveMessageReports(messageId base2.Id, decisionId base2.Id, lastReportId base2.Id) (err error) {
+	_, err = dbo.DatabaseObject.PreparedStatement(DbPsid_ResolveMessageReports).Exec(decisionId, messageId, lastReportId)
 	if err != nil {
-		return nil, err
+		return err
+	}
+
+	return nil
+}
+
+// RestoreForumFromTrash copies a deleted forum back keeping its ID. The
+// record of the deleted forum must be deleted afterwards.
+func (dbo *DatabaseObject) RestoreForumFromTrash(forumId base2.Id) (err error) {
+	var result sql.Result
+	result, err = dbo.DatabaseObject.PreparedStatement(DbPsid_RestoreForumFromTrash).Exec(forumId)
+	if err != nil {
+		return err
+	}
+
+	return dbo2.CheckRowsAffected(result, 1)
+}
+
+// RestoreMessageFromTrash copies a deleted message back keeping its ID. The
+// record of the deleted message must be deleted afterwards.
+func (dbo *DatabaseObject) RestoreMessageFromTrash(messageId base2.Id) (err error) {
+	var result sql.Result
+	result, err = dbo.DatabaseObject.PreparedStatement(DbPsid_RestoreMessageFromTrash).Exec(messageId)
+	if err != nil {
+		return err
+	}
+
+	return dbo2.CheckRowsAffected(result, 1)
+}
+
+// RestoreSectionFromTrash copies a deleted section back keeping its ID. The
+// record of the deleted section must be deleted afterwards.
+func (dbo *DatabaseObject) RestoreSectionFromTrash(sectionId base2.Id) (err error) {
+	var result sql.Result
+	result, err = dbo.DatabaseObject.PreparedStatement(DbPsid_RestoreSectionFromTrash).Exec(sectionId)
+	if err != nil {
+		return err
+	}
+
+	return dbo2.CheckRowsAffected(result, 1)
+}
+
+// RestoreThreadFromTrash copies a deleted thread back keeping its ID. The
+// record of the deleted thread must be deleted afterwards.
+func (dbo *DatabaseObject) RestoreThreadFromTrash(threadId base2.Id) (err error) {
+	var result sql.Result
+	result, err = dbo.DatabaseObject.PreparedStatement(DbPsid_RestoreThreadFromTrash).Exec(threadId)
+	if err != nil {
+		return err
 	}
 
+	return dbo2.CheckRowsAffected(result, 1)
+}
+
+func (dbo *DatabaseObject) SearchMessages(sf *mm.SearchFilter, pageNumber base2.Count, pageSize base2.Count) (messageIds *ul.UidList, err error) {
+	query, args := dbo.dbQuery_SearchMessages(sf, pageNumber, pageSize)
+
 	var rows *sql.Rows
-	rows, err = dbo.DB().Query(query)
+	rows, err = dbo.DatabaseObject.DB().Query(query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -414,22 +1661,20 @@ func (dbo *DatabaseObject) ReadThreadNamesByIds(threadIds ul.UidList) (threadNam
 		}
 	}()
 
-	return cms.NewArrayFromScannableSource[cm.Name](rows)
-}
-
-func (dbo *DatabaseObject) ReadThreadsById(threadIds *ul.UidList) (threads []derived2.IThread, err error) {
-	if threadIds == nil {
-		return []derived2.IThread{}, nil
-	}
-
-	var query string
-	query, err = dbo.dbQuery_ReadThreadsById(*threadIds)
+	var ids []base2.Id
+	ids, err = cms.NewArrayFromScannableSource[base2.Id](rows)
 	if err != nil {
 		return nil, err
 	}
 
+	return ul.NewFromArray(ids)
+}
+
+func (dbo *DatabaseObject) SearchThreads(sf *mm.SearchFilter, pageNumber base2.Count, pageSize base2.Count) (threadIds *ul.UidList, err error) {
+	query, args := dbo.dbQuery_SearchThreads(sf, pageNumber, pageSize)
+
 	var rows *sql.Rows
-	rows, err = dbo.DB().Query(query)
+	rows, err = dbo.DatabaseObject.DB().Query(query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -441,7 +1686,32 @@ func (dbo *DatabaseObject) ReadThreadsById(threadIds *ul.UidList) (threads []der
 		}
 	}()
 
-	return t.NewThreadArrayFromRows(rows)
+	var ids []base2.Id
+	ids, err = cms.NewArrayFromScannableSource[base2.Id](rows)
+	if err != nil {
+		return nil, err
+	}
+
+	return ul.NewFromArray(ids)
+}
+
+func (dbo *DatabaseObject) SetConversationLastMessageTime(conversationId base2.Id) (err error) {
+	_, err = dbo.DatabaseObject.PreparedStatement(DbPsid_SetConversationLastMessageTime).Exec(conversationId)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (dbo *DatabaseObject) SetForumIsReadOnlyById(forumId base2.Id, isReadOnly base2.Flag, editorUserId base2.Id) (err error) {
+	var result sql.Result
+	result, err = dbo.DatabaseObject.PreparedStatement(DbPsid_SetForumIsReadOnlyById).Exec(isReadOnly, editorUserId, forumId)
+	if err != nil {
+		return err
+	}
+
+	return dbo2.CheckRowsAffected(result, 1)
 }
 
 func (dbo *DatabaseObject) SetForumNameById(forumId base2.Id, name cm.Name, editorUserId base2.Id) (err error) {
@@ -474,9 +1744,32 @@ func (dbo *DatabaseObject) SetForumThreadsById(forumId base2.Id, threads *ul.Uid
 	return dbo2.CheckRowsAffected(result, 1)
 }
 
-func (dbo *DatabaseObject) SetMessageTextById(messageId base2.Id, text base2.Text, textChecksum []byte, editorUserId base2.Id) (err error) {
+// SetLastReadPrivateMessageId marks messages of a conversation as read by the
+// member. The mark never moves back.
+func (dbo *DatabaseObject) SetLastReadPrivateMessageId(conversationId base2.Id, userId base2.Id, messageId base2.Id) (err error) {
+	_, err = dbo.DatabaseObject.PreparedStatement(DbPsid_SetLastReadPrivateMessageId).Exec(messageId, conversationId, userId)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// SetMessageHtmlById sets the rendered HTML of a message. The message is not
+// marked as edited.
+func (dbo *DatabaseObject) SetMessageHtmlById(messageId base2.Id, textHtml base2.Text) (err error) {
+	var result sql.Result
+	result, err = dbo.DatabaseObject.PreparedStatement(DbPsid_SetMessageHtmlById).Exec(textHtml, messageId)
+	if err != nil {
+		return err
+	}
+
+	return dbo2.CheckRowsAffected(result, 1)
+}
+
+func (dbo *DatabaseObject) SetMessageTextById(messageId base2.Id, text base2.Text, textChecksum []byte, textHtml base2.Text, editorUserId base2.Id) (err error) {
 	var result sql.Result
-	result, err = dbo.DatabaseObject.PreparedStatement(DbPsid_SetMessageTextById).Exec(text, textChecksum, editorUserId, messageId)
+	result, err = dbo.DatabaseObject.PreparedStatement(DbPsid_SetMessageTextById).Exec(text, textChecksum, textHtml, editorUserId, messageId)
 	if err != nil {
 		return err
 	}
@@ -494,6 +1787,16 @@ func (dbo *DatabaseObject) SetMessageThreadById(messageId base2.Id, threadId bas
 	return dbo2.CheckRowsAffected(result, 1)
 }
 
+func (dbo *DatabaseObject) SetPollVoterEditTime(pollId base2.Id, userId base2.Id) (err error) {
+	var result sql.Result
+	result, err = dbo.DatabaseObject.PreparedStatement(DbPsid_SetPollVoterEditTime).Exec(pollId, userId)
+	if err != nil {
+		return err
+	}
+
+	return dbo2.CheckRowsAffected(result, 1)
+}
+
 func (dbo *DatabaseObject) SetSectionChildTypeById(sectionId base2.Id, childType derived1.ISectionChildType) (err error) {
 	var result sql.Result
 	result, err = dbo.DatabaseObject.PreparedStatement(DbPsid_SetSectionChildTypeById).Exec(childType, sectionId)
@@ -514,6 +1817,16 @@ func (dbo *DatabaseObject) SetSectionChildrenById(sectionId base2.Id, children *
 	return dbo2.CheckRowsAffected(result, 1)
 }
 
+func (dbo *DatabaseObject) SetSectionIsPrivateById(sectionId base2.Id, isPrivate base2.Flag, editorUserId base2.Id) (err error) {
+	var result sql.Result
+	result, err = dbo.DatabaseObject.PreparedStatement(DbPsid_SetSectionIsPrivateById).Exec(isPrivate, editorUserId, sectionId)
+	if err != nil {
+		return err
+	}
+
+	return dbo2.CheckRowsAffected(result, 1)
+}
+
 func (dbo *DatabaseObject) SetSectionNameById(sectionId base2.Id, name cm.Name, editorUserId base2.Id) (err error) {
 	var result sql.Result
 	result, err = dbo.DatabaseObject.PreparedStatement(DbPsid_SetSectionNameById).Exec(name, editorUserId, sectionId)
@@ -544,6 +1857,36 @@ func (dbo *DatabaseObject) SetThreadForumById(threadId base2.Id, forumId base2.I
 	return dbo2.CheckRowsAffected(result, 1)
 }
 
+func (dbo *DatabaseObject) SetThreadIsAnnouncementById(threadId base2.Id, isAnnouncement base2.Flag, editorUserId base2.Id) (err error) {
+	var result sql.Result
+	result, err = dbo.DatabaseObject.PreparedStatement(DbPsid_SetThreadIsAnnouncementById).Exec(isAnnouncement, editorUserId, threadId)
+	if err != nil {
+		return err
+	}
+
+	return dbo2.CheckRowsAffected(result, 1)
+}
+
+func (dbo *DatabaseObject) SetThreadIsLockedById(threadId base2.Id, isLocked base2.Flag, editorUserId base2.Id) (err error) {
+	var result sql.Result
+	result, err = dbo.DatabaseObject.PreparedStatement(DbPsid_SetThreadIsLockedById).Exec(isLocked, editorUserId, threadId)
+	if err != nil {
+		return err
+	}
+
+	return dbo2.CheckRowsAffected(result, 1)
+}
+
+func (dbo *DatabaseObject) SetThreadIsPinnedById(threadId base2.Id, isPinned base2.Flag, editorUserId base2.Id) (err error) {
+	var result sql.Result
+	result, err = dbo.DatabaseObject.PreparedStatement(DbPsid_SetThreadIsPinnedById).Exec(isPinned, editorUserId, threadId)
+	if err != nil {
+		return err
+	}
+
+	return dbo2.CheckRowsAffected(result, 1)
+}
+
 func (dbo *DatabaseObject) SetThreadMessagesById(threadId base2.Id, messages *ul.UidList) (err error) {
 	var result sql.Result
 	result, err = dbo.DatabaseObject.PreparedStatement(DbPsid_SetThreadMessagesById).Exec(messages, threadId)
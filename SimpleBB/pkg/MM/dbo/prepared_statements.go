@@ -7,47 +7,144 @@ import (
 
 // Indices of prepared statements.
 const (
-	DbPsid_ReadSections                 = 0
-	DbPsid_InsertNewForum               = 1
-	DbPsid_CountForumsById              = 2
-	DbPsid_DeleteSectionById            = 3
-	DbPsid_GetSectionById               = 4
-	DbPsid_SetForumNameById             = 5
-	DbPsid_SetSectionChildTypeById      = 6
-	DbPsid_SetForumSectionById          = 7
-	DbPsid_GetForumSectionById          = 8
-	DbPsid_InsertNewThread              = 9
-	DbPsid_GetForumThreadsById          = 10
-	DbPsid_SetForumThreadsById          = 11
-	DbPsid_SetThreadNameById            = 12
-	DbPsid_GetThreadForumById           = 13
-	DbPsid_SetThreadForumById           = 14
-	DbPsid_CountThreadsById             = 15
-	DbPsid_GetThreadMessagesById        = 16
-	DbPsid_InsertNewMessage             = 17
-	DbPsid_SetThreadMessagesById        = 18
-	DbPsid_SetMessageTextById           = 19
-	DbPsid_GetMessageThreadById         = 20
-	DbPsid_SetMessageThreadById         = 21
-	DbPsid_GetMessageCreatorAndTimeById = 22
-	DbPsid_GetMessageById               = 23
-	DbPsid_DeleteMessageById            = 24
-	DbPsid_GetThreadByIdM               = 25
-	DbPsid_DeleteThreadById             = 26
-	DbPsid_GetForumById                 = 27
-	DbPsid_DeleteForumById              = 28
-	DbPsid_ReadForums                   = 29
-	DbPsid_CountRootSections            = 30
-	DbPsid_InsertNewSection             = 31
-	DbPsid_CountSectionsById            = 32
-	DbPsid_GetSectionChildrenById       = 33
-	DbPsid_SetSectionChildrenById       = 34
-	DbPsid_SetSectionNameById           = 35
-	DbPsid_GetSectionParentById         = 36
-	DbPsid_SetSectionParentById         = 37
-	DbPsid_GetSectionChildTypeById      = 38
-	DbPsid_CountMessagesById            = 39
-	DbPsid_ReadThreadLinks              = 40
+	DbPsid_ReadSections                   = 0
+	DbPsid_InsertNewForum                 = 1
+	DbPsid_CountForumsById                = 2
+	DbPsid_DeleteSectionById              = 3
+	DbPsid_GetSectionById                 = 4
+	DbPsid_SetForumNameById               = 5
+	DbPsid_SetSectionChildTypeById        = 6
+	DbPsid_SetForumSectionById            = 7
+	DbPsid_GetForumSectionById            = 8
+	DbPsid_InsertNewThread                = 9
+	DbPsid_GetForumThreadsById            = 10
+	DbPsid_SetForumThreadsById            = 11
+	DbPsid_SetThreadNameById              = 12
+	DbPsid_GetThreadForumById             = 13
+	DbPsid_SetThreadForumById             = 14
+	DbPsid_CountThreadsById               = 15
+	DbPsid_GetThreadMessagesById          = 16
+	DbPsid_InsertNewMessage               = 17
+	DbPsid_SetThreadMessagesById          = 18
+	DbPsid_SetMessageTextById             = 19
+	DbPsid_GetMessageThreadById           = 20
+	DbPsid_SetMessageThreadById           = 21
+	DbPsid_GetMessageCreatorAndTimeById   = 22
+	DbPsid_GetMessageById                 = 23
+	DbPsid_DeleteMessageById              = 24
+	DbPsid_GetThreadByIdM                 = 25
+	DbPsid_DeleteThreadById               = 26
+	DbPsid_GetForumById                   = 27
+	DbPsid_DeleteForumById                = 28
+	DbPsid_ReadForums                     = 29
+	DbPsid_CountRootSections              = 30
+	DbPsid_InsertNewSection               = 31
+	DbPsid_CountSectionsById              = 32
+	DbPsid_GetSectionChildrenById         = 33
+	DbPsid_SetSectionChildrenById         = 34
+	DbPsid_SetSectionNameById             = 35
+	DbPsid_GetSectionParentById           = 36
+	DbPsid_SetSectionParentById           = 37
+	DbPsid_GetSectionChildTypeById        = 38
+	DbPsid_CountMessagesById              = 39
+	DbPsid_ReadThreadLinks                = 40
+	DbPsid_InsertMessageWord              = 41
+	DbPsid_DeleteMessageWordsById         = 42
+	DbPsid_InsertThreadWord               = 43
+	DbPsid_DeleteThreadWordsById          = 44
+	DbPsid_InsertMessageRevision          = 45
+	DbPsid_GetMessageRevisionById         = 46
+	DbPsid_ReadMessageRevisions           = 47
+	DbPsid_DeleteMessageRevisions         = 48
+	DbPsid_SetSectionIsPrivateById        = 49
+	DbPsid_SetForumIsReadOnlyById         = 50
+	DbPsid_InsertNewConversation          = 51
+	DbPsid_InsertConversationMember       = 52
+	DbPsid_InsertNewPrivateMessage        = 53
+	DbPsid_SetConversationLastMessageTime = 54
+	DbPsid_GetConversationById            = 55
+	DbPsid_ReadConversationsOfUserOnPage  = 56
+	DbPsid_CountConversationsOfUser       = 57
+	DbPsid_CountUnreadPrivateMessages     = 58
+	DbPsid_ReadPrivateMessagesOnPage      = 59
+	DbPsid_CountPrivateMessages           = 60
+	DbPsid_SetLastReadPrivateMessageId    = 61
+	DbPsid_DeleteConversationMember       = 62
+	DbPsid_CountConversationMembers       = 63
+	DbPsid_DeleteConversationById         = 64
+	DbPsid_DeletePrivateMessages          = 65
+	DbPsid_InsertUserBlock                = 66
+	DbPsid_DeleteUserBlock                = 67
+	DbPsid_ReadBlockedUsers               = 68
+	DbPsid_CountUserBlocks                = 69
+	DbPsid_InsertMessageReaction          = 70
+	DbPsid_GetMessageReaction             = 71
+	DbPsid_DeleteMessageReaction          = 72
+	DbPsid_DeleteMessageReactions         = 73
+	DbPsid_ChangeUserReputation           = 74
+	DbPsid_GetUserReputation              = 75
+	DbPsid_InsertPoll                     = 76
+	DbPsid_InsertPollOption               = 77
+	DbPsid_GetPollByThreadId              = 78
+	DbPsid_ReadPollOptions                = 79
+	DbPsid_InsertPollVoter                = 80
+	DbPsid_CountPollVoter                 = 81
+	DbPsid_SetPollVoterEditTime           = 82
+	DbPsid_InsertPollVote                 = 83
+	DbPsid_DeletePollVotesOfUser          = 84
+	DbPsid_ReadPollVotesOfUser            = 85
+	DbPsid_ReadPollOptionResults          = 86
+	DbPsid_CountPollVoters                = 87
+	DbPsid_ReadExpiredPollThreads         = 88
+	DbPsid_ClosePollByThreadId            = 89
+	DbPsid_DeletePollById                 = 90
+	DbPsid_DeletePollOptions              = 91
+	DbPsid_DeletePollVoters               = 92
+	DbPsid_DeletePollVotes                = 93
+	DbPsid_InsertAttachment               = 94
+	DbPsid_GetAttachmentById              = 95
+	DbPsid_DeleteAttachmentsByMessageId   = 96
+	DbPsid_InsertBlob                     = 97
+	DbPsid_ReadOrphanedBlobs              = 98
+	DbPsid_DeleteBlob                     = 99
+	DbPsid_InsertTrashItem                = 100
+	DbPsid_GetTrashItemById               = 101
+	DbPsid_ReadTrashItemsOnPage           = 102
+	DbPsid_CountTrashItems                = 103
+	DbPsid_ReadExpiredTrashItems          = 104
+	DbPsid_DeleteTrashItemById            = 105
+	DbPsid_MoveSectionToTrash             = 106
+	DbPsid_RestoreSectionFromTrash        = 107
+	DbPsid_DeleteSectionFromTrash         = 108
+	DbPsid_MoveForumToTrash               = 109
+	DbPsid_RestoreForumFromTrash          = 110
+	DbPsid_DeleteForumFromTrash           = 111
+	DbPsid_MoveThreadToTrash              = 112
+	DbPsid_RestoreThreadFromTrash         = 113
+	DbPsid_DeleteThreadFromTrash          = 114
+	DbPsid_MoveMessageToTrash             = 115
+	DbPsid_RestoreMessageFromTrash        = 116
+	DbPsid_DeleteMessageFromTrash         = 117
+	DbPsid_ReadMessageLinks               = 118
+	DbPsid_InsertMessageReport            = 119
+	DbPsid_CountOpenReportsOfUser         = 120
+	DbPsid_ReadReportedMessageIdsOnPage   = 121
+	DbPsid_CountReportedMessages          = 122
+	DbPsid_ReadOpenReportsByMessageId     = 123
+	DbPsid_InsertModerationDecision       = 124
+	DbPsid_ResolveMessageReports          = 125
+	DbPsid_ReadModerationDecisionsOnPage  = 126
+	DbPsid_CountModerationDecisions       = 127
+	DbPsid_SetThreadIsLockedById          = 128
+	DbPsid_DeleteOpenReportsByMessageId   = 129
+	DbPsid_SetThreadIsPinnedById          = 130
+	DbPsid_SetThreadIsAnnouncementById    = 131
+	DbPsid_ReadPinnedThreadIds            = 132
+	DbPsid_ReadAnnouncementsOfSection     = 133
+	DbPsid_ReadMessagesWithoutHtml        = 134
+	DbPsid_SetMessageHtmlById             = 135
+	DbPsid_ReadMessagesWithoutWords       = 136
+	DbPsid_ReadThreadsWithoutWords        = 137
 )
 
 func (dbo *DatabaseObject) makePreparedStatementQueryStrings() (qs []string) {
@@ -55,7 +152,7 @@ func (dbo *DatabaseObject) makePreparedStatementQueryStrings() (qs []string) {
 	qs = make([]string, 0)
 
 	// 0.
-	q = fmt.Sprintf(`SELECT Id, Parent, ChildType, Children, Name, CreatorUserId, CreatorTime, EditorUserId, EditorTime FROM %s;`, dbo.tableNames.Sections)
+	q = fmt.Sprintf(`SELECT Id, Parent, ChildType, Children, Name, IsPrivate, CreatorUserId, CreatorTime, EditorUserId, EditorTime FROM %s;`, dbo.tableNames.Sections)
 	qs = append(qs, q)
 
 	// 1.
@@ -71,7 +168,7 @@ func (dbo *DatabaseObject) makePreparedStatementQueryStrings() (qs []string) {
 	qs = append(qs, q)
 
 	// 4.
-	q = fmt.Sprintf(`SELECT Id, Parent, ChildType, Children, Name, CreatorUserId, CreatorTime, EditorUserId, EditorTime FROM %s WHERE Id = ?;`, dbo.tableNames.Sections)
+	q = fmt.Sprintf(`SELECT Id, Parent, ChildType, Children, Name, IsPrivate, CreatorUserId, CreatorTime, EditorUserId, EditorTime FROM %s WHERE Id = ?;`, dbo.tableNames.Sections)
 	qs = append(qs, q)
 
 	// 5.
@@ -123,7 +220,7 @@ func (dbo *DatabaseObject) makePreparedStatementQueryStrings() (qs []string) {
 	qs = append(qs, q)
 
 	// 17.
-	q = fmt.Sprintf(`INSERT INTO %s (ThreadId, TEXT, TextChecksum, CreatorUserId, CreatorTime) VALUES (?, ?, ?, ?, Now());`, dbo.tableNames.Messages)
+	q = fmt.Sprintf(`INSERT INTO %s (ThreadId, TEXT, TextChecksum, TextHtml, CreatorUserId, CreatorTime) VALUES (?, ?, ?, ?, ?, Now());`, dbo.tableNames.Messages)
 	qs = append(qs, q)
 
 	// 18.
@@ -131,7 +228,7 @@ func (dbo *DatabaseObject) makePreparedStatementQueryStrings() (qs []string) {
 	qs = append(qs, q)
 
 	// 19.
-	q = fmt.Sprintf(`UPDATE %s SET TEXT = ?, TextChecksum = ?, EditorUserId = ?, EditorTime = Now() WHERE Id = ?;`, dbo.tableNames.Messages)
+	q = fmt.Sprintf(`UPDATE %s SET TEXT = ?, TextChecksum = ?, TextHtml = ?, EditorUserId = ?, EditorTime = Now() WHERE Id = ?;`, dbo.tableNames.Messages)
 	qs = append(qs, q)
 
 	// 20.
@@ -147,7 +244,7 @@ func (dbo *DatabaseObject) makePreparedStatementQueryStrings() (qs []string) {
 	qs = append(qs, q)
 
 	// 23.
-	q = fmt.Sprintf(`SELECT Id, ThreadId, Text, TextChecksum, CreatorUserId, CreatorTime, EditorUserId, EditorTime FROM %s WHERE Id = ?;`, dbo.tableNames.Messages)
+	q = fmt.Sprintf(`SELECT Id, ThreadId, Text, TextChecksum, TextHtml, CreatorUserId, CreatorTime, EditorUserId, EditorTime FROM %s WHERE Id = ?;`, dbo.tableNames.Messages)
 	qs = append(qs, q)
 
 	// 24.
@@ -155,7 +252,7 @@ func (dbo *DatabaseObject) makePreparedStatementQueryStrings() (qs []string) {
 	qs = append(qs, q)
 
 	// 25.
-	q = fmt.Sprintf(`SELECT Id, ForumId, Name, Messages, CreatorUserId, CreatorTime, EditorUserId, EditorTime FROM %s WHERE Id = ?;`, dbo.tableNames.Threads)
+	q = fmt.Sprintf(`SELECT Id, ForumId, Name, Messages, IsLocked, IsPinned, IsAnnouncement, CreatorUserId, CreatorTime, EditorUserId, EditorTime FROM %s WHERE Id = ?;`, dbo.tableNames.Threads)
 	qs = append(qs, q)
 
 	// 26.
@@ -163,7 +260,7 @@ func (dbo *DatabaseObject) makePreparedStatementQueryStrings() (qs []string) {
 	qs = append(qs, q)
 
 	// 27.
-	q = fmt.Sprintf(`SELECT Id, SectionId, Name, Threads, CreatorUserId, CreatorTime, EditorUserId, EditorTime FROM %s WHERE Id = ?;`, dbo.tableNames.Forums)
+	q = fmt.Sprintf(`SELECT Id, SectionId, Name, Threads, IsReadOnly, CreatorUserId, CreatorTime, EditorUserId, EditorTime FROM %s WHERE Id = ?;`, dbo.tableNames.Forums)
 	qs = append(qs, q)
 
 	// 28.
@@ -171,7 +268,7 @@ func (dbo *DatabaseObject) makePreparedStatementQueryStrings() (qs []string) {
 	qs = append(qs, q)
 
 	// 29.
-	q = fmt.Sprintf(`SELECT Id, SectionId, Name, Threads, CreatorUserId, CreatorTime, EditorUserId, EditorTime FROM %s;`, dbo.tableNames.Forums)
+	q = fmt.Sprintf(`SELECT Id, SectionId, Name, Threads, IsReadOnly, CreatorUserId, CreatorTime, EditorUserId, EditorTime FROM %s;`, dbo.tableNames.Forums)
 	qs = append(qs, q)
 
 	// 30.
@@ -218,6 +315,394 @@ func (dbo *DatabaseObject) makePreparedStatementQueryStrings() (qs []string) {
 	q = fmt.Sprintf(`SELECT Id, ForumId, Messages FROM %s;`, dbo.tableNames.Threads)
 	qs = append(qs, q)
 
+	// 41.
+	q = fmt.Sprintf(`INSERT INTO %s (Word, MessageId, Frequency) VALUES (?, ?, ?) ON DUPLICATE KEY UPDATE Frequency = Frequency + VALUES(Frequency);`, dbo.tableNames.MessageWords)
+	qs = append(qs, q)
+
+	// 42.
+	q = fmt.Sprintf(`DELETE FROM %s WHERE MessageId = ?;`, dbo.tableNames.MessageWords)
+	qs = append(qs, q)
+
+	// 43.
+	q = fmt.Sprintf(`INSERT INTO %s (Word, ThreadId, Frequency) VALUES (?, ?, ?) ON DUPLICATE KEY UPDATE Frequency = Frequency + VALUES(Frequency);`, dbo.tableNames.ThreadWords)
+	qs = append(qs, q)
+
+	// 44.
+	q = fmt.Sprintf(`DELETE FROM %s WHERE ThreadId = ?;`, dbo.tableNames.ThreadWords)
+	qs = append(qs, q)
+
+	// 45.
+	q = fmt.Sprintf(`INSERT INTO %s (MessageId, Text, TextChecksum, EditorUserId, EditorTime) VALUES (?, ?, ?, ?, ?);`, dbo.tableNames.MessageRevisions)
+	qs = append(qs, q)
+
+	// 46.
+	q = fmt.Sprintf(`SELECT Id, MessageId, Text, TextChecksum, EditorUserId, EditorTime FROM %s WHERE Id = ?;`, dbo.tableNames.MessageRevisions)
+	qs = append(qs, q)
+
+	// 47.
+	q = fmt.Sprintf(`SELECT Id, MessageId, Text, TextChecksum, EditorUserId, EditorTime FROM %s WHERE MessageId = ? ORDER BY Id DESC;`, dbo.tableNames.MessageRevisions)
+	qs = append(qs, q)
+
+	// 48.
+	q = fmt.Sprintf(`DELETE FROM %s WHERE MessageId = ?;`, dbo.tableNames.MessageRevisions)
+	qs = append(qs, q)
+
+	// 49.
+	q = fmt.Sprintf(`UPDATE %s SET IsPrivate = ?, EditorUserId = ?, EditorTime = Now() WHERE Id = ?;`, dbo.tableNames.Sections)
+	qs = append(qs, q)
+
+	// 50.
+	q = fmt.Sprintf(`UPDATE %s SET IsReadOnly = ?, EditorUserId = ?, EditorTime = Now() WHERE Id = ?;`, dbo.tableNames.Forums)
+	qs = append(qs, q)
+
+	// 51.
+	q = fmt.Sprintf(`INSERT INTO %s (Subject, LastMessageTime, CreatorUserId, CreatorTime) VALUES (?, Now(), ?, Now());`, dbo.tableNames.Conversations)
+	qs = append(qs, q)
+
+	// 52.
+	q = fmt.Sprintf(`INSERT INTO %s (ConversationId, UserId) VALUES (?, ?);`, dbo.tableNames.ConversationMembers)
+	qs = append(qs, q)
+
+	// 53.
+	q = fmt.Sprintf(`INSERT INTO %s (ConversationId, Text, CreatorUserId, CreatorTime) VALUES (?, ?, ?, Now());`, dbo.tableNames.PrivateMessages)
+	qs = append(qs, q)
+
+	// 54.
+	q = fmt.Sprintf(`UPDATE %s SET LastMessageTime = Now() WHERE Id = ?;`, dbo.tableNames.Conversations)
+	qs = append(qs, q)
+
+	// 55.
+	q = fmt.Sprintf(`SELECT c.Id, c.Subject, (SELECT JSON_ARRAYAGG(cm.UserId) FROM %[2]s AS cm WHERE cm.ConversationId = c.Id), (SELECT COUNT(*) FROM %[3]s AS pm WHERE pm.ConversationId = c.Id AND pm.Id > m.LastReadMessageId AND pm.CreatorUserId <> m.UserId), c.LastMessageTime, c.CreatorUserId, c.CreatorTime FROM %[1]s AS c INNER JOIN %[2]s AS m ON m.ConversationId = c.Id WHERE m.UserId = ? AND c.Id = ?;`, dbo.tableNames.Conversations, dbo.tableNames.ConversationMembers, dbo.tableNames.PrivateMessages)
+	qs = append(qs, q)
+
+	// 56.
+	q = fmt.Sprintf(`SELECT c.Id, c.Subject, (SELECT JSON_ARRAYAGG(cm.UserId) FROM %[2]s AS cm WHERE cm.ConversationId = c.Id), (SELECT COUNT(*) FROM %[3]s AS pm WHERE pm.ConversationId = c.Id AND pm.Id > m.LastReadMessageId AND pm.CreatorUserId <> m.UserId), c.LastMessageTime, c.CreatorUserId, c.CreatorTime FROM %[1]s AS c INNER JOIN %[2]s AS m ON m.ConversationId = c.Id WHERE m.UserId = ? ORDER BY c.LastMessageTime DESC, c.Id DESC LIMIT ? OFFSET ?;`, dbo.tableNames.Conversations, dbo.tableNames.ConversationMembers, dbo.tableNames.PrivateMessages)
+	qs = append(qs, q)
+
+	// 57.
+	q = fmt.Sprintf(`SELECT COUNT(*) FROM %s WHERE UserId = ?;`, dbo.tableNames.ConversationMembers)
+	qs = append(qs, q)
+
+	// 58.
+	q = fmt.Sprintf(`SELECT COUNT(*) FROM %s AS pm INNER JOIN %s AS m ON m.ConversationId = pm.ConversationId WHERE m.UserId = ? AND pm.Id > m.LastReadMessageId AND pm.CreatorUserId <> m.UserId;`, dbo.tableNames.PrivateMessages, dbo.tableNames.ConversationMembers)
+	qs = append(qs, q)
+
+	// 59.
+	q = fmt.Sprintf(`SELECT Id, ConversationId, Text, CreatorUserId, CreatorTime FROM %s WHERE ConversationId = ? ORDER BY Id LIMIT ? OFFSET ?;`, dbo.tableNames.PrivateMessages)
+	qs = append(qs, q)
+
+	// 60.
+	q = fmt.Sprintf(`SELECT COUNT(*) FROM %s WHERE ConversationId = ?;`, dbo.tableNames.PrivateMessages)
+	qs = append(qs, q)
+
+	// 61.
+	q = fmt.Sprintf(`UPDATE %s SET LastReadMessageId = GREATEST(LastReadMessageId, ?) WHERE ConversationId = ? AND UserId = ?;`, dbo.tableNames.ConversationMembers)
+	qs = append(qs, q)
+
+	// 62.
+	q = fmt.Sprintf(`DELETE FROM %s WHERE ConversationId = ? AND UserId = ?;`, dbo.tableNames.ConversationMembers)
+	qs = append(qs, q)
+
+	// 63.
+	q = fmt.Sprintf(`SELECT COUNT(*) FROM %s WHERE ConversationId = ?;`, dbo.tableNames.ConversationMembers)
+	qs = append(qs, q)
+
+	// 64.
+	q = fmt.Sprintf(`DELETE FROM %s WHERE Id = ?;`, dbo.tableNames.Conversations)
+	qs = append(qs, q)
+
+	// 65.
+	q = fmt.Sprintf(`DELETE FROM %s WHERE ConversationId = ?;`, dbo.tableNames.PrivateMessages)
+	qs = append(qs, q)
+
+	// 66.
+	q = fmt.Sprintf(`INSERT IGNORE INTO %s (UserId, BlockedUserId, ToC) VALUES (?, ?, Now());`, dbo.tableNames.UserBlocks)
+	qs = append(qs, q)
+
+	// 67.
+	q = fmt.Sprintf(`DELETE FROM %s WHERE UserId = ? AND BlockedUserId = ?;`, dbo.tableNames.UserBlocks)
+	qs = append(qs, q)
+
+	// 68.
+	q = fmt.Sprintf(`SELECT BlockedUserId FROM %s WHERE UserId = ? ORDER BY BlockedUserId;`, dbo.tableNames.UserBlocks)
+	qs = append(qs, q)
+
+	// 69.
+	q = fmt.Sprintf(`SELECT COUNT(*) FROM %s WHERE UserId = ? AND BlockedUserId = ?;`, dbo.tableNames.UserBlocks)
+	qs = append(qs, q)
+
+	// 70.
+	q = fmt.Sprintf(`INSERT INTO %s (MessageId, UserId, Emoji, AuthorUserId, Weight, ToC) VALUES (?, ?, ?, ?, ?, Now());`, dbo.tableNames.MessageReactions)
+	qs = append(qs, q)
+
+	// 71.
+	q = fmt.Sprintf(`SELECT MessageId, UserId, Emoji, AuthorUserId, Weight, ToC FROM %s WHERE MessageId = ? AND UserId = ? AND Emoji = ?;`, dbo.tableNames.MessageReactions)
+	qs = append(qs, q)
+
+	// 72.
+	q = fmt.Sprintf(`DELETE FROM %s WHERE MessageId = ? AND UserId = ? AND Emoji = ?;`, dbo.tableNames.MessageReactions)
+	qs = append(qs, q)
+
+	// 73.
+	q = fmt.Sprintf(`DELETE FROM %s WHERE MessageId = ?;`, dbo.tableNames.MessageReactions)
+	qs = append(qs, q)
+
+	// 74.
+	q = fmt.Sprintf(`INSERT INTO %s (UserId, Reputation, ToU) VALUES (?, ?, Now()) ON DUPLICATE KEY UPDATE Reputation = Reputation + VALUES(Reputation), ToU = Now();`, dbo.tableNames.UserReputations)
+	qs = append(qs, q)
+
+	// 75.
+	q = fmt.Sprintf(`SELECT IFNULL(SUM(Reputation), 0) FROM %s WHERE UserId = ?;`, dbo.tableNames.UserReputations)
+	qs = append(qs, q)
+
+	// 76.
+	q = fmt.Sprintf(`INSERT INTO %s (ThreadId, Question, IsMultipleChoice, AreResultsHidden, ClosingTime, IsClosed, ToC) VALUES (?, ?, ?, ?, ?, FALSE, Now());`, dbo.tableNames.Polls)
+	qs = append(qs, q)
+
+	// 77.
+	q = fmt.Sprintf(`INSERT INTO %s (PollId, Text) VALUES (?, ?);`, dbo.tableNames.PollOptions)
+	qs = append(qs, q)
+
+	// 78.
+	q = fmt.Sprintf(`SELECT Id, ThreadId, Question, IsMultipleChoice, AreResultsHidden, ClosingTime, IsClosed, ToC FROM %s WHERE ThreadId = ?;`, dbo.tableNames.Polls)
+	qs = append(qs, q)
+
+	// 79.
+	q = fmt.Sprintf(`SELECT Id, PollId, Text FROM %s WHERE PollId = ? ORDER BY Id;`, dbo.tableNames.PollOptions)
+	qs = append(qs, q)
+
+	// 80.
+	q = fmt.Sprintf(`INSERT INTO %s (PollId, UserId, ToC) VALUES (?, ?, Now());`, dbo.tableNames.PollVoters)
+	qs = append(qs, q)
+
+	// 81.
+	q = fmt.Sprintf(`SELECT COUNT(*) FROM %s WHERE PollId = ? AND UserId = ?;`, dbo.tableNames.PollVoters)
+	qs = append(qs, q)
+
+	// 82.
+	q = fmt.Sprintf(`UPDATE %s SET ToE = Now() WHERE PollId = ? AND UserId = ?;`, dbo.tableNames.PollVoters)
+	qs = append(qs, q)
+
+	// 83.
+	q = fmt.Sprintf(`INSERT INTO %s (PollId, UserId, OptionId) VALUES (?, ?, ?);`, dbo.tableNames.PollVotes)
+	qs = append(qs, q)
+
+	// 84.
+	q = fmt.Sprintf(`DELETE FROM %s WHERE PollId = ? AND UserId = ?;`, dbo.tableNames.PollVotes)
+	qs = append(qs, q)
+
+	// 85.
+	q = fmt.Sprintf(`SELECT OptionId FROM %s WHERE PollId = ? AND UserId = ? ORDER BY OptionId;`, dbo.tableNames.PollVotes)
+	qs = append(qs, q)
+
+	// 86.
+	q = fmt.Sprintf(`SELECT o.Id, COUNT(v.OptionId) FROM %s AS o LEFT JOIN %s AS v ON v.OptionId = o.Id WHERE o.PollId = ? GROUP BY o.Id ORDER BY o.Id;`, dbo.tableNames.PollOptions, dbo.tableNames.PollVotes)
+	qs = append(qs, q)
+
+	// 87.
+	q = fmt.Sprintf(`SELECT COUNT(*) FROM %s WHERE PollId = ?;`, dbo.tableNames.PollVoters)
+	qs = append(qs, q)
+
+	// 88.
+	q = fmt.Sprintf(`SELECT p.ThreadId FROM %s AS p WHERE p.IsClosed = FALSE AND p.ClosingTime <= Now() AND EXISTS (SELECT 1 FROM %s AS t WHERE t.Id = p.ThreadId);`, dbo.tableNames.Polls, dbo.tableNames.Threads)
+	qs = append(qs, q)
+
+	// 89.
+	q = fmt.Sprintf(`UPDATE %s SET IsClosed = TRUE WHERE ThreadId = ?;`, dbo.tableNames.Polls)
+	qs = append(qs, q)
+
+	// 90.
+	q = fmt.Sprintf(`DELETE FROM %s WHERE Id = ?;`, dbo.tableNames.Polls)
+	qs = append(qs, q)
+
+	// 91.
+	q = fmt.Sprintf(`DELETE FROM %s WHERE PollId = ?;`, dbo.tableNames.PollOptions)
+	qs = append(qs, q)
+
+	// 92.
+	q = fmt.Sprintf(`DELETE FROM %s WHERE PollId = ?;`, dbo.tableNames.PollVoters)
+	qs = append(qs, q)
+
+	// 93.
+	q = fmt.Sprintf(`DELETE FROM %s WHERE PollId = ?;`, dbo.tableNames.PollVotes)
+	qs = append(qs, q)
+
+	// 94.
+	q = fmt.Sprintf(`INSERT INTO %s (MessageId, Name, ContentType, Size, BlobHash, ThumbnailHash, ToC) VALUES (?, ?, ?, ?, ?, ?, Now());`, dbo.tableNames.Attachments)
+	qs = append(qs, q)
+
+	// 95.
+	q = fmt.Sprintf(`SELECT a.Id, a.MessageId, a.Name, a.ContentType, a.Size, a.BlobHash, a.ThumbnailHash, a.ToC FROM %s AS a WHERE a.Id = ? AND EXISTS (SELECT 1 FROM %s AS m WHERE m.Id = a.MessageId);`, dbo.tableNames.Attachments, dbo.tableNames.Messages)
+	qs = append(qs, q)
+
+	// 96.
+	q = fmt.Sprintf(`DELETE FROM %s WHERE MessageId = ?;`, dbo.tableNames.Attachments)
+	qs = append(qs, q)
+
+	// 97.
+	q = fmt.Sprintf(`INSERT IGNORE INTO %s (Hash, Size, ToC) VALUES (?, ?, Now());`, dbo.tableNames.Blobs)
+	qs = append(qs, q)
+
+	// 98.
+	q = fmt.Sprintf(`SELECT b.Hash FROM %s AS b WHERE NOT EXISTS (SELECT 1 FROM %s AS a WHERE a.BlobHash = b.Hash) AND NOT EXISTS (SELECT 1 FROM %s AS a WHERE a.ThumbnailHash = b.Hash);`, dbo.tableNames.Blobs, dbo.tableNames.Attachments, dbo.tableNames.Attachments)
+	qs = append(qs, q)
+
+	// 99.
+	q = fmt.Sprintf(`DELETE FROM %s WHERE Hash = ?;`, dbo.tableNames.Blobs)
+	qs = append(qs, q)
+
+	// 100.
+	q = fmt.Sprintf(`INSERT INTO %s (ObjectType, ObjectId, Name, ParentId, Position, PreviousId, DeletedBy, DeletedAt) VALUES (?, ?, ?, ?, ?, ?, ?, Now());`, dbo.tableNames.Trash)
+	qs = append(qs, q)
+
+	// 101.
+	q = fmt.Sprintf(`SELECT Id, ObjectType, ObjectId, Name, ParentId, Position, PreviousId, DeletedBy, DeletedAt FROM %s WHERE Id = ?;`, dbo.tableNames.Trash)
+	qs = append(qs, q)
+
+	// 102.
+	q = fmt.Sprintf(`SELECT Id, ObjectType, ObjectId, Name, ParentId, Position, PreviousId, DeletedBy, DeletedAt FROM %s ORDER BY DeletedAt DESC, Id DESC LIMIT ? OFFSET ?;`, dbo.tableNames.Trash)
+	qs = append(qs, q)
+
+	// 103.
+	q = fmt.Sprintf(`SELECT COUNT(Id) FROM %s;`, dbo.tableNames.Trash)
+	qs = append(qs, q)
+
+	// 104.
+	q = fmt.Sprintf(`SELECT Id, ObjectType, ObjectId, Name, ParentId, Position, PreviousId, DeletedBy, DeletedAt FROM %s WHERE DeletedAt < DATE_SUB(Now(), INTERVAL ? DAY) ORDER BY Id;`, dbo.tableNames.Trash)
+	qs = append(qs, q)
+
+	// 105.
+	q = fmt.Sprintf(`DELETE FROM %s WHERE Id = ?;`, dbo.tableNames.Trash)
+	qs = append(qs, q)
+
+	// 106.
+	q = fmt.Sprintf(`INSERT INTO %s (Id, Parent, ChildType, Children, Name, IsPrivate, CreatorUserId, CreatorTime, EditorUserId, EditorTime) SELECT Id, Parent, ChildType, Children, Name, IsPrivate, CreatorUserId, CreatorTime, EditorUserId, EditorTime FROM %s WHERE Id = ?;`, dbo.tableNames.DeletedSections, dbo.tableNames.Sections)
+	qs = append(qs, q)
+
+	// 107.
+	q = fmt.Sprintf(`INSERT INTO %s (Id, Parent, ChildType, Children, Name, IsPrivate, CreatorUserId, CreatorTime, EditorUserId, EditorTime) SELECT Id, Parent, ChildType, Children, Name, IsPrivate, CreatorUserId, CreatorTime, EditorUserId, EditorTime FROM %s WHERE Id = ?;`, dbo.tableNames.Sections, dbo.tableNames.DeletedSections)
+	qs = append(qs, q)
+
+	// 108.
+	q = fmt.Sprintf(`DELETE FROM %s WHERE Id = ?;`, dbo.tableNames.DeletedSections)
+	qs = append(qs, q)
+
+	// 109.
+	q = fmt.Sprintf(`INSERT INTO %s (Id, SectionId, Name, Threads, IsReadOnly, CreatorUserId, CreatorTime, EditorUserId, EditorTime) SELECT Id, SectionId, Name, Threads, IsReadOnly, CreatorUserId, CreatorTime, EditorUserId, EditorTime FROM %s WHERE Id = ?;`, dbo.tableNames.DeletedForums, dbo.tableNames.Forums)
+	qs = append(qs, q)
+
+	// 110.
+	q = fmt.Sprintf(`INSERT INTO %s (Id, SectionId, Name, Threads, IsReadOnly, CreatorUserId, CreatorTime, EditorUserId, EditorTime) SELECT Id, SectionId, Name, Threads, IsReadOnly, CreatorUserId, CreatorTime, EditorUserId, EditorTime FROM %s WHERE Id = ?;`, dbo.tableNames.Forums, dbo.tableNames.DeletedForums)
+	qs = append(qs, q)
+
+	// 111.
+	q = fmt.Sprintf(`DELETE FROM %s WHERE Id = ?;`, dbo.tableNames.DeletedForums)
+	qs = append(qs, q)
+
+	// 112.
+	q = fmt.Sprintf(`INSERT INTO %s (Id, ForumId, Name, Messages, IsLocked, IsPinned, IsAnnouncement, CreatorUserId, CreatorTime, EditorUserId, EditorTime) SELECT Id, ForumId, Name, Messages, IsLocked, IsPinned, IsAnnouncement, CreatorUserId, CreatorTime, EditorUserId, EditorTime FROM %s WHERE Id = ?;`, dbo.tableNames.DeletedThreads, dbo.tableNames.Threads)
+	qs = append(qs, q)
+
+	// 113.
+	q = fmt.Sprintf(`INSERT INTO %s (Id, ForumId, Name, Messages, IsLocked, IsPinned, IsAnnouncement, CreatorUserId, CreatorTime, EditorUserId, EditorTime) SELECT Id, ForumId, Name, Messages, IsLocked, IsPinned, IsAnnouncement, CreatorUserId, CreatorTime, EditorUserId, EditorTime FROM %s WHERE Id = ?;`, dbo.tableNames.Threads, dbo.tableNames.DeletedThreads)
+	qs = append(qs, q)
+
+	// 114.
+	q = fmt.Sprintf(`DELETE FROM %s WHERE Id = ?;`, dbo.tableNames.DeletedThreads)
+	qs = append(qs, q)
+
+	// 115.
+	q = fmt.Sprintf(`INSERT INTO %s (Id, ThreadId, Text, TextChecksum, TextHtml, CreatorUserId, CreatorTime, EditorUserId, EditorTime) SELECT Id, ThreadId, Text, TextChecksum, TextHtml, CreatorUserId, CreatorTime, EditorUserId, EditorTime FROM %s WHERE Id = ?;`, dbo.tableNames.DeletedMessages, dbo.tableNames.Messages)
+	qs = append(qs, q)
+
+	// 116.
+	q = fmt.Sprintf(`INSERT INTO %s (Id, ThreadId, Text, TextChecksum, TextHtml, CreatorUserId, CreatorTime, EditorUserId, EditorTime) SELECT Id, ThreadId, Text, TextChecksum, TextHtml, CreatorUserId, CreatorTime, EditorUserId, EditorTime FROM %s WHERE Id = ?;`, dbo.tableNames.Messages, dbo.tableNames.DeletedMessages)
+	qs = append(qs, q)
+
+	// 117.
+	q = fmt.Sprintf(`DELETE FROM %s WHERE Id = ?;`, dbo.tableNames.DeletedMessages)
+	qs = append(qs, q)
+
+	// 118.
+	q = fmt.Sprintf(`SELECT Id, ThreadId FROM %s ORDER BY Id;`, dbo.tableNames.Messages)
+	qs = append(qs, q)
+
+	// 119.
+	q = fmt.Sprintf(`INSERT INTO %s (MessageId, ThreadId, AuthorUserId, ReporterUserId, Reason, Comment, ToC) VALUES (?, ?, ?, ?, ?, ?, Now());`, dbo.tableNames.MessageReports)
+	qs = append(qs, q)
+
+	// 120.
+	q = fmt.Sprintf(`SELECT COUNT(*) FROM %s WHERE MessageId = ? AND ReporterUserId = ? AND DecisionId IS NULL;`, dbo.tableNames.MessageReports)
+	qs = append(qs, q)
+
+	// 121.
+	q = fmt.Sprintf(`SELECT r.MessageId FROM %s AS r INNER JOIN %s AS m ON m.Id = r.MessageId WHERE r.DecisionId IS NULL GROUP BY r.MessageId ORDER BY MIN(r.Id) LIMIT ? OFFSET ?;`, dbo.tableNames.MessageReports, dbo.tableNames.Messages)
+	qs = append(qs, q)
+
+	// 122.
+	q = fmt.Sprintf(`SELECT COUNT(DISTINCT r.MessageId) FROM %s AS r INNER JOIN %s AS m ON m.Id = r.MessageId WHERE r.DecisionId IS NULL;`, dbo.tableNames.MessageReports, dbo.tableNames.Messages)
+	qs = append(qs, q)
+
+	// 123.
+	q = fmt.Sprintf(`SELECT Id, MessageId, ThreadId, AuthorUserId, ReporterUserId, Reason, Comment, ToC, DecisionId FROM %s WHERE MessageId = ? AND DecisionId IS NULL ORDER BY Id;`, dbo.tableNames.MessageReports)
+	qs = append(qs, q)
+
+	// 124.
+	q = fmt.Sprintf(`INSERT INTO %s (MessageId, ThreadId, AuthorUserId, Action, Comment, ReportsCount, ModeratorUserId, ToC) VALUES (?, ?, ?, ?, ?, ?, ?, Now());`, dbo.tableNames.ModerationDecisions)
+	qs = append(qs, q)
+
+	// 125.
+	q = fmt.Sprintf(`UPDATE %s SET DecisionId = ? WHERE MessageId = ? AND DecisionId IS NULL AND Id <= ?;`, dbo.tableNames.MessageReports)
+	qs = append(qs, q)
+
+	// 126.
+	q = fmt.Sprintf(`SELECT Id, MessageId, ThreadId, AuthorUserId, Action, Comment, ReportsCount, ModeratorUserId, ToC FROM %s ORDER BY Id DESC LIMIT ? OFFSET ?;`, dbo.tableNames.ModerationDecisions)
+	qs = append(qs, q)
+
+	// 127.
+	q = fmt.Sprintf(`SELECT COUNT(*) FROM %s;`, dbo.tableNames.ModerationDecisions)
+	qs = append(qs, q)
+
+	// 128.
+	q = fmt.Sprintf(`UPDATE %s SET IsLocked = ?, EditorUserId = ?, EditorTime = Now() WHERE Id = ?;`, dbo.tableNames.Threads)
+	qs = append(qs, q)
+
+	// 129.
+	q = fmt.Sprintf(`DELETE FROM %s WHERE MessageId = ? AND DecisionId IS NULL;`, dbo.tableNames.MessageReports)
+	qs = append(qs, q)
+
+	// 130.
+	q = fmt.Sprintf(`UPDATE %s SET IsPinned = ?, EditorUserId = ?, EditorTime = Now() WHERE Id = ?;`, dbo.tableNames.Threads)
+	qs = append(qs, q)
+
+	// 131.
+	q = fmt.Sprintf(`UPDATE %s SET IsAnnouncement = ?, EditorUserId = ?, EditorTime = Now() WHERE Id = ?;`, dbo.tableNames.Threads)
+	qs = append(qs, q)
+
+	// 132.
+	q = fmt.Sprintf(`SELECT Id FROM %s WHERE ForumId = ? AND (IsPinned OR IsAnnouncement);`, dbo.tableNames.Threads)
+	qs = append(qs, q)
+
+	// 133.
+	q = fmt.Sprintf(`SELECT t.Id, t.ForumId, t.Name, t.Messages, t.IsLocked, t.IsPinned, t.IsAnnouncement, t.CreatorUserId, t.CreatorTime, t.EditorUserId, t.EditorTime FROM %s AS t INNER JOIN %s AS f ON f.Id = t.ForumId WHERE f.SectionId = ? AND t.ForumId <> ? AND t.IsAnnouncement ORDER BY t.Id DESC;`, dbo.tableNames.Threads, dbo.tableNames.Forums)
+	qs = append(qs, q)
+
+	// 134.
+	q = fmt.Sprintf(`SELECT Id, Text FROM %s WHERE TextHtml = '' AND Id > ? ORDER BY Id LIMIT ?;`, dbo.tableNames.Messages)
+	qs = append(qs, q)
+
+	// 135.
+	q = fmt.Sprintf(`UPDATE %s SET TextHtml = ? WHERE Id = ?;`, dbo.tableNames.Messages)
+	qs = append(qs, q)
+
+	// 136.
+	q = fmt.Sprintf(`SELECT m.Id, m.Text FROM %s AS m WHERE m.Id > ? AND NOT EXISTS (SELECT 1 FROM %s AS w WHERE w.MessageId = m.Id) ORDER BY m.Id LIMIT ?;`, dbo.tableNames.Messages, dbo.tableNames.MessageWords)
+	qs = append(qs, q)
+
+	// 137.
+	q = fmt.Sprintf(`SELECT t.Id, t.Name FROM %s AS t WHERE t.Id > ? AND NOT EXISTS (SELECT 1 FROM %s AS w WHERE w.ThreadId = t.Id) ORDER BY t.Id LIMIT ?;`, dbo.tableNames.Threads, dbo.tableNames.ThreadWords)
+	qs = append(qs, q)
+
 	return qs
 }
 
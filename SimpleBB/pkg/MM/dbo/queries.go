@@ -1,7 +1,10 @@
 package dbo
 
 import (
+	mm "github.com/vault-thirteen/SimpleBB/pkg/MM/models"
 	"github.com/vault-thirteen/SimpleBB/pkg/common/models/UidList"
+	base2 "github.com/vault-thirteen/SimpleBB/pkg/common/models/base"
+	"strings"
 )
 
 func (dbo *DatabaseObject) dbQuery_ReadMessagesById(messageIds ul.UidList) (query string, err error) {
@@ -11,7 +14,7 @@ func (dbo *DatabaseObject) dbQuery_ReadMessagesById(messageIds ul.UidList) (quer
 		return "", err
 	}
 
-	return `SELECT Id, ThreadId, Text, TextChecksum, CreatorUserId, CreatorTime, EditorUserId, EditorTime FROM ` + dbo.tableNames.Messages + ` WHERE Id IN (` + vs + `) ORDER BY FIND_IN_SET(Id, '` + vs + `');`, nil
+	return `SELECT Id, ThreadId, Text, TextChecksum, TextHtml, CreatorUserId, CreatorTime, EditorUserId, EditorTime FROM ` + dbo.tableNames.Messages + ` WHERE Id IN (` + vs + `) ORDER BY FIND_IN_SET(Id, '` + vs + `');`, nil
 }
 
 func (dbo *DatabaseObject) dbQuery_ReadMessageLinksById(messageIds ul.UidList) (query string, err error) {
@@ -41,5 +44,124 @@ func (dbo *DatabaseObject) dbQuery_ReadThreadsById(threadIds ul.UidList) (query
 		return "", err
 	}
 
-	return `SELECT Id, ForumId, Name, Messages, CreatorUserId, CreatorTime, EditorUserId, EditorTime FROM ` + dbo.tableNames.Threads + ` WHERE Id IN (` + vs + `) ORDER BY FIND_IN_SET(Id, '` + vs + `');`, nil
+	return `SELECT Id, ForumId, Name, Messages, IsLocked, IsPinned, IsAnnouncement, CreatorUserId, CreatorTime, EditorUserId, EditorTime FROM ` + dbo.tableNames.Threads + ` WHERE Id IN (` + vs + `) ORDER BY FIND_IN_SET(Id, '` + vs + `');`, nil
+}
+
+// dbQuery_ReadMessageReactionCountsById composes a query which counts
+// reactions of each type on messages. The user's own reactions are marked.
+// Reactions of a message are ordered by time of the first reaction.
+func (dbo *DatabaseObject) dbQuery_ReadMessageReactionCountsById(messageIds ul.UidList, userId base2.Id) (query string, args []any, err error) {
+	var vs string
+	vs, err = messageIds.ValuesString()
+	if err != nil {
+		return "", nil, err
+	}
+
+	return `SELECT MessageId, Emoji, COUNT(*), MAX(UserId = ?) FROM ` + dbo.tableNames.MessageReactions + ` WHERE MessageId IN (` + vs + `) GROUP BY MessageId, Emoji ORDER BY FIND_IN_SET(MessageId, '` + vs + `'), MIN(ToC), Emoji;`, []any{userId}, nil
+}
+
+// dbQuery_ReadAttachmentsByMessageIds composes a query which reads
+// attachments of messages. Attachments of a message are ordered as they were
+// attached.
+func (dbo *DatabaseObject) dbQuery_ReadAttachmentsByMessageIds(messageIds ul.UidList) (query string, err error) {
+	var vs string
+	vs, err = messageIds.ValuesString()
+	if err != nil {
+		return "", err
+	}
+
+	return `SELECT Id, MessageId, Name, ContentType, Size, BlobHash, ThumbnailHash, ToC FROM ` + dbo.tableNames.Attachments + ` WHERE MessageId IN (` + vs + `) ORDER BY FIND_IN_SET(MessageId, '` + vs + `'), Id;`, nil
+}
+
+// dbQuery_ReadOpenReportsByMessageIds composes a query which reads open
+// reports about messages. Reports of a message are ordered by time.
+func (dbo *DatabaseObject) dbQuery_ReadOpenReportsByMessageIds(messageIds ul.UidList) (query string, err error) {
+	var vs string
+	vs, err = messageIds.ValuesString()
+	if err != nil {
+		return "", err
+	}
+
+	return `SELECT Id, MessageId, ThreadId, AuthorUserId, ReporterUserId, Reason, Comment, ToC, DecisionId FROM ` + dbo.tableNames.MessageReports + ` WHERE MessageId IN (` + vs + `) AND DecisionId IS NULL ORDER BY FIND_IN_SET(MessageId, '` + vs + `'), Id;`, nil
+}
+
+// dbQuery_SearchCondition composes the 'WHERE' part of a search query.
+// Words table must be aliased as 'w', threads table must be aliased as 't',
+// the searched object (message or thread) must be aliased as 'o'.
+func dbQuery_SearchCondition(sf *mm.SearchFilter) (condition string, args []any) {
+	args = make([]any, 0, len(sf.Words)+4)
+
+	placeholders := strings.TrimSuffix(strings.Repeat(`?, `, len(sf.Words)), `, `)
+	condition = `w.Word IN (` + placeholders + `)`
+	for _, word := range sf.Words {
+		args = append(args, word)
+	}
+
+	if sf.ForumId != nil {
+		condition += ` AND t.ForumId = ?`
+		args = append(args, *sf.ForumId)
+	}
+
+	if sf.ForumIds != nil {
+		if len(sf.ForumIds) == 0 {
+			condition += ` AND FALSE`
+		} else {
+			condition += ` AND t.ForumId IN (` + strings.TrimSuffix(strings.Repeat(`?, `, len(sf.ForumIds)), `, `) + `)`
+			for _, forumId := range sf.ForumIds {
+				args = append(args, forumId)
+			}
+		}
+	}
+
+	if sf.CreatorUserId != nil {
+		condition += ` AND o.CreatorUserId = ?`
+		args = append(args, *sf.CreatorUserId)
+	}
+
+	if sf.FromTime != nil {
+		condition += ` AND o.CreatorTime >= ?`
+		args = append(args, *sf.FromTime)
+	}
+
+	if sf.ToTime != nil {
+		condition += ` AND o.CreatorTime <= ?`
+		args = append(args, *sf.ToTime)
+	}
+
+	return condition, args
+}
+
+// dbQuery_SearchMessages composes a query which finds messages on a page.
+// Messages containing more of the searched words go first, then go messages
+// where these words are used more often, then go newer messages.
+func (dbo *DatabaseObject) dbQuery_SearchMessages(sf *mm.SearchFilter, pageNumber base2.Count, pageSize base2.Count) (query string, args []any) {
+	var condition string
+	condition, args = dbQuery_SearchCondition(sf)
+	args = append(args, pageSize, pageSize*(pageNumber-1))
+
+	return `SELECT w.MessageId FROM ` + dbo.tableNames.MessageWords + ` AS w INNER JOIN ` + dbo.tableNames.Messages + ` AS o ON o.Id = w.MessageId INNER JOIN ` + dbo.tableNames.Threads + ` AS t ON t.Id = o.ThreadId WHERE ` + condition + ` GROUP BY w.MessageId ORDER BY COUNT(w.Word) DESC, SUM(w.Frequency) DESC, w.MessageId DESC LIMIT ? OFFSET ?;`, args
+}
+
+func (dbo *DatabaseObject) dbQuery_CountMessagesFound(sf *mm.SearchFilter) (query string, args []any) {
+	var condition string
+	condition, args = dbQuery_SearchCondition(sf)
+
+	return `SELECT COUNT(DISTINCT w.MessageId) FROM ` + dbo.tableNames.MessageWords + ` AS w INNER JOIN ` + dbo.tableNames.Messages + ` AS o ON o.Id = w.MessageId INNER JOIN ` + dbo.tableNames.Threads + ` AS t ON t.Id = o.ThreadId WHERE ` + condition + `;`, args
+}
+
+// dbQuery_SearchThreads composes a query which finds threads on a page.
+// Threads are ordered in the same way as messages.
+func (dbo *DatabaseObject) dbQuery_SearchThreads(sf *mm.SearchFilter, pageNumber base2.Count, pageSize base2.Count) (query string, args []any) {
+	var condition string
+	condition, args = dbQuery_SearchCondition(sf)
+	args = append(args, pageSize, pageSize*(pageNumber-1))
+
+	return `SELECT w.ThreadId FROM ` + dbo.tableNames.ThreadWords + ` AS w INNER JOIN ` + dbo.tableNames.Threads + ` AS o ON o.Id = w.ThreadId INNER JOIN ` + dbo.tableNames.Threads + ` AS t ON t.Id = o.Id WHERE ` + condition + ` GROUP BY w.ThreadId ORDER BY COUNT(w.Word) DESC, SUM(w.Frequency) DESC, w.ThreadId DESC LIMIT ? OFFSET ?;`, args
+}
+
+func (dbo *DatabaseObject) dbQuery_CountThreadsFound(sf *mm.SearchFilter) (query string, args []any) {
+	var condition string
+	condition, args = dbQuery_SearchCondition(sf)
+
+	return `SELECT COUNT(DISTINCT w.ThreadId) FROM ` + dbo.tableNames.ThreadWords + ` AS w INNER JOIN ` + dbo.tableNames.Threads + ` AS o ON o.Id = w.ThreadId INNER JOIN ` + dbo.tableNames.Threads + ` AS t ON t.Id = o.Id WHERE ` + condition + `;`, args
 }
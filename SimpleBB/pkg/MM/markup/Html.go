@@ -0,0 +1,100 @@
+package markup
+
+import (
+	"html"
+	"strings"
+
+	cmb "github.com/vault-thirteen/SimpleBB/pkg/common/models/base"
+)
+
+const (
+	// LinkRel is the relationship of all links. Links are written by users,
+	// so they are not endorsed by the forum and pages opened by them get no
+	// access to the forum's page.
+	LinkRel = "nofollow noopener noreferrer ugc"
+
+	QuoteClass   = "quote"
+	MentionClass = "mention"
+)
+
+// Html renders the document as HTML. All the texts are escaped, so the result
+// contains only the tags produced by this function. Users are identifiers of
+// mentioned users by their names; mentions of unknown users are rendered as
+// a plain text.
+func (doc *Document) Html(users map[string]cmb.Id) string {
+	var sb strings.Builder
+	renderNodes(&sb, doc.Nodes, users)
+	return sb.String()
+}
+
+func renderNodes(sb *strings.Builder, nodes []*Node, users map[string]cmb.Id) {
+	for _, n := range nodes {
+		renderNode(sb, n, users)
+	}
+}
+
+func renderNode(sb *strings.Builder, n *Node, users map[string]cmb.Id) {
+	switch n.Type {
+	case NodeType_Paragraph:
+		renderElement(sb, "p", n.Children, users)
+
+	case NodeType_CodeBlock:
+		sb.WriteString(`<pre><code>`)
+		sb.WriteString(html.EscapeString(n.Text))
+		sb.WriteString(`</code></pre>`)
+
+	case NodeType_Quote:
+		sb.WriteString(`<blockquote class="` + QuoteClass + `"`)
+		if n.MessageId != 0 {
+			sb.WriteString(` data-message-id="` + n.MessageId.ToString() + `"`)
+		}
+		sb.WriteString(`>`)
+		renderNodes(sb, n.Children, users)
+		sb.WriteString(`</blockquote>`)
+
+	case NodeType_Text:
+		sb.WriteString(html.EscapeString(n.Text))
+
+	case NodeType_LineBreak:
+		sb.WriteString(`<br>`)
+
+	case NodeType_Bold:
+		renderElement(sb, "strong", n.Children, users)
+
+	case NodeType_Italic:
+		renderElement(sb, "em", n.Children, users)
+
+	case NodeType_Underline:
+		renderElement(sb, "u", n.Children, users)
+
+	case NodeType_Strike:
+		renderElement(sb, "s", n.Children, users)
+
+	case NodeType_InlineCode:
+		sb.WriteString(`<code>`)
+		sb.WriteString(html.EscapeString(n.Text))
+		sb.WriteString(`</code>`)
+
+	case NodeType_Link:
+		sb.WriteString(`<a href="` + html.EscapeString(n.Url) + `" rel="` + LinkRel + `">`)
+		renderNodes(sb, n.Children, users)
+		sb.WriteString(`</a>`)
+
+	case NodeType_Mention:
+		userId, ok := users[n.Text]
+		if !ok {
+			sb.WriteString(html.EscapeString("@" + n.Text))
+			return
+		}
+
+		sb.WriteString(`<span class="` + MentionClass + `" data-user-id="` + userId.ToString() + `">`)
+		sb.WriteString(html.EscapeString("@" + n.Text))
+		sb.WriteString(`</span>`)
+	}
+}
+
+func renderElement(sb *strings.Builder, tag string, children []*Node, users map[string]cmb.Id) {
+	sb.WriteString(`<` + tag + `>`)
+	renderNodes(sb, children, users)
+	sb.WriteString(`</` + tag + `>`)
+}
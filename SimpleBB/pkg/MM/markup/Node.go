@@ -0,0 +1,108 @@
+package markup
+
+import (
+	cmb "github.com/vault-thirteen/SimpleBB/pkg/common/models/base"
+)
+
+// NodeType is a type of node of a parsed message.
+type NodeType byte
+
+const (
+	// Block nodes.
+	NodeType_Paragraph = NodeType(1)
+	NodeType_CodeBlock = NodeType(2)
+	NodeType_Quote     = NodeType(3)
+
+	// Inline nodes.
+	NodeType_Text       = NodeType(4)
+	NodeType_LineBreak  = NodeType(5)
+	NodeType_Bold       = NodeType(6)
+	NodeType_Italic     = NodeType(7)
+	NodeType_Underline  = NodeType(8)
+	NodeType_Strike     = NodeType(9)
+	NodeType_InlineCode = NodeType(10)
+	NodeType_Link       = NodeType(11)
+	NodeType_Mention    = NodeType(12)
+)
+
+// Node is a node of a parsed message.
+type Node struct {
+	Type NodeType
+
+	// Text of a text node, of a code, or a name of a mentioned user.
+	Text string
+
+	// Address of a link.
+	Url string
+
+	// Identifier of a quoted message. Zero when a quote has no source.
+	MessageId cmb.Id
+
+	Children []*Node
+}
+
+// Document is a parsed message.
+type Document struct {
+	Nodes []*Node
+}
+
+// Mentions returns names of all the users mentioned in the document. Each
+// name is returned once, in order of appearance.
+func (doc *Document) Mentions() (names []string) {
+	return doc.mentions(true)
+}
+
+// DirectMentions returns names of the users mentioned in the document outside
+// of quotes. Mentions inside quotes belong to the quoted text, so users
+// mentioned there are not notified once more.
+func (doc *Document) DirectMentions() (names []string) {
+	return doc.mentions(false)
+}
+
+// QuotedMessages returns identifiers of all the quoted messages. Each
+// identifier is returned once, in order of appearance.
+func (doc *Document) QuotedMessages() (ids []cmb.Id) {
+	ids = []cmb.Id{}
+	var isKnown = make(map[cmb.Id]bool)
+
+	walk(doc.Nodes, true, func(n *Node) {
+		if (n.Type != NodeType_Quote) || (n.MessageId == 0) || isKnown[n.MessageId] {
+			return
+		}
+
+		isKnown[n.MessageId] = true
+		ids = append(ids, n.MessageId)
+	})
+
+	return ids
+}
+
+func (doc *Document) mentions(withQuotes bool) (names []string) {
+	names = []string{}
+	var isKnown = make(map[string]bool)
+
+	walk(doc.Nodes, withQuotes, func(n *Node) {
+		if (n.Type != NodeType_Mention) || isKnown[n.Text] {
+			return
+		}
+
+		isKnown[n.Text] = true
+		names = append(names, n.Text)
+	})
+
+	return names
+}
+
+// walk visits nodes in depth-first order. Contents of quotes are visited only
+// when the flag is set.
+func walk(nodes []*Node, withQuotes bool, visit func(n *Node)) {
+	for _, n := range nodes {
+		visit(n)
+
+		if (n.Type == NodeType_Quote) && !withQuotes {
+			continue
+		}
+
+		walk(n.Children, withQuotes, visit)
+	}
+}
@@ -0,0 +1,730 @@
+package markup
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	cmb "github.com/vault-thirteen/SimpleBB/pkg/common/models/base"
+)
+
+const (
+	// NestingDepthMax is the maximal depth of nested quotes and nested
+	// formatting. Deeper mark-up is left as a plain text.
+	NestingDepthMax = 8
+
+	// UrlLengthMax is the maximal length of a link's address in bytes.
+	UrlLengthMax = 2048
+)
+
+// Mark-up of blocks.
+const (
+	codeFence       = "```"
+	quoteLinePrefix = ">"
+	quoteTagOpen    = "[quote"
+	quoteTagClose   = "[/quote]"
+)
+
+// Mark-up of links.
+const (
+	urlTagOpen         = "[url]"
+	urlTagWithAddress  = "[url="
+	urlTagClose        = "[/url]"
+	autoLinkPrefixHttp = "http://"
+	autoLinkPrefixSsl  = "https://"
+
+	// Symbols which are not a part of an automatic link when they end it,
+	// e.g. a full stop after an address.
+	autoLinkTrailingSymbols = ".,:;!?)'\""
+)
+
+// Schemes of links which are allowed.
+const (
+	UrlSchemeHttp   = "http"
+	UrlSchemeHttps  = "https"
+	UrlSchemeMailto = "mailto"
+)
+
+// inlineTag is a pair of delimiters of an inline element.
+type inlineTag struct {
+	open     string
+	close    string
+	nodeType NodeType
+}
+
+// BBCode tags of inline elements. Tags are case-insensitive.
+var bbCodeTags = []inlineTag{
+	{"[b]", "[/b]", NodeType_Bold},
+	{"[i]", "[/i]", NodeType_Italic},
+	{"[u]", "[/u]", NodeType_Underline},
+	{"[s]", "[/s]", NodeType_Strike},
+	{"[code]", "[/code]", NodeType_InlineCode},
+}
+
+// Parse parses a message written in a restricted dialect of BBCode and
+// Markdown.
+//
+// Blocks are separated by empty lines. A block is either a paragraph, a code
+// block fenced with '```' lines, a quote of a message ('[quote=ID]' ...
+// '[/quote]'), an anonymous quote ('[quote]' ... '[/quote]') or a group of
+// lines starting with '>'. Inline elements are bold ('**', '[b]'), italic
+// ('*', '[i]'), underlined ('[u]') and struck ('~~', '[s]') texts, code ('`',
+// '[code]'), links ('[url]', '[url=...]', '[text](...)' and bare 'http://'
+// and 'https://' addresses) and mentions of users ('@name'). Anything else is
+// a plain text.
+func Parse(text string) (doc *Document) {
+	text = strings.ToValidUTF8(text, string(utf8.RuneError))
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	text = strings.ReplaceAll(text, "\r", "\n")
+
+	return &Document{
+		Nodes: parseBlocks(text, 0),
+	}
+}
+
+// Blocks.
+
+func parseBlocks(s string, depth int) (nodes []*Node) {
+	nodes = []*Node{}
+
+	var n *Node
+	for {
+		s = skipEmptyLines(s)
+		if len(s) == 0 {
+			return nodes
+		}
+
+		n, s = parseBlock(s, depth)
+		nodes = append(nodes, n)
+	}
+}
+
+func parseBlock(s string, depth int) (n *Node, rest string) {
+	if strings.HasPrefix(s, codeFence) {
+		return parseCodeBlock(s)
+	}
+
+	if depth < NestingDepthMax {
+		var ok bool
+		n, rest, ok = parseQuoteTag(s, depth)
+		if ok {
+			return n, rest
+		}
+
+		if strings.HasPrefix(s, quoteLinePrefix) {
+			return parseQuoteLines(s, depth)
+		}
+	}
+
+	return parseParagraph(s, depth)
+}
+
+// parseCodeBlock parses a fenced code block. The rest of the opening line
+// usually names a language, it is ignored. An unclosed block lasts till the
+// end of the text.
+func parseCodeBlock(s string) (n *Node, rest string) {
+	_, rest = splitLine(s)
+
+	var line string
+	var lines = []string{}
+	for len(rest) > 0 {
+		line, rest = splitLine(rest)
+		if strings.TrimSpace(line) == codeFence {
+			break
+		}
+
+		lines = append(lines, line)
+	}
+
+	return &Node{Type: NodeType_CodeBlock, Text: strings.Join(lines, "\n")}, rest
+}
+
+// parseQuoteTag parses a quote made with BBCode tags. Quotes may be nested.
+// The flag is not set when the text does not start with a valid quote.
+func parseQuoteTag(s string, depth int) (n *Node, rest string, ok bool) {
+	if !isQuoteTag(s) {
+		return nil, s, false
+	}
+
+	end := strings.IndexAny(s, "]\n")
+	if (end < 0) || (s[end] != ']') {
+		return nil, s, false
+	}
+
+	var messageId cmb.Id
+	attribute := s[len(quoteTagOpen):end]
+	if len(attribute) > 0 {
+		messageId, ok = parseMessageId(attribute)
+		if !ok {
+			return nil, s, false
+		}
+	}
+
+	body := s[end+1:]
+	closing := findQuoteClosingTag(body)
+	if closing < 0 {
+		return nil, s, false
+	}
+
+	n = &Node{
+		Type:      NodeType_Quote,
+		MessageId: messageId,
+		Children:  parseBlocks(body[:closing], depth+1),
+	}
+
+	return n, body[closing+len(quoteTagClose):], true
+}
+
+// parseMessageId parses the attribute of a quote tag, i.e. '=123'.
+func parseMessageId(attribute string) (id cmb.Id, ok bool) {
+	if (len(attribute) < 2) || (attribute[0] != '=') || (len(attribute) > 19) {
+		return 0, false
+	}
+
+	for _, r := range attribute[1:] {
+		if (r < '0') || (r > '9') {
+			return 0, false
+		}
+	}
+
+	x, err := strconv.Atoi(attribute[1:])
+	if (err != nil) || (x <= 0) {
+		return 0, false
+	}
+
+	return cmb.Id(x), true
+}
+
+// findQuoteClosingTag finds the tag closing a quote taking nested quotes into
+// account. It returns -1 when the quote is not closed.
+func findQuoteClosingTag(s string) (index int) {
+	var level = 0
+	for i := 0; i < len(s); i++ {
+		if s[i] != '[' {
+			continue
+		}
+
+		if hasPrefixFold(s[i:], quoteTagClose) {
+			if level == 0 {
+				return i
+			}
+			level--
+			continue
+		}
+
+		if isQuoteTag(s[i:]) {
+			level++
+		}
+	}
+
+	return -1
+}
+
+// parseQuoteLines parses a group of lines starting with '>'.
+func parseQuoteLines(s string, depth int) (n *Node, rest string) {
+	var line string
+	var lines = []string{}
+	rest = s
+	for strings.HasPrefix(rest, quoteLinePrefix) {
+		line, rest = splitLine(rest)
+		line = strings.TrimPrefix(line, quoteLinePrefix)
+		line = strings.TrimPrefix(line, " ")
+		lines = append(lines, line)
+	}
+
+	n = &Node{
+		Type:     NodeType_Quote,
+		Children: parseBlocks(strings.Join(lines, "\n"), depth+1),
+	}
+
+	return n, rest
+}
+
+// parseParagraph parses lines till an empty line or till a start of another
+// block. The first line always belongs to the paragraph, even when it looks
+// like a start of an invalid block, e.g. of an unclosed quote.
+func parseParagraph(s string, depth int) (n *Node, rest string) {
+	var line, next string
+	var lines = []string{}
+	rest = s
+	for len(rest) > 0 {
+		line, next = splitLine(rest)
+		if (strings.TrimSpace(line) == "") ||
+			((len(lines) > 0) && isBlockStart(line, depth)) {
+			break
+		}
+
+		lines = append(lines, line)
+		rest = next
+	}
+
+	n = &Node{
+		Type:     NodeType_Paragraph,
+		Children: parseInline(strings.Join(lines, "\n"), depth, false),
+	}
+
+	return n, rest
+}
+
+func isBlockStart(line string, depth int) bool {
+	if strings.HasPrefix(line, codeFence) {
+		return true
+	}
+
+	if depth >= NestingDepthMax {
+		return false
+	}
+
+	return strings.HasPrefix(line, quoteLinePrefix) || isQuoteTag(line)
+}
+
+// isQuoteTag checks whether the text starts with an opening quote tag.
+func isQuoteTag(s string) bool {
+	if !hasPrefixFold(s, quoteTagOpen) || (len(s) == len(quoteTagOpen)) {
+		return false
+	}
+
+	c := s[len(quoteTagOpen)]
+	return (c == ']') || (c == '=')
+}
+
+// Inline elements.
+
+// inlineParser parses inline elements of a single text.
+type inlineParser struct {
+	text   string
+	depth  int
+	inLink bool
+
+	// Closing delimiters which are known to be absent after the current
+	// position. They are not searched for once more, so that parsing time
+	// does not grow quadratically with the number of unclosed elements.
+	unclosed map[string]bool
+}
+
+func parseInline(s string, depth int, inLink bool) (nodes []*Node) {
+	p := &inlineParser{
+		text:     s,
+		depth:    depth,
+		inLink:   inLink,
+		unclosed: make(map[string]bool),
+	}
+
+	return p.parse()
+}
+
+func (p *inlineParser) parse() (nodes []*Node) {
+	nodes = []*Node{}
+
+	var sb strings.Builder
+	flush := func() {
+		if sb.Len() > 0 {
+			nodes = append(nodes, &Node{Type: NodeType_Text, Text: sb.String()})
+			sb.Reset()
+		}
+	}
+
+	var n *Node
+	var size int
+	for i := 0; i < len(p.text); {
+		n, size = p.parseElement(i)
+		if n != nil {
+			flush()
+			nodes = append(nodes, n)
+			i += size
+			continue
+		}
+
+		_, size = utf8.DecodeRuneInString(p.text[i:])
+		sb.WriteString(p.text[i : i+size])
+		i += size
+	}
+
+	flush()
+	return nodes
+}
+
+// parseElement parses an element starting at the position. It returns nil
+// when there is no element.
+func (p *inlineParser) parseElement(i int) (n *Node, size int) {
+	s := p.text[i:]
+
+	switch s[0] {
+	case '\n':
+		return &Node{Type: NodeType_LineBreak}, 1
+
+	case '`':
+		return p.parseEnclosed(i, inlineTag{"`", "`", NodeType_InlineCode})
+
+	case '*':
+		if strings.HasPrefix(s, "**") {
+			return p.parseEnclosed(i, inlineTag{"**", "**", NodeType_Bold})
+		}
+		return p.parseEnclosed(i, inlineTag{"*", "*", NodeType_Italic})
+
+	case '~':
+		if strings.HasPrefix(s, "~~") {
+			return p.parseEnclosed(i, inlineTag{"~~", "~~", NodeType_Strike})
+		}
+
+	case '[':
+		return p.parseBracket(i)
+
+	case '@':
+		return p.parseMention(i)
+
+	case 'h', 'H':
+		return p.parseAutoLink(i)
+	}
+
+	return nil, 0
+}
+
+// parseEnclosed parses an element placed between two delimiters. Contents of
+// code are not parsed. Markdown elements other than code must not start or
+// end with a space, so that e.g. '2 * 3 * 4' stays a plain text.
+func (p *inlineParser) parseEnclosed(i int, tag inlineTag) (n *Node, size int) {
+	isCode := tag.nodeType == NodeType_InlineCode
+	if !isCode && (p.depth >= NestingDepthMax) {
+		return nil, 0
+	}
+
+	start := i + len(tag.open)
+	closing := p.findClosing(start, tag.close)
+	if closing < 0 {
+		return nil, 0
+	}
+
+	inner := p.text[start:closing]
+	if len(inner) == 0 {
+		return nil, 0
+	}
+
+	isBBCode := tag.open[0] == '['
+	if !isCode && !isBBCode && (strings.TrimSpace(inner) != inner) {
+		return nil, 0
+	}
+
+	size = closing + len(tag.close) - i
+
+	if isCode {
+		return &Node{Type: NodeType_InlineCode, Text: inner}, size
+	}
+
+	return &Node{Type: tag.nodeType, Children: parseInline(inner, p.depth+1, p.inLink)}, size
+}
+
+func (p *inlineParser) parseBracket(i int) (n *Node, size int) {
+	s := p.text[i:]
+
+	for _, tag := range bbCodeTags {
+		if hasPrefixFold(s, tag.open) {
+			return p.parseEnclosed(i, tag)
+		}
+	}
+
+	if hasPrefixFold(s, urlTagOpen) {
+		return p.parseUrlTag(i)
+	}
+
+	if hasPrefixFold(s, urlTagWithAddress) {
+		return p.parseUrlTagWithAddress(i)
+	}
+
+	return p.parseMarkdownLink(i)
+}
+
+// parseUrlTag parses a link like '[url]https://example.org[/url]'.
+func (p *inlineParser) parseUrlTag(i int) (n *Node, size int) {
+	if p.inLink {
+		return nil, 0
+	}
+
+	start := i + len(urlTagOpen)
+	closing := p.findClosing(start, urlTagClose)
+	if closing < 0 {
+		return nil, 0
+	}
+
+	address := p.text[start:closing]
+	href, ok := NormaliseUrl(address)
+	if !ok {
+		return nil, 0
+	}
+
+	n = &Node{
+		Type:     NodeType_Link,
+		Url:      href,
+		Children: []*Node{{Type: NodeType_Text, Text: address}},
+	}
+
+	return n, closing + len(urlTagClose) - i
+}
+
+// parseUrlTagWithAddress parses a link like '[url=https://example.org]Example
+// [/url]'.
+func (p *inlineParser) parseUrlTagWithAddress(i int) (n *Node, size int) {
+	if p.inLink || (p.depth >= NestingDepthMax) {
+		return nil, 0
+	}
+
+	start := i + len(urlTagWithAddress)
+	end := strings.IndexAny(p.text[start:], "]\n")
+	if (end < 0) || (p.text[start+end] != ']') {
+		return nil, 0
+	}
+	end += start
+
+	href, ok := NormaliseUrl(strings.Trim(p.text[start:end], `"`))
+	if !ok {
+		return nil, 0
+	}
+
+	closing := p.findClosing(end+1, urlTagClose)
+	if closing < 0 {
+		return nil, 0
+	}
+
+	n = &Node{
+		Type:     NodeType_Link,
+		Url:      href,
+		Children: parseInline(p.text[end+1:closing], p.depth+1, true),
+	}
+
+	return n, closing + len(urlTagClose) - i
+}
+
+// parseMarkdownLink parses a link like '[Example](https://example.org)'.
+func (p *inlineParser) parseMarkdownLink(i int) (n *Node, size int) {
+	if p.inLink || (p.depth >= NestingDepthMax) {
+		return nil, 0
+	}
+
+	// Text of a link is a single line without brackets.
+	end := strings.IndexAny(p.text[i+1:], "[]\n")
+	if (end <= 0) || (p.text[i+1+end] != ']') {
+		return nil, 0
+	}
+	end += i + 1
+
+	if !strings.HasPrefix(p.text[end+1:], "(") {
+		return nil, 0
+	}
+
+	start := end + 2
+	closing := strings.IndexFunc(p.text[start:], func(r rune) bool {
+		return (r == ')') || unicode.IsSpace(r)
+	})
+	if (closing < 0) || (p.text[start+closing] != ')') {
+		return nil, 0
+	}
+	closing += start
+
+	href, ok := NormaliseUrl(p.text[start:closing])
+	if !ok {
+		return nil, 0
+	}
+
+	n = &Node{
+		Type:     NodeType_Link,
+		Url:      href,
+		Children: parseInline(p.text[i+1:end], p.depth+1, true),
+	}
+
+	return n, closing + 1 - i
+}
+
+// parseAutoLink parses a bare address of a web page. Symbols of punctuation
+// ending the address are left outside of the link.
+func (p *inlineParser) parseAutoLink(i int) (n *Node, size int) {
+	if p.inLink || !p.isWordStart(i) {
+		return nil, 0
+	}
+
+	s := p.text[i:]
+	if !hasPrefixFold(s, autoLinkPrefixHttp) && !hasPrefixFold(s, autoLinkPrefixSsl) {
+		return nil, 0
+	}
+
+	end := strings.IndexFunc(s, func(r rune) bool {
+		return unicode.IsSpace(r) || strings.ContainsRune(`<>[]"`, r)
+	})
+	if end < 0 {
+		end = len(s)
+	}
+
+	address := strings.TrimRight(s[:end], autoLinkTrailingSymbols)
+	href, ok := NormaliseUrl(address)
+	if !ok {
+		return nil, 0
+	}
+
+	n = &Node{
+		Type:     NodeType_Link,
+		Url:      href,
+		Children: []*Node{{Type: NodeType_Text, Text: address}},
+	}
+
+	return n, len(address)
+}
+
+// parseMention parses a mention of a user. Only names consisting of letters,
+// digits and symbols '_', '.' and '-' can be mentioned. A mention must start
+// a word, so that e-mail addresses are not taken for mentions.
+func (p *inlineParser) parseMention(i int) (n *Node, size int) {
+	if !p.isWordStart(i) {
+		return nil, 0
+	}
+
+	start := i + 1
+	end := strings.IndexFunc(p.text[start:], func(r rune) bool {
+		return !IsNameSymbol(r)
+	})
+	if end < 0 {
+		end = len(p.text)
+	} else {
+		end += start
+	}
+
+	name := strings.TrimRight(p.text[start:end], ".-")
+	if len(name) == 0 {
+		return nil, 0
+	}
+
+	return &Node{Type: NodeType_Mention, Text: name}, len(name) + 1
+}
+
+// findClosing finds a closing delimiter after the position. It returns -1
+// when the delimiter is absent.
+func (p *inlineParser) findClosing(start int, delimiter string) (index int) {
+	if p.unclosed[delimiter] {
+		return -1
+	}
+
+	index = indexFold(p.text[start:], delimiter)
+	if index < 0 {
+		p.unclosed[delimiter] = true
+		return -1
+	}
+
+	return start + index
+}
+
+// isWordStart checks whether the position is at a start of a word.
+func (p *inlineParser) isWordStart(i int) bool {
+	if i == 0 {
+		return true
+	}
+
+	r, _ := utf8.DecodeLastRuneInString(p.text[:i])
+	return !IsNameSymbol(r)
+}
+
+// IsNameSymbol checks whether the symbol may be a part of a mentioned name.
+func IsNameSymbol(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) ||
+		(r == '_') || (r == '.') || (r == '-')
+}
+
+// NormaliseUrl checks an address of a link and returns it in a normalised
+// form. Only addresses with 'http', 'https' and 'mailto' schemes are allowed,
+// so that scripts can not be injected into links.
+func NormaliseUrl(address string) (href string, ok bool) {
+	if (len(address) == 0) || (len(address) > UrlLengthMax) {
+		return "", false
+	}
+
+	for _, r := range address {
+		if unicode.IsSpace(r) || unicode.IsControl(r) {
+			return "", false
+		}
+	}
+
+	u, err := url.Parse(address)
+	if err != nil {
+		return "", false
+	}
+
+	switch u.Scheme {
+	case UrlSchemeHttp, UrlSchemeHttps:
+		if len(u.Host) == 0 {
+			return "", false
+		}
+
+	case UrlSchemeMailto:
+		if len(u.Opaque) == 0 {
+			return "", false
+		}
+
+	default:
+		return "", false
+	}
+
+	return u.String(), true
+}
+
+// Text helpers.
+
+// splitLine splits the first line of a text from the rest of it.
+func splitLine(s string) (line string, rest string) {
+	i := strings.IndexByte(s, '\n')
+	if i < 0 {
+		return s, ""
+	}
+
+	return s[:i], s[i+1:]
+}
+
+// skipEmptyLines removes empty lines and lines made of spaces from the start
+// of a text.
+func skipEmptyLines(s string) string {
+	var line, rest string
+	for len(s) > 0 {
+		line, rest = splitLine(s)
+		if strings.TrimSpace(line) != "" {
+			return s
+		}
+
+		s = rest
+	}
+
+	return s
+}
+
+// hasPrefixFold is a case-insensitive version of the strings.HasPrefix
+// function for ASCII prefixes.
+func hasPrefixFold(s string, prefix string) bool {
+	if len(s) < len(prefix) {
+		return false
+	}
+
+	for i := 0; i < len(prefix); i++ {
+		if toLowerAscii(s[i]) != toLowerAscii(prefix[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// indexFold is a case-insensitive version of the strings.Index function for
+// ASCII sub-strings.
+func indexFold(s string, substr string) int {
+	for i := 0; i+len(substr) <= len(s); i++ {
+		if hasPrefixFold(s[i:], substr) {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func toLowerAscii(b byte) byte {
+	if (b >= 'A') && (b <= 'Z') {
+		return b + ('a' - 'A')
+	}
+
+	return b
+}
@@ -0,0 +1,147 @@
+package markup
+
+import (
+	"strings"
+	"testing"
+
+	cmb "github.com/vault-thirteen/SimpleBB/pkg/common/models/base"
+	"github.com/vault-thirteen/auxie/tester"
+)
+
+func Test_Html(t *testing.T) {
+	aTest := tester.New(t)
+
+	type TestData struct {
+		text string
+		html string
+	}
+
+	users := map[string]cmb.Id{"John": 7}
+
+	tests := []TestData{
+		// Paragraphs and line breaks.
+		{"", ""},
+		{"Hello", "<p>Hello</p>"},
+		{"a\nb\n\n\nc", "<p>a<br>b</p><p>c</p>"},
+		{"a\r\nb", "<p>a<br>b</p>"},
+
+		// Escaping.
+		{`<script>alert("x")</script>`, "<p>&lt;script&gt;alert(&#34;x&#34;)&lt;/script&gt;</p>"},
+		{"a & b", "<p>a &amp; b</p>"},
+		{"\xff", "<p>�</p>"},
+
+		// Formatting.
+		{"**b** *i* ~~s~~", "<p><strong>b</strong> <em>i</em> <s>s</s></p>"},
+		{"[B]b[/b] [i]i[/i] [u]u[/u] [s]s[/s]", "<p><strong>b</strong> <em>i</em> <u>u</u> <s>s</s></p>"},
+		{"**a *b* c**", "<p><strong>a <em>b</em> c</strong></p>"},
+		{"2 * 3 * 4", "<p>2 * 3 * 4</p>"},
+		{"**unclosed", "<p>**unclosed</p>"},
+		{"[b]unclosed", "<p>[b]unclosed</p>"},
+
+		// Code.
+		{"`**x** <y>`", "<p><code>**x** &lt;y&gt;</code></p>"},
+		{"[code]@John[/code]", "<p><code>@John</code></p>"},
+		{"```go\nif a < b {\n\n}\n```\nafter", "<pre><code>if a &lt; b {\n\n}</code></pre><p>after</p>"},
+		{"```\nunclosed", "<pre><code>unclosed</code></pre>"},
+
+		// Links.
+		{"[url]https://example.org/a?b=1&c=2[/url]", `<p><a href="https://example.org/a?b=1&amp;c=2" rel="nofollow noopener noreferrer ugc">https://example.org/a?b=1&amp;c=2</a></p>`},
+		{"[url=https://example.org]**Ex**[/url]", `<p><a href="https://example.org" rel="nofollow noopener noreferrer ugc"><strong>Ex</strong></a></p>`},
+		{"[Ex](mailto:a@example.org)", `<p><a href="mailto:a@example.org" rel="nofollow noopener noreferrer ugc">Ex</a></p>`},
+		{"See http://example.org.", `<p>See <a href="http://example.org" rel="nofollow noopener noreferrer ugc">http://example.org</a>.</p>`},
+		{"[url=https://a.org][url]https://b.org[/url][/url]", `<p><a href="https://a.org" rel="nofollow noopener noreferrer ugc">[url]https://b.org</a>[/url]</p>`},
+		{"[x](javascript:alert(1))", "<p>[x](javascript:alert(1))</p>"},
+		{"[url]javascript:alert(1)[/url]", "<p>[url]javascript:alert(1)[/url]</p>"},
+		{`[url="https://a.org" onclick="x"]a[/url]`, `<p>[url=&#34;<a href="https://a.org" rel="nofollow noopener noreferrer ugc">https://a.org</a>&#34; onclick=&#34;x&#34;]a[/url]</p>`},
+		{"[x](https:///path)", "<p>[x](https:///path)</p>"},
+
+		// Quotes.
+		{"[quote=12]Hi[/quote]", `<blockquote class="quote" data-message-id="12"><p>Hi</p></blockquote>`},
+		{"[quote]a\n[quote=1]b[/quote]\nc[/quote]d", `<blockquote class="quote"><p>a</p><blockquote class="quote" data-message-id="1"><p>b</p></blockquote><p>c</p></blockquote><p>d</p>`},
+		{"[quote=x]a[/quote]", "<p>[quote=x]a[/quote]</p>"},
+		{"[quote]unclosed", "<p>[quote]unclosed</p>"},
+		{"> a\n> > b\nc", `<blockquote class="quote"><p>a</p><blockquote class="quote"><p>b</p></blockquote></blockquote><p>c</p>`},
+		{"text\n> quote", `<p>text</p><blockquote class="quote"><p>quote</p></blockquote>`},
+		{"[quotes] are not quotes", "<p>[quotes] are not quotes</p>"},
+
+		// Mentions.
+		{"Hi, @John!", `<p>Hi, <span class="mention" data-user-id="7">@John</span>!</p>`},
+		{"@John.", `<p><span class="mention" data-user-id="7">@John</span>.</p>`},
+		{"@Unknown user", "<p>@Unknown user</p>"},
+		{"john@example.org", "<p>john@example.org</p>"},
+		{"@ alone", "<p>@ alone</p>"},
+	}
+
+	for _, test := range tests {
+		aTest.MustBeEqual(Parse(test.text).Html(users), test.html)
+	}
+}
+
+func Test_NestingDepth(t *testing.T) {
+	aTest := tester.New(t)
+
+	text := strings.Repeat("[quote]", NestingDepthMax+1) + "x" + strings.Repeat("[/quote]", NestingDepthMax+1)
+	html := Parse(text).Html(nil)
+	aTest.MustBeEqual(strings.Count(html, "<blockquote"), NestingDepthMax)
+
+	// Formatting is not parsed beyond the limit.
+	html = Parse(strings.Repeat(">", NestingDepthMax-1) + " **x**").Html(nil)
+	aTest.MustBeEqual(strings.Count(html, "<blockquote"), NestingDepthMax-1)
+	aTest.MustBeEqual(strings.Contains(html, "<strong>x</strong>"), true)
+
+	html = Parse(strings.Repeat(">", NestingDepthMax) + " **x**").Html(nil)
+	aTest.MustBeEqual(strings.Count(html, "<blockquote"), NestingDepthMax)
+	aTest.MustBeEqual(strings.Contains(html, "<p>**x**</p>"), true)
+}
+
+func Test_Document(t *testing.T) {
+	aTest := tester.New(t)
+
+	doc := Parse("@a @b\n\n[quote=5]@c @a[/quote]\n\n> @d [quote=6]x[/quote]\n\n[quote=5]y[/quote] `@e`")
+	aTest.MustBeEqual(doc.Mentions(), []string{"a", "b", "c", "d"})
+	aTest.MustBeEqual(doc.DirectMentions(), []string{"a", "b"})
+	aTest.MustBeEqual(doc.QuotedMessages(), []cmb.Id{5})
+
+	aTest.MustBeEqual(Parse("").Mentions(), []string{})
+}
+
+func Test_NormaliseUrl(t *testing.T) {
+	aTest := tester.New(t)
+
+	type TestData struct {
+		address string
+		href    string
+		ok      bool
+	}
+
+	tests := []TestData{
+		{"https://example.org", "https://example.org", true},
+		{"HTTP://example.org/a b", "", false},
+		{"HTTP://example.org/a%20b", "http://example.org/a%20b", true},
+		{"mailto:a@example.org", "mailto:a@example.org", true},
+		{"ftp://example.org", "", false},
+		{"javascript:alert(1)", "", false},
+		{"data:text/html,x", "", false},
+		{"//example.org", "", false},
+		{"/relative", "", false},
+		{"", "", false},
+		{"https://" + strings.Repeat("a", UrlLengthMax), "", false},
+	}
+
+	var href string
+	var ok bool
+	for _, test := range tests {
+		href, ok = NormaliseUrl(test.address)
+		aTest.MustBeEqual(ok, test.ok)
+		aTest.MustBeEqual(href, test.href)
+	}
+}
+
+func Test_UnclosedElements(t *testing.T) {
+	aTest := tester.New(t)
+
+	// Many unclosed elements must not make parsing slow.
+	text := strings.Repeat("[b][url=https://a.org][u][quote=1]", 10_000)
+	html := Parse(text).Html(nil)
+	aTest.MustBeEqual(strings.Contains(html, "<strong>"), false)
+}
@@ -0,0 +1,139 @@
+package models
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/vault-thirteen/SimpleBB/pkg/common/interfaces/base1"
+	cmb "github.com/vault-thirteen/SimpleBB/pkg/common/models/base"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	// AttachmentNameMaxLength is the maximal length of a file name in
+	// symbols.
+	AttachmentNameMaxLength = 255
+
+	// Forbidden symbols of file names. Path separators are forbidden to
+	// prevent path traversal when a file is saved by a browser, quotes are
+	// forbidden to keep HTTP headers valid.
+	attachmentNameForbiddenSymbols = "/\\\"\x00"
+)
+
+// NewAttachment is a file attached to a new message.
+type NewAttachment struct {
+	// Name of the file.
+	Name cmb.Text `json:"name"`
+
+	// Contents of the file.
+	Data []byte `json:"data"`
+}
+
+// Attachment is a file attached to a message. Contents of the file and of
+// its thumbnail are stored in the blob store.
+type Attachment struct {
+	Id          cmb.Id    `json:"id"`
+	MessageId   cmb.Id    `json:"messageId"`
+	Name        cmb.Text  `json:"name"`
+	ContentType string    `json:"contentType"`
+	Size        cmb.Count `json:"size"`
+
+	// Addresses of the file and of its thumbnail in the blob store. Only
+	// images have thumbnails.
+	BlobHash      string  `json:"-"`
+	ThumbnailHash *string `json:"-"`
+
+	// HasThumbnail flag is set when the attachment is an image having a
+	// thumbnail.
+	HasThumbnail cmb.Flag `json:"hasThumbnail"`
+
+	TimeOfCreation time.Time `json:"timeOfCreation"`
+}
+
+// MessageAttachments are files attached to a message.
+type MessageAttachments struct {
+	MessageId   cmb.Id       `json:"messageId"`
+	Attachments []Attachment `json:"attachments"`
+}
+
+func NewAttachmentFromScannableSource(src base.IScannable) (a *Attachment, err error) {
+	a = &Attachment{}
+
+	err = src.Scan(
+		&a.Id,
+		&a.MessageId,
+		&a.Name,
+		&a.ContentType,
+		&a.Size,
+		&a.BlobHash,
+		&a.ThumbnailHash,
+		&a.TimeOfCreation,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		} else {
+			return nil, err
+		}
+	}
+
+	a.HasThumbnail = a.ThumbnailHash != nil
+
+	return a, nil
+}
+
+func NewAttachmentArrayFromRows(rows base.IScannableSequence) (as []Attachment, err error) {
+	as = []Attachment{}
+	var a *Attachment
+
+	for rows.Next() {
+		a, err = NewAttachmentFromScannableSource(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		as = append(as, *a)
+	}
+
+	return as, nil
+}
+
+// IsAttachmentNameValid checks a name of an attached file.
+func IsAttachmentNameValid(name cmb.Text) bool {
+	s := name.ToString()
+
+	if (len(s) == 0) ||
+		!utf8.ValidString(s) ||
+		(utf8.RuneCountInString(s) > AttachmentNameMaxLength) ||
+		strings.ContainsAny(s, attachmentNameForbiddenSymbols) ||
+		(strings.TrimSpace(s) != s) ||
+		(s == ".") || (s == "..") {
+		return false
+	}
+
+	return true
+}
+
+// GroupAttachments groups attachments by messages. The order of messages and
+// the order of attachments of each message are preserved.
+func GroupAttachments(as []Attachment) (mas []MessageAttachments) {
+	mas = []MessageAttachments{}
+	var indices = make(map[cmb.Id]int)
+
+	for _, a := range as {
+		i, ok := indices[a.MessageId]
+		if !ok {
+			i = len(mas)
+			indices[a.MessageId] = i
+			mas = append(mas, MessageAttachments{
+				MessageId:   a.MessageId,
+				Attachments: []Attachment{},
+			})
+		}
+
+		mas[i].Attachments = append(mas[i].Attachments, a)
+	}
+
+	return mas
+}
@@ -0,0 +1,40 @@
+package models
+
+import (
+	"strings"
+	"testing"
+
+	cmb "github.com/vault-thirteen/SimpleBB/pkg/common/models/base"
+	"github.com/vault-thirteen/auxie/tester"
+)
+
+func Test_IsAttachmentNameValid(t *testing.T) {
+	aTest := tester.New(t)
+
+	aTest.MustBeEqual(IsAttachmentNameValid("photo.jpg"), true)
+	aTest.MustBeEqual(IsAttachmentNameValid("Отчёт 2024.pdf"), true)
+	aTest.MustBeEqual(IsAttachmentNameValid(""), false)
+	aTest.MustBeEqual(IsAttachmentNameValid(".."), false)
+	aTest.MustBeEqual(IsAttachmentNameValid("../passwd"), false)
+	aTest.MustBeEqual(IsAttachmentNameValid(`C:\file.txt`), false)
+	aTest.MustBeEqual(IsAttachmentNameValid(`a"b.txt`), false)
+	aTest.MustBeEqual(IsAttachmentNameValid(" a.txt"), false)
+	aTest.MustBeEqual(IsAttachmentNameValid(cmb.Text(strings.Repeat("я", AttachmentNameMaxLength))), true)
+	aTest.MustBeEqual(IsAttachmentNameValid(cmb.Text(strings.Repeat("я", AttachmentNameMaxLength+1))), false)
+}
+
+func Test_GroupAttachments(t *testing.T) {
+	aTest := tester.New(t)
+
+	aTest.MustBeEqual(GroupAttachments(nil), []MessageAttachments{})
+
+	var as = []Attachment{
+		{Id: 1, MessageId: 5},
+		{Id: 2, MessageId: 3},
+		{Id: 3, MessageId: 5},
+	}
+	aTest.MustBeEqual(GroupAttachments(as), []MessageAttachments{
+		{MessageId: 5, Attachments: []Attachment{{Id: 1, MessageId: 5}, {Id: 3, MessageId: 5}}},
+		{MessageId: 3, Attachments: []Attachment{{Id: 2, MessageId: 3}}},
+	})
+}
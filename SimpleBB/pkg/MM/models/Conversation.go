@@ -0,0 +1,85 @@
+package models
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/vault-thirteen/SimpleBB/pkg/common/interfaces/base1"
+	"github.com/vault-thirteen/SimpleBB/pkg/common/models/UidList"
+	cmb "github.com/vault-thirteen/SimpleBB/pkg/common/models/base"
+	cm "github.com/vault-thirteen/SimpleBB/pkg/common/models/simple"
+	"time"
+)
+
+// Conversation is a private conversation between two or more users.
+// Conversation is always read on behalf of one of its members, so the number
+// of unread messages is the number for that member.
+type Conversation struct {
+	// Identifier of this conversation.
+	Id cmb.Id `json:"id"`
+
+	// Subject of this conversation.
+	Subject cm.Name `json:"subject"`
+
+	// List of IDs of users taking part in this conversation.
+	Members *ul.UidList `json:"members"`
+
+	// Number of messages which are not yet read by the member.
+	UnreadMessages cmb.Count `json:"unreadMessages"`
+
+	// Time of the latest message.
+	LastMessageTime time.Time `json:"lastMessageTime"`
+
+	// Creator of this conversation and time of creation.
+	CreatorUserId cmb.Id    `json:"creatorUserId"`
+	CreatorTime   time.Time `json:"creatorTime"`
+}
+
+func NewConversation() (c *Conversation) {
+	return &Conversation{
+		Members: ul.New(),
+	}
+}
+
+func NewConversationFromScannableSource(src base.IScannable) (c *Conversation, err error) {
+	c = NewConversation()
+
+	err = src.Scan(
+		&c.Id,
+		&c.Subject,
+		c.Members,
+		&c.UnreadMessages,
+		&c.LastMessageTime,
+		&c.CreatorUserId,
+		&c.CreatorTime,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		} else {
+			return nil, err
+		}
+	}
+
+	return c, nil
+}
+
+func NewConversationArrayFromRows(rows base.IScannableSequence) (cs []Conversation, err error) {
+	cs = []Conversation{}
+	var c *Conversation
+
+	for rows.Next() {
+		c, err = NewConversationFromScannableSource(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		cs = append(cs, *c)
+	}
+
+	return cs, nil
+}
+
+// IsMember checks whether the user takes part in the conversation.
+func (c *Conversation) IsMember(userId cmb.Id) bool {
+	return c.Members.HasItem(userId)
+}
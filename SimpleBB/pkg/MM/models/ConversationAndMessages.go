@@ -0,0 +1,18 @@
+package models
+
+import (
+	cmr "github.com/vault-thirteen/SimpleBB/pkg/common/models/rpc"
+)
+
+// ConversationAndMessages is a conversation with a page of its messages.
+// Messages are ordered by time, the oldest messages go first.
+type ConversationAndMessages struct {
+	Conversation *Conversation    `json:"conversation"`
+	Messages     []PrivateMessage `json:"messages"`
+	PageData     *cmr.PageData    `json:"pageData,omitempty"`
+}
+
+func NewConversationAndMessages() (cam *ConversationAndMessages) {
+	cam = &ConversationAndMessages{}
+	return cam
+}
@@ -0,0 +1,17 @@
+package models
+
+import (
+	cmr "github.com/vault-thirteen/SimpleBB/pkg/common/models/rpc"
+)
+
+// ConversationsOnPage is a page of a user's inbox. Conversations are ordered
+// by time of the latest message, the newest conversations go first.
+type ConversationsOnPage struct {
+	Conversations []Conversation `json:"conversations"`
+	PageData      *cmr.PageData  `json:"pageData,omitempty"`
+}
+
+func NewConversationsOnPage() (cop *ConversationsOnPage) {
+	cop = &ConversationsOnPage{}
+	return cop
+}
@@ -0,0 +1,56 @@
+package models
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/vault-thirteen/SimpleBB/pkg/common/interfaces/base1"
+	cmb "github.com/vault-thirteen/SimpleBB/pkg/common/models/base"
+	"time"
+)
+
+// MessageReaction is a reaction of a user to a message.
+type MessageReaction struct {
+	// Identifier of a message.
+	MessageId cmb.Id `json:"messageId"`
+
+	// User who has reacted to the message.
+	UserId cmb.Id `json:"userId"`
+
+	Emoji string `json:"emoji"`
+
+	// Author of the message receiving the reputation.
+	AuthorUserId cmb.Id `json:"authorUserId"`
+
+	// Weight of the reaction at the moment of its creation. It is stored to
+	// revert the reputation correctly when the reaction is removed after a
+	// change of settings.
+	Weight cmb.Count `json:"weight"`
+
+	TimeOfCreation time.Time `json:"timeOfCreation"`
+}
+
+func NewMessageReaction() (mr *MessageReaction) {
+	return &MessageReaction{}
+}
+
+func NewMessageReactionFromScannableSource(src base.IScannable) (mr *MessageReaction, err error) {
+	mr = NewMessageReaction()
+
+	err = src.Scan(
+		&mr.MessageId,
+		&mr.UserId,
+		&mr.Emoji,
+		&mr.AuthorUserId,
+		&mr.Weight,
+		&mr.TimeOfCreation,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		} else {
+			return nil, err
+		}
+	}
+
+	return mr, nil
+}
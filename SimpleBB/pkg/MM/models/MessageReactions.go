@@ -0,0 +1,93 @@
+package models
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/vault-thirteen/SimpleBB/pkg/common/interfaces/base1"
+	cmb "github.com/vault-thirteen/SimpleBB/pkg/common/models/base"
+)
+
+// ReactionCount is a number of reactions of a single type put on a message.
+type ReactionCount struct {
+	Emoji string    `json:"emoji"`
+	Count cmb.Count `json:"count"`
+
+	// IsSelf flag is set when the reading user is among the reacted users.
+	IsSelf cmb.Flag `json:"isSelf"`
+}
+
+// MessageReactionCount is a number of reactions of a single type put on a
+// message. This model is used for reading aggregates from the database.
+type MessageReactionCount struct {
+	MessageId cmb.Id `json:"messageId"`
+	ReactionCount
+}
+
+// MessageReactions are aggregated reactions of a message.
+type MessageReactions struct {
+	MessageId cmb.Id          `json:"messageId"`
+	Reactions []ReactionCount `json:"reactions"`
+}
+
+func NewMessageReactionCount() (mrc *MessageReactionCount) {
+	return &MessageReactionCount{}
+}
+
+func NewMessageReactionCountFromScannableSource(src base.IScannable) (mrc *MessageReactionCount, err error) {
+	mrc = NewMessageReactionCount()
+
+	err = src.Scan(
+		&mrc.MessageId,
+		&mrc.Emoji,
+		&mrc.Count,
+		&mrc.IsSelf,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		} else {
+			return nil, err
+		}
+	}
+
+	return mrc, nil
+}
+
+func NewMessageReactionCountArrayFromRows(rows base.IScannableSequence) (mrcs []MessageReactionCount, err error) {
+	mrcs = []MessageReactionCount{}
+	var mrc *MessageReactionCount
+
+	for rows.Next() {
+		mrc, err = NewMessageReactionCountFromScannableSource(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		mrcs = append(mrcs, *mrc)
+	}
+
+	return mrcs, nil
+}
+
+// GroupMessageReactionCounts groups reaction counts by messages. The order of
+// messages and the order of reactions of each message are preserved.
+func GroupMessageReactionCounts(mrcs []MessageReactionCount) (mrs []MessageReactions) {
+	mrs = []MessageReactions{}
+	var indices = make(map[cmb.Id]int)
+
+	for _, mrc := range mrcs {
+		i, ok := indices[mrc.MessageId]
+		if !ok {
+			i = len(mrs)
+			indices[mrc.MessageId] = i
+			mrs = append(mrs, MessageReactions{
+				MessageId: mrc.MessageId,
+				Reactions: []ReactionCount{},
+			})
+		}
+
+		mrs[i].Reactions = append(mrs[i].Reactions, mrc.ReactionCount)
+	}
+
+	return mrs
+}
@@ -0,0 +1,163 @@
+package models
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+	"unicode/utf8"
+
+	"github.com/vault-thirteen/SimpleBB/pkg/common/interfaces/base1"
+	cmb "github.com/vault-thirteen/SimpleBB/pkg/common/models/base"
+)
+
+// ReportReason is a reason of a report about a message.
+type ReportReason byte
+
+const (
+	ReportReason_Spam     = ReportReason(1)
+	ReportReason_Abuse    = ReportReason(2)
+	ReportReason_OffTopic = ReportReason(3)
+	ReportReason_Illegal  = ReportReason(4)
+	ReportReason_Other    = ReportReason(5)
+
+	ReportReasonMax = ReportReason_Other
+)
+
+const (
+	// ReportCommentMaxLength is the maximal length of a comment of a report
+	// or of a moderation decision in symbols.
+	ReportCommentMaxLength = 255
+)
+
+func (rr ReportReason) IsValid() bool {
+	return (rr >= ReportReason_Spam) && (rr <= ReportReasonMax)
+}
+
+// IsReportCommentValid checks length of a comment. Comment is optional.
+func IsReportCommentValid(comment cmb.Text) bool {
+	return utf8.RuneCountInString(comment.ToString()) <= ReportCommentMaxLength
+}
+
+// MessageReport is a report of a reader about a message. The report is open
+// until a moderator makes a decision about the message.
+type MessageReport struct {
+	Id        cmb.Id `json:"id"`
+	MessageId cmb.Id `json:"messageId"`
+	ThreadId  cmb.Id `json:"threadId"`
+
+	// Author of the reported message.
+	AuthorUserId cmb.Id `json:"authorUserId"`
+
+	ReporterUserId cmb.Id       `json:"reporterUserId"`
+	Reason         ReportReason `json:"reason"`
+	Comment        cmb.Text     `json:"comment"`
+	TimeOfCreation time.Time    `json:"toc"`
+
+	// Decision is not set while the report is open.
+	DecisionId *cmb.Id `json:"decisionId"`
+}
+
+// ReportedMessage is a message with its open reports.
+type ReportedMessage struct {
+	MessageId cmb.Id          `json:"messageId"`
+	ThreadId  cmb.Id          `json:"threadId"`
+	Reports   []MessageReport `json:"reports"`
+}
+
+func NewMessageReport() (mr *MessageReport) {
+	return &MessageReport{}
+}
+
+func NewMessageReportFromScannableSource(src base.IScannable) (mr *MessageReport, err error) {
+	mr = NewMessageReport()
+
+	err = src.Scan(
+		&mr.Id,
+		&mr.MessageId,
+		&mr.ThreadId,
+		&mr.AuthorUserId,
+		&mr.ReporterUserId,
+		&mr.Reason,
+		&mr.Comment,
+		&mr.TimeOfCreation,
+		&mr.DecisionId,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		} else {
+			return nil, err
+		}
+	}
+
+	return mr, nil
+}
+
+func NewMessageReportArrayFromRows(rows base.IScannableSequence) (mrs []MessageReport, err error) {
+	mrs = []MessageReport{}
+	var mr *MessageReport
+
+	for rows.Next() {
+		mr, err = NewMessageReportFromScannableSource(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		mrs = append(mrs, *mr)
+	}
+
+	return mrs, nil
+}
+
+// GroupMessageReports groups reports by messages. The order of messages and
+// the order of reports of each message are preserved.
+func GroupMessageReports(mrs []MessageReport) (rms []ReportedMessage) {
+	rms = []ReportedMessage{}
+	var indices = make(map[cmb.Id]int)
+
+	for _, mr := range mrs {
+		i, ok := indices[mr.MessageId]
+		if !ok {
+			i = len(rms)
+			indices[mr.MessageId] = i
+			rms = append(rms, ReportedMessage{
+				MessageId: mr.MessageId,
+				ThreadId:  mr.ThreadId,
+				Reports:   []MessageReport{},
+			})
+		}
+
+		rms[i].Reports = append(rms[i].Reports, mr)
+	}
+
+	return rms
+}
+
+// ListReporters returns a list of users who reported the message. Each user
+// is listed once, the order of reports is preserved.
+func (rm *ReportedMessage) ListReporters() (userIds []cmb.Id) {
+	userIds = []cmb.Id{}
+	var seen = make(map[cmb.Id]bool)
+
+	for _, r := range rm.Reports {
+		if seen[r.ReporterUserId] {
+			continue
+		}
+
+		seen[r.ReporterUserId] = true
+		userIds = append(userIds, r.ReporterUserId)
+	}
+
+	return userIds
+}
+
+// LastReportId returns the largest identifier of the message's reports.
+func (rm *ReportedMessage) LastReportId() (id cmb.Id) {
+	for _, r := range rm.Reports {
+		if r.Id > id {
+			id = r.Id
+		}
+	}
+
+	return id
+}
@@ -0,0 +1,65 @@
+package models
+
+import (
+	"strings"
+	"testing"
+
+	cmb "github.com/vault-thirteen/SimpleBB/pkg/common/models/base"
+	"github.com/vault-thirteen/auxie/tester"
+)
+
+func Test_GroupMessageReports(t *testing.T) {
+	aTest := tester.New(t)
+
+	// Test #1. No reports.
+	aTest.MustBeEqual(GroupMessageReports([]MessageReport{}), []ReportedMessage{})
+
+	// Test #2. Order of messages and reports is preserved.
+	mrs := []MessageReport{
+		{Id: 3, MessageId: 20, ThreadId: 2, ReporterUserId: 7},
+		{Id: 1, MessageId: 10, ThreadId: 1, ReporterUserId: 7},
+		{Id: 5, MessageId: 20, ThreadId: 2, ReporterUserId: 8},
+	}
+	rms := GroupMessageReports(mrs)
+	aTest.MustBeEqual(len(rms), 2)
+	aTest.MustBeEqual(rms[0].MessageId, cmb.Id(20))
+	aTest.MustBeEqual(rms[0].ThreadId, cmb.Id(2))
+	aTest.MustBeEqual(rms[0].Reports, []MessageReport{mrs[0], mrs[2]})
+	aTest.MustBeEqual(rms[1].MessageId, cmb.Id(10))
+	aTest.MustBeEqual(rms[1].Reports, []MessageReport{mrs[1]})
+}
+
+func Test_ReportedMessage_ListReporters(t *testing.T) {
+	aTest := tester.New(t)
+
+	rm := ReportedMessage{
+		MessageId: 10,
+		Reports: []MessageReport{
+			{Id: 1, ReporterUserId: 8},
+			{Id: 4, ReporterUserId: 7},
+			{Id: 2, ReporterUserId: 8},
+		},
+	}
+	aTest.MustBeEqual(rm.ListReporters(), []cmb.Id{8, 7})
+	aTest.MustBeEqual(rm.LastReportId(), cmb.Id(4))
+}
+
+func Test_ReportReason_IsValid(t *testing.T) {
+	aTest := tester.New(t)
+
+	aTest.MustBeEqual(ReportReason(0).IsValid(), false)
+	aTest.MustBeEqual(ReportReason_Spam.IsValid(), true)
+	aTest.MustBeEqual(ReportReasonMax.IsValid(), true)
+	aTest.MustBeEqual((ReportReasonMax + 1).IsValid(), false)
+	aTest.MustBeEqual(ModerationAction(0).IsValid(), false)
+	aTest.MustBeEqual(ModerationAction_LockThread.IsValid(), true)
+	aTest.MustBeEqual((ModerationActionMax + 1).IsValid(), false)
+}
+
+func Test_IsReportCommentValid(t *testing.T) {
+	aTest := tester.New(t)
+
+	aTest.MustBeEqual(IsReportCommentValid(""), true)
+	aTest.MustBeEqual(IsReportCommentValid(cmb.Text(strings.Repeat("я", ReportCommentMaxLength))), true)
+	aTest.MustBeEqual(IsReportCommentValid(cmb.Text(strings.Repeat("я", ReportCommentMaxLength+1))), false)
+}
@@ -0,0 +1,72 @@
+package models
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/vault-thirteen/SimpleBB/pkg/common/interfaces/base1"
+	cmb "github.com/vault-thirteen/SimpleBB/pkg/common/models/base"
+	"time"
+)
+
+// MessageRevision is a previous version of a message's text. A new revision
+// is saved each time when the text of a message is changed.
+type MessageRevision struct {
+	// Identifier of this revision.
+	Id cmb.Id `json:"id"`
+
+	// Identifier of a message.
+	MessageId cmb.Id `json:"messageId"`
+
+	// Text of the message in this revision.
+	Text cmb.Text `json:"text"`
+
+	// Check sum of the Text field.
+	TextChecksum []byte `json:"textChecksum"`
+
+	// Author of this revision, i.e. a user who created or edited the message
+	// making this text, and time of that action.
+	EditorUserId cmb.Id    `json:"editorUserId"`
+	EditorTime   time.Time `json:"editorTime"`
+}
+
+func NewMessageRevision() (mr *MessageRevision) {
+	return &MessageRevision{}
+}
+
+func NewMessageRevisionFromScannableSource(src base.IScannable) (mr *MessageRevision, err error) {
+	mr = NewMessageRevision()
+
+	err = src.Scan(
+		&mr.Id,
+		&mr.MessageId,
+		&mr.Text,
+		&mr.TextChecksum,
+		&mr.EditorUserId,
+		&mr.EditorTime,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		} else {
+			return nil, err
+		}
+	}
+
+	return mr, nil
+}
+
+func NewMessageRevisionArrayFromRows(rows base.IScannableSequence) (mrs []MessageRevision, err error) {
+	mrs = []MessageRevision{}
+	var mr *MessageRevision
+
+	for rows.Next() {
+		mr, err = NewMessageRevisionFromScannableSource(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		mrs = append(mrs, *mr)
+	}
+
+	return mrs, nil
+}
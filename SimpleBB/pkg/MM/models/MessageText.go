@@ -0,0 +1,56 @@
+package models
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/vault-thirteen/SimpleBB/pkg/common/interfaces/base1"
+	cmb "github.com/vault-thirteen/SimpleBB/pkg/common/models/base"
+)
+
+// MessageText is a short variant of a message which stores only its ID and
+// text.
+type MessageText struct {
+	// Identifier of this message.
+	Id cmb.Id `json:"id"`
+
+	// Text of this message.
+	Text cmb.Text `json:"text"`
+}
+
+func NewMessageText() (mt *MessageText) {
+	return &MessageText{}
+}
+
+func NewMessageTextFromScannableSource(src base.IScannable) (mt *MessageText, err error) {
+	mt = NewMessageText()
+
+	err = src.Scan(
+		&mt.Id,
+		&mt.Text,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		} else {
+			return nil, err
+		}
+	}
+
+	return mt, nil
+}
+
+func NewMessageTextArrayFromRows(rows base.IScannableSequence) (mts []MessageText, err error) {
+	mts = []MessageText{}
+	var mt *MessageText
+
+	for rows.Next() {
+		mt, err = NewMessageTextFromScannableSource(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		mts = append(mts, *mt)
+	}
+
+	return mts, nil
+}
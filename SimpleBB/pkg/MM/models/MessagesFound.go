@@ -0,0 +1,20 @@
+package models
+
+import (
+	"github.com/vault-thirteen/SimpleBB/pkg/common/interfaces/derived2"
+	"github.com/vault-thirteen/SimpleBB/pkg/common/models/UidList"
+	cmr "github.com/vault-thirteen/SimpleBB/pkg/common/models/rpc"
+)
+
+// MessagesFound is a page of messages found by a full-text search. Messages
+// are ordered by relevance.
+type MessagesFound struct {
+	MessageIds *ul.UidList         `json:"messageIds"`
+	Messages   []derived2.IMessage `json:"messages"`
+	PageData   *cmr.PageData       `json:"pageData,omitempty"`
+}
+
+func NewMessagesFound() (mf *MessagesFound) {
+	mf = &MessagesFound{}
+	return mf
+}
@@ -0,0 +1,100 @@
+package models
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/vault-thirteen/SimpleBB/pkg/common/interfaces/base1"
+	cmb "github.com/vault-thirteen/SimpleBB/pkg/common/models/base"
+)
+
+// ModerationAction is an action taken by a moderator about a reported
+// message.
+type ModerationAction byte
+
+const (
+	// ModerationAction_Dismiss closes reports without any action.
+	ModerationAction_Dismiss = ModerationAction(1)
+
+	// ModerationAction_DeleteMessage moves the message to the trash.
+	ModerationAction_DeleteMessage = ModerationAction(2)
+
+	// ModerationAction_BanAuthor bans the author of the message.
+	ModerationAction_BanAuthor = ModerationAction(3)
+
+	// ModerationAction_LockThread locks the thread of the message.
+	ModerationAction_LockThread = ModerationAction(4)
+
+	ModerationActionMax = ModerationAction_LockThread
+)
+
+func (ma ModerationAction) IsValid() bool {
+	return (ma >= ModerationAction_Dismiss) && (ma <= ModerationActionMax)
+}
+
+// ModerationDecision is a record of a decision made by a moderator about a
+// reported message. Decisions are never changed, so they are an audit trail
+// of the moderation.
+type ModerationDecision struct {
+	Id        cmb.Id `json:"id"`
+	MessageId cmb.Id `json:"messageId"`
+	ThreadId  cmb.Id `json:"threadId"`
+
+	// Author of the reported message.
+	AuthorUserId cmb.Id `json:"authorUserId"`
+
+	Action  ModerationAction `json:"action"`
+	Comment cmb.Text         `json:"comment"`
+
+	// Number of reports resolved by the decision.
+	ReportsCount cmb.Count `json:"reportsCount"`
+
+	ModeratorUserId cmb.Id    `json:"moderatorUserId"`
+	TimeOfCreation  time.Time `json:"toc"`
+}
+
+func NewModerationDecision() (md *ModerationDecision) {
+	return &ModerationDecision{}
+}
+
+func NewModerationDecisionFromScannableSource(src base.IScannable) (md *ModerationDecision, err error) {
+	md = NewModerationDecision()
+
+	err = src.Scan(
+		&md.Id,
+		&md.MessageId,
+		&md.ThreadId,
+		&md.AuthorUserId,
+		&md.Action,
+		&md.Comment,
+		&md.ReportsCount,
+		&md.ModeratorUserId,
+		&md.TimeOfCreation,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		} else {
+			return nil, err
+		}
+	}
+
+	return md, nil
+}
+
+func NewModerationDecisionArrayFromRows(rows base.IScannableSequence) (mds []ModerationDecision, err error) {
+	mds = []ModerationDecision{}
+	var md *ModerationDecision
+
+	for rows.Next() {
+		md, err = NewModerationDecisionFromScannableSource(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		mds = append(mds, *md)
+	}
+
+	return mds, nil
+}
@@ -0,0 +1,29 @@
+package models
+
+import (
+	cmr "github.com/vault-thirteen/SimpleBB/pkg/common/models/rpc"
+)
+
+// ModerationQueueOnPage is a page of the moderation queue. Messages are
+// ordered by their first open report, the oldest reported messages go first.
+type ModerationQueueOnPage struct {
+	Messages []ReportedMessage `json:"messages"`
+	PageData *cmr.PageData     `json:"pageData,omitempty"`
+}
+
+func NewModerationQueueOnPage() (mqop *ModerationQueueOnPage) {
+	mqop = &ModerationQueueOnPage{}
+	return mqop
+}
+
+// ModerationDecisionsOnPage is a page of moderation decisions. The latest
+// decisions go first.
+type ModerationDecisionsOnPage struct {
+	Decisions []ModerationDecision `json:"decisions"`
+	PageData  *cmr.PageData        `json:"pageData,omitempty"`
+}
+
+func NewModerationDecisionsOnPage() (mdop *ModerationDecisionsOnPage) {
+	mdop = &ModerationDecisionsOnPage{}
+	return mdop
+}
@@ -0,0 +1,229 @@
+package models
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/vault-thirteen/SimpleBB/pkg/common/interfaces/base1"
+	cmb "github.com/vault-thirteen/SimpleBB/pkg/common/models/base"
+	"time"
+)
+
+const (
+	// PollOptionsMinCount is the minimal number of options in a poll.
+	PollOptionsMinCount = 2
+)
+
+// NewPoll is a set of parameters of a poll created together with a thread.
+type NewPoll struct {
+	Question cmb.Text   `json:"question"`
+	Options  []cmb.Text `json:"options"`
+
+	// IsMultipleChoice flag allows to vote for several options.
+	IsMultipleChoice cmb.Flag `json:"isMultipleChoice"`
+
+	// AreResultsHidden flag hides results until the poll is closed.
+	AreResultsHidden cmb.Flag `json:"areResultsHidden"`
+
+	// Poll without closing time is never closed.
+	ClosingTime *time.Time `json:"closingTime"`
+}
+
+// Poll is a poll attached to a thread. A thread may have only one poll.
+type Poll struct {
+	Id       cmb.Id   `json:"id"`
+	ThreadId cmb.Id   `json:"threadId"`
+	Question cmb.Text `json:"question"`
+
+	IsMultipleChoice cmb.Flag   `json:"isMultipleChoice"`
+	AreResultsHidden cmb.Flag   `json:"areResultsHidden"`
+	ClosingTime      *time.Time `json:"closingTime"`
+
+	// IsClosed flag is set by the scheduler when closing time has come.
+	IsClosed cmb.Flag `json:"isClosed"`
+
+	TimeOfCreation time.Time `json:"timeOfCreation"`
+
+	Options []PollOption `json:"options"`
+}
+
+// PollOption is an option of a poll.
+type PollOption struct {
+	Id     cmb.Id   `json:"id"`
+	PollId cmb.Id   `json:"pollId"`
+	Text   cmb.Text `json:"text"`
+}
+
+// PollOptionResult is a number of votes given to an option of a poll.
+type PollOptionResult struct {
+	OptionId   cmb.Id    `json:"optionId"`
+	VotesCount cmb.Count `json:"votesCount"`
+}
+
+// PollResults are results of a poll. In a poll with multiple choice the sum
+// of votes may exceed the number of voters.
+type PollResults struct {
+	VotersCount cmb.Count          `json:"votersCount"`
+	Options     []PollOptionResult `json:"options"`
+}
+
+// CheckNewPollOptions checks options of a new poll. Options must be set and
+// they must not be repeated.
+func CheckNewPollOptions(options []cmb.Text, maxCount cmb.Count) (ok bool) {
+	if (len(options) < PollOptionsMinCount) || (cmb.Count(len(options)) > maxCount) {
+		return false
+	}
+
+	var texts = make(map[cmb.Text]bool, len(options))
+	for _, option := range options {
+		if len(option) == 0 {
+			return false
+		}
+
+		if texts[option] {
+			return false
+		}
+
+		texts[option] = true
+	}
+
+	return true
+}
+
+func NewPollFromScannableSource(src base.IScannable) (p *Poll, err error) {
+	p = &Poll{}
+
+	err = src.Scan(
+		&p.Id,
+		&p.ThreadId,
+		&p.Question,
+		&p.IsMultipleChoice,
+		&p.AreResultsHidden,
+		&p.ClosingTime,
+		&p.IsClosed,
+		&p.TimeOfCreation,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		} else {
+			return nil, err
+		}
+	}
+
+	p.Options = []PollOption{}
+
+	return p, nil
+}
+
+func NewPollOptionFromScannableSource(src base.IScannable) (po *PollOption, err error) {
+	po = &PollOption{}
+
+	err = src.Scan(
+		&po.Id,
+		&po.PollId,
+		&po.Text,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		} else {
+			return nil, err
+		}
+	}
+
+	return po, nil
+}
+
+func NewPollOptionArrayFromRows(rows base.IScannableSequence) (pos []PollOption, err error) {
+	pos = []PollOption{}
+	var po *PollOption
+
+	for rows.Next() {
+		po, err = NewPollOptionFromScannableSource(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		pos = append(pos, *po)
+	}
+
+	return pos, nil
+}
+
+func NewPollOptionResultFromScannableSource(src base.IScannable) (por *PollOptionResult, err error) {
+	por = &PollOptionResult{}
+
+	err = src.Scan(
+		&por.OptionId,
+		&por.VotesCount,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		} else {
+			return nil, err
+		}
+	}
+
+	return por, nil
+}
+
+func NewPollOptionResultArrayFromRows(rows base.IScannableSequence) (pors []PollOptionResult, err error) {
+	pors = []PollOptionResult{}
+	var por *PollOptionResult
+
+	for rows.Next() {
+		por, err = NewPollOptionResultFromScannableSource(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		pors = append(pors, *por)
+	}
+
+	return pors, nil
+}
+
+// IsOpen checks whether the poll accepts votes at the specified time.
+func (p *Poll) IsOpen(now time.Time) bool {
+	if p.IsClosed {
+		return false
+	}
+
+	return (p.ClosingTime == nil) || now.Before(*p.ClosingTime)
+}
+
+// AreResultsVisible checks whether results of the poll may be shown at the
+// specified time.
+func (p *Poll) AreResultsVisible(now time.Time) bool {
+	return !p.AreResultsHidden.AsBool() || !p.IsOpen(now)
+}
+
+// IsVoteValid checks a set of options chosen by a voter. Options must belong
+// to the poll, they must not be repeated, and only a single option may be
+// chosen in a poll without multiple choice.
+func (p *Poll) IsVoteValid(optionIds []cmb.Id) bool {
+	if len(optionIds) == 0 {
+		return false
+	}
+
+	if !p.IsMultipleChoice && (len(optionIds) > 1) {
+		return false
+	}
+
+	var pollOptionIds = make(map[cmb.Id]bool, len(p.Options))
+	for _, option := range p.Options {
+		pollOptionIds[option.Id] = true
+	}
+
+	var chosenOptionIds = make(map[cmb.Id]bool, len(optionIds))
+	for _, optionId := range optionIds {
+		if !pollOptionIds[optionId] || chosenOptionIds[optionId] {
+			return false
+		}
+
+		chosenOptionIds[optionId] = true
+	}
+
+	return true
+}
@@ -0,0 +1,53 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	cmb "github.com/vault-thirteen/SimpleBB/pkg/common/models/base"
+	"github.com/vault-thirteen/auxie/tester"
+)
+
+func Test_CheckNewPollOptions(t *testing.T) {
+	aTest := tester.New(t)
+
+	aTest.MustBeEqual(CheckNewPollOptions([]cmb.Text{"Yes", "No"}, 2), true)
+	aTest.MustBeEqual(CheckNewPollOptions([]cmb.Text{"Yes"}, 10), false)
+	aTest.MustBeEqual(CheckNewPollOptions([]cmb.Text{"A", "B", "C"}, 2), false)
+	aTest.MustBeEqual(CheckNewPollOptions([]cmb.Text{"Yes", ""}, 10), false)
+	aTest.MustBeEqual(CheckNewPollOptions([]cmb.Text{"Yes", "Yes"}, 10), false)
+}
+
+func Test_Poll_IsOpen(t *testing.T) {
+	aTest := tester.New(t)
+	var now = time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	var future = now.Add(time.Hour)
+	var past = now.Add(-time.Hour)
+
+	aTest.MustBeEqual((&Poll{}).IsOpen(now), true)
+	aTest.MustBeEqual((&Poll{ClosingTime: &future}).IsOpen(now), true)
+	aTest.MustBeEqual((&Poll{ClosingTime: &past}).IsOpen(now), false)
+	aTest.MustBeEqual((&Poll{ClosingTime: &now}).IsOpen(now), false)
+	aTest.MustBeEqual((&Poll{IsClosed: true}).IsOpen(now), false)
+
+	// Hidden results are shown when the poll is closed.
+	aTest.MustBeEqual((&Poll{ClosingTime: &future}).AreResultsVisible(now), true)
+	aTest.MustBeEqual((&Poll{ClosingTime: &future, AreResultsHidden: true}).AreResultsVisible(now), false)
+	aTest.MustBeEqual((&Poll{ClosingTime: &past, AreResultsHidden: true}).AreResultsVisible(now), true)
+}
+
+func Test_Poll_IsVoteValid(t *testing.T) {
+	aTest := tester.New(t)
+	var options = []PollOption{{Id: 1}, {Id: 2}, {Id: 3}}
+
+	var single = &Poll{Options: options}
+	aTest.MustBeEqual(single.IsVoteValid([]cmb.Id{2}), true)
+	aTest.MustBeEqual(single.IsVoteValid([]cmb.Id{}), false)
+	aTest.MustBeEqual(single.IsVoteValid([]cmb.Id{1, 2}), false)
+	aTest.MustBeEqual(single.IsVoteValid([]cmb.Id{4}), false)
+
+	var multiple = &Poll{Options: options, IsMultipleChoice: true}
+	aTest.MustBeEqual(multiple.IsVoteValid([]cmb.Id{1, 3}), true)
+	aTest.MustBeEqual(multiple.IsVoteValid([]cmb.Id{1, 1}), false)
+	aTest.MustBeEqual(multiple.IsVoteValid([]cmb.Id{1, 4}), false)
+}
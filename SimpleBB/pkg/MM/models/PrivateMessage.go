@@ -0,0 +1,79 @@
+package models
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/vault-thirteen/SimpleBB/pkg/common/interfaces/base1"
+	cmb "github.com/vault-thirteen/SimpleBB/pkg/common/models/base"
+	"time"
+)
+
+// PrivateMessage is a message of a private conversation. Private messages
+// can not be edited.
+type PrivateMessage struct {
+	// Identifier of this message.
+	Id cmb.Id `json:"id"`
+
+	// Identifier of a conversation containing this message.
+	ConversationId cmb.Id `json:"conversationId"`
+
+	// Text of this message.
+	Text cmb.Text `json:"text"`
+
+	// Author of this message and time of creation.
+	CreatorUserId cmb.Id    `json:"creatorUserId"`
+	CreatorTime   time.Time `json:"creatorTime"`
+}
+
+func NewPrivateMessage() (pm *PrivateMessage) {
+	return &PrivateMessage{}
+}
+
+func NewPrivateMessageFromScannableSource(src base.IScannable) (pm *PrivateMessage, err error) {
+	pm = NewPrivateMessage()
+
+	err = src.Scan(
+		&pm.Id,
+		&pm.ConversationId,
+		&pm.Text,
+		&pm.CreatorUserId,
+		&pm.CreatorTime,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		} else {
+			return nil, err
+		}
+	}
+
+	return pm, nil
+}
+
+func NewPrivateMessageArrayFromRows(rows base.IScannableSequence) (pms []PrivateMessage, err error) {
+	pms = []PrivateMessage{}
+	var pm *PrivateMessage
+
+	for rows.Next() {
+		pm, err = NewPrivateMessageFromScannableSource(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		pms = append(pms, *pm)
+	}
+
+	return pms, nil
+}
+
+// LastPrivateMessageId returns the largest ID of messages, or zero when the
+// list is empty.
+func LastPrivateMessageId(pms []PrivateMessage) (lastId cmb.Id) {
+	for _, pm := range pms {
+		if pm.Id > lastId {
+			lastId = pm.Id
+		}
+	}
+
+	return lastId
+}
@@ -0,0 +1,63 @@
+package models
+
+import (
+	"errors"
+	cmb "github.com/vault-thirteen/SimpleBB/pkg/common/models/base"
+)
+
+const (
+	// ReactionEmojiMaxLength is the maximal length of an emoji (in bytes). It
+	// must be in accordance with the size of the 'Emoji' column of the
+	// reactions table.
+	ReactionEmojiMaxLength = 32
+)
+
+const (
+	ErrReactionEmojiIsNotSet   = "reaction emoji is not set"
+	ErrReactionEmojiIsTooLong  = "reaction emoji is too long"
+	ErrReactionEmojiIsRepeated = "reaction emoji is repeated"
+)
+
+// Reaction is a type of reaction which users may put on messages. Each
+// reaction changes reputation of the message's author by its weight. Weight
+// may be negative.
+type Reaction struct {
+	Emoji  string    `json:"emoji"`
+	Weight cmb.Count `json:"weight"`
+}
+
+// CheckReactions checks a set of reactions. Emojis must be set and they must
+// not be repeated.
+func CheckReactions(reactions []Reaction) (err error) {
+	var emojis = make(map[string]bool, len(reactions))
+
+	for _, r := range reactions {
+		if len(r.Emoji) == 0 {
+			return errors.New(ErrReactionEmojiIsNotSet)
+		}
+
+		if len(r.Emoji) > ReactionEmojiMaxLength {
+			return errors.New(ErrReactionEmojiIsTooLong)
+		}
+
+		if emojis[r.Emoji] {
+			return errors.New(ErrReactionEmojiIsRepeated)
+		}
+
+		emojis[r.Emoji] = true
+	}
+
+	return nil
+}
+
+// FindReaction searches for a reaction with the emoji. Null is returned when
+// the reaction is not found.
+func FindReaction(reactions []Reaction, emoji string) (r *Reaction) {
+	for i := range reactions {
+		if reactions[i].Emoji == emoji {
+			return &reactions[i]
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,51 @@
+package models
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/vault-thirteen/auxie/tester"
+)
+
+func Test_CheckReactions(t *testing.T) {
+	aTest := tester.New(t)
+
+	aTest.MustBeNoError(CheckReactions(nil))
+	aTest.MustBeNoError(CheckReactions([]Reaction{{Emoji: "👍", Weight: 1}, {Emoji: "👎", Weight: -1}}))
+	aTest.MustBeAnError(CheckReactions([]Reaction{{Emoji: "", Weight: 1}}))
+	aTest.MustBeAnError(CheckReactions([]Reaction{{Emoji: strings.Repeat("x", ReactionEmojiMaxLength+1)}}))
+	aTest.MustBeAnError(CheckReactions([]Reaction{{Emoji: "👍", Weight: 1}, {Emoji: "👍", Weight: 2}}))
+}
+
+func Test_FindReaction(t *testing.T) {
+	aTest := tester.New(t)
+	var reactions = []Reaction{{Emoji: "👍", Weight: 1}, {Emoji: "👎", Weight: -1}}
+
+	aTest.MustBeEqual(*FindReaction(reactions, "👎"), Reaction{Emoji: "👎", Weight: -1})
+	aTest.MustBeEqual(FindReaction(reactions, "🙂") == nil, true)
+}
+
+func Test_GroupMessageReactionCounts(t *testing.T) {
+	aTest := tester.New(t)
+
+	aTest.MustBeEqual(GroupMessageReactionCounts(nil), []MessageReactions{})
+
+	var mrcs = []MessageReactionCount{
+		{MessageId: 5, ReactionCount: ReactionCount{Emoji: "👍", Count: 3, IsSelf: true}},
+		{MessageId: 5, ReactionCount: ReactionCount{Emoji: "👎", Count: 1}},
+		{MessageId: 2, ReactionCount: ReactionCount{Emoji: "👎", Count: 2}},
+	}
+	aTest.MustBeEqual(GroupMessageReactionCounts(mrcs), []MessageReactions{
+		{
+			MessageId: 5,
+			Reactions: []ReactionCount{
+				{Emoji: "👍", Count: 3, IsSelf: true},
+				{Emoji: "👎", Count: 1},
+			},
+		},
+		{
+			MessageId: 2,
+			Reactions: []ReactionCount{{Emoji: "👎", Count: 2}},
+		},
+	})
+}
@@ -0,0 +1,23 @@
+package models
+
+import (
+	cmb "github.com/vault-thirteen/SimpleBB/pkg/common/models/base"
+	"time"
+)
+
+// SearchFilter is a set of conditions used in a full-text search.
+// This model is used in internal processes.
+type SearchFilter struct {
+	// Words to search for. At least one word must be set.
+	Words []string
+
+	// Optional filters. Null means that the filter is not used.
+	ForumId       *cmb.Id
+	CreatorUserId *cmb.Id
+	FromTime      *time.Time
+	ToTime        *time.Time
+
+	// Forums where the search is allowed. Null means that all the forums are
+	// allowed, an empty list means that none of them is.
+	ForumIds []cmb.Id
+}
@@ -0,0 +1,376 @@
+package models
+
+import (
+	"fmt"
+	"slices"
+
+	ul "github.com/vault-thirteen/SimpleBB/pkg/common/models/UidList"
+	cmb "github.com/vault-thirteen/SimpleBB/pkg/common/models/base"
+	sct "github.com/vault-thirteen/SimpleBB/pkg/common/models/complex/SectionChildType"
+)
+
+const (
+	// LostAndFoundName is a name of the forum collecting objects detached from
+	// the board's structure, and of the section containing that forum.
+	LostAndFoundName = "Lost+Found"
+
+	// LostAndFoundThreadNameF is a name format of a thread collecting messages
+	// of a missing thread.
+	LostAndFoundThreadNameF = "Lost+Found: messages of thread %v"
+
+	ProblemF_NoRootSection     = "root section is not found, section %v can not be re-linked"
+	ProblemF_RootSectionIsFull = "root section contains forums, section %v can not be re-linked"
+	Problem_NoLostAndFound     = "root section is not found, Lost+Found forum can not be created"
+)
+
+// StructureNode is a section, forum, thread or message reduced to the links
+// between objects. Parent is not set for a root section, children are not set
+// for messages. Name and type of children are used for sections and forums
+// only.
+type StructureNode struct {
+	Id        cmb.Id
+	ParentId  *cmb.Id
+	Children  *ul.UidList
+	ChildType byte
+	Name      string
+}
+
+// Structure is the structure of the board.
+type Structure struct {
+	Sections []StructureNode
+	Forums   []StructureNode
+	Threads  []StructureNode
+	Messages []StructureNode
+}
+
+// Orphans are objects detached from the board's structure. Sections are
+// re-linked into the root section, forums and threads are re-linked into the
+// Lost+Found forum and its section, messages are re-linked into threads of
+// the Lost+Found forum, one thread per missing thread.
+type Orphans struct {
+	RootSectionId *cmb.Id       `json:"rootSectionId"`
+	Sections      []cmb.Id      `json:"sections"`
+	Forums        []cmb.Id      `json:"forums"`
+	Threads       []cmb.Id      `json:"threads"`
+	Messages      []MessageLink `json:"messages"`
+}
+
+// LostAndFound are objects which collect orphans.
+type LostAndFound struct {
+	SectionId cmb.Id   `json:"sectionId"`
+	ForumId   cmb.Id   `json:"forumId"`
+	ThreadIds []cmb.Id `json:"threadIds"`
+}
+
+// ListRepair is a rebuilt list of children of an object.
+type ListRepair struct {
+	ParentId cmb.Id      `json:"parentId"`
+	OldItems *ul.UidList `json:"oldItems"`
+	NewItems *ul.UidList `json:"newItems"`
+}
+
+// ChildTypeRepair is a corrected type of children of a section.
+type ChildTypeRepair struct {
+	SectionId    cmb.Id `json:"sectionId"`
+	OldChildType byte   `json:"oldChildType"`
+	NewChildType byte   `json:"newChildType"`
+}
+
+// ListRepairs are changes of lists of children.
+type ListRepairs struct {
+	SectionChildren   []ListRepair      `json:"sectionChildren"`
+	SectionChildTypes []ChildTypeRepair `json:"sectionChildTypes"`
+	ForumThreads      []ListRepair      `json:"forumThreads"`
+	ThreadMessages    []ListRepair      `json:"threadMessages"`
+}
+
+// ConsistencyReport is a result of a repair of the board's structure. When
+// the repair is not applied, the report shows changes which would be made.
+// Lists are rebuilt after orphans are re-linked, so in a dry run the orphans
+// are not shown in the lists.
+type ConsistencyReport struct {
+	IsApplied    cmb.Flag      `json:"isApplied"`
+	Orphans      *Orphans      `json:"orphans"`
+	LostAndFound *LostAndFound `json:"lostAndFound,omitempty"`
+	ListRepairs  *ListRepairs  `json:"listRepairs"`
+
+	// Problems which can not be repaired automatically.
+	Problems []string `json:"problems"`
+}
+
+func NewConsistencyReport() (cr *ConsistencyReport) {
+	cr = &ConsistencyReport{
+		Problems: []string{},
+	}
+	return cr
+}
+
+// NeedsLostAndFound checks whether the orphans need the Lost+Found forum.
+func (o *Orphans) NeedsLostAndFound() bool {
+	return (len(o.Forums) > 0) || (len(o.Threads) > 0) || (len(o.Messages) > 0)
+}
+
+// containsId checks whether a sorted array contains the identifier.
+func containsId(ids []cmb.Id, id cmb.Id) bool {
+	_, found := slices.BinarySearch(ids, id)
+	return found
+}
+
+// FindOrphans finds objects which are detached from the structure. A section
+// is detached when its parent does not exist, when it is one more root
+// section or when it is a part of a loop of sections. A forum is detached
+// when its section does not exist or contains sections. Threads and messages
+// are detached when their parents do not exist. Sections which can not be
+// re-linked are returned as problems.
+func (s *Structure) FindOrphans() (o *Orphans, problems []string) {
+	o = &Orphans{
+		Sections: []cmb.Id{},
+		Forums:   []cmb.Id{},
+		Threads:  []cmb.Id{},
+		Messages: []MessageLink{},
+	}
+	problems = []string{}
+
+	sections := sortNodes(s.Sections)
+	sectionsMap := makeNodesMap(sections)
+
+	// Root section is the oldest section without a parent.
+	for _, section := range sections {
+		if section.ParentId == nil {
+			id := section.Id
+			o.RootSectionId = &id
+			break
+		}
+	}
+
+	// Sections.
+	var rootHasForums = false
+	if o.RootSectionId != nil {
+		for _, forum := range s.Forums {
+			if (forum.ParentId != nil) && (*forum.ParentId == *o.RootSectionId) {
+				rootHasForums = true
+				break
+			}
+		}
+	}
+
+	var hasSections = make(map[cmb.Id]bool)
+	for _, section := range sections {
+		if !isSectionDetached(section, sectionsMap, o.RootSectionId) {
+			if section.ParentId != nil {
+				hasSections[*section.ParentId] = true
+			}
+			continue
+		}
+
+		if o.RootSectionId == nil {
+			problems = append(problems, fmt.Sprintf(ProblemF_NoRootSection, section.Id))
+			continue
+		}
+
+		if rootHasForums {
+			problems = append(problems, fmt.Sprintf(ProblemF_RootSectionIsFull, section.Id))
+			continue
+		}
+
+		o.Sections = append(o.Sections, section.Id)
+		hasSections[*o.RootSectionId] = true
+	}
+
+	// Forums.
+	forums := sortNodes(s.Forums)
+	for _, forum := range forums {
+		if (forum.ParentId == nil) || (sectionsMap[*forum.ParentId] == nil) || hasSections[*forum.ParentId] {
+			o.Forums = append(o.Forums, forum.Id)
+		}
+	}
+
+	// Threads.
+	forumsMap := makeNodesMap(forums)
+	threads := sortNodes(s.Threads)
+	for _, thread := range threads {
+		if (thread.ParentId == nil) || (forumsMap[*thread.ParentId] == nil) {
+			o.Threads = append(o.Threads, thread.Id)
+		}
+	}
+
+	// Messages.
+	threadsMap := makeNodesMap(threads)
+	for _, message := range sortNodes(s.Messages) {
+		if (message.ParentId == nil) || (threadsMap[*message.ParentId] == nil) {
+			ml := MessageLink{Id: message.Id}
+			if message.ParentId != nil {
+				ml.ThreadId = *message.ParentId
+			}
+			o.Messages = append(o.Messages, ml)
+		}
+	}
+
+	return o, problems
+}
+
+// isSectionDetached checks whether a section is detached from the root
+// section. Sections attached to a detached section are not detached
+// themselves, they are moved together with their parent.
+func isSectionDetached(section *StructureNode, sectionsMap map[cmb.Id]*StructureNode, rootId *cmb.Id) bool {
+	if section.ParentId == nil {
+		return (rootId == nil) || (section.Id != *rootId)
+	}
+
+	// Loops are searched for by going upwards. A section is a part of a loop
+	// when it is met again.
+	var current = section
+	for i := 0; i <= len(sectionsMap); i++ {
+		if current.ParentId == nil {
+			return false
+		}
+
+		parent := sectionsMap[*current.ParentId]
+		if parent == nil {
+			// Parent of the section itself is missing.
+			return current == section
+		}
+
+		if parent == section {
+			return true
+		}
+
+		current = parent
+	}
+
+	return false
+}
+
+// RebuildLists rebuilds lists of children from the actual parents of objects
+// and corrects types of children of sections. Existing order of children is
+// kept, missing children are added to the end of a list in order of their
+// creation. Orphans are not added to any list.
+func (s *Structure) RebuildLists(o *Orphans) (lr *ListRepairs) {
+	lr = &ListRepairs{
+		SectionChildren:   []ListRepair{},
+		SectionChildTypes: []ChildTypeRepair{},
+		ForumThreads:      []ListRepair{},
+		ThreadMessages:    []ListRepair{},
+	}
+
+	var orphanMessages = make([]cmb.Id, 0, len(o.Messages))
+	for _, ml := range o.Messages {
+		orphanMessages = append(orphanMessages, ml.Id)
+	}
+
+	childSections := groupChildren(s.Sections, o.Sections)
+	childForums := groupChildren(s.Forums, o.Forums)
+	childThreads := groupChildren(s.Threads, o.Threads)
+	childMessages := groupChildren(s.Messages, orphanMessages)
+
+	// Sections.
+	for _, section := range sortNodes(s.Sections) {
+		var children = childSections[section.Id]
+		var childType byte = sct.SectionChildType_Section
+		if len(children) == 0 {
+			children = childForums[section.Id]
+			childType = sct.SectionChildType_Forum
+		}
+		if len(children) == 0 {
+			childType = sct.SectionChildType_None
+		}
+
+		if section.ChildType != childType {
+			lr.SectionChildTypes = append(lr.SectionChildTypes, ChildTypeRepair{
+				SectionId:    section.Id,
+				OldChildType: section.ChildType,
+				NewChildType: childType,
+			})
+		}
+
+		list, isChanged := RebuildList(section.Children, children)
+		if isChanged {
+			lr.SectionChildren = append(lr.SectionChildren, ListRepair{ParentId: section.Id, OldItems: section.Children, NewItems: list})
+		}
+	}
+
+	// Forums.
+	for _, forum := range sortNodes(s.Forums) {
+		list, isChanged := RebuildList(forum.Children, childThreads[forum.Id])
+		if isChanged {
+			lr.ForumThreads = append(lr.ForumThreads, ListRepair{ParentId: forum.Id, OldItems: forum.Children, NewItems: list})
+		}
+	}
+
+	// Threads.
+	for _, thread := range sortNodes(s.Threads) {
+		list, isChanged := RebuildList(thread.Children, childMessages[thread.Id])
+		if isChanged {
+			lr.ThreadMessages = append(lr.ThreadMessages, ListRepair{ParentId: thread.Id, OldItems: thread.Children, NewItems: list})
+		}
+	}
+
+	return lr
+}
+
+// RebuildList makes a list of the actual children. Children which are already
+// in the list keep their order, other children are added to the end of the
+// list. Children must be sorted.
+func RebuildList(current *ul.UidList, children []cmb.Id) (list *ul.UidList, isChanged bool) {
+	var isChild = make(map[cmb.Id]bool, len(children))
+	for _, id := range children {
+		isChild[id] = true
+	}
+
+	var items = make([]cmb.Id, 0, len(children))
+	var isAdded = make(map[cmb.Id]bool, len(children))
+	for _, id := range current.AsArray() {
+		if isChild[id] && !isAdded[id] {
+			items = append(items, id)
+			isAdded[id] = true
+		}
+	}
+
+	for _, id := range children {
+		if !isAdded[id] {
+			items = append(items, id)
+		}
+	}
+
+	l := ul.UidList(items)
+	return &l, !slices.Equal(current.AsArray(), items)
+}
+
+// groupChildren groups identifiers of objects by their parents. Orphans must
+// be sorted.
+func groupChildren(nodes []StructureNode, orphans []cmb.Id) (children map[cmb.Id][]cmb.Id) {
+	children = make(map[cmb.Id][]cmb.Id)
+
+	for _, node := range sortNodes(nodes) {
+		if (node.ParentId == nil) || containsId(orphans, node.Id) {
+			continue
+		}
+
+		children[*node.ParentId] = append(children[*node.ParentId], node.Id)
+	}
+
+	return children
+}
+
+// sortNodes returns pointers to nodes sorted by their identifiers, i.e. in
+// order of their creation.
+func sortNodes(nodes []StructureNode) (sorted []*StructureNode) {
+	sorted = make([]*StructureNode, 0, len(nodes))
+	for i := range nodes {
+		sorted = append(sorted, &nodes[i])
+	}
+
+	slices.SortFunc(sorted, func(a, b *StructureNode) int {
+		return int(a.Id) - int(b.Id)
+	})
+
+	return sorted
+}
+
+func makeNodesMap(nodes []*StructureNode) (m map[cmb.Id]*StructureNode) {
+	m = make(map[cmb.Id]*StructureNode, len(nodes))
+	for _, node := range nodes {
+		m[node.Id] = node
+	}
+	return m
+}
@@ -0,0 +1,142 @@
+package models
+
+import (
+	"testing"
+
+	ul "github.com/vault-thirteen/SimpleBB/pkg/common/models/UidList"
+	cmb "github.com/vault-thirteen/SimpleBB/pkg/common/models/base"
+	sct "github.com/vault-thirteen/SimpleBB/pkg/common/models/complex/SectionChildType"
+	"github.com/vault-thirteen/auxie/tester"
+)
+
+func Test_RebuildList(t *testing.T) {
+	aTest := tester.New(t)
+
+	type TestData struct {
+		current   *ul.UidList
+		children  []cmb.Id
+		list      *ul.UidList
+		isChanged bool
+	}
+
+	tests := []TestData{
+		{makeList(3, 1, 9), []cmb.Id{1, 2, 3}, makeList(3, 1, 2), true},
+		{makeList(3, 1), []cmb.Id{1, 3}, makeList(3, 1), false},
+		{makeList(1, 1), []cmb.Id{1}, makeList(1), true},
+		{nil, []cmb.Id{4, 5}, makeList(4, 5), true},
+		{nil, nil, makeList(), false},
+		{makeList(7), nil, makeList(), true},
+	}
+
+	var list *ul.UidList
+	var isChanged bool
+	for _, test := range tests {
+		list, isChanged = RebuildList(test.current, test.children)
+		aTest.MustBeEqual(list.AsArray(), test.list.AsArray())
+		aTest.MustBeEqual(isChanged, test.isChanged)
+	}
+}
+
+func Test_FindOrphans(t *testing.T) {
+	aTest := tester.New(t)
+
+	s := makeStructure()
+	o, problems := s.FindOrphans()
+	aTest.MustBeEqual(*o.RootSectionId, cmb.Id(1))
+	aTest.MustBeEqual(o.Sections, []cmb.Id{3, 4, 5, 6})
+	aTest.MustBeEqual(o.Forums, []cmb.Id{11, 12})
+	aTest.MustBeEqual(o.Threads, []cmb.Id{21})
+	aTest.MustBeEqual(o.Messages, []MessageLink{{Id: 31, ThreadId: 88}, {Id: 32, ThreadId: 88}})
+	aTest.MustBeEqual(problems, []string{})
+	aTest.MustBeEqual(o.NeedsLostAndFound(), true)
+
+	// Sections can not be moved into a root section containing forums.
+	s.Forums = append(s.Forums, StructureNode{Id: 13, ParentId: makeId(1)})
+	o, problems = s.FindOrphans()
+	aTest.MustBeEqual(o.Sections, []cmb.Id{})
+	aTest.MustBeEqual(len(problems), 4)
+
+	// Without a root section nothing can be re-linked.
+	s = &Structure{Sections: []StructureNode{{Id: 1, ParentId: makeId(2)}, {Id: 2, ParentId: makeId(1)}}}
+	o, problems = s.FindOrphans()
+	aTest.MustBeEqual(o.RootSectionId == nil, true)
+	aTest.MustBeEqual(len(problems), 2)
+	aTest.MustBeEqual(o.NeedsLostAndFound(), false)
+}
+
+func Test_RebuildLists(t *testing.T) {
+	aTest := tester.New(t)
+
+	s := makeStructure()
+	o, _ := s.FindOrphans()
+	lr := s.RebuildLists(o)
+
+	aTest.MustBeEqual(lr.SectionChildTypes, []ChildTypeRepair{
+		{SectionId: 2, OldChildType: sct.SectionChildType_None, NewChildType: sct.SectionChildType_Forum},
+		{SectionId: 3, OldChildType: sct.SectionChildType_Forum, NewChildType: sct.SectionChildType_None},
+		{SectionId: 5, OldChildType: sct.SectionChildType_None, NewChildType: sct.SectionChildType_Section},
+		{SectionId: 7, OldChildType: sct.SectionChildType_Section, NewChildType: sct.SectionChildType_None},
+	})
+
+	aTest.MustBeEqual(len(lr.SectionChildren), 4)
+	aTest.MustBeEqual(lr.SectionChildren[0].ParentId, cmb.Id(2))
+	aTest.MustBeEqual(lr.SectionChildren[0].NewItems.AsArray(), []cmb.Id{10})
+	aTest.MustBeEqual(lr.SectionChildren[1].ParentId, cmb.Id(3))
+	aTest.MustBeEqual(lr.SectionChildren[1].NewItems.AsArray(), []cmb.Id{})
+	aTest.MustBeEqual(lr.SectionChildren[2].ParentId, cmb.Id(5))
+	aTest.MustBeEqual(lr.SectionChildren[2].NewItems.AsArray(), []cmb.Id{7})
+	aTest.MustBeEqual(lr.SectionChildren[3].ParentId, cmb.Id(7))
+	aTest.MustBeEqual(lr.SectionChildren[3].NewItems.AsArray(), []cmb.Id{})
+
+	aTest.MustBeEqual(len(lr.ForumThreads), 1)
+	aTest.MustBeEqual(lr.ForumThreads[0].ParentId, cmb.Id(10))
+	aTest.MustBeEqual(lr.ForumThreads[0].OldItems.AsArray(), []cmb.Id{25, 20})
+	aTest.MustBeEqual(lr.ForumThreads[0].NewItems.AsArray(), []cmb.Id{20})
+
+	aTest.MustBeEqual(len(lr.ThreadMessages), 2)
+	aTest.MustBeEqual(lr.ThreadMessages[0].ParentId, cmb.Id(20))
+	aTest.MustBeEqual(lr.ThreadMessages[0].NewItems.AsArray(), []cmb.Id{30})
+	aTest.MustBeEqual(lr.ThreadMessages[1].ParentId, cmb.Id(21))
+	aTest.MustBeEqual(lr.ThreadMessages[1].NewItems.AsArray(), []cmb.Id{})
+}
+
+// makeStructure makes a damaged structure. Section 3 has a missing parent,
+// section 4 is one more root section, sections 5 and 6 make a loop. Forum 11
+// has a missing section, forum 12 is in a section containing sections. Thread
+// 21 and messages 31 and 32 have missing parents.
+func makeStructure() (s *Structure) {
+	return &Structure{
+		Sections: []StructureNode{
+			{Id: 7, ParentId: makeId(5), ChildType: sct.SectionChildType_Section, Children: makeList(99)},
+			{Id: 1, Children: makeList(2), ChildType: sct.SectionChildType_Section},
+			{Id: 2, ParentId: makeId(1), ChildType: sct.SectionChildType_None},
+			{Id: 3, ParentId: makeId(99), ChildType: sct.SectionChildType_Forum, Children: makeList(11)},
+			{Id: 4, ChildType: sct.SectionChildType_None},
+			{Id: 5, ParentId: makeId(6), ChildType: sct.SectionChildType_None},
+			{Id: 6, ParentId: makeId(5), ChildType: sct.SectionChildType_None},
+		},
+		Forums: []StructureNode{
+			{Id: 10, ParentId: makeId(2), Children: makeList(25, 20)},
+			{Id: 11, ParentId: makeId(50)},
+			{Id: 12, ParentId: makeId(5)},
+		},
+		Threads: []StructureNode{
+			{Id: 20, ParentId: makeId(10)},
+			{Id: 21, ParentId: makeId(77), Children: makeList(40)},
+		},
+		Messages: []StructureNode{
+			{Id: 32, ParentId: makeId(88)},
+			{Id: 30, ParentId: makeId(20)},
+			{Id: 31, ParentId: makeId(88)},
+		},
+	}
+}
+
+func makeList(ids ...cmb.Id) *ul.UidList {
+	l := ul.UidList(ids)
+	return &l
+}
+
+func makeId(id cmb.Id) *cmb.Id {
+	return &id
+}
@@ -0,0 +1,79 @@
+package models
+
+import (
+	"strings"
+)
+
+const (
+	DiffLineType_Unchanged = " "
+	DiffLineType_Removed   = "-"
+	DiffLineType_Added     = "+"
+)
+
+// TextDiffMaxComplexity limits the size of a table used for comparison of
+// texts. When texts are too big, they are shown as fully replaced.
+const TextDiffMaxComplexity = 1_000_000
+
+// DiffLine is a line of difference between two texts.
+type DiffLine struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// NewTextDiff compares two texts line by line and returns a list of lines
+// which shows how the old text is turned into the new text.
+func NewTextDiff(oldText string, newText string) (diff []DiffLine) {
+	a := strings.Split(oldText, "\n")
+	b := strings.Split(newText, "\n")
+
+	if len(a)*len(b) > TextDiffMaxComplexity {
+		diff = make([]DiffLine, 0, len(a)+len(b))
+		for _, line := range a {
+			diff = append(diff, DiffLine{Type: DiffLineType_Removed, Text: line})
+		}
+		for _, line := range b {
+			diff = append(diff, DiffLine{Type: DiffLineType_Added, Text: line})
+		}
+		return diff
+	}
+
+	// Lengths of the longest common subsequences of suffixes.
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	diff = make([]DiffLine, 0, max(len(a), len(b)))
+	i, j := 0, 0
+	for (i < len(a)) && (j < len(b)) {
+		switch {
+		case a[i] == b[j]:
+			diff = append(diff, DiffLine{Type: DiffLineType_Unchanged, Text: a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			diff = append(diff, DiffLine{Type: DiffLineType_Removed, Text: a[i]})
+			i++
+		default:
+			diff = append(diff, DiffLine{Type: DiffLineType_Added, Text: b[j]})
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		diff = append(diff, DiffLine{Type: DiffLineType_Removed, Text: a[i]})
+	}
+	for ; j < len(b); j++ {
+		diff = append(diff, DiffLine{Type: DiffLineType_Added, Text: b[j]})
+	}
+
+	return diff
+}
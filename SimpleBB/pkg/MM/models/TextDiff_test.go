@@ -0,0 +1,32 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/vault-thirteen/auxie/tester"
+)
+
+func Test_NewTextDiff(t *testing.T) {
+	aTest := tester.New(t)
+
+	// Test #1. Equal texts.
+	aTest.MustBeEqual(NewTextDiff("a\nb", "a\nb"), []DiffLine{
+		{Type: DiffLineType_Unchanged, Text: "a"},
+		{Type: DiffLineType_Unchanged, Text: "b"},
+	})
+
+	// Test #2. Changed line.
+	aTest.MustBeEqual(NewTextDiff("a\nb\nc", "a\nx\nc"), []DiffLine{
+		{Type: DiffLineType_Unchanged, Text: "a"},
+		{Type: DiffLineType_Removed, Text: "b"},
+		{Type: DiffLineType_Added, Text: "x"},
+		{Type: DiffLineType_Unchanged, Text: "c"},
+	})
+
+	// Test #3. Added and removed lines.
+	aTest.MustBeEqual(NewTextDiff("a\nb", "b\nc"), []DiffLine{
+		{Type: DiffLineType_Removed, Text: "a"},
+		{Type: DiffLineType_Unchanged, Text: "b"},
+		{Type: DiffLineType_Added, Text: "c"},
+	})
+}
@@ -0,0 +1,56 @@
+package models
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/vault-thirteen/SimpleBB/pkg/common/interfaces/base1"
+	cmb "github.com/vault-thirteen/SimpleBB/pkg/common/models/base"
+)
+
+// ThreadName is a short variant of a thread which stores only its ID and
+// name.
+type ThreadName struct {
+	// Identifier of this thread.
+	Id cmb.Id `json:"id"`
+
+	// Name of this thread.
+	Name cmb.Text `json:"name"`
+}
+
+func NewThreadName() (tn *ThreadName) {
+	return &ThreadName{}
+}
+
+func NewThreadNameFromScannableSource(src base.IScannable) (tn *ThreadName, err error) {
+	tn = NewThreadName()
+
+	err = src.Scan(
+		&tn.Id,
+		&tn.Name,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		} else {
+			return nil, err
+		}
+	}
+
+	return tn, nil
+}
+
+func NewThreadNameArrayFromRows(rows base.IScannableSequence) (tns []ThreadName, err error) {
+	tns = []ThreadName{}
+	var tn *ThreadName
+
+	for rows.Next() {
+		tn, err = NewThreadNameFromScannableSource(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		tns = append(tns, *tn)
+	}
+
+	return tns, nil
+}
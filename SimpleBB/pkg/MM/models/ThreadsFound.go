@@ -0,0 +1,20 @@
+package models
+
+import (
+	"github.com/vault-thirteen/SimpleBB/pkg/common/interfaces/derived2"
+	"github.com/vault-thirteen/SimpleBB/pkg/common/models/UidList"
+	cmr "github.com/vault-thirteen/SimpleBB/pkg/common/models/rpc"
+)
+
+// ThreadsFound is a page of threads found by a full-text search. Threads are
+// ordered by relevance.
+type ThreadsFound struct {
+	ThreadIds *ul.UidList        `json:"threadIds"`
+	Threads   []derived2.IThread `json:"threads"`
+	PageData  *cmr.PageData      `json:"pageData,omitempty"`
+}
+
+func NewThreadsFound() (tf *ThreadsFound) {
+	tf = &ThreadsFound{}
+	return tf
+}
@@ -0,0 +1,155 @@
+package models
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/vault-thirteen/SimpleBB/pkg/common/interfaces/base1"
+	ul "github.com/vault-thirteen/SimpleBB/pkg/common/models/UidList"
+	cmb "github.com/vault-thirteen/SimpleBB/pkg/common/models/base"
+	"github.com/vault-thirteen/SimpleBB/pkg/common/models/simple"
+)
+
+// TrashObjectType is a type of deleted object.
+type TrashObjectType byte
+
+const (
+	TrashObjectType_Section = TrashObjectType(1)
+	TrashObjectType_Forum   = TrashObjectType(2)
+	TrashObjectType_Thread  = TrashObjectType(3)
+	TrashObjectType_Message = TrashObjectType(4)
+)
+
+const (
+	// TrashItemNameMaxLength is the maximal length of a trash item's name in
+	// symbols.
+	TrashItemNameMaxLength = 255
+)
+
+// TrashItem is a deleted section, forum, thread or message. The object itself
+// is kept in a separate table until it is restored or purged. Place of the
+// object in the list of its parent is remembered to restore the object where
+// it was.
+type TrashItem struct {
+	Id         cmb.Id          `json:"id"`
+	ObjectType TrashObjectType `json:"objectType"`
+	ObjectId   cmb.Id          `json:"objectId"`
+
+	// Name of a section, forum or thread, or the beginning of a message's
+	// text.
+	Name cmb.Text `json:"name"`
+
+	// Parent is not set for a root section. Previous item is the item which
+	// preceded the object in the parent's list, it is not set when the object
+	// was the first item.
+	ParentId   *cmb.Id      `json:"parentId"`
+	Position   simple.Index `json:"position"`
+	PreviousId *cmb.Id      `json:"previousId"`
+
+	DeletedBy cmb.Id    `json:"deletedBy"`
+	DeletedAt time.Time `json:"deletedAt"`
+}
+
+// NewTrashItem prepares a trash item for an object which is about to be
+// removed from the list of its parent. The list is not set for a root
+// section.
+func NewTrashItem(objectType TrashObjectType, objectId cmb.Id, name string, parentId *cmb.Id, list *ul.UidList, deletedBy cmb.Id) (ti *TrashItem, err error) {
+	ti = &TrashItem{
+		ObjectType: objectType,
+		ObjectId:   objectId,
+		Name:       cmb.Text(truncateText(name, TrashItemNameMaxLength)),
+		ParentId:   parentId,
+		DeletedBy:  deletedBy,
+	}
+
+	if list == nil {
+		return ti, nil
+	}
+
+	ti.Position, err = list.SearchForItem(objectId)
+	if err != nil {
+		return nil, err
+	}
+
+	if ti.Position > 0 {
+		previousId := (*list)[ti.Position-1]
+		ti.PreviousId = &previousId
+	}
+
+	return ti, nil
+}
+
+// RestorePosition finds a position in the parent's list for the restored
+// object. The object is put after the item which preceded it. When that item
+// has gone, the original position is used.
+func (ti *TrashItem) RestorePosition(list *ul.UidList) (pos simple.Index) {
+	if ti.PreviousId == nil {
+		return 0
+	}
+
+	idx, err := list.SearchForItem(*ti.PreviousId)
+	if err == nil {
+		return idx + 1
+	}
+
+	if ti.Position > list.Size() {
+		return list.Size()
+	}
+
+	return ti.Position
+}
+
+func NewTrashItemFromScannableSource(src base.IScannable) (ti *TrashItem, err error) {
+	ti = &TrashItem{}
+
+	err = src.Scan(
+		&ti.Id,
+		&ti.ObjectType,
+		&ti.ObjectId,
+		&ti.Name,
+		&ti.ParentId,
+		&ti.Position,
+		&ti.PreviousId,
+		&ti.DeletedBy,
+		&ti.DeletedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		} else {
+			return nil, err
+		}
+	}
+
+	return ti, nil
+}
+
+func NewTrashItemArrayFromRows(rows base.IScannableSequence) (tis []TrashItem, err error) {
+	tis = []TrashItem{}
+	var ti *TrashItem
+
+	for rows.Next() {
+		ti, err = NewTrashItemFromScannableSource(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		tis = append(tis, *ti)
+	}
+
+	return tis, nil
+}
+
+// truncateText cuts a text to the specified number of symbols.
+func truncateText(text string, maxLength int) string {
+	var n = 0
+	for i := range text {
+		if n == maxLength {
+			return text[:i]
+		}
+		n++
+	}
+
+	return text
+}
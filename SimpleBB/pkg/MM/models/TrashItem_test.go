@@ -0,0 +1,84 @@
+package models
+
+import (
+	"strings"
+	"testing"
+
+	ul "github.com/vault-thirteen/SimpleBB/pkg/common/models/UidList"
+	cmb "github.com/vault-thirteen/SimpleBB/pkg/common/models/base"
+	"github.com/vault-thirteen/SimpleBB/pkg/common/models/simple"
+	"github.com/vault-thirteen/auxie/tester"
+)
+
+func Test_NewTrashItem(t *testing.T) {
+	aTest := tester.New(t)
+	var ti *TrashItem
+	var err error
+
+	list, err := ul.NewFromArray([]cmb.Id{4, 5, 6})
+	aTest.MustBeNoError(err)
+	parentId := cmb.Id(1)
+
+	// Test #1. First item.
+	ti, err = NewTrashItem(TrashObjectType_Thread, 4, "Thread", &parentId, list, 9)
+	aTest.MustBeNoError(err)
+	aTest.MustBeEqual(ti.Position, simple.Index(0))
+	aTest.MustBeEqual(ti.PreviousId, (*cmb.Id)(nil))
+	aTest.MustBeEqual(ti.DeletedBy, cmb.Id(9))
+
+	// Test #2. Last item.
+	ti, err = NewTrashItem(TrashObjectType_Thread, 6, "Thread", &parentId, list, 9)
+	aTest.MustBeNoError(err)
+	aTest.MustBeEqual(ti.Position, simple.Index(2))
+	aTest.MustBeEqual(*ti.PreviousId, cmb.Id(5))
+
+	// Test #3. Item is not in the list.
+	_, err = NewTrashItem(TrashObjectType_Thread, 7, "Thread", &parentId, list, 9)
+	aTest.MustBeAnError(err)
+
+	// Test #4. Root section.
+	ti, err = NewTrashItem(TrashObjectType_Section, 1, "Root", nil, nil, 9)
+	aTest.MustBeNoError(err)
+	aTest.MustBeEqual(ti.Position, simple.Index(0))
+	aTest.MustBeEqual(ti.ParentId, (*cmb.Id)(nil))
+
+	// Test #5. Long name.
+	ti, err = NewTrashItem(TrashObjectType_Message, 4, strings.Repeat("я", TrashItemNameMaxLength+1), &parentId, list, 9)
+	aTest.MustBeNoError(err)
+	aTest.MustBeEqual(ti.Name, cmb.Text(strings.Repeat("я", TrashItemNameMaxLength)))
+}
+
+func Test_RestorePosition(t *testing.T) {
+	aTest := tester.New(t)
+
+	var list *ul.UidList
+	var err error
+	previousId := cmb.Id(5)
+	ti := &TrashItem{ObjectId: 6, Position: 2, PreviousId: &previousId}
+
+	// Test #1. Previous item is in place.
+	list, err = ul.NewFromArray([]cmb.Id{4, 5, 7})
+	aTest.MustBeNoError(err)
+	aTest.MustBeEqual(ti.RestorePosition(list), simple.Index(2))
+
+	// Test #2. Previous item has moved.
+	list, err = ul.NewFromArray([]cmb.Id{5, 4, 7})
+	aTest.MustBeNoError(err)
+	aTest.MustBeEqual(ti.RestorePosition(list), simple.Index(1))
+
+	// Test #3. Previous item has gone.
+	list, err = ul.NewFromArray([]cmb.Id{4, 7, 8})
+	aTest.MustBeNoError(err)
+	aTest.MustBeEqual(ti.RestorePosition(list), simple.Index(2))
+
+	// Test #4. Previous item has gone and the list is shorter.
+	list, err = ul.NewFromArray([]cmb.Id{4})
+	aTest.MustBeNoError(err)
+	aTest.MustBeEqual(ti.RestorePosition(list), simple.Index(1))
+
+	// Test #5. The object was the first item.
+	ti = &TrashItem{ObjectId: 4, Position: 0}
+	list, err = ul.NewFromArray([]cmb.Id{5, 7})
+	aTest.MustBeNoError(err)
+	aTest.MustBeEqual(ti.RestorePosition(list), simple.Index(0))
+}
@@ -0,0 +1,17 @@
+package models
+
+import (
+	cmr "github.com/vault-thirteen/SimpleBB/pkg/common/models/rpc"
+)
+
+// TrashOnPage is a page of the trash. Items are ordered by time of deletion,
+// the latest deleted items go first.
+type TrashOnPage struct {
+	Items    []TrashItem   `json:"items"`
+	PageData *cmr.PageData `json:"pageData,omitempty"`
+}
+
+func NewTrashOnPage() (top *TrashOnPage) {
+	top = &TrashOnPage{}
+	return top
+}
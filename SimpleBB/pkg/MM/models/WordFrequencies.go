@@ -0,0 +1,74 @@
+package models
+
+import (
+	cmb "github.com/vault-thirteen/SimpleBB/pkg/common/models/base"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	// SearchWordMaxLength is the maximal length of a word (in symbols) which
+	// is stored in the search index. It must be in accordance with the size of
+	// the 'Word' column of the index tables.
+	SearchWordMaxLength = 64
+
+	// SearchQueryMaxWords is the maximal number of words used in a search
+	// query. Extra words are ignored.
+	SearchQueryMaxWords = 16
+)
+
+// WordFrequencies is a set of words of a text with the number of occurrences
+// of each word. This model is used for building the search index.
+type WordFrequencies map[string]cmb.Count
+
+// NewWordFrequencies splits the text into words and counts them. Words are
+// converted to lower case. Words which are shorter than the minimal length or
+// longer than the maximal length are ignored.
+func NewWordFrequencies(text string, minWordLength int) (wf WordFrequencies) {
+	wf = WordFrequencies{}
+
+	words := strings.FieldsFunc(strings.ToLower(text), isWordSeparator)
+
+	var wordLength int
+	for _, word := range words {
+		wordLength = utf8.RuneCountInString(word)
+		if (wordLength < minWordLength) || (wordLength > SearchWordMaxLength) {
+			continue
+		}
+
+		wf[word]++
+	}
+
+	return wf
+}
+
+func isWordSeparator(r rune) bool {
+	return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+}
+
+// Words returns a sorted list of unique words.
+func (wf WordFrequencies) Words() (words []string) {
+	words = make([]string, 0, len(wf))
+
+	for word := range wf {
+		words = append(words, word)
+	}
+
+	sort.Strings(words)
+
+	return words
+}
+
+// QueryWords returns a sorted list of unique words limited by the maximal
+// number of words in a search query.
+func (wf WordFrequencies) QueryWords() (words []string) {
+	words = wf.Words()
+
+	if len(words) > SearchQueryMaxWords {
+		words = words[:SearchQueryMaxWords]
+	}
+
+	return words
+}
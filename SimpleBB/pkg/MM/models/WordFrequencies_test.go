@@ -0,0 +1,45 @@
+package models
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/vault-thirteen/auxie/tester"
+)
+
+func Test_NewWordFrequencies(t *testing.T) {
+	aTest := tester.New(t)
+	var wf WordFrequencies
+
+	// Test #1. Empty text.
+	wf = NewWordFrequencies("", 3)
+	aTest.MustBeEqual(wf, WordFrequencies{})
+
+	// Test #2. Short words, case and separators.
+	wf = NewWordFrequencies("The cat, THE dog; a cat!", 3)
+	aTest.MustBeEqual(wf, WordFrequencies{"the": 2, "cat": 2, "dog": 1})
+
+	// Test #3. Non-latin letters and digits.
+	wf = NewWordFrequencies("Привет, мир 2024", 3)
+	aTest.MustBeEqual(wf, WordFrequencies{"привет": 1, "мир": 1, "2024": 1})
+
+	// Test #4. Too long words.
+	wf = NewWordFrequencies("abc "+strings.Repeat("x", SearchWordMaxLength+1), 3)
+	aTest.MustBeEqual(wf, WordFrequencies{"abc": 1})
+}
+
+func Test_WordFrequencies_QueryWords(t *testing.T) {
+	aTest := tester.New(t)
+	var wf WordFrequencies
+
+	// Test #1. Words are sorted.
+	wf = NewWordFrequencies("ccc aaa bbb aaa", 3)
+	aTest.MustBeEqual(wf.QueryWords(), []string{"aaa", "bbb", "ccc"})
+
+	// Test #2. Number of words is limited.
+	wf = WordFrequencies{}
+	for i := 0; i < SearchQueryMaxWords*2; i++ {
+		wf[strings.Repeat("w", i+1)] = 1
+	}
+	aTest.MustBeEqual(len(wf.QueryWords()), SearchQueryMaxWords)
+}
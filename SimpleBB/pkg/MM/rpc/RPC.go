@@ -6,6 +6,7 @@ import (
 	base2 "github.com/vault-thirteen/SimpleBB/pkg/common/models/base"
 	rpc2 "github.com/vault-thirteen/SimpleBB/pkg/common/models/rpc"
 	cm "github.com/vault-thirteen/SimpleBB/pkg/common/models/simple"
+	"time"
 )
 
 // Ping.
@@ -55,6 +56,17 @@ type ChangeSectionParentParams struct {
 }
 type ChangeSectionParentResult = rpc2.CommonResultWithSuccess
 
+type ChangeSectionPrivacyParams struct {
+	rpc2.CommonParams
+
+	// Identifier of a section.
+	SectionId base2.Id `json:"sectionId"`
+
+	// Private section is visible only to users having permissions in it.
+	IsPrivate base2.Flag `json:"isPrivate"`
+}
+type ChangeSectionPrivacyResult = rpc2.CommonResultWithSuccess
+
 type GetSectionParams struct {
 	rpc2.CommonParams
 
@@ -89,6 +101,18 @@ type DeleteSectionParams struct {
 }
 type DeleteSectionResult = rpc2.CommonResultWithSuccess
 
+type SectionExistsSParams struct {
+	rpc2.CommonParams
+	rpc2.DKeyParams
+
+	SectionId base2.Id `json:"sectionId"`
+}
+type SectionExistsSResult struct {
+	rpc2.CommonResult
+
+	Exists base2.Flag `json:"exists"`
+}
+
 // Forum.
 
 type AddForumParams struct {
@@ -128,6 +152,17 @@ type ChangeForumSectionParams struct {
 }
 type ChangeForumSectionResult = rpc2.CommonResultWithSuccess
 
+type ChangeForumReadOnlyParams struct {
+	rpc2.CommonParams
+
+	// Identifier of this forum.
+	ForumId base2.Id `json:"forumId"`
+
+	// Only users having permissions in a read-only forum may write into it.
+	IsReadOnly base2.Flag `json:"isReadOnly"`
+}
+type ChangeForumReadOnlyResult = rpc2.CommonResultWithSuccess
+
 type GetForumParams struct {
 	rpc2.CommonParams
 
@@ -162,6 +197,18 @@ type DeleteForumParams struct {
 }
 type DeleteForumResult = rpc2.CommonResultWithSuccess
 
+type ForumExistsSParams struct {
+	rpc2.CommonParams
+	rpc2.DKeyParams
+
+	ForumId base2.Id `json:"forumId"`
+}
+type ForumExistsSResult struct {
+	rpc2.CommonResult
+
+	Exists base2.Flag `json:"exists"`
+}
+
 // Thread.
 
 type AddThreadParams struct {
@@ -172,6 +219,9 @@ type AddThreadParams struct {
 
 	// Thread name.
 	Name cm.Name `json:"name"`
+
+	// Optional poll attached to the thread.
+	Poll *models.NewPoll `json:"poll"`
 }
 type AddThreadResult struct {
 	rpc2.CommonResult
@@ -246,6 +296,40 @@ type DeleteThreadParams struct {
 }
 type DeleteThreadResult = rpc2.CommonResultWithSuccess
 
+type LockThreadParams struct {
+	rpc2.CommonParams
+
+	ThreadId base2.Id `json:"threadId"`
+}
+type LockThreadResult = rpc2.CommonResultWithSuccess
+
+type UnlockThreadParams struct {
+	rpc2.CommonParams
+
+	ThreadId base2.Id `json:"threadId"`
+}
+type UnlockThreadResult = rpc2.CommonResultWithSuccess
+
+type ChangeThreadPinnedParams struct {
+	rpc2.CommonParams
+
+	ThreadId base2.Id `json:"threadId"`
+
+	// Pinned threads are shown above other threads of the forum.
+	IsPinned base2.Flag `json:"isPinned"`
+}
+type ChangeThreadPinnedResult = rpc2.CommonResultWithSuccess
+
+type ChangeThreadAnnouncementParams struct {
+	rpc2.CommonParams
+
+	ThreadId base2.Id `json:"threadId"`
+
+	// Announcements are shown in all forums of the section.
+	IsAnnouncement base2.Flag `json:"isAnnouncement"`
+}
+type ChangeThreadAnnouncementResult = rpc2.CommonResultWithSuccess
+
 type ThreadExistsSParams struct {
 	rpc2.CommonParams
 	rpc2.DKeyParams
@@ -258,6 +342,26 @@ type ThreadExistsSResult struct {
 	Exists base2.Flag `json:"exists"`
 }
 
+type GetThreadLocationSParams struct {
+	rpc2.CommonParams
+	rpc2.DKeyParams
+
+	ThreadId base2.Id `json:"threadId"`
+}
+type GetThreadLocationSResult struct {
+	rpc2.CommonResult
+
+	ThreadId   base2.Id   `json:"threadId"`
+	ThreadName base2.Text `json:"threadName"`
+	ForumId    base2.Id   `json:"forumId"`
+	ForumName  base2.Text `json:"forumName"`
+
+	// Sections containing the forum, from the forum's section upwards. The
+	// list ends with the root section or with the first private section,
+	// while places outside a private section do not see its content.
+	SectionIds []base2.Id `json:"sectionIds"`
+}
+
 // Message.
 
 type AddMessageParams struct {
@@ -268,6 +372,9 @@ type AddMessageParams struct {
 
 	// Message text.
 	Text base2.Text `json:"text"`
+
+	// Files attached to the message (optional).
+	Attachments []models.NewAttachment `json:"attachments"`
 }
 type AddMessageResult struct {
 	rpc2.CommonResult
@@ -325,6 +432,43 @@ type DeleteMessageParams struct {
 }
 type DeleteMessageResult = rpc2.CommonResultWithSuccess
 
+type ListMessageRevisionsParams struct {
+	rpc2.CommonParams
+
+	MessageId base2.Id `json:"messageId"`
+}
+type ListMessageRevisionsResult struct {
+	rpc2.CommonResult
+
+	// Revisions are ordered from the newest to the oldest.
+	MessageRevisions []models.MessageRevision `json:"messageRevisions"`
+}
+
+type GetMessageRevisionParams struct {
+	rpc2.CommonParams
+
+	RevisionId base2.Id `json:"revisionId"`
+}
+type GetMessageRevisionResult struct {
+	rpc2.CommonResult
+
+	MessageRevision *models.MessageRevision `json:"messageRevision"`
+
+	// IsIntact shows whether the text of the revision matches its check sum.
+	IsIntact base2.Flag `json:"isIntact"`
+
+	// Difference between the text of the revision and the current text of
+	// the message.
+	Diff []models.DiffLine `json:"diff"`
+}
+
+type RestoreMessageRevisionParams struct {
+	rpc2.CommonParams
+
+	RevisionId base2.Id `json:"revisionId"`
+}
+type RestoreMessageRevisionResult = rpc2.CommonResultWithSuccess
+
 // Composite objects.
 
 type ListThreadAndMessagesParams struct {
@@ -348,6 +492,14 @@ type ListThreadAndMessagesOnPageResult struct {
 	rpc2.CommonResult
 
 	ThreadAndMessagesOnPage derived2.IThreadAndMessages `json:"tamop"`
+
+	// Aggregated reactions of messages on the page. Messages without
+	// reactions are not listed.
+	MessageReactions []models.MessageReactions `json:"messageReactions"`
+
+	// Files attached to messages on the page. Messages without attachments
+	// are not listed.
+	MessageAttachments []models.MessageAttachments `json:"messageAttachments"`
 }
 
 type ListForumAndThreadsParams struct {
@@ -382,6 +534,325 @@ type ListSectionsAndForumsResult struct {
 	SectionsAndForums *models.SectionsAndForums `json:"saf"`
 }
 
+// Search.
+
+type SearchMessagesParams struct {
+	rpc2.CommonParams
+
+	// Searched text.
+	Text base2.Text `json:"text"`
+
+	// Optional filters.
+	ForumId  *base2.Id  `json:"forumId"`
+	AuthorId *base2.Id  `json:"authorId"`
+	FromTime *time.Time `json:"fromTime"`
+	ToTime   *time.Time `json:"toTime"`
+
+	Page base2.Count `json:"page"`
+}
+type SearchMessagesResult struct {
+	rpc2.CommonResult
+
+	MessagesFound *models.MessagesFound `json:"mf"`
+}
+
+type SearchThreadsParams struct {
+	rpc2.CommonParams
+
+	// Searched text.
+	Text base2.Text `json:"text"`
+
+	// Optional filters.
+	ForumId  *base2.Id  `json:"forumId"`
+	AuthorId *base2.Id  `json:"authorId"`
+	FromTime *time.Time `json:"fromTime"`
+	ToTime   *time.Time `json:"toTime"`
+
+	Page base2.Count `json:"page"`
+}
+type SearchThreadsResult struct {
+	rpc2.CommonResult
+
+	ThreadsFound *models.ThreadsFound `json:"tf"`
+}
+
+// Private messages.
+
+type StartConversationParams struct {
+	rpc2.CommonParams
+
+	// Subject of the conversation.
+	Subject cm.Name `json:"subject"`
+
+	// Users invited into the conversation. Creator of the conversation is
+	// added automatically.
+	UserIds []base2.Id `json:"userIds"`
+
+	// Text of the first message.
+	Text base2.Text `json:"text"`
+}
+type StartConversationResult struct {
+	rpc2.CommonResult
+
+	// ID of the created conversation and its first message.
+	ConversationId base2.Id `json:"conversationId"`
+	MessageId      base2.Id `json:"messageId"`
+}
+
+type SendPrivateMessageParams struct {
+	rpc2.CommonParams
+
+	ConversationId base2.Id   `json:"conversationId"`
+	Text           base2.Text `json:"text"`
+}
+type SendPrivateMessageResult struct {
+	rpc2.CommonResult
+
+	// ID of the created message.
+	MessageId base2.Id `json:"messageId"`
+}
+
+type ListConversationsParams struct {
+	rpc2.CommonParams
+
+	Page base2.Count `json:"page"`
+}
+type ListConversationsResult struct {
+	rpc2.CommonResult
+
+	ConversationsOnPage *models.ConversationsOnPage `json:"cop"`
+}
+
+type ReadConversationParams struct {
+	rpc2.CommonParams
+
+	ConversationId base2.Id    `json:"conversationId"`
+	Page           base2.Count `json:"page"`
+}
+type ReadConversationResult struct {
+	rpc2.CommonResult
+
+	ConversationAndMessages *models.ConversationAndMessages `json:"cam"`
+}
+
+type DeleteConversationParams struct {
+	rpc2.CommonParams
+
+	ConversationId base2.Id `json:"conversationId"`
+}
+type DeleteConversationResult = rpc2.CommonResultWithSuccess
+
+type CountUnreadPrivateMessagesParams struct {
+	rpc2.CommonParams
+}
+type CountUnreadPrivateMessagesResult struct {
+	rpc2.CommonResult
+
+	// Number of unread private messages.
+	UPMC base2.Count `json:"upmc"`
+}
+
+type BlockUserParams struct {
+	rpc2.CommonParams
+
+	// User whose private messages are blocked.
+	UserId base2.Id `json:"userId"`
+}
+type BlockUserResult = rpc2.CommonResultWithSuccess
+
+type UnblockUserParams struct {
+	rpc2.CommonParams
+
+	UserId base2.Id `json:"userId"`
+}
+type UnblockUserResult = rpc2.CommonResultWithSuccess
+
+type ListBlockedUsersParams struct {
+	rpc2.CommonParams
+}
+type ListBlockedUsersResult struct {
+	rpc2.CommonResult
+
+	UserIds []base2.Id `json:"userIds"`
+}
+
+// Polls.
+
+type GetPollParams struct {
+	rpc2.CommonParams
+
+	ThreadId base2.Id `json:"threadId"`
+}
+type GetPollResult struct {
+	rpc2.CommonResult
+
+	Poll *models.Poll `json:"poll"`
+
+	// Results are not set while they are hidden.
+	Results *models.PollResults `json:"results"`
+
+	// Options chosen by the user. The list is empty when the user has not
+	// voted.
+	SelfOptionIds []base2.Id `json:"selfOptionIds"`
+}
+
+type VoteInPollParams struct {
+	rpc2.CommonParams
+
+	ThreadId  base2.Id   `json:"threadId"`
+	OptionIds []base2.Id `json:"optionIds"`
+}
+type VoteInPollResult = rpc2.CommonResultWithSuccess
+
+type ChangePollVoteParams struct {
+	rpc2.CommonParams
+
+	ThreadId  base2.Id   `json:"threadId"`
+	OptionIds []base2.Id `json:"optionIds"`
+}
+type ChangePollVoteResult = rpc2.CommonResultWithSuccess
+
+// Reactions.
+
+type ListReactionsParams struct {
+	rpc2.CommonParams
+}
+type ListReactionsResult struct {
+	rpc2.CommonResult
+
+	Reactions []models.Reaction `json:"reactions"`
+}
+
+type AddMessageReactionParams struct {
+	rpc2.CommonParams
+
+	MessageId base2.Id `json:"messageId"`
+	Emoji     string   `json:"emoji"`
+}
+type AddMessageReactionResult = rpc2.CommonResultWithSuccess
+
+type RemoveMessageReactionParams struct {
+	rpc2.CommonParams
+
+	MessageId base2.Id `json:"messageId"`
+	Emoji     string   `json:"emoji"`
+}
+type RemoveMessageReactionResult = rpc2.CommonResultWithSuccess
+
+type GetUserReputationParams struct {
+	rpc2.CommonParams
+
+	UserId base2.Id `json:"userId"`
+}
+type GetUserReputationResult struct {
+	rpc2.CommonResult
+
+	UserId     base2.Id    `json:"userId"`
+	Reputation base2.Count `json:"reputation"`
+}
+
+// Attachments.
+
+type GetAttachmentParams struct {
+	rpc2.CommonParams
+
+	AttachmentId base2.Id `json:"attachmentId"`
+
+	// IsThumbnail flag selects the thumbnail of an image instead of the
+	// image itself.
+	IsThumbnail base2.Flag `json:"isThumbnail"`
+}
+type GetAttachmentResult struct {
+	rpc2.CommonResult
+
+	Attachment *models.Attachment `json:"attachment"`
+
+	// Type and contents of the selected file.
+	ContentType string `json:"contentType"`
+	Data        []byte `json:"data"`
+}
+
+// Trash.
+
+type ListTrashParams struct {
+	rpc2.CommonParams
+
+	Page base2.Count `json:"page"`
+}
+type ListTrashResult struct {
+	rpc2.CommonResult
+
+	TrashOnPage *models.TrashOnPage `json:"top"`
+}
+
+type RestoreFromTrashParams struct {
+	rpc2.CommonParams
+
+	TrashItemId base2.Id `json:"trashItemId"`
+}
+type RestoreFromTrashResult = rpc2.CommonResultWithSuccess
+
+// Consistency.
+
+type RepairDatabaseConsistencyParams struct {
+	rpc2.CommonParams
+
+	// Apply flag makes changes in the database. Without this flag only a
+	// report is made.
+	Apply base2.Flag `json:"apply"`
+}
+type RepairDatabaseConsistencyResult struct {
+	rpc2.CommonResult
+
+	Report *models.ConsistencyReport `json:"report"`
+}
+
+// Moderation.
+
+type ReportMessageParams struct {
+	rpc2.CommonParams
+
+	MessageId base2.Id            `json:"messageId"`
+	Reason    models.ReportReason `json:"reason"`
+	Comment   base2.Text          `json:"comment"`
+}
+type ReportMessageResult = rpc2.CommonResultWithSuccess
+
+type ListModerationQueueParams struct {
+	rpc2.CommonParams
+
+	Page base2.Count `json:"page"`
+}
+type ListModerationQueueResult struct {
+	rpc2.CommonResult
+
+	ModerationQueueOnPage *models.ModerationQueueOnPage `json:"mqop"`
+}
+
+type ResolveMessageReportsParams struct {
+	rpc2.CommonParams
+
+	MessageId base2.Id                `json:"messageId"`
+	Action    models.ModerationAction `json:"action"`
+	Comment   base2.Text              `json:"comment"`
+}
+type ResolveMessageReportsResult struct {
+	rpc2.CommonResult
+
+	DecisionId base2.Id `json:"decisionId"`
+}
+
+type ListModerationDecisionsParams struct {
+	rpc2.CommonParams
+
+	Page base2.Count `json:"page"`
+}
+type ListModerationDecisionsResult struct {
+	rpc2.CommonResult
+
+	ModerationDecisionsOnPage *models.ModerationDecisionsOnPage `json:"mdop"`
+}
+
 // Other.
 
 type GetDKeyParams struct {
@@ -397,6 +868,7 @@ type ShowDiagnosticDataParams struct{}
 type ShowDiagnosticDataResult struct {
 	rpc2.CommonResult
 	rpc2.RequestsCount
+	rpc2.ScheduledTasks
 }
 
 type TestParams struct {
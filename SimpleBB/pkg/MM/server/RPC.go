@@ -32,17 +32,21 @@ func (srv *Server) initRpc() (err error) {
 		srv.AddSection,
 		srv.ChangeSectionName,
 		srv.ChangeSectionParent,
+		srv.ChangeSectionPrivacy,
 		srv.GetSection,
 		srv.MoveSectionUp,
 		srv.MoveSectionDown,
 		srv.DeleteSection,
+		srv.SectionExistsS,
 		srv.AddForum,
 		srv.ChangeForumName,
 		srv.ChangeForumSection,
+		srv.ChangeForumReadOnly,
 		srv.GetForum,
 		srv.MoveForumUp,
 		srv.MoveForumDown,
 		srv.DeleteForum,
+		srv.ForumExistsS,
 		srv.AddThread,
 		srv.ChangeThreadName,
 		srv.ChangeThreadForum,
@@ -51,18 +55,52 @@ func (srv *Server) initRpc() (err error) {
 		srv.MoveThreadUp,
 		srv.MoveThreadDown,
 		srv.DeleteThread,
+		srv.LockThread,
+		srv.UnlockThread,
+		srv.ChangeThreadPinned,
+		srv.ChangeThreadAnnouncement,
 		srv.ThreadExistsS,
+		srv.GetThreadLocationS,
 		srv.AddMessage,
 		srv.ChangeMessageText,
 		srv.ChangeMessageThread,
 		srv.GetMessage,
 		srv.GetLatestMessageOfThread,
 		srv.DeleteMessage,
+		srv.ListMessageRevisions,
+		srv.GetMessageRevision,
+		srv.RestoreMessageRevision,
 		srv.ListThreadAndMessages,
 		srv.ListThreadAndMessagesOnPage,
 		srv.ListForumAndThreads,
 		srv.ListForumAndThreadsOnPage,
 		srv.ListSectionsAndForums,
+		srv.SearchMessages,
+		srv.SearchThreads,
+		srv.StartConversation,
+		srv.SendPrivateMessage,
+		srv.ListConversations,
+		srv.ReadConversation,
+		srv.DeleteConversation,
+		srv.CountUnreadPrivateMessages,
+		srv.BlockUser,
+		srv.UnblockUser,
+		srv.ListBlockedUsers,
+		srv.GetPoll,
+		srv.VoteInPoll,
+		srv.ChangePollVote,
+		srv.ListReactions,
+		srv.AddMessageReaction,
+		srv.RemoveMessageReaction,
+		srv.GetUserReputation,
+		srv.GetAttachment,
+		srv.ListTrash,
+		srv.RestoreFromTrash,
+		srv.RepairDatabaseConsistency,
+		srv.ReportMessage,
+		srv.ListModerationQueue,
+		srv.ResolveMessageReports,
+		srv.ListModerationDecisions,
 		srv.GetDKey,
 		srv.ShowDiagnosticData,
 		srv.Test,
@@ -139,6 +177,22 @@ func (srv *Server) ChangeSectionParent(params *json.RawMessage, _ *jrm1.Response
 	return r, nil
 }
 
+func (srv *Server) ChangeSectionPrivacy(params *json.RawMessage, _ *jrm1.ResponseMetaData) (result any, re *jrm1.RpcError) {
+	var p *mm.ChangeSectionPrivacyParams
+	re = jrm1.ParseParameters(params, &p)
+	if re != nil {
+		return nil, re
+	}
+
+	var r *mm.ChangeSectionPrivacyResult
+	r, re = srv.changeSectionPrivacy(p)
+	if re != nil {
+		return nil, re
+	}
+
+	return r, nil
+}
+
 func (srv *Server) GetSection(params *json.RawMessage, _ *jrm1.ResponseMetaData) (result any, re *jrm1.RpcError) {
 	var p *mm.GetSectionParams
 	re = jrm1.ParseParameters(params, &p)
@@ -203,6 +257,22 @@ func (srv *Server) DeleteSection(params *json.RawMessage, _ *jrm1.ResponseMetaDa
 	return r, nil
 }
 
+func (srv *Server) SectionExistsS(params *json.RawMessage, _ *jrm1.ResponseMetaData) (result any, re *jrm1.RpcError) {
+	var p *mm.SectionExistsSParams
+	re = jrm1.ParseParameters(params, &p)
+	if re != nil {
+		return nil, re
+	}
+
+	var r *mm.SectionExistsSResult
+	r, re = srv.sectionExistsS(p)
+	if re != nil {
+		return nil, re
+	}
+
+	return r, nil
+}
+
 // Forum.
 
 func (srv *Server) AddForum(params *json.RawMessage, _ *jrm1.ResponseMetaData) (result any, re *jrm1.RpcError) {
@@ -253,6 +323,22 @@ func (srv *Server) ChangeForumSection(params *json.RawMessage, _ *jrm1.ResponseM
 	return r, nil
 }
 
+func (srv *Server) ChangeForumReadOnly(params *json.RawMessage, _ *jrm1.ResponseMetaData) (result any, re *jrm1.RpcError) {
+	var p *mm.ChangeForumReadOnlyParams
+	re = jrm1.ParseParameters(params, &p)
+	if re != nil {
+		return nil, re
+	}
+
+	var r *mm.ChangeForumReadOnlyResult
+	r, re = srv.changeForumReadOnly(p)
+	if re != nil {
+		return nil, re
+	}
+
+	return r, nil
+}
+
 func (srv *Server) GetForum(params *json.RawMessage, _ *jrm1.ResponseMetaData) (result any, re *jrm1.RpcError) {
 	var p *mm.GetForumParams
 	re = jrm1.ParseParameters(params, &p)
@@ -317,6 +403,22 @@ func (srv *Server) DeleteForum(params *json.RawMessage, _ *jrm1.ResponseMetaData
 	return r, nil
 }
 
+func (srv *Server) ForumExistsS(params *json.RawMessage, _ *jrm1.ResponseMetaData) (result any, re *jrm1.RpcError) {
+	var p *mm.ForumExistsSParams
+	re = jrm1.ParseParameters(params, &p)
+	if re != nil {
+		return nil, re
+	}
+
+	var r *mm.ForumExistsSResult
+	r, re = srv.forumExistsS(p)
+	if re != nil {
+		return nil, re
+	}
+
+	return r, nil
+}
+
 // Thread.
 
 func (srv *Server) AddThread(params *json.RawMessage, _ *jrm1.ResponseMetaData) (result any, re *jrm1.RpcError) {
@@ -447,6 +549,70 @@ func (srv *Server) DeleteThread(params *json.RawMessage, _ *jrm1.ResponseMetaDat
 	return r, nil
 }
 
+func (srv *Server) LockThread(params *json.RawMessage, _ *jrm1.ResponseMetaData) (result any, re *jrm1.RpcError) {
+	var p *mm.LockThreadParams
+	re = jrm1.ParseParameters(params, &p)
+	if re != nil {
+		return nil, re
+	}
+
+	var r *mm.LockThreadResult
+	r, re = srv.lockThread(p)
+	if re != nil {
+		return nil, re
+	}
+
+	return r, nil
+}
+
+func (srv *Server) UnlockThread(params *json.RawMessage, _ *jrm1.ResponseMetaData) (result any, re *jrm1.RpcError) {
+	var p *mm.UnlockThreadParams
+	re = jrm1.ParseParameters(params, &p)
+	if re != nil {
+		return nil, re
+	}
+
+	var r *mm.UnlockThreadResult
+	r, re = srv.unlockThread(p)
+	if re != nil {
+		return nil, re
+	}
+
+	return r, nil
+}
+
+func (srv *Server) ChangeThreadPinned(params *json.RawMessage, _ *jrm1.ResponseMetaData) (result any, re *jrm1.RpcError) {
+	var p *mm.ChangeThreadPinnedParams
+	re = jrm1.ParseParameters(params, &p)
+	if re != nil {
+		return nil, re
+	}
+
+	var r *mm.ChangeThreadPinnedResult
+	r, re = srv.changeThreadPinned(p)
+	if re != nil {
+		return nil, re
+	}
+
+	return r, nil
+}
+
+func (srv *Server) ChangeThreadAnnouncement(params *json.RawMessage, _ *jrm1.ResponseMetaData) (result any, re *jrm1.RpcError) {
+	var p *mm.ChangeThreadAnnouncementParams
+	re = jrm1.ParseParameters(params, &p)
+	if re != nil {
+		return nil, re
+	}
+
+	var r *mm.ChangeThreadAnnouncementResult
+	r, re = srv.changeThreadAnnouncement(p)
+	if re != nil {
+		return nil, re
+	}
+
+	return r, nil
+}
+
 func (srv *Server) ThreadExistsS(params *json.RawMessage, _ *jrm1.ResponseMetaData) (result any, re *jrm1.RpcError) {
 	var p *mm.ThreadExistsSParams
 	re = jrm1.ParseParameters(params, &p)
@@ -463,6 +629,22 @@ func (srv *Server) ThreadExistsS(params *json.RawMessage, _ *jrm1.ResponseMetaDa
 	return r, nil
 }
 
+func (srv *Server) GetThreadLocationS(params *json.RawMessage, _ *jrm1.ResponseMetaData) (result any, re *jrm1.RpcError) {
+	var p *mm.GetThreadLocationSParams
+	re = jrm1.ParseParameters(params, &p)
+	if re != nil {
+		return nil, re
+	}
+
+	var r *mm.GetThreadLocationSResult
+	r, re = srv.getThreadLocationS(p)
+	if re != nil {
+		return nil, re
+	}
+
+	return r, nil
+}
+
 // Message.
 
 func (srv *Server) AddMessage(params *json.RawMessage, _ *jrm1.ResponseMetaData) (result any, re *jrm1.RpcError) {
@@ -561,6 +743,54 @@ func (srv *Server) DeleteMessage(params *json.RawMessage, _ *jrm1.ResponseMetaDa
 	return r, nil
 }
 
+func (srv *Server) ListMessageRevisions(params *json.RawMessage, _ *jrm1.ResponseMetaData) (result any, re *jrm1.RpcError) {
+	var p *mm.ListMessageRevisionsParams
+	re = jrm1.ParseParameters(params, &p)
+	if re != nil {
+		return nil, re
+	}
+
+	var r *mm.ListMessageRevisionsResult
+	r, re = srv.listMessageRevisions(p)
+	if re != nil {
+		return nil, re
+	}
+
+	return r, nil
+}
+
+func (srv *Server) GetMessageRevision(params *json.RawMessage, _ *jrm1.ResponseMetaData) (result any, re *jrm1.RpcError) {
+	var p *mm.GetMessageRevisionParams
+	re = jrm1.ParseParameters(params, &p)
+	if re != nil {
+		return nil, re
+	}
+
+	var r *mm.GetMessageRevisionResult
+	r, re = srv.getMessageRevision(p)
+	if re != nil {
+		return nil, re
+	}
+
+	return r, nil
+}
+
+func (srv *Server) RestoreMessageRevision(params *json.RawMessage, _ *jrm1.ResponseMetaData) (result any, re *jrm1.RpcError) {
+	var p *mm.RestoreMessageRevisionParams
+	re = jrm1.ParseParameters(params, &p)
+	if re != nil {
+		return nil, re
+	}
+
+	var r *mm.RestoreMessageRevisionResult
+	r, re = srv.restoreMessageRevision(p)
+	if re != nil {
+		return nil, re
+	}
+
+	return r, nil
+}
+
 // Composite objects.
 
 func (srv *Server) ListThreadAndMessages(params *json.RawMessage, _ *jrm1.ResponseMetaData) (result any, re *jrm1.RpcError) {
@@ -643,6 +873,438 @@ func (srv *Server) ListSectionsAndForums(params *json.RawMessage, _ *jrm1.Respon
 	return r, nil
 }
 
+// Search.
+
+func (srv *Server) SearchMessages(params *json.RawMessage, _ *jrm1.ResponseMetaData) (result any, re *jrm1.RpcError) {
+	var p *mm.SearchMessagesParams
+	re = jrm1.ParseParameters(params, &p)
+	if re != nil {
+		return nil, re
+	}
+
+	var r *mm.SearchMessagesResult
+	r, re = srv.searchMessages(p)
+	if re != nil {
+		return nil, re
+	}
+
+	return r, nil
+}
+
+func (srv *Server) SearchThreads(params *json.RawMessage, _ *jrm1.ResponseMetaData) (result any, re *jrm1.RpcError) {
+	var p *mm.SearchThreadsParams
+	re = jrm1.ParseParameters(params, &p)
+	if re != nil {
+		return nil, re
+	}
+
+	var r *mm.SearchThreadsResult
+	r, re = srv.searchThreads(p)
+	if re != nil {
+		return nil, re
+	}
+
+	return r, nil
+}
+
+// Private messages.
+
+func (srv *Server) StartConversation(params *json.RawMessage, _ *jrm1.ResponseMetaData) (result any, re *jrm1.RpcError) {
+	var p *mm.StartConversationParams
+	re = jrm1.ParseParameters(params, &p)
+	if re != nil {
+		return nil, re
+	}
+
+	var r *mm.StartConversationResult
+	r, re = srv.startConversation(p)
+	if re != nil {
+		return nil, re
+	}
+
+	return r, nil
+}
+
+func (srv *Server) SendPrivateMessage(params *json.RawMessage, _ *jrm1.ResponseMetaData) (result any, re *jrm1.RpcError) {
+	var p *mm.SendPrivateMessageParams
+	re = jrm1.ParseParameters(params, &p)
+	if re != nil {
+		return nil, re
+	}
+
+	var r *mm.SendPrivateMessageResult
+	r, re = srv.sendPrivateMessage(p)
+	if re != nil {
+		return nil, re
+	}
+
+	return r, nil
+}
+
+func (srv *Server) ListConversations(params *json.RawMessage, _ *jrm1.ResponseMetaData) (result any, re *jrm1.RpcError) {
+	var p *mm.ListConversationsParams
+	re = jrm1.ParseParameters(params, &p)
+	if re != nil {
+		return nil, re
+	}
+
+	var r *mm.ListConversationsResult
+	r, re = srv.listConversations(p)
+	if re != nil {
+		return nil, re
+	}
+
+	return r, nil
+}
+
+func (srv *Server) ReadConversation(params *json.RawMessage, _ *jrm1.ResponseMetaData) (result any, re *jrm1.RpcError) {
+	var p *mm.ReadConversationParams
+	re = jrm1.ParseParameters(params, &p)
+	if re != nil {
+		return nil, re
+	}
+
+	var r *mm.ReadConversationResult
+	r, re = srv.readConversation(p)
+	if re != nil {
+		return nil, re
+	}
+
+	return r, nil
+}
+
+func (srv *Server) DeleteConversation(params *json.RawMessage, _ *jrm1.ResponseMetaData) (result any, re *jrm1.RpcError) {
+	var p *mm.DeleteConversationParams
+	re = jrm1.ParseParameters(params, &p)
+	if re != nil {
+		return nil, re
+	}
+
+	var r *mm.DeleteConversationResult
+	r, re = srv.deleteConversation(p)
+	if re != nil {
+		return nil, re
+	}
+
+	return r, nil
+}
+
+func (srv *Server) CountUnreadPrivateMessages(params *json.RawMessage, _ *jrm1.ResponseMetaData) (result any, re *jrm1.RpcError) {
+	var p *mm.CountUnreadPrivateMessagesParams
+	re = jrm1.ParseParameters(params, &p)
+	if re != nil {
+		return nil, re
+	}
+
+	var r *mm.CountUnreadPrivateMessagesResult
+	r, re = srv.countUnreadPrivateMessages(p)
+	if re != nil {
+		return nil, re
+	}
+
+	return r, nil
+}
+
+func (srv *Server) BlockUser(params *json.RawMessage, _ *jrm1.ResponseMetaData) (result any, re *jrm1.RpcError) {
+	var p *mm.BlockUserParams
+	re = jrm1.ParseParameters(params, &p)
+	if re != nil {
+		return nil, re
+	}
+
+	var r *mm.BlockUserResult
+	r, re = srv.blockUser(p)
+	if re != nil {
+		return nil, re
+	}
+
+	return r, nil
+}
+
+func (srv *Server) UnblockUser(params *json.RawMessage, _ *jrm1.ResponseMetaData) (result any, re *jrm1.RpcError) {
+	var p *mm.UnblockUserParams
+	re = jrm1.ParseParameters(params, &p)
+	if re != nil {
+		return nil, re
+	}
+
+	var r *mm.UnblockUserResult
+	r, re = srv.unblockUser(p)
+	if re != nil {
+		return nil, re
+	}
+
+	return r, nil
+}
+
+func (srv *Server) ListBlockedUsers(params *json.RawMessage, _ *jrm1.ResponseMetaData) (result any, re *jrm1.RpcError) {
+	var p *mm.ListBlockedUsersParams
+	re = jrm1.ParseParameters(params, &p)
+	if re != nil {
+		return nil, re
+	}
+
+	var r *mm.ListBlockedUsersResult
+	r, re = srv.listBlockedUsers(p)
+	if re != nil {
+		return nil, re
+	}
+
+	return r, nil
+}
+
+// Polls.
+
+func (srv *Server) GetPoll(params *json.RawMessage, _ *jrm1.ResponseMetaData) (result any, re *jrm1.RpcError) {
+	var p *mm.GetPollParams
+	re = jrm1.ParseParameters(params, &p)
+	if re != nil {
+		return nil, re
+	}
+
+	var r *mm.GetPollResult
+	r, re = srv.getPoll(p)
+	if re != nil {
+		return nil, re
+	}
+
+	return r, nil
+}
+
+func (srv *Server) VoteInPoll(params *json.RawMessage, _ *jrm1.ResponseMetaData) (result any, re *jrm1.RpcError) {
+	var p *mm.VoteInPollParams
+	re = jrm1.ParseParameters(params, &p)
+	if re != nil {
+		return nil, re
+	}
+
+	var r *mm.VoteInPollResult
+	r, re = srv.voteInPoll(p)
+	if re != nil {
+		return nil, re
+	}
+
+	return r, nil
+}
+
+func (srv *Server) ChangePollVote(params *json.RawMessage, _ *jrm1.ResponseMetaData) (result any, re *jrm1.RpcError) {
+	var p *mm.ChangePollVoteParams
+	re = jrm1.ParseParameters(params, &p)
+	if re != nil {
+		return nil, re
+	}
+
+	var r *mm.ChangePollVoteResult
+	r, re = srv.changePollVote(p)
+	if re != nil {
+		return nil, re
+	}
+
+	return r, nil
+}
+
+// Reactions.
+
+func (srv *Server) ListReactions(params *json.RawMessage, _ *jrm1.ResponseMetaData) (result any, re *jrm1.RpcError) {
+	var p *mm.ListReactionsParams
+	re = jrm1.ParseParameters(params, &p)
+	if re != nil {
+		return nil, re
+	}
+
+	var r *mm.ListReactionsResult
+	r, re = srv.listReactions(p)
+	if re != nil {
+		return nil, re
+	}
+
+	return r, nil
+}
+
+func (srv *Server) AddMessageReaction(params *json.RawMessage, _ *jrm1.ResponseMetaData) (result any, re *jrm1.RpcError) {
+	var p *mm.AddMessageReactionParams
+	re = jrm1.ParseParameters(params, &p)
+	if re != nil {
+		return nil, re
+	}
+
+	var r *mm.AddMessageReactionResult
+	r, re = srv.addMessageReaction(p)
+	if re != nil {
+		return nil, re
+	}
+
+	return r, nil
+}
+
+func (srv *Server) RemoveMessageReaction(params *json.RawMessage, _ *jrm1.ResponseMetaData) (result any, re *jrm1.RpcError) {
+	var p *mm.RemoveMessageReactionParams
+	re = jrm1.ParseParameters(params, &p)
+	if re != nil {
+		return nil, re
+	}
+
+	var r *mm.RemoveMessageReactionResult
+	r, re = srv.removeMessageReaction(p)
+	if re != nil {
+		return nil, re
+	}
+
+	return r, nil
+}
+
+func (srv *Server) GetUserReputation(params *json.RawMessage, _ *jrm1.ResponseMetaData) (result any, re *jrm1.RpcError) {
+	var p *mm.GetUserReputationParams
+	re = jrm1.ParseParameters(params, &p)
+	if re != nil {
+		return nil, re
+	}
+
+	var r *mm.GetUserReputationResult
+	r, re = srv.getUserReputation(p)
+	if re != nil {
+		return nil, re
+	}
+
+	return r, nil
+}
+
+// Attachments.
+
+func (srv *Server) GetAttachment(params *json.RawMessage, _ *jrm1.ResponseMetaData) (result any, re *jrm1.RpcError) {
+	var p *mm.GetAttachmentParams
+	re = jrm1.ParseParameters(params, &p)
+	if re != nil {
+		return nil, re
+	}
+
+	var r *mm.GetAttachmentResult
+	r, re = srv.getAttachment(p)
+	if re != nil {
+		return nil, re
+	}
+
+	return r, nil
+}
+
+// Trash.
+
+func (srv *Server) ListTrash(params *json.RawMessage, _ *jrm1.ResponseMetaData) (result any, re *jrm1.RpcError) {
+	var p *mm.ListTrashParams
+	re = jrm1.ParseParameters(params, &p)
+	if re != nil {
+		return nil, re
+	}
+
+	var r *mm.ListTrashResult
+	r, re = srv.listTrash(p)
+	if re != nil {
+		return nil, re
+	}
+
+	return r, nil
+}
+
+func (srv *Server) RestoreFromTrash(params *json.RawMessage, _ *jrm1.ResponseMetaData) (result any, re *jrm1.RpcError) {
+	var p *mm.RestoreFromTrashParams
+	re = jrm1.ParseParameters(params, &p)
+	if re != nil {
+		return nil, re
+	}
+
+	var r *mm.RestoreFromTrashResult
+	r, re = srv.restoreFromTrash(p)
+	if re != nil {
+		return nil, re
+	}
+
+	return r, nil
+}
+
+// Consistency.
+
+func (srv *Server) RepairDatabaseConsistency(params *json.RawMessage, _ *jrm1.ResponseMetaData) (result any, re *jrm1.RpcError) {
+	var p *mm.RepairDatabaseConsistencyParams
+	re = jrm1.ParseParameters(params, &p)
+	if re != nil {
+		return nil, re
+	}
+
+	var r *mm.RepairDatabaseConsistencyResult
+	r, re = srv.repairDatabaseConsistency(p)
+	if re != nil {
+		return nil, re
+	}
+
+	return r, nil
+}
+
+// Moderation.
+
+func (srv *Server) ReportMessage(params *json.RawMessage, _ *jrm1.ResponseMetaData) (result any, re *jrm1.RpcError) {
+	var p *mm.ReportMessageParams
+	re = jrm1.ParseParameters(params, &p)
+	if re != nil {
+		return nil, re
+	}
+
+	var r *mm.ReportMessageResult
+	r, re = srv.reportMessage(p)
+	if re != nil {
+		return nil, re
+	}
+
+	return r, nil
+}
+
+func (srv *Server) ListModerationQueue(params *json.RawMessage, _ *jrm1.ResponseMetaData) (result any, re *jrm1.RpcError) {
+	var p *mm.ListModerationQueueParams
+	re = jrm1.ParseParameters(params, &p)
+	if re != nil {
+		return nil, re
+	}
+
+	var r *mm.ListModerationQueueResult
+	r, re = srv.listModerationQueue(p)
+	if re != nil {
+		return nil, re
+	}
+
+	return r, nil
+}
+
+func (srv *Server) ResolveMessageReports(params *json.RawMessage, _ *jrm1.ResponseMetaData) (result any, re *jrm1.RpcError) {
+	var p *mm.ResolveMessageReportsParams
+	re = jrm1.ParseParameters(params, &p)
+	if re != nil {
+		return nil, re
+	}
+
+	var r *mm.ResolveMessageReportsResult
+	r, re = srv.resolveMessageReports(p)
+	if re != nil {
+		return nil, re
+	}
+
+	return r, nil
+}
+
+func (srv *Server) ListModerationDecisions(params *json.RawMessage, _ *jrm1.ResponseMetaData) (result any, re *jrm1.RpcError) {
+	var p *mm.ListModerationDecisionsParams
+	re = jrm1.ParseParameters(params, &p)
+	if re != nil {
+		return nil, re
+	}
+
+	var r *mm.ListModerationDecisionsResult
+	r, re = srv.listModerationDecisions(p)
+	if re != nil {
+		return nil, re
+	}
+
+	return r, nil
+}
+
 // Other.
 
 func (srv *Server) GetDKey(params *json.RawMessage, _ *jrm1.ResponseMetaData) (result any, re *jrm1.RpcError) {
@@ -8,23 +8,38 @@ import (
 	rpc2 "github.com/vault-thirteen/SimpleBB/pkg/MM/rpc"
 	nm "github.com/vault-thirteen/SimpleBB/pkg/NM/rpc"
 	"github.com/vault-thirteen/SimpleBB/pkg/common/interfaces/derived2"
+	"github.com/vault-thirteen/SimpleBB/pkg/common/models/AuditLog"
+	"github.com/vault-thirteen/SimpleBB/pkg/common/models/Permission"
 	"github.com/vault-thirteen/SimpleBB/pkg/common/models/UidList"
 	base2 "github.com/vault-thirteen/SimpleBB/pkg/common/models/base"
+	ev "github.com/vault-thirteen/SimpleBB/pkg/common/models/complex/EnumValue"
+	"github.com/vault-thirteen/SimpleBB/pkg/common/models/complex/SectionChildType"
+	cm "github.com/vault-thirteen/SimpleBB/pkg/common/models/complex/SystemEvent"
+	"github.com/vault-thirteen/SimpleBB/pkg/common/models/complex/SystemEventData"
+	set "github.com/vault-thirteen/SimpleBB/pkg/common/models/complex/SystemEventType"
 	cn "github.com/vault-thirteen/SimpleBB/pkg/common/models/net"
 	rpc3 "github.com/vault-thirteen/SimpleBB/pkg/common/models/rpc"
 	server2 "github.com/vault-thirteen/SimpleBB/pkg/common/models/server"
 	"log"
+	"slices"
 	"sync"
 	"time"
 
 	jrm1 "github.com/vault-thirteen/JSON-RPC-M1"
 	ac "github.com/vault-thirteen/SimpleBB/pkg/ACM/client"
+	"github.com/vault-thirteen/SimpleBB/pkg/MM/blob"
+	"github.com/vault-thirteen/SimpleBB/pkg/MM/markup"
+	mm "github.com/vault-thirteen/SimpleBB/pkg/MM/models"
 	nc "github.com/vault-thirteen/SimpleBB/pkg/NM/client"
 	ah "github.com/vault-thirteen/auxie/hash"
 )
 
 // Auxiliary functions used in RPC functions.
 
+const (
+	NotificationF_NewPrivateMessage = "New private message from user %v in conversation %v."
+)
+
 // logError logs error if debug mode is enabled.
 func (srv *Server) logError(err error) {
 	if err == nil {
@@ -149,6 +164,66 @@ func (srv *Server) doTestA(wg *sync.WaitGroup, errChan chan error) {
 	}
 }
 
+// getDKeyForACM receives a DKey from Access Control module.
+func (srv *Server) getDKeyForACM() (dKey *base2.Text, re *jrm1.RpcError) {
+	params := am.GetDKeyParams{}
+	result := new(am.GetDKeyResult)
+	var err error
+	re, err = srv.acmServiceClient.MakeRequest(context.Background(), ac.FuncGetDKey, params, result)
+	if err != nil {
+		srv.logError(err)
+		return nil, jrm1.NewRpcErrorByUser(server2.RpcErrorCode_RPCCall, server2.RpcErrorMsg_RPCCall, nil)
+	}
+	if re != nil {
+		return nil, re
+	}
+
+	// DKey must be non-empty.
+	if len(result.DKey) == 0 {
+		return nil, jrm1.NewRpcErrorByUser(server2.RpcErrorCode_ModuleSynchronisation, server2.RpcErrorMsg_ModuleSynchronisation, nil)
+	}
+
+	return &result.DKey, nil
+}
+
+// audit saves a record of a privileged action in the audit log of Access
+// Control module. The action has already been made, so a record which can not
+// be saved does not fail the request and the error is only logged. The method
+// makes a remote call and must not be used while the database is locked.
+// Functions which lock the database fill a record while they hold the lock
+// and pass it to this method in a deferred call, which runs after the
+// deferred unlocking of the database. A nil record is not audited, so failed
+// actions leave no record.
+func (srv *Server) audit(auth *rpc3.Auth, r *al.Record) {
+	if r == nil {
+		return
+	}
+
+	params := am.AddAuditRecordSParams{
+		DKeyParams: rpc3.DKeyParams{
+			// DKey is set during module start-up, so it is non-null.
+			DKey: *srv.dKeyForACM,
+		},
+		ActorUserId: r.ActorUserId,
+		ActorIPA:    auth.UserIPA,
+		Action:      r.Action,
+		TargetType:  r.TargetType,
+		TargetId:    r.TargetId,
+		ValueBefore: r.ValueBefore,
+		ValueAfter:  r.ValueAfter,
+	}
+	result := new(am.AddAuditRecordSResult)
+	re, err := srv.acmServiceClient.MakeRequest(context.Background(), ac.FuncAddAuditRecordS, params, result)
+	if err != nil {
+		srv.logError(err)
+		return
+	}
+	if re != nil {
+		srv.logError(re.AsError())
+		return
+	}
+}
+
 // getDKeyForNM receives a DKey from Notification module.
 func (srv *Server) getDKeyForNM() (dKey *base2.Text, re *jrm1.RpcError) {
 	params := nm.GetDKeyParams{}
@@ -205,20 +280,21 @@ func (srv *Server) checkMessageTextChecksum(msgText base2.Text, checksum []byte)
 }
 
 // canUserEditMessage checks whether a user (specified by the 'userRoles'
-// argument) can edit a message (specified as an 'message' argument).
-func (srv *Server) canUserEditMessage(userRoles *am.GetSelfRolesResult, message derived2.IMessage) (ok bool) {
+// argument) can edit a message (specified as an 'message' argument). Scope of
+// permissions of the message is specified as a 'scope' argument.
+func (srv *Server) canUserEditMessage(userRoles *am.GetSelfRolesResult, message derived2.IMessage, scope *perm.Scope) (ok bool) {
 	// Are you stupid, kido ?
 	if (userRoles == nil) || (message == nil) {
 		return false
 	}
 
 	// Moderators have extended rights to edit messages of any users.
-	if userRoles.User.GetUserParameters().GetRoles().IsModerator {
+	if userRoles.User.GetUserParameters().GetRoles().IsGranted(perm.Permission_Moderate, scope) {
 		return true
 	}
 
 	// Writers can edit their own messages.
-	if !userRoles.User.GetUserParameters().GetRoles().IsWriter {
+	if !userRoles.User.GetUserParameters().GetRoles().IsGranted(perm.Permission_Write, scope) {
 		return false
 	}
 
@@ -239,15 +315,16 @@ func (srv *Server) canUserEditMessage(userRoles *am.GetSelfRolesResult, message
 // argument) can add a new message into a thread in case when there is a
 // [latest] message in the thread (specified as an 'latestMessageInThread'
 // argument). If the thread is empty, i.e. no latest message is available, it
-// must be set as null.
-func (srv *Server) canUserAddMessage(userRoles *am.GetSelfRolesResult, latestMessageInThread derived2.IMessage) (ok bool) {
+// must be set as null. Scope of permissions of the thread is specified as a
+// 'scope' argument.
+func (srv *Server) canUserAddMessage(userRoles *am.GetSelfRolesResult, latestMessageInThread derived2.IMessage, scope *perm.Scope) (ok bool) {
 	// Are you stupid, kido ?
 	if userRoles == nil {
 		return false
 	}
 
 	// Only writers can add new messages.
-	if !userRoles.User.GetUserParameters().GetRoles().IsWriter {
+	if !userRoles.User.GetUserParameters().GetRoles().IsGranted(perm.Permission_Write, scope) {
 		return false
 	}
 
@@ -272,6 +349,237 @@ func (srv *Server) canUserAddMessage(userRoles *am.GetSelfRolesResult, latestMes
 	return true
 }
 
+// mustHavePermission checks whether a user has a permission in a scope.
+func (srv *Server) mustHavePermission(userRoles *am.GetSelfRolesResult, p perm.Permission, scope *perm.Scope) (re *jrm1.RpcError) {
+	if !userRoles.User.GetUserParameters().GetRoles().IsGranted(p, scope) {
+		return jrm1.NewRpcErrorByUser(server2.RpcErrorCode_Permission, server2.RpcErrorMsg_Permission, nil)
+	}
+
+	return nil
+}
+
+// getSectionScopeH is a helper function used by other functions to get a
+// scope of permissions of a section.
+func (srv *Server) getSectionScopeH(sectionId base2.Id) (scope *perm.Scope, re *jrm1.RpcError) {
+	scope, err := makeSectionScope(sectionId, srv.dbo.GetSectionById)
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+
+	if len(scope.Sections) == 0 {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_SectionIsNotFound, RpcErrorMsg_SectionIsNotFound, nil)
+	}
+
+	return scope, nil
+}
+
+// getForumScopeH is a helper function used by other functions to get a scope
+// of permissions of a forum.
+func (srv *Server) getForumScopeH(forumId base2.Id) (scope *perm.Scope, re *jrm1.RpcError) {
+	forum, err := srv.dbo.GetForumById(forumId)
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+
+	if forum == nil {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_ForumIsNotFound, RpcErrorMsg_ForumIsNotFound, nil)
+	}
+
+	scope, re = srv.getSectionScopeH(forum.GetSectionId())
+	if re != nil {
+		return nil, re
+	}
+
+	addForumToScope(scope, forum)
+	return scope, nil
+}
+
+// getThreadScopeH is a helper function used by other functions to get a scope
+// of permissions of a thread. Threads have no own permissions, so this is the
+// scope of the thread's forum.
+func (srv *Server) getThreadScopeH(threadId base2.Id) (scope *perm.Scope, re *jrm1.RpcError) {
+	thread, err := srv.dbo.GetThreadById(threadId)
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+
+	if thread == nil {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_ThreadIsNotFound, RpcErrorMsg_ThreadIsNotFound, nil)
+	}
+
+	return srv.getForumScopeH(thread.GetForumId())
+}
+
+// getMessageScopeH is a helper function used by other functions to get a
+// scope of permissions of a message.
+func (srv *Server) getMessageScopeH(messageId base2.Id) (scope *perm.Scope, re *jrm1.RpcError) {
+	message, err := srv.dbo.GetMessageById(messageId)
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+
+	if message == nil {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_MessageIsNotFound, RpcErrorMsg_MessageIsNotFound, nil)
+	}
+
+	return srv.getThreadScopeH(message.GetThreadId())
+}
+
+// makeSectionScope makes a scope of permissions of a section walking from the
+// section up to the root section. Sections are read by the 'getSection'
+// function which returns null when a section is not found.
+func makeSectionScope(sectionId base2.Id, getSection func(sectionId base2.Id) (derived2.ISection, error)) (scope *perm.Scope, err error) {
+	scope = &perm.Scope{
+		Sections: []perm.ScopeSection{},
+	}
+
+	var section derived2.ISection
+	var isVisited = map[base2.Id]bool{}
+	for id := &sectionId; (id != nil) && !isVisited[*id]; id = section.GetParent() {
+		isVisited[*id] = true
+
+		section, err = getSection(*id)
+		if err != nil {
+			return nil, err
+		}
+
+		if section == nil {
+			break
+		}
+
+		scope.Sections = append(scope.Sections, perm.ScopeSection{
+			Id:        section.GetId(),
+			IsPrivate: section.GetIsPrivate(),
+		})
+	}
+
+	return scope, nil
+}
+
+// addForumToScope turns a scope of a section into a scope of its forum.
+func addForumToScope(scope *perm.Scope, forum derived2.IForum) {
+	scope.ForumId = forum.GetIdPtr()
+	scope.IsForumReadOnly = forum.GetIsReadOnly()
+}
+
+// filterSectionsAndForums removes sections and forums which can not be read
+// by a user. A section containing readable items stays visible. Lists of
+// children of visible sections are cut to visible items.
+func filterSectionsAndForums(userRoles *am.GetSelfRolesResult, sections []derived2.ISection, forums []derived2.IForum) (visibleSections []derived2.ISection, visibleForums []derived2.IForum, err error) {
+	var sectionsById = make(map[base2.Id]derived2.ISection, len(sections))
+	for _, section := range sections {
+		sectionsById[section.GetId()] = section
+	}
+
+	getSection := func(sectionId base2.Id) (derived2.ISection, error) {
+		return sectionsById[sectionId], nil
+	}
+
+	var isSectionVisible = map[base2.Id]bool{}
+	var isForumVisible = map[base2.Id]bool{}
+	var roles = userRoles.User.GetUserParameters().GetRoles()
+	var scope *perm.Scope
+
+	for _, section := range sections {
+		scope, err = makeSectionScope(section.GetId(), getSection)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if roles.IsGranted(perm.Permission_Read, scope) {
+			for _, ss := range scope.Sections {
+				isSectionVisible[ss.Id] = true
+			}
+		}
+	}
+
+	for _, forum := range forums {
+		scope, err = makeSectionScope(forum.GetSectionId(), getSection)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		addForumToScope(scope, forum)
+
+		if roles.IsGranted(perm.Permission_Read, scope) {
+			isForumVisible[forum.GetId()] = true
+			for _, ss := range scope.Sections {
+				isSectionVisible[ss.Id] = true
+			}
+		}
+	}
+
+	visibleSections = make([]derived2.ISection, 0, len(isSectionVisible))
+	var children *ul.UidList
+	for _, section := range sections {
+		if !isSectionVisible[section.GetId()] {
+			continue
+		}
+
+		if section.GetChildren() == nil {
+			visibleSections = append(visibleSections, section)
+			continue
+		}
+
+		var isChildVisible = isSectionVisible
+		if section.GetChildType().AsInt() == sct.SectionChildType_Forum {
+			isChildVisible = isForumVisible
+		}
+
+		var visibleChildren = []base2.Id{}
+		for _, childId := range section.GetChildren().AsArray() {
+			if isChildVisible[childId] {
+				visibleChildren = append(visibleChildren, childId)
+			}
+		}
+
+		children, err = ul.NewFromArray(visibleChildren)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		section.SetChildren(children)
+		visibleSections = append(visibleSections, section)
+	}
+
+	visibleForums = make([]derived2.IForum, 0, len(isForumVisible))
+	for _, forum := range forums {
+		if isForumVisible[forum.GetId()] {
+			visibleForums = append(visibleForums, forum)
+		}
+	}
+
+	return visibleSections, visibleForums, nil
+}
+
+// getReadableForumIdsH is a helper function used by other functions to get
+// IDs of forums which can be read by a user.
+func (srv *Server) getReadableForumIdsH(userRoles *am.GetSelfRolesResult) (forumIds []base2.Id, re *jrm1.RpcError) {
+	sections, err := srv.dbo.ReadSections()
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+
+	var forums []derived2.IForum
+	forums, err = srv.dbo.ReadForums()
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+
+	_, forums, err = filterSectionsAndForums(userRoles, sections, forums)
+	if err != nil {
+		srv.logError(err)
+		return nil, jrm1.NewRpcErrorByUser(server2.RpcErrorCode_UidList, fmt.Sprintf(server2.RpcErrorMsgF_UidList, err.Error()), nil)
+	}
+
+	forumIds = make([]base2.Id, 0, len(forums))
+	for _, forum := range forums {
+		forumIds = append(forumIds, forum.GetId())
+	}
+
+	return forumIds, nil
+}
+
 // getLatestMessageOfThreadH is a helper function used by other functions to
 // get the latest message of a thread.
 func (srv *Server) getLatestMessageOfThreadH(threadId base2.Id) (message derived2.IMessage, re *jrm1.RpcError) {
@@ -315,9 +623,9 @@ func (srv *Server) getMessageMaxEditTime(message derived2.IMessage) time.Time {
 	return message.GetLastTouchTime().Add(time.Second * time.Duration(srv.settings.SystemSettings.MessageEditTime))
 }
 
-// deleteThreadH is a helper function used by other functions to delete a
-// thread.
-func (srv *Server) deleteThreadH(threadId base2.Id) (re *jrm1.RpcError) {
+// deleteThreadH is a helper function used by other functions to move a thread
+// to the trash.
+func (srv *Server) deleteThreadH(threadId base2.Id, userId base2.Id) (re *jrm1.RpcError) {
 	srv.dbo.LockForWriting()
 	defer srv.dbo.UnlockAfterWriting()
 
@@ -345,6 +653,14 @@ func (srv *Server) deleteThreadH(threadId base2.Id) (re *jrm1.RpcError) {
 		return srv.databaseError(err)
 	}
 
+	// Remember the place of the thread.
+	var ti *mm.TrashItem
+	ti, err = mm.NewTrashItem(mm.TrashObjectType_Thread, threadId, thread.GetNamePtr().ToString(), thread.GetForumIdPtr(), linkThreads, userId)
+	if err != nil {
+		srv.logError(err)
+		return jrm1.NewRpcErrorByUser(server2.RpcErrorCode_UidList, fmt.Sprintf(server2.RpcErrorMsgF_UidList, err.Error()), nil)
+	}
+
 	err = linkThreads.RemoveItem(threadId)
 	if err != nil {
 		srv.logError(err)
@@ -356,8 +672,14 @@ func (srv *Server) deleteThreadH(threadId base2.Id) (re *jrm1.RpcError) {
 		return srv.databaseError(err)
 	}
 
-	// Delete the thread.
-	err = srv.dbo.DeleteThreadById(threadId)
+	// Move the thread to the trash. The poll is kept until the thread is
+	// purged.
+	err = srv.moveToTrashH(ti)
+	if err != nil {
+		return srv.databaseError(err)
+	}
+
+	err = srv.dbo.DeleteThreadWordsById(threadId)
 	if err != nil {
 		return srv.databaseError(err)
 	}
@@ -366,8 +688,8 @@ func (srv *Server) deleteThreadH(threadId base2.Id) (re *jrm1.RpcError) {
 }
 
 // changeThreadForumH is a helper function used by other functions to move a
-// thread from an old forum to a new forum.
-func (srv *Server) changeThreadForumH(threadId base2.Id, newForumId base2.Id, userId base2.Id) (re *jrm1.RpcError) {
+// thread from an old forum to a new forum. ID of the old forum is returned.
+func (srv *Server) changeThreadForumH(threadId base2.Id, newForumId base2.Id, userId base2.Id) (oldParent base2.Id, re *jrm1.RpcError) {
 	srv.dbo.LockForWriting()
 	defer srv.dbo.UnlockAfterWriting()
 
@@ -375,82 +697,164 @@ func (srv *Server) changeThreadForumH(threadId base2.Id, newForumId base2.Id, us
 	var err error
 	n, err = srv.dbo.CountThreadsById(threadId)
 	if err != nil {
-		return srv.databaseError(err)
+		return 0, srv.databaseError(err)
 	}
 
 	if n == 0 {
-		return jrm1.NewRpcErrorByUser(RpcErrorCode_ThreadIsNotFound, RpcErrorMsg_ThreadIsNotFound, nil)
+		return 0, jrm1.NewRpcErrorByUser(RpcErrorCode_ThreadIsNotFound, RpcErrorMsg_ThreadIsNotFound, nil)
 	}
 
 	// Ensure that an old parent exists.
-	var oldParent base2.Id
 	oldParent, err = srv.dbo.GetThreadForumById(threadId)
 	if err != nil {
-		return srv.databaseError(err)
+		return 0, srv.databaseError(err)
 	}
 
 	n, err = srv.dbo.CountForumsById(oldParent)
 	if err != nil {
-		return srv.databaseError(err)
+		return 0, srv.databaseError(err)
 	}
 
 	if n == 0 {
-		return jrm1.NewRpcErrorByUser(RpcErrorCode_ForumIsNotFound, RpcErrorMsg_ForumIsNotFound, nil)
+		return 0, jrm1.NewRpcErrorByUser(RpcErrorCode_ForumIsNotFound, RpcErrorMsg_ForumIsNotFound, nil)
 	}
 
 	// Ensure that a new parent exists.
 	n, err = srv.dbo.CountForumsById(newForumId)
 	if err != nil {
-		return srv.databaseError(err)
+		return 0, srv.databaseError(err)
 	}
 
 	if n == 0 {
-		return jrm1.NewRpcErrorByUser(RpcErrorCode_ForumIsNotFound, RpcErrorMsg_ForumIsNotFound, nil)
+		return 0, jrm1.NewRpcErrorByUser(RpcErrorCode_ForumIsNotFound, RpcErrorMsg_ForumIsNotFound, nil)
 	}
 
 	// Update the moved thread.
 	err = srv.dbo.SetThreadForumById(threadId, newForumId, userId)
 	if err != nil {
-		return srv.databaseError(err)
+		return 0, srv.databaseError(err)
 	}
 
 	// Update the new link.
 	var threadsR *ul.UidList
 	threadsR, err = srv.dbo.GetForumThreadsById(newForumId)
 	if err != nil {
-		return srv.databaseError(err)
+		return 0, srv.databaseError(err)
 	}
 
 	err = threadsR.AddItem(threadId, false)
 	if err != nil {
 		srv.logError(err)
-		return jrm1.NewRpcErrorByUser(server2.RpcErrorCode_UidList, fmt.Sprintf(server2.RpcErrorMsgF_UidList, err.Error()), nil)
+		return 0, jrm1.NewRpcErrorByUser(server2.RpcErrorCode_UidList, fmt.Sprintf(server2.RpcErrorMsgF_UidList, err.Error()), nil)
 	}
 
 	err = srv.dbo.SetForumThreadsById(newForumId, threadsR)
 	if err != nil {
-		return srv.databaseError(err)
+		return 0, srv.databaseError(err)
 	}
 
 	// Update the old link.
 	var threadsL *ul.UidList
 	threadsL, err = srv.dbo.GetForumThreadsById(oldParent)
 	if err != nil {
-		return srv.databaseError(err)
+		return 0, srv.databaseError(err)
 	}
 
 	err = threadsL.RemoveItem(threadId)
 	if err != nil {
 		srv.logError(err)
-		return jrm1.NewRpcErrorByUser(server2.RpcErrorCode_UidList, fmt.Sprintf(server2.RpcErrorMsgF_UidList, err.Error()), nil)
+		return 0, jrm1.NewRpcErrorByUser(server2.RpcErrorCode_UidList, fmt.Sprintf(server2.RpcErrorMsgF_UidList, err.Error()), nil)
 	}
 
 	err = srv.dbo.SetForumThreadsById(oldParent, threadsL)
 	if err != nil {
-		return srv.databaseError(err)
+		return 0, srv.databaseError(err)
 	}
 
-	return nil
+	return oldParent, nil
+}
+
+// addThreadH is a helper function used by other functions to insert a new
+// thread into a forum.
+func (srv *Server) addThreadH(p *rpc2.AddThreadParams, userRoles *am.GetSelfRolesResult) (result *rpc2.AddThreadResult, re *jrm1.RpcError) {
+	srv.dbo.LockForWriting()
+	defer srv.dbo.UnlockAfterWriting()
+
+	// Ensure that a forum exists.
+	var err error
+	var n base2.Count
+	n, err = srv.dbo.CountForumsById(p.ForumId)
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+
+	if n == 0 {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_ForumIsNotFound, RpcErrorMsg_ForumIsNotFound, nil)
+	}
+
+	// Check permissions.
+	var scope *perm.Scope
+	scope, re = srv.getForumScopeH(p.ForumId)
+	if re != nil {
+		return nil, re
+	}
+
+	re = srv.mustHavePermission(userRoles, perm.Permission_CreateThread, scope)
+	if re != nil {
+		return nil, re
+	}
+
+	// Insert a thread and link it with its forum.
+	var parentThreads *ul.UidList
+	parentThreads, err = srv.dbo.GetForumThreadsById(p.ForumId)
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+
+	var insertedThreadId base2.Id
+	insertedThreadId, err = srv.dbo.InsertNewThread(p.ForumId, p.Name, userRoles.User.GetUserParameters().GetId())
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+
+	// A thread which is not linked with its forum is removed.
+	var isThreadLinked bool
+	defer func() {
+		if !isThreadLinked {
+			srv.discardNewThread(insertedThreadId)
+		}
+	}()
+
+	err = srv.updateThreadSearchIndex(insertedThreadId, p.Name)
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+
+	if p.Poll != nil {
+		err = srv.insertPoll(insertedThreadId, p.Poll)
+		if err != nil {
+			return nil, srv.databaseError(err)
+		}
+	}
+
+	err = parentThreads.AddItem(insertedThreadId, srv.settings.SystemSettings.NewThreadsAtTop.AsBool())
+	if err != nil {
+		srv.logError(err)
+		return nil, jrm1.NewRpcErrorByUser(server2.RpcErrorCode_UidList, fmt.Sprintf(server2.RpcErrorMsgF_UidList, err.Error()), nil)
+	}
+
+	err = srv.dbo.SetForumThreadsById(p.ForumId, parentThreads)
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+
+	isThreadLinked = true
+
+	result = &rpc2.AddThreadResult{
+		ThreadId: insertedThreadId,
+	}
+
+	return result, nil
 }
 
 // changeThreadNameH is a helper function used by other functions to rename a
@@ -475,12 +879,17 @@ func (srv *Server) changeThreadNameH(threadId base2.Id, newThreadName base2.Text
 		return srv.databaseError(err)
 	}
 
+	err = srv.updateThreadSearchIndex(threadId, newThreadName)
+	if err != nil {
+		return srv.databaseError(err)
+	}
+
 	return nil
 }
 
 // addMessageH is a helper function used by other functions to inserts a new
 // message into a thread.
-func (srv *Server) addMessageH(threadId base2.Id, messageText base2.Text, userRoles *am.GetSelfRolesResult) (result *rpc2.AddMessageResult, re *jrm1.RpcError) {
+func (srv *Server) addMessageH(threadId base2.Id, messageText base2.Text, messageHtml base2.Text, files []attachmentFile, userRoles *am.GetSelfRolesResult) (result *rpc2.AddMessageResult, re *jrm1.RpcError) {
 	srv.dbo.LockForWriting()
 	defer srv.dbo.UnlockAfterWriting()
 
@@ -491,24 +900,35 @@ func (srv *Server) addMessageH(threadId base2.Id, messageText base2.Text, userRo
 		return nil, re
 	}
 
-	// Check permissions (Part II).
-	canIAddMessage := srv.canUserAddMessage(userRoles, latestMessageInThread)
+	// Check permissions.
+	var scope *perm.Scope
+	scope, re = srv.getThreadScopeH(threadId)
+	if re != nil {
+		return nil, re
+	}
+
+	canIAddMessage := srv.canUserAddMessage(userRoles, latestMessageInThread, scope)
 	if !canIAddMessage {
 		return nil, jrm1.NewRpcErrorByUser(server2.RpcErrorCode_Permission, server2.RpcErrorMsg_Permission, nil)
 	}
 
 	// Ensure that a parent exists.
 	var err error
-	var n base2.Count
-	n, err = srv.dbo.CountThreadsById(threadId)
+	var messageThread derived2.IThread
+	messageThread, err = srv.dbo.GetThreadById(threadId)
 	if err != nil {
 		return nil, srv.databaseError(err)
 	}
 
-	if n == 0 {
+	if messageThread == nil {
 		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_ThreadIsNotFound, RpcErrorMsg_ThreadIsNotFound, nil)
 	}
 
+	// Only moderators can write into a locked thread.
+	if messageThread.GetIsLocked().AsBool() && !userRoles.User.GetUserParameters().GetRoles().IsGranted(perm.Permission_Moderate, scope) {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_ThreadIsLocked, RpcErrorMsg_ThreadIsLocked, nil)
+	}
+
 	// Insert a message and link it with its thread.
 	var parentMessages *ul.UidList
 	parentMessages, err = srv.dbo.GetThreadMessagesById(threadId)
@@ -519,11 +939,29 @@ func (srv *Server) addMessageH(threadId base2.Id, messageText base2.Text, userRo
 	messageTextChecksum := srv.getMessageTextChecksum(messageText)
 
 	var insertedMessageId base2.Id
-	insertedMessageId, err = srv.dbo.InsertNewMessage(threadId, messageText, messageTextChecksum, userRoles.User.GetUserParameters().GetId())
+	insertedMessageId, err = srv.dbo.InsertNewMessage(threadId, messageText, messageTextChecksum, messageHtml, userRoles.User.GetUserParameters().GetId())
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+
+	// A message which is not linked with its thread is removed.
+	var isMessageLinked bool
+	defer func() {
+		if !isMessageLinked {
+			srv.discardNewMessage(insertedMessageId)
+		}
+	}()
+
+	err = srv.updateMessageSearchIndex(insertedMessageId, messageText)
 	if err != nil {
 		return nil, srv.databaseError(err)
 	}
 
+	re = srv.insertAttachments(insertedMessageId, files)
+	if re != nil {
+		return nil, re
+	}
+
 	err = parentMessages.AddItem(insertedMessageId, false)
 	if err != nil {
 		srv.logError(err)
@@ -535,18 +973,10 @@ func (srv *Server) addMessageH(threadId base2.Id, messageText base2.Text, userRo
 		return nil, srv.databaseError(err)
 	}
 
+	isMessageLinked = true
+
 	// Update thread's position if needed.
 	if srv.settings.SystemSettings.NewThreadsAtTop {
-		var messageThread derived2.IThread
-		messageThread, err = srv.dbo.GetThreadById(threadId)
-		if err != nil {
-			return nil, srv.databaseError(err)
-		}
-
-		if messageThread == nil {
-			return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_ThreadIsNotFound, RpcErrorMsg_ThreadIsNotFound, nil)
-		}
-
 		var threads *ul.UidList
 		threads, err = srv.dbo.GetForumThreadsById(messageThread.GetForumId())
 		if err != nil {
@@ -578,7 +1008,7 @@ func (srv *Server) addMessageH(threadId base2.Id, messageText base2.Text, userRo
 
 // changeMessageTextH is a helper function used by other functions to change
 // text of a message.
-func (srv *Server) changeMessageTextH(messageId base2.Id, newText base2.Text, userRoles *am.GetSelfRolesResult) (initialMessage derived2.IMessage, re *jrm1.RpcError) {
+func (srv *Server) changeMessageTextH(messageId base2.Id, newText base2.Text, newHtml base2.Text, userRoles *am.GetSelfRolesResult) (initialMessage derived2.IMessage, re *jrm1.RpcError) {
 	srv.dbo.LockForWriting()
 	defer srv.dbo.UnlockAfterWriting()
 
@@ -594,15 +1024,32 @@ func (srv *Server) changeMessageTextH(messageId base2.Id, newText base2.Text, us
 	}
 
 	// Check permissions.
-	canIEditMessage := srv.canUserEditMessage(userRoles, initialMessage)
+	var scope *perm.Scope
+	scope, re = srv.getThreadScopeH(initialMessage.GetThreadId())
+	if re != nil {
+		return nil, re
+	}
+
+	canIEditMessage := srv.canUserEditMessage(userRoles, initialMessage, scope)
 	if !canIEditMessage {
 		return nil, jrm1.NewRpcErrorByUser(server2.RpcErrorCode_Permission, server2.RpcErrorMsg_Permission, nil)
 	}
 
+	// Save the current text as a revision.
+	err = srv.saveMessageRevision(initialMessage)
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+
 	// Edit the message.
 	messageTextChecksum := srv.getMessageTextChecksum(newText)
 
-	err = srv.dbo.SetMessageTextById(messageId, newText, messageTextChecksum, userRoles.User.GetUserParameters().GetId())
+	err = srv.dbo.SetMessageTextById(messageId, newText, messageTextChecksum, newHtml, userRoles.User.GetUserParameters().GetId())
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+
+	err = srv.updateMessageSearchIndex(messageId, newText)
 	if err != nil {
 		return nil, srv.databaseError(err)
 	}
@@ -704,9 +1151,9 @@ func (srv *Server) changeMessageThreadH(messageId base2.Id, newThreadId base2.Id
 	return initialMessage, nil
 }
 
-// deleteMessageH is a helper function used by other functions to remove a
-// message.
-func (srv *Server) deleteMessageH(messageId base2.Id) (initialMessage derived2.IMessage, re *jrm1.RpcError) {
+// deleteMessageH is a helper function used by other functions to move a
+// message to the trash.
+func (srv *Server) deleteMessageH(messageId base2.Id, userId base2.Id) (initialMessage derived2.IMessage, re *jrm1.RpcError) {
 	srv.dbo.LockForWriting()
 	defer srv.dbo.UnlockAfterWriting()
 
@@ -728,6 +1175,14 @@ func (srv *Server) deleteMessageH(messageId base2.Id) (initialMessage derived2.I
 		return nil, srv.databaseError(err)
 	}
 
+	// Remember the place of the message.
+	var ti *mm.TrashItem
+	ti, err = mm.NewTrashItem(mm.TrashObjectType_Message, messageId, initialMessage.GetTextPtr().ToString(), initialMessage.GetThreadIdPtr(), linkMessages, userId)
+	if err != nil {
+		srv.logError(err)
+		return nil, jrm1.NewRpcErrorByUser(server2.RpcErrorCode_UidList, fmt.Sprintf(server2.RpcErrorMsgF_UidList, err.Error()), nil)
+	}
+
 	err = linkMessages.RemoveItem(messageId)
 	if err != nil {
 		srv.logError(err)
@@ -739,8 +1194,14 @@ func (srv *Server) deleteMessageH(messageId base2.Id) (initialMessage derived2.I
 		return nil, srv.databaseError(err)
 	}
 
-	// Delete the message.
-	err = srv.dbo.DeleteMessageById(messageId)
+	// Move the message to the trash. Revisions, reactions and attachments
+	// are kept until the message is purged.
+	err = srv.moveToTrashH(ti)
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+
+	err = srv.dbo.DeleteMessageWordsById(messageId)
 	if err != nil {
 		return nil, srv.databaseError(err)
 	}
@@ -748,29 +1209,1839 @@ func (srv *Server) deleteMessageH(messageId base2.Id) (initialMessage derived2.I
 	return initialMessage, nil
 }
 
-// reportSystemEvent reports the system event to the notification module.
-func (srv *Server) reportSystemEvent(se derived2.ISystemEvent) (re *jrm1.RpcError) {
-	if se == nil {
-		return jrm1.NewRpcErrorByUser(server2.RpcErrorCode_SystemEvent, server2.RpcErrorMsg_SystemEvent, nil)
+// attachmentFile is a checked file attached to a new message.
+type attachmentFile struct {
+	Name        base2.Text
+	ContentType string
+	Data        []byte
+
+	// Thumbnail is set for images only.
+	Thumbnail []byte
+}
+
+// prepareAttachments checks files attached to a new message and makes
+// thumbnails of images. Type of a file is detected by its contents. When a
+// thumbnail can not be made, the image is attached without it.
+func (srv *Server) prepareAttachments(nas []mm.NewAttachment) (files []attachmentFile, re *jrm1.RpcError) {
+	as := srv.settings.AttachmentSettings
+
+	if base2.Count(len(nas)) > as.MaxFilesPerMessage {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_TooManyAttachments, RpcErrorMsg_TooManyAttachments, nil)
 	}
 
-	params := nm.ProcessSystemEventSParams{
-		DKeyParams: rpc3.DKeyParams{
-			// DKey is set during module start-up, so it is non-null.
-			DKey: *srv.dKeyForNM,
-		},
-		SystemEventData: se.GetSystemEventData(),
+	files = make([]attachmentFile, 0, len(nas))
+	var err error
+	for _, na := range nas {
+		if !mm.IsAttachmentNameValid(na.Name) {
+			return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_AttachmentNameIsNotValid, RpcErrorMsg_AttachmentNameIsNotValid, nil)
+		}
+
+		if (len(na.Data) == 0) || (base2.Count(len(na.Data)) > as.MaxFileSize) {
+			return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_AttachmentSizeIsNotValid, RpcErrorMsg_AttachmentSizeIsNotValid, nil)
+		}
+
+		file := attachmentFile{
+			Name:        na.Name,
+			ContentType: blob.DetectContentType(na.Data),
+			Data:        na.Data,
+		}
+
+		if !as.IsContentTypeAllowed(file.ContentType) {
+			return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_AttachmentTypeIsNotAllowed, RpcErrorMsg_AttachmentTypeIsNotAllowed, nil)
+		}
+
+		if blob.IsThumbnailSupported(file.ContentType) {
+			file.Thumbnail, err = blob.MakeThumbnail(file.Data, int(as.ThumbnailSize))
+			if err != nil {
+				srv.logError(err)
+				file.Thumbnail = nil
+			}
+		}
+
+		files = append(files, file)
 	}
-	result := new(nm.ProcessSystemEventSResult)
+
+	return files, nil
+}
+
+// insertAttachments saves files attached to a message.
+func (srv *Server) insertAttachments(messageId base2.Id, files []attachmentFile) (re *jrm1.RpcError) {
+	var blobHash string
+	var thumbnailHash *string
 	var err error
-	re, err = srv.nmServiceClient.MakeRequest(context.Background(), nc.FuncProcessSystemEventS, params, result)
+	for _, file := range files {
+		blobHash, re = srv.saveBlob(file.Data)
+		if re != nil {
+			return re
+		}
+
+		thumbnailHash = nil
+		if file.Thumbnail != nil {
+			var h string
+			h, re = srv.saveBlob(file.Thumbnail)
+			if re != nil {
+				return re
+			}
+			thumbnailHash = &h
+		}
+
+		_, err = srv.dbo.InsertAttachment(messageId, file.Name, file.ContentType, base2.Count(len(file.Data)), blobHash, thumbnailHash)
+		if err != nil {
+			return srv.databaseError(err)
+		}
+	}
+
+	return nil
+}
+
+// saveBlob registers a blob and writes it into the blob store. A blob is
+// registered before it is written, so that a failed write leaves no
+// unregistered files. A blob which is not used is deleted by the scheduler.
+func (srv *Server) saveBlob(data []byte) (hash string, re *jrm1.RpcError) {
+	hash = blob.Hash(data)
+
+	err := srv.dbo.InsertBlob(hash, base2.Count(len(data)))
 	if err != nil {
-		srv.logError(err)
-		return jrm1.NewRpcErrorByUser(server2.RpcErrorCode_RPCCall, server2.RpcErrorMsg_RPCCall, nil)
+		return "", srv.databaseError(err)
+	}
+
+	_, err = srv.blobStore.Save(data)
+	if err != nil {
+		return "", srv.blobStoreError(err)
+	}
+
+	return hash, nil
+}
+
+// blobStoreError logs an error of the blob store and returns an RPC error.
+func (srv *Server) blobStoreError(err error) (re *jrm1.RpcError) {
+	srv.logError(err)
+	return jrm1.NewRpcErrorByUser(RpcErrorCode_BlobStore, RpcErrorMsg_BlobStore, nil)
+}
+
+// checkNewPoll checks parameters of a new poll.
+func (srv *Server) checkNewPoll(np *mm.NewPoll) (re *jrm1.RpcError) {
+	if len(np.Question) == 0 {
+		return jrm1.NewRpcErrorByUser(RpcErrorCode_PollQuestionIsNotSet, RpcErrorMsg_PollQuestionIsNotSet, nil)
+	}
+
+	if !mm.CheckNewPollOptions(np.Options, srv.settings.SystemSettings.MaxPollOptions) {
+		return jrm1.NewRpcErrorByUser(RpcErrorCode_PollOptionsAreNotValid, RpcErrorMsg_PollOptionsAreNotValid, nil)
+	}
+
+	if (np.ClosingTime != nil) && !np.ClosingTime.After(time.Now()) {
+		return jrm1.NewRpcErrorByUser(RpcErrorCode_PollClosingTimeIsNotValid, RpcErrorMsg_PollClosingTimeIsNotValid, nil)
+	}
+
+	return nil
+}
+
+// insertPoll saves a new poll of a thread with its options.
+func (srv *Server) insertPoll(threadId base2.Id, np *mm.NewPoll) (err error) {
+	var pollId base2.Id
+	pollId, err = srv.dbo.InsertPoll(threadId, np.Question, np.IsMultipleChoice, np.AreResultsHidden, np.ClosingTime)
+	if err != nil {
+		return err
+	}
+
+	for _, option := range np.Options {
+		_, err = srv.dbo.InsertPollOption(pollId, option)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// deletePollOfThread deletes a poll of a thread with its options and votes.
+// Threads without polls are ignored.
+func (srv *Server) deletePollOfThread(threadId base2.Id) (err error) {
+	var poll *mm.Poll
+	poll, err = srv.dbo.GetPollByThreadId(threadId)
+	if err != nil {
+		return err
+	}
+
+	if poll == nil {
+		return nil
+	}
+
+	err = srv.dbo.DeletePollVotes(poll.Id)
+	if err != nil {
+		return err
+	}
+
+	err = srv.dbo.DeletePollVoters(poll.Id)
+	if err != nil {
+		return err
+	}
+
+	err = srv.dbo.DeletePollOptions(poll.Id)
+	if err != nil {
+		return err
+	}
+
+	return srv.dbo.DeletePollById(poll.Id)
+}
+
+// getPollH is a helper function used by other functions to read a poll of a
+// thread with its options. The user must be able to read the thread.
+func (srv *Server) getPollH(threadId base2.Id, userRoles *am.GetSelfRolesResult) (poll *mm.Poll, re *jrm1.RpcError) {
+	// Check permissions.
+	var scope *perm.Scope
+	scope, re = srv.getThreadScopeH(threadId)
+	if re != nil {
+		return nil, re
+	}
+
+	re = srv.mustHavePermission(userRoles, perm.Permission_Read, scope)
+	if re != nil {
+		return nil, re
+	}
+
+	// Read the poll.
+	var err error
+	poll, err = srv.dbo.GetPollByThreadId(threadId)
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+
+	if poll == nil {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_PollIsNotFound, RpcErrorMsg_PollIsNotFound, nil)
+	}
+
+	poll.Options, err = srv.dbo.ReadPollOptions(poll.Id)
+	if err != nil {
+		return nil, srv.databaseError(err)
 	}
+
+	return poll, nil
+}
+
+// voteInPollH is a helper function used by other functions to save a vote
+// of a user in a poll. When the 'isChange' flag is set, an existing vote is
+// replaced, otherwise a new vote is added.
+func (srv *Server) voteInPollH(threadId base2.Id, optionIds []base2.Id, userRoles *am.GetSelfRolesResult, isChange bool) (re *jrm1.RpcError) {
+	srv.dbo.LockForWriting()
+	defer srv.dbo.UnlockAfterWriting()
+
+	var poll *mm.Poll
+	poll, re = srv.getPollH(threadId, userRoles)
 	if re != nil {
 		return re
 	}
 
+	if !poll.IsOpen(time.Now()) {
+		return jrm1.NewRpcErrorByUser(RpcErrorCode_PollIsClosed, RpcErrorMsg_PollIsClosed, nil)
+	}
+
+	if !poll.IsVoteValid(optionIds) {
+		return jrm1.NewRpcErrorByUser(RpcErrorCode_PollVoteIsNotValid, RpcErrorMsg_PollVoteIsNotValid, nil)
+	}
+
+	userId := userRoles.User.GetUserParameters().GetId()
+
+	n, err := srv.dbo.CountPollVoter(poll.Id, userId)
+	if err != nil {
+		return srv.databaseError(err)
+	}
+
+	if isChange {
+		if n == 0 {
+			return jrm1.NewRpcErrorByUser(RpcErrorCode_PollVoteIsNotFound, RpcErrorMsg_PollVoteIsNotFound, nil)
+		}
+
+		err = srv.dbo.DeletePollVotesOfUser(poll.Id, userId)
+		if err != nil {
+			return srv.databaseError(err)
+		}
+
+		err = srv.dbo.SetPollVoterEditTime(poll.Id, userId)
+		if err != nil {
+			return srv.databaseError(err)
+		}
+	} else {
+		if n > 0 {
+			return jrm1.NewRpcErrorByUser(RpcErrorCode_PollVoteAlreadyExists, RpcErrorMsg_PollVoteAlreadyExists, nil)
+		}
+
+		err = srv.dbo.InsertPollVoter(poll.Id, userId)
+		if err != nil {
+			return srv.databaseError(err)
+		}
+	}
+
+	for _, optionId := range optionIds {
+		err = srv.dbo.InsertPollVote(poll.Id, userId, optionId)
+		if err != nil {
+			return srv.databaseError(err)
+		}
+	}
+
+	return nil
+}
+
+// addMessageReactionH is a helper function used by other functions to put a
+// reaction on a message. Users may react to messages which they can read,
+// except their own messages.
+func (srv *Server) addMessageReactionH(messageId base2.Id, reaction *mm.Reaction, userRoles *am.GetSelfRolesResult) (message derived2.IMessage, re *jrm1.RpcError) {
+	srv.dbo.LockForWriting()
+	defer srv.dbo.UnlockAfterWriting()
+
+	// Read the message.
+	var err error
+	message, err = srv.dbo.GetMessageById(messageId)
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+
+	if message == nil {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_MessageIsNotFound, RpcErrorMsg_MessageIsNotFound, nil)
+	}
+
+	// Check permissions.
+	var scope *perm.Scope
+	scope, re = srv.getThreadScopeH(message.GetThreadId())
+	if re != nil {
+		return nil, re
+	}
+
+	re = srv.mustHavePermission(userRoles, perm.Permission_Read, scope)
+	if re != nil {
+		return nil, re
+	}
+
+	userId := userRoles.User.GetUserParameters().GetId()
+	authorUserId := message.GetEventData().GetCreatorUserId()
+	if userId == authorUserId {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_SelfReaction, RpcErrorMsg_SelfReaction, nil)
+	}
+
+	var existingReaction *mm.MessageReaction
+	existingReaction, err = srv.dbo.GetMessageReaction(messageId, userId, reaction.Emoji)
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+
+	if existingReaction != nil {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_ReactionAlreadyExists, RpcErrorMsg_ReactionAlreadyExists, nil)
+	}
+
+	// Save the reaction.
+	err = srv.dbo.InsertMessageReaction(messageId, userId, reaction.Emoji, authorUserId, reaction.Weight)
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+
+	err = srv.dbo.ChangeUserReputation(authorUserId, reaction.Weight)
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+
+	return message, nil
+}
+
+// removeMessageReactionH is a helper function used by other functions to
+// remove a reaction from a message. The weight saved with the reaction is
+// used, so that the reputation is restored correctly even when the weight is
+// changed in settings.
+func (srv *Server) removeMessageReactionH(messageId base2.Id, emoji string, userId base2.Id) (re *jrm1.RpcError) {
+	srv.dbo.LockForWriting()
+	defer srv.dbo.UnlockAfterWriting()
+
+	reaction, err := srv.dbo.GetMessageReaction(messageId, userId, emoji)
+	if err != nil {
+		return srv.databaseError(err)
+	}
+
+	if reaction == nil {
+		return jrm1.NewRpcErrorByUser(RpcErrorCode_ReactionIsNotFound, RpcErrorMsg_ReactionIsNotFound, nil)
+	}
+
+	err = srv.dbo.DeleteMessageReaction(messageId, userId, emoji)
+	if err != nil {
+		return srv.databaseError(err)
+	}
+
+	err = srv.dbo.ChangeUserReputation(reaction.AuthorUserId, -reaction.Weight)
+	if err != nil {
+		return srv.databaseError(err)
+	}
+
 	return nil
 }
+
+// getMessageRevisionH is a helper function used by other functions to read a
+// revision of a message. Only moderators of the message may read it.
+func (srv *Server) getMessageRevisionH(revisionId base2.Id, userRoles *am.GetSelfRolesResult) (revision *mm.MessageRevision, re *jrm1.RpcError) {
+	srv.dbo.LockForReading()
+	defer srv.dbo.UnlockAfterReading()
+
+	var err error
+	revision, err = srv.dbo.GetMessageRevisionById(revisionId)
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+
+	if revision == nil {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_RevisionIsNotFound, RpcErrorMsg_RevisionIsNotFound, nil)
+	}
+
+	// Check permissions.
+	var scope *perm.Scope
+	scope, re = srv.getMessageScopeH(revision.MessageId)
+	if re != nil {
+		return nil, re
+	}
+
+	re = srv.mustHavePermission(userRoles, perm.Permission_Moderate, scope)
+	if re != nil {
+		return nil, re
+	}
+
+	return revision, nil
+}
+
+// saveMessageRevision saves the current text of a message as its revision.
+// This function must be called when the database is locked for writing.
+func (srv *Server) saveMessageRevision(message derived2.IMessage) (err error) {
+	// Author of the current text is the last editor of the message, if the
+	// message was edited, or its creator otherwise.
+	editorUserId := message.GetEventData().GetCreatorUserId()
+	lastEditorUserId := *message.GetEventData().GetEditorUserIdPtr()
+	if lastEditorUserId != nil {
+		editorUserId = *lastEditorUserId
+	}
+
+	_, err = srv.dbo.InsertMessageRevision(*message.GetIdPtr(), *message.GetTextPtr(), *message.GetTextChecksumPtr(), editorUserId, message.GetLastTouchTime())
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// discardNewThread removes a new thread which has not been linked with its
+// forum together with its search index and poll. This function must be called
+// when the database is locked for writing.
+func (srv *Server) discardNewThread(threadId base2.Id) {
+	err := srv.dbo.DeleteThreadWordsById(threadId)
+	if err != nil {
+		srv.processDatabaseError(err)
+	}
+
+	err = srv.deletePollOfThread(threadId)
+	if err != nil {
+		srv.processDatabaseError(err)
+	}
+
+	err = srv.dbo.DeleteThreadById(threadId)
+	if err != nil {
+		srv.processDatabaseError(err)
+	}
+}
+
+// discardNewMessage removes a new message which has not been linked with its
+// thread together with its search index and attachments. Blobs of the
+// attachments are deleted by the scheduler. This function must be called when
+// the database is locked for writing.
+func (srv *Server) discardNewMessage(messageId base2.Id) {
+	err := srv.dbo.DeleteMessageWordsById(messageId)
+	if err != nil {
+		srv.processDatabaseError(err)
+	}
+
+	err = srv.dbo.DeleteAttachmentsByMessageId(messageId)
+	if err != nil {
+		srv.processDatabaseError(err)
+	}
+
+	err = srv.dbo.DeleteMessageById(messageId)
+	if err != nil {
+		srv.processDatabaseError(err)
+	}
+}
+
+// updateMessageSearchIndex re-indexes words of a message. This function must
+// be called when the database is locked for writing.
+func (srv *Server) updateMessageSearchIndex(messageId base2.Id, messageText base2.Text) (err error) {
+	err = srv.dbo.DeleteMessageWordsById(messageId)
+	if err != nil {
+		return err
+	}
+
+	wf := mm.NewWordFrequencies(messageText.ToString(), srv.settings.SystemSettings.SearchMinWordLength.AsInt())
+	for word, frequency := range wf {
+		err = srv.dbo.InsertMessageWord(messageId, word, frequency)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// updateThreadSearchIndex re-indexes words of a thread's name. This function
+// must be called when the database is locked for writing.
+func (srv *Server) updateThreadSearchIndex(threadId base2.Id, threadName base2.Text) (err error) {
+	err = srv.dbo.DeleteThreadWordsById(threadId)
+	if err != nil {
+		return err
+	}
+
+	wf := mm.NewWordFrequencies(threadName.ToString(), srv.settings.SystemSettings.SearchMinWordLength.AsInt())
+	for word, frequency := range wf {
+		err = srv.dbo.InsertThreadWord(threadId, word, frequency)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// makeSearchFilter checks parameters of a search and composes a search
+// filter.
+func (srv *Server) makeSearchFilter(text base2.Text, forumId *base2.Id, authorId *base2.Id, fromTime *time.Time, toTime *time.Time) (sf *mm.SearchFilter, re *jrm1.RpcError) {
+	if len(text) == 0 {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_SearchTextIsNotSet, RpcErrorMsg_SearchTextIsNotSet, nil)
+	}
+
+	words := mm.NewWordFrequencies(text.ToString(), srv.settings.SystemSettings.SearchMinWordLength.AsInt()).QueryWords()
+	if len(words) == 0 {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_SearchTextHasNoWords, RpcErrorMsg_SearchTextHasNoWords, nil)
+	}
+
+	if (fromTime != nil) && (toTime != nil) && fromTime.After(*toTime) {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_TimeRangeIsNotValid, RpcErrorMsg_TimeRangeIsNotValid, nil)
+	}
+
+	sf = &mm.SearchFilter{
+		Words:         words,
+		ForumId:       forumId,
+		CreatorUserId: authorId,
+		FromTime:      fromTime,
+		ToTime:        toTime,
+	}
+
+	return sf, nil
+}
+
+// reportSystemEvent reports the system event to the notification module.
+func (srv *Server) reportSystemEvent(se derived2.ISystemEvent) (re *jrm1.RpcError) {
+	if se == nil {
+		return jrm1.NewRpcErrorByUser(server2.RpcErrorCode_SystemEvent, server2.RpcErrorMsg_SystemEvent, nil)
+	}
+
+	params := nm.ProcessSystemEventSParams{
+		DKeyParams: rpc3.DKeyParams{
+			// DKey is set during module start-up, so it is non-null.
+			DKey: *srv.dKeyForNM,
+		},
+		SystemEventData: se.GetSystemEventData(),
+	}
+	result := new(nm.ProcessSystemEventSResult)
+	var err error
+	re, err = srv.nmServiceClient.MakeRequest(context.Background(), nc.FuncProcessSystemEventS, params, result)
+	if err != nil {
+		srv.logError(err)
+		return jrm1.NewRpcErrorByUser(server2.RpcErrorCode_RPCCall, server2.RpcErrorMsg_RPCCall, nil)
+	}
+	if re != nil {
+		return re
+	}
+
+	return nil
+}
+
+// makeConversationMembers checks the list of users invited into a conversation
+// and adds the creator of the conversation to the list. Recipients are the
+// invited users without the creator.
+func (srv *Server) makeConversationMembers(creatorUserId base2.Id, userIds []base2.Id) (members *ul.UidList, recipients []base2.Id, re *jrm1.RpcError) {
+	members = ul.New()
+	recipients = make([]base2.Id, 0, len(userIds))
+
+	var err error
+	err = members.AddItem(creatorUserId, false)
+	if err != nil {
+		srv.logError(err)
+		return nil, nil, jrm1.NewRpcErrorByUser(server2.RpcErrorCode_UidList, fmt.Sprintf(server2.RpcErrorMsgF_UidList, err.Error()), nil)
+	}
+
+	for _, userId := range userIds {
+		if userId == 0 {
+			return nil, nil, jrm1.NewRpcErrorByUser(RpcErrorCode_UserIdIsNotSet, RpcErrorMsg_UserIdIsNotSet, nil)
+		}
+
+		if members.HasItem(userId) {
+			continue
+		}
+
+		err = members.AddItem(userId, false)
+		if err != nil {
+			srv.logError(err)
+			return nil, nil, jrm1.NewRpcErrorByUser(server2.RpcErrorCode_UidList, fmt.Sprintf(server2.RpcErrorMsgF_UidList, err.Error()), nil)
+		}
+
+		recipients = append(recipients, userId)
+	}
+
+	if len(recipients) == 0 {
+		return nil, nil, jrm1.NewRpcErrorByUser(RpcErrorCode_RecipientsAreNotSet, RpcErrorMsg_RecipientsAreNotSet, nil)
+	}
+
+	if members.Size() > srv.settings.SystemSettings.MaxConversationMembers {
+		return nil, nil, jrm1.NewRpcErrorByUser(RpcErrorCode_TooManyRecipients, RpcErrorMsg_TooManyRecipients, nil)
+	}
+
+	return members, recipients, nil
+}
+
+// mustUsersExist ensures that all the users exist. Users are checked by the
+// ACM module on behalf of the RPC caller.
+func (srv *Server) mustUsersExist(auth *rpc3.Auth, userIds []base2.Id) (re *jrm1.RpcError) {
+	var err error
+	for _, userId := range userIds {
+		params := am.GetUserNameParams{
+			CommonParams: rpc3.CommonParams{
+				Auth: auth,
+			},
+			UserId: userId,
+		}
+		result := new(am.GetUserNameResult)
+
+		re, err = srv.acmServiceClient.MakeRequest(context.Background(), ac.FuncGetUserName, params, result)
+		if err != nil {
+			srv.logError(err)
+			return jrm1.NewRpcErrorByUser(server2.RpcErrorCode_RPCCall, server2.RpcErrorMsg_RPCCall, nil)
+		}
+		if re != nil {
+			return jrm1.NewRpcErrorByUser(RpcErrorCode_UserIsNotFound, RpcErrorMsg_UserIsNotFound, userId)
+		}
+	}
+
+	return nil
+}
+
+// renderedMessage is a text of a message rendered as HTML.
+type renderedMessage struct {
+	doc  *markup.Document
+	html base2.Text
+
+	// Identifiers of mentioned users by their names.
+	mentionedUsers map[string]base2.Id
+}
+
+// renderMessageText parses a text of a message and renders it as HTML.
+// Mentioned users are looked up by the ACM module on behalf of the RPC
+// caller. Mentions of unknown users and mentions beyond the limit are left as
+// a plain text.
+func (srv *Server) renderMessageText(auth *rpc3.Auth, text base2.Text) (rm *renderedMessage, re *jrm1.RpcError) {
+	rm = &renderedMessage{
+		doc:            markup.Parse(text.ToString()),
+		mentionedUsers: make(map[string]base2.Id),
+	}
+
+	names := rm.doc.Mentions()
+	if len(names) > srv.settings.SystemSettings.MaxMentionsPerMessage.AsInt() {
+		names = names[:srv.settings.SystemSettings.MaxMentionsPerMessage.AsInt()]
+	}
+
+	if len(names) > 0 {
+		params := am.GetUserIdsByNamesParams{
+			CommonParams: rpc3.CommonParams{
+				Auth: auth,
+			},
+			UserNames: make([]base2.Text, 0, len(names)),
+		}
+		for _, name := range names {
+			params.UserNames = append(params.UserNames, base2.Text(name))
+		}
+		result := new(am.GetUserIdsByNamesResult)
+
+		var err error
+		re, err = srv.acmServiceClient.MakeRequest(context.Background(), ac.FuncGetUserIdsByNames, params, result)
+		if err != nil {
+			srv.logError(err)
+			return nil, jrm1.NewRpcErrorByUser(server2.RpcErrorCode_RPCCall, server2.RpcErrorMsg_RPCCall, nil)
+		}
+		if re != nil {
+			return nil, re
+		}
+
+		for name, userId := range result.UserIds {
+			rm.mentionedUsers[name.ToString()] = userId
+		}
+	}
+
+	rm.html = base2.Text(rm.doc.Html(rm.mentionedUsers))
+
+	return rm, nil
+}
+
+// getMentionedUsers returns identifiers of users mentioned outside of quotes.
+// Users mentioned in the previous text of a message are skipped, so that an
+// edit of a message does not notify them once more.
+func (rm *renderedMessage) getMentionedUsers(previousText *base2.Text) (userIds []base2.Id) {
+	var isOld = make(map[string]bool)
+	if previousText != nil {
+		for _, name := range markup.Parse(previousText.ToString()).DirectMentions() {
+			isOld[name] = true
+		}
+	}
+
+	userIds = []base2.Id{}
+	for _, name := range rm.doc.DirectMentions() {
+		userId, ok := rm.mentionedUsers[name]
+		if !ok || isOld[name] {
+			continue
+		}
+
+		userIds = append(userIds, userId)
+	}
+
+	return userIds
+}
+
+// reportMentions reports mentions of users in a message to the notification
+// module. Authors mentioning themselves are not notified.
+func (srv *Server) reportMentions(threadId base2.Id, messageId base2.Id, authorId base2.Id, userIds []base2.Id) (re *jrm1.RpcError) {
+	var se derived2.ISystemEvent
+	var err error
+	for _, userId := range userIds {
+		if userId == authorId {
+			continue
+		}
+
+		seData := sed.NewSystemEventDataWithValue(
+			set.NewSystemEventTypeWithValue(ev.NewEnumValue(set.SystemEventType_MessageMention)),
+			&threadId,
+			&messageId,
+			&authorId,
+			&userId,
+		)
+
+		se, err = cm.NewSystemEventWithData(seData)
+		if err != nil {
+			return jrm1.NewRpcErrorByUser(server2.RpcErrorCode_SystemEvent, server2.RpcErrorMsg_SystemEvent, nil)
+		}
+
+		re = srv.reportSystemEvent(se)
+		if re != nil {
+			return re
+		}
+	}
+
+	return nil
+}
+
+// mustNotBeBlockedH ensures that none of the recipients has blocked private
+// messages of the sender.
+func (srv *Server) mustNotBeBlockedH(senderUserId base2.Id, recipients []base2.Id) (re *jrm1.RpcError) {
+	var n base2.Count
+	var err error
+	for _, recipient := range recipients {
+		n, err = srv.dbo.CountUserBlocks(recipient, senderUserId)
+		if err != nil {
+			return srv.databaseError(err)
+		}
+
+		if n > 0 {
+			return jrm1.NewRpcErrorByUser(RpcErrorCode_UserIsBlocked, RpcErrorMsg_UserIsBlocked, recipient)
+		}
+	}
+
+	return nil
+}
+
+// startConversationH is a helper function used by other functions to create a
+// conversation.
+func (srv *Server) startConversationH(subject base2.Text, text base2.Text, senderUserId base2.Id, members *ul.UidList, recipients []base2.Id) (result *rpc2.StartConversationResult, re *jrm1.RpcError) {
+	srv.dbo.LockForWriting()
+	defer srv.dbo.UnlockAfterWriting()
+
+	re = srv.mustNotBeBlockedH(senderUserId, recipients)
+	if re != nil {
+		return nil, re
+	}
+
+	conversationId, err := srv.dbo.InsertNewConversation(subject, senderUserId)
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+
+	for _, member := range members.AsArray() {
+		err = srv.dbo.InsertConversationMember(conversationId, member)
+		if err != nil {
+			return nil, srv.databaseError(err)
+		}
+	}
+
+	var messageId base2.Id
+	messageId, re = srv.addPrivateMessageH(conversationId, text, senderUserId)
+	if re != nil {
+		return nil, re
+	}
+
+	result = &rpc2.StartConversationResult{
+		ConversationId: conversationId,
+		MessageId:      messageId,
+	}
+
+	return result, nil
+}
+
+// sendPrivateMessageH is a helper function used by other functions to add a
+// message into a conversation. Recipients of the message are returned.
+func (srv *Server) sendPrivateMessageH(conversationId base2.Id, text base2.Text, senderUserId base2.Id) (result *rpc2.SendPrivateMessageResult, recipients []base2.Id, re *jrm1.RpcError) {
+	srv.dbo.LockForWriting()
+	defer srv.dbo.UnlockAfterWriting()
+
+	conversation, err := srv.dbo.GetConversationById(senderUserId, conversationId)
+	if err != nil {
+		return nil, nil, srv.databaseError(err)
+	}
+
+	if conversation == nil {
+		return nil, nil, jrm1.NewRpcErrorByUser(RpcErrorCode_ConversationIsNotFound, RpcErrorMsg_ConversationIsNotFound, nil)
+	}
+
+	recipients = make([]base2.Id, 0, conversation.Members.Size())
+	for _, member := range conversation.Members.AsArray() {
+		if member != senderUserId {
+			recipients = append(recipients, member)
+		}
+	}
+
+	re = srv.mustNotBeBlockedH(senderUserId, recipients)
+	if re != nil {
+		return nil, nil, re
+	}
+
+	var messageId base2.Id
+	messageId, re = srv.addPrivateMessageH(conversationId, text, senderUserId)
+	if re != nil {
+		return nil, nil, re
+	}
+
+	result = &rpc2.SendPrivateMessageResult{
+		MessageId: messageId,
+	}
+
+	return result, recipients, nil
+}
+
+// addPrivateMessageH inserts a new message into a conversation. The message is
+// marked as read by its author.
+// This function must be called when the database is locked for writing.
+func (srv *Server) addPrivateMessageH(conversationId base2.Id, text base2.Text, senderUserId base2.Id) (messageId base2.Id, re *jrm1.RpcError) {
+	var err error
+	messageId, err = srv.dbo.InsertNewPrivateMessage(conversationId, text, senderUserId)
+	if err != nil {
+		return 0, srv.databaseError(err)
+	}
+
+	err = srv.dbo.SetConversationLastMessageTime(conversationId)
+	if err != nil {
+		return 0, srv.databaseError(err)
+	}
+
+	err = srv.dbo.SetLastReadPrivateMessageId(conversationId, senderUserId, messageId)
+	if err != nil {
+		return 0, srv.databaseError(err)
+	}
+
+	return messageId, nil
+}
+
+// notifyAboutPrivateMessage sends a notification about a new private message
+// to each of the recipients.
+func (srv *Server) notifyAboutPrivateMessage(conversationId base2.Id, senderUserId base2.Id, recipients []base2.Id) (re *jrm1.RpcError) {
+	text := base2.Text(fmt.Sprintf(NotificationF_NewPrivateMessage, senderUserId, conversationId))
+
+	for _, recipient := range recipients {
+		re = srv.sendNotificationToUser(recipient, text)
+		if re != nil {
+			return re
+		}
+	}
+
+	return nil
+}
+
+// moveToTrashH moves an object to the trash. The object must already be
+// removed from the list of its parent. This function must be called under
+// the write lock.
+func (srv *Server) moveToTrashH(ti *mm.TrashItem) (err error) {
+	err = srv.dbo.InsertTrashItem(ti)
+	if err != nil {
+		return err
+	}
+
+	switch ti.ObjectType {
+	case mm.TrashObjectType_Section:
+		err = srv.dbo.MoveSectionToTrash(ti.ObjectId)
+		if err != nil {
+			return err
+		}
+
+		return srv.dbo.DeleteSectionById(ti.ObjectId)
+
+	case mm.TrashObjectType_Forum:
+		err = srv.dbo.MoveForumToTrash(ti.ObjectId)
+		if err != nil {
+			return err
+		}
+
+		return srv.dbo.DeleteForumById(ti.ObjectId)
+
+	case mm.TrashObjectType_Thread:
+		err = srv.dbo.MoveThreadToTrash(ti.ObjectId)
+		if err != nil {
+			return err
+		}
+
+		return srv.dbo.DeleteThreadById(ti.ObjectId)
+
+	case mm.TrashObjectType_Message:
+		err = srv.dbo.MoveMessageToTrash(ti.ObjectId)
+		if err != nil {
+			return err
+		}
+
+		return srv.dbo.DeleteMessageById(ti.ObjectId)
+	}
+
+	return fmt.Errorf(ErrF_TrashObjectType, ti.ObjectType)
+}
+
+// restoreFromTrashH is a helper function used by other functions to restore
+// an object from the trash. The object is put back into the list of its
+// parent where it was before the deletion.
+func (srv *Server) restoreFromTrashH(trashItemId base2.Id) (re *jrm1.RpcError) {
+	srv.dbo.LockForWriting()
+	defer srv.dbo.UnlockAfterWriting()
+
+	var ti *mm.TrashItem
+	var err error
+	ti, err = srv.dbo.GetTrashItemById(trashItemId)
+	if err != nil {
+		return srv.databaseError(err)
+	}
+
+	if ti == nil {
+		return jrm1.NewRpcErrorByUser(RpcErrorCode_TrashItemIsNotFound, RpcErrorMsg_TrashItemIsNotFound, nil)
+	}
+
+	switch ti.ObjectType {
+	case mm.TrashObjectType_Section:
+		re = srv.restoreSectionH(ti)
+
+	case mm.TrashObjectType_Forum:
+		re = srv.restoreForumH(ti)
+
+	case mm.TrashObjectType_Thread:
+		re = srv.restoreThreadH(ti)
+
+	case mm.TrashObjectType_Message:
+		re = srv.restoreMessageH(ti)
+
+	default:
+		return jrm1.NewRpcErrorByUser(RpcErrorCode_TrashItemIsDamaged, RpcErrorMsg_TrashItemIsDamaged, nil)
+	}
+	if re != nil {
+		return re
+	}
+
+	err = srv.dbo.DeleteTrashItemById(ti.Id)
+	if err != nil {
+		return srv.databaseError(err)
+	}
+
+	return nil
+}
+
+// restoreSectionH restores a section from the trash. This function must be
+// called under the write lock.
+func (srv *Server) restoreSectionH(ti *mm.TrashItem) (re *jrm1.RpcError) {
+	var n base2.Count
+	var err error
+	n, err = srv.dbo.CountSectionsById(ti.ObjectId)
+	if err != nil {
+		return srv.databaseError(err)
+	}
+
+	if n > 0 {
+		return jrm1.NewRpcErrorByUser(RpcErrorCode_ObjectIdIsAlreadyUsed, RpcErrorMsg_ObjectIdIsAlreadyUsed, nil)
+	}
+
+	// Only a single root section may exist.
+	if ti.ParentId == nil {
+		n, err = srv.dbo.CountRootSections()
+		if err != nil {
+			return srv.databaseError(err)
+		}
+
+		if n > 0 {
+			return jrm1.NewRpcErrorByUser(RpcErrorCode_RootSectionAlreadyExists, RpcErrorMsg_RootSectionAlreadyExists, nil)
+		}
+
+		return srv.restoreSectionRecordH(ti.ObjectId)
+	}
+
+	// Ensure that the parent exists and accepts sections.
+	n, err = srv.dbo.CountSectionsById(*ti.ParentId)
+	if err != nil {
+		return srv.databaseError(err)
+	}
+
+	if n == 0 {
+		return jrm1.NewRpcErrorByUser(RpcErrorCode_ParentIsNotFound, RpcErrorMsg_ParentIsNotFound, nil)
+	}
+
+	re = srv.setSectionChildTypeH(*ti.ParentId, sct.SectionChildType_Section)
+	if re != nil {
+		return re
+	}
+
+	// Restore the link.
+	var linkSections *ul.UidList
+	linkSections, err = srv.dbo.GetSectionChildrenById(*ti.ParentId)
+	if err != nil {
+		return srv.databaseError(err)
+	}
+
+	err = linkSections.InsertItemAtPos(ti.ObjectId, ti.RestorePosition(linkSections))
+	if err != nil {
+		srv.logError(err)
+		return jrm1.NewRpcErrorByUser(server2.RpcErrorCode_UidList, fmt.Sprintf(server2.RpcErrorMsgF_UidList, err.Error()), nil)
+	}
+
+	re = srv.restoreSectionRecordH(ti.ObjectId)
+	if re != nil {
+		return re
+	}
+
+	err = srv.dbo.SetSectionChildrenById(*ti.ParentId, linkSections)
+	if err != nil {
+		return srv.databaseError(err)
+	}
+
+	return nil
+}
+
+// restoreSectionRecordH moves a record of a section out of the trash.
+func (srv *Server) restoreSectionRecordH(sectionId base2.Id) (re *jrm1.RpcError) {
+	err := srv.dbo.RestoreSectionFromTrash(sectionId)
+	if err != nil {
+		return srv.databaseError(err)
+	}
+
+	err = srv.dbo.DeleteSectionFromTrash(sectionId)
+	if err != nil {
+		return srv.databaseError(err)
+	}
+
+	return nil
+}
+
+// setSectionChildTypeH ensures that a section may have children of the
+// specified type. The type is set when the section has no children.
+func (srv *Server) setSectionChildTypeH(sectionId base2.Id, childType byte) (re *jrm1.RpcError) {
+	ct, err := srv.dbo.GetSectionChildTypeById(sectionId)
+	if err != nil {
+		return srv.databaseError(err)
+	}
+
+	if ct.AsInt() == sct.SectionChildType_None {
+		err = srv.dbo.SetSectionChildTypeById(sectionId, sct.NewSectionChildTypeWithValue(ev.NewEnumValue(childType)))
+		if err != nil {
+			return srv.databaseError(err)
+		}
+
+		return nil
+	}
+
+	if ct.AsInt() != int(childType) {
+		return jrm1.NewRpcErrorByUser(RpcErrorCode_IncompatibleChildType, RpcErrorMsg_IncompatibleChildType, nil)
+	}
+
+	return nil
+}
+
+// restoreForumH restores a forum from the trash. This function must be called
+// under the write lock.
+func (srv *Server) restoreForumH(ti *mm.TrashItem) (re *jrm1.RpcError) {
+	var n base2.Count
+	var err error
+	n, err = srv.dbo.CountForumsById(ti.ObjectId)
+	if err != nil {
+		return srv.databaseError(err)
+	}
+
+	if n > 0 {
+		return jrm1.NewRpcErrorByUser(RpcErrorCode_ObjectIdIsAlreadyUsed, RpcErrorMsg_ObjectIdIsAlreadyUsed, nil)
+	}
+
+	// Ensure that the section exists and accepts forums.
+	if ti.ParentId == nil {
+		return jrm1.NewRpcErrorByUser(RpcErrorCode_TrashItemIsDamaged, RpcErrorMsg_TrashItemIsDamaged, nil)
+	}
+
+	n, err = srv.dbo.CountSectionsById(*ti.ParentId)
+	if err != nil {
+		return srv.databaseError(err)
+	}
+
+	if n == 0 {
+		return jrm1.NewRpcErrorByUser(RpcErrorCode_ParentIsNotFound, RpcErrorMsg_ParentIsNotFound, nil)
+	}
+
+	re = srv.setSectionChildTypeH(*ti.ParentId, sct.SectionChildType_Forum)
+	if re != nil {
+		return re
+	}
+
+	// Restore the link.
+	var linkChildren *ul.UidList
+	linkChildren, err = srv.dbo.GetSectionChildrenById(*ti.ParentId)
+	if err != nil {
+		return srv.databaseError(err)
+	}
+
+	err = linkChildren.InsertItemAtPos(ti.ObjectId, ti.RestorePosition(linkChildren))
+	if err != nil {
+		srv.logError(err)
+		return jrm1.NewRpcErrorByUser(server2.RpcErrorCode_UidList, fmt.Sprintf(server2.RpcErrorMsgF_UidList, err.Error()), nil)
+	}
+
+	err = srv.dbo.RestoreForumFromTrash(ti.ObjectId)
+	if err != nil {
+		return srv.databaseError(err)
+	}
+
+	err = srv.dbo.DeleteForumFromTrash(ti.ObjectId)
+	if err != nil {
+		return srv.databaseError(err)
+	}
+
+	err = srv.dbo.SetSectionChildrenById(*ti.ParentId, linkChildren)
+	if err != nil {
+		return srv.databaseError(err)
+	}
+
+	return nil
+}
+
+// restoreThreadH restores a thread from the trash. This function must be
+// called under the write lock.
+func (srv *Server) restoreThreadH(ti *mm.TrashItem) (re *jrm1.RpcError) {
+	var n base2.Count
+	var err error
+	n, err = srv.dbo.CountThreadsById(ti.ObjectId)
+	if err != nil {
+		return srv.databaseError(err)
+	}
+
+	if n > 0 {
+		return jrm1.NewRpcErrorByUser(RpcErrorCode_ObjectIdIsAlreadyUsed, RpcErrorMsg_ObjectIdIsAlreadyUsed, nil)
+	}
+
+	// Ensure that the forum exists.
+	if ti.ParentId == nil {
+		return jrm1.NewRpcErrorByUser(RpcErrorCode_TrashItemIsDamaged, RpcErrorMsg_TrashItemIsDamaged, nil)
+	}
+
+	n, err = srv.dbo.CountForumsById(*ti.ParentId)
+	if err != nil {
+		return srv.databaseError(err)
+	}
+
+	if n == 0 {
+		return jrm1.NewRpcErrorByUser(RpcErrorCode_ParentIsNotFound, RpcErrorMsg_ParentIsNotFound, nil)
+	}
+
+	// Restore the link.
+	var linkThreads *ul.UidList
+	linkThreads, err = srv.dbo.GetForumThreadsById(*ti.ParentId)
+	if err != nil {
+		return srv.databaseError(err)
+	}
+
+	err = linkThreads.InsertItemAtPos(ti.ObjectId, ti.RestorePosition(linkThreads))
+	if err != nil {
+		srv.logError(err)
+		return jrm1.NewRpcErrorByUser(server2.RpcErrorCode_UidList, fmt.Sprintf(server2.RpcErrorMsgF_UidList, err.Error()), nil)
+	}
+
+	err = srv.dbo.RestoreThreadFromTrash(ti.ObjectId)
+	if err != nil {
+		return srv.databaseError(err)
+	}
+
+	err = srv.dbo.DeleteThreadFromTrash(ti.ObjectId)
+	if err != nil {
+		return srv.databaseError(err)
+	}
+
+	err = srv.dbo.SetForumThreadsById(*ti.ParentId, linkThreads)
+	if err != nil {
+		return srv.databaseError(err)
+	}
+
+	// Words of the thread's name were removed from the search index.
+	var thread derived2.IThread
+	thread, err = srv.dbo.GetThreadById(ti.ObjectId)
+	if err != nil {
+		return srv.databaseError(err)
+	}
+
+	err = srv.updateThreadSearchIndex(ti.ObjectId, *thread.GetNamePtr())
+	if err != nil {
+		return srv.databaseError(err)
+	}
+
+	return nil
+}
+
+// restoreMessageH restores a message from the trash. This function must be
+// called under the write lock.
+func (srv *Server) restoreMessageH(ti *mm.TrashItem) (re *jrm1.RpcError) {
+	var n base2.Count
+	var err error
+	n, err = srv.dbo.CountMessagesById(ti.ObjectId)
+	if err != nil {
+		return srv.databaseError(err)
+	}
+
+	if n > 0 {
+		return jrm1.NewRpcErrorByUser(RpcErrorCode_ObjectIdIsAlreadyUsed, RpcErrorMsg_ObjectIdIsAlreadyUsed, nil)
+	}
+
+	// Ensure that the thread exists.
+	if ti.ParentId == nil {
+		return jrm1.NewRpcErrorByUser(RpcErrorCode_TrashItemIsDamaged, RpcErrorMsg_TrashItemIsDamaged, nil)
+	}
+
+	n, err = srv.dbo.CountThreadsById(*ti.ParentId)
+	if err != nil {
+		return srv.databaseError(err)
+	}
+
+	if n == 0 {
+		return jrm1.NewRpcErrorByUser(RpcErrorCode_ParentIsNotFound, RpcErrorMsg_ParentIsNotFound, nil)
+	}
+
+	// Restore the link.
+	var linkMessages *ul.UidList
+	linkMessages, err = srv.dbo.GetThreadMessagesById(*ti.ParentId)
+	if err != nil {
+		return srv.databaseError(err)
+	}
+
+	err = linkMessages.InsertItemAtPos(ti.ObjectId, ti.RestorePosition(linkMessages))
+	if err != nil {
+		srv.logError(err)
+		return jrm1.NewRpcErrorByUser(server2.RpcErrorCode_UidList, fmt.Sprintf(server2.RpcErrorMsgF_UidList, err.Error()), nil)
+	}
+
+	err = srv.dbo.RestoreMessageFromTrash(ti.ObjectId)
+	if err != nil {
+		return srv.databaseError(err)
+	}
+
+	err = srv.dbo.DeleteMessageFromTrash(ti.ObjectId)
+	if err != nil {
+		return srv.databaseError(err)
+	}
+
+	err = srv.dbo.SetThreadMessagesById(*ti.ParentId, linkMessages)
+	if err != nil {
+		return srv.databaseError(err)
+	}
+
+	// Words of the message were removed from the search index.
+	var message derived2.IMessage
+	message, err = srv.dbo.GetMessageById(ti.ObjectId)
+	if err != nil {
+		return srv.databaseError(err)
+	}
+
+	err = srv.updateMessageSearchIndex(ti.ObjectId, *message.GetTextPtr())
+	if err != nil {
+		return srv.databaseError(err)
+	}
+
+	return nil
+}
+
+// repairDatabaseConsistencyH repairs the board's structure or, when changes
+// are not applied, makes a report of the repair.
+func (srv *Server) repairDatabaseConsistencyH(apply base2.Flag, userId base2.Id) (report *mm.ConsistencyReport, re *jrm1.RpcError) {
+	if apply {
+		srv.dbo.LockForWriting()
+		defer srv.dbo.UnlockAfterWriting()
+	} else {
+		srv.dbo.LockForReading()
+		defer srv.dbo.UnlockAfterReading()
+	}
+
+	report = mm.NewConsistencyReport()
+	report.IsApplied = apply
+
+	s, err := srv.readStructureH()
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+
+	report.Orphans, report.Problems = s.FindOrphans()
+
+	if !apply {
+		report.ListRepairs = s.RebuildLists(report.Orphans)
+		return report, nil
+	}
+
+	re = srv.relinkOrphansH(s, report, userId)
+	if re != nil {
+		return nil, re
+	}
+
+	// Lists are rebuilt from the new parents. Orphans which could not be
+	// re-linked are still left out.
+	s, err = srv.readStructureH()
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+
+	orphans, _ := s.FindOrphans()
+	report.ListRepairs = s.RebuildLists(orphans)
+
+	re = srv.applyListRepairsH(report.ListRepairs)
+	if re != nil {
+		return nil, re
+	}
+
+	return report, nil
+}
+
+// readStructureH reads links between all sections, forums, threads and
+// messages.
+func (srv *Server) readStructureH() (s *mm.Structure, err error) {
+	s = &mm.Structure{}
+
+	var sections []derived2.ISection
+	sections, err = srv.dbo.ReadSections()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, section := range sections {
+		s.Sections = append(s.Sections, mm.StructureNode{
+			Id:        section.GetId(),
+			ParentId:  section.GetParent(),
+			Children:  section.GetChildren(),
+			ChildType: byte(section.GetChildType().AsInt()),
+			Name:      string(*section.GetNamePtr()),
+		})
+	}
+
+	var forums []derived2.IForum
+	forums, err = srv.dbo.ReadForums()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, forum := range forums {
+		s.Forums = append(s.Forums, mm.StructureNode{
+			Id:       forum.GetId(),
+			ParentId: forum.GetSectionIdPtr(),
+			Children: forum.GetThreads(),
+			Name:     string(*forum.GetNamePtr()),
+		})
+	}
+
+	var threads []mm.ThreadLink
+	threads, err = srv.dbo.ReadThreadLinks()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, thread := range threads {
+		forumId := thread.ForumId
+		s.Threads = append(s.Threads, mm.StructureNode{
+			Id:       thread.Id,
+			ParentId: &forumId,
+			Children: thread.Messages,
+		})
+	}
+
+	var messages []mm.MessageLink
+	messages, err = srv.dbo.ReadMessageLinks()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, message := range messages {
+		threadId := message.ThreadId
+		s.Messages = append(s.Messages, mm.StructureNode{
+			Id:       message.Id,
+			ParentId: &threadId,
+		})
+	}
+
+	return s, nil
+}
+
+// relinkOrphansH attaches orphans to the root section and to the Lost+Found
+// forum. This function must be called under the write lock.
+func (srv *Server) relinkOrphansH(s *mm.Structure, report *mm.ConsistencyReport, userId base2.Id) (re *jrm1.RpcError) {
+	o := report.Orphans
+
+	var err error
+	for _, sectionId := range o.Sections {
+		err = srv.dbo.SetSectionParentById(sectionId, *o.RootSectionId, userId)
+		if err != nil {
+			return srv.databaseError(err)
+		}
+	}
+
+	if !o.NeedsLostAndFound() {
+		return nil
+	}
+
+	if o.RootSectionId == nil {
+		report.Problems = append(report.Problems, mm.Problem_NoLostAndFound)
+		return nil
+	}
+
+	var laf *mm.LostAndFound
+	laf, re = srv.getLostAndFoundH(s, o, userId)
+	if re != nil {
+		return re
+	}
+
+	report.LostAndFound = laf
+
+	for _, forumId := range o.Forums {
+		err = srv.dbo.SetForumSectionById(forumId, laf.SectionId, userId)
+		if err != nil {
+			return srv.databaseError(err)
+		}
+	}
+
+	for _, threadId := range o.Threads {
+		err = srv.dbo.SetThreadForumById(threadId, laf.ForumId, userId)
+		if err != nil {
+			return srv.databaseError(err)
+		}
+	}
+
+	// Messages of a missing thread are collected into a new thread.
+	var newThreadIds = make(map[base2.Id]base2.Id)
+	for _, ml := range o.Messages {
+		newThreadId, ok := newThreadIds[ml.ThreadId]
+		if !ok {
+			threadName := base2.Text(fmt.Sprintf(mm.LostAndFoundThreadNameF, ml.ThreadId))
+			newThreadId, err = srv.dbo.InsertNewThread(laf.ForumId, threadName, userId)
+			if err != nil {
+				return srv.databaseError(err)
+			}
+
+			err = srv.updateThreadSearchIndex(newThreadId, threadName)
+			if err != nil {
+				return srv.databaseError(err)
+			}
+
+			newThreadIds[ml.ThreadId] = newThreadId
+			laf.ThreadIds = append(laf.ThreadIds, newThreadId)
+		}
+
+		err = srv.dbo.SetMessageThreadById(ml.Id, newThreadId, userId)
+		if err != nil {
+			return srv.databaseError(err)
+		}
+	}
+
+	return nil
+}
+
+// getLostAndFoundH finds the Lost+Found forum or creates it. The forum is
+// created in the root section when the root section does not contain other
+// sections, otherwise it is created in the Lost+Found section of the root
+// section.
+func (srv *Server) getLostAndFoundH(s *mm.Structure, o *mm.Orphans, userId base2.Id) (laf *mm.LostAndFound, re *jrm1.RpcError) {
+	laf = &mm.LostAndFound{
+		ThreadIds: []base2.Id{},
+	}
+
+	for _, forum := range s.Forums {
+		if (forum.Name == mm.LostAndFoundName) && (forum.ParentId != nil) && !slices.Contains(o.Forums, forum.Id) {
+			laf.SectionId = *forum.ParentId
+			laf.ForumId = forum.Id
+			return laf, nil
+		}
+	}
+
+	rootHasSections := len(o.Sections) > 0
+	for _, section := range s.Sections {
+		if (section.ParentId != nil) && (*section.ParentId == *o.RootSectionId) {
+			rootHasSections = true
+			break
+		}
+	}
+
+	var err error
+	laf.SectionId = *o.RootSectionId
+	if rootHasSections {
+		laf.SectionId, err = srv.dbo.InsertNewSection(o.RootSectionId, mm.LostAndFoundName, userId)
+		if err != nil {
+			return nil, srv.databaseError(err)
+		}
+	}
+
+	laf.ForumId, err = srv.dbo.InsertNewForum(laf.SectionId, mm.LostAndFoundName, userId)
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+
+	return laf, nil
+}
+
+// applyListRepairsH writes rebuilt lists of children into the database. This
+// function must be called under the write lock.
+func (srv *Server) applyListRepairsH(lr *mm.ListRepairs) (re *jrm1.RpcError) {
+	var err error
+	for _, ctr := range lr.SectionChildTypes {
+		err = srv.dbo.SetSectionChildTypeById(ctr.SectionId, sct.NewSectionChildTypeWithValue(ev.NewEnumValue(ctr.NewChildType)))
+		if err != nil {
+			return srv.databaseError(err)
+		}
+	}
+
+	for _, r := range lr.SectionChildren {
+		err = srv.dbo.SetSectionChildrenById(r.ParentId, r.NewItems)
+		if err != nil {
+			return srv.databaseError(err)
+		}
+	}
+
+	for _, r := range lr.ForumThreads {
+		err = srv.dbo.SetForumThreadsById(r.ParentId, r.NewItems)
+		if err != nil {
+			return srv.databaseError(err)
+		}
+	}
+
+	for _, r := range lr.ThreadMessages {
+		err = srv.dbo.SetThreadMessagesById(r.ParentId, r.NewItems)
+		if err != nil {
+			return srv.databaseError(err)
+		}
+	}
+
+	return nil
+}
+
+// reportMessageDeletionH reports system events about a deleted message to
+// subscribers of the thread and to the author of the message.
+func (srv *Server) reportMessageDeletionH(message derived2.IMessage, userId *base2.Id) (re *jrm1.RpcError) {
+	seData := sed.NewSystemEventDataWithValue(
+		set.NewSystemEventTypeWithValue(ev.NewEnumValue(set.SystemEventType_ThreadMessageDeletion)),
+		message.GetThreadIdPtr(),
+		message.GetIdPtr(),
+		userId,
+		nil,
+	)
+
+	se, err := cm.NewSystemEventWithData(seData)
+	if err != nil {
+		return jrm1.NewRpcErrorByUser(server2.RpcErrorCode_SystemEvent, server2.RpcErrorMsg_SystemEvent, nil)
+	}
+
+	re = srv.reportSystemEvent(se)
+	if re != nil {
+		return re
+	}
+
+	seData = sed.NewSystemEventDataWithValue(
+		set.NewSystemEventTypeWithValue(ev.NewEnumValue(set.SystemEventType_MessageDeletion)),
+		message.GetThreadIdPtr(),
+		message.GetIdPtr(),
+		userId,
+		message.GetEventData().GetCreatorUserIdPtr(),
+	)
+
+	se, err = cm.NewSystemEventWithData(seData)
+	if err != nil {
+		return jrm1.NewRpcErrorByUser(server2.RpcErrorCode_SystemEvent, server2.RpcErrorMsg_SystemEvent, nil)
+	}
+
+	return srv.reportSystemEvent(se)
+}
+
+// getReportedMessageH reads a message with its open reports. The user must
+// be able to moderate the message.
+func (srv *Server) getReportedMessageH(messageId base2.Id, userRoles *am.GetSelfRolesResult) (message derived2.IMessage, rm *mm.ReportedMessage, re *jrm1.RpcError) {
+	srv.dbo.LockForReading()
+	defer srv.dbo.UnlockAfterReading()
+
+	var err error
+	message, err = srv.dbo.GetMessageById(messageId)
+	if err != nil {
+		return nil, nil, srv.databaseError(err)
+	}
+
+	if message == nil {
+		return nil, nil, jrm1.NewRpcErrorByUser(RpcErrorCode_MessageIsNotFound, RpcErrorMsg_MessageIsNotFound, nil)
+	}
+
+	// Check permissions.
+	var scope *perm.Scope
+	scope, re = srv.getThreadScopeH(message.GetThreadId())
+	if re != nil {
+		return nil, nil, re
+	}
+
+	re = srv.mustHavePermission(userRoles, perm.Permission_Moderate, scope)
+	if re != nil {
+		return nil, nil, re
+	}
+
+	var reports []mm.MessageReport
+	reports, err = srv.dbo.ReadOpenReportsByMessageId(messageId)
+	if err != nil {
+		return nil, nil, srv.databaseError(err)
+	}
+
+	if len(reports) == 0 {
+		return nil, nil, jrm1.NewRpcErrorByUser(RpcErrorCode_MessageIsNotReported, RpcErrorMsg_MessageIsNotReported, nil)
+	}
+
+	rm = &mm.GroupMessageReports(reports)[0]
+	return message, rm, nil
+}
+
+// setThreadIsLockedH locks or unlocks a thread. Nothing is changed when the
+// thread is already in the requested state.
+func (srv *Server) setThreadIsLockedH(threadId base2.Id, isLocked base2.Flag, userId base2.Id) (isChanged bool, re *jrm1.RpcError) {
+	srv.dbo.LockForWriting()
+	defer srv.dbo.UnlockAfterWriting()
+
+	thread, err := srv.dbo.GetThreadById(threadId)
+	if err != nil {
+		return false, srv.databaseError(err)
+	}
+
+	if thread == nil {
+		return false, jrm1.NewRpcErrorByUser(RpcErrorCode_ThreadIsNotFound, RpcErrorMsg_ThreadIsNotFound, nil)
+	}
+
+	if thread.GetIsLocked() == isLocked {
+		return false, nil
+	}
+
+	err = srv.dbo.SetThreadIsLockedById(threadId, isLocked, userId)
+	if err != nil {
+		return false, srv.databaseError(err)
+	}
+
+	return true, nil
+}
+
+// banUserH bans a user in the ACM module on behalf of the RPC caller.
+func (srv *Server) banUserH(auth *rpc3.Auth, userId base2.Id) (re *jrm1.RpcError) {
+	params := am.BanUserParams{
+		CommonParams: rpc3.CommonParams{
+			Auth: auth,
+		},
+		UserId: userId,
+	}
+	result := new(am.BanUserResult)
+
+	var err error
+	re, err = srv.acmServiceClient.MakeRequest(context.Background(), ac.FuncBanUser, params, result)
+	if err != nil {
+		srv.logError(err)
+		return jrm1.NewRpcErrorByUser(server2.RpcErrorCode_RPCCall, server2.RpcErrorMsg_RPCCall, nil)
+	}
+	if re != nil {
+		return re
+	}
+
+	return nil
+}
+
+// saveModerationDecisionH records a decision and resolves the reports which
+// were seen by the moderator.
+func (srv *Server) saveModerationDecisionH(md *mm.ModerationDecision, lastReportId base2.Id) (decisionId base2.Id, re *jrm1.RpcError) {
+	srv.dbo.LockForWriting()
+	defer srv.dbo.UnlockAfterWriting()
+
+	var err error
+	decisionId, err = srv.dbo.InsertModerationDecision(md)
+	if err != nil {
+		return 0, srv.databaseError(err)
+	}
+
+	err = srv.dbo.ResolveMessageReports(md.MessageId, decisionId, lastReportId)
+	if err != nil {
+		return 0, srv.databaseError(err)
+	}
+
+	return decisionId, nil
+}
+
+// notifyReportersH notifies each user who reported the message about the
+// decision of the moderator.
+func (srv *Server) notifyReportersH(rm *mm.ReportedMessage, threadId base2.Id, moderatorUserId base2.Id) (re *jrm1.RpcError) {
+	var se derived2.ISystemEvent
+	var err error
+	for _, reporterUserId := range rm.ListReporters() {
+		seData := sed.NewSystemEventDataWithValue(
+			set.NewSystemEventTypeWithValue(ev.NewEnumValue(set.SystemEventType_MessageReportResolved)),
+			&threadId,
+			&rm.MessageId,
+			&moderatorUserId,
+			&reporterUserId,
+		)
+
+		se, err = cm.NewSystemEventWithData(seData)
+		if err != nil {
+			return jrm1.NewRpcErrorByUser(server2.RpcErrorCode_SystemEvent, server2.RpcErrorMsg_SystemEvent, nil)
+		}
+
+		re = srv.reportSystemEvent(se)
+		if re != nil {
+			return re
+		}
+	}
+
+	return nil
+}
+
+// getForumThreadsInOrderH returns identifiers of the forum's threads in the
+// order of display. Pinned threads and announcements go first, other threads
+// keep the order of the forum's list.
+func (srv *Server) getForumThreadsInOrderH(forum derived2.IForum) (threadIds *ul.UidList, re *jrm1.RpcError) {
+	pinnedThreadIds, err := srv.dbo.ReadPinnedThreadIds(forum.GetId())
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+
+	return forum.GetThreads().PutItemsOnTop(pinnedThreadIds), nil
+}
+
+// mustModerateThreadH checks whether a user may moderate a thread.
+func (srv *Server) mustModerateThreadH(userRoles *am.GetSelfRolesResult, threadId base2.Id) (re *jrm1.RpcError) {
+	srv.dbo.LockForReading()
+	defer srv.dbo.UnlockAfterReading()
+
+	var scope *perm.Scope
+	scope, re = srv.getThreadScopeH(threadId)
+	if re != nil {
+		return re
+	}
+
+	return srv.mustHavePermission(userRoles, perm.Permission_Moderate, scope)
+}
+
+// reportThreadLockingH reports locking or unlocking of a thread to the
+// notification module.
+func (srv *Server) reportThreadLockingH(threadId base2.Id, isLocked base2.Flag, userId base2.Id) (re *jrm1.RpcError) {
+	var setValue byte = set.SystemEventType_ThreadUnlock
+	if isLocked {
+		setValue = set.SystemEventType_ThreadLock
+	}
+
+	seData := sed.NewSystemEventDataWithValue(
+		set.NewSystemEventTypeWithValue(ev.NewEnumValue(setValue)),
+		&threadId,
+		nil,
+		&userId,
+		nil,
+	)
+
+	se, err := cm.NewSystemEventWithData(seData)
+	if err != nil {
+		return jrm1.NewRpcErrorByUser(server2.RpcErrorCode_SystemEvent, server2.RpcErrorMsg_SystemEvent, nil)
+	}
+
+	return srv.reportSystemEvent(se)
+}
+
+// changeThreadIsPinnedH pins or unpins a thread. Nothing is changed when the
+// thread is already in the requested state.
+func (srv *Server) changeThreadIsPinnedH(threadId base2.Id, isPinned base2.Flag, userId base2.Id) (isChanged bool, re *jrm1.RpcError) {
+	srv.dbo.LockForWriting()
+	defer srv.dbo.UnlockAfterWriting()
+
+	thread, err := srv.dbo.GetThreadById(threadId)
+	if err != nil {
+		return false, srv.databaseError(err)
+	}
+
+	if thread == nil {
+		return false, jrm1.NewRpcErrorByUser(RpcErrorCode_ThreadIsNotFound, RpcErrorMsg_ThreadIsNotFound, nil)
+	}
+
+	if thread.GetIsPinned() == isPinned {
+		return false, nil
+	}
+
+	err = srv.dbo.SetThreadIsPinnedById(threadId, isPinned, userId)
+	if err != nil {
+		return false, srv.databaseError(err)
+	}
+
+	return true, nil
+}
+
+// changeThreadIsAnnouncementH makes a thread an announcement or an ordinary
+// thread. Announcements are managed by moderators of the whole section, so
+// permissions are checked here, in the section's scope.
+func (srv *Server) changeThreadIsAnnouncementH(threadId base2.Id, isAnnouncement base2.Flag, userRoles *am.GetSelfRolesResult) (isChanged bool, re *jrm1.RpcError) {
+	srv.dbo.LockForWriting()
+	defer srv.dbo.UnlockAfterWriting()
+
+	thread, err := srv.dbo.GetThreadById(threadId)
+	if err != nil {
+		return false, srv.databaseError(err)
+	}
+
+	if thread == nil {
+		return false, jrm1.NewRpcErrorByUser(RpcErrorCode_ThreadIsNotFound, RpcErrorMsg_ThreadIsNotFound, nil)
+	}
+
+	var forum derived2.IForum
+	forum, err = srv.dbo.GetForumById(thread.GetForumId())
+	if err != nil {
+		return false, srv.databaseError(err)
+	}
+
+	if forum == nil {
+		return false, jrm1.NewRpcErrorByUser(RpcErrorCode_ForumIsNotFound, RpcErrorMsg_ForumIsNotFound, nil)
+	}
+
+	var scope *perm.Scope
+	scope, re = srv.getSectionScopeH(forum.GetSectionId())
+	if re != nil {
+		return false, re
+	}
+
+	re = srv.mustHavePermission(userRoles, perm.Permission_Moderate, scope)
+	if re != nil {
+		return false, re
+	}
+
+	if thread.GetIsAnnouncement() == isAnnouncement {
+		return false, nil
+	}
+
+	err = srv.dbo.SetThreadIsAnnouncementById(threadId, isAnnouncement, userRoles.User.GetUserParameters().GetId())
+	if err != nil {
+		return false, srv.databaseError(err)
+	}
+
+	return true, nil
+}
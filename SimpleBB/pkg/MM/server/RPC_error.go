@@ -8,78 +8,223 @@ import "net/http"
 
 // Codes.
 const (
-	RpcErrorCode_SectionNameIsNotSet      = 1
-	RpcErrorCode_RootSectionAlreadyExists = 2
-	RpcErrorCode_SectionIsNotFound        = 3
-	RpcErrorCode_SectionIdIsNotSet        = 4
-	RpcErrorCode_SectionHasChildren       = 5
-	RpcErrorCode_RootSectionCanNotBeMoved = 6
-	RpcErrorCode_ForumNameIsNotSet        = 7
-	RpcErrorCode_ForumIsNotFound          = 8
-	RpcErrorCode_ForumIdIsNotSet          = 9
-	RpcErrorCode_ForumHasThreads          = 10
-	RpcErrorCode_ThreadNameIsNotSet       = 11
-	RpcErrorCode_ThreadIdIsNotSet         = 12
-	RpcErrorCode_ThreadIsNotFound         = 13
-	RpcErrorCode_ThreadIsNotEmpty         = 14
-	RpcErrorCode_MessageTextIsNotSet      = 15
-	RpcErrorCode_MessageIdIsNotSet        = 16
-	RpcErrorCode_IncompatibleChildType    = 17
-	RpcErrorCode_MessageIsNotFound        = 18
-	RpcErrorCode_PageIsNotSet             = 19
-	RpcErrorCode_TestError                = 20
+	RpcErrorCode_SectionNameIsNotSet        = 1
+	RpcErrorCode_RootSectionAlreadyExists   = 2
+	RpcErrorCode_SectionIsNotFound          = 3
+	RpcErrorCode_SectionIdIsNotSet          = 4
+	RpcErrorCode_SectionHasChildren         = 5
+	RpcErrorCode_RootSectionCanNotBeMoved   = 6
+	RpcErrorCode_ForumNameIsNotSet          = 7
+	RpcErrorCode_ForumIsNotFound            = 8
+	RpcErrorCode_ForumIdIsNotSet            = 9
+	RpcErrorCode_ForumHasThreads            = 10
+	RpcErrorCode_ThreadNameIsNotSet         = 11
+	RpcErrorCode_ThreadIdIsNotSet           = 12
+	RpcErrorCode_ThreadIsNotFound           = 13
+	RpcErrorCode_ThreadIsNotEmpty           = 14
+	RpcErrorCode_MessageTextIsNotSet        = 15
+	RpcErrorCode_MessageIdIsNotSet          = 16
+	RpcErrorCode_IncompatibleChildType      = 17
+	RpcErrorCode_MessageIsNotFound          = 18
+	RpcErrorCode_PageIsNotSet               = 19
+	RpcErrorCode_TestError                  = 20
+	RpcErrorCode_SearchTextIsNotSet         = 21
+	RpcErrorCode_SearchTextHasNoWords       = 22
+	RpcErrorCode_TimeRangeIsNotValid        = 23
+	RpcErrorCode_RevisionIdIsNotSet         = 24
+	RpcErrorCode_RevisionIsNotFound         = 25
+	RpcErrorCode_RevisionIsDamaged          = 26
+	RpcErrorCode_ConversationIdIsNotSet     = 27
+	RpcErrorCode_ConversationIsNotFound     = 28
+	RpcErrorCode_SubjectIsNotSet            = 29
+	RpcErrorCode_RecipientsAreNotSet        = 30
+	RpcErrorCode_TooManyRecipients          = 31
+	RpcErrorCode_UserIdIsNotSet             = 32
+	RpcErrorCode_UserIsNotFound             = 33
+	RpcErrorCode_UserIsBlocked              = 34
+	RpcErrorCode_SelfBlock                  = 35
+	RpcErrorCode_EmojiIsNotSet              = 36
+	RpcErrorCode_ReactionIsNotAllowed       = 37
+	RpcErrorCode_SelfReaction               = 38
+	RpcErrorCode_ReactionAlreadyExists      = 39
+	RpcErrorCode_ReactionIsNotFound         = 40
+	RpcErrorCode_PollQuestionIsNotSet       = 41
+	RpcErrorCode_PollOptionsAreNotValid     = 42
+	RpcErrorCode_PollClosingTimeIsNotValid  = 43
+	RpcErrorCode_PollIsNotFound             = 44
+	RpcErrorCode_PollIsClosed               = 45
+	RpcErrorCode_PollVoteIsNotValid         = 46
+	RpcErrorCode_PollVoteAlreadyExists      = 47
+	RpcErrorCode_PollVoteIsNotFound         = 48
+	RpcErrorCode_TooManyAttachments         = 49
+	RpcErrorCode_AttachmentNameIsNotValid   = 50
+	RpcErrorCode_AttachmentSizeIsNotValid   = 51
+	RpcErrorCode_AttachmentTypeIsNotAllowed = 52
+	RpcErrorCode_AttachmentIdIsNotSet       = 53
+	RpcErrorCode_AttachmentIsNotFound       = 54
+	RpcErrorCode_ThumbnailIsNotFound        = 55
+	RpcErrorCode_BlobStore                  = 56
+	RpcErrorCode_TrashItemIdIsNotSet        = 57
+	RpcErrorCode_TrashItemIsNotFound        = 58
+	RpcErrorCode_TrashItemIsDamaged         = 59
+	RpcErrorCode_ParentIsNotFound           = 60
+	RpcErrorCode_ObjectIdIsAlreadyUsed      = 61
+	RpcErrorCode_MessageIsAlreadyReported   = 62
+	RpcErrorCode_OwnMessageCanNotBeReported = 63
+	RpcErrorCode_ReportReasonIsNotValid     = 64
+	RpcErrorCode_ReportCommentIsTooLong     = 65
+	RpcErrorCode_ModerationActionIsNotValid = 66
+	RpcErrorCode_MessageIsNotReported       = 67
+	RpcErrorCode_ThreadIsLocked             = 68
 )
 
 // Messages.
 const (
-	RpcErrorMsg_SectionNameIsNotSet      = "section name is not set"
-	RpcErrorMsg_RootSectionAlreadyExists = "root section already exists"
-	RpcErrorMsg_SectionIsNotFound        = "section is not found"
-	RpcErrorMsg_SectionIdIsNotSet        = "section ID is not set"
-	RpcErrorMsg_SectionHasChildren       = "section has children"
-	RpcErrorMsg_RootSectionCanNotBeMoved = "root section can not be moved"
-	RpcErrorMsg_ForumNameIsNotSet        = "forum name is not set"
-	RpcErrorMsg_ForumIsNotFound          = "forum is not found"
-	RpcErrorMsg_ForumIdIsNotSet          = "forum ID is not set"
-	RpcErrorMsg_ForumHasThreads          = "forum has threads"
-	RpcErrorMsg_ThreadNameIsNotSet       = "thread name is not set"
-	RpcErrorMsg_ThreadIdIsNotSet         = "thread ID is not set"
-	RpcErrorMsg_ThreadIsNotFound         = "thread is not found"
-	RpcErrorMsg_ThreadIsNotEmpty         = "thread is not empty"
-	RpcErrorMsg_MessageTextIsNotSet      = "message text is not set"
-	RpcErrorMsg_MessageIdIsNotSet        = "message ID is not set"
-	RpcErrorMsg_IncompatibleChildType    = "incompatible child type"
-	RpcErrorMsg_MessageIsNotFound        = "message is not found"
-	RpcErrorMsg_PageIsNotSet             = "page is not set"
-	RpcErrorMsgF_TestError               = "test error: %s"
+	RpcErrorMsg_SectionNameIsNotSet        = "section name is not set"
+	RpcErrorMsg_RootSectionAlreadyExists   = "root section already exists"
+	RpcErrorMsg_SectionIsNotFound          = "section is not found"
+	RpcErrorMsg_SectionIdIsNotSet          = "section ID is not set"
+	RpcErrorMsg_SectionHasChildren         = "section has children"
+	RpcErrorMsg_RootSectionCanNotBeMoved   = "root section can not be moved"
+	RpcErrorMsg_ForumNameIsNotSet          = "forum name is not set"
+	RpcErrorMsg_ForumIsNotFound            = "forum is not found"
+	RpcErrorMsg_ForumIdIsNotSet            = "forum ID is not set"
+	RpcErrorMsg_ForumHasThreads            = "forum has threads"
+	RpcErrorMsg_ThreadNameIsNotSet         = "thread name is not set"
+	RpcErrorMsg_ThreadIdIsNotSet           = "thread ID is not set"
+	RpcErrorMsg_ThreadIsNotFound           = "thread is not found"
+	RpcErrorMsg_ThreadIsNotEmpty           = "thread is not empty"
+	RpcErrorMsg_MessageTextIsNotSet        = "message text is not set"
+	RpcErrorMsg_MessageIdIsNotSet          = "message ID is not set"
+	RpcErrorMsg_IncompatibleChildType      = "incompatible child type"
+	RpcErrorMsg_MessageIsNotFound          = "message is not found"
+	RpcErrorMsg_PageIsNotSet               = "page is not set"
+	RpcErrorMsgF_TestError                 = "test error: %s"
+	RpcErrorMsg_SearchTextIsNotSet         = "search text is not set"
+	RpcErrorMsg_SearchTextHasNoWords       = "search text has no words suitable for search"
+	RpcErrorMsg_TimeRangeIsNotValid        = "time range is not valid"
+	RpcErrorMsg_RevisionIdIsNotSet         = "revision ID is not set"
+	RpcErrorMsg_RevisionIsNotFound         = "revision is not found"
+	RpcErrorMsg_RevisionIsDamaged          = "revision is damaged"
+	RpcErrorMsg_ConversationIdIsNotSet     = "conversation ID is not set"
+	RpcErrorMsg_ConversationIsNotFound     = "conversation is not found"
+	RpcErrorMsg_SubjectIsNotSet            = "subject is not set"
+	RpcErrorMsg_RecipientsAreNotSet        = "recipients are not set"
+	RpcErrorMsg_TooManyRecipients          = "too many recipients"
+	RpcErrorMsg_UserIdIsNotSet             = "user ID is not set"
+	RpcErrorMsg_UserIsNotFound             = "user is not found"
+	RpcErrorMsg_UserIsBlocked              = "user does not accept your private messages"
+	RpcErrorMsg_SelfBlock                  = "users can not block themselves"
+	RpcErrorMsg_EmojiIsNotSet              = "emoji is not set"
+	RpcErrorMsg_ReactionIsNotAllowed       = "reaction is not allowed"
+	RpcErrorMsg_SelfReaction               = "users can not react to their own messages"
+	RpcErrorMsg_ReactionAlreadyExists      = "reaction already exists"
+	RpcErrorMsg_ReactionIsNotFound         = "reaction is not found"
+	RpcErrorMsg_PollQuestionIsNotSet       = "poll question is not set"
+	RpcErrorMsg_PollOptionsAreNotValid     = "poll options are not valid"
+	RpcErrorMsg_PollClosingTimeIsNotValid  = "poll closing time is not valid"
+	RpcErrorMsg_PollIsNotFound             = "poll is not found"
+	RpcErrorMsg_PollIsClosed               = "poll is closed"
+	RpcErrorMsg_PollVoteIsNotValid         = "poll vote is not valid"
+	RpcErrorMsg_PollVoteAlreadyExists      = "user has already voted in the poll"
+	RpcErrorMsg_PollVoteIsNotFound         = "user has not voted in the poll"
+	RpcErrorMsg_TooManyAttachments         = "too many attachments"
+	RpcErrorMsg_AttachmentNameIsNotValid   = "attachment name is not valid"
+	RpcErrorMsg_AttachmentSizeIsNotValid   = "attachment size is not valid"
+	RpcErrorMsg_AttachmentTypeIsNotAllowed = "attachment type is not allowed"
+	RpcErrorMsg_AttachmentIdIsNotSet       = "attachment ID is not set"
+	RpcErrorMsg_AttachmentIsNotFound       = "attachment is not found"
+	RpcErrorMsg_ThumbnailIsNotFound        = "thumbnail is not found"
+	RpcErrorMsg_BlobStore                  = "blob store error"
+	RpcErrorMsg_TrashItemIdIsNotSet        = "trash item ID is not set"
+	RpcErrorMsg_TrashItemIsNotFound        = "trash item is not found"
+	RpcErrorMsg_TrashItemIsDamaged         = "trash item is damaged"
+	RpcErrorMsg_ParentIsNotFound           = "parent is not found"
+	RpcErrorMsg_ObjectIdIsAlreadyUsed      = "object ID is already used"
+	RpcErrorMsg_MessageIsAlreadyReported   = "message is already reported"
+	RpcErrorMsg_OwnMessageCanNotBeReported = "own message can not be reported"
+	RpcErrorMsg_ReportReasonIsNotValid     = "report reason is not valid"
+	RpcErrorMsg_ReportCommentIsTooLong     = "report comment is too long"
+	RpcErrorMsg_ModerationActionIsNotValid = "moderation action is not valid"
+	RpcErrorMsg_MessageIsNotReported       = "message is not reported"
+	RpcErrorMsg_ThreadIsLocked             = "thread is locked"
 )
 
 // Unique HTTP status codes used in the map:
 // - 400 (Bad request);
+// - 403 (Forbidden);
 // - 404 (Not found);
 // - 409 (Conflict);
 // - 500 (Internal server error).
 func GetMapOfHttpStatusCodesByRpcErrorCodes() map[int]int {
 	return map[int]int{
-		RpcErrorCode_SectionNameIsNotSet:      http.StatusBadRequest,
-		RpcErrorCode_RootSectionAlreadyExists: http.StatusConflict,
-		RpcErrorCode_SectionIsNotFound:        http.StatusNotFound,
-		RpcErrorCode_SectionIdIsNotSet:        http.StatusBadRequest,
-		RpcErrorCode_SectionHasChildren:       http.StatusConflict,
-		RpcErrorCode_RootSectionCanNotBeMoved: http.StatusConflict,
-		RpcErrorCode_ForumNameIsNotSet:        http.StatusBadRequest,
-		RpcErrorCode_ForumIsNotFound:          http.StatusNotFound,
-		RpcErrorCode_ForumIdIsNotSet:          http.StatusBadRequest,
-		RpcErrorCode_ForumHasThreads:          http.StatusConflict,
-		RpcErrorCode_ThreadNameIsNotSet:       http.StatusBadRequest,
-		RpcErrorCode_ThreadIdIsNotSet:         http.StatusBadRequest,
-		RpcErrorCode_ThreadIsNotFound:         http.StatusNotFound,
-		RpcErrorCode_ThreadIsNotEmpty:         http.StatusConflict,
-		RpcErrorCode_MessageTextIsNotSet:      http.StatusBadRequest,
-		RpcErrorCode_MessageIdIsNotSet:        http.StatusBadRequest,
-		RpcErrorCode_IncompatibleChildType:    http.StatusConflict,
-		RpcErrorCode_MessageIsNotFound:        http.StatusNotFound,
-		RpcErrorCode_PageIsNotSet:             http.StatusBadRequest,
-		RpcErrorCode_TestError:                http.StatusInternalServerError,
+		RpcErrorCode_SectionNameIsNotSet:        http.StatusBadRequest,
+		RpcErrorCode_RootSectionAlreadyExists:   http.StatusConflict,
+		RpcErrorCode_SectionIsNotFound:          http.StatusNotFound,
+		RpcErrorCode_SectionIdIsNotSet:          http.StatusBadRequest,
+		RpcErrorCode_SectionHasChildren:         http.StatusConflict,
+		RpcErrorCode_RootSectionCanNotBeMoved:   http.StatusConflict,
+		RpcErrorCode_ForumNameIsNotSet:          http.StatusBadRequest,
+		RpcErrorCode_ForumIsNotFound:            http.StatusNotFound,
+		RpcErrorCode_ForumIdIsNotSet:            http.StatusBadRequest,
+		RpcErrorCode_ForumHasThreads:            http.StatusConflict,
+		RpcErrorCode_ThreadNameIsNotSet:         http.StatusBadRequest,
+		RpcErrorCode_ThreadIdIsNotSet:           http.StatusBadRequest,
+		RpcErrorCode_ThreadIsNotFound:           http.StatusNotFound,
+		RpcErrorCode_ThreadIsNotEmpty:           http.StatusConflict,
+		RpcErrorCode_MessageTextIsNotSet:        http.StatusBadRequest,
+		RpcErrorCode_MessageIdIsNotSet:          http.StatusBadRequest,
+		RpcErrorCode_IncompatibleChildType:      http.StatusConflict,
+		RpcErrorCode_MessageIsNotFound:          http.StatusNotFound,
+		RpcErrorCode_PageIsNotSet:               http.StatusBadRequest,
+		RpcErrorCode_TestError:                  http.StatusInternalServerError,
+		RpcErrorCode_SearchTextIsNotSet:         http.StatusBadRequest,
+		RpcErrorCode_SearchTextHasNoWords:       http.StatusBadRequest,
+		RpcErrorCode_TimeRangeIsNotValid:        http.StatusBadRequest,
+		RpcErrorCode_RevisionIdIsNotSet:         http.StatusBadRequest,
+		RpcErrorCode_RevisionIsNotFound:         http.StatusNotFound,
+		RpcErrorCode_RevisionIsDamaged:          http.StatusInternalServerError,
+		RpcErrorCode_ConversationIdIsNotSet:     http.StatusBadRequest,
+		RpcErrorCode_ConversationIsNotFound:     http.StatusNotFound,
+		RpcErrorCode_SubjectIsNotSet:            http.StatusBadRequest,
+		RpcErrorCode_RecipientsAreNotSet:        http.StatusBadRequest,
+		RpcErrorCode_TooManyRecipients:          http.StatusBadRequest,
+		RpcErrorCode_UserIdIsNotSet:             http.StatusBadRequest,
+		RpcErrorCode_UserIsNotFound:             http.StatusNotFound,
+		RpcErrorCode_UserIsBlocked:              http.StatusForbidden,
+		RpcErrorCode_SelfBlock:                  http.StatusBadRequest,
+		RpcErrorCode_EmojiIsNotSet:              http.StatusBadRequest,
+		RpcErrorCode_ReactionIsNotAllowed:       http.StatusBadRequest,
+		RpcErrorCode_SelfReaction:               http.StatusBadRequest,
+		RpcErrorCode_ReactionAlreadyExists:      http.StatusConflict,
+		RpcErrorCode_ReactionIsNotFound:         http.StatusNotFound,
+		RpcErrorCode_PollQuestionIsNotSet:       http.StatusBadRequest,
+		RpcErrorCode_PollOptionsAreNotValid:     http.StatusBadRequest,
+		RpcErrorCode_PollClosingTimeIsNotValid:  http.StatusBadRequest,
+		RpcErrorCode_PollIsNotFound:             http.StatusNotFound,
+		RpcErrorCode_PollIsClosed:               http.StatusConflict,
+		RpcErrorCode_PollVoteIsNotValid:         http.StatusBadRequest,
+		RpcErrorCode_PollVoteAlreadyExists:      http.StatusConflict,
+		RpcErrorCode_PollVoteIsNotFound:         http.StatusNotFound,
+		RpcErrorCode_TooManyAttachments:         http.StatusBadRequest,
+		RpcErrorCode_AttachmentNameIsNotValid:   http.StatusBadRequest,
+		RpcErrorCode_AttachmentSizeIsNotValid:   http.StatusBadRequest,
+		RpcErrorCode_AttachmentTypeIsNotAllowed: http.StatusBadRequest,
+		RpcErrorCode_AttachmentIdIsNotSet:       http.StatusBadRequest,
+		RpcErrorCode_AttachmentIsNotFound:       http.StatusNotFound,
+		RpcErrorCode_ThumbnailIsNotFound:        http.StatusNotFound,
+		RpcErrorCode_BlobStore:                  http.StatusInternalServerError,
+		RpcErrorCode_TrashItemIdIsNotSet:        http.StatusBadRequest,
+		RpcErrorCode_TrashItemIsNotFound:        http.StatusNotFound,
+		RpcErrorCode_TrashItemIsDamaged:         http.StatusInternalServerError,
+		RpcErrorCode_ParentIsNotFound:           http.StatusConflict,
+		RpcErrorCode_ObjectIdIsAlreadyUsed:      http.StatusConflict,
+		RpcErrorCode_MessageIsAlreadyReported:   http.StatusConflict,
+		RpcErrorCode_OwnMessageCanNotBeReported: http.StatusBadRequest,
+		RpcErrorCode_ReportReasonIsNotValid:     http.StatusBadRequest,
+		RpcErrorCode_ReportCommentIsTooLong:     http.StatusBadRequest,
+		RpcErrorCode_ModerationActionIsNotValid: http.StatusBadRequest,
+		RpcErrorCode_MessageIsNotReported:       http.StatusNotFound,
+		RpcErrorCode_ThreadIsLocked:             http.StatusConflict,
 	}
 }
@@ -6,6 +6,8 @@ import (
 	rpc2 "github.com/vault-thirteen/SimpleBB/pkg/MM/rpc"
 	"github.com/vault-thirteen/SimpleBB/pkg/common/interfaces/derived1"
 	"github.com/vault-thirteen/SimpleBB/pkg/common/interfaces/derived2"
+	"github.com/vault-thirteen/SimpleBB/pkg/common/models/AuditLog"
+	"github.com/vault-thirteen/SimpleBB/pkg/common/models/Permission"
 	"github.com/vault-thirteen/SimpleBB/pkg/common/models/UidList"
 	base2 "github.com/vault-thirteen/SimpleBB/pkg/common/models/base"
 	ev "github.com/vault-thirteen/SimpleBB/pkg/common/models/complex/EnumValue"
@@ -19,8 +21,10 @@ import (
 	c "github.com/vault-thirteen/SimpleBB/pkg/common/models/server"
 	"github.com/vault-thirteen/SimpleBB/pkg/common/models/simple"
 	"sync"
+	"time"
 
 	jrm1 "github.com/vault-thirteen/JSON-RPC-M1"
+	"github.com/vault-thirteen/SimpleBB/pkg/MM/blob"
 	mm "github.com/vault-thirteen/SimpleBB/pkg/MM/models"
 )
 
@@ -46,6 +50,9 @@ func (srv *Server) addSection(p *rpc2.AddSectionParams) (result *rpc2.AddSection
 		return nil, jrm1.NewRpcErrorByUser(c.RpcErrorCode_Permission, c.RpcErrorMsg_Permission, nil)
 	}
 
+	var auditRecord *al.Record
+	defer func() { srv.audit(p.Auth, auditRecord) }()
+
 	srv.dbo.LockForWriting()
 	defer srv.dbo.UnlockAfterWriting()
 
@@ -69,6 +76,14 @@ func (srv *Server) addSection(p *rpc2.AddSectionParams) (result *rpc2.AddSection
 			return nil, srv.databaseError(err)
 		}
 
+		auditRecord = &al.Record{
+			ActorUserId: userRoles.User.GetUserParameters().GetId(),
+			Action:      al.Action_AddSection,
+			TargetType:  al.TargetType_Section,
+			TargetId:    insertedSectionId,
+			ValueAfter:  al.TextValue(p.Name),
+		}
+
 		result = &rpc2.AddSectionResult{
 			SectionId: insertedSectionId,
 		}
@@ -129,6 +144,14 @@ func (srv *Server) addSection(p *rpc2.AddSectionParams) (result *rpc2.AddSection
 		return nil, srv.databaseError(err)
 	}
 
+	auditRecord = &al.Record{
+		ActorUserId: userRoles.User.GetUserParameters().GetId(),
+		Action:      al.Action_AddSection,
+		TargetType:  al.TargetType_Section,
+		TargetId:    insertedSectionId,
+		ValueAfter:  al.TextValue(p.Name),
+	}
+
 	result = &rpc2.AddSectionResult{
 		SectionId: insertedSectionId,
 	}
@@ -158,17 +181,20 @@ func (srv *Server) changeSectionName(p *rpc2.ChangeSectionNameParams) (result *r
 		return nil, jrm1.NewRpcErrorByUser(c.RpcErrorCode_Permission, c.RpcErrorMsg_Permission, nil)
 	}
 
+	var auditRecord *al.Record
+	defer func() { srv.audit(p.Auth, auditRecord) }()
+
 	srv.dbo.LockForWriting()
 	defer srv.dbo.UnlockAfterWriting()
 
-	var n base2.Count
+	var section derived2.ISection
 	var err error
-	n, err = srv.dbo.CountSectionsById(p.SectionId)
+	section, err = srv.dbo.GetSectionById(p.SectionId)
 	if err != nil {
 		return nil, srv.databaseError(err)
 	}
 
-	if n == 0 {
+	if section == nil {
 		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_SectionIsNotFound, RpcErrorMsg_SectionIsNotFound, nil)
 	}
 
@@ -177,6 +203,15 @@ func (srv *Server) changeSectionName(p *rpc2.ChangeSectionNameParams) (result *r
 		return nil, srv.databaseError(err)
 	}
 
+	auditRecord = &al.Record{
+		ActorUserId: userRoles.User.GetUserParameters().GetId(),
+		Action:      al.Action_ChangeSectionName,
+		TargetType:  al.TargetType_Section,
+		TargetId:    p.SectionId,
+		ValueBefore: al.TextValue(*section.GetNamePtr()),
+		ValueAfter:  al.TextValue(p.Name),
+	}
+
 	result = &rpc2.ChangeSectionNameResult{
 		Success: rpc3.Success{
 			OK: true,
@@ -207,6 +242,9 @@ func (srv *Server) changeSectionParent(p *rpc2.ChangeSectionParentParams) (resul
 		return nil, jrm1.NewRpcErrorByUser(c.RpcErrorCode_Permission, c.RpcErrorMsg_Permission, nil)
 	}
 
+	var auditRecord *al.Record
+	defer func() { srv.audit(p.Auth, auditRecord) }()
+
 	srv.dbo.LockForWriting()
 	defer srv.dbo.UnlockAfterWriting()
 
@@ -319,6 +357,15 @@ func (srv *Server) changeSectionParent(p *rpc2.ChangeSectionParentParams) (resul
 		}
 	}
 
+	auditRecord = &al.Record{
+		ActorUserId: userRoles.User.GetUserParameters().GetId(),
+		Action:      al.Action_ChangeSectionParent,
+		TargetType:  al.TargetType_Section,
+		TargetId:    p.SectionId,
+		ValueBefore: al.IdValue(*oldParent),
+		ValueAfter:  al.IdValue(p.Parent),
+	}
+
 	result = &rpc2.ChangeSectionParentResult{
 		Success: rpc3.Success{
 			OK: true,
@@ -327,8 +374,8 @@ func (srv *Server) changeSectionParent(p *rpc2.ChangeSectionParentParams) (resul
 	return result, nil
 }
 
-// getSection reads a section.
-func (srv *Server) getSection(p *rpc2.GetSectionParams) (result *rpc2.GetSectionResult, re *jrm1.RpcError) {
+// changeSectionPrivacy makes a section private or public.
+func (srv *Server) changeSectionPrivacy(p *rpc2.ChangeSectionPrivacyParams) (result *rpc2.ChangeSectionPrivacyResult, re *jrm1.RpcError) {
 	// Check parameters.
 	if p.SectionId == 0 {
 		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_SectionIdIsNotSet, RpcErrorMsg_SectionIdIsNotSet, nil)
@@ -341,10 +388,62 @@ func (srv *Server) getSection(p *rpc2.GetSectionParams) (result *rpc2.GetSection
 	}
 
 	// Check permissions.
-	if !userRoles.User.GetUserParameters().GetRoles().IsReader {
+	if !userRoles.User.GetUserParameters().GetRoles().IsAdministrator {
 		return nil, jrm1.NewRpcErrorByUser(c.RpcErrorCode_Permission, c.RpcErrorMsg_Permission, nil)
 	}
 
+	var auditRecord *al.Record
+	defer func() { srv.audit(p.Auth, auditRecord) }()
+
+	srv.dbo.LockForWriting()
+	defer srv.dbo.UnlockAfterWriting()
+
+	var section derived2.ISection
+	var err error
+	section, err = srv.dbo.GetSectionById(p.SectionId)
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+
+	if section == nil {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_SectionIsNotFound, RpcErrorMsg_SectionIsNotFound, nil)
+	}
+
+	err = srv.dbo.SetSectionIsPrivateById(p.SectionId, p.IsPrivate, userRoles.User.GetUserParameters().GetId())
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+
+	auditRecord = &al.Record{
+		ActorUserId: userRoles.User.GetUserParameters().GetId(),
+		Action:      al.Action_ChangeSectionPrivacy,
+		TargetType:  al.TargetType_Section,
+		TargetId:    p.SectionId,
+		ValueBefore: al.FlagValue(section.GetIsPrivate()),
+		ValueAfter:  al.FlagValue(p.IsPrivate),
+	}
+
+	result = &rpc2.ChangeSectionPrivacyResult{
+		Success: rpc3.Success{
+			OK: true,
+		},
+	}
+	return result, nil
+}
+
+// getSection reads a section.
+func (srv *Server) getSection(p *rpc2.GetSectionParams) (result *rpc2.GetSectionResult, re *jrm1.RpcError) {
+	// Check parameters.
+	if p.SectionId == 0 {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_SectionIdIsNotSet, RpcErrorMsg_SectionIdIsNotSet, nil)
+	}
+
+	var userRoles *am.GetSelfRolesResult
+	userRoles, re = srv.mustBeAnAuthToken(p.Auth)
+	if re != nil {
+		return nil, re
+	}
+
 	srv.dbo.LockForReading()
 	defer srv.dbo.UnlockAfterReading()
 
@@ -359,6 +458,18 @@ func (srv *Server) getSection(p *rpc2.GetSectionParams) (result *rpc2.GetSection
 		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_SectionIsNotFound, RpcErrorMsg_SectionIsNotFound, nil)
 	}
 
+	// Check permissions.
+	var scope *perm.Scope
+	scope, re = srv.getSectionScopeH(p.SectionId)
+	if re != nil {
+		return nil, re
+	}
+
+	re = srv.mustHavePermission(userRoles, perm.Permission_Read, scope)
+	if re != nil {
+		return nil, re
+	}
+
 	result = &rpc2.GetSectionResult{
 		Section: section,
 	}
@@ -384,6 +495,9 @@ func (srv *Server) moveSectionUp(p *rpc2.MoveSectionUpParams) (result *rpc2.Move
 		return nil, jrm1.NewRpcErrorByUser(c.RpcErrorCode_Permission, c.RpcErrorMsg_Permission, nil)
 	}
 
+	var auditRecord *al.Record
+	defer func() { srv.audit(p.Auth, auditRecord) }()
+
 	srv.dbo.LockForWriting()
 	defer srv.dbo.UnlockAfterWriting()
 
@@ -440,6 +554,13 @@ func (srv *Server) moveSectionUp(p *rpc2.MoveSectionUpParams) (result *rpc2.Move
 		return nil, srv.databaseError(err)
 	}
 
+	auditRecord = &al.Record{
+		ActorUserId: userRoles.User.GetUserParameters().GetId(),
+		Action:      al.Action_MoveSectionUp,
+		TargetType:  al.TargetType_Section,
+		TargetId:    p.SectionId,
+	}
+
 	result = &rpc2.MoveSectionUpResult{
 		Success: rpc3.Success{
 			OK: true,
@@ -466,6 +587,9 @@ func (srv *Server) moveSectionDown(p *rpc2.MoveSectionDownParams) (result *rpc2.
 		return nil, jrm1.NewRpcErrorByUser(c.RpcErrorCode_Permission, c.RpcErrorMsg_Permission, nil)
 	}
 
+	var auditRecord *al.Record
+	defer func() { srv.audit(p.Auth, auditRecord) }()
+
 	srv.dbo.LockForWriting()
 	defer srv.dbo.UnlockAfterWriting()
 
@@ -522,6 +646,13 @@ func (srv *Server) moveSectionDown(p *rpc2.MoveSectionDownParams) (result *rpc2.
 		return nil, srv.databaseError(err)
 	}
 
+	auditRecord = &al.Record{
+		ActorUserId: userRoles.User.GetUserParameters().GetId(),
+		Action:      al.Action_MoveSectionDown,
+		TargetType:  al.TargetType_Section,
+		TargetId:    p.SectionId,
+	}
+
 	result = &rpc2.MoveSectionDownResult{
 		Success: rpc3.Success{
 			OK: true,
@@ -530,7 +661,7 @@ func (srv *Server) moveSectionDown(p *rpc2.MoveSectionDownParams) (result *rpc2.
 	return result, nil
 }
 
-// deleteSection removes a section.
+// deleteSection moves a section to the trash.
 func (srv *Server) deleteSection(p *rpc2.DeleteSectionParams) (result *rpc2.DeleteSectionResult, re *jrm1.RpcError) {
 	// Check parameters.
 	if p.SectionId == 0 {
@@ -548,6 +679,9 @@ func (srv *Server) deleteSection(p *rpc2.DeleteSectionParams) (result *rpc2.Dele
 		return nil, jrm1.NewRpcErrorByUser(c.RpcErrorCode_Permission, c.RpcErrorMsg_Permission, nil)
 	}
 
+	var auditRecord *al.Record
+	defer func() { srv.audit(p.Auth, auditRecord) }()
+
 	srv.dbo.LockForWriting()
 	defer srv.dbo.UnlockAfterWriting()
 
@@ -573,13 +707,23 @@ func (srv *Server) deleteSection(p *rpc2.DeleteSectionParams) (result *rpc2.Dele
 	}
 
 	// Update the link.
+	var linkSections *ul.UidList
 	if !isRootSection {
-		var linkSections *ul.UidList
 		linkSections, err = srv.dbo.GetSectionChildrenById(*section.GetParent())
 		if err != nil {
 			return nil, srv.databaseError(err)
 		}
+	}
+
+	// Remember the place of the section.
+	var ti *mm.TrashItem
+	ti, err = mm.NewTrashItem(mm.TrashObjectType_Section, p.SectionId, section.GetNamePtr().ToString(), section.GetParent(), linkSections, userRoles.User.GetUserParameters().GetId())
+	if err != nil {
+		srv.logError(err)
+		return nil, jrm1.NewRpcErrorByUser(c.RpcErrorCode_UidList, fmt.Sprintf(c.RpcErrorMsgF_UidList, err.Error()), nil)
+	}
 
+	if !isRootSection {
 		err = linkSections.RemoveItem(p.SectionId)
 		if err != nil {
 			srv.logError(err)
@@ -600,12 +744,20 @@ func (srv *Server) deleteSection(p *rpc2.DeleteSectionParams) (result *rpc2.Dele
 		}
 	}
 
-	// Delete the section.
-	err = srv.dbo.DeleteSectionById(p.SectionId)
+	// Move the section to the trash.
+	err = srv.moveToTrashH(ti)
 	if err != nil {
 		return nil, srv.databaseError(err)
 	}
 
+	auditRecord = &al.Record{
+		ActorUserId: userRoles.User.GetUserParameters().GetId(),
+		Action:      al.Action_DeleteSection,
+		TargetType:  al.TargetType_Section,
+		TargetId:    p.SectionId,
+		ValueBefore: al.TextValue(*section.GetNamePtr()),
+	}
+
 	result = &rpc2.DeleteSectionResult{
 		Success: rpc3.Success{
 			OK: true,
@@ -614,6 +766,42 @@ func (srv *Server) deleteSection(p *rpc2.DeleteSectionParams) (result *rpc2.Dele
 	return result, nil
 }
 
+// sectionExistsS checks whether the specified section exists or not. This
+// method is used by the system.
+func (srv *Server) sectionExistsS(p *rpc2.SectionExistsSParams) (result *rpc2.SectionExistsSResult, re *jrm1.RpcError) {
+	// Check parameters.
+	if p.SectionId == 0 {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_SectionIdIsNotSet, RpcErrorMsg_SectionIdIsNotSet, nil)
+	}
+
+	re = srv.mustBeNoAuth(p.Auth)
+	if re != nil {
+		return nil, re
+	}
+
+	// Check the DKey.
+	if !srv.dKeyI.CheckString(p.DKey.ToString()) {
+		return nil, jrm1.NewRpcErrorByUser(c.RpcErrorCode_Permission, c.RpcErrorMsg_Permission, nil)
+	}
+
+	srv.dbo.LockForReading()
+	defer srv.dbo.UnlockAfterReading()
+
+	// Count sections.
+	var n base2.Count
+	var err error
+	n, err = srv.dbo.CountSectionsById(p.SectionId)
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+
+	result = &rpc2.SectionExistsSResult{
+		Exists: n == 1,
+	}
+
+	return result, nil
+}
+
 // Forum.
 
 // addForum inserts a new forum into a section.
@@ -638,6 +826,9 @@ func (srv *Server) addForum(p *rpc2.AddForumParams) (result *rpc2.AddForumResult
 		return nil, jrm1.NewRpcErrorByUser(c.RpcErrorCode_Permission, c.RpcErrorMsg_Permission, nil)
 	}
 
+	var auditRecord *al.Record
+	defer func() { srv.audit(p.Auth, auditRecord) }()
+
 	srv.dbo.LockForWriting()
 	defer srv.dbo.UnlockAfterWriting()
 
@@ -693,6 +884,14 @@ func (srv *Server) addForum(p *rpc2.AddForumParams) (result *rpc2.AddForumResult
 		return nil, srv.databaseError(err)
 	}
 
+	auditRecord = &al.Record{
+		ActorUserId: userRoles.User.GetUserParameters().GetId(),
+		Action:      al.Action_AddForum,
+		TargetType:  al.TargetType_Forum,
+		TargetId:    insertedForumId,
+		ValueAfter:  al.TextValue(p.Name),
+	}
+
 	result = &rpc2.AddForumResult{
 		ForumId: insertedForumId,
 	}
@@ -722,17 +921,20 @@ func (srv *Server) changeForumName(p *rpc2.ChangeForumNameParams) (result *rpc2.
 		return nil, jrm1.NewRpcErrorByUser(c.RpcErrorCode_Permission, c.RpcErrorMsg_Permission, nil)
 	}
 
+	var auditRecord *al.Record
+	defer func() { srv.audit(p.Auth, auditRecord) }()
+
 	srv.dbo.LockForWriting()
 	defer srv.dbo.UnlockAfterWriting()
 
-	var n base2.Count
+	var forum derived2.IForum
 	var err error
-	n, err = srv.dbo.CountForumsById(p.ForumId)
+	forum, err = srv.dbo.GetForumById(p.ForumId)
 	if err != nil {
 		return nil, srv.databaseError(err)
 	}
 
-	if n == 0 {
+	if forum == nil {
 		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_ForumIsNotFound, RpcErrorMsg_ForumIsNotFound, nil)
 	}
 
@@ -741,6 +943,15 @@ func (srv *Server) changeForumName(p *rpc2.ChangeForumNameParams) (result *rpc2.
 		return nil, srv.databaseError(err)
 	}
 
+	auditRecord = &al.Record{
+		ActorUserId: userRoles.User.GetUserParameters().GetId(),
+		Action:      al.Action_ChangeForumName,
+		TargetType:  al.TargetType_Forum,
+		TargetId:    p.ForumId,
+		ValueBefore: al.TextValue(*forum.GetNamePtr()),
+		ValueAfter:  al.TextValue(p.Name),
+	}
+
 	result = &rpc2.ChangeForumNameResult{
 		Success: rpc3.Success{
 			OK: true,
@@ -771,6 +982,9 @@ func (srv *Server) changeForumSection(p *rpc2.ChangeForumSectionParams) (result
 		return nil, jrm1.NewRpcErrorByUser(c.RpcErrorCode_Permission, c.RpcErrorMsg_Permission, nil)
 	}
 
+	var auditRecord *al.Record
+	defer func() { srv.audit(p.Auth, auditRecord) }()
+
 	srv.dbo.LockForWriting()
 	defer srv.dbo.UnlockAfterWriting()
 
@@ -879,6 +1093,15 @@ func (srv *Server) changeForumSection(p *rpc2.ChangeForumSectionParams) (result
 		}
 	}
 
+	auditRecord = &al.Record{
+		ActorUserId: userRoles.User.GetUserParameters().GetId(),
+		Action:      al.Action_ChangeForumSection,
+		TargetType:  al.TargetType_Forum,
+		TargetId:    p.ForumId,
+		ValueBefore: al.IdValue(oldParent),
+		ValueAfter:  al.IdValue(p.SectionId),
+	}
+
 	result = &rpc2.ChangeForumSectionResult{
 		Success: rpc3.Success{
 			OK: true,
@@ -887,8 +1110,8 @@ func (srv *Server) changeForumSection(p *rpc2.ChangeForumSectionParams) (result
 	return result, nil
 }
 
-// getForum reads a forum.
-func (srv *Server) getForum(p *rpc2.GetForumParams) (result *rpc2.GetForumResult, re *jrm1.RpcError) {
+// changeForumReadOnly makes a forum read-only or writable.
+func (srv *Server) changeForumReadOnly(p *rpc2.ChangeForumReadOnlyParams) (result *rpc2.ChangeForumReadOnlyResult, re *jrm1.RpcError) {
 	// Check parameters.
 	if p.ForumId == 0 {
 		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_ForumIdIsNotSet, RpcErrorMsg_ForumIdIsNotSet, nil)
@@ -901,10 +1124,62 @@ func (srv *Server) getForum(p *rpc2.GetForumParams) (result *rpc2.GetForumResult
 	}
 
 	// Check permissions.
-	if !userRoles.User.GetUserParameters().GetRoles().IsReader {
+	if !userRoles.User.GetUserParameters().GetRoles().IsAdministrator {
 		return nil, jrm1.NewRpcErrorByUser(c.RpcErrorCode_Permission, c.RpcErrorMsg_Permission, nil)
 	}
 
+	var auditRecord *al.Record
+	defer func() { srv.audit(p.Auth, auditRecord) }()
+
+	srv.dbo.LockForWriting()
+	defer srv.dbo.UnlockAfterWriting()
+
+	var forum derived2.IForum
+	var err error
+	forum, err = srv.dbo.GetForumById(p.ForumId)
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+
+	if forum == nil {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_ForumIsNotFound, RpcErrorMsg_ForumIsNotFound, nil)
+	}
+
+	err = srv.dbo.SetForumIsReadOnlyById(p.ForumId, p.IsReadOnly, userRoles.User.GetUserParameters().GetId())
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+
+	auditRecord = &al.Record{
+		ActorUserId: userRoles.User.GetUserParameters().GetId(),
+		Action:      al.Action_ChangeForumReadOnly,
+		TargetType:  al.TargetType_Forum,
+		TargetId:    p.ForumId,
+		ValueBefore: al.FlagValue(forum.GetIsReadOnly()),
+		ValueAfter:  al.FlagValue(p.IsReadOnly),
+	}
+
+	result = &rpc2.ChangeForumReadOnlyResult{
+		Success: rpc3.Success{
+			OK: true,
+		},
+	}
+	return result, nil
+}
+
+// getForum reads a forum.
+func (srv *Server) getForum(p *rpc2.GetForumParams) (result *rpc2.GetForumResult, re *jrm1.RpcError) {
+	// Check parameters.
+	if p.ForumId == 0 {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_ForumIdIsNotSet, RpcErrorMsg_ForumIdIsNotSet, nil)
+	}
+
+	var userRoles *am.GetSelfRolesResult
+	userRoles, re = srv.mustBeAnAuthToken(p.Auth)
+	if re != nil {
+		return nil, re
+	}
+
 	srv.dbo.LockForReading()
 	defer srv.dbo.UnlockAfterReading()
 
@@ -920,6 +1195,18 @@ func (srv *Server) getForum(p *rpc2.GetForumParams) (result *rpc2.GetForumResult
 		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_ForumIsNotFound, RpcErrorMsg_ForumIsNotFound, nil)
 	}
 
+	// Check permissions.
+	var scope *perm.Scope
+	scope, re = srv.getForumScopeH(p.ForumId)
+	if re != nil {
+		return nil, re
+	}
+
+	re = srv.mustHavePermission(userRoles, perm.Permission_Read, scope)
+	if re != nil {
+		return nil, re
+	}
+
 	result = &rpc2.GetForumResult{
 		Forum: forum,
 	}
@@ -945,6 +1232,9 @@ func (srv *Server) moveForumUp(p *rpc2.MoveForumUpParams) (result *rpc2.MoveForu
 		return nil, jrm1.NewRpcErrorByUser(c.RpcErrorCode_Permission, c.RpcErrorMsg_Permission, nil)
 	}
 
+	var auditRecord *al.Record
+	defer func() { srv.audit(p.Auth, auditRecord) }()
+
 	srv.dbo.LockForWriting()
 	defer srv.dbo.UnlockAfterWriting()
 
@@ -998,6 +1288,13 @@ func (srv *Server) moveForumUp(p *rpc2.MoveForumUpParams) (result *rpc2.MoveForu
 		return nil, srv.databaseError(err)
 	}
 
+	auditRecord = &al.Record{
+		ActorUserId: userRoles.User.GetUserParameters().GetId(),
+		Action:      al.Action_MoveForumUp,
+		TargetType:  al.TargetType_Forum,
+		TargetId:    p.ForumId,
+	}
+
 	result = &rpc2.MoveForumUpResult{
 		Success: rpc3.Success{
 			OK: true,
@@ -1024,6 +1321,9 @@ func (srv *Server) moveForumDown(p *rpc2.MoveForumDownParams) (result *rpc2.Move
 		return nil, jrm1.NewRpcErrorByUser(c.RpcErrorCode_Permission, c.RpcErrorMsg_Permission, nil)
 	}
 
+	var auditRecord *al.Record
+	defer func() { srv.audit(p.Auth, auditRecord) }()
+
 	srv.dbo.LockForWriting()
 	defer srv.dbo.UnlockAfterWriting()
 
@@ -1077,6 +1377,13 @@ func (srv *Server) moveForumDown(p *rpc2.MoveForumDownParams) (result *rpc2.Move
 		return nil, srv.databaseError(err)
 	}
 
+	auditRecord = &al.Record{
+		ActorUserId: userRoles.User.GetUserParameters().GetId(),
+		Action:      al.Action_MoveForumDown,
+		TargetType:  al.TargetType_Forum,
+		TargetId:    p.ForumId,
+	}
+
 	result = &rpc2.MoveForumDownResult{
 		Success: rpc3.Success{
 			OK: true,
@@ -1085,7 +1392,7 @@ func (srv *Server) moveForumDown(p *rpc2.MoveForumDownParams) (result *rpc2.Move
 	return result, nil
 }
 
-// deleteForum removes a forum.
+// deleteForum moves a forum to the trash.
 func (srv *Server) deleteForum(p *rpc2.DeleteForumParams) (result *rpc2.DeleteForumResult, re *jrm1.RpcError) {
 	// Check parameters.
 	if p.ForumId == 0 {
@@ -1103,6 +1410,9 @@ func (srv *Server) deleteForum(p *rpc2.DeleteForumParams) (result *rpc2.DeleteFo
 		return nil, jrm1.NewRpcErrorByUser(c.RpcErrorCode_Permission, c.RpcErrorMsg_Permission, nil)
 	}
 
+	var auditRecord *al.Record
+	defer func() { srv.audit(p.Auth, auditRecord) }()
+
 	srv.dbo.LockForWriting()
 	defer srv.dbo.UnlockAfterWriting()
 
@@ -1130,6 +1440,14 @@ func (srv *Server) deleteForum(p *rpc2.DeleteForumParams) (result *rpc2.DeleteFo
 		return nil, srv.databaseError(err)
 	}
 
+	// Remember the place of the forum.
+	var ti *mm.TrashItem
+	ti, err = mm.NewTrashItem(mm.TrashObjectType_Forum, p.ForumId, forum.GetNamePtr().ToString(), forum.GetSectionIdPtr(), linkChildren, userRoles.User.GetUserParameters().GetId())
+	if err != nil {
+		srv.logError(err)
+		return nil, jrm1.NewRpcErrorByUser(c.RpcErrorCode_UidList, fmt.Sprintf(c.RpcErrorMsgF_UidList, err.Error()), nil)
+	}
+
 	err = linkChildren.RemoveItem(p.ForumId)
 	if err != nil {
 		srv.logError(err)
@@ -1149,12 +1467,20 @@ func (srv *Server) deleteForum(p *rpc2.DeleteForumParams) (result *rpc2.DeleteFo
 		}
 	}
 
-	// Delete the forum.
-	err = srv.dbo.DeleteForumById(p.ForumId)
+	// Move the forum to the trash.
+	err = srv.moveToTrashH(ti)
 	if err != nil {
 		return nil, srv.databaseError(err)
 	}
 
+	auditRecord = &al.Record{
+		ActorUserId: userRoles.User.GetUserParameters().GetId(),
+		Action:      al.Action_DeleteForum,
+		TargetType:  al.TargetType_Forum,
+		TargetId:    p.ForumId,
+		ValueBefore: al.TextValue(*forum.GetNamePtr()),
+	}
+
 	result = &rpc2.DeleteForumResult{
 		Success: rpc3.Success{
 			OK: true,
@@ -1163,94 +1489,112 @@ func (srv *Server) deleteForum(p *rpc2.DeleteForumParams) (result *rpc2.DeleteFo
 	return result, nil
 }
 
-// Thread.
-
-// addThread inserts a new thread into a forum.
-func (srv *Server) addThread(p *rpc2.AddThreadParams) (result *rpc2.AddThreadResult, re *jrm1.RpcError) {
+// forumExistsS checks whether the specified forum exists or not. This method
+// is used by the system.
+func (srv *Server) forumExistsS(p *rpc2.ForumExistsSParams) (result *rpc2.ForumExistsSResult, re *jrm1.RpcError) {
 	// Check parameters.
 	if p.ForumId == 0 {
 		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_ForumIdIsNotSet, RpcErrorMsg_ForumIdIsNotSet, nil)
 	}
 
-	if len(p.Name) == 0 {
-		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_ThreadNameIsNotSet, RpcErrorMsg_ThreadNameIsNotSet, nil)
-	}
-
-	var userRoles *am.GetSelfRolesResult
-	userRoles, re = srv.mustBeAnAuthToken(p.Auth)
+	re = srv.mustBeNoAuth(p.Auth)
 	if re != nil {
 		return nil, re
 	}
 
-	// Check permissions.
-	if !userRoles.User.GetUserParameters().GetRoles().IsAuthor {
+	// Check the DKey.
+	if !srv.dKeyI.CheckString(p.DKey.ToString()) {
 		return nil, jrm1.NewRpcErrorByUser(c.RpcErrorCode_Permission, c.RpcErrorMsg_Permission, nil)
 	}
 
-	srv.dbo.LockForWriting()
-	defer srv.dbo.UnlockAfterWriting()
+	srv.dbo.LockForReading()
+	defer srv.dbo.UnlockAfterReading()
 
-	// Ensure that a forum exists.
-	var err error
+	// Count forums.
 	var n base2.Count
+	var err error
 	n, err = srv.dbo.CountForumsById(p.ForumId)
 	if err != nil {
 		return nil, srv.databaseError(err)
 	}
 
-	if n == 0 {
-		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_ForumIsNotFound, RpcErrorMsg_ForumIsNotFound, nil)
-	}
-
-	// Insert a thread and link it with its forum.
-	var parentThreads *ul.UidList
-	parentThreads, err = srv.dbo.GetForumThreadsById(p.ForumId)
-	if err != nil {
-		return nil, srv.databaseError(err)
-	}
-
-	var insertedThreadId base2.Id
-	insertedThreadId, err = srv.dbo.InsertNewThread(p.ForumId, p.Name, userRoles.User.GetUserParameters().GetId())
-	if err != nil {
-		return nil, srv.databaseError(err)
-	}
-
-	err = parentThreads.AddItem(insertedThreadId, srv.settings.SystemSettings.NewThreadsAtTop.AsBool())
-	if err != nil {
-		srv.logError(err)
-		return nil, jrm1.NewRpcErrorByUser(c.RpcErrorCode_UidList, fmt.Sprintf(c.RpcErrorMsgF_UidList, err.Error()), nil)
-	}
-
-	err = srv.dbo.SetForumThreadsById(p.ForumId, parentThreads)
-	if err != nil {
-		return nil, srv.databaseError(err)
-	}
-
-	result = &rpc2.AddThreadResult{
-		ThreadId: insertedThreadId,
+	result = &rpc2.ForumExistsSResult{
+		Exists: n == 1,
 	}
 
 	return result, nil
 }
 
-// changeThreadName renames a thread.
-func (srv *Server) changeThreadName(p *rpc2.ChangeThreadNameParams) (result *rpc2.ChangeThreadNameResult, re *jrm1.RpcError) {
+// Thread.
+
+// addThread inserts a new thread into a forum.
+func (srv *Server) addThread(p *rpc2.AddThreadParams) (result *rpc2.AddThreadResult, re *jrm1.RpcError) {
 	// Check parameters.
-	if p.ThreadId == 0 {
-		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_ThreadIdIsNotSet, RpcErrorMsg_ThreadIdIsNotSet, nil)
+	if p.ForumId == 0 {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_ForumIdIsNotSet, RpcErrorMsg_ForumIdIsNotSet, nil)
 	}
 
 	if len(p.Name) == 0 {
 		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_ThreadNameIsNotSet, RpcErrorMsg_ThreadNameIsNotSet, nil)
 	}
 
+	if p.Poll != nil {
+		re = srv.checkNewPoll(p.Poll)
+		if re != nil {
+			return nil, re
+		}
+	}
+
 	var userRoles *am.GetSelfRolesResult
 	userRoles, re = srv.mustBeAnAuthToken(p.Auth)
 	if re != nil {
 		return nil, re
 	}
 
-	// Check permissions.
+	result, re = srv.addThreadH(p, userRoles)
+	if re != nil {
+		return nil, re
+	}
+
+	seData := sed.NewSystemEventDataWithValue(
+		set.NewSystemEventTypeWithValue(ev.NewEnumValue(set.SystemEventType_ForumNewThread)),
+		&result.ThreadId,
+		nil,
+		userRoles.User.GetUserParameters().GetIdPtr(),
+		nil,
+	)
+
+	se, err := cm.NewSystemEventWithData(seData)
+	if err != nil {
+		return nil, jrm1.NewRpcErrorByUser(c.RpcErrorCode_SystemEvent, c.RpcErrorMsg_SystemEvent, nil)
+	}
+
+	re = srv.reportSystemEvent(se)
+	if re != nil {
+		return nil, re
+	}
+
+	return result, nil
+}
+
+// changeThreadName renames a thread.
+func (srv *Server) changeThreadName(p *rpc2.ChangeThreadNameParams) (result *rpc2.ChangeThreadNameResult, re *jrm1.RpcError) {
+	// Check parameters.
+	if p.ThreadId == 0 {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_ThreadIdIsNotSet, RpcErrorMsg_ThreadIdIsNotSet, nil)
+	}
+
+	if len(p.Name) == 0 {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_ThreadNameIsNotSet, RpcErrorMsg_ThreadNameIsNotSet, nil)
+	}
+
+	var userRoles *am.GetSelfRolesResult
+	userRoles, re = srv.mustBeAnAuthToken(p.Auth)
+	if re != nil {
+		return nil, re
+	}
+
+	// Check permissions.
 	if !userRoles.User.GetUserParameters().GetRoles().IsAdministrator {
 		return nil, jrm1.NewRpcErrorByUser(c.RpcErrorCode_Permission, c.RpcErrorMsg_Permission, nil)
 	}
@@ -1326,11 +1670,21 @@ func (srv *Server) changeThreadForum(p *rpc2.ChangeThreadForumParams) (result *r
 		return nil, re
 	}
 
-	re = srv.changeThreadForumH(p.ThreadId, p.ForumId, userRoles.User.GetUserParameters().GetId())
+	var oldForumId base2.Id
+	oldForumId, re = srv.changeThreadForumH(p.ThreadId, p.ForumId, userRoles.User.GetUserParameters().GetId())
 	if re != nil {
 		return nil, re
 	}
 
+	srv.audit(p.Auth, &al.Record{
+		ActorUserId: userRoles.User.GetUserParameters().GetId(),
+		Action:      al.Action_ChangeThreadForum,
+		TargetType:  al.TargetType_Thread,
+		TargetId:    p.ThreadId,
+		ValueBefore: al.IdValue(oldForumId),
+		ValueAfter:  al.IdValue(p.ForumId),
+	})
+
 	result = &rpc2.ChangeThreadForumResult{
 		Success: rpc3.Success{
 			OK: true,
@@ -1352,11 +1706,6 @@ func (srv *Server) getThread(p *rpc2.GetThreadParams) (result *rpc2.GetThreadRes
 		return nil, re
 	}
 
-	// Check permissions.
-	if !userRoles.User.GetUserParameters().GetRoles().IsReader {
-		return nil, jrm1.NewRpcErrorByUser(c.RpcErrorCode_Permission, c.RpcErrorMsg_Permission, nil)
-	}
-
 	srv.dbo.LockForReading()
 	defer srv.dbo.UnlockAfterReading()
 
@@ -1372,6 +1721,18 @@ func (srv *Server) getThread(p *rpc2.GetThreadParams) (result *rpc2.GetThreadRes
 		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_ThreadIsNotFound, RpcErrorMsg_ThreadIsNotFound, nil)
 	}
 
+	// Check permissions.
+	var scope *perm.Scope
+	scope, re = srv.getForumScopeH(thread.GetForumId())
+	if re != nil {
+		return nil, re
+	}
+
+	re = srv.mustHavePermission(userRoles, perm.Permission_Read, scope)
+	if re != nil {
+		return nil, re
+	}
+
 	result = &rpc2.GetThreadResult{
 		Thread: thread,
 	}
@@ -1392,24 +1753,45 @@ func (srv *Server) getThreadNamesByIds(p *rpc2.GetThreadNamesByIdsParams) (resul
 		return nil, re
 	}
 
-	// Check permissions.
-	if !userRoles.User.GetUserParameters().GetRoles().IsReader {
-		return nil, jrm1.NewRpcErrorByUser(c.RpcErrorCode_Permission, c.RpcErrorMsg_Permission, nil)
-	}
-
 	srv.dbo.LockForReading()
 	defer srv.dbo.UnlockAfterReading()
 
-	// Read thread names.
-	var threadNames []simple.Name
+	// Names of threads which can not be read are not shown.
+	var threadIds = make([]base2.Id, 0, len(p.ThreadIds))
+	var thread derived2.IThread
+	var scope *perm.Scope
 	var err error
-	threadNames, err = srv.dbo.ReadThreadNamesByIds(p.ThreadIds)
-	if err != nil {
-		return nil, srv.databaseError(err)
+	for _, threadId := range p.ThreadIds {
+		thread, err = srv.dbo.GetThreadById(threadId)
+		if err != nil {
+			return nil, srv.databaseError(err)
+		}
+
+		if thread == nil {
+			continue
+		}
+
+		scope, re = srv.getForumScopeH(thread.GetForumId())
+		if re != nil {
+			return nil, re
+		}
+
+		if userRoles.User.GetUserParameters().GetRoles().IsGranted(perm.Permission_Read, scope) {
+			threadIds = append(threadIds, threadId)
+		}
+	}
+
+	// Read thread names.
+	var threadNames = []simple.Name{}
+	if len(threadIds) > 0 {
+		threadNames, err = srv.dbo.ReadThreadNamesByIds(threadIds)
+		if err != nil {
+			return nil, srv.databaseError(err)
+		}
 	}
 
 	result = &rpc2.GetThreadNamesByIdsResult{
-		ThreadIds:   p.ThreadIds,
+		ThreadIds:   threadIds,
 		ThreadNames: threadNames,
 	}
 
@@ -1564,7 +1946,7 @@ func (srv *Server) moveThreadDown(p *rpc2.MoveThreadDownParams) (result *rpc2.Mo
 	return result, nil
 }
 
-// deleteThread removes a thread.
+// deleteThread moves a thread to the trash.
 func (srv *Server) deleteThread(p *rpc2.DeleteThreadParams) (result *rpc2.DeleteThreadResult, re *jrm1.RpcError) {
 	// Check parameters.
 	if p.ThreadId == 0 {
@@ -1600,11 +1982,18 @@ func (srv *Server) deleteThread(p *rpc2.DeleteThreadParams) (result *rpc2.Delete
 		return nil, re
 	}
 
-	re = srv.deleteThreadH(p.ThreadId)
+	re = srv.deleteThreadH(p.ThreadId, userRoles.User.GetUserParameters().GetId())
 	if re != nil {
 		return nil, re
 	}
 
+	srv.audit(p.Auth, &al.Record{
+		ActorUserId: userRoles.User.GetUserParameters().GetId(),
+		Action:      al.Action_DeleteThread,
+		TargetType:  al.TargetType_Thread,
+		TargetId:    p.ThreadId,
+	})
+
 	result = &rpc2.DeleteThreadResult{
 		Success: rpc3.Success{
 			OK: true,
@@ -1613,101 +2002,110 @@ func (srv *Server) deleteThread(p *rpc2.DeleteThreadParams) (result *rpc2.Delete
 	return result, nil
 }
 
-// threadExistsS checks whether the specified thread exists or not. This method
-// is used by the system.
-func (srv *Server) threadExistsS(p *rpc2.ThreadExistsSParams) (result *rpc2.ThreadExistsSResult, re *jrm1.RpcError) {
+// lockThread locks a thread. New messages can not be added to a locked thread.
+func (srv *Server) lockThread(p *rpc2.LockThreadParams) (result *rpc2.LockThreadResult, re *jrm1.RpcError) {
 	// Check parameters.
 	if p.ThreadId == 0 {
 		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_ThreadIdIsNotSet, RpcErrorMsg_ThreadIdIsNotSet, nil)
 	}
 
-	re = srv.mustBeNoAuth(p.Auth)
+	var userRoles *am.GetSelfRolesResult
+	userRoles, re = srv.mustBeAnAuthToken(p.Auth)
 	if re != nil {
 		return nil, re
 	}
 
-	// Check the DKey.
-	if !srv.dKeyI.CheckString(p.DKey.ToString()) {
-		return nil, jrm1.NewRpcErrorByUser(c.RpcErrorCode_Permission, c.RpcErrorMsg_Permission, nil)
+	// Check permissions.
+	re = srv.mustModerateThreadH(userRoles, p.ThreadId)
+	if re != nil {
+		return nil, re
 	}
 
-	srv.dbo.LockForReading()
-	defer srv.dbo.UnlockAfterReading()
+	userId := userRoles.User.GetUserParameters().GetId()
 
-	// Count threads.
-	var n base2.Count
-	var err error
-	n, err = srv.dbo.CountThreadsById(p.ThreadId)
-	if err != nil {
-		return nil, srv.databaseError(err)
+	var isChanged bool
+	isChanged, re = srv.setThreadIsLockedH(p.ThreadId, true, userId)
+	if re != nil {
+		return nil, re
 	}
 
-	result = &rpc2.ThreadExistsSResult{
-		Exists: n == 1,
+	if isChanged {
+		re = srv.reportThreadLockingH(p.ThreadId, true, userId)
+		if re != nil {
+			return nil, re
+		}
+
+		srv.audit(p.Auth, &al.Record{
+			ActorUserId: userId,
+			Action:      al.Action_LockThread,
+			TargetType:  al.TargetType_Thread,
+			TargetId:    p.ThreadId,
+		})
 	}
 
+	result = &rpc2.LockThreadResult{
+		Success: rpc3.Success{
+			OK: true,
+		},
+	}
 	return result, nil
 }
 
-// Message.
-
-// addMessage inserts a new message into a thread.
-func (srv *Server) addMessage(p *rpc2.AddMessageParams) (result *rpc2.AddMessageResult, re *jrm1.RpcError) {
+// unlockThread unlocks a thread.
+func (srv *Server) unlockThread(p *rpc2.UnlockThreadParams) (result *rpc2.UnlockThreadResult, re *jrm1.RpcError) {
 	// Check parameters.
 	if p.ThreadId == 0 {
 		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_ThreadIdIsNotSet, RpcErrorMsg_ThreadIdIsNotSet, nil)
 	}
 
-	if len(p.Text) == 0 {
-		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_MessageTextIsNotSet, RpcErrorMsg_MessageTextIsNotSet, nil)
-	}
-
 	var userRoles *am.GetSelfRolesResult
 	userRoles, re = srv.mustBeAnAuthToken(p.Auth)
 	if re != nil {
 		return nil, re
 	}
 
-	// Check permissions (Part I).
-	if !userRoles.User.GetUserParameters().GetRoles().IsReader {
-		return nil, jrm1.NewRpcErrorByUser(c.RpcErrorCode_Permission, c.RpcErrorMsg_Permission, nil)
+	// Check permissions.
+	re = srv.mustModerateThreadH(userRoles, p.ThreadId)
+	if re != nil {
+		return nil, re
 	}
 
-	result, re = srv.addMessageH(p.ThreadId, p.Text, userRoles)
+	userId := userRoles.User.GetUserParameters().GetId()
+
+	var isChanged bool
+	isChanged, re = srv.setThreadIsLockedH(p.ThreadId, false, userId)
 	if re != nil {
 		return nil, re
 	}
 
-	seData := sed.NewSystemEventDataWithValue(
-		set.NewSystemEventTypeWithValue(ev.NewEnumValue(set.SystemEventType_ThreadNewMessage)),
-		&p.ThreadId,
-		&result.MessageId,
-		userRoles.User.GetUserParameters().GetIdPtr(),
-		nil,
-	)
+	if isChanged {
+		re = srv.reportThreadLockingH(p.ThreadId, false, userId)
+		if re != nil {
+			return nil, re
+		}
 
-	se, err := cm.NewSystemEventWithData(seData)
-	if err != nil {
-		return nil, jrm1.NewRpcErrorByUser(c.RpcErrorCode_SystemEvent, c.RpcErrorMsg_SystemEvent, nil)
+		srv.audit(p.Auth, &al.Record{
+			ActorUserId: userId,
+			Action:      al.Action_UnlockThread,
+			TargetType:  al.TargetType_Thread,
+			TargetId:    p.ThreadId,
+		})
 	}
 
-	re = srv.reportSystemEvent(se)
-	if re != nil {
-		return nil, re
+	result = &rpc2.UnlockThreadResult{
+		Success: rpc3.Success{
+			OK: true,
+		},
 	}
-
 	return result, nil
 }
 
-// changeMessageText changes text of a message.
-func (srv *Server) changeMessageText(p *rpc2.ChangeMessageTextParams) (result *rpc2.ChangeMessageTextResult, re *jrm1.RpcError) {
+// changeThreadPinned pins or unpins a thread. Pinned threads are shown above
+// other threads of the forum.
+func (srv *Server) changeThreadPinned(p *rpc2.ChangeThreadPinnedParams) (result *rpc2.ChangeThreadPinnedResult, re *jrm1.RpcError) {
 	// Check parameters.
-	if p.MessageId == 0 {
-		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_MessageIdIsNotSet, RpcErrorMsg_MessageIdIsNotSet, nil)
-	}
-
-	if len(p.Text) == 0 {
-		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_MessageTextIsNotSet, RpcErrorMsg_MessageTextIsNotSet, nil)
+	if p.ThreadId == 0 {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_ThreadIdIsNotSet, RpcErrorMsg_ThreadIdIsNotSet, nil)
 	}
 
 	var userRoles *am.GetSelfRolesResult
@@ -1716,49 +2114,30 @@ func (srv *Server) changeMessageText(p *rpc2.ChangeMessageTextParams) (result *r
 		return nil, re
 	}
 
-	var initialMessage derived2.IMessage
-	initialMessage, re = srv.changeMessageTextH(p.MessageId, p.Text, userRoles)
+	// Check permissions.
+	re = srv.mustModerateThreadH(userRoles, p.ThreadId)
 	if re != nil {
 		return nil, re
 	}
 
-	seData := sed.NewSystemEventDataWithValue(
-		set.NewSystemEventTypeWithValue(ev.NewEnumValue(set.SystemEventType_ThreadMessageEdit)),
-		initialMessage.GetThreadIdPtr(),
-		&p.MessageId,
-		userRoles.User.GetUserParameters().GetIdPtr(),
-		nil,
-	)
-
-	se, err := cm.NewSystemEventWithData(seData)
-	if err != nil {
-		return nil, jrm1.NewRpcErrorByUser(c.RpcErrorCode_SystemEvent, c.RpcErrorMsg_SystemEvent, nil)
-	}
-
-	re = srv.reportSystemEvent(se)
+	var isChanged bool
+	isChanged, re = srv.changeThreadIsPinnedH(p.ThreadId, p.IsPinned, userRoles.User.GetUserParameters().GetId())
 	if re != nil {
 		return nil, re
 	}
 
-	seData = sed.NewSystemEventDataWithValue(
-		set.NewSystemEventTypeWithValue(ev.NewEnumValue(set.SystemEventType_MessageTextEdit)),
-		initialMessage.GetThreadIdPtr(),
-		&p.MessageId,
-		userRoles.User.GetUserParameters().GetIdPtr(),
-		initialMessage.GetEventData().GetCreatorUserIdPtr(),
-	)
-
-	se, err = cm.NewSystemEventWithData(seData)
-	if err != nil {
-		return nil, jrm1.NewRpcErrorByUser(c.RpcErrorCode_SystemEvent, c.RpcErrorMsg_SystemEvent, nil)
-	}
-
-	re = srv.reportSystemEvent(se)
-	if re != nil {
-		return nil, re
+	if isChanged {
+		srv.audit(p.Auth, &al.Record{
+			ActorUserId: userRoles.User.GetUserParameters().GetId(),
+			Action:      al.Action_ChangeThreadPinned,
+			TargetType:  al.TargetType_Thread,
+			TargetId:    p.ThreadId,
+			ValueBefore: al.FlagValue(!p.IsPinned),
+			ValueAfter:  al.FlagValue(p.IsPinned),
+		})
 	}
 
-	result = &rpc2.ChangeMessageTextResult{
+	result = &rpc2.ChangeThreadPinnedResult{
 		Success: rpc3.Success{
 			OK: true,
 		},
@@ -1766,13 +2145,10 @@ func (srv *Server) changeMessageText(p *rpc2.ChangeMessageTextParams) (result *r
 	return result, nil
 }
 
-// changeMessageThread moves a message from an old thread to a new thread.
-func (srv *Server) changeMessageThread(p *rpc2.ChangeMessageThreadParams) (result *rpc2.ChangeMessageThreadResult, re *jrm1.RpcError) {
+// changeThreadAnnouncement makes a thread an announcement or an ordinary
+// thread. Announcements are shown in all forums of the section.
+func (srv *Server) changeThreadAnnouncement(p *rpc2.ChangeThreadAnnouncementParams) (result *rpc2.ChangeThreadAnnouncementResult, re *jrm1.RpcError) {
 	// Check parameters.
-	if p.MessageId == 0 {
-		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_MessageIdIsNotSet, RpcErrorMsg_MessageIdIsNotSet, nil)
-	}
-
 	if p.ThreadId == 0 {
 		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_ThreadIdIsNotSet, RpcErrorMsg_ThreadIdIsNotSet, nil)
 	}
@@ -1783,36 +2159,24 @@ func (srv *Server) changeMessageThread(p *rpc2.ChangeMessageThreadParams) (resul
 		return nil, re
 	}
 
-	// Check permissions.
-	if !userRoles.User.GetUserParameters().GetRoles().IsAdministrator {
-		return nil, jrm1.NewRpcErrorByUser(c.RpcErrorCode_Permission, c.RpcErrorMsg_Permission, nil)
-	}
-
-	var initialMessage derived2.IMessage
-	initialMessage, re = srv.changeMessageThreadH(p.MessageId, p.ThreadId, userRoles)
+	var isChanged bool
+	isChanged, re = srv.changeThreadIsAnnouncementH(p.ThreadId, p.IsAnnouncement, userRoles)
 	if re != nil {
 		return nil, re
 	}
 
-	seData := sed.NewSystemEventDataWithValue(
-		set.NewSystemEventTypeWithValue(ev.NewEnumValue(set.SystemEventType_MessageParentChange)),
-		initialMessage.GetThreadIdPtr(),
-		&p.MessageId,
-		userRoles.User.GetUserParameters().GetIdPtr(),
-		initialMessage.GetEventData().GetCreatorUserIdPtr(),
-	)
-
-	se, err := cm.NewSystemEventWithData(seData)
-	if err != nil {
-		return nil, jrm1.NewRpcErrorByUser(c.RpcErrorCode_SystemEvent, c.RpcErrorMsg_SystemEvent, nil)
-	}
-
-	re = srv.reportSystemEvent(se)
-	if re != nil {
-		return nil, re
+	if isChanged {
+		srv.audit(p.Auth, &al.Record{
+			ActorUserId: userRoles.User.GetUserParameters().GetId(),
+			Action:      al.Action_ChangeThreadAnnouncement,
+			TargetType:  al.TargetType_Thread,
+			TargetId:    p.ThreadId,
+			ValueBefore: al.FlagValue(!p.IsAnnouncement),
+			ValueAfter:  al.FlagValue(p.IsAnnouncement),
+		})
 	}
 
-	result = &rpc2.ChangeMessageThreadResult{
+	result = &rpc2.ChangeThreadAnnouncementResult{
 		Success: rpc3.Success{
 			OK: true,
 		},
@@ -1820,68 +2184,376 @@ func (srv *Server) changeMessageThread(p *rpc2.ChangeMessageThreadParams) (resul
 	return result, nil
 }
 
-// getMessage reads a message.
-func (srv *Server) getMessage(p *rpc2.GetMessageParams) (result *rpc2.GetMessageResult, re *jrm1.RpcError) {
+// threadExistsS checks whether the specified thread exists or not. This method
+// is used by the system.
+func (srv *Server) threadExistsS(p *rpc2.ThreadExistsSParams) (result *rpc2.ThreadExistsSResult, re *jrm1.RpcError) {
 	// Check parameters.
-	if p.MessageId == 0 {
-		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_MessageIdIsNotSet, RpcErrorMsg_MessageIdIsNotSet, nil)
+	if p.ThreadId == 0 {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_ThreadIdIsNotSet, RpcErrorMsg_ThreadIdIsNotSet, nil)
 	}
 
-	var userRoles *am.GetSelfRolesResult
-	userRoles, re = srv.mustBeAnAuthToken(p.Auth)
+	re = srv.mustBeNoAuth(p.Auth)
 	if re != nil {
 		return nil, re
 	}
 
-	// Check permissions.
-	if !userRoles.User.GetUserParameters().GetRoles().IsReader {
+	// Check the DKey.
+	if !srv.dKeyI.CheckString(p.DKey.ToString()) {
 		return nil, jrm1.NewRpcErrorByUser(c.RpcErrorCode_Permission, c.RpcErrorMsg_Permission, nil)
 	}
 
 	srv.dbo.LockForReading()
 	defer srv.dbo.UnlockAfterReading()
 
-	// Read the message.
-	var message derived2.IMessage
+	// Count threads.
+	var n base2.Count
 	var err error
-	message, err = srv.dbo.GetMessageById(p.MessageId)
+	n, err = srv.dbo.CountThreadsById(p.ThreadId)
 	if err != nil {
 		return nil, srv.databaseError(err)
 	}
 
-	if message == nil {
-		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_MessageIsNotFound, RpcErrorMsg_MessageIsNotFound, nil)
-	}
-
-	result = &rpc2.GetMessageResult{
-		Message: message,
+	result = &rpc2.ThreadExistsSResult{
+		Exists: n == 1,
 	}
 
 	return result, nil
 }
 
-// getLatestMessageOfThread reads the latest message of a thread.
-func (srv *Server) getLatestMessageOfThread(p *rpc2.GetLatestMessageOfThreadParams) (result *rpc2.GetLatestMessageOfThreadResult, re *jrm1.RpcError) {
+// getThreadLocationS reads names of a thread and its forum and a list of
+// sections containing the forum. This method is used by the system.
+func (srv *Server) getThreadLocationS(p *rpc2.GetThreadLocationSParams) (result *rpc2.GetThreadLocationSResult, re *jrm1.RpcError) {
 	// Check parameters.
 	if p.ThreadId == 0 {
 		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_ThreadIdIsNotSet, RpcErrorMsg_ThreadIdIsNotSet, nil)
 	}
 
-	var userRoles *am.GetSelfRolesResult
-	userRoles, re = srv.mustBeAnAuthToken(p.Auth)
+	re = srv.mustBeNoAuth(p.Auth)
 	if re != nil {
 		return nil, re
 	}
 
-	// Check permissions.
-	if !userRoles.User.GetUserParameters().GetRoles().IsReader {
+	// Check the DKey.
+	if !srv.dKeyI.CheckString(p.DKey.ToString()) {
 		return nil, jrm1.NewRpcErrorByUser(c.RpcErrorCode_Permission, c.RpcErrorMsg_Permission, nil)
 	}
 
 	srv.dbo.LockForReading()
 	defer srv.dbo.UnlockAfterReading()
 
-	result = &rpc2.GetLatestMessageOfThreadResult{}
+	thread, err := srv.dbo.GetThreadById(p.ThreadId)
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+
+	if thread == nil {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_ThreadIsNotFound, RpcErrorMsg_ThreadIsNotFound, nil)
+	}
+
+	forum, err := srv.dbo.GetForumById(thread.GetForumId())
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+
+	if forum == nil {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_ForumIsNotFound, RpcErrorMsg_ForumIsNotFound, nil)
+	}
+
+	var scope *perm.Scope
+	scope, re = srv.getSectionScopeH(forum.GetSectionId())
+	if re != nil {
+		return nil, re
+	}
+
+	result = &rpc2.GetThreadLocationSResult{
+		ThreadId:   p.ThreadId,
+		ThreadName: *thread.GetNamePtr(),
+		ForumId:    forum.GetId(),
+		ForumName:  *forum.GetNamePtr(),
+		SectionIds: make([]base2.Id, 0, len(scope.Sections)),
+	}
+
+	for _, section := range scope.Sections {
+		result.SectionIds = append(result.SectionIds, section.Id)
+
+		if section.IsPrivate {
+			break
+		}
+	}
+
+	return result, nil
+}
+
+// Message.
+
+// addMessage inserts a new message into a thread.
+func (srv *Server) addMessage(p *rpc2.AddMessageParams) (result *rpc2.AddMessageResult, re *jrm1.RpcError) {
+	// Check parameters.
+	if p.ThreadId == 0 {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_ThreadIdIsNotSet, RpcErrorMsg_ThreadIdIsNotSet, nil)
+	}
+
+	if len(p.Text) == 0 {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_MessageTextIsNotSet, RpcErrorMsg_MessageTextIsNotSet, nil)
+	}
+
+	var userRoles *am.GetSelfRolesResult
+	userRoles, re = srv.mustBeAnAuthToken(p.Auth)
+	if re != nil {
+		return nil, re
+	}
+
+	var files []attachmentFile
+	files, re = srv.prepareAttachments(p.Attachments)
+	if re != nil {
+		return nil, re
+	}
+
+	var rm *renderedMessage
+	rm, re = srv.renderMessageText(p.Auth, p.Text)
+	if re != nil {
+		return nil, re
+	}
+
+	result, re = srv.addMessageH(p.ThreadId, p.Text, rm.html, files, userRoles)
+	if re != nil {
+		return nil, re
+	}
+
+	seData := sed.NewSystemEventDataWithValue(
+		set.NewSystemEventTypeWithValue(ev.NewEnumValue(set.SystemEventType_ThreadNewMessage)),
+		&p.ThreadId,
+		&result.MessageId,
+		userRoles.User.GetUserParameters().GetIdPtr(),
+		nil,
+	)
+
+	se, err := cm.NewSystemEventWithData(seData)
+	if err != nil {
+		return nil, jrm1.NewRpcErrorByUser(c.RpcErrorCode_SystemEvent, c.RpcErrorMsg_SystemEvent, nil)
+	}
+
+	re = srv.reportSystemEvent(se)
+	if re != nil {
+		return nil, re
+	}
+
+	re = srv.reportMentions(p.ThreadId, result.MessageId, userRoles.User.GetUserParameters().GetId(), rm.getMentionedUsers(nil))
+	if re != nil {
+		return nil, re
+	}
+
+	return result, nil
+}
+
+// changeMessageText changes text of a message.
+func (srv *Server) changeMessageText(p *rpc2.ChangeMessageTextParams) (result *rpc2.ChangeMessageTextResult, re *jrm1.RpcError) {
+	// Check parameters.
+	if p.MessageId == 0 {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_MessageIdIsNotSet, RpcErrorMsg_MessageIdIsNotSet, nil)
+	}
+
+	if len(p.Text) == 0 {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_MessageTextIsNotSet, RpcErrorMsg_MessageTextIsNotSet, nil)
+	}
+
+	var userRoles *am.GetSelfRolesResult
+	userRoles, re = srv.mustBeAnAuthToken(p.Auth)
+	if re != nil {
+		return nil, re
+	}
+
+	var rm *renderedMessage
+	rm, re = srv.renderMessageText(p.Auth, p.Text)
+	if re != nil {
+		return nil, re
+	}
+
+	var initialMessage derived2.IMessage
+	initialMessage, re = srv.changeMessageTextH(p.MessageId, p.Text, rm.html, userRoles)
+	if re != nil {
+		return nil, re
+	}
+
+	seData := sed.NewSystemEventDataWithValue(
+		set.NewSystemEventTypeWithValue(ev.NewEnumValue(set.SystemEventType_ThreadMessageEdit)),
+		initialMessage.GetThreadIdPtr(),
+		&p.MessageId,
+		userRoles.User.GetUserParameters().GetIdPtr(),
+		nil,
+	)
+
+	se, err := cm.NewSystemEventWithData(seData)
+	if err != nil {
+		return nil, jrm1.NewRpcErrorByUser(c.RpcErrorCode_SystemEvent, c.RpcErrorMsg_SystemEvent, nil)
+	}
+
+	re = srv.reportSystemEvent(se)
+	if re != nil {
+		return nil, re
+	}
+
+	seData = sed.NewSystemEventDataWithValue(
+		set.NewSystemEventTypeWithValue(ev.NewEnumValue(set.SystemEventType_MessageTextEdit)),
+		initialMessage.GetThreadIdPtr(),
+		&p.MessageId,
+		userRoles.User.GetUserParameters().GetIdPtr(),
+		initialMessage.GetEventData().GetCreatorUserIdPtr(),
+	)
+
+	se, err = cm.NewSystemEventWithData(seData)
+	if err != nil {
+		return nil, jrm1.NewRpcErrorByUser(c.RpcErrorCode_SystemEvent, c.RpcErrorMsg_SystemEvent, nil)
+	}
+
+	re = srv.reportSystemEvent(se)
+	if re != nil {
+		return nil, re
+	}
+
+	re = srv.reportMentions(initialMessage.GetThreadId(), p.MessageId, userRoles.User.GetUserParameters().GetId(), rm.getMentionedUsers(initialMessage.GetTextPtr()))
+	if re != nil {
+		return nil, re
+	}
+
+	result = &rpc2.ChangeMessageTextResult{
+		Success: rpc3.Success{
+			OK: true,
+		},
+	}
+	return result, nil
+}
+
+// changeMessageThread moves a message from an old thread to a new thread.
+func (srv *Server) changeMessageThread(p *rpc2.ChangeMessageThreadParams) (result *rpc2.ChangeMessageThreadResult, re *jrm1.RpcError) {
+	// Check parameters.
+	if p.MessageId == 0 {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_MessageIdIsNotSet, RpcErrorMsg_MessageIdIsNotSet, nil)
+	}
+
+	if p.ThreadId == 0 {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_ThreadIdIsNotSet, RpcErrorMsg_ThreadIdIsNotSet, nil)
+	}
+
+	var userRoles *am.GetSelfRolesResult
+	userRoles, re = srv.mustBeAnAuthToken(p.Auth)
+	if re != nil {
+		return nil, re
+	}
+
+	// Check permissions.
+	if !userRoles.User.GetUserParameters().GetRoles().IsAdministrator {
+		return nil, jrm1.NewRpcErrorByUser(c.RpcErrorCode_Permission, c.RpcErrorMsg_Permission, nil)
+	}
+
+	var initialMessage derived2.IMessage
+	initialMessage, re = srv.changeMessageThreadH(p.MessageId, p.ThreadId, userRoles)
+	if re != nil {
+		return nil, re
+	}
+
+	seData := sed.NewSystemEventDataWithValue(
+		set.NewSystemEventTypeWithValue(ev.NewEnumValue(set.SystemEventType_MessageParentChange)),
+		initialMessage.GetThreadIdPtr(),
+		&p.MessageId,
+		userRoles.User.GetUserParameters().GetIdPtr(),
+		initialMessage.GetEventData().GetCreatorUserIdPtr(),
+	)
+
+	se, err := cm.NewSystemEventWithData(seData)
+	if err != nil {
+		return nil, jrm1.NewRpcErrorByUser(c.RpcErrorCode_SystemEvent, c.RpcErrorMsg_SystemEvent, nil)
+	}
+
+	re = srv.reportSystemEvent(se)
+	if re != nil {
+		return nil, re
+	}
+
+	result = &rpc2.ChangeMessageThreadResult{
+		Success: rpc3.Success{
+			OK: true,
+		},
+	}
+	return result, nil
+}
+
+// getMessage reads a message.
+func (srv *Server) getMessage(p *rpc2.GetMessageParams) (result *rpc2.GetMessageResult, re *jrm1.RpcError) {
+	// Check parameters.
+	if p.MessageId == 0 {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_MessageIdIsNotSet, RpcErrorMsg_MessageIdIsNotSet, nil)
+	}
+
+	var userRoles *am.GetSelfRolesResult
+	userRoles, re = srv.mustBeAnAuthToken(p.Auth)
+	if re != nil {
+		return nil, re
+	}
+
+	srv.dbo.LockForReading()
+	defer srv.dbo.UnlockAfterReading()
+
+	// Read the message.
+	var message derived2.IMessage
+	var err error
+	message, err = srv.dbo.GetMessageById(p.MessageId)
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+
+	if message == nil {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_MessageIsNotFound, RpcErrorMsg_MessageIsNotFound, nil)
+	}
+
+	// Check permissions.
+	var scope *perm.Scope
+	scope, re = srv.getThreadScopeH(message.GetThreadId())
+	if re != nil {
+		return nil, re
+	}
+
+	re = srv.mustHavePermission(userRoles, perm.Permission_Read, scope)
+	if re != nil {
+		return nil, re
+	}
+
+	result = &rpc2.GetMessageResult{
+		Message: message,
+	}
+
+	return result, nil
+}
+
+// getLatestMessageOfThread reads the latest message of a thread.
+func (srv *Server) getLatestMessageOfThread(p *rpc2.GetLatestMessageOfThreadParams) (result *rpc2.GetLatestMessageOfThreadResult, re *jrm1.RpcError) {
+	// Check parameters.
+	if p.ThreadId == 0 {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_ThreadIdIsNotSet, RpcErrorMsg_ThreadIdIsNotSet, nil)
+	}
+
+	var userRoles *am.GetSelfRolesResult
+	userRoles, re = srv.mustBeAnAuthToken(p.Auth)
+	if re != nil {
+		return nil, re
+	}
+
+	srv.dbo.LockForReading()
+	defer srv.dbo.UnlockAfterReading()
+
+	// Check permissions.
+	var scope *perm.Scope
+	scope, re = srv.getThreadScopeH(p.ThreadId)
+	if re != nil {
+		return nil, re
+	}
+
+	re = srv.mustHavePermission(userRoles, perm.Permission_Read, scope)
+	if re != nil {
+		return nil, re
+	}
+
+	result = &rpc2.GetLatestMessageOfThreadResult{}
 
 	result.Message, re = srv.getLatestMessageOfThreadH(p.ThreadId)
 	if re != nil {
@@ -1891,11 +2563,1536 @@ func (srv *Server) getLatestMessageOfThread(p *rpc2.GetLatestMessageOfThreadPara
 	return result, nil
 }
 
-// deleteMessage removes a message.
-func (srv *Server) deleteMessage(p *rpc2.DeleteMessageParams) (result *rpc2.DeleteMessageResult, re *jrm1.RpcError) {
+// deleteMessage moves a message to the trash.
+func (srv *Server) deleteMessage(p *rpc2.DeleteMessageParams) (result *rpc2.DeleteMessageResult, re *jrm1.RpcError) {
+	// Check parameters.
+	if p.MessageId == 0 {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_MessageIdIsNotSet, RpcErrorMsg_MessageIdIsNotSet, nil)
+	}
+
+	var userRoles *am.GetSelfRolesResult
+	userRoles, re = srv.mustBeAnAuthToken(p.Auth)
+	if re != nil {
+		return nil, re
+	}
+
+	// Check permissions.
+	if !userRoles.User.GetUserParameters().GetRoles().IsAdministrator {
+		return nil, jrm1.NewRpcErrorByUser(c.RpcErrorCode_Permission, c.RpcErrorMsg_Permission, nil)
+	}
+
+	var initialMessage derived2.IMessage
+	initialMessage, re = srv.deleteMessageH(p.MessageId, userRoles.User.GetUserParameters().GetId())
+	if re != nil {
+		return nil, re
+	}
+
+	re = srv.reportMessageDeletionH(initialMessage, userRoles.User.GetUserParameters().GetIdPtr())
+	if re != nil {
+		return nil, re
+	}
+
+	result = &rpc2.DeleteMessageResult{
+		Success: rpc3.Success{
+			OK: true,
+		},
+	}
+	return result, nil
+}
+
+// listMessageRevisions reads all revisions of a message.
+func (srv *Server) listMessageRevisions(p *rpc2.ListMessageRevisionsParams) (result *rpc2.ListMessageRevisionsResult, re *jrm1.RpcError) {
+	// Check parameters.
+	if p.MessageId == 0 {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_MessageIdIsNotSet, RpcErrorMsg_MessageIdIsNotSet, nil)
+	}
+
+	var userRoles *am.GetSelfRolesResult
+	userRoles, re = srv.mustBeAnAuthToken(p.Auth)
+	if re != nil {
+		return nil, re
+	}
+
+	srv.dbo.LockForReading()
+	defer srv.dbo.UnlockAfterReading()
+
+	// Ensure that the message exists.
+	n, err := srv.dbo.CountMessagesById(p.MessageId)
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+
+	if n == 0 {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_MessageIsNotFound, RpcErrorMsg_MessageIsNotFound, nil)
+	}
+
+	// Check permissions.
+	var scope *perm.Scope
+	scope, re = srv.getMessageScopeH(p.MessageId)
+	if re != nil {
+		return nil, re
+	}
+
+	re = srv.mustHavePermission(userRoles, perm.Permission_Moderate, scope)
+	if re != nil {
+		return nil, re
+	}
+
+	// Read revisions.
+	var revisions []mm.MessageRevision
+	revisions, err = srv.dbo.ReadMessageRevisionsByMessageId(p.MessageId)
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+
+	result = &rpc2.ListMessageRevisionsResult{
+		MessageRevisions: revisions,
+	}
+
+	return result, nil
+}
+
+// getMessageRevision reads a revision of a message and compares it with the
+// current text of the message.
+func (srv *Server) getMessageRevision(p *rpc2.GetMessageRevisionParams) (result *rpc2.GetMessageRevisionResult, re *jrm1.RpcError) {
+	// Check parameters.
+	if p.RevisionId == 0 {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_RevisionIdIsNotSet, RpcErrorMsg_RevisionIdIsNotSet, nil)
+	}
+
+	var userRoles *am.GetSelfRolesResult
+	userRoles, re = srv.mustBeAnAuthToken(p.Auth)
+	if re != nil {
+		return nil, re
+	}
+
+	srv.dbo.LockForReading()
+	defer srv.dbo.UnlockAfterReading()
+
+	// Read the revision.
+	revision, err := srv.dbo.GetMessageRevisionById(p.RevisionId)
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+
+	if revision == nil {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_RevisionIsNotFound, RpcErrorMsg_RevisionIsNotFound, nil)
+	}
+
+	// Read the message.
+	var message derived2.IMessage
+	message, err = srv.dbo.GetMessageById(revision.MessageId)
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+
+	if message == nil {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_MessageIsNotFound, RpcErrorMsg_MessageIsNotFound, nil)
+	}
+
+	// Check permissions.
+	var scope *perm.Scope
+	scope, re = srv.getThreadScopeH(message.GetThreadId())
+	if re != nil {
+		return nil, re
+	}
+
+	re = srv.mustHavePermission(userRoles, perm.Permission_Moderate, scope)
+	if re != nil {
+		return nil, re
+	}
+
+	result = &rpc2.GetMessageRevisionResult{
+		MessageRevision: revision,
+		IsIntact:        base2.Flag(srv.checkMessageTextChecksum(revision.Text, revision.TextChecksum)),
+		Diff:            mm.NewTextDiff(revision.Text.ToString(), message.GetTextPtr().ToString()),
+	}
+
+	return result, nil
+}
+
+// restoreMessageRevision sets the text of a message to the text of its
+// revision. The replaced text is saved as a new revision.
+func (srv *Server) restoreMessageRevision(p *rpc2.RestoreMessageRevisionParams) (result *rpc2.RestoreMessageRevisionResult, re *jrm1.RpcError) {
+	// Check parameters.
+	if p.RevisionId == 0 {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_RevisionIdIsNotSet, RpcErrorMsg_RevisionIdIsNotSet, nil)
+	}
+
+	var userRoles *am.GetSelfRolesResult
+	userRoles, re = srv.mustBeAnAuthToken(p.Auth)
+	if re != nil {
+		return nil, re
+	}
+
+	var revision *mm.MessageRevision
+	revision, re = srv.getMessageRevisionH(p.RevisionId, userRoles)
+	if re != nil {
+		return nil, re
+	}
+
+	// Damaged text must not get into the message.
+	if !srv.checkMessageTextChecksum(revision.Text, revision.TextChecksum) {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_RevisionIsDamaged, RpcErrorMsg_RevisionIsDamaged, nil)
+	}
+
+	var rm *renderedMessage
+	rm, re = srv.renderMessageText(p.Auth, revision.Text)
+	if re != nil {
+		return nil, re
+	}
+
+	var initialMessage derived2.IMessage
+	initialMessage, re = srv.changeMessageTextH(revision.MessageId, revision.Text, rm.html, userRoles)
+	if re != nil {
+		return nil, re
+	}
+
+	seData := sed.NewSystemEventDataWithValue(
+		set.NewSystemEventTypeWithValue(ev.NewEnumValue(set.SystemEventType_ThreadMessageEdit)),
+		initialMessage.GetThreadIdPtr(),
+		&revision.MessageId,
+		userRoles.User.GetUserParameters().GetIdPtr(),
+		nil,
+	)
+
+	se, err := cm.NewSystemEventWithData(seData)
+	if err != nil {
+		return nil, jrm1.NewRpcErrorByUser(c.RpcErrorCode_SystemEvent, c.RpcErrorMsg_SystemEvent, nil)
+	}
+
+	re = srv.reportSystemEvent(se)
+	if re != nil {
+		return nil, re
+	}
+
+	seData = sed.NewSystemEventDataWithValue(
+		set.NewSystemEventTypeWithValue(ev.NewEnumValue(set.SystemEventType_MessageTextEdit)),
+		initialMessage.GetThreadIdPtr(),
+		&revision.MessageId,
+		userRoles.User.GetUserParameters().GetIdPtr(),
+		initialMessage.GetEventData().GetCreatorUserIdPtr(),
+	)
+
+	se, err = cm.NewSystemEventWithData(seData)
+	if err != nil {
+		return nil, jrm1.NewRpcErrorByUser(c.RpcErrorCode_SystemEvent, c.RpcErrorMsg_SystemEvent, nil)
+	}
+
+	re = srv.reportSystemEvent(se)
+	if re != nil {
+		return nil, re
+	}
+
+	re = srv.reportMentions(initialMessage.GetThreadId(), revision.MessageId, userRoles.User.GetUserParameters().GetId(), rm.getMentionedUsers(initialMessage.GetTextPtr()))
+	if re != nil {
+		return nil, re
+	}
+
+	result = &rpc2.RestoreMessageRevisionResult{
+		Success: rpc3.Success{
+			OK: true,
+		},
+	}
+	return result, nil
+}
+
+// Composite objects.
+
+// listThreadAndMessages reads a thread and all its messages.
+func (srv *Server) listThreadAndMessages(p *rpc2.ListThreadAndMessagesParams) (result *rpc2.ListThreadAndMessagesResult, re *jrm1.RpcError) {
+	// Check parameters.
+	if p.ThreadId == 0 {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_ThreadIdIsNotSet, RpcErrorMsg_ThreadIdIsNotSet, nil)
+	}
+
+	var userRoles *am.GetSelfRolesResult
+	userRoles, re = srv.mustBeAnAuthToken(p.Auth)
+	if re != nil {
+		return nil, re
+	}
+
+	srv.dbo.LockForReading()
+	defer srv.dbo.UnlockAfterReading()
+
+	// Read the thread.
+	var thread derived2.IThread
+	var err error
+	thread, err = srv.dbo.GetThreadById(p.ThreadId)
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+
+	if thread == nil {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_ThreadIsNotFound, RpcErrorMsg_ThreadIsNotFound, nil)
+	}
+
+	// Check permissions.
+	var scope *perm.Scope
+	scope, re = srv.getForumScopeH(thread.GetForumId())
+	if re != nil {
+		return nil, re
+	}
+
+	re = srv.mustHavePermission(userRoles, perm.Permission_Read, scope)
+	if re != nil {
+		return nil, re
+	}
+
+	// Read messages.
+	var allMessageIds = thread.GetMessages()
+
+	var allMessages []derived2.IMessage
+	allMessages, err = srv.dbo.ReadMessagesById(allMessageIds)
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+
+	taM := tam.NewThreadAndMessages()
+	taM.SetThread(thread)
+	taM.SetMessages(allMessages)
+
+	result = &rpc2.ListThreadAndMessagesResult{
+		ThreadAndMessages: taM,
+	}
+
+	return result, nil
+}
+
+// listThreadAndMessagesOnPage reads a thread and its messages on a selected page.
+func (srv *Server) listThreadAndMessagesOnPage(p *rpc2.ListThreadAndMessagesOnPageParams) (result *rpc2.ListThreadAndMessagesOnPageResult, re *jrm1.RpcError) {
+	// Check parameters.
+	if p.ThreadId == 0 {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_ThreadIdIsNotSet, RpcErrorMsg_ThreadIdIsNotSet, nil)
+	}
+
+	if p.Page == 0 {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_PageIsNotSet, RpcErrorMsg_PageIsNotSet, nil)
+	}
+
+	var userRoles *am.GetSelfRolesResult
+	userRoles, re = srv.mustBeAnAuthToken(p.Auth)
+	if re != nil {
+		return nil, re
+	}
+
+	srv.dbo.LockForReading()
+	defer srv.dbo.UnlockAfterReading()
+
+	// Read the thread.
+	var thread derived2.IThread
+	var err error
+	thread, err = srv.dbo.GetThreadById(p.ThreadId)
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+
+	if thread == nil {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_ThreadIsNotFound, RpcErrorMsg_ThreadIsNotFound, nil)
+	}
+
+	// Check permissions.
+	var scope *perm.Scope
+	scope, re = srv.getForumScopeH(thread.GetForumId())
+	if re != nil {
+		return nil, re
+	}
+
+	re = srv.mustHavePermission(userRoles, perm.Permission_Read, scope)
+	if re != nil {
+		return nil, re
+	}
+
+	// Read messages.
+	var allMessageIds = thread.GetMessages()
+	var messageIdsOnPage = allMessageIds.OnPage(p.Page, srv.settings.SystemSettings.PageSize)
+
+	var messagesOnPage []derived2.IMessage
+	messagesOnPage, err = srv.dbo.ReadMessagesById(messageIdsOnPage)
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+
+	taM := tam.NewThreadAndMessages()
+	taM.SetThread(thread)
+	taM.SetMessages(messagesOnPage)
+	taM.SetPageData(&rpc3.PageData{
+		PageNumber:  p.Page,
+		TotalPages:  base2.CalculateTotalPages(allMessageIds.Size(), srv.settings.SystemSettings.PageSize),
+		PageSize:    srv.settings.SystemSettings.PageSize,
+		ItemsOnPage: messageIdsOnPage.Size(),
+		TotalItems:  allMessageIds.Size(),
+	})
+	thread.SetMessages(messageIdsOnPage)
+
+	// Read reactions.
+	var mrcs []mm.MessageReactionCount
+	mrcs, err = srv.dbo.ReadMessageReactionCountsById(messageIdsOnPage, userRoles.User.GetUserParameters().GetId())
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+
+	// Read attachments.
+	var attachments []mm.Attachment
+	attachments, err = srv.dbo.ReadAttachmentsByMessageIds(messageIdsOnPage)
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+
+	result = &rpc2.ListThreadAndMessagesOnPageResult{
+		ThreadAndMessagesOnPage: taM,
+		MessageReactions:        mm.GroupMessageReactionCounts(mrcs),
+		MessageAttachments:      mm.GroupAttachments(attachments),
+	}
+
+	return result, nil
+}
+
+// listForumAndThreads reads a forum and all its threads.
+func (srv *Server) listForumAndThreads(p *rpc2.ListForumAndThreadsParams) (result *rpc2.ListForumAndThreadsResult, re *jrm1.RpcError) {
+	// Check parameters.
+	if p.ForumId == 0 {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_ForumIdIsNotSet, RpcErrorMsg_ForumIdIsNotSet, nil)
+	}
+
+	var userRoles *am.GetSelfRolesResult
+	userRoles, re = srv.mustBeAnAuthToken(p.Auth)
+	if re != nil {
+		return nil, re
+	}
+
+	srv.dbo.LockForReading()
+	defer srv.dbo.UnlockAfterReading()
+
+	// Read the forum.
+	var forum derived2.IForum
+	var err error
+	forum, err = srv.dbo.GetForumById(p.ForumId)
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+
+	if forum == nil {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_ForumIsNotFound, RpcErrorMsg_ForumIsNotFound, nil)
+	}
+
+	// Check permissions.
+	var scope *perm.Scope
+	scope, re = srv.getForumScopeH(p.ForumId)
+	if re != nil {
+		return nil, re
+	}
+
+	re = srv.mustHavePermission(userRoles, perm.Permission_Read, scope)
+	if re != nil {
+		return nil, re
+	}
+
+	// Read threads. Pinned threads go first.
+	var allThreadIds *ul.UidList
+	allThreadIds, re = srv.getForumThreadsInOrderH(forum)
+	if re != nil {
+		return nil, re
+	}
+
+	var allThreads []derived2.IThread
+	allThreads, err = srv.dbo.ReadThreadsById(allThreadIds)
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+
+	var announcements []derived2.IThread
+	announcements, err = srv.dbo.ReadAnnouncementsOfSection(forum.GetSectionId(), forum.GetId())
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+
+	faT := fat.NewForumAndThreads()
+	faT.SetForum(forum)
+	faT.SetThreads(allThreads)
+	faT.SetAnnouncements(announcements)
+	forum.SetThreads(allThreadIds)
+
+	result = &rpc2.ListForumAndThreadsResult{
+		ForumAndThreads: faT,
+	}
+
+	return result, nil
+}
+
+// listForumAndThreadsOnPage reads a forum and its threads on a selected page.
+func (srv *Server) listForumAndThreadsOnPage(p *rpc2.ListForumAndThreadsOnPageParams) (result *rpc2.ListForumAndThreadsOnPageResult, re *jrm1.RpcError) {
+	// Check parameters.
+	if p.ForumId == 0 {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_ForumIdIsNotSet, RpcErrorMsg_ForumIdIsNotSet, nil)
+	}
+
+	if p.Page == 0 {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_PageIsNotSet, RpcErrorMsg_PageIsNotSet, nil)
+	}
+
+	var userRoles *am.GetSelfRolesResult
+	userRoles, re = srv.mustBeAnAuthToken(p.Auth)
+	if re != nil {
+		return nil, re
+	}
+
+	srv.dbo.LockForReading()
+	defer srv.dbo.UnlockAfterReading()
+
+	// Read the forum.
+	var forum derived2.IForum
+	var err error
+	forum, err = srv.dbo.GetForumById(p.ForumId)
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+
+	if forum == nil {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_ForumIsNotFound, RpcErrorMsg_ForumIsNotFound, nil)
+	}
+
+	// Check permissions.
+	var scope *perm.Scope
+	scope, re = srv.getForumScopeH(p.ForumId)
+	if re != nil {
+		return nil, re
+	}
+
+	re = srv.mustHavePermission(userRoles, perm.Permission_Read, scope)
+	if re != nil {
+		return nil, re
+	}
+
+	// Read threads. Pinned threads go first.
+	var allThreadIds *ul.UidList
+	allThreadIds, re = srv.getForumThreadsInOrderH(forum)
+	if re != nil {
+		return nil, re
+	}
+
+	var threadIdsOnPage = allThreadIds.OnPage(p.Page, srv.settings.SystemSettings.PageSize)
+
+	var threadsOnPage []derived2.IThread
+	threadsOnPage, err = srv.dbo.ReadThreadsById(threadIdsOnPage)
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+
+	var announcements []derived2.IThread
+	announcements, err = srv.dbo.ReadAnnouncementsOfSection(forum.GetSectionId(), forum.GetId())
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+
+	faT := fat.NewForumAndThreads()
+	faT.SetForum(forum)
+	faT.SetThreads(threadsOnPage)
+	faT.SetAnnouncements(announcements)
+	faT.SetPageData(&rpc3.PageData{
+		PageNumber:  p.Page,
+		TotalPages:  base2.CalculateTotalPages(allThreadIds.Size(), srv.settings.SystemSettings.PageSize),
+		PageSize:    srv.settings.SystemSettings.PageSize,
+		ItemsOnPage: threadIdsOnPage.Size(),
+		TotalItems:  allThreadIds.Size(),
+	})
+	forum.SetThreads(threadIdsOnPage)
+
+	result = &rpc2.ListForumAndThreadsOnPageResult{
+		ForumAndThreadsOnPage: faT,
+	}
+
+	return result, nil
+}
+
+// listSectionsAndForums reads all sections and forums.
+func (srv *Server) listSectionsAndForums(p *rpc2.ListSectionsAndForumsParams) (result *rpc2.ListSectionsAndForumsResult, re *jrm1.RpcError) {
+	var userRoles *am.GetSelfRolesResult
+	userRoles, re = srv.mustBeAnAuthToken(p.Auth)
+	if re != nil {
+		return nil, re
+	}
+
+	srv.dbo.LockForReading()
+	defer srv.dbo.UnlockAfterReading()
+
+	// Read all the sections.
+	var sections []derived2.ISection
+	var err error
+	sections, err = srv.dbo.ReadSections()
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+
+	// Read all the forums.
+	var forums []derived2.IForum
+	forums, err = srv.dbo.ReadForums()
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+
+	// Hide sections and forums which can not be read.
+	sections, forums, err = filterSectionsAndForums(userRoles, sections, forums)
+	if err != nil {
+		srv.logError(err)
+		return nil, jrm1.NewRpcErrorByUser(c.RpcErrorCode_UidList, fmt.Sprintf(c.RpcErrorMsgF_UidList, err.Error()), nil)
+	}
+
+	result = &rpc2.ListSectionsAndForumsResult{
+		SectionsAndForums: &mm.SectionsAndForums{
+			Sections: sections,
+			Forums:   forums,
+		},
+	}
+
+	return result, nil
+}
+
+// Search.
+
+// searchMessages finds messages containing the searched words.
+func (srv *Server) searchMessages(p *rpc2.SearchMessagesParams) (result *rpc2.SearchMessagesResult, re *jrm1.RpcError) {
+	// Check parameters.
+	if p.Page == 0 {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_PageIsNotSet, RpcErrorMsg_PageIsNotSet, nil)
+	}
+
+	var sf *mm.SearchFilter
+	sf, re = srv.makeSearchFilter(p.Text, p.ForumId, p.AuthorId, p.FromTime, p.ToTime)
+	if re != nil {
+		return nil, re
+	}
+
+	var userRoles *am.GetSelfRolesResult
+	userRoles, re = srv.mustBeAnAuthToken(p.Auth)
+	if re != nil {
+		return nil, re
+	}
+
+	srv.dbo.LockForReading()
+	defer srv.dbo.UnlockAfterReading()
+
+	// Search only in forums which can be read.
+	sf.ForumIds, re = srv.getReadableForumIdsH(userRoles)
+	if re != nil {
+		return nil, re
+	}
+
+	// Find messages on page.
+	messageIds, err := srv.dbo.SearchMessages(sf, p.Page, srv.settings.SystemSettings.PageSize)
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+
+	// Count all found messages.
+	var allMessagesCount base2.Count
+	allMessagesCount, err = srv.dbo.CountMessagesFound(sf)
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+
+	var messages = []derived2.IMessage{}
+	if messageIds.Size() > 0 {
+		messages, err = srv.dbo.ReadMessagesById(messageIds)
+		if err != nil {
+			return nil, srv.databaseError(err)
+		}
+	}
+
+	mf := mm.NewMessagesFound()
+	mf.MessageIds = messageIds
+	mf.Messages = messages
+	mf.PageData = &rpc3.PageData{
+		PageNumber:  p.Page,
+		TotalPages:  base2.CalculateTotalPages(allMessagesCount, srv.settings.SystemSettings.PageSize),
+		PageSize:    srv.settings.SystemSettings.PageSize,
+		ItemsOnPage: messageIds.Size(),
+		TotalItems:  allMessagesCount,
+	}
+
+	result = &rpc2.SearchMessagesResult{
+		MessagesFound: mf,
+	}
+
+	return result, nil
+}
+
+// searchThreads finds threads having the searched words in their names.
+func (srv *Server) searchThreads(p *rpc2.SearchThreadsParams) (result *rpc2.SearchThreadsResult, re *jrm1.RpcError) {
+	// Check parameters.
+	if p.Page == 0 {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_PageIsNotSet, RpcErrorMsg_PageIsNotSet, nil)
+	}
+
+	var sf *mm.SearchFilter
+	sf, re = srv.makeSearchFilter(p.Text, p.ForumId, p.AuthorId, p.FromTime, p.ToTime)
+	if re != nil {
+		return nil, re
+	}
+
+	var userRoles *am.GetSelfRolesResult
+	userRoles, re = srv.mustBeAnAuthToken(p.Auth)
+	if re != nil {
+		return nil, re
+	}
+
+	srv.dbo.LockForReading()
+	defer srv.dbo.UnlockAfterReading()
+
+	// Search only in forums which can be read.
+	sf.ForumIds, re = srv.getReadableForumIdsH(userRoles)
+	if re != nil {
+		return nil, re
+	}
+
+	// Find threads on page.
+	threadIds, err := srv.dbo.SearchThreads(sf, p.Page, srv.settings.SystemSettings.PageSize)
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+
+	// Count all found threads.
+	var allThreadsCount base2.Count
+	allThreadsCount, err = srv.dbo.CountThreadsFound(sf)
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+
+	var threads = []derived2.IThread{}
+	if threadIds.Size() > 0 {
+		threads, err = srv.dbo.ReadThreadsById(threadIds)
+		if err != nil {
+			return nil, srv.databaseError(err)
+		}
+	}
+
+	tf := mm.NewThreadsFound()
+	tf.ThreadIds = threadIds
+	tf.Threads = threads
+	tf.PageData = &rpc3.PageData{
+		PageNumber:  p.Page,
+		TotalPages:  base2.CalculateTotalPages(allThreadsCount, srv.settings.SystemSettings.PageSize),
+		PageSize:    srv.settings.SystemSettings.PageSize,
+		ItemsOnPage: threadIds.Size(),
+		TotalItems:  allThreadsCount,
+	}
+
+	result = &rpc2.SearchThreadsResult{
+		ThreadsFound: tf,
+	}
+
+	return result, nil
+}
+
+// Private messages.
+
+// startConversation creates a new private conversation with its first
+// message.
+func (srv *Server) startConversation(p *rpc2.StartConversationParams) (result *rpc2.StartConversationResult, re *jrm1.RpcError) {
+	// Check parameters.
+	if len(p.Subject) == 0 {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_SubjectIsNotSet, RpcErrorMsg_SubjectIsNotSet, nil)
+	}
+
+	if len(p.Text) == 0 {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_MessageTextIsNotSet, RpcErrorMsg_MessageTextIsNotSet, nil)
+	}
+
+	var userRoles *am.GetSelfRolesResult
+	userRoles, re = srv.mustBeAnAuthToken(p.Auth)
+	if re != nil {
+		return nil, re
+	}
+
+	// Check permissions.
+	if !userRoles.User.GetUserParameters().GetRoles().CanLogIn {
+		return nil, jrm1.NewRpcErrorByUser(c.RpcErrorCode_Permission, c.RpcErrorMsg_Permission, nil)
+	}
+
+	senderUserId := userRoles.User.GetUserParameters().GetId()
+
+	var members *ul.UidList
+	var recipients []base2.Id
+	members, recipients, re = srv.makeConversationMembers(senderUserId, p.UserIds)
+	if re != nil {
+		return nil, re
+	}
+
+	re = srv.mustUsersExist(p.Auth, recipients)
+	if re != nil {
+		return nil, re
+	}
+
+	result, re = srv.startConversationH(p.Subject, p.Text, senderUserId, members, recipients)
+	if re != nil {
+		return nil, re
+	}
+
+	re = srv.notifyAboutPrivateMessage(result.ConversationId, senderUserId, recipients)
+	if re != nil {
+		return nil, re
+	}
+
+	return result, nil
+}
+
+// sendPrivateMessage adds a new message into a private conversation.
+func (srv *Server) sendPrivateMessage(p *rpc2.SendPrivateMessageParams) (result *rpc2.SendPrivateMessageResult, re *jrm1.RpcError) {
+	// Check parameters.
+	if p.ConversationId == 0 {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_ConversationIdIsNotSet, RpcErrorMsg_ConversationIdIsNotSet, nil)
+	}
+
+	if len(p.Text) == 0 {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_MessageTextIsNotSet, RpcErrorMsg_MessageTextIsNotSet, nil)
+	}
+
+	var userRoles *am.GetSelfRolesResult
+	userRoles, re = srv.mustBeAnAuthToken(p.Auth)
+	if re != nil {
+		return nil, re
+	}
+
+	// Check permissions.
+	if !userRoles.User.GetUserParameters().GetRoles().CanLogIn {
+		return nil, jrm1.NewRpcErrorByUser(c.RpcErrorCode_Permission, c.RpcErrorMsg_Permission, nil)
+	}
+
+	senderUserId := userRoles.User.GetUserParameters().GetId()
+
+	var recipients []base2.Id
+	result, recipients, re = srv.sendPrivateMessageH(p.ConversationId, p.Text, senderUserId)
+	if re != nil {
+		return nil, re
+	}
+
+	re = srv.notifyAboutPrivateMessage(p.ConversationId, senderUserId, recipients)
+	if re != nil {
+		return nil, re
+	}
+
+	return result, nil
+}
+
+// listConversations reads a page of the user's inbox.
+func (srv *Server) listConversations(p *rpc2.ListConversationsParams) (result *rpc2.ListConversationsResult, re *jrm1.RpcError) {
+	// Check parameters.
+	if p.Page == 0 {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_PageIsNotSet, RpcErrorMsg_PageIsNotSet, nil)
+	}
+
+	var userRoles *am.GetSelfRolesResult
+	userRoles, re = srv.mustBeAnAuthToken(p.Auth)
+	if re != nil {
+		return nil, re
+	}
+
+	// Check permissions.
+	if !userRoles.User.GetUserParameters().GetRoles().CanLogIn {
+		return nil, jrm1.NewRpcErrorByUser(c.RpcErrorCode_Permission, c.RpcErrorMsg_Permission, nil)
+	}
+
+	userId := userRoles.User.GetUserParameters().GetId()
+
+	srv.dbo.LockForReading()
+	defer srv.dbo.UnlockAfterReading()
+
+	conversations, err := srv.dbo.ReadConversationsOfUserOnPage(userId, p.Page, srv.settings.SystemSettings.PageSize)
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+
+	var allConversationsCount base2.Count
+	allConversationsCount, err = srv.dbo.CountConversationsOfUser(userId)
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+
+	cop := mm.NewConversationsOnPage()
+	cop.Conversations = conversations
+	cop.PageData = &rpc3.PageData{
+		PageNumber:  p.Page,
+		TotalPages:  base2.CalculateTotalPages(allConversationsCount, srv.settings.SystemSettings.PageSize),
+		PageSize:    srv.settings.SystemSettings.PageSize,
+		ItemsOnPage: base2.Count(len(conversations)),
+		TotalItems:  allConversationsCount,
+	}
+
+	result = &rpc2.ListConversationsResult{
+		ConversationsOnPage: cop,
+	}
+
+	return result, nil
+}
+
+// readConversation reads a conversation and a page of its messages. Messages
+// shown on the page are marked as read.
+func (srv *Server) readConversation(p *rpc2.ReadConversationParams) (result *rpc2.ReadConversationResult, re *jrm1.RpcError) {
+	// Check parameters.
+	if p.ConversationId == 0 {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_ConversationIdIsNotSet, RpcErrorMsg_ConversationIdIsNotSet, nil)
+	}
+
+	if p.Page == 0 {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_PageIsNotSet, RpcErrorMsg_PageIsNotSet, nil)
+	}
+
+	var userRoles *am.GetSelfRolesResult
+	userRoles, re = srv.mustBeAnAuthToken(p.Auth)
+	if re != nil {
+		return nil, re
+	}
+
+	// Check permissions.
+	if !userRoles.User.GetUserParameters().GetRoles().CanLogIn {
+		return nil, jrm1.NewRpcErrorByUser(c.RpcErrorCode_Permission, c.RpcErrorMsg_Permission, nil)
+	}
+
+	userId := userRoles.User.GetUserParameters().GetId()
+
+	// Reading marks messages as read.
+	srv.dbo.LockForWriting()
+	defer srv.dbo.UnlockAfterWriting()
+
+	conversation, err := srv.dbo.GetConversationById(userId, p.ConversationId)
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+
+	if conversation == nil {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_ConversationIsNotFound, RpcErrorMsg_ConversationIsNotFound, nil)
+	}
+
+	var messages []mm.PrivateMessage
+	messages, err = srv.dbo.ReadPrivateMessagesOnPage(p.ConversationId, p.Page, srv.settings.SystemSettings.PageSize)
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+
+	var allMessagesCount base2.Count
+	allMessagesCount, err = srv.dbo.CountPrivateMessages(p.ConversationId)
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+
+	lastMessageId := mm.LastPrivateMessageId(messages)
+	if lastMessageId > 0 {
+		err = srv.dbo.SetLastReadPrivateMessageId(p.ConversationId, userId, lastMessageId)
+		if err != nil {
+			return nil, srv.databaseError(err)
+		}
+	}
+
+	cam := mm.NewConversationAndMessages()
+	cam.Conversation = conversation
+	cam.Messages = messages
+	cam.PageData = &rpc3.PageData{
+		PageNumber:  p.Page,
+		TotalPages:  base2.CalculateTotalPages(allMessagesCount, srv.settings.SystemSettings.PageSize),
+		PageSize:    srv.settings.SystemSettings.PageSize,
+		ItemsOnPage: base2.Count(len(messages)),
+		TotalItems:  allMessagesCount,
+	}
+
+	result = &rpc2.ReadConversationResult{
+		ConversationAndMessages: cam,
+	}
+
+	return result, nil
+}
+
+// deleteConversation removes a conversation from the user's inbox. Other
+// members still see the conversation. The conversation is deleted when its
+// last member leaves it.
+func (srv *Server) deleteConversation(p *rpc2.DeleteConversationParams) (result *rpc2.DeleteConversationResult, re *jrm1.RpcError) {
+	// Check parameters.
+	if p.ConversationId == 0 {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_ConversationIdIsNotSet, RpcErrorMsg_ConversationIdIsNotSet, nil)
+	}
+
+	var userRoles *am.GetSelfRolesResult
+	userRoles, re = srv.mustBeAnAuthToken(p.Auth)
+	if re != nil {
+		return nil, re
+	}
+
+	// Check permissions.
+	if !userRoles.User.GetUserParameters().GetRoles().CanLogIn {
+		return nil, jrm1.NewRpcErrorByUser(c.RpcErrorCode_Permission, c.RpcErrorMsg_Permission, nil)
+	}
+
+	userId := userRoles.User.GetUserParameters().GetId()
+
+	srv.dbo.LockForWriting()
+	defer srv.dbo.UnlockAfterWriting()
+
+	conversation, err := srv.dbo.GetConversationById(userId, p.ConversationId)
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+
+	if conversation == nil {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_ConversationIsNotFound, RpcErrorMsg_ConversationIsNotFound, nil)
+	}
+
+	err = srv.dbo.DeleteConversationMember(p.ConversationId, userId)
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+
+	var n base2.Count
+	n, err = srv.dbo.CountConversationMembers(p.ConversationId)
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+
+	if n == 0 {
+		err = srv.dbo.DeletePrivateMessagesByConversationId(p.ConversationId)
+		if err != nil {
+			return nil, srv.databaseError(err)
+		}
+
+		err = srv.dbo.DeleteConversationById(p.ConversationId)
+		if err != nil {
+			return nil, srv.databaseError(err)
+		}
+	}
+
+	result = &rpc2.DeleteConversationResult{
+		Success: rpc3.Success{
+			OK: true,
+		},
+	}
+
+	return result, nil
+}
+
+// countUnreadPrivateMessages counts private messages which are not yet read
+// by the user in all the conversations.
+func (srv *Server) countUnreadPrivateMessages(p *rpc2.CountUnreadPrivateMessagesParams) (result *rpc2.CountUnreadPrivateMessagesResult, re *jrm1.RpcError) {
+	var userRoles *am.GetSelfRolesResult
+	userRoles, re = srv.mustBeAnAuthToken(p.Auth)
+	if re != nil {
+		return nil, re
+	}
+
+	// Check permissions.
+	if !userRoles.User.GetUserParameters().GetRoles().CanLogIn {
+		return nil, jrm1.NewRpcErrorByUser(c.RpcErrorCode_Permission, c.RpcErrorMsg_Permission, nil)
+	}
+
+	srv.dbo.LockForReading()
+	defer srv.dbo.UnlockAfterReading()
+
+	n, err := srv.dbo.CountUnreadPrivateMessages(userRoles.User.GetUserParameters().GetId())
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+
+	result = &rpc2.CountUnreadPrivateMessagesResult{
+		UPMC: n,
+	}
+
+	return result, nil
+}
+
+// blockUser blocks private messages from another user.
+func (srv *Server) blockUser(p *rpc2.BlockUserParams) (result *rpc2.BlockUserResult, re *jrm1.RpcError) {
+	// Check parameters.
+	if p.UserId == 0 {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_UserIdIsNotSet, RpcErrorMsg_UserIdIsNotSet, nil)
+	}
+
+	var userRoles *am.GetSelfRolesResult
+	userRoles, re = srv.mustBeAnAuthToken(p.Auth)
+	if re != nil {
+		return nil, re
+	}
+
+	// Check permissions.
+	if !userRoles.User.GetUserParameters().GetRoles().CanLogIn {
+		return nil, jrm1.NewRpcErrorByUser(c.RpcErrorCode_Permission, c.RpcErrorMsg_Permission, nil)
+	}
+
+	userId := userRoles.User.GetUserParameters().GetId()
+	if p.UserId == userId {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_SelfBlock, RpcErrorMsg_SelfBlock, nil)
+	}
+
+	re = srv.mustUsersExist(p.Auth, []base2.Id{p.UserId})
+	if re != nil {
+		return nil, re
+	}
+
+	srv.dbo.LockForWriting()
+	defer srv.dbo.UnlockAfterWriting()
+
+	err := srv.dbo.InsertUserBlock(userId, p.UserId)
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+
+	result = &rpc2.BlockUserResult{
+		Success: rpc3.Success{
+			OK: true,
+		},
+	}
+
+	return result, nil
+}
+
+// unblockUser allows private messages from a previously blocked user.
+func (srv *Server) unblockUser(p *rpc2.UnblockUserParams) (result *rpc2.UnblockUserResult, re *jrm1.RpcError) {
+	// Check parameters.
+	if p.UserId == 0 {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_UserIdIsNotSet, RpcErrorMsg_UserIdIsNotSet, nil)
+	}
+
+	var userRoles *am.GetSelfRolesResult
+	userRoles, re = srv.mustBeAnAuthToken(p.Auth)
+	if re != nil {
+		return nil, re
+	}
+
+	// Check permissions.
+	if !userRoles.User.GetUserParameters().GetRoles().CanLogIn {
+		return nil, jrm1.NewRpcErrorByUser(c.RpcErrorCode_Permission, c.RpcErrorMsg_Permission, nil)
+	}
+
+	srv.dbo.LockForWriting()
+	defer srv.dbo.UnlockAfterWriting()
+
+	err := srv.dbo.DeleteUserBlock(userRoles.User.GetUserParameters().GetId(), p.UserId)
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+
+	result = &rpc2.UnblockUserResult{
+		Success: rpc3.Success{
+			OK: true,
+		},
+	}
+
+	return result, nil
+}
+
+// listBlockedUsers lists users whose private messages are blocked by the user.
+func (srv *Server) listBlockedUsers(p *rpc2.ListBlockedUsersParams) (result *rpc2.ListBlockedUsersResult, re *jrm1.RpcError) {
+	var userRoles *am.GetSelfRolesResult
+	userRoles, re = srv.mustBeAnAuthToken(p.Auth)
+	if re != nil {
+		return nil, re
+	}
+
+	// Check permissions.
+	if !userRoles.User.GetUserParameters().GetRoles().CanLogIn {
+		return nil, jrm1.NewRpcErrorByUser(c.RpcErrorCode_Permission, c.RpcErrorMsg_Permission, nil)
+	}
+
+	srv.dbo.LockForReading()
+	defer srv.dbo.UnlockAfterReading()
+
+	userIds, err := srv.dbo.ReadBlockedUsers(userRoles.User.GetUserParameters().GetId())
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+
+	result = &rpc2.ListBlockedUsersResult{
+		UserIds: userIds,
+	}
+
+	return result, nil
+}
+
+// Polls.
+
+// getPoll reads a poll of a thread. Results of the poll are shown unless
+// they are hidden until the poll is closed.
+func (srv *Server) getPoll(p *rpc2.GetPollParams) (result *rpc2.GetPollResult, re *jrm1.RpcError) {
+	// Check parameters.
+	if p.ThreadId == 0 {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_ThreadIdIsNotSet, RpcErrorMsg_ThreadIdIsNotSet, nil)
+	}
+
+	var userRoles *am.GetSelfRolesResult
+	userRoles, re = srv.mustBeAnAuthToken(p.Auth)
+	if re != nil {
+		return nil, re
+	}
+
+	srv.dbo.LockForReading()
+	defer srv.dbo.UnlockAfterReading()
+
+	var poll *mm.Poll
+	poll, re = srv.getPollH(p.ThreadId, userRoles)
+	if re != nil {
+		return nil, re
+	}
+
+	var err error
+	result = &rpc2.GetPollResult{
+		Poll: poll,
+	}
+
+	result.SelfOptionIds, err = srv.dbo.ReadPollVotesOfUser(poll.Id, userRoles.User.GetUserParameters().GetId())
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+
+	if !poll.AreResultsVisible(time.Now()) {
+		return result, nil
+	}
+
+	result.Results = &mm.PollResults{}
+
+	result.Results.VotersCount, err = srv.dbo.CountPollVoters(poll.Id)
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+
+	result.Results.Options, err = srv.dbo.ReadPollOptionResults(poll.Id)
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+
+	return result, nil
+}
+
+// voteInPoll saves a vote of a user in a poll. A user may vote only once.
+func (srv *Server) voteInPoll(p *rpc2.VoteInPollParams) (result *rpc2.VoteInPollResult, re *jrm1.RpcError) {
+	// Check parameters.
+	if p.ThreadId == 0 {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_ThreadIdIsNotSet, RpcErrorMsg_ThreadIdIsNotSet, nil)
+	}
+
+	var userRoles *am.GetSelfRolesResult
+	userRoles, re = srv.mustBeAnAuthToken(p.Auth)
+	if re != nil {
+		return nil, re
+	}
+
+	// Check permissions.
+	if !userRoles.User.GetUserParameters().GetRoles().CanLogIn {
+		return nil, jrm1.NewRpcErrorByUser(c.RpcErrorCode_Permission, c.RpcErrorMsg_Permission, nil)
+	}
+
+	re = srv.voteInPollH(p.ThreadId, p.OptionIds, userRoles, false)
+	if re != nil {
+		return nil, re
+	}
+
+	result = &rpc2.VoteInPollResult{
+		Success: rpc3.Success{
+			OK: true,
+		},
+	}
+
+	return result, nil
+}
+
+// changePollVote replaces a vote of a user in a poll while the poll is open.
+func (srv *Server) changePollVote(p *rpc2.ChangePollVoteParams) (result *rpc2.ChangePollVoteResult, re *jrm1.RpcError) {
+	// Check parameters.
+	if p.ThreadId == 0 {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_ThreadIdIsNotSet, RpcErrorMsg_ThreadIdIsNotSet, nil)
+	}
+
+	var userRoles *am.GetSelfRolesResult
+	userRoles, re = srv.mustBeAnAuthToken(p.Auth)
+	if re != nil {
+		return nil, re
+	}
+
+	// Check permissions.
+	if !userRoles.User.GetUserParameters().GetRoles().CanLogIn {
+		return nil, jrm1.NewRpcErrorByUser(c.RpcErrorCode_Permission, c.RpcErrorMsg_Permission, nil)
+	}
+
+	re = srv.voteInPollH(p.ThreadId, p.OptionIds, userRoles, true)
+	if re != nil {
+		return nil, re
+	}
+
+	result = &rpc2.ChangePollVoteResult{
+		Success: rpc3.Success{
+			OK: true,
+		},
+	}
+
+	return result, nil
+}
+
+// Reactions.
+
+// listReactions lists reactions which users may put on messages.
+func (srv *Server) listReactions(p *rpc2.ListReactionsParams) (result *rpc2.ListReactionsResult, re *jrm1.RpcError) {
+	_, re = srv.mustBeAnAuthToken(p.Auth)
+	if re != nil {
+		return nil, re
+	}
+
+	result = &rpc2.ListReactionsResult{
+		Reactions: srv.settings.SystemSettings.Reactions,
+	}
+
+	return result, nil
+}
+
+// addMessageReaction puts a reaction on a message. Reputation of the
+// message's author is changed by the weight of the reaction.
+func (srv *Server) addMessageReaction(p *rpc2.AddMessageReactionParams) (result *rpc2.AddMessageReactionResult, re *jrm1.RpcError) {
+	// Check parameters.
+	if p.MessageId == 0 {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_MessageIdIsNotSet, RpcErrorMsg_MessageIdIsNotSet, nil)
+	}
+
+	if len(p.Emoji) == 0 {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_EmojiIsNotSet, RpcErrorMsg_EmojiIsNotSet, nil)
+	}
+
+	reaction := mm.FindReaction(srv.settings.SystemSettings.Reactions, p.Emoji)
+	if reaction == nil {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_ReactionIsNotAllowed, RpcErrorMsg_ReactionIsNotAllowed, nil)
+	}
+
+	var userRoles *am.GetSelfRolesResult
+	userRoles, re = srv.mustBeAnAuthToken(p.Auth)
+	if re != nil {
+		return nil, re
+	}
+
+	// Check permissions.
+	if !userRoles.User.GetUserParameters().GetRoles().CanLogIn {
+		return nil, jrm1.NewRpcErrorByUser(c.RpcErrorCode_Permission, c.RpcErrorMsg_Permission, nil)
+	}
+
+	var message derived2.IMessage
+	message, re = srv.addMessageReactionH(p.MessageId, reaction, userRoles)
+	if re != nil {
+		return nil, re
+	}
+
+	seData := sed.NewSystemEventDataWithValue(
+		set.NewSystemEventTypeWithValue(ev.NewEnumValue(set.SystemEventType_MessageReaction)),
+		message.GetThreadIdPtr(),
+		&p.MessageId,
+		userRoles.User.GetUserParameters().GetIdPtr(),
+		message.GetEventData().GetCreatorUserIdPtr(),
+	)
+
+	se, err := cm.NewSystemEventWithData(seData)
+	if err != nil {
+		return nil, jrm1.NewRpcErrorByUser(c.RpcErrorCode_SystemEvent, c.RpcErrorMsg_SystemEvent, nil)
+	}
+
+	re = srv.reportSystemEvent(se)
+	if re != nil {
+		return nil, re
+	}
+
+	result = &rpc2.AddMessageReactionResult{
+		Success: rpc3.Success{
+			OK: true,
+		},
+	}
+
+	return result, nil
+}
+
+// removeMessageReaction removes a reaction of the user from a message.
+// Reputation of the message's author is changed back.
+func (srv *Server) removeMessageReaction(p *rpc2.RemoveMessageReactionParams) (result *rpc2.RemoveMessageReactionResult, re *jrm1.RpcError) {
+	// Check parameters.
+	if p.MessageId == 0 {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_MessageIdIsNotSet, RpcErrorMsg_MessageIdIsNotSet, nil)
+	}
+
+	if len(p.Emoji) == 0 {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_EmojiIsNotSet, RpcErrorMsg_EmojiIsNotSet, nil)
+	}
+
+	var userRoles *am.GetSelfRolesResult
+	userRoles, re = srv.mustBeAnAuthToken(p.Auth)
+	if re != nil {
+		return nil, re
+	}
+
+	// Check permissions.
+	if !userRoles.User.GetUserParameters().GetRoles().CanLogIn {
+		return nil, jrm1.NewRpcErrorByUser(c.RpcErrorCode_Permission, c.RpcErrorMsg_Permission, nil)
+	}
+
+	re = srv.removeMessageReactionH(p.MessageId, p.Emoji, userRoles.User.GetUserParameters().GetId())
+	if re != nil {
+		return nil, re
+	}
+
+	result = &rpc2.RemoveMessageReactionResult{
+		Success: rpc3.Success{
+			OK: true,
+		},
+	}
+
+	return result, nil
+}
+
+// getUserReputation reads the reputation of a user.
+func (srv *Server) getUserReputation(p *rpc2.GetUserReputationParams) (result *rpc2.GetUserReputationResult, re *jrm1.RpcError) {
+	// Check parameters.
+	if p.UserId == 0 {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_UserIdIsNotSet, RpcErrorMsg_UserIdIsNotSet, nil)
+	}
+
+	_, re = srv.mustBeAnAuthToken(p.Auth)
+	if re != nil {
+		return nil, re
+	}
+
+	srv.dbo.LockForReading()
+	defer srv.dbo.UnlockAfterReading()
+
+	reputation, err := srv.dbo.GetUserReputation(p.UserId)
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+
+	result = &rpc2.GetUserReputationResult{
+		UserId:     p.UserId,
+		Reputation: reputation,
+	}
+
+	return result, nil
+}
+
+// Attachments.
+
+// getAttachment reads a file attached to a message or its thumbnail.
+func (srv *Server) getAttachment(p *rpc2.GetAttachmentParams) (result *rpc2.GetAttachmentResult, re *jrm1.RpcError) {
+	// Check parameters.
+	if p.AttachmentId == 0 {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_AttachmentIdIsNotSet, RpcErrorMsg_AttachmentIdIsNotSet, nil)
+	}
+
+	var userRoles *am.GetSelfRolesResult
+	userRoles, re = srv.mustBeAnAuthToken(p.Auth)
+	if re != nil {
+		return nil, re
+	}
+
+	srv.dbo.LockForReading()
+	defer srv.dbo.UnlockAfterReading()
+
+	// Read the attachment.
+	var attachment *mm.Attachment
+	var err error
+	attachment, err = srv.dbo.GetAttachmentById(p.AttachmentId)
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+
+	if attachment == nil {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_AttachmentIsNotFound, RpcErrorMsg_AttachmentIsNotFound, nil)
+	}
+
+	// Check permissions.
+	var threadId base2.Id
+	threadId, err = srv.dbo.GetMessageThreadById(attachment.MessageId)
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+
+	var scope *perm.Scope
+	scope, re = srv.getThreadScopeH(threadId)
+	if re != nil {
+		return nil, re
+	}
+
+	re = srv.mustHavePermission(userRoles, perm.Permission_Read, scope)
+	if re != nil {
+		return nil, re
+	}
+
+	// Read the file.
+	result = &rpc2.GetAttachmentResult{
+		Attachment:  attachment,
+		ContentType: attachment.ContentType,
+	}
+
+	var hash = attachment.BlobHash
+	if p.IsThumbnail {
+		if attachment.ThumbnailHash == nil {
+			return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_ThumbnailIsNotFound, RpcErrorMsg_ThumbnailIsNotFound, nil)
+		}
+
+		hash = *attachment.ThumbnailHash
+		result.ContentType = blob.ThumbnailContentType
+	}
+
+	result.Data, err = srv.blobStore.Read(hash)
+	if err != nil {
+		return nil, srv.blobStoreError(err)
+	}
+
+	return result, nil
+}
+
+// Trash.
+
+// listTrash reads a page of the trash.
+func (srv *Server) listTrash(p *rpc2.ListTrashParams) (result *rpc2.ListTrashResult, re *jrm1.RpcError) {
+	// Check parameters.
+	if p.Page == 0 {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_PageIsNotSet, RpcErrorMsg_PageIsNotSet, nil)
+	}
+
+	var userRoles *am.GetSelfRolesResult
+	userRoles, re = srv.mustBeAnAuthToken(p.Auth)
+	if re != nil {
+		return nil, re
+	}
+
+	// Check permissions.
+	if !userRoles.User.GetUserParameters().GetRoles().IsAdministrator {
+		return nil, jrm1.NewRpcErrorByUser(c.RpcErrorCode_Permission, c.RpcErrorMsg_Permission, nil)
+	}
+
+	srv.dbo.LockForReading()
+	defer srv.dbo.UnlockAfterReading()
+
+	items, err := srv.dbo.ReadTrashItemsOnPage(p.Page, srv.settings.SystemSettings.PageSize)
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+
+	var allItemsCount base2.Count
+	allItemsCount, err = srv.dbo.CountTrashItems()
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+
+	top := mm.NewTrashOnPage()
+	top.Items = items
+	top.PageData = &rpc3.PageData{
+		PageNumber:  p.Page,
+		TotalPages:  base2.CalculateTotalPages(allItemsCount, srv.settings.SystemSettings.PageSize),
+		PageSize:    srv.settings.SystemSettings.PageSize,
+		ItemsOnPage: base2.Count(len(items)),
+		TotalItems:  allItemsCount,
+	}
+
+	result = &rpc2.ListTrashResult{
+		TrashOnPage: top,
+	}
+
+	return result, nil
+}
+
+// restoreFromTrash puts a deleted section, forum, thread or message back to
+// its place.
+func (srv *Server) restoreFromTrash(p *rpc2.RestoreFromTrashParams) (result *rpc2.RestoreFromTrashResult, re *jrm1.RpcError) {
 	// Check parameters.
-	if p.MessageId == 0 {
-		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_MessageIdIsNotSet, RpcErrorMsg_MessageIdIsNotSet, nil)
+	if p.TrashItemId == 0 {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_TrashItemIdIsNotSet, RpcErrorMsg_TrashItemIdIsNotSet, nil)
 	}
 
 	var userRoles *am.GetSelfRolesResult
@@ -1909,49 +4106,19 @@ func (srv *Server) deleteMessage(p *rpc2.DeleteMessageParams) (result *rpc2.Dele
 		return nil, jrm1.NewRpcErrorByUser(c.RpcErrorCode_Permission, c.RpcErrorMsg_Permission, nil)
 	}
 
-	var initialMessage derived2.IMessage
-	initialMessage, re = srv.deleteMessageH(p.MessageId)
-	if re != nil {
-		return nil, re
-	}
-
-	seData := sed.NewSystemEventDataWithValue(
-		set.NewSystemEventTypeWithValue(ev.NewEnumValue(set.SystemEventType_ThreadMessageDeletion)),
-		initialMessage.GetThreadIdPtr(),
-		&p.MessageId,
-		userRoles.User.GetUserParameters().GetIdPtr(),
-		nil,
-	)
-
-	se, err := cm.NewSystemEventWithData(seData)
-	if err != nil {
-		return nil, jrm1.NewRpcErrorByUser(c.RpcErrorCode_SystemEvent, c.RpcErrorMsg_SystemEvent, nil)
-	}
-
-	re = srv.reportSystemEvent(se)
+	re = srv.restoreFromTrashH(p.TrashItemId)
 	if re != nil {
 		return nil, re
 	}
 
-	seData = sed.NewSystemEventDataWithValue(
-		set.NewSystemEventTypeWithValue(ev.NewEnumValue(set.SystemEventType_MessageDeletion)),
-		initialMessage.GetThreadIdPtr(),
-		&p.MessageId,
-		userRoles.User.GetUserParameters().GetIdPtr(),
-		initialMessage.GetEventData().GetCreatorUserIdPtr(),
-	)
-
-	se, err = cm.NewSystemEventWithData(seData)
-	if err != nil {
-		return nil, jrm1.NewRpcErrorByUser(c.RpcErrorCode_SystemEvent, c.RpcErrorMsg_SystemEvent, nil)
-	}
-
-	re = srv.reportSystemEvent(se)
-	if re != nil {
-		return nil, re
-	}
+	srv.audit(p.Auth, &al.Record{
+		ActorUserId: userRoles.User.GetUserParameters().GetId(),
+		Action:      al.Action_RestoreFromTrash,
+		TargetType:  al.TargetType_TrashItem,
+		TargetId:    p.TrashItemId,
+	})
 
-	result = &rpc2.DeleteMessageResult{
+	result = &rpc2.RestoreFromTrashResult{
 		Success: rpc3.Success{
 			OK: true,
 		},
@@ -1959,15 +4126,13 @@ func (srv *Server) deleteMessage(p *rpc2.DeleteMessageParams) (result *rpc2.Dele
 	return result, nil
 }
 
-// Composite objects.
-
-// listThreadAndMessages reads a thread and all its messages.
-func (srv *Server) listThreadAndMessages(p *rpc2.ListThreadAndMessagesParams) (result *rpc2.ListThreadAndMessagesResult, re *jrm1.RpcError) {
-	// Check parameters.
-	if p.ThreadId == 0 {
-		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_ThreadIdIsNotSet, RpcErrorMsg_ThreadIdIsNotSet, nil)
-	}
+// Consistency.
 
+// repairDatabaseConsistency rebuilds lists of children of sections, forums and
+// threads from the actual parents of objects. Objects detached from the
+// board's structure are re-linked into the Lost+Found forum. Without the apply
+// flag only a report is made.
+func (srv *Server) repairDatabaseConsistency(p *rpc2.RepairDatabaseConsistencyParams) (result *rpc2.RepairDatabaseConsistencyResult, re *jrm1.RpcError) {
 	var userRoles *am.GetSelfRolesResult
 	userRoles, re = srv.mustBeAnAuthToken(p.Auth)
 	if re != nil {
@@ -1975,54 +4140,48 @@ func (srv *Server) listThreadAndMessages(p *rpc2.ListThreadAndMessagesParams) (r
 	}
 
 	// Check permissions.
-	if !userRoles.User.GetUserParameters().GetRoles().IsReader {
+	if !userRoles.User.GetUserParameters().GetRoles().IsAdministrator {
 		return nil, jrm1.NewRpcErrorByUser(c.RpcErrorCode_Permission, c.RpcErrorMsg_Permission, nil)
 	}
 
-	srv.dbo.LockForReading()
-	defer srv.dbo.UnlockAfterReading()
-
-	// Read the thread.
-	var thread derived2.IThread
-	var err error
-	thread, err = srv.dbo.GetThreadById(p.ThreadId)
-	if err != nil {
-		return nil, srv.databaseError(err)
-	}
-
-	if thread == nil {
-		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_ThreadIsNotFound, RpcErrorMsg_ThreadIsNotFound, nil)
+	var report *mm.ConsistencyReport
+	report, re = srv.repairDatabaseConsistencyH(p.Apply, userRoles.User.GetUserParameters().GetId())
+	if re != nil {
+		return nil, re
 	}
 
-	// Read messages.
-	var allMessageIds = thread.GetMessages()
-
-	var allMessages []derived2.IMessage
-	allMessages, err = srv.dbo.ReadMessagesById(allMessageIds)
-	if err != nil {
-		return nil, srv.databaseError(err)
+	if p.Apply {
+		srv.audit(p.Auth, &al.Record{
+			ActorUserId: userRoles.User.GetUserParameters().GetId(),
+			Action:      al.Action_RepairDatabaseConsistency,
+			TargetType:  al.TargetType_Board,
+			TargetId:    0,
+		})
 	}
 
-	taM := tam.NewThreadAndMessages()
-	taM.SetThread(thread)
-	taM.SetMessages(allMessages)
-
-	result = &rpc2.ListThreadAndMessagesResult{
-		ThreadAndMessages: taM,
+	result = &rpc2.RepairDatabaseConsistencyResult{
+		Report: report,
 	}
 
 	return result, nil
 }
 
-// listThreadAndMessagesOnPage reads a thread and its messages on a selected page.
-func (srv *Server) listThreadAndMessagesOnPage(p *rpc2.ListThreadAndMessagesOnPageParams) (result *rpc2.ListThreadAndMessagesOnPageResult, re *jrm1.RpcError) {
+// Moderation.
+
+// reportMessage lets a reader flag a message for moderators. A user may have
+// only one open report about a message.
+func (srv *Server) reportMessage(p *rpc2.ReportMessageParams) (result *rpc2.ReportMessageResult, re *jrm1.RpcError) {
 	// Check parameters.
-	if p.ThreadId == 0 {
-		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_ThreadIdIsNotSet, RpcErrorMsg_ThreadIdIsNotSet, nil)
+	if p.MessageId == 0 {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_MessageIdIsNotSet, RpcErrorMsg_MessageIdIsNotSet, nil)
 	}
 
-	if p.Page == 0 {
-		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_PageIsNotSet, RpcErrorMsg_PageIsNotSet, nil)
+	if !p.Reason.IsValid() {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_ReportReasonIsNotValid, RpcErrorMsg_ReportReasonIsNotValid, nil)
+	}
+
+	if !mm.IsReportCommentValid(p.Comment) {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_ReportCommentIsTooLong, RpcErrorMsg_ReportCommentIsTooLong, nil)
 	}
 
 	var userRoles *am.GetSelfRolesResult
@@ -2031,60 +4190,73 @@ func (srv *Server) listThreadAndMessagesOnPage(p *rpc2.ListThreadAndMessagesOnPa
 		return nil, re
 	}
 
+	srv.dbo.LockForWriting()
+	defer srv.dbo.UnlockAfterWriting()
+
+	message, err := srv.dbo.GetMessageById(p.MessageId)
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+
+	if message == nil {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_MessageIsNotFound, RpcErrorMsg_MessageIsNotFound, nil)
+	}
+
 	// Check permissions.
-	if !userRoles.User.GetUserParameters().GetRoles().IsReader {
-		return nil, jrm1.NewRpcErrorByUser(c.RpcErrorCode_Permission, c.RpcErrorMsg_Permission, nil)
+	var scope *perm.Scope
+	scope, re = srv.getThreadScopeH(message.GetThreadId())
+	if re != nil {
+		return nil, re
 	}
 
-	srv.dbo.LockForReading()
-	defer srv.dbo.UnlockAfterReading()
+	re = srv.mustHavePermission(userRoles, perm.Permission_Read, scope)
+	if re != nil {
+		return nil, re
+	}
 
-	// Read the thread.
-	var thread derived2.IThread
-	var err error
-	thread, err = srv.dbo.GetThreadById(p.ThreadId)
+	userId := userRoles.User.GetUserParameters().GetId()
+	if message.GetEventData().GetCreatorUserId() == userId {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_OwnMessageCanNotBeReported, RpcErrorMsg_OwnMessageCanNotBeReported, nil)
+	}
+
+	var n base2.Count
+	n, err = srv.dbo.CountOpenReportsOfUser(p.MessageId, userId)
 	if err != nil {
 		return nil, srv.databaseError(err)
 	}
 
-	if thread == nil {
-		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_ThreadIsNotFound, RpcErrorMsg_ThreadIsNotFound, nil)
+	if n > 0 {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_MessageIsAlreadyReported, RpcErrorMsg_MessageIsAlreadyReported, nil)
 	}
 
-	// Read messages.
-	var allMessageIds = thread.GetMessages()
-	var messageIdsOnPage = allMessageIds.OnPage(p.Page, srv.settings.SystemSettings.PageSize)
+	mr := &mm.MessageReport{
+		MessageId:      p.MessageId,
+		ThreadId:       message.GetThreadId(),
+		AuthorUserId:   message.GetEventData().GetCreatorUserId(),
+		ReporterUserId: userId,
+		Reason:         p.Reason,
+		Comment:        p.Comment,
+	}
 
-	var messagesOnPage []derived2.IMessage
-	messagesOnPage, err = srv.dbo.ReadMessagesById(messageIdsOnPage)
+	err = srv.dbo.InsertMessageReport(mr)
 	if err != nil {
 		return nil, srv.databaseError(err)
 	}
 
-	taM := tam.NewThreadAndMessages()
-	taM.SetThread(thread)
-	taM.SetMessages(messagesOnPage)
-	taM.SetPageData(&rpc3.PageData{
-		PageNumber:  p.Page,
-		TotalPages:  base2.CalculateTotalPages(allMessageIds.Size(), srv.settings.SystemSettings.PageSize),
-		PageSize:    srv.settings.SystemSettings.PageSize,
-		ItemsOnPage: messageIdsOnPage.Size(),
-		TotalItems:  allMessageIds.Size(),
-	})
-	thread.SetMessages(messageIdsOnPage)
-
-	result = &rpc2.ListThreadAndMessagesOnPageResult{
-		ThreadAndMessagesOnPage: taM,
+	result = &rpc2.ReportMessageResult{
+		Success: rpc3.Success{
+			OK: true,
+		},
 	}
-
 	return result, nil
 }
 
-// listForumAndThreads reads a forum and all its threads.
-func (srv *Server) listForumAndThreads(p *rpc2.ListForumAndThreadsParams) (result *rpc2.ListForumAndThreadsResult, re *jrm1.RpcError) {
+// listModerationQueue reads messages having open reports. Reports are grouped
+// by messages.
+func (srv *Server) listModerationQueue(p *rpc2.ListModerationQueueParams) (result *rpc2.ListModerationQueueResult, re *jrm1.RpcError) {
 	// Check parameters.
-	if p.ForumId == 0 {
-		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_ForumIdIsNotSet, RpcErrorMsg_ForumIdIsNotSet, nil)
+	if p.Page == 0 {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_PageIsNotSet, RpcErrorMsg_PageIsNotSet, nil)
 	}
 
 	var userRoles *am.GetSelfRolesResult
@@ -2094,54 +4266,63 @@ func (srv *Server) listForumAndThreads(p *rpc2.ListForumAndThreadsParams) (resul
 	}
 
 	// Check permissions.
-	if !userRoles.User.GetUserParameters().GetRoles().IsReader {
+	roles := userRoles.User.GetUserParameters().GetRoles()
+	if !(roles.IsModerator || roles.IsAdministrator) {
 		return nil, jrm1.NewRpcErrorByUser(c.RpcErrorCode_Permission, c.RpcErrorMsg_Permission, nil)
 	}
 
 	srv.dbo.LockForReading()
 	defer srv.dbo.UnlockAfterReading()
 
-	// Read the forum.
-	var forum derived2.IForum
-	var err error
-	forum, err = srv.dbo.GetForumById(p.ForumId)
+	messageIds, err := srv.dbo.ReadReportedMessageIdsOnPage(p.Page, srv.settings.SystemSettings.PageSize)
 	if err != nil {
 		return nil, srv.databaseError(err)
 	}
 
-	if forum == nil {
-		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_ForumIsNotFound, RpcErrorMsg_ForumIsNotFound, nil)
+	var reports []mm.MessageReport
+	reports, err = srv.dbo.ReadOpenReportsByMessageIds(messageIds)
+	if err != nil {
+		return nil, srv.databaseError(err)
 	}
 
-	// Read threads.
-	var allThreadIds = forum.GetThreads()
-
-	var allThreads []derived2.IThread
-	allThreads, err = srv.dbo.ReadThreadsById(allThreadIds)
+	var allMessagesCount base2.Count
+	allMessagesCount, err = srv.dbo.CountReportedMessages()
 	if err != nil {
 		return nil, srv.databaseError(err)
 	}
 
-	faT := fat.NewForumAndThreads()
-	faT.SetForum(forum)
-	faT.SetThreads(allThreads)
+	mqop := mm.NewModerationQueueOnPage()
+	mqop.Messages = mm.GroupMessageReports(reports)
+	mqop.PageData = &rpc3.PageData{
+		PageNumber:  p.Page,
+		TotalPages:  base2.CalculateTotalPages(allMessagesCount, srv.settings.SystemSettings.PageSize),
+		PageSize:    srv.settings.SystemSettings.PageSize,
+		ItemsOnPage: base2.Count(len(mqop.Messages)),
+		TotalItems:  allMessagesCount,
+	}
 
-	result = &rpc2.ListForumAndThreadsResult{
-		ForumAndThreads: faT,
+	result = &rpc2.ListModerationQueueResult{
+		ModerationQueueOnPage: mqop,
 	}
 
 	return result, nil
 }
 
-// listForumAndThreadsOnPage reads a forum and its threads on a selected page.
-func (srv *Server) listForumAndThreadsOnPage(p *rpc2.ListForumAndThreadsOnPageParams) (result *rpc2.ListForumAndThreadsOnPageResult, re *jrm1.RpcError) {
+// resolveMessageReports applies a moderator's decision to a reported message.
+// The decision is recorded, open reports about the message are closed and
+// the reporters are notified.
+func (srv *Server) resolveMessageReports(p *rpc2.ResolveMessageReportsParams) (result *rpc2.ResolveMessageReportsResult, re *jrm1.RpcError) {
 	// Check parameters.
-	if p.ForumId == 0 {
-		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_ForumIdIsNotSet, RpcErrorMsg_ForumIdIsNotSet, nil)
+	if p.MessageId == 0 {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_MessageIdIsNotSet, RpcErrorMsg_MessageIdIsNotSet, nil)
 	}
 
-	if p.Page == 0 {
-		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_PageIsNotSet, RpcErrorMsg_PageIsNotSet, nil)
+	if !p.Action.IsValid() {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_ModerationActionIsNotValid, RpcErrorMsg_ModerationActionIsNotValid, nil)
+	}
+
+	if !mm.IsReportCommentValid(p.Comment) {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_ReportCommentIsTooLong, RpcErrorMsg_ReportCommentIsTooLong, nil)
 	}
 
 	var userRoles *am.GetSelfRolesResult
@@ -2150,57 +4331,94 @@ func (srv *Server) listForumAndThreadsOnPage(p *rpc2.ListForumAndThreadsOnPagePa
 		return nil, re
 	}
 
-	// Check permissions.
-	if !userRoles.User.GetUserParameters().GetRoles().IsReader {
-		return nil, jrm1.NewRpcErrorByUser(c.RpcErrorCode_Permission, c.RpcErrorMsg_Permission, nil)
+	var message derived2.IMessage
+	var rm *mm.ReportedMessage
+	message, rm, re = srv.getReportedMessageH(p.MessageId, userRoles)
+	if re != nil {
+		return nil, re
 	}
 
-	srv.dbo.LockForReading()
-	defer srv.dbo.UnlockAfterReading()
+	moderatorUserId := userRoles.User.GetUserParameters().GetId()
 
-	// Read the forum.
-	var forum derived2.IForum
-	var err error
-	forum, err = srv.dbo.GetForumById(p.ForumId)
-	if err != nil {
-		return nil, srv.databaseError(err)
+	// Take the action.
+	switch p.Action {
+	case mm.ModerationAction_Dismiss:
+
+	case mm.ModerationAction_DeleteMessage:
+		message, re = srv.deleteMessageH(p.MessageId, moderatorUserId)
+		if re != nil {
+			return nil, re
+		}
+
+		re = srv.reportMessageDeletionH(message, &moderatorUserId)
+		if re != nil {
+			return nil, re
+		}
+
+	case mm.ModerationAction_BanAuthor:
+		re = srv.banUserH(p.Auth, message.GetEventData().GetCreatorUserId())
+		if re != nil {
+			return nil, re
+		}
+
+	case mm.ModerationAction_LockThread:
+		var isChanged bool
+		isChanged, re = srv.setThreadIsLockedH(message.GetThreadId(), true, moderatorUserId)
+		if re != nil {
+			return nil, re
+		}
+
+		if isChanged {
+			re = srv.reportThreadLockingH(message.GetThreadId(), true, moderatorUserId)
+			if re != nil {
+				return nil, re
+			}
+		}
 	}
 
-	if forum == nil {
-		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_ForumIsNotFound, RpcErrorMsg_ForumIsNotFound, nil)
+	md := &mm.ModerationDecision{
+		MessageId:       p.MessageId,
+		ThreadId:        message.GetThreadId(),
+		AuthorUserId:    message.GetEventData().GetCreatorUserId(),
+		Action:          p.Action,
+		Comment:         p.Comment,
+		ReportsCount:    base2.Count(len(rm.Reports)),
+		ModeratorUserId: moderatorUserId,
 	}
 
-	// Read threads.
-	var allThreadIds = forum.GetThreads()
-	var threadIdsOnPage = allThreadIds.OnPage(p.Page, srv.settings.SystemSettings.PageSize)
+	var decisionId base2.Id
+	decisionId, re = srv.saveModerationDecisionH(md, rm.LastReportId())
+	if re != nil {
+		return nil, re
+	}
 
-	var threadsOnPage []derived2.IThread
-	threadsOnPage, err = srv.dbo.ReadThreadsById(threadIdsOnPage)
-	if err != nil {
-		return nil, srv.databaseError(err)
+	re = srv.notifyReportersH(rm, message.GetThreadId(), moderatorUserId)
+	if re != nil {
+		return nil, re
 	}
 
-	faT := fat.NewForumAndThreads()
-	faT.SetForum(forum)
-	faT.SetThreads(threadsOnPage)
-	faT.SetPageData(&rpc3.PageData{
-		PageNumber:  p.Page,
-		TotalPages:  base2.CalculateTotalPages(allThreadIds.Size(), srv.settings.SystemSettings.PageSize),
-		PageSize:    srv.settings.SystemSettings.PageSize,
-		ItemsOnPage: threadIdsOnPage.Size(),
-		TotalItems:  allThreadIds.Size(),
+	srv.audit(p.Auth, &al.Record{
+		ActorUserId: moderatorUserId,
+		Action:      al.Action_ResolveMessageReports,
+		TargetType:  al.TargetType_Message,
+		TargetId:    p.MessageId,
+		ValueAfter:  al.DecisionValue(base2.Count(p.Action), decisionId),
 	})
-	forum.SetThreads(threadIdsOnPage)
 
-	result = &rpc2.ListForumAndThreadsOnPageResult{
-		ForumAndThreadsOnPage: faT,
+	result = &rpc2.ResolveMessageReportsResult{
+		DecisionId: decisionId,
 	}
 
 	return result, nil
 }
 
-// listSectionsAndForums reads all sections and forums.
-func (srv *Server) listSectionsAndForums(p *rpc2.ListSectionsAndForumsParams) (result *rpc2.ListSectionsAndForumsResult, re *jrm1.RpcError) {
+// listModerationDecisions reads the log of moderation decisions.
+func (srv *Server) listModerationDecisions(p *rpc2.ListModerationDecisionsParams) (result *rpc2.ListModerationDecisionsResult, re *jrm1.RpcError) {
+	// Check parameters.
+	if p.Page == 0 {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_PageIsNotSet, RpcErrorMsg_PageIsNotSet, nil)
+	}
+
 	var userRoles *am.GetSelfRolesResult
 	userRoles, re = srv.mustBeAnAuthToken(p.Auth)
 	if re != nil {
@@ -2208,33 +4426,37 @@ func (srv *Server) listSectionsAndForums(p *rpc2.ListSectionsAndForumsParams) (r
 	}
 
 	// Check permissions.
-	if !userRoles.User.GetUserParameters().GetRoles().IsReader {
+	roles := userRoles.User.GetUserParameters().GetRoles()
+	if !(roles.IsModerator || roles.IsAdministrator) {
 		return nil, jrm1.NewRpcErrorByUser(c.RpcErrorCode_Permission, c.RpcErrorMsg_Permission, nil)
 	}
 
 	srv.dbo.LockForReading()
 	defer srv.dbo.UnlockAfterReading()
 
-	// Read all the sections.
-	var sections []derived2.ISection
-	var err error
-	sections, err = srv.dbo.ReadSections()
+	decisions, err := srv.dbo.ReadModerationDecisionsOnPage(p.Page, srv.settings.SystemSettings.PageSize)
 	if err != nil {
 		return nil, srv.databaseError(err)
 	}
 
-	// Read all the forums.
-	var forums []derived2.IForum
-	forums, err = srv.dbo.ReadForums()
+	var allDecisionsCount base2.Count
+	allDecisionsCount, err = srv.dbo.CountModerationDecisions()
 	if err != nil {
 		return nil, srv.databaseError(err)
 	}
 
-	result = &rpc2.ListSectionsAndForumsResult{
-		SectionsAndForums: &mm.SectionsAndForums{
-			Sections: sections,
-			Forums:   forums,
-		},
+	mdop := mm.NewModerationDecisionsOnPage()
+	mdop.Decisions = decisions
+	mdop.PageData = &rpc3.PageData{
+		PageNumber:  p.Page,
+		TotalPages:  base2.CalculateTotalPages(allDecisionsCount, srv.settings.SystemSettings.PageSize),
+		PageSize:    srv.settings.SystemSettings.PageSize,
+		ItemsOnPage: base2.Count(len(decisions)),
+		TotalItems:  allDecisionsCount,
+	}
+
+	result = &rpc2.ListModerationDecisionsResult{
+		ModerationDecisionsOnPage: mdop,
 	}
 
 	return result, nil
@@ -2263,6 +4485,9 @@ func (srv *Server) showDiagnosticData() (result *rpc2.ShowDiagnosticDataResult,
 			TotalRequestsCount:      base2.Text(trc),
 			SuccessfulRequestsCount: base2.Text(src),
 		},
+		ScheduledTasks: rpc3.ScheduledTasks{
+			ScheduledTasks: srv.scheduler.GetTaskStatuses(),
+		},
 	}
 
 	return result, nil
@@ -13,7 +13,6 @@ import (
 	cmb "github.com/vault-thirteen/SimpleBB/pkg/common/models/base"
 	server2 "github.com/vault-thirteen/SimpleBB/pkg/common/models/server"
 	cset "github.com/vault-thirteen/SimpleBB/pkg/common/models/settings"
-	"github.com/vault-thirteen/SimpleBB/pkg/common/models/simple"
 	"hash/crc32"
 	"log"
 	"net"
@@ -24,6 +23,7 @@ import (
 	"time"
 
 	jrm1 "github.com/vault-thirteen/JSON-RPC-M1"
+	"github.com/vault-thirteen/SimpleBB/pkg/MM/blob"
 	"github.com/vault-thirteen/SimpleBB/pkg/MM/dbo"
 	ms "github.com/vault-thirteen/SimpleBB/pkg/MM/settings"
 )
@@ -51,6 +51,9 @@ type Server struct {
 	// Database Object.
 	dbo *dbo.DatabaseObject
 
+	// Storage of attached files.
+	blobStore *blob.BlobStore
+
 	// JSON-RPC server.
 	js *jrm1.Processor
 
@@ -65,7 +68,8 @@ type Server struct {
 	dKeyI *dk.DKey
 
 	// External DKeys.
-	dKeyForNM *cmb.Text
+	dKeyForACM *cmb.Text
+	dKeyForNM  *cmb.Text
 
 	// Scheduler.
 	scheduler *cm.Scheduler
@@ -107,6 +111,12 @@ func NewServer(s base.ISettings) (srv *Server, err error) {
 		return nil, err
 	}
 
+	// Blob store.
+	srv.blobStore, err = blob.NewBlobStore(srv.settings.AttachmentSettings.Folder)
+	if err != nil {
+		return nil, err
+	}
+
 	// HTTP Server.
 	srv.httpServer = &http.Server{
 		Addr:    srv.listenDsn,
@@ -128,7 +138,10 @@ func NewServer(s base.ISettings) (srv *Server, err error) {
 		return nil, err
 	}
 
-	srv.initScheduler()
+	err = srv.initScheduler()
+	if err != nil {
+		return nil, err
+	}
 
 	return srv, nil
 }
@@ -167,7 +180,17 @@ func (srv *Server) Start() (err error) {
 		return err
 	}
 
-	err = srv.checkDatabaseConsistency()
+	err = srv.checkDatabaseConsistency(context.Background())
+	if err != nil {
+		return err
+	}
+
+	err = srv.renderMessagesWithoutHtml()
+	if err != nil {
+		return err
+	}
+
+	err = srv.indexMessagesAndThreads()
 	if err != nil {
 		return err
 	}
@@ -340,6 +363,23 @@ func (srv *Server) pingClientsForExternalServices() (err error) {
 }
 
 func (srv *Server) synchroniseModules(verbose bool) (err error) {
+	// ACM module.
+	{
+		if verbose {
+			fmt.Print(fmt.Sprintf(server2.MsgFSynchronisingWithModule, app.ServiceShortName_ACM))
+		}
+
+		var re *jrm1.RpcError
+		srv.dKeyForACM, re = srv.getDKeyForACM()
+		if re != nil {
+			return re.AsError()
+		}
+
+		if verbose {
+			fmt.Println(server2.MsgOK)
+		}
+	}
+
 	// NM module.
 	{
 		if verbose {
@@ -375,11 +415,20 @@ func (srv *Server) initKeys() (err error) {
 	return nil
 }
 
-func (srv *Server) initScheduler() {
-	funcs3600 := []simple.ScheduledFn{
-		srv.checkDatabaseConsistency,
+func (srv *Server) initScheduler() (err error) {
+	tasks := []cm.Task{
+		{Name: "checkDatabaseConsistency", Schedule: "@every 1h", Fn: srv.checkDatabaseConsistency, Jitter: 5 * time.Minute, Timeout: 30 * time.Minute},
+		{Name: "closeExpiredPolls", Schedule: "@every 1m", Fn: srv.closeExpiredPolls, Timeout: 5 * time.Minute},
+		{Name: "purgeTrash", Schedule: "@every 1h", Fn: srv.purgeTrash, Jitter: 5 * time.Minute, Timeout: 30 * time.Minute},
+		{Name: "deleteOrphanedBlobs", Schedule: "@every 1h", Fn: srv.deleteOrphanedBlobs, Jitter: 5 * time.Minute, Timeout: 30 * time.Minute},
 	}
-	srv.scheduler = cm.NewScheduler(srv, nil, nil, funcs3600)
+
+	srv.scheduler, err = cm.NewScheduler(srv, tasks)
+	if err != nil {
+		return err
+	}
+
+	return nil
 }
 
 func (srv *Server) ReportStart() {
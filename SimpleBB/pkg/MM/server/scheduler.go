@@ -1,14 +1,22 @@
 package server
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"github.com/vault-thirteen/SimpleBB/pkg/MM/dbo"
+	"github.com/vault-thirteen/SimpleBB/pkg/MM/markup"
 	mm "github.com/vault-thirteen/SimpleBB/pkg/MM/models"
 	"github.com/vault-thirteen/SimpleBB/pkg/common/interfaces/derived2"
 	cmb "github.com/vault-thirteen/SimpleBB/pkg/common/models/base"
+	ev "github.com/vault-thirteen/SimpleBB/pkg/common/models/complex/EnumValue"
 	"github.com/vault-thirteen/SimpleBB/pkg/common/models/complex/SectionChildType"
+	cm "github.com/vault-thirteen/SimpleBB/pkg/common/models/complex/SystemEvent"
+	"github.com/vault-thirteen/SimpleBB/pkg/common/models/complex/SystemEventData"
+	set "github.com/vault-thirteen/SimpleBB/pkg/common/models/complex/SystemEventType"
 	c "github.com/vault-thirteen/SimpleBB/pkg/common/models/server"
+
+	ae "github.com/vault-thirteen/auxie/errors"
 )
 
 const (
@@ -21,12 +29,21 @@ const (
 	ErrF_ThreadIsDamaged    = "thread is damaged, ID=%v"
 	ErrF_MessageIsNotFound  = "message is not found, ID=%v"
 	ErrF_MessageIsDamaged   = "message is damaged, ID=%v"
+	ErrF_TrashObjectType    = "unknown type of trash object: %v"
 )
 
+// MessageHtmlRenderingBatchSize is the number of messages read at once when
+// messages without HTML are rendered.
+const MessageHtmlRenderingBatchSize = 100
+
+// SearchIndexingBatchSize is the number of messages or threads read at once
+// when messages and threads without words in the search index are indexed.
+const SearchIndexingBatchSize = 100
+
 // checkDatabaseConsistency checks consistency of sections, forums, threads and
 // messages. This function is used in the scheduler and is also run once during
 // the server's start.
-func (srv *Server) checkDatabaseConsistency() (err error) {
+func (srv *Server) checkDatabaseConsistency(ctx context.Context) (err error) {
 	srv.dbo.LockForReading()
 	defer srv.dbo.UnlockAfterReading()
 
@@ -49,6 +66,11 @@ func (srv *Server) checkDatabaseConsistency() (err error) {
 		return err
 	}
 
+	err = ctx.Err()
+	if err != nil {
+		return err
+	}
+
 	// Forums.
 	var forums []derived2.IForum
 	forums, err = srv.dbo.ReadForums()
@@ -66,6 +88,11 @@ func (srv *Server) checkDatabaseConsistency() (err error) {
 		return err
 	}
 
+	err = ctx.Err()
+	if err != nil {
+		return err
+	}
+
 	// Threads.
 	var threads []mm.ThreadLink
 	threads, err = srv.dbo.ReadThreadLinks()
@@ -84,7 +111,7 @@ func (srv *Server) checkDatabaseConsistency() (err error) {
 	}
 
 	// Messages.
-	err = checkMessages(srv.dbo, threads)
+	err = checkMessages(ctx, srv.dbo, threads)
 	if err != nil {
 		return err
 	}
@@ -94,6 +121,103 @@ func (srv *Server) checkDatabaseConsistency() (err error) {
 	return nil
 }
 
+// renderMessagesWithoutHtml renders messages which were written before the
+// markup was introduced. The migration of the database leaves them with an
+// empty HTML. Mentions in such messages are left as a plain text, since they
+// were never resolved. This function is run once during the server's start.
+func (srv *Server) renderMessagesWithoutHtml() (err error) {
+	srv.dbo.LockForWriting()
+	defer srv.dbo.UnlockAfterWriting()
+
+	fmt.Print(c.MsgRenderingMessagesWithoutHtml)
+
+	var mts []mm.MessageText
+	var lastId cmb.Id
+	for {
+		mts, err = srv.dbo.ReadMessagesWithoutHtml(lastId, MessageHtmlRenderingBatchSize)
+		if err != nil {
+			return err
+		}
+
+		if len(mts) == 0 {
+			break
+		}
+
+		for _, mt := range mts {
+			err = srv.dbo.SetMessageHtmlById(mt.Id, cmb.Text(markup.Parse(mt.Text.ToString()).Html(nil)))
+			if err != nil {
+				return err
+			}
+
+			lastId = mt.Id
+		}
+	}
+
+	fmt.Println(c.MsgOK)
+
+	return nil
+}
+
+// indexMessagesAndThreads adds to the search index messages and threads which
+// were written before the search was introduced. Messages and threads without
+// a single indexed word, e.g. those containing only short words, are read
+// again at every start, which is cheap while there are few of them. This
+// function is run once during the server's start.
+func (srv *Server) indexMessagesAndThreads() (err error) {
+	srv.dbo.LockForWriting()
+	defer srv.dbo.UnlockAfterWriting()
+
+	fmt.Print(c.MsgIndexingMessagesAndThreads)
+
+	var mts []mm.MessageText
+	var lastId cmb.Id
+	for {
+		mts, err = srv.dbo.ReadMessagesWithoutWords(lastId, SearchIndexingBatchSize)
+		if err != nil {
+			return err
+		}
+
+		if len(mts) == 0 {
+			break
+		}
+
+		for _, mt := range mts {
+			err = srv.updateMessageSearchIndex(mt.Id, mt.Text)
+			if err != nil {
+				return err
+			}
+
+			lastId = mt.Id
+		}
+	}
+
+	var tns []mm.ThreadName
+	lastId = 0
+	for {
+		tns, err = srv.dbo.ReadThreadsWithoutWords(lastId, SearchIndexingBatchSize)
+		if err != nil {
+			return err
+		}
+
+		if len(tns) == 0 {
+			break
+		}
+
+		for _, tn := range tns {
+			err = srv.updateThreadSearchIndex(tn.Id, tn.Name)
+			if err != nil {
+				return err
+			}
+
+			lastId = tn.Id
+		}
+	}
+
+	fmt.Println(c.MsgOK)
+
+	return nil
+}
+
 func checkSections(sections []derived2.ISection, sectionsMap map[cmb.Id]derived2.ISection) (err error) {
 	// Step I. Downward check (parent to child).
 	var childSection derived2.ISection
@@ -271,10 +395,15 @@ func checkThreads(forums []derived2.IForum, forumsMap map[cmb.Id]derived2.IForum
 	return nil
 }
 
-func checkMessages(dbo *dbo.DatabaseObject, threads []mm.ThreadLink) (err error) {
+func checkMessages(ctx context.Context, dbo *dbo.DatabaseObject, threads []mm.ThreadLink) (err error) {
 	// Step I. Downward check (parent to child).
 	var messages []mm.MessageLink
 	for _, thread := range threads {
+		err = ctx.Err()
+		if err != nil {
+			return err
+		}
+
 		if thread.Messages.Size() == 0 {
 			continue
 		}
@@ -309,3 +438,177 @@ func checkMessages(dbo *dbo.DatabaseObject, threads []mm.ThreadLink) (err error)
 
 	return nil
 }
+
+// closeExpiredPolls closes polls whose closing time has come and notifies
+// subscribers of their threads. A failed notification does not stop
+// notifications about other polls.
+func (srv *Server) closeExpiredPolls(ctx context.Context) (err error) {
+	var threadIds []cmb.Id
+	threadIds, err = srv.closeExpiredPollsH()
+	if err != nil {
+		return err
+	}
+
+	var se derived2.ISystemEvent
+	var serr error
+	for _, threadId := range threadIds {
+		// Polls are already closed, so only notifications are left.
+		if ctx.Err() != nil {
+			return ae.Combine(err, ctx.Err())
+		}
+
+		seData := sed.NewSystemEventDataWithValue(
+			set.NewSystemEventTypeWithValue(ev.NewEnumValue(set.SystemEventType_ThreadPollClosed)),
+			&threadId,
+			nil,
+			nil,
+			nil,
+		)
+
+		se, serr = cm.NewSystemEventWithData(seData)
+		if serr != nil {
+			err = ae.Combine(err, serr)
+			continue
+		}
+
+		re := srv.reportSystemEvent(se)
+		if re != nil {
+			err = ae.Combine(err, re.AsError())
+		}
+	}
+
+	return err
+}
+
+// closeExpiredPollsH closes expired polls and returns IDs of their threads.
+func (srv *Server) closeExpiredPollsH() (threadIds []cmb.Id, err error) {
+	srv.dbo.LockForWriting()
+	defer srv.dbo.UnlockAfterWriting()
+
+	threadIds, err = srv.dbo.ReadExpiredPollThreads()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, threadId := range threadIds {
+		err = srv.dbo.ClosePollByThreadId(threadId)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return threadIds, nil
+}
+
+// purgeTrash deletes objects which have been in the trash longer than the
+// retention period. Data attached to messages and threads is deleted with
+// them; files of attachments are deleted later as orphaned blobs.
+func (srv *Server) purgeTrash(ctx context.Context) (err error) {
+	srv.dbo.LockForWriting()
+	defer srv.dbo.UnlockAfterWriting()
+
+	var tis []mm.TrashItem
+	tis, err = srv.dbo.ReadExpiredTrashItems(srv.settings.SystemSettings.TrashRetentionDays)
+	if err != nil {
+		return err
+	}
+
+	for _, ti := range tis {
+		err = ctx.Err()
+		if err != nil {
+			return err
+		}
+
+		err = srv.purgeTrashItem(ti)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// purgeTrashItem permanently deletes an object of the trash.
+func (srv *Server) purgeTrashItem(ti mm.TrashItem) (err error) {
+	switch ti.ObjectType {
+	case mm.TrashObjectType_Section:
+		err = srv.dbo.DeleteSectionFromTrash(ti.ObjectId)
+
+	case mm.TrashObjectType_Forum:
+		err = srv.dbo.DeleteForumFromTrash(ti.ObjectId)
+
+	case mm.TrashObjectType_Thread:
+		err = srv.deletePollOfThread(ti.ObjectId)
+		if err != nil {
+			return err
+		}
+
+		err = srv.dbo.DeleteThreadFromTrash(ti.ObjectId)
+
+	case mm.TrashObjectType_Message:
+		err = srv.dbo.DeleteMessageRevisionsByMessageId(ti.ObjectId)
+		if err != nil {
+			return err
+		}
+
+		// Reputation received by the author is cumulative, so it is kept.
+		err = srv.dbo.DeleteMessageReactionsByMessageId(ti.ObjectId)
+		if err != nil {
+			return err
+		}
+
+		err = srv.dbo.DeleteAttachmentsByMessageId(ti.ObjectId)
+		if err != nil {
+			return err
+		}
+
+		// Resolved reports are kept with moderation decisions.
+		err = srv.dbo.DeleteOpenReportsByMessageId(ti.ObjectId)
+		if err != nil {
+			return err
+		}
+
+		err = srv.dbo.DeleteMessageFromTrash(ti.ObjectId)
+
+	default:
+		err = fmt.Errorf(ErrF_TrashObjectType, ti.ObjectType)
+	}
+	if err != nil {
+		return err
+	}
+
+	return srv.dbo.DeleteTrashItemById(ti.Id)
+}
+
+// deleteOrphanedBlobs deletes files of the blob store which are not used by
+// any attachment, e.g. after deletion of messages. A file is deleted before
+// its registration, so that a failure never leaves an unregistered file.
+func (srv *Server) deleteOrphanedBlobs(ctx context.Context) (err error) {
+	srv.dbo.LockForWriting()
+	defer srv.dbo.UnlockAfterWriting()
+
+	var hashes []string
+	hashes, err = srv.dbo.ReadOrphanedBlobs()
+	if err != nil {
+		return err
+	}
+
+	for _, hash := range hashes {
+		err = ctx.Err()
+		if err != nil {
+			return err
+		}
+
+		err = srv.blobStore.Delete(hash)
+		if err != nil {
+			return err
+		}
+
+		err = srv.dbo.DeleteBlob(hash)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,60 @@
+package s
+
+import (
+	"errors"
+	base2 "github.com/vault-thirteen/SimpleBB/pkg/common/models/base"
+	c "github.com/vault-thirteen/SimpleBB/pkg/common/models/server"
+)
+
+// AttachmentSettings are parameters of files attached to messages.
+type AttachmentSettings struct {
+	// Folder of the blob store where contents of files are stored.
+	Folder string `json:"folder"`
+
+	// MaxFileSize is the maximal size of a single file in bytes.
+	MaxFileSize base2.Count `json:"maxFileSize"`
+
+	// MaxFilesPerMessage is the maximal number of files attached to a
+	// message. Zero disables attachments.
+	MaxFilesPerMessage base2.Count `json:"maxFilesPerMessage"`
+
+	// AllowedContentTypes is a list of MIME types of files which may be
+	// attached. The type of file is detected by its contents, a name of
+	// file is not taken into account.
+	AllowedContentTypes []string `json:"allowedContentTypes"`
+
+	// ThumbnailSize is the maximal width and height of thumbnails of images
+	// in pixels.
+	ThumbnailSize base2.Count `json:"thumbnailSize"`
+}
+
+func (s AttachmentSettings) Check() (err error) {
+	if (len(s.Folder) == 0) ||
+		(s.MaxFileSize == 0) ||
+		(s.ThumbnailSize == 0) {
+		return errors.New(c.MsgAttachmentSettingError)
+	}
+
+	if (s.MaxFilesPerMessage > 0) && (len(s.AllowedContentTypes) == 0) {
+		return errors.New(c.MsgAttachmentSettingError)
+	}
+
+	for _, ct := range s.AllowedContentTypes {
+		if len(ct) == 0 {
+			return errors.New(c.MsgAttachmentSettingError)
+		}
+	}
+
+	return nil
+}
+
+// IsContentTypeAllowed checks whether files of the type may be attached.
+func (s AttachmentSettings) IsContentTypeAllowed(contentType string) bool {
+	for _, ct := range s.AllowedContentTypes {
+		if ct == contentType {
+			return true
+		}
+	}
+
+	return false
+}
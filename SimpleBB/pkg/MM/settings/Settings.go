@@ -24,6 +24,8 @@ type Settings struct {
 	DbSettings     `json:"db"`
 	SystemSettings `json:"system"`
 
+	AttachmentSettings `json:"attachments"`
+
 	// External services.
 	AcmSettings s.ServiceClientSettings `json:"acm"`
 	NmSettings  s.ServiceClientSettings `json:"nm"`
@@ -85,6 +87,12 @@ func (stn *Settings) Check() (err error) {
 		return err
 	}
 
+	// Attachments.
+	err = stn.AttachmentSettings.Check()
+	if err != nil {
+		return err
+	}
+
 	// External services.
 	err = stn.AcmSettings.Check()
 	if err != nil {
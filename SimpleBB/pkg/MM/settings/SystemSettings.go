@@ -4,6 +4,8 @@ import (
 	"errors"
 	base2 "github.com/vault-thirteen/SimpleBB/pkg/common/models/base"
 	c "github.com/vault-thirteen/SimpleBB/pkg/common/models/server"
+
+	mm "github.com/vault-thirteen/SimpleBB/pkg/MM/models"
 )
 
 // SystemSettings are system settings.
@@ -23,13 +25,50 @@ type SystemSettings struct {
 	// list.
 	NewThreadsAtTop base2.Flag `json:"newThreadsAtTop"`
 
+	// SearchMinWordLength is the minimal length of a word (in symbols) which
+	// is stored in the search index. Shorter words are ignored both when the
+	// index is built and when a search query is parsed.
+	SearchMinWordLength base2.Count `json:"searchMinWordLength"`
+
+	// MaxConversationMembers is the maximal number of users in a private
+	// conversation including its creator.
+	MaxConversationMembers base2.Count `json:"maxConversationMembers"`
+
+	// Reactions is a set of reactions which users may put on messages. Order
+	// of reactions is kept when they are shown. An empty set disables
+	// reactions.
+	Reactions []mm.Reaction `json:"reactions"`
+
+	// MaxPollOptions is the maximal number of options in a poll.
+	MaxPollOptions base2.Count `json:"maxPollOptions"`
+
+	// MaxMentionsPerMessage is the maximal number of distinct users mentioned
+	// in a message. Further mentions are shown as a plain text and nobody is
+	// notified about them.
+	MaxMentionsPerMessage base2.Count `json:"maxMentionsPerMessage"`
+
+	// TrashRetentionDays is the number of days during which deleted
+	// sections, forums, threads and messages are kept in the trash and may be
+	// restored. Older items are purged by the scheduler.
+	TrashRetentionDays base2.Count `json:"trashRetentionDays"`
+
 	IsDebugMode base2.Flag `json:"isDebugMode"`
 }
 
 func (s SystemSettings) Check() (err error) {
 	if (s.DKeySize == 0) ||
 		(s.MessageEditTime == 0) ||
-		(s.PageSize == 0) {
+		(s.PageSize == 0) ||
+		(s.SearchMinWordLength == 0) ||
+		(s.MaxConversationMembers < 2) ||
+		(s.MaxPollOptions < mm.PollOptionsMinCount) ||
+		(s.MaxMentionsPerMessage == 0) ||
+		(s.TrashRetentionDays == 0) {
+		return errors.New(c.MsgSystemSettingError)
+	}
+
+	err = mm.CheckReactions(s.Reactions)
+	if err != nil {
 		return errors.New(c.MsgSystemSettingError)
 	}
 
@@ -28,6 +28,17 @@ const (
 	FuncGetListOfAllResourcesOnPage = "GetListOfAllResourcesOnPage"
 	FuncDeleteResource              = "DeleteResource"
 
+	// Notification template.
+	FuncAddNotificationTemplate    = "AddNotificationTemplate"
+	FuncGetNotificationTemplate    = "GetNotificationTemplate"
+	FuncListNotificationTemplates  = "ListNotificationTemplates"
+	FuncChangeNotificationTemplate = "ChangeNotificationTemplate"
+	FuncDeleteNotificationTemplate = "DeleteNotificationTemplate"
+
+	// Language.
+	FuncSetSelfLanguage = "SetSelfLanguage"
+	FuncGetSelfLanguage = "GetSelfLanguage"
+
 	// Other.
 	FuncProcessSystemEventS = "ProcessSystemEventS"
 	FuncGetDKey             = "GetDKey"
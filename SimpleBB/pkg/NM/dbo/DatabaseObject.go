@@ -39,10 +39,12 @@ func (dbo *DatabaseObject) Init() (err error) {
 
 func (dbo *DatabaseObject) initTableNames() {
 	dbo.tableNames = &TableNames{
-		Incidents:     dbo.prefixTableName(TableIncidents),
-		Notifications: dbo.prefixTableName(TableNotifications),
-		Resources:     dbo.prefixTableName(TableResources),
-		SystemEvents:  dbo.prefixTableName(TableSystemEvents),
+		Incidents:             dbo.prefixTableName(TableIncidents),
+		Notifications:         dbo.prefixTableName(TableNotifications),
+		NotificationTemplates: dbo.prefixTableName(TableNotificationTemplates),
+		Resources:             dbo.prefixTableName(TableResources),
+		SystemEvents:          dbo.prefixTableName(TableSystemEvents),
+		UserLanguages:         dbo.prefixTableName(TableUserLanguages),
 	}
 }
 
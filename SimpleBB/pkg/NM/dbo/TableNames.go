@@ -1,15 +1,19 @@
 package dbo
 
 const (
-	TableIncidents     = "Incidents"
-	TableNotifications = "Notifications"
-	TableResources     = "Resources"
-	TableSystemEvents  = "SystemEvents"
+	TableIncidents             = "Incidents"
+	TableNotifications         = "Notifications"
+	TableNotificationTemplates = "NotificationTemplates"
+	TableResources             = "Resources"
+	TableSystemEvents          = "SystemEvents"
+	TableUserLanguages         = "UserLanguages"
 )
 
 type TableNames struct {
-	Incidents     string
-	Notifications string
-	Resources     string
-	SystemEvents  string
+	Incidents             string
+	Notifications         string
+	NotificationTemplates string
+	Resources             string
+	SystemEvents          string
+	UserLanguages         string
 }
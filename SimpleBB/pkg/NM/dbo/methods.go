@@ -15,6 +15,7 @@ import (
 	"net"
 
 	nm "github.com/vault-thirteen/SimpleBB/pkg/NM/models"
+	"github.com/vault-thirteen/SimpleBB/pkg/NM/models/nt"
 	ae "github.com/vault-thirteen/auxie/errors"
 )
 
@@ -71,6 +72,16 @@ func (dbo *DatabaseObject) DeleteNotificationById(notificationId base2.Id) (err
 	return dbo2.CheckRowsAffected(result, 1)
 }
 
+func (dbo *DatabaseObject) DeleteNotificationTemplateById(templateId base2.Id) (err error) {
+	var result sql.Result
+	result, err = dbo.DatabaseObject.PreparedStatement(DbPsid_DeleteNotificationTemplateById).Exec(templateId)
+	if err != nil {
+		return err
+	}
+
+	return dbo2.CheckRowsAffected(result, 1)
+}
+
 func (dbo *DatabaseObject) DeleteResourceById(resourceId base2.Id) (err error) {
 	var result sql.Result
 	result, err = dbo.DatabaseObject.PreparedStatement(DbPsid_DeleteResourceById).Exec(resourceId)
@@ -109,6 +120,28 @@ func (dbo *DatabaseObject) GetNotificationById(notificationId base2.Id) (notific
 	return notification, nil
 }
 
+func (dbo *DatabaseObject) GetNotificationTemplate(systemEventType base2.Count, language base2.Text) (template *nt.NotificationTemplate, err error) {
+	row := dbo.DatabaseObject.PreparedStatement(DbPsid_GetNotificationTemplate).QueryRow(systemEventType, language)
+
+	template, err = nt.NewNotificationTemplateFromScannableSource(row)
+	if err != nil {
+		return nil, err
+	}
+
+	return template, nil
+}
+
+func (dbo *DatabaseObject) GetNotificationTemplateById(templateId base2.Id) (template *nt.NotificationTemplate, err error) {
+	row := dbo.DatabaseObject.PreparedStatement(DbPsid_GetNotificationTemplateById).QueryRow(templateId)
+
+	template, err = nt.NewNotificationTemplateFromScannableSource(row)
+	if err != nil {
+		return nil, err
+	}
+
+	return template, nil
+}
+
 func (dbo *DatabaseObject) GetNotificationsByUserIdOnPage(userId base2.Id, pageNumber base2.Count, pageSize base2.Count) (notifications []nm.Notification, err error) {
 	var rows *sql.Rows
 	rows, err = dbo.PreparedStatement(DbPsid_GetNotificationsByUserIdOnPage).Query(userId, pageSize, (pageNumber-1)*pageSize)
@@ -182,6 +215,17 @@ func (dbo *DatabaseObject) GetUnreadNotifications(userId base2.Id) (notification
 	return nm.NewNotificationArrayFromRows(rows)
 }
 
+func (dbo *DatabaseObject) GetUserLanguage(userId base2.Id) (language *base2.Text, err error) {
+	row := dbo.DatabaseObject.PreparedStatement(DbPsid_GetUserLanguage).QueryRow(userId)
+
+	language, err = cms.NewValueFromScannableSource[base2.Text](row)
+	if err != nil {
+		return nil, err
+	}
+
+	return language, nil
+}
+
 func (dbo *DatabaseObject) InsertNewNotification(userId base2.Id, text base2.Text) (lastInsertedId base2.Id, err error) {
 	var result sql.Result
 	result, err = dbo.DatabaseObject.PreparedStatement(DbPsid_InsertNewNotification).Exec(userId, text)
@@ -192,6 +236,33 @@ func (dbo *DatabaseObject) InsertNewNotification(userId base2.Id, text base2.Tex
 	return dbo2.CheckRowsAffectedAndGetLastInsertedId(result, 1)
 }
 
+func (dbo *DatabaseObject) InsertNotificationTemplate(template *nt.NotificationTemplate) (lastInsertedId base2.Id, err error) {
+	var result sql.Result
+	result, err = dbo.DatabaseObject.PreparedStatement(DbPsid_InsertNotificationTemplate).Exec(template.Name, template.SystemEventType, template.Language, template.FormatString.String())
+	if err != nil {
+		return dbo2.LastInsertedIdOnError, err
+	}
+
+	return dbo2.CheckRowsAffectedAndGetLastInsertedId(result, 1)
+}
+
+func (dbo *DatabaseObject) ListNotificationTemplates() (templates []nt.NotificationTemplate, err error) {
+	var rows *sql.Rows
+	rows, err = dbo.DatabaseObject.PreparedStatement(DbPsid_ListNotificationTemplates).Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		derr := rows.Close()
+		if derr != nil {
+			err = ae.Combine(err, derr)
+		}
+	}()
+
+	return nt.NewNotificationTemplateArrayFromRows(rows)
+}
+
 func (dbo *DatabaseObject) MarkNotificationAsRead(notificationId base2.Id, userId base2.Id) (err error) {
 	var result sql.Result
 	result, err = dbo.DatabaseObject.PreparedStatement(DbPsid_MarkNotificationAsRead).Exec(notificationId, userId)
@@ -233,3 +304,21 @@ func (dbo *DatabaseObject) SaveSystemEvent(se derived2.ISystemEvent) (err error)
 
 	return dbo2.CheckRowsAffected(result, 1)
 }
+
+func (dbo *DatabaseObject) SetUserLanguage(userId base2.Id, language base2.Text) (err error) {
+	_, err = dbo.DatabaseObject.PreparedStatement(DbPsid_SetUserLanguage).Exec(userId, language)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (dbo *DatabaseObject) UpdateNotificationTemplate(template *nt.NotificationTemplate) (err error) {
+	_, err = dbo.DatabaseObject.PreparedStatement(DbPsid_UpdateNotificationTemplate).Exec(template.Name, template.SystemEventType, template.Language, template.FormatString.String(), template.Id)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
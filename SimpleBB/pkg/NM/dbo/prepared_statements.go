@@ -27,6 +27,14 @@ const (
 	DbPsid_DeleteResourceById               = 16
 	DbPsid_ListAllResourceIdsOnPage         = 17
 	DbPsid_CountAllResources                = 18
+	DbPsid_InsertNotificationTemplate       = 19
+	DbPsid_GetNotificationTemplateById      = 20
+	DbPsid_GetNotificationTemplate          = 21
+	DbPsid_ListNotificationTemplates        = 22
+	DbPsid_UpdateNotificationTemplate       = 23
+	DbPsid_DeleteNotificationTemplateById   = 24
+	DbPsid_SetUserLanguage                  = 25
+	DbPsid_GetUserLanguage                  = 26
 )
 
 func (dbo *DatabaseObject) makePreparedStatementQueryStrings() (qs []string) {
@@ -109,6 +117,38 @@ func (dbo *DatabaseObject) makePreparedStatementQueryStrings() (qs []string) {
 	q = fmt.Sprintf(`SELECT COUNT(Id) FROM %s;`, dbo.tableNames.Resources)
 	qs = append(qs, q)
 
+	// 19.
+	q = fmt.Sprintf(`INSERT INTO %s (Name, SystemEventType, Language, FormatString) VALUES (?, ?, ?, ?);`, dbo.tableNames.NotificationTemplates)
+	qs = append(qs, q)
+
+	// 20.
+	q = fmt.Sprintf(`SELECT Id, Name, SystemEventType, Language, FormatString FROM %s WHERE Id = ?;`, dbo.tableNames.NotificationTemplates)
+	qs = append(qs, q)
+
+	// 21.
+	q = fmt.Sprintf(`SELECT Id, Name, SystemEventType, Language, FormatString FROM %s WHERE SystemEventType = ? AND Language = ?;`, dbo.tableNames.NotificationTemplates)
+	qs = append(qs, q)
+
+	// 22.
+	q = fmt.Sprintf(`SELECT Id, Name, SystemEventType, Language, FormatString FROM %s ORDER BY SystemEventType, Language;`, dbo.tableNames.NotificationTemplates)
+	qs = append(qs, q)
+
+	// 23.
+	q = fmt.Sprintf(`UPDATE %s SET Name = ?, SystemEventType = ?, Language = ?, FormatString = ? WHERE Id = ?;`, dbo.tableNames.NotificationTemplates)
+	qs = append(qs, q)
+
+	// 24.
+	q = fmt.Sprintf(`DELETE FROM %s WHERE Id = ?;`, dbo.tableNames.NotificationTemplates)
+	qs = append(qs, q)
+
+	// 25.
+	q = fmt.Sprintf(`INSERT INTO %s (UserId, Language) VALUES (?, ?) ON DUPLICATE KEY UPDATE Language = VALUES(Language);`, dbo.tableNames.UserLanguages)
+	qs = append(qs, q)
+
+	// 26.
+	q = fmt.Sprintf(`SELECT Language FROM %s WHERE UserId = ?;`, dbo.tableNames.UserLanguages)
+	qs = append(qs, q)
+
 	return qs
 }
 
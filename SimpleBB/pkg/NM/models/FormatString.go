@@ -1,6 +1,7 @@
 package models
 
 import (
+	"encoding/json"
 	"fmt"
 	"strings"
 )
@@ -141,3 +142,27 @@ func (fs *FormatString) HasU() bool {
 func (fs *FormatString) Placeholders() []Placeholder {
 	return fs.placeholders
 }
+
+// MarshalJSON stores the format string as an ordinary string.
+func (fs *FormatString) MarshalJSON() ([]byte, error) {
+	return json.Marshal(fs.s)
+}
+
+// UnmarshalJSON reads the format string from an ordinary string and checks
+// its placeholders.
+func (fs *FormatString) UnmarshalJSON(data []byte) (err error) {
+	var s string
+	err = json.Unmarshal(data, &s)
+	if err != nil {
+		return err
+	}
+
+	var x *FormatString
+	x, err = NewFormatString(s)
+	if err != nil {
+		return err
+	}
+
+	*fs = *x
+	return nil
+}
@@ -0,0 +1,34 @@
+package models
+
+import (
+	base2 "github.com/vault-thirteen/SimpleBB/pkg/common/models/base"
+)
+
+const (
+	LanguageLengthMin = 2
+	LanguageLengthMax = 16
+)
+
+// IsValidLanguage checks a language tag, such as 'en' or 'pt-BR'. Only latin
+// letters, digits and hyphens are allowed; the tag must start with a letter.
+func IsValidLanguage(lang base2.Text) bool {
+	if (len(lang) < LanguageLengthMin) || (len(lang) > LanguageLengthMax) {
+		return false
+	}
+
+	for i, r := range lang {
+		switch {
+		case (r >= 'a') && (r <= 'z'),
+			(r >= 'A') && (r <= 'Z'):
+		case (r >= '0') && (r <= '9'),
+			r == '-':
+			if i == 0 {
+				return false
+			}
+		default:
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,138 @@
+package np
+
+import (
+	"context"
+	jrm1 "github.com/vault-thirteen/JSON-RPC-M1"
+	gc "github.com/vault-thirteen/SimpleBB/pkg/GWM/client"
+	gm "github.com/vault-thirteen/SimpleBB/pkg/GWM/rpc"
+	nm "github.com/vault-thirteen/SimpleBB/pkg/NM/models"
+	cc "github.com/vault-thirteen/SimpleBB/pkg/common/models/Client"
+	"github.com/vault-thirteen/SimpleBB/pkg/common/models/avm"
+	server2 "github.com/vault-thirteen/SimpleBB/pkg/common/models/server"
+	"log"
+	"sync"
+)
+
+const (
+	TaskChannelSize = 64
+)
+
+// NotificationPublisher sends new notifications to the Gateway module which
+// pushes them to connected clients. Publishing is done in background, so
+// that a slow gateway does not delay creation of notifications. Clients which
+// miss a notification receive it when they reconnect.
+type NotificationPublisher struct {
+	ssp                    *avm.SSP
+	wg                     *sync.WaitGroup
+	tasks                  chan *nm.Notification
+	isNotificationPushUsed bool
+	gwmClient              *cc.Client
+}
+
+func NewNotificationPublisher(
+	isNotificationPushUsed bool,
+	gwmClient *cc.Client,
+) (np *NotificationPublisher) {
+	np = &NotificationPublisher{
+		ssp:                    avm.NewSSP(),
+		wg:                     new(sync.WaitGroup),
+		tasks:                  make(chan *nm.Notification, TaskChannelSize),
+		isNotificationPushUsed: isNotificationPushUsed,
+		gwmClient:              gwmClient,
+	}
+
+	return np
+}
+
+// Start starts the notification publisher.
+func (np *NotificationPublisher) Start() (err error) {
+	np.ssp.Lock()
+	defer np.ssp.Unlock()
+
+	err = np.ssp.BeginStart()
+	if err != nil {
+		return err
+	}
+
+	np.wg.Add(1)
+	go np.run()
+
+	np.ssp.CompleteStart()
+
+	return nil
+}
+
+// run is the main work loop of the notification publisher.
+func (np *NotificationPublisher) run() {
+	defer np.wg.Done()
+
+	var re *jrm1.RpcError
+	for n := range np.tasks {
+		re = np.informGateway(n)
+		if re != nil {
+			np.logError(re.AsError())
+		}
+	}
+
+	log.Println(server2.MsgNotificationPublisherHasStopped)
+}
+
+// Stop stops the notification publisher.
+func (np *NotificationPublisher) Stop() (err error) {
+	np.ssp.Lock()
+	defer np.ssp.Unlock()
+
+	err = np.ssp.BeginStop()
+	if err != nil {
+		return err
+	}
+
+	close(np.tasks)
+	np.wg.Wait()
+
+	np.ssp.CompleteStop()
+
+	return nil
+}
+
+// PublishNotification queues the notification for publishing. When the queue
+// is full, the notification is not published, while it is still available to
+// clients via ordinary requests.
+func (np *NotificationPublisher) PublishNotification(n *nm.Notification) {
+	if !np.isNotificationPushUsed {
+		return
+	}
+
+	select {
+	case np.tasks <- n:
+	default:
+		log.Println(server2.MsgNotificationQueueIsFull)
+	}
+}
+
+func (np *NotificationPublisher) logError(err error) {
+	if err == nil {
+		return
+	}
+
+	log.Println(err)
+}
+
+func (np *NotificationPublisher) informGateway(n *nm.Notification) (re *jrm1.RpcError) {
+	var params = gm.PublishNotificationParams{
+		Notification: n,
+	}
+
+	var result = new(gm.PublishNotificationResult)
+	var err error
+	re, err = np.gwmClient.MakeRequest(context.Background(), gc.FuncPublishNotification, params, result)
+	if err != nil {
+		np.logError(err)
+		return jrm1.NewRpcErrorByUser(server2.RpcErrorCode_RPCCall, server2.RpcErrorMsg_RPCCall, nil)
+	}
+	if re != nil {
+		return re
+	}
+
+	return nil
+}
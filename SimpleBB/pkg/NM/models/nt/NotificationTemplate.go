@@ -1,8 +1,17 @@
 package nt
 
 import (
+	"database/sql"
+	"errors"
+	"fmt"
 	nm "github.com/vault-thirteen/SimpleBB/pkg/NM/models"
+	"github.com/vault-thirteen/SimpleBB/pkg/common/interfaces/base1"
 	base2 "github.com/vault-thirteen/SimpleBB/pkg/common/models/base"
+	"strings"
+)
+
+const (
+	ErrF_ArgumentIsMissing = "argument is missing: %s"
 )
 
 type NotificationTemplate struct {
@@ -10,4 +19,84 @@ type NotificationTemplate struct {
 	Name         base2.Text                     `json:"name"`
 	FormatString *nm.FormatString               `json:"formatString"`
 	Arguments    *NotificationTemplateArguments `json:"arguments"`
+
+	// Type of system events rendered with this template.
+	SystemEventType base2.Count `json:"systemEventType"`
+
+	// Language of the template.
+	Language base2.Text `json:"language"`
+}
+
+func NewNotificationTemplate() (t *NotificationTemplate) {
+	return &NotificationTemplate{}
+}
+
+func NewNotificationTemplateFromScannableSource(src base.IScannable) (t *NotificationTemplate, err error) {
+	t = NewNotificationTemplate()
+	var fs string
+
+	err = src.Scan(
+		&t.Id,
+		&t.Name,
+		&t.SystemEventType,
+		&t.Language,
+		&fs,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		} else {
+			return nil, err
+		}
+	}
+
+	t.FormatString, err = nm.NewFormatString(fs)
+	if err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+func NewNotificationTemplateArrayFromRows(rows base.IScannableSequence) (ts []NotificationTemplate, err error) {
+	ts = []NotificationTemplate{}
+	var t *NotificationTemplate
+
+	for rows.Next() {
+		t, err = NewNotificationTemplateFromScannableSource(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		ts = append(ts, *t)
+	}
+
+	return ts, nil
+}
+
+// CheckArguments ensures that each placeholder of the format string has an
+// argument.
+func (t *NotificationTemplate) CheckArguments() (err error) {
+	for _, ph := range t.FormatString.Placeholders() {
+		if t.Arguments.Get(ph.Type) == nil {
+			return fmt.Errorf(ErrF_ArgumentIsMissing, ph.Type)
+		}
+	}
+
+	return nil
+}
+
+// Render puts the arguments into the format string.
+func (t *NotificationTemplate) Render() (text base2.Text, err error) {
+	err = t.CheckArguments()
+	if err != nil {
+		return "", err
+	}
+
+	var oldnew = make([]string, 0, len(t.FormatString.Placeholders())*2)
+	for _, ph := range t.FormatString.Placeholders() {
+		oldnew = append(oldnew, "{"+ph.Type+"}", t.Arguments.Get(ph.Type).ToString())
+	}
+
+	return base2.Text(strings.NewReplacer(oldnew...).Replace(t.FormatString.String())), nil
 }
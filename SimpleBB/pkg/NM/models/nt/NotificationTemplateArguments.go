@@ -1,7 +1,15 @@
 package nt
 
 import (
+	"errors"
+	nm "github.com/vault-thirteen/SimpleBB/pkg/NM/models"
+	"github.com/vault-thirteen/SimpleBB/pkg/common/interfaces/derived2"
 	cmb "github.com/vault-thirteen/SimpleBB/pkg/common/models/base"
+	set "github.com/vault-thirteen/SimpleBB/pkg/common/models/complex/SystemEventType"
+)
+
+const (
+	ErrSystemEventType = "system event type error"
 )
 
 type NotificationTemplateArguments struct {
@@ -10,3 +18,77 @@ type NotificationTemplateArguments struct {
 	ThreadId   *cmb.Id `json:"threadId"`
 	UserId     *cmb.Id `json:"userId"`
 }
+
+// NewNotificationTemplateArgumentsFromSystemEventData takes arguments from
+// the system event.
+func NewNotificationTemplateArgumentsFromSystemEventData(sed derived2.ISystemEventData) (args *NotificationTemplateArguments) {
+	return &NotificationTemplateArguments{
+		MessageId: sed.GetMessageId(),
+		ThreadId:  sed.GetThreadId(),
+		UserId:    sed.GetUserId(),
+	}
+}
+
+// NewNotificationTemplateArgumentsForSystemEventType creates sample arguments
+// which are always available in system events of the specified type. These
+// arguments are used to check templates before they are saved.
+func NewNotificationTemplateArgumentsForSystemEventType(systemEventType cmb.Count) (args *NotificationTemplateArguments, err error) {
+	var sample = cmb.Id(1)
+
+	// Default arguments (TU).
+	args = &NotificationTemplateArguments{
+		ThreadId: &sample,
+		UserId:   &sample,
+	}
+
+	switch systemEventType {
+	case set.SystemEventType_ThreadParentChange,
+		set.SystemEventType_ThreadNameChange,
+		set.SystemEventType_ThreadDeletion,
+		set.SystemEventType_ForumNewThread,
+		set.SystemEventType_ThreadLock,
+		set.SystemEventType_ThreadUnlock:
+		// Default arguments are used (TU).
+
+	case set.SystemEventType_ThreadNewMessage,
+		set.SystemEventType_ThreadMessageEdit,
+		set.SystemEventType_ThreadMessageDeletion,
+		set.SystemEventType_MessageTextEdit,
+		set.SystemEventType_MessageParentChange,
+		set.SystemEventType_MessageDeletion,
+		set.SystemEventType_MessageReaction,
+		set.SystemEventType_MessageMention,
+		set.SystemEventType_MessageReportResolved:
+		// MTU.
+		args.MessageId = &sample
+
+	case set.SystemEventType_ThreadPollClosed:
+		// T.
+		args.UserId = nil
+
+	default:
+		return nil, errors.New(ErrSystemEventType)
+	}
+
+	return args, nil
+}
+
+// Get returns an argument for the placeholder type.
+func (args *NotificationTemplateArguments) Get(placeholderType string) (arg *cmb.Id) {
+	if args == nil {
+		return nil
+	}
+
+	switch placeholderType {
+	case nm.PlaceholderTypeM:
+		return args.MessageId
+	case nm.PlaceholderTypeR:
+		return args.ResourceId
+	case nm.PlaceholderTypeT:
+		return args.ThreadId
+	case nm.PlaceholderTypeU:
+		return args.UserId
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,55 @@
+package nt
+
+import (
+	"testing"
+
+	nm "github.com/vault-thirteen/SimpleBB/pkg/NM/models"
+	cmb "github.com/vault-thirteen/SimpleBB/pkg/common/models/base"
+	set "github.com/vault-thirteen/SimpleBB/pkg/common/models/complex/SystemEventType"
+	"github.com/vault-thirteen/auxie/tester"
+)
+
+func Test_NotificationTemplate_CheckArguments(t *testing.T) {
+	aTest := tester.New(t)
+
+	fs, err := nm.NewFormatString("Message {M} in thread {T} was edited by user {U}.")
+	aTest.MustBeNoError(err)
+
+	// Thread deletion events have no message.
+	tpl := &NotificationTemplate{FormatString: fs}
+	tpl.Arguments, err = NewNotificationTemplateArgumentsForSystemEventType(set.SystemEventType_ThreadDeletion)
+	aTest.MustBeNoError(err)
+	aTest.MustBeAnError(tpl.CheckArguments())
+
+	tpl.Arguments, err = NewNotificationTemplateArgumentsForSystemEventType(set.SystemEventType_MessageTextEdit)
+	aTest.MustBeNoError(err)
+	aTest.MustBeNoError(tpl.CheckArguments())
+
+	_, err = NewNotificationTemplateArgumentsForSystemEventType(0)
+	aTest.MustBeAnError(err)
+}
+
+func Test_NotificationTemplate_Render(t *testing.T) {
+	aTest := tester.New(t)
+
+	fs, err := nm.NewFormatString("Сообщение {M} в теме {T} изменено пользователем {U}.")
+	aTest.MustBeNoError(err)
+
+	var m, th, u = cmb.Id(3), cmb.Id(2), cmb.Id(1)
+	tpl := &NotificationTemplate{
+		FormatString: fs,
+		Arguments: &NotificationTemplateArguments{
+			MessageId: &m,
+			ThreadId:  &th,
+			UserId:    &u,
+		},
+	}
+
+	text, err := tpl.Render()
+	aTest.MustBeNoError(err)
+	aTest.MustBeEqual(text, cmb.Text("Сообщение 3 в теме 2 изменено пользователем 1."))
+
+	tpl.Arguments.MessageId = nil
+	_, err = tpl.Render()
+	aTest.MustBeAnError(err)
+}
@@ -2,6 +2,7 @@ package rpc
 
 import (
 	"github.com/vault-thirteen/SimpleBB/pkg/NM/models"
+	"github.com/vault-thirteen/SimpleBB/pkg/NM/models/nt"
 	"github.com/vault-thirteen/SimpleBB/pkg/common/interfaces/derived2"
 	"github.com/vault-thirteen/SimpleBB/pkg/common/models/UidList"
 	base2 "github.com/vault-thirteen/SimpleBB/pkg/common/models/base"
@@ -158,6 +159,74 @@ type DeleteResourceParams struct {
 }
 type DeleteResourceResult = rpc2.CommonResultWithSuccess
 
+// Notification template.
+
+type AddNotificationTemplateParams struct {
+	rpc2.CommonParams
+	Name            base2.Text  `json:"name"`
+	SystemEventType base2.Count `json:"systemEventType"`
+	Language        base2.Text  `json:"language"`
+	FormatString    base2.Text  `json:"formatString"`
+}
+type AddNotificationTemplateResult struct {
+	rpc2.CommonResult
+
+	// ID of the created notification template.
+	NotificationTemplateId base2.Id `json:"notificationTemplateId"`
+}
+
+type GetNotificationTemplateParams struct {
+	rpc2.CommonParams
+	NotificationTemplateId base2.Id `json:"notificationTemplateId"`
+}
+type GetNotificationTemplateResult struct {
+	rpc2.CommonResult
+	NotificationTemplate *nt.NotificationTemplate `json:"notificationTemplate"`
+}
+
+type ListNotificationTemplatesParams struct {
+	rpc2.CommonParams
+}
+type ListNotificationTemplatesResult struct {
+	rpc2.CommonResult
+	NotificationTemplates []nt.NotificationTemplate `json:"notificationTemplates"`
+}
+
+type ChangeNotificationTemplateParams struct {
+	rpc2.CommonParams
+	NotificationTemplateId base2.Id    `json:"notificationTemplateId"`
+	Name                   base2.Text  `json:"name"`
+	SystemEventType        base2.Count `json:"systemEventType"`
+	Language               base2.Text  `json:"language"`
+	FormatString           base2.Text  `json:"formatString"`
+}
+type ChangeNotificationTemplateResult = rpc2.CommonResultWithSuccess
+
+type DeleteNotificationTemplateParams struct {
+	rpc2.CommonParams
+	NotificationTemplateId base2.Id `json:"notificationTemplateId"`
+}
+type DeleteNotificationTemplateResult = rpc2.CommonResultWithSuccess
+
+// Language.
+
+type SetSelfLanguageParams struct {
+	rpc2.CommonParams
+	Language base2.Text `json:"language"`
+}
+type SetSelfLanguageResult = rpc2.CommonResultWithSuccess
+
+type GetSelfLanguageParams struct {
+	rpc2.CommonParams
+}
+type GetSelfLanguageResult struct {
+	rpc2.CommonResult
+
+	// Language of notifications. When a user has not selected a language,
+	// the default language is returned.
+	Language base2.Text `json:"language"`
+}
+
 // Other.
 
 type ProcessSystemEventSParams struct {
@@ -179,6 +248,7 @@ type ShowDiagnosticDataParams struct{}
 type ShowDiagnosticDataResult struct {
 	rpc2.CommonResult
 	rpc2.RequestsCount
+	rpc2.ScheduledTasks
 }
 
 type TestParams struct {
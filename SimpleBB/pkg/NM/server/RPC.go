@@ -44,6 +44,13 @@ func (srv *Server) initRpc() (err error) {
 		srv.GetResourceValue,
 		srv.GetListOfAllResourcesOnPage,
 		srv.DeleteResource,
+		srv.AddNotificationTemplate,
+		srv.GetNotificationTemplate,
+		srv.ListNotificationTemplates,
+		srv.ChangeNotificationTemplate,
+		srv.DeleteNotificationTemplate,
+		srv.SetSelfLanguage,
+		srv.GetSelfLanguage,
 		srv.ProcessSystemEventS,
 		srv.GetDKey,
 		srv.ShowDiagnosticData,
@@ -315,6 +322,122 @@ func (srv *Server) DeleteResource(params *json.RawMessage, _ *jrm1.ResponseMetaD
 	return r, nil
 }
 
+// Notification template.
+
+func (srv *Server) AddNotificationTemplate(params *json.RawMessage, _ *jrm1.ResponseMetaData) (result any, re *jrm1.RpcError) {
+	var p *nm.AddNotificationTemplateParams
+	re = jrm1.ParseParameters(params, &p)
+	if re != nil {
+		return nil, re
+	}
+
+	var r *nm.AddNotificationTemplateResult
+	r, re = srv.addNotificationTemplate(p)
+	if re != nil {
+		return nil, re
+	}
+
+	return r, nil
+}
+
+func (srv *Server) GetNotificationTemplate(params *json.RawMessage, _ *jrm1.ResponseMetaData) (result any, re *jrm1.RpcError) {
+	var p *nm.GetNotificationTemplateParams
+	re = jrm1.ParseParameters(params, &p)
+	if re != nil {
+		return nil, re
+	}
+
+	var r *nm.GetNotificationTemplateResult
+	r, re = srv.getNotificationTemplate(p)
+	if re != nil {
+		return nil, re
+	}
+
+	return r, nil
+}
+
+func (srv *Server) ListNotificationTemplates(params *json.RawMessage, _ *jrm1.ResponseMetaData) (result any, re *jrm1.RpcError) {
+	var p *nm.ListNotificationTemplatesParams
+	re = jrm1.ParseParameters(params, &p)
+	if re != nil {
+		return nil, re
+	}
+
+	var r *nm.ListNotificationTemplatesResult
+	r, re = srv.listNotificationTemplates(p)
+	if re != nil {
+		return nil, re
+	}
+
+	return r, nil
+}
+
+func (srv *Server) ChangeNotificationTemplate(params *json.RawMessage, _ *jrm1.ResponseMetaData) (result any, re *jrm1.RpcError) {
+	var p *nm.ChangeNotificationTemplateParams
+	re = jrm1.ParseParameters(params, &p)
+	if re != nil {
+		return nil, re
+	}
+
+	var r *nm.ChangeNotificationTemplateResult
+	r, re = srv.changeNotificationTemplate(p)
+	if re != nil {
+		return nil, re
+	}
+
+	return r, nil
+}
+
+func (srv *Server) DeleteNotificationTemplate(params *json.RawMessage, _ *jrm1.ResponseMetaData) (result any, re *jrm1.RpcError) {
+	var p *nm.DeleteNotificationTemplateParams
+	re = jrm1.ParseParameters(params, &p)
+	if re != nil {
+		return nil, re
+	}
+
+	var r *nm.DeleteNotificationTemplateResult
+	r, re = srv.deleteNotificationTemplate(p)
+	if re != nil {
+		return nil, re
+	}
+
+	return r, nil
+}
+
+// Language.
+
+func (srv *Server) SetSelfLanguage(params *json.RawMessage, _ *jrm1.ResponseMetaData) (result any, re *jrm1.RpcError) {
+	var p *nm.SetSelfLanguageParams
+	re = jrm1.ParseParameters(params, &p)
+	if re != nil {
+		return nil, re
+	}
+
+	var r *nm.SetSelfLanguageResult
+	r, re = srv.setSelfLanguage(p)
+	if re != nil {
+		return nil, re
+	}
+
+	return r, nil
+}
+
+func (srv *Server) GetSelfLanguage(params *json.RawMessage, _ *jrm1.ResponseMetaData) (result any, re *jrm1.RpcError) {
+	var p *nm.GetSelfLanguageParams
+	re = jrm1.ParseParameters(params, &p)
+	if re != nil {
+		return nil, re
+	}
+
+	var r *nm.GetSelfLanguageResult
+	r, re = srv.getSelfLanguage(p)
+	if re != nil {
+		return nil, re
+	}
+
+	return r, nil
+}
+
 // Other.
 
 func (srv *Server) ProcessSystemEventS(params *json.RawMessage, _ *jrm1.ResponseMetaData) (result any, re *jrm1.RpcError) {
@@ -6,6 +6,8 @@ import (
 	jrm1 "github.com/vault-thirteen/JSON-RPC-M1"
 	ac "github.com/vault-thirteen/SimpleBB/pkg/ACM/client"
 	am "github.com/vault-thirteen/SimpleBB/pkg/ACM/rpc"
+	nm2 "github.com/vault-thirteen/SimpleBB/pkg/NM/models"
+	"github.com/vault-thirteen/SimpleBB/pkg/NM/models/nt"
 	nm "github.com/vault-thirteen/SimpleBB/pkg/NM/rpc"
 	sc "github.com/vault-thirteen/SimpleBB/pkg/SM/client"
 	sm "github.com/vault-thirteen/SimpleBB/pkg/SM/models"
@@ -223,6 +225,18 @@ func (srv *Server) processSystemEvent_ThreadNameChange(se derived2.ISystemEvent)
 	return srv.sendNotificationsToThreadSubscribers(se)
 }
 
+func (srv *Server) processSystemEvent_ThreadPollClosed(se derived2.ISystemEvent) (re *jrm1.RpcError) {
+	return srv.sendNotificationsToThreadSubscribers(se)
+}
+
+func (srv *Server) processSystemEvent_ThreadLock(se derived2.ISystemEvent) (re *jrm1.RpcError) {
+	return srv.sendNotificationsToThreadSubscribers(se)
+}
+
+func (srv *Server) processSystemEvent_ThreadUnlock(se derived2.ISystemEvent) (re *jrm1.RpcError) {
+	return srv.sendNotificationsToThreadSubscribers(se)
+}
+
 func (srv *Server) processSystemEvent_ThreadDeletion(se derived2.ISystemEvent) (re *jrm1.RpcError) {
 	var threadId base2.Id
 	threadId, re = tryGetSystemEventThreadId(se)
@@ -345,6 +359,92 @@ func (srv *Server) processSystemEvent_MessageDeletion(se derived2.ISystemEvent)
 	return srv.sendNotificationToCreator(se)
 }
 
+// processSystemEvent_MessageReaction notifies the author of a message about a
+// reaction. Subscribers of the thread are not notified about reactions.
+func (srv *Server) processSystemEvent_MessageReaction(se derived2.ISystemEvent) (re *jrm1.RpcError) {
+	var userId, creatorId base2.Id
+	userId, re = tryGetSystemEventUserId(se)
+	if re != nil {
+		return re
+	}
+	creatorId, re = tryGetSystemEventCreatorId(se)
+	if re != nil {
+		return re
+	}
+
+	// The actor does not need a notification about self action.
+	if userId == creatorId {
+		return nil
+	}
+
+	return srv.sendNotificationToCreator(se)
+}
+
+// processSystemEvent_MessageMention notifies a user mentioned in a message.
+// The mentioned user is passed as the creator of the event.
+func (srv *Server) processSystemEvent_MessageMention(se derived2.ISystemEvent) (re *jrm1.RpcError) {
+	var userId, mentionedUserId base2.Id
+	userId, re = tryGetSystemEventUserId(se)
+	if re != nil {
+		return re
+	}
+	mentionedUserId, re = tryGetSystemEventCreatorId(se)
+	if re != nil {
+		return re
+	}
+
+	// Users mentioning themselves are not notified.
+	if userId == mentionedUserId {
+		return nil
+	}
+
+	return srv.sendNotificationToCreator(se)
+}
+
+// processSystemEvent_ForumNewThread notifies users subscribed to the forum of
+// a new thread or to sections containing the forum. Users preferring digests
+// are not notified here, the SM module queues the thread into their digests.
+func (srv *Server) processSystemEvent_ForumNewThread(se derived2.ISystemEvent) (re *jrm1.RpcError) {
+	var threadId, userId base2.Id
+	threadId, re = tryGetSystemEventThreadId(se)
+	if re != nil {
+		return re
+	}
+	userId, re = tryGetSystemEventUserId(se)
+	if re != nil {
+		return re
+	}
+
+	var instantSubscribers []base2.Id
+	instantSubscribers, re = srv.processNewThread(threadId, userId)
+	if re != nil {
+		return re
+	}
+
+	var notificationTexts = make(map[base2.Text]base2.Text)
+	var notificationText base2.Text
+	for _, subscriberId := range instantSubscribers {
+		notificationText, re = srv.composeNotificationTextForUser(se, subscriberId, notificationTexts)
+		if re != nil {
+			return re
+		}
+
+		_, re = srv.sendNotificationIfPossibleH(subscriberId, notificationText)
+		if re != nil {
+			return re
+		}
+	}
+
+	return nil
+}
+
+// processSystemEvent_MessageReportResolved notifies a user who reported a
+// message about the decision of a moderator. The reporter is passed as the
+// creator of the event.
+func (srv *Server) processSystemEvent_MessageReportResolved(se derived2.ISystemEvent) (re *jrm1.RpcError) {
+	return srv.sendNotificationToCreator(se)
+}
+
 // sendNotificationsToThreadSubscribers sends notifications to thread
 // subscribers.
 func (srv *Server) sendNotificationsToThreadSubscribers(se derived2.ISystemEvent) (re *jrm1.RpcError) {
@@ -354,11 +454,10 @@ func (srv *Server) sendNotificationsToThreadSubscribers(se derived2.ISystemEvent
 		return re
 	}
 
+	// Many subscribers share the same language, so texts are composed once
+	// per language.
+	var notificationTexts = make(map[base2.Text]base2.Text)
 	var notificationText base2.Text
-	notificationText, re = srv.composeNotificationText(se)
-	if re != nil {
-		return re
-	}
 
 	if tsr != nil {
 		for _, userId := range tsr.Users.AsArray() {
@@ -369,6 +468,11 @@ func (srv *Server) sendNotificationsToThreadSubscribers(se derived2.ISystemEvent
 				}
 			}
 
+			notificationText, re = srv.composeNotificationTextForUser(se, userId, notificationTexts)
+			if re != nil {
+				return re
+			}
+
 			_, re = srv.sendNotificationIfPossibleH(userId, notificationText)
 			if re != nil {
 				return re
@@ -383,7 +487,7 @@ func (srv *Server) sendNotificationsToThreadSubscribers(se derived2.ISystemEvent
 // object.
 func (srv *Server) sendNotificationToCreator(se derived2.ISystemEvent) (re *jrm1.RpcError) {
 	var notificationText base2.Text
-	notificationText, re = srv.composeNotificationText(se)
+	notificationText, re = srv.composeNotificationTextForUser(se, *se.GetSystemEventData().GetCreator(), make(map[base2.Text]base2.Text))
 	if re != nil {
 		return re
 	}
@@ -396,8 +500,149 @@ func (srv *Server) sendNotificationToCreator(se derived2.ISystemEvent) (re *jrm1
 	return nil
 }
 
-// composeNotificationText creates a text for notification about the system
-// event.
+// composeNotificationTextForUser creates a text for notification about the
+// system event in the language of the user. Composed texts are stored in the
+// map of texts by language, which may be shared by several calls.
+func (srv *Server) composeNotificationTextForUser(se derived2.ISystemEvent, userId base2.Id, textsByLanguage map[base2.Text]base2.Text) (text base2.Text, re *jrm1.RpcError) {
+	var language base2.Text
+	language, re = srv.getUserLanguageH(userId)
+	if re != nil {
+		return "", re
+	}
+
+	var ok bool
+	text, ok = textsByLanguage[language]
+	if ok {
+		return text, nil
+	}
+
+	text, re = srv.composeNotificationTextH(se, language)
+	if re != nil {
+		return "", re
+	}
+
+	textsByLanguage[language] = text
+
+	return text, nil
+}
+
+// composeNotificationTextH creates a text for notification about the system
+// event using a notification template. If there is no template for the
+// language, a template for the default language is used. If there is no
+// template at all, a built-in text is used.
+func (srv *Server) composeNotificationTextH(se derived2.ISystemEvent, language base2.Text) (text base2.Text, re *jrm1.RpcError) {
+	seType := base2.Count(se.GetSystemEventData().GetType().AsInt())
+
+	var template *nt.NotificationTemplate
+	template, re = srv.getNotificationTemplateH(seType, language)
+	if re != nil {
+		return "", re
+	}
+
+	if (template == nil) && (language != srv.settings.SystemSettings.DefaultLanguage) {
+		template, re = srv.getNotificationTemplateH(seType, srv.settings.SystemSettings.DefaultLanguage)
+		if re != nil {
+			return "", re
+		}
+	}
+
+	if template == nil {
+		return srv.composeNotificationText(se)
+	}
+
+	template.Arguments = nt.NewNotificationTemplateArgumentsFromSystemEventData(se.GetSystemEventData())
+
+	var err error
+	text, err = template.Render()
+	if err != nil {
+		srv.logError(err)
+		return "", jrm1.NewRpcErrorByUser(RpcErrorCode_TemplateArgumentIsMissing, fmt.Sprintf(RpcErrorMsgF_TemplateArgIsMissing, err.Error()), nil)
+	}
+
+	return text, nil
+}
+
+// getNotificationTemplateH reads a notification template for the system event
+// type and language. If the template is not found, null is returned.
+func (srv *Server) getNotificationTemplateH(systemEventType base2.Count, language base2.Text) (template *nt.NotificationTemplate, re *jrm1.RpcError) {
+	srv.dbo.LockForReading()
+	defer srv.dbo.UnlockAfterReading()
+
+	var err error
+	template, err = srv.dbo.GetNotificationTemplate(systemEventType, language)
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+
+	return template, nil
+}
+
+// getUserLanguageH reads the language of notifications for the user. If the
+// user has not selected a language, the default language is returned.
+func (srv *Server) getUserLanguageH(userId base2.Id) (language base2.Text, re *jrm1.RpcError) {
+	srv.dbo.LockForReading()
+	defer srv.dbo.UnlockAfterReading()
+
+	userLanguage, err := srv.dbo.GetUserLanguage(userId)
+	if err != nil {
+		return "", srv.databaseError(err)
+	}
+
+	if userLanguage == nil {
+		return srv.settings.SystemSettings.DefaultLanguage, nil
+	}
+
+	return *userLanguage, nil
+}
+
+// makeNotificationTemplate checks parameters of a notification template and
+// creates it. Each placeholder of the format string must have an argument in
+// the events of the selected type.
+func makeNotificationTemplate(name base2.Text, systemEventType base2.Count, language base2.Text, formatString base2.Text) (template *nt.NotificationTemplate, re *jrm1.RpcError) {
+	if len(name) == 0 {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_TemplateNameIsNotSet, RpcErrorMsg_TemplateNameIsNotSet, nil)
+	}
+
+	if !nm2.IsValidLanguage(language) {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_LanguageIsNotValid, RpcErrorMsg_LanguageIsNotValid, nil)
+	}
+
+	if len(formatString) == 0 {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_TextIsNotSet, RpcErrorMsg_TextIsNotSet, nil)
+	}
+
+	fs, err := nm2.NewFormatString(formatString.ToString())
+	if err != nil {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_FormatStringIsNotValid, fmt.Sprintf(RpcErrorMsgF_FormatStringIsNotValid, err.Error()), nil)
+	}
+
+	var args *nt.NotificationTemplateArguments
+	args, err = nt.NewNotificationTemplateArgumentsForSystemEventType(systemEventType)
+	if err != nil {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_SystemEventTypeIsNotValid, RpcErrorMsg_SystemEventTypeIsNotValid, nil)
+	}
+
+	template = &nt.NotificationTemplate{
+		Name:            name,
+		FormatString:    fs,
+		Arguments:       args,
+		SystemEventType: systemEventType,
+		Language:        language,
+	}
+
+	err = template.CheckArguments()
+	if err != nil {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_TemplateArgumentIsMissing, fmt.Sprintf(RpcErrorMsgF_TemplateArgIsMissing, err.Error()), nil)
+	}
+
+	// Arguments are set for each event separately.
+	template.Arguments = nil
+
+	return template, nil
+}
+
+// composeNotificationText creates a built-in text for notification about the
+// system event.
 func (srv *Server) composeNotificationText(se derived2.ISystemEvent) (text base2.Text, re *jrm1.RpcError) {
 	switch se.GetSystemEventData().GetType().AsInt() {
 	case set.SystemEventType_ThreadParentChange:
@@ -436,6 +681,34 @@ func (srv *Server) composeNotificationText(se derived2.ISystemEvent) (text base2
 		// Template: FMTU.
 		text = base2.Text(fmt.Sprintf("Your message (%d) in the thread (%d) was deleted by a user (%d).", *se.GetSystemEventData().GetMessageId(), *se.GetSystemEventData().GetThreadId(), *se.GetSystemEventData().GetUserId()))
 
+	case set.SystemEventType_MessageReaction:
+		// Template: FMTU.
+		text = base2.Text(fmt.Sprintf("Your message (%d) in the thread (%d) got a reaction from a user (%d).", *se.GetSystemEventData().GetMessageId(), *se.GetSystemEventData().GetThreadId(), *se.GetSystemEventData().GetUserId()))
+
+	case set.SystemEventType_ThreadPollClosed:
+		// Template: FT.
+		text = base2.Text(fmt.Sprintf("The poll in the thread (%d) is closed.", *se.GetSystemEventData().GetThreadId()))
+
+	case set.SystemEventType_MessageMention:
+		// Template: FUMT.
+		text = base2.Text(fmt.Sprintf("A user (%d) has mentioned you in a message (%d) in the thread (%d).", *se.GetSystemEventData().GetUserId(), *se.GetSystemEventData().GetMessageId(), *se.GetSystemEventData().GetThreadId()))
+
+	case set.SystemEventType_ForumNewThread:
+		// Template: FUT.
+		text = base2.Text(fmt.Sprintf("A user (%d) has created a new thread (%d) in a place you follow.", *se.GetSystemEventData().GetUserId(), *se.GetSystemEventData().GetThreadId()))
+
+	case set.SystemEventType_MessageReportResolved:
+		// Template: FMTU.
+		text = base2.Text(fmt.Sprintf("Your report about a message (%d) in the thread (%d) was resolved by a moderator (%d).", *se.GetSystemEventData().GetMessageId(), *se.GetSystemEventData().GetThreadId(), *se.GetSystemEventData().GetUserId()))
+
+	case set.SystemEventType_ThreadLock:
+		// Template: FUT.
+		text = base2.Text(fmt.Sprintf("A user (%d) has locked the thread (%d).", *se.GetSystemEventData().GetUserId(), *se.GetSystemEventData().GetThreadId()))
+
+	case set.SystemEventType_ThreadUnlock:
+		// Template: FUT.
+		text = base2.Text(fmt.Sprintf("A user (%d) has unlocked the thread (%d).", *se.GetSystemEventData().GetUserId(), *se.GetSystemEventData().GetThreadId()))
+
 	default:
 		return "", jrm1.NewRpcErrorByUser(RpcErrorCode_SystemEvent, RpcErrorMsg_SystemEvent, nil)
 	}
@@ -490,6 +763,32 @@ func (srv *Server) getThreadSubscribers(threadId base2.Id) (tsr *sm.ThreadSubscr
 	return result.ThreadSubscriptions, nil
 }
 
+// processNewThread passes a new thread to the SM module, which queues the
+// thread into digests of subscribers and returns subscribers who must be
+// notified instantly.
+func (srv *Server) processNewThread(threadId base2.Id, authorId base2.Id) (instantSubscribers []base2.Id, re *jrm1.RpcError) {
+	params := rpc2.ProcessNewThreadSParams{
+		DKeyParams: rpc3.DKeyParams{
+			// DKey is set during module start-up, so it is non-null.
+			DKey: *srv.dKeyForSM,
+		},
+		ThreadId: threadId,
+		AuthorId: authorId,
+	}
+	result := new(rpc2.ProcessNewThreadSResult)
+	var err error
+	re, err = srv.smServiceClient.MakeRequest(context.Background(), sc.FuncProcessNewThreadS, params, result)
+	if err != nil {
+		srv.logError(err)
+		return nil, jrm1.NewRpcErrorByUser(server2.RpcErrorCode_RPCCall, server2.RpcErrorMsg_RPCCall, nil)
+	}
+	if re != nil {
+		return nil, re
+	}
+
+	return result.InstantSubscribers, nil
+}
+
 // isUserSubscribed checks whether the user is subscribed to the thread.
 func (srv *Server) isUserSubscribed(threadId base2.Id, userId base2.Id) (isSubscribed base2.Flag, re *jrm1.RpcError) {
 	params := rpc2.IsUserSubscribedSParams{
@@ -541,6 +840,11 @@ func (srv *Server) sendNotificationIfPossibleH(userId base2.Id, text base2.Text)
 		return nil, srv.databaseError(err)
 	}
 
+	re = srv.publishNotification(insertedNotificationId)
+	if re != nil {
+		return nil, re
+	}
+
 	result = &nm.SendNotificationIfPossibleSResult{
 		IsSent:         true,
 		NotificationId: insertedNotificationId,
@@ -549,6 +853,26 @@ func (srv *Server) sendNotificationIfPossibleH(userId base2.Id, text base2.Text)
 	return result, nil
 }
 
+// publishNotification passes a newly created notification to the
+// notification publisher. The caller must hold the database lock.
+func (srv *Server) publishNotification(notificationId base2.Id) (re *jrm1.RpcError) {
+	if !srv.settings.SystemSettings.IsNotificationPushUsed {
+		return nil
+	}
+
+	notification, err := srv.dbo.GetNotificationById(notificationId)
+	if err != nil {
+		return srv.databaseError(err)
+	}
+	if notification == nil {
+		return jrm1.NewRpcErrorByUser(RpcErrorCode_NotificationIsNotFound, RpcErrorMsg_NotificationIsNotFound, nil)
+	}
+
+	srv.notificationPublisher.PublishNotification(notification)
+
+	return nil
+}
+
 // saveSystemEventH saves the system event into database.
 func (srv *Server) saveSystemEventH(se derived2.ISystemEvent) (re *jrm1.RpcError) {
 	srv.dbo.LockForWriting()
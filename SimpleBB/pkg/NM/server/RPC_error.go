@@ -22,6 +22,14 @@ const (
 	RpcErrorCode_FormatStringType          = 12
 	RpcErrorCode_ThreadIdIsNotSet          = 13
 	RpcErrorCode_CreatorIsNotSet           = 14
+	RpcErrorCode_TemplateIdIsNotSet        = 15
+	RpcErrorCode_TemplateIsNotFound        = 16
+	RpcErrorCode_TemplateNameIsNotSet      = 17
+	RpcErrorCode_SystemEventTypeIsNotValid = 18
+	RpcErrorCode_LanguageIsNotValid        = 19
+	RpcErrorCode_FormatStringIsNotValid    = 20
+	RpcErrorCode_TemplateArgumentIsMissing = 21
+	RpcErrorCode_TemplateAlreadyExists     = 22
 )
 
 // Messages.
@@ -40,6 +48,14 @@ const (
 	RpcErrorMsg_FormatStringType          = "format string type error"
 	RpcErrorMsg_ThreadIdIsNotSet          = "thread ID is not set"
 	RpcErrorMsg_CreatorIsNotSet           = "creator is not set"
+	RpcErrorMsg_TemplateIdIsNotSet        = "template ID is not set"
+	RpcErrorMsg_TemplateIsNotFound        = "template is not found"
+	RpcErrorMsg_TemplateNameIsNotSet      = "template name is not set"
+	RpcErrorMsg_SystemEventTypeIsNotValid = "system event type is not valid"
+	RpcErrorMsg_LanguageIsNotValid        = "language is not valid"
+	RpcErrorMsgF_FormatStringIsNotValid   = "format string is not valid: %s"
+	RpcErrorMsgF_TemplateArgIsMissing     = "template argument is missing: %s"
+	RpcErrorMsg_TemplateAlreadyExists     = "template already exists"
 )
 
 // Unique HTTP status codes used in the map:
@@ -63,5 +79,13 @@ func GetMapOfHttpStatusCodesByRpcErrorCodes() map[int]int {
 		RpcErrorCode_FormatStringType:          http.StatusBadRequest,
 		RpcErrorCode_ThreadIdIsNotSet:          http.StatusBadRequest,
 		RpcErrorCode_CreatorIsNotSet:           http.StatusBadRequest,
+		RpcErrorCode_TemplateIdIsNotSet:        http.StatusBadRequest,
+		RpcErrorCode_TemplateIsNotFound:        http.StatusNotFound,
+		RpcErrorCode_TemplateNameIsNotSet:      http.StatusBadRequest,
+		RpcErrorCode_SystemEventTypeIsNotValid: http.StatusBadRequest,
+		RpcErrorCode_LanguageIsNotValid:        http.StatusBadRequest,
+		RpcErrorCode_FormatStringIsNotValid:    http.StatusBadRequest,
+		RpcErrorCode_TemplateArgumentIsMissing: http.StatusBadRequest,
+		RpcErrorCode_TemplateAlreadyExists:     http.StatusConflict,
 	}
 }
@@ -17,6 +17,7 @@ import (
 
 	jrm1 "github.com/vault-thirteen/JSON-RPC-M1"
 	nm "github.com/vault-thirteen/SimpleBB/pkg/NM/models"
+	"github.com/vault-thirteen/SimpleBB/pkg/NM/models/nt"
 )
 
 // RPC functions.
@@ -54,6 +55,11 @@ func (srv *Server) addNotification(p *rpc2.AddNotificationParams) (result *rpc2.
 		return nil, srv.databaseError(err)
 	}
 
+	re = srv.publishNotification(insertedNotificationId)
+	if re != nil {
+		return nil, re
+	}
+
 	result = &rpc2.AddNotificationResult{
 		NotificationId: insertedNotificationId,
 	}
@@ -92,6 +98,11 @@ func (srv *Server) addNotificationS(p *rpc2.AddNotificationSParams) (result *rpc
 		return nil, srv.databaseError(err)
 	}
 
+	re = srv.publishNotification(insertedNotificationId)
+	if re != nil {
+		return nil, re
+	}
+
 	result = &rpc2.AddNotificationSResult{
 		NotificationId: insertedNotificationId,
 	}
@@ -613,6 +624,288 @@ func (srv *Server) deleteResource(p *rpc2.DeleteResourceParams) (result *rpc2.De
 	return result, nil
 }
 
+// Notification template.
+
+// addNotificationTemplate creates a new notification template.
+func (srv *Server) addNotificationTemplate(p *rpc2.AddNotificationTemplateParams) (result *rpc2.AddNotificationTemplateResult, re *jrm1.RpcError) {
+	// Check parameters.
+	var template *nt.NotificationTemplate
+	template, re = makeNotificationTemplate(p.Name, p.SystemEventType, p.Language, p.FormatString)
+	if re != nil {
+		return nil, re
+	}
+
+	var userRoles *am.GetSelfRolesResult
+	userRoles, re = srv.mustBeAnAuthToken(p.Auth)
+	if re != nil {
+		return nil, re
+	}
+
+	// Check permissions.
+	if !userRoles.User.GetUserParameters().GetRoles().IsAdministrator {
+		return nil, jrm1.NewRpcErrorByUser(c.RpcErrorCode_Permission, c.RpcErrorMsg_Permission, nil)
+	}
+
+	srv.dbo.LockForWriting()
+	defer srv.dbo.UnlockAfterWriting()
+
+	// Only a single template may exist for an event type and language.
+	existingTemplate, err := srv.dbo.GetNotificationTemplate(template.SystemEventType, template.Language)
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+
+	if existingTemplate != nil {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_TemplateAlreadyExists, RpcErrorMsg_TemplateAlreadyExists, nil)
+	}
+
+	var insertedTemplateId base2.Id
+	insertedTemplateId, err = srv.dbo.InsertNotificationTemplate(template)
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+
+	result = &rpc2.AddNotificationTemplateResult{
+		NotificationTemplateId: insertedTemplateId,
+	}
+
+	return result, nil
+}
+
+// getNotificationTemplate reads a notification template.
+func (srv *Server) getNotificationTemplate(p *rpc2.GetNotificationTemplateParams) (result *rpc2.GetNotificationTemplateResult, re *jrm1.RpcError) {
+	// Check parameters.
+	if p.NotificationTemplateId == 0 {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_TemplateIdIsNotSet, RpcErrorMsg_TemplateIdIsNotSet, nil)
+	}
+
+	var userRoles *am.GetSelfRolesResult
+	userRoles, re = srv.mustBeAnAuthToken(p.Auth)
+	if re != nil {
+		return nil, re
+	}
+
+	// Check permissions.
+	if !userRoles.User.GetUserParameters().GetRoles().IsAdministrator {
+		return nil, jrm1.NewRpcErrorByUser(c.RpcErrorCode_Permission, c.RpcErrorMsg_Permission, nil)
+	}
+
+	srv.dbo.LockForReading()
+	defer srv.dbo.UnlockAfterReading()
+
+	template, err := srv.dbo.GetNotificationTemplateById(p.NotificationTemplateId)
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+
+	if template == nil {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_TemplateIsNotFound, RpcErrorMsg_TemplateIsNotFound, nil)
+	}
+
+	result = &rpc2.GetNotificationTemplateResult{
+		NotificationTemplate: template,
+	}
+
+	return result, nil
+}
+
+// listNotificationTemplates reads all notification templates.
+func (srv *Server) listNotificationTemplates(p *rpc2.ListNotificationTemplatesParams) (result *rpc2.ListNotificationTemplatesResult, re *jrm1.RpcError) {
+	var userRoles *am.GetSelfRolesResult
+	userRoles, re = srv.mustBeAnAuthToken(p.Auth)
+	if re != nil {
+		return nil, re
+	}
+
+	// Check permissions.
+	if !userRoles.User.GetUserParameters().GetRoles().IsAdministrator {
+		return nil, jrm1.NewRpcErrorByUser(c.RpcErrorCode_Permission, c.RpcErrorMsg_Permission, nil)
+	}
+
+	srv.dbo.LockForReading()
+	defer srv.dbo.UnlockAfterReading()
+
+	templates, err := srv.dbo.ListNotificationTemplates()
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+
+	result = &rpc2.ListNotificationTemplatesResult{
+		NotificationTemplates: templates,
+	}
+
+	return result, nil
+}
+
+// changeNotificationTemplate changes a notification template.
+func (srv *Server) changeNotificationTemplate(p *rpc2.ChangeNotificationTemplateParams) (result *rpc2.ChangeNotificationTemplateResult, re *jrm1.RpcError) {
+	// Check parameters.
+	if p.NotificationTemplateId == 0 {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_TemplateIdIsNotSet, RpcErrorMsg_TemplateIdIsNotSet, nil)
+	}
+
+	var template *nt.NotificationTemplate
+	template, re = makeNotificationTemplate(p.Name, p.SystemEventType, p.Language, p.FormatString)
+	if re != nil {
+		return nil, re
+	}
+	template.Id = p.NotificationTemplateId
+
+	var userRoles *am.GetSelfRolesResult
+	userRoles, re = srv.mustBeAnAuthToken(p.Auth)
+	if re != nil {
+		return nil, re
+	}
+
+	// Check permissions.
+	if !userRoles.User.GetUserParameters().GetRoles().IsAdministrator {
+		return nil, jrm1.NewRpcErrorByUser(c.RpcErrorCode_Permission, c.RpcErrorMsg_Permission, nil)
+	}
+
+	srv.dbo.LockForWriting()
+	defer srv.dbo.UnlockAfterWriting()
+
+	oldTemplate, err := srv.dbo.GetNotificationTemplateById(template.Id)
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+
+	if oldTemplate == nil {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_TemplateIsNotFound, RpcErrorMsg_TemplateIsNotFound, nil)
+	}
+
+	// Only a single template may exist for an event type and language.
+	var existingTemplate *nt.NotificationTemplate
+	existingTemplate, err = srv.dbo.GetNotificationTemplate(template.SystemEventType, template.Language)
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+
+	if (existingTemplate != nil) && (existingTemplate.Id != template.Id) {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_TemplateAlreadyExists, RpcErrorMsg_TemplateAlreadyExists, nil)
+	}
+
+	err = srv.dbo.UpdateNotificationTemplate(template)
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+
+	result = &rpc2.ChangeNotificationTemplateResult{
+		Success: rpc3.Success{
+			OK: true,
+		},
+	}
+
+	return result, nil
+}
+
+// deleteNotificationTemplate removes a notification template.
+func (srv *Server) deleteNotificationTemplate(p *rpc2.DeleteNotificationTemplateParams) (result *rpc2.DeleteNotificationTemplateResult, re *jrm1.RpcError) {
+	// Check parameters.
+	if p.NotificationTemplateId == 0 {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_TemplateIdIsNotSet, RpcErrorMsg_TemplateIdIsNotSet, nil)
+	}
+
+	var userRoles *am.GetSelfRolesResult
+	userRoles, re = srv.mustBeAnAuthToken(p.Auth)
+	if re != nil {
+		return nil, re
+	}
+
+	// Check permissions.
+	if !userRoles.User.GetUserParameters().GetRoles().IsAdministrator {
+		return nil, jrm1.NewRpcErrorByUser(c.RpcErrorCode_Permission, c.RpcErrorMsg_Permission, nil)
+	}
+
+	srv.dbo.LockForWriting()
+	defer srv.dbo.UnlockAfterWriting()
+
+	template, err := srv.dbo.GetNotificationTemplateById(p.NotificationTemplateId)
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+
+	if template == nil {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_TemplateIsNotFound, RpcErrorMsg_TemplateIsNotFound, nil)
+	}
+
+	err = srv.dbo.DeleteNotificationTemplateById(p.NotificationTemplateId)
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+
+	result = &rpc2.DeleteNotificationTemplateResult{
+		Success: rpc3.Success{
+			OK: true,
+		},
+	}
+
+	return result, nil
+}
+
+// Language.
+
+// setSelfLanguage sets the language of notifications for the current user.
+func (srv *Server) setSelfLanguage(p *rpc2.SetSelfLanguageParams) (result *rpc2.SetSelfLanguageResult, re *jrm1.RpcError) {
+	// Check parameters.
+	if !nm.IsValidLanguage(p.Language) {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_LanguageIsNotValid, RpcErrorMsg_LanguageIsNotValid, nil)
+	}
+
+	var userRoles *am.GetSelfRolesResult
+	userRoles, re = srv.mustBeAnAuthToken(p.Auth)
+	if re != nil {
+		return nil, re
+	}
+
+	// Check permissions.
+	if !userRoles.User.GetUserParameters().GetRoles().CanLogIn {
+		return nil, jrm1.NewRpcErrorByUser(c.RpcErrorCode_Permission, c.RpcErrorMsg_Permission, nil)
+	}
+
+	srv.dbo.LockForWriting()
+	defer srv.dbo.UnlockAfterWriting()
+
+	err := srv.dbo.SetUserLanguage(userRoles.User.GetUserParameters().GetId(), p.Language)
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+
+	result = &rpc2.SetSelfLanguageResult{
+		Success: rpc3.Success{
+			OK: true,
+		},
+	}
+
+	return result, nil
+}
+
+// getSelfLanguage reads the language of notifications for the current user.
+func (srv *Server) getSelfLanguage(p *rpc2.GetSelfLanguageParams) (result *rpc2.GetSelfLanguageResult, re *jrm1.RpcError) {
+	var userRoles *am.GetSelfRolesResult
+	userRoles, re = srv.mustBeAnAuthToken(p.Auth)
+	if re != nil {
+		return nil, re
+	}
+
+	// Check permissions.
+	if !userRoles.User.GetUserParameters().GetRoles().CanLogIn {
+		return nil, jrm1.NewRpcErrorByUser(c.RpcErrorCode_Permission, c.RpcErrorMsg_Permission, nil)
+	}
+
+	var language base2.Text
+	language, re = srv.getUserLanguageH(userRoles.User.GetUserParameters().GetId())
+	if re != nil {
+		return nil, re
+	}
+
+	result = &rpc2.GetSelfLanguageResult{
+		Language: language,
+	}
+
+	return result, nil
+}
+
 // Other.
 
 // processSystemEventS processes a system event. This method is used by the
@@ -660,6 +953,20 @@ func (srv *Server) processSystemEventS(p *rpc2.ProcessSystemEventSParams) (resul
 		re = srv.processSystemEvent_MessageParentChange(se)
 	case set.SystemEventType_MessageDeletion:
 		re = srv.processSystemEvent_MessageDeletion(se)
+	case set.SystemEventType_MessageReaction:
+		re = srv.processSystemEvent_MessageReaction(se)
+	case set.SystemEventType_ThreadPollClosed:
+		re = srv.processSystemEvent_ThreadPollClosed(se)
+	case set.SystemEventType_MessageMention:
+		re = srv.processSystemEvent_MessageMention(se)
+	case set.SystemEventType_ForumNewThread:
+		re = srv.processSystemEvent_ForumNewThread(se)
+	case set.SystemEventType_MessageReportResolved:
+		re = srv.processSystemEvent_MessageReportResolved(se)
+	case set.SystemEventType_ThreadLock:
+		re = srv.processSystemEvent_ThreadLock(se)
+	case set.SystemEventType_ThreadUnlock:
+		re = srv.processSystemEvent_ThreadUnlock(se)
 
 	default:
 		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_SystemEvent, RpcErrorMsg_SystemEvent, nil)
@@ -698,6 +1005,9 @@ func (srv *Server) showDiagnosticData() (result *rpc2.ShowDiagnosticDataResult,
 			TotalRequestsCount:      base2.Text(trc),
 			SuccessfulRequestsCount: base2.Text(src),
 		},
+		ScheduledTasks: rpc3.ScheduledTasks{
+			ScheduledTasks: srv.scheduler.GetTaskStatuses(),
+		},
 	}
 
 	return result, nil
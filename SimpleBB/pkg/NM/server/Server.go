@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"github.com/vault-thirteen/SimpleBB/pkg/NM/models/complex/IncidentManager"
+	"github.com/vault-thirteen/SimpleBB/pkg/NM/models/complex/NotificationPublisher"
 	"github.com/vault-thirteen/SimpleBB/pkg/common/interfaces/base1"
 	cc "github.com/vault-thirteen/SimpleBB/pkg/common/models/Client"
 	"github.com/vault-thirteen/SimpleBB/pkg/common/models/DKey"
@@ -14,7 +15,6 @@ import (
 	cmb "github.com/vault-thirteen/SimpleBB/pkg/common/models/base"
 	server2 "github.com/vault-thirteen/SimpleBB/pkg/common/models/server"
 	cset "github.com/vault-thirteen/SimpleBB/pkg/common/models/settings"
-	"github.com/vault-thirteen/SimpleBB/pkg/common/models/simple"
 	"log"
 	"net"
 	"net/http"
@@ -62,6 +62,9 @@ type Server struct {
 	// Incident manager.
 	incidentManager *im.IncidentManager
 
+	// Notification publisher.
+	notificationPublisher *np.NotificationPublisher
+
 	// Internal DKeys.
 	dKeyI *dk.DKey
 
@@ -124,12 +127,20 @@ func NewServer(s base.ISettings) (srv *Server, err error) {
 		return nil, err
 	}
 
+	err = srv.initNotificationPublisher()
+	if err != nil {
+		return nil, err
+	}
+
 	err = srv.initKeys()
 	if err != nil {
 		return nil, err
 	}
 
-	srv.initScheduler()
+	err = srv.initScheduler()
+	if err != nil {
+		return nil, err
+	}
 
 	return srv, nil
 }
@@ -163,6 +174,11 @@ func (srv *Server) Start() (err error) {
 		return err
 	}
 
+	err = srv.notificationPublisher.Start()
+	if err != nil {
+		return err
+	}
+
 	err = srv.pingClientsForExternalServices()
 	if err != nil {
 		return err
@@ -206,6 +222,11 @@ func (srv *Server) Stop() (err error) {
 		return err
 	}
 
+	err = srv.notificationPublisher.Stop()
+	if err != nil {
+		return err
+	}
+
 	err = srv.dbo.Fin()
 	if err != nil {
 		return err
@@ -312,7 +333,16 @@ func (srv *Server) createClientsForExternalServices() (err error) {
 			fmt.Println(server2.MsgIncidentsTableIsDisabled)
 		} else {
 			fmt.Println(server2.MsgIncidentsTableIsEnabled)
+		}
+
+		if !srv.settings.SystemSettings.IsNotificationPushUsed {
+			fmt.Println(server2.MsgNotificationPushIsDisabled)
+		} else {
+			fmt.Println(server2.MsgNotificationPushIsEnabled)
+		}
 
+		if srv.settings.SystemSettings.IsTableOfIncidentsUsed ||
+			srv.settings.SystemSettings.IsNotificationPushUsed {
 			var gwmSCS = &cset.ServiceClientSettings{
 				Schema:                      srv.settings.GwmSettings.Schema,
 				Host:                        srv.settings.GwmSettings.Host,
@@ -404,11 +434,25 @@ func (srv *Server) initIncidentManager() (err error) {
 	return nil
 }
 
-func (srv *Server) initScheduler() {
-	funcs60 := []simple.ScheduledFn{
-		srv.clearNotifications,
+// This method uses the GWM service client as an argument, thus it should be
+// called after initialisation of all external service clients.
+func (srv *Server) initNotificationPublisher() (err error) {
+	srv.notificationPublisher = np.NewNotificationPublisher(srv.settings.SystemSettings.IsNotificationPushUsed.AsBool(), srv.gwmServiceClient)
+
+	return nil
+}
+
+func (srv *Server) initScheduler() (err error) {
+	tasks := []cm.Task{
+		{Name: "clearNotifications", Schedule: "@every 1m", Fn: srv.clearNotifications, Timeout: time.Minute},
+	}
+
+	srv.scheduler, err = cm.NewScheduler(srv, tasks)
+	if err != nil {
+		return err
 	}
-	srv.scheduler = cm.NewScheduler(srv, funcs60, nil, nil)
+
+	return nil
 }
 
 func (srv *Server) initKeys() (err error) {
@@ -1,18 +1,19 @@
 package server
 
 import (
+	"context"
 	"time"
 
 	"github.com/vault-thirteen/SimpleBB/pkg/NM/dbo"
 )
 
-func (srv *Server) clearNotifications() (err error) {
+func (srv *Server) clearNotifications(ctx context.Context) (err error) {
 	srv.dbo.LockForWriting()
 	defer srv.dbo.UnlockAfterWriting()
 
 	timeBorder := time.Now().Add(-time.Duration(srv.settings.SystemSettings.NotificationTtl) * time.Second)
 
-	_, err = srv.dbo.GetPreparedStatementByIndex(dbo.DbPsid_ClearNotifications).Exec(timeBorder)
+	_, err = srv.dbo.GetPreparedStatementByIndex(dbo.DbPsid_ClearNotifications).ExecContext(ctx, timeBorder)
 	if err != nil {
 		return err
 	}
@@ -13,12 +13,19 @@ type SystemSettings struct {
 	PageSize               base2.Count `json:"pageSize"`
 	DKeySize               base2.Count `json:"dKeySize"`
 
+	// Language of notifications for users who have not selected a language.
+	DefaultLanguage base2.Text `json:"defaultLanguage"`
+
 	// This setting must be synchronised with settings of the Gateway module.
 	IsTableOfIncidentsUsed base2.Flag `json:"isTableOfIncidentsUsed"`
 
 	// This setting is used only when a table of incidents is enabled.
 	BlockTimePerIncident BlockTimePerIncident `json:"blockTimePerIncident"`
 
+	// New notifications are pushed to the Gateway module which streams them
+	// to connected clients.
+	IsNotificationPushUsed base2.Flag `json:"isNotificationPushUsed"`
+
 	IsDebugMode base2.Flag `json:"isDebugMode"`
 }
 
@@ -33,7 +40,8 @@ func (s SystemSettings) Check() (err error) {
 	if (s.NotificationTtl == 0) ||
 		(s.NotificationCountLimit == 0) ||
 		(s.PageSize == 0) ||
-		(s.DKeySize == 0) {
+		(s.DKeySize == 0) ||
+		(len(s.DefaultLanguage) == 0) {
 		return errors.New(c.MsgSystemSettingError)
 	}
 
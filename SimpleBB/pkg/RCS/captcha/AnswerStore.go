@@ -0,0 +1,79 @@
+package captcha
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// AnswerStoreSizeLimit protects the memory from clients which create tasks
+// without solving them.
+const AnswerStoreSizeLimit = 100_000
+
+// answerStore keeps expected answers of tasks until they are checked or
+// expired.
+type answerStore struct {
+	ttl     time.Duration
+	guard   sync.Mutex
+	answers map[string]*storedAnswer
+}
+
+type storedAnswer struct {
+	value     string
+	expiresAt time.Time
+}
+
+func newAnswerStore(ttl time.Duration) (s *answerStore) {
+	return &answerStore{
+		ttl:     ttl,
+		answers: make(map[string]*storedAnswer),
+	}
+}
+
+func (s *answerStore) put(taskId string, value string) (err error) {
+	s.guard.Lock()
+	defer s.guard.Unlock()
+
+	if len(s.answers) >= AnswerStoreSizeLimit {
+		return errors.New(ErrTooManyTasks)
+	}
+
+	s.answers[taskId] = &storedAnswer{
+		value:     value,
+		expiresAt: time.Now().Add(s.ttl),
+	}
+
+	return nil
+}
+
+// take returns the expected answer and forgets the task, so that a task can
+// not be guessed in several attempts.
+func (s *answerStore) take(taskId string) (value string, err error) {
+	s.guard.Lock()
+	defer s.guard.Unlock()
+
+	sa, ok := s.answers[taskId]
+	if !ok {
+		return "", errors.New(ErrTaskIsNotFound)
+	}
+
+	delete(s.answers, taskId)
+
+	if time.Now().After(sa.expiresAt) {
+		return "", errors.New(ErrTaskIsNotFound)
+	}
+
+	return sa.value, nil
+}
+
+func (s *answerStore) clearJunk() {
+	s.guard.Lock()
+	defer s.guard.Unlock()
+
+	now := time.Now()
+	for taskId, sa := range s.answers {
+		if now.After(sa.expiresAt) {
+			delete(s.answers, taskId)
+		}
+	}
+}
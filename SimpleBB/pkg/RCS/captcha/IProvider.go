@@ -0,0 +1,21 @@
+package captcha
+
+import (
+	ck "github.com/vault-thirteen/SimpleBB/pkg/common/models/CaptchaKind"
+)
+
+// IProvider creates and checks captcha tasks of a single kind.
+type IProvider interface {
+	GetKind() ck.CaptchaKind
+
+	CreateTask() (task *Task, err error)
+
+	// CheckTask compares the answer with the expected one. A task may be
+	// checked only once.
+	CheckTask(taskId string, answer string) (ok bool, err error)
+
+	// ClearJunk deletes expired tasks.
+	ClearJunk() (err error)
+
+	Stop() (err error)
+}
@@ -0,0 +1,68 @@
+package captcha
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	rc "github.com/vault-thirteen/RingCaptcha/server"
+	ck "github.com/vault-thirteen/SimpleBB/pkg/common/models/CaptchaKind"
+)
+
+// ImageProvider creates image captcha using the RingCaptcha library.
+type ImageProvider struct {
+	cm *rc.CaptchaManager
+}
+
+func NewImageProvider(cm *rc.CaptchaManager) (p *ImageProvider) {
+	return &ImageProvider{cm: cm}
+}
+
+// GetListenDsn returns the address of the HTTP server serving saved images.
+func (p *ImageProvider) GetListenDsn() (dsn string) {
+	return p.cm.GetListenDsn()
+}
+
+func (p *ImageProvider) GetKind() ck.CaptchaKind {
+	return ck.CaptchaKind_Image
+}
+
+func (p *ImageProvider) CreateTask() (task *Task, err error) {
+	resp, err := p.cm.CreateCaptcha()
+	if err != nil {
+		return nil, err
+	}
+
+	task = &Task{
+		Id:                  resp.TaskId,
+		Kind:                ck.CaptchaKind_Image,
+		ImageFormat:         resp.ImageFormat,
+		IsImageDataReturned: resp.IsImageDataReturned,
+		ImageData:           resp.ImageData,
+	}
+
+	return task, nil
+}
+
+func (p *ImageProvider) CheckTask(taskId string, answer string) (ok bool, err error) {
+	var value uint64
+	value, err = strconv.ParseUint(strings.TrimSpace(answer), 10, 0)
+	if (err != nil) || (value == 0) {
+		return false, errors.New(ErrAnswerIsNotANumber)
+	}
+
+	resp, err := p.cm.CheckCaptcha(&rc.CheckCaptchaRequest{TaskId: taskId, Value: uint(value)})
+	if err != nil {
+		return false, err
+	}
+
+	return resp.IsSuccess, nil
+}
+
+func (p *ImageProvider) ClearJunk() (err error) {
+	return p.cm.ClearJunk()
+}
+
+func (p *ImageProvider) Stop() (err error) {
+	return p.cm.Stop()
+}
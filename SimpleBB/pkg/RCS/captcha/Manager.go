@@ -0,0 +1,107 @@
+package captcha
+
+import (
+	"errors"
+	"fmt"
+
+	ck "github.com/vault-thirteen/SimpleBB/pkg/common/models/CaptchaKind"
+)
+
+const (
+	ErrNoProviders           = "no captcha providers"
+	ErrFDuplicateProvider    = "duplicate provider of captcha kind %v"
+	ErrKindIsNotSupported    = "captcha kind is not supported"
+	ErrTaskIsNotFound        = "task is not found"
+	ErrTooManyTasks          = "too many tasks"
+	ErrAnswerIsNotANumber    = "answer is not a number"
+	ErrDigitsCountIsNotValid = "digits count is not valid"
+	ErrQuestionsAreNotSet    = "questions are not set"
+	ErrFQuestionIsNotValid   = "question %v is not valid"
+	ErrDifficultyIsNotValid  = "difficulty is not valid"
+	ErrTtlIsNotSet           = "time to live is not set"
+)
+
+// Manager routes captcha tasks to providers of different kinds. The kind of a
+// checked task is found by its ID, so that a client can not answer a task of
+// another kind than the one which was issued.
+type Manager struct {
+	providers map[ck.CaptchaKind]IProvider
+}
+
+func NewManager(providers ...IProvider) (m *Manager, err error) {
+	if len(providers) == 0 {
+		return nil, errors.New(ErrNoProviders)
+	}
+
+	m = &Manager{
+		providers: make(map[ck.CaptchaKind]IProvider, len(providers)),
+	}
+
+	for _, p := range providers {
+		_, isDuplicate := m.providers[p.GetKind()]
+		if isDuplicate {
+			return nil, fmt.Errorf(ErrFDuplicateProvider, p.GetKind())
+		}
+
+		m.providers[p.GetKind()] = p
+	}
+
+	return m, nil
+}
+
+// IsKindSupported checks whether tasks of the kind may be created. When the
+// kind is not set, the default kind is checked.
+func (m *Manager) IsKindSupported(kind ck.CaptchaKind) bool {
+	if kind == 0 {
+		kind = ck.CaptchaKind_Default
+	}
+
+	_, ok := m.providers[kind]
+	return ok
+}
+
+// CreateTask creates a task of the specified kind. When the kind is not set,
+// the default kind is used.
+func (m *Manager) CreateTask(kind ck.CaptchaKind) (task *Task, err error) {
+	if kind == 0 {
+		kind = ck.CaptchaKind_Default
+	}
+
+	p, ok := m.providers[kind]
+	if !ok {
+		return nil, errors.New(ErrKindIsNotSupported)
+	}
+
+	return p.CreateTask()
+}
+
+func (m *Manager) CheckTask(taskId string, answer string) (ok bool, err error) {
+	p, found := m.providers[GetTaskKind(taskId)]
+	if !found {
+		return false, errors.New(ErrTaskIsNotFound)
+	}
+
+	return p.CheckTask(taskId, answer)
+}
+
+func (m *Manager) ClearJunk() (err error) {
+	for _, p := range m.providers {
+		err = p.ClearJunk()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *Manager) Stop() (err error) {
+	for _, p := range m.providers {
+		err = p.Stop()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
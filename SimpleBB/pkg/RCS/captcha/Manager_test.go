@@ -0,0 +1,105 @@
+package captcha
+
+import (
+	"testing"
+	"time"
+
+	ck "github.com/vault-thirteen/SimpleBB/pkg/common/models/CaptchaKind"
+	"github.com/vault-thirteen/auxie/tester"
+)
+
+func Test_GetTaskKind(t *testing.T) {
+	aTest := tester.New(t)
+
+	for _, kind := range []ck.CaptchaKind{ck.CaptchaKind_Question, ck.CaptchaKind_ProofOfWork} {
+		taskId, err := NewTaskId(kind)
+		aTest.MustBeNoError(err)
+		aTest.MustBeEqual(GetTaskKind(taskId), kind)
+	}
+
+	aTest.MustBeEqual(GetTaskKind("3f1b8e0c2a"), ck.CaptchaKind(ck.CaptchaKind_Image))
+	aTest.MustBeEqual(GetTaskKind("unknown_3f1b8e0c2a"), ck.CaptchaKind(ck.CaptchaKind_Image))
+}
+
+func Test_Manager(t *testing.T) {
+	aTest := tester.New(t)
+
+	qp, err := NewQuestionProvider([]Question{{Text: "What colour is the sky?", Answers: []string{"blue"}}}, time.Minute)
+	aTest.MustBeNoError(err)
+
+	var pp *ProofOfWorkProvider
+	pp, err = NewProofOfWorkProvider(8, time.Minute)
+	aTest.MustBeNoError(err)
+
+	_, err = NewManager()
+	aTest.MustBeAnError(err)
+
+	_, err = NewManager(qp, qp)
+	aTest.MustBeAnError(err)
+
+	var m *Manager
+	m, err = NewManager(qp, pp)
+	aTest.MustBeNoError(err)
+	aTest.MustBeEqual(m.IsKindSupported(ck.CaptchaKind_Question), true)
+	aTest.MustBeEqual(m.IsKindSupported(ck.CaptchaKind_ProofOfWork), true)
+	aTest.MustBeEqual(m.IsKindSupported(ck.CaptchaKind_Image), false)
+	aTest.MustBeEqual(m.IsKindSupported(0), false)
+
+	// Image captcha is the default kind and it is not provided here.
+	_, err = m.CreateTask(0)
+	aTest.MustBeAnError(err)
+
+	_, err = m.CreateTask(ck.CaptchaKind_Image)
+	aTest.MustBeAnError(err)
+
+	// Tasks are checked by the provider which created them.
+	var task *Task
+	task, err = m.CreateTask(ck.CaptchaKind_ProofOfWork)
+	aTest.MustBeNoError(err)
+	aTest.MustBeEqual(task.Kind, ck.CaptchaKind(ck.CaptchaKind_ProofOfWork))
+
+	var ok bool
+	ok, err = m.CheckTask(task.Id, SolveProofOfWork(task.Challenge, task.Difficulty))
+	aTest.MustBeNoError(err)
+	aTest.MustBeEqual(ok, true)
+
+	// A task is checked only once.
+	_, err = m.CheckTask(task.Id, SolveProofOfWork(task.Challenge, task.Difficulty))
+	aTest.MustBeAnError(err)
+
+	task, err = m.CreateTask(ck.CaptchaKind_Question)
+	aTest.MustBeNoError(err)
+
+	ok, err = m.CheckTask(task.Id, " Blue ")
+	aTest.MustBeNoError(err)
+	aTest.MustBeEqual(ok, true)
+
+	// Image tasks are not provided.
+	_, err = m.CheckTask("3f1b8e0c2a", "123")
+	aTest.MustBeAnError(err)
+}
+
+func Test_answerStore(t *testing.T) {
+	aTest := tester.New(t)
+
+	s := newAnswerStore(time.Minute)
+	aTest.MustBeNoError(s.put("a", "1"))
+	aTest.MustBeNoError(s.put("b", "2"))
+
+	value, err := s.take("a")
+	aTest.MustBeNoError(err)
+	aTest.MustBeEqual(value, "1")
+
+	_, err = s.take("a")
+	aTest.MustBeAnError(err)
+
+	// Expired answers are not returned.
+	s.answers["b"].expiresAt = time.Now().Add(-time.Second)
+	_, err = s.take("b")
+	aTest.MustBeAnError(err)
+
+	aTest.MustBeNoError(s.put("c", "3"))
+	s.answers["c"].expiresAt = time.Now().Add(-time.Second)
+	s.clearJunk()
+	aTest.MustBeEqual(len(s.answers), 0)
+}
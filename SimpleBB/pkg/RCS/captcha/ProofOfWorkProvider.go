@@ -0,0 +1,133 @@
+package captcha
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"math/bits"
+	"strconv"
+	"time"
+
+	ck "github.com/vault-thirteen/SimpleBB/pkg/common/models/CaptchaKind"
+)
+
+// Proof of work is solved by the client's software, a user does not need to
+// see or hear anything. The client must find a nonce such that the SHA-256
+// hash of the challenge followed by the nonce starts with the required number
+// of zero bits. Finding the nonce takes about 2^difficulty hashes, checking it
+// takes a single hash, so that mass requests become expensive.
+const (
+	ChallengeSize = 16
+
+	DifficultyMin = 1
+	DifficultyMax = 32
+
+	// NonceLengthMax limits the size of hashed data.
+	NonceLengthMax = 64
+)
+
+type ProofOfWorkProvider struct {
+	difficulty uint
+	answers    *answerStore
+}
+
+func NewProofOfWorkProvider(difficulty uint, ttl time.Duration) (p *ProofOfWorkProvider, err error) {
+	if (difficulty < DifficultyMin) || (difficulty > DifficultyMax) {
+		return nil, errors.New(ErrDifficultyIsNotValid)
+	}
+
+	if ttl <= 0 {
+		return nil, errors.New(ErrTtlIsNotSet)
+	}
+
+	p = &ProofOfWorkProvider{
+		difficulty: difficulty,
+		answers:    newAnswerStore(ttl),
+	}
+
+	return p, nil
+}
+
+func (p *ProofOfWorkProvider) GetKind() ck.CaptchaKind {
+	return ck.CaptchaKind_ProofOfWork
+}
+
+func (p *ProofOfWorkProvider) CreateTask() (task *Task, err error) {
+	buf := make([]byte, ChallengeSize)
+
+	_, err = rand.Read(buf)
+	if err != nil {
+		return nil, err
+	}
+
+	task = &Task{
+		Kind:       ck.CaptchaKind_ProofOfWork,
+		Challenge:  hex.EncodeToString(buf),
+		Difficulty: p.difficulty,
+	}
+
+	task.Id, err = NewTaskId(ck.CaptchaKind_ProofOfWork)
+	if err != nil {
+		return nil, err
+	}
+
+	err = p.answers.put(task.Id, task.Challenge)
+	if err != nil {
+		return nil, err
+	}
+
+	return task, nil
+}
+
+func (p *ProofOfWorkProvider) CheckTask(taskId string, answer string) (ok bool, err error) {
+	var challenge string
+	challenge, err = p.answers.take(taskId)
+	if err != nil {
+		return false, err
+	}
+
+	return IsProofOfWorkValid(challenge, answer, p.difficulty), nil
+}
+
+func (p *ProofOfWorkProvider) ClearJunk() (err error) {
+	p.answers.clearJunk()
+	return nil
+}
+
+func (p *ProofOfWorkProvider) Stop() (err error) {
+	return nil
+}
+
+// IsProofOfWorkValid checks the nonce found by a client.
+func IsProofOfWorkValid(challenge string, nonce string, difficulty uint) bool {
+	if (len(nonce) == 0) || (len(nonce) > NonceLengthMax) {
+		return false
+	}
+
+	sum := sha256.Sum256([]byte(challenge + nonce))
+
+	return leadingZeroBits(sum[:]) >= difficulty
+}
+
+// SolveProofOfWork finds a nonce for the challenge. Clients do the same.
+func SolveProofOfWork(challenge string, difficulty uint) (nonce string) {
+	for i := uint64(0); ; i++ {
+		nonce = strconv.FormatUint(i, 10)
+		if IsProofOfWorkValid(challenge, nonce, difficulty) {
+			return nonce
+		}
+	}
+}
+
+func leadingZeroBits(data []byte) (n uint) {
+	for _, b := range data {
+		if b != 0 {
+			return n + uint(bits.LeadingZeros8(b))
+		}
+
+		n += 8
+	}
+
+	return n
+}
@@ -0,0 +1,58 @@
+package captcha
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/vault-thirteen/auxie/tester"
+)
+
+func Test_leadingZeroBits(t *testing.T) {
+	aTest := tester.New(t)
+
+	aTest.MustBeEqual(leadingZeroBits([]byte{0x80}), uint(0))
+	aTest.MustBeEqual(leadingZeroBits([]byte{0x01}), uint(7))
+	aTest.MustBeEqual(leadingZeroBits([]byte{0x00, 0x10}), uint(11))
+	aTest.MustBeEqual(leadingZeroBits([]byte{0x00, 0x00}), uint(16))
+}
+
+func Test_IsProofOfWorkValid(t *testing.T) {
+	aTest := tester.New(t)
+
+	// SHA-256 of "abc15" starts with 0x35, i.e. with two zero bits.
+	aTest.MustBeEqual(IsProofOfWorkValid("abc", "15", 2), true)
+	aTest.MustBeEqual(IsProofOfWorkValid("abc", "15", 3), false)
+	aTest.MustBeEqual(IsProofOfWorkValid("abc", "1", 1), false)
+
+	aTest.MustBeEqual(IsProofOfWorkValid("abc", "", 0), false)
+	aTest.MustBeEqual(IsProofOfWorkValid("abc", strings.Repeat("0", NonceLengthMax+1), 0), false)
+
+	nonce := SolveProofOfWork("abc", 12)
+	aTest.MustBeEqual(IsProofOfWorkValid("abc", nonce, 12), true)
+}
+
+func Test_ProofOfWorkProvider(t *testing.T) {
+	aTest := tester.New(t)
+
+	_, err := NewProofOfWorkProvider(0, time.Minute)
+	aTest.MustBeAnError(err)
+
+	_, err = NewProofOfWorkProvider(DifficultyMax+1, time.Minute)
+	aTest.MustBeAnError(err)
+
+	var p *ProofOfWorkProvider
+	p, err = NewProofOfWorkProvider(10, time.Minute)
+	aTest.MustBeNoError(err)
+
+	var task *Task
+	task, err = p.CreateTask()
+	aTest.MustBeNoError(err)
+	aTest.MustBeEqual(len(task.Challenge), ChallengeSize*2)
+	aTest.MustBeEqual(task.Difficulty, uint(10))
+
+	var ok bool
+	ok, err = p.CheckTask(task.Id, SolveProofOfWork(task.Challenge, task.Difficulty))
+	aTest.MustBeNoError(err)
+	aTest.MustBeEqual(ok, true)
+}
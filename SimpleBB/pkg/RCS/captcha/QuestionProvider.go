@@ -0,0 +1,118 @@
+package captcha
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	ck "github.com/vault-thirteen/SimpleBB/pkg/common/models/CaptchaKind"
+)
+
+// Question is a question of the text captcha with accepted answers. Answers
+// are compared ignoring letter case and extra spaces.
+type Question struct {
+	Text    string   `json:"question"`
+	Answers []string `json:"answers"`
+}
+
+type QuestionProvider struct {
+	questions []Question
+	answers   *answerStore
+}
+
+func NewQuestionProvider(questions []Question, ttl time.Duration) (p *QuestionProvider, err error) {
+	if len(questions) == 0 {
+		return nil, errors.New(ErrQuestionsAreNotSet)
+	}
+
+	for i, q := range questions {
+		if (len(strings.TrimSpace(q.Text)) == 0) || (len(q.Answers) == 0) {
+			return nil, fmt.Errorf(ErrFQuestionIsNotValid, i+1)
+		}
+
+		for _, a := range q.Answers {
+			if len(normaliseAnswer(a)) == 0 {
+				return nil, fmt.Errorf(ErrFQuestionIsNotValid, i+1)
+			}
+		}
+	}
+
+	if ttl <= 0 {
+		return nil, errors.New(ErrTtlIsNotSet)
+	}
+
+	p = &QuestionProvider{
+		questions: questions,
+		answers:   newAnswerStore(ttl),
+	}
+
+	return p, nil
+}
+
+func (p *QuestionProvider) GetKind() ck.CaptchaKind {
+	return ck.CaptchaKind_Question
+}
+
+func (p *QuestionProvider) CreateTask() (task *Task, err error) {
+	var idx int
+	idx, err = randomInt(len(p.questions))
+	if err != nil {
+		return nil, err
+	}
+
+	task = &Task{
+		Kind:     ck.CaptchaKind_Question,
+		Question: p.questions[idx].Text,
+	}
+
+	task.Id, err = NewTaskId(ck.CaptchaKind_Question)
+	if err != nil {
+		return nil, err
+	}
+
+	// The index of the question is stored instead of the answers.
+	err = p.answers.put(task.Id, strconv.Itoa(idx))
+	if err != nil {
+		return nil, err
+	}
+
+	return task, nil
+}
+
+func (p *QuestionProvider) CheckTask(taskId string, answer string) (ok bool, err error) {
+	var s string
+	s, err = p.answers.take(taskId)
+	if err != nil {
+		return false, err
+	}
+
+	var idx int
+	idx, err = strconv.Atoi(s)
+	if err != nil {
+		return false, err
+	}
+
+	answer = normaliseAnswer(answer)
+	for _, a := range p.questions[idx].Answers {
+		if normaliseAnswer(a) == answer {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func (p *QuestionProvider) ClearJunk() (err error) {
+	p.answers.clearJunk()
+	return nil
+}
+
+func (p *QuestionProvider) Stop() (err error) {
+	return nil
+}
+
+func normaliseAnswer(s string) string {
+	return strings.ToLower(strings.Join(strings.Fields(s), " "))
+}
@@ -0,0 +1,42 @@
+package captcha
+
+import (
+	"testing"
+	"time"
+
+	"github.com/vault-thirteen/auxie/tester"
+)
+
+func Test_QuestionProvider(t *testing.T) {
+	aTest := tester.New(t)
+
+	_, err := NewQuestionProvider(nil, time.Minute)
+	aTest.MustBeAnError(err)
+
+	_, err = NewQuestionProvider([]Question{{Text: "Question?"}}, time.Minute)
+	aTest.MustBeAnError(err)
+
+	_, err = NewQuestionProvider([]Question{{Text: "Question?", Answers: []string{" "}}}, time.Minute)
+	aTest.MustBeAnError(err)
+
+	var p *QuestionProvider
+	p, err = NewQuestionProvider([]Question{{Text: "Which city is the capital of France?", Answers: []string{"Paris"}}}, time.Minute)
+	aTest.MustBeNoError(err)
+
+	var task *Task
+	task, err = p.CreateTask()
+	aTest.MustBeNoError(err)
+	aTest.MustBeEqual(task.Question, "Which city is the capital of France?")
+
+	var ok bool
+	ok, err = p.CheckTask(task.Id, "  pARIS ")
+	aTest.MustBeNoError(err)
+	aTest.MustBeEqual(ok, true)
+
+	task, err = p.CreateTask()
+	aTest.MustBeNoError(err)
+
+	ok, err = p.CheckTask(task.Id, "London")
+	aTest.MustBeNoError(err)
+	aTest.MustBeEqual(ok, false)
+}
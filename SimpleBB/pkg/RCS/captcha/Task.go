@@ -0,0 +1,94 @@
+package captcha
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"math/big"
+	"strings"
+
+	ck "github.com/vault-thirteen/SimpleBB/pkg/common/models/CaptchaKind"
+)
+
+const (
+	// TaskIdRandomPartSize is the number of random bytes in the ID of a task
+	// created by providers of this package.
+	TaskIdRandomPartSize = 16
+
+	// IDs of tasks created by providers of this package start with a prefix
+	// showing the kind of the task. Tasks of the image captcha are created by
+	// an external library, their IDs have no prefix.
+	TaskIdPrefix_Question    = "question"
+	TaskIdPrefix_ProofOfWork = "pow"
+	TaskIdPrefixSeparator    = "_"
+)
+
+// Task is a captcha task created by a provider. Only the fields of the task's
+// kind are set.
+type Task struct {
+	Id   string
+	Kind ck.CaptchaKind
+
+	// Image.
+	ImageFormat         string
+	IsImageDataReturned bool
+	ImageData           []byte
+
+	// Question.
+	Question string
+
+	// Proof of work.
+	Challenge  string
+	Difficulty uint
+}
+
+// NewTaskId creates a random ID of a task of the specified kind.
+func NewTaskId(kind ck.CaptchaKind) (taskId string, err error) {
+	buf := make([]byte, TaskIdRandomPartSize)
+
+	_, err = rand.Read(buf)
+	if err != nil {
+		return "", err
+	}
+
+	return taskIdPrefix(kind) + TaskIdPrefixSeparator + hex.EncodeToString(buf), nil
+}
+
+// GetTaskKind returns the kind of a task by its ID. IDs without a known prefix
+// belong to the image captcha.
+func GetTaskKind(taskId string) (kind ck.CaptchaKind) {
+	prefix, _, found := strings.Cut(taskId, TaskIdPrefixSeparator)
+	if !found {
+		return ck.CaptchaKind_Image
+	}
+
+	switch prefix {
+	case TaskIdPrefix_Question:
+		return ck.CaptchaKind_Question
+	case TaskIdPrefix_ProofOfWork:
+		return ck.CaptchaKind_ProofOfWork
+	default:
+		return ck.CaptchaKind_Image
+	}
+}
+
+func taskIdPrefix(kind ck.CaptchaKind) string {
+	switch kind {
+	case ck.CaptchaKind_Question:
+		return TaskIdPrefix_Question
+	case ck.CaptchaKind_ProofOfWork:
+		return TaskIdPrefix_ProofOfWork
+	default:
+		return ""
+	}
+}
+
+// randomInt returns a uniformly distributed random number in [0, n).
+func randomInt(n int) (x int, err error) {
+	var bi *big.Int
+	bi, err = rand.Int(rand.Reader, big.NewInt(int64(n)))
+	if err != nil {
+		return 0, err
+	}
+
+	return int(bi.Int64()), nil
+}
@@ -1,6 +1,7 @@
 package rpc
 
 import (
+	ck "github.com/vault-thirteen/SimpleBB/pkg/common/models/CaptchaKind"
 	rpc2 "github.com/vault-thirteen/SimpleBB/pkg/common/models/rpc"
 )
 
@@ -11,17 +12,34 @@ type PingResult = rpc2.PingResult
 
 // Captcha.
 
-type CreateCaptchaParams struct{}
+type CreateCaptchaParams struct {
+	// Kind of captcha. When it is not set, an image is created.
+	Kind ck.CaptchaKind `json:"kind"`
+}
 type CreateCaptchaResult struct {
 	rpc2.CommonResult
-	TaskId              string `json:"taskId"`
-	ImageFormat         string `json:"imageFormat"`
+	TaskId string         `json:"taskId"`
+	Kind   ck.CaptchaKind `json:"kind"`
+
+	// Image captcha.
+	ImageFormat         string `json:"imageFormat,omitempty"`
 	IsImageDataReturned bool   `json:"isImageDataReturned"`
 	ImageDataB64        string `json:"imageDataB64,omitempty"`
+
+	// Text question.
+	Question string `json:"question,omitempty"`
+
+	// Proof of work.
+	Challenge  string `json:"challenge,omitempty"`
+	Difficulty uint   `json:"difficulty,omitempty"`
 }
 
 type CheckCaptchaParams struct {
 	TaskId string `json:"taskId"`
+
+	// Answer is used by captcha of all kinds. Value is the numeric answer of
+	// an image captcha, it is used when the answer is not set.
+	Answer string `json:"answer"`
 	Value  uint   `json:"value"`
 }
 type CheckCaptchaResult struct {
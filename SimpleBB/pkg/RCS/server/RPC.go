@@ -65,7 +65,7 @@ func (srv *Server) CreateCaptcha(params *json.RawMessage, _ *jrm1.ResponseMetaDa
 	}
 
 	var r *rm.CreateCaptchaResult
-	r, re = srv.createCaptcha()
+	r, re = srv.createCaptcha(p)
 	if re != nil {
 		return nil, re
 	}
@@ -3,8 +3,9 @@ package server
 import (
 	"encoding/base64"
 	"fmt"
+	"strconv"
+
 	jrm1 "github.com/vault-thirteen/JSON-RPC-M1"
-	rc "github.com/vault-thirteen/RingCaptcha/server"
 	rm "github.com/vault-thirteen/SimpleBB/pkg/RCS/rpc"
 	cmb "github.com/vault-thirteen/SimpleBB/pkg/common/models/base"
 	cmr "github.com/vault-thirteen/SimpleBB/pkg/common/models/rpc"
@@ -12,21 +13,30 @@ import (
 
 // RPC functions.
 
-func (srv *Server) createCaptcha() (result *rm.CreateCaptchaResult, re *jrm1.RpcError) {
-	ccResponse, err := srv.captchaManager.CreateCaptcha()
+func (srv *Server) createCaptcha(p *rm.CreateCaptchaParams) (result *rm.CreateCaptchaResult, re *jrm1.RpcError) {
+	// Check parameters.
+	if !srv.captchaManager.IsKindSupported(p.Kind) {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_KindIsNotSupported, RpcErrorMsg_KindIsNotSupported, nil)
+	}
+
+	task, err := srv.captchaManager.CreateTask(p.Kind)
 	if err != nil {
 		srv.logError(err)
 		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_CreateError, fmt.Sprintf(RpcErrorMsgF_CreateError, err.Error()), nil)
 	}
 
 	result = &rm.CreateCaptchaResult{
-		TaskId:              ccResponse.TaskId,
-		ImageFormat:         ccResponse.ImageFormat,
-		IsImageDataReturned: ccResponse.IsImageDataReturned,
+		TaskId:              task.Id,
+		Kind:                task.Kind,
+		ImageFormat:         task.ImageFormat,
+		IsImageDataReturned: task.IsImageDataReturned,
+		Question:            task.Question,
+		Challenge:           task.Challenge,
+		Difficulty:          task.Difficulty,
 	}
 
-	if ccResponse.IsImageDataReturned {
-		result.ImageDataB64 = base64.StdEncoding.EncodeToString(ccResponse.ImageData)
+	if task.IsImageDataReturned {
+		result.ImageDataB64 = base64.StdEncoding.EncodeToString(task.ImageData)
 	}
 
 	return result, nil
@@ -37,11 +47,16 @@ func (srv *Server) checkCaptcha(p *rm.CheckCaptchaParams) (result *rm.CheckCaptc
 	if len(p.TaskId) == 0 {
 		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_TaskIdIsNotSet, RpcErrorMsg_TaskIdIsNotSet, nil)
 	}
-	if p.Value == 0 {
+
+	answer := p.Answer
+	if (len(answer) == 0) && (p.Value != 0) {
+		answer = strconv.FormatUint(uint64(p.Value), 10)
+	}
+	if len(answer) == 0 {
 		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_AnswerIsNotSet, RpcErrorMsg_AnswerIsNotSet, nil)
 	}
 
-	resp, err := srv.captchaManager.CheckCaptcha(&rc.CheckCaptchaRequest{TaskId: p.TaskId, Value: p.Value})
+	isSuccess, err := srv.captchaManager.CheckTask(p.TaskId, answer)
 	if err != nil {
 		srv.logError(err)
 		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_CheckError, fmt.Sprintf(RpcErrorMsgF_CheckError, err.Error()), nil)
@@ -49,7 +64,7 @@ func (srv *Server) checkCaptcha(p *rm.CheckCaptchaParams) (result *rm.CheckCaptc
 
 	result = &rm.CheckCaptchaResult{
 		TaskId:    p.TaskId,
-		IsSuccess: resp.IsSuccess,
+		IsSuccess: isSuccess,
 	}
 
 	return result, nil
@@ -16,6 +16,7 @@ import (
 
 	jrm1 "github.com/vault-thirteen/JSON-RPC-M1"
 	rc "github.com/vault-thirteen/RingCaptcha/server"
+	"github.com/vault-thirteen/SimpleBB/pkg/RCS/captcha"
 	rs "github.com/vault-thirteen/SimpleBB/pkg/RCS/settings"
 )
 
@@ -37,8 +38,9 @@ type Server struct {
 	httpErrors  chan error
 	ssp         *avm.SSP
 
-	// Captcha manager.
-	captchaManager *rc.CaptchaManager
+	// Captcha manager. Image provider is kept apart for its HTTP server.
+	imageProvider  *captcha.ImageProvider
+	captchaManager *captcha.Manager
 
 	// JSON-RPC server.
 	js *jrm1.Processor
@@ -88,7 +90,7 @@ func (srv *Server) GetListenDsn() (dsn string) {
 }
 
 func (srv *Server) GetCaptchaManagerListenDsn() (dsn string) {
-	return srv.captchaManager.GetListenDsn()
+	return srv.imageProvider.GetListenDsn()
 }
 
 func (srv *Server) GetStopChannel() *chan bool {
@@ -210,27 +212,59 @@ func (srv *Server) httpRouter(rw http.ResponseWriter, req *http.Request) {
 }
 
 func (srv *Server) initCaptchaManager() (err error) {
-	srv.captchaManager, err = rc.NewCaptchaManager(
-		srv.settings.CaptchaSettings.StoreImages,
-		srv.settings.CaptchaSettings.ImagesFolder,
-		srv.settings.CaptchaSettings.ImageWidth,
-		srv.settings.CaptchaSettings.ImageHeight,
-		srv.settings.CaptchaSettings.ImageTtlSec,
-		srv.settings.CaptchaSettings.ClearImagesFolderAtStart,
-		srv.settings.CaptchaSettings.UseHttpServerForImages,
-		srv.settings.CaptchaSettings.HttpServerHost,
-		srv.settings.CaptchaSettings.HttpServerPort,
+	cs := srv.settings.CaptchaSettings
+
+	var rcm *rc.CaptchaManager
+	rcm, err = rc.NewCaptchaManager(
+		cs.StoreImages,
+		cs.ImagesFolder,
+		cs.ImageWidth,
+		cs.ImageHeight,
+		cs.ImageTtlSec,
+		cs.ClearImagesFolderAtStart,
+		cs.UseHttpServerForImages,
+		cs.HttpServerHost,
+		cs.HttpServerPort,
 		&srv.httpErrors,
-		srv.settings.CaptchaSettings.HttpServerName,
-		srv.settings.CaptchaSettings.IsCachingEnabled,
-		srv.settings.CaptchaSettings.CacheSizeLimit,
-		srv.settings.CaptchaSettings.CacheVolumeLimit,
-		srv.settings.CaptchaSettings.CacheRecordTtl,
+		cs.HttpServerName,
+		cs.IsCachingEnabled,
+		cs.CacheSizeLimit,
+		cs.CacheVolumeLimit,
+		cs.CacheRecordTtl,
 	)
 	if err != nil {
 		return err
 	}
 
+	srv.imageProvider = captcha.NewImageProvider(rcm)
+	providers := []captcha.IProvider{srv.imageProvider}
+
+	// Tasks of other kinds live as long as images.
+	ttl := time.Duration(cs.ImageTtlSec) * time.Second
+
+	if cs.IsQuestionEnabled {
+		var qp *captcha.QuestionProvider
+		qp, err = captcha.NewQuestionProvider(cs.Questions, ttl)
+		if err != nil {
+			return err
+		}
+		providers = append(providers, qp)
+	}
+
+	if cs.IsProofOfWorkEnabled {
+		var pp *captcha.ProofOfWorkProvider
+		pp, err = captcha.NewProofOfWorkProvider(cs.ProofOfWorkDifficulty, ttl)
+		if err != nil {
+			return err
+		}
+		providers = append(providers, pp)
+	}
+
+	srv.captchaManager, err = captcha.NewManager(providers...)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -2,6 +2,7 @@ package settings
 
 import (
 	"errors"
+	"github.com/vault-thirteen/SimpleBB/pkg/RCS/captcha"
 	c "github.com/vault-thirteen/SimpleBB/pkg/common/models/server"
 )
 
@@ -42,8 +43,30 @@ type CaptchaSettings struct {
 	CacheSizeLimit   int  `json:"cacheSizeLimit"`
 	CacheVolumeLimit int  `json:"cacheVolumeLimit"`
 	CacheRecordTtl   uint `json:"cacheRecordTtl"`
+
+	// Accessible kinds of captcha. An image captcha is always available,
+	// other kinds are enabled separately. They are weaker than the image and
+	// are meant only as a fallback for users who can not see it, so all of
+	// them are disabled by default. Tasks of all kinds live as long as
+	// images do.
+
+	// Text question with a list of accepted answers. The list of questions
+	// must be private: questions published anywhere, including a public
+	// repository, are answered by a lookup table.
+	IsQuestionEnabled bool               `json:"isQuestionEnabled"`
+	Questions         []captcha.Question `json:"questions"`
+
+	// Proof of work solved by the client's software. Difficulty is the
+	// number of leading zero bits of a hash, solving takes about
+	// 2^difficulty hashes.
+	IsProofOfWorkEnabled  bool `json:"isProofOfWorkEnabled"`
+	ProofOfWorkDifficulty uint `json:"proofOfWorkDifficulty"`
 }
 
+// ProofOfWorkDifficultyMin is the lowest difficulty of proof of work which
+// takes a noticeable time to solve.
+const ProofOfWorkDifficultyMin = 20
+
 func (s CaptchaSettings) Check() (err error) {
 	if s.StoreImages == true {
 		if len(s.ImagesFolder) == 0 {
@@ -72,5 +95,18 @@ func (s CaptchaSettings) Check() (err error) {
 		}
 	}
 
+	if s.IsQuestionEnabled {
+		if len(s.Questions) == 0 {
+			return errors.New(c.MsgCaptchaServiceSettingError)
+		}
+	}
+
+	if s.IsProofOfWorkEnabled {
+		if (s.ProofOfWorkDifficulty < ProofOfWorkDifficultyMin) ||
+			(s.ProofOfWorkDifficulty > captcha.DifficultyMax) {
+			return errors.New(c.MsgCaptchaServiceSettingError)
+		}
+	}
+
 	return nil
 }
@@ -25,6 +25,16 @@ const (
 	FuncDeleteSubscriptionS        = "DeleteSubscriptionS"
 	FuncClearThreadSubscriptionsS  = "ClearThreadSubscriptionsS"
 
+	// Forum and section subscriptions.
+	FuncAddSelfForumSubscription            = "AddSelfForumSubscription"
+	FuncDeleteSelfForumSubscription         = "DeleteSelfForumSubscription"
+	FuncAddSelfSectionSubscription          = "AddSelfSectionSubscription"
+	FuncDeleteSelfSectionSubscription       = "DeleteSelfSectionSubscription"
+	FuncGetSelfForumAndSectionSubscriptions = "GetSelfForumAndSectionSubscriptions"
+	FuncSetSelfDeliveryMode                 = "SetSelfDeliveryMode"
+	FuncGetSelfDeliveryMode                 = "GetSelfDeliveryMode"
+	FuncProcessNewThreadS                   = "ProcessNewThreadS"
+
 	// Other.
 	FuncGetDKey            = "GetDKey"
 	FuncShowDiagnosticData = cc.FuncShowDiagnosticData
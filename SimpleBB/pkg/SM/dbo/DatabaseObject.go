@@ -39,8 +39,12 @@ func (dbo *DatabaseObject) Init() (err error) {
 
 func (dbo *DatabaseObject) initTableNames() {
 	dbo.tableNames = &TableNames{
-		ThreadSubscriptions: dbo.prefixTableName(TableThreadSubscriptions),
-		UserSubscriptions:   dbo.prefixTableName(TableUserSubscriptions),
+		ThreadSubscriptions:  dbo.prefixTableName(TableThreadSubscriptions),
+		UserSubscriptions:    dbo.prefixTableName(TableUserSubscriptions),
+		ForumSubscriptions:   dbo.prefixTableName(TableForumSubscriptions),
+		SectionSubscriptions: dbo.prefixTableName(TableSectionSubscriptions),
+		DeliveryPreferences:  dbo.prefixTableName(TableDeliveryPreferences),
+		DigestItems:          dbo.prefixTableName(TableDigestItems),
 	}
 }
 
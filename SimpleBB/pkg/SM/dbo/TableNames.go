@@ -1,11 +1,19 @@
 package dbo
 
 const (
-	TableThreadSubscriptions = "ThreadSubscriptions"
-	TableUserSubscriptions   = "UserSubscriptions"
+	TableThreadSubscriptions  = "ThreadSubscriptions"
+	TableUserSubscriptions    = "UserSubscriptions"
+	TableForumSubscriptions   = "ForumSubscriptions"
+	TableSectionSubscriptions = "SectionSubscriptions"
+	TableDeliveryPreferences  = "DeliveryPreferences"
+	TableDigestItems          = "DigestItems"
 )
 
 type TableNames struct {
-	ThreadSubscriptions string
-	UserSubscriptions   string
+	ThreadSubscriptions  string
+	UserSubscriptions    string
+	ForumSubscriptions   string
+	SectionSubscriptions string
+	DeliveryPreferences  string
+	DigestItems          string
 }
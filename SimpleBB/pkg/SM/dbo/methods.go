@@ -9,6 +9,7 @@ import (
 	dbo2 "github.com/vault-thirteen/SimpleBB/pkg/common/models/dbo"
 	cms "github.com/vault-thirteen/SimpleBB/pkg/common/models/sql"
 	ae "github.com/vault-thirteen/auxie/errors"
+	"time"
 )
 
 func (dbo *DatabaseObject) CountUserSubscriptions(userId base2.Id) (n base2.Count, err error) {
@@ -149,3 +150,209 @@ func (dbo *DatabaseObject) ClearThreadSubscriptionRecord(threadId base2.Id) (err
 
 	return dbo2.CheckRowsAffected(result, 1)
 }
+
+func (dbo *DatabaseObject) CountForumSubscription(forumId base2.Id, userId base2.Id) (n base2.Count, err error) {
+	row := dbo.DatabaseObject.PreparedStatement(DbPsid_CountForumSubscription).QueryRow(forumId, userId)
+
+	n, err = cms.NewNonNullValueFromScannableSource[base2.Count](row)
+	if err != nil {
+		return dbo2.CountOnError, err
+	}
+
+	return n, nil
+}
+
+func (dbo *DatabaseObject) InsertForumSubscription(forumId base2.Id, userId base2.Id) (err error) {
+	var result sql.Result
+	result, err = dbo.DatabaseObject.PreparedStatement(DbPsid_InsertForumSubscription).Exec(forumId, userId)
+	if err != nil {
+		return err
+	}
+
+	return dbo2.CheckRowsAffected(result, 1)
+}
+
+func (dbo *DatabaseObject) DeleteForumSubscription(forumId base2.Id, userId base2.Id) (err error) {
+	var result sql.Result
+	result, err = dbo.DatabaseObject.PreparedStatement(DbPsid_DeleteForumSubscription).Exec(forumId, userId)
+	if err != nil {
+		return err
+	}
+
+	return dbo2.CheckRowsAffected(result, 1)
+}
+
+func (dbo *DatabaseObject) GetForumSubscribers(forumId base2.Id) (userIds []base2.Id, err error) {
+	return dbo.getIds(DbPsid_GetForumSubscribers, forumId)
+}
+
+func (dbo *DatabaseObject) GetUserForumSubscriptions(userId base2.Id) (forumIds []base2.Id, err error) {
+	return dbo.getIds(DbPsid_GetUserForumSubscriptions, userId)
+}
+
+func (dbo *DatabaseObject) CountSectionSubscription(sectionId base2.Id, userId base2.Id) (n base2.Count, err error) {
+	row := dbo.DatabaseObject.PreparedStatement(DbPsid_CountSectionSubscription).QueryRow(sectionId, userId)
+
+	n, err = cms.NewNonNullValueFromScannableSource[base2.Count](row)
+	if err != nil {
+		return dbo2.CountOnError, err
+	}
+
+	return n, nil
+}
+
+func (dbo *DatabaseObject) InsertSectionSubscription(sectionId base2.Id, userId base2.Id) (err error) {
+	var result sql.Result
+	result, err = dbo.DatabaseObject.PreparedStatement(DbPsid_InsertSectionSubscription).Exec(sectionId, userId)
+	if err != nil {
+		return err
+	}
+
+	return dbo2.CheckRowsAffected(result, 1)
+}
+
+func (dbo *DatabaseObject) DeleteSectionSubscription(sectionId base2.Id, userId base2.Id) (err error) {
+	var result sql.Result
+	result, err = dbo.DatabaseObject.PreparedStatement(DbPsid_DeleteSectionSubscription).Exec(sectionId, userId)
+	if err != nil {
+		return err
+	}
+
+	return dbo2.CheckRowsAffected(result, 1)
+}
+
+func (dbo *DatabaseObject) GetSectionSubscribers(sectionId base2.Id) (userIds []base2.Id, err error) {
+	return dbo.getIds(DbPsid_GetSectionSubscribers, sectionId)
+}
+
+func (dbo *DatabaseObject) GetUserSectionSubscriptions(userId base2.Id) (sectionIds []base2.Id, err error) {
+	return dbo.getIds(DbPsid_GetUserSectionSubscriptions, userId)
+}
+
+// GetDeliveryPreference reads a delivery preference of a user. If the user
+// has not chosen a delivery mode, a virtual preference with the default mode
+// is returned.
+func (dbo *DatabaseObject) GetDeliveryPreference(userId base2.Id) (dp *sm.DeliveryPreference, err error) {
+	row := dbo.DatabaseObject.PreparedStatement(DbPsid_GetDeliveryPreference).QueryRow(userId)
+
+	dp, err = sm.NewDeliveryPreferenceFromScannableSource(row)
+	if err != nil {
+		return nil, err
+	}
+
+	if dp == nil {
+		dp = &sm.DeliveryPreference{
+			UserId:       userId,
+			DeliveryMode: sm.DeliveryModeDefault,
+		}
+	}
+
+	return dp, nil
+}
+
+// SetDeliveryMode sets a delivery mode of a user. The period of digests
+// starts anew.
+func (dbo *DatabaseObject) SetDeliveryMode(userId base2.Id, dm sm.DeliveryMode) (err error) {
+	// Updated rows are counted twice by MySQL, so the count is not checked.
+	_, err = dbo.DatabaseObject.PreparedStatement(DbPsid_SetDeliveryMode).Exec(userId, dm)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetDigestDeliveryPreferences reads preferences of all users receiving
+// digests.
+func (dbo *DatabaseObject) GetDigestDeliveryPreferences() (dps []sm.DeliveryPreference, err error) {
+	var rows *sql.Rows
+	rows, err = dbo.DatabaseObject.PreparedStatement(DbPsid_GetDigestDeliveryPreferences).Query(sm.DeliveryMode_Instant)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		derr := rows.Close()
+		if derr != nil {
+			err = ae.Combine(err, derr)
+		}
+	}()
+
+	return sm.NewDeliveryPreferenceArrayFromRows(rows)
+}
+
+func (dbo *DatabaseObject) SetLastDigestTime(userId base2.Id, lastDigestTime time.Time) (err error) {
+	var result sql.Result
+	result, err = dbo.DatabaseObject.PreparedStatement(DbPsid_SetLastDigestTime).Exec(lastDigestTime, userId)
+	if err != nil {
+		return err
+	}
+
+	return dbo2.CheckRowsAffected(result, 1)
+}
+
+func (dbo *DatabaseObject) InsertDigestItem(di *sm.DigestItem) (err error) {
+	var result sql.Result
+	result, err = dbo.DatabaseObject.PreparedStatement(DbPsid_InsertDigestItem).Exec(di.UserId, di.ThreadId, di.ThreadName, di.ForumId, di.ForumName)
+	if err != nil {
+		return err
+	}
+
+	return dbo2.CheckRowsAffected(result, 1)
+}
+
+func (dbo *DatabaseObject) GetDigestItems(userId base2.Id) (dis []sm.DigestItem, err error) {
+	var rows *sql.Rows
+	rows, err = dbo.DatabaseObject.PreparedStatement(DbPsid_GetDigestItems).Query(userId)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		derr := rows.Close()
+		if derr != nil {
+			err = ae.Combine(err, derr)
+		}
+	}()
+
+	return sm.NewDigestItemArrayFromRows(rows)
+}
+
+// DeleteDigestItems deletes items of a user up to the specified item
+// inclusively.
+func (dbo *DatabaseObject) DeleteDigestItems(userId base2.Id, lastItemId base2.Id) (err error) {
+	_, err = dbo.DatabaseObject.PreparedStatement(DbPsid_DeleteDigestItems).Exec(userId, lastItemId)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (dbo *DatabaseObject) DeleteAllDigestItems(userId base2.Id) (err error) {
+	_, err = dbo.DatabaseObject.PreparedStatement(DbPsid_DeleteAllDigestItems).Exec(userId)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// getIds reads a list of identifiers using a prepared statement with a single
+// parameter.
+func (dbo *DatabaseObject) getIds(psid int, id base2.Id) (ids []base2.Id, err error) {
+	var rows *sql.Rows
+	rows, err = dbo.DatabaseObject.PreparedStatement(psid).Query(id)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		derr := rows.Close()
+		if derr != nil {
+			err = ae.Combine(err, derr)
+		}
+	}()
+
+	return cms.NewArrayFromScannableSource[base2.Id](rows)
+}
@@ -18,6 +18,24 @@ const (
 	DbPsid_ClearThreadSubscriptionRecord = 8
 	DbPsid_GetAllThreadSubscriptions     = 9
 	DbPsid_GetAllUserSubscriptions       = 10
+	DbPsid_CountForumSubscription        = 11
+	DbPsid_InsertForumSubscription       = 12
+	DbPsid_DeleteForumSubscription       = 13
+	DbPsid_GetForumSubscribers           = 14
+	DbPsid_GetUserForumSubscriptions     = 15
+	DbPsid_CountSectionSubscription      = 16
+	DbPsid_InsertSectionSubscription     = 17
+	DbPsid_DeleteSectionSubscription     = 18
+	DbPsid_GetSectionSubscribers         = 19
+	DbPsid_GetUserSectionSubscriptions   = 20
+	DbPsid_GetDeliveryPreference         = 21
+	DbPsid_SetDeliveryMode               = 22
+	DbPsid_GetDigestDeliveryPreferences  = 23
+	DbPsid_SetLastDigestTime             = 24
+	DbPsid_InsertDigestItem              = 25
+	DbPsid_GetDigestItems                = 26
+	DbPsid_DeleteDigestItems             = 27
+	DbPsid_DeleteAllDigestItems          = 28
 )
 
 func (dbo *DatabaseObject) makePreparedStatementQueryStrings() (qs []string) {
@@ -68,6 +86,78 @@ func (dbo *DatabaseObject) makePreparedStatementQueryStrings() (qs []string) {
 	q = fmt.Sprintf(`SELECT Id, UserId, Threads FROM %s;`, dbo.tableNames.UserSubscriptions)
 	qs = append(qs, q)
 
+	// 11.
+	q = fmt.Sprintf(`SELECT COUNT(Id) FROM %s WHERE ForumId = ? AND UserId = ?;`, dbo.tableNames.ForumSubscriptions)
+	qs = append(qs, q)
+
+	// 12.
+	q = fmt.Sprintf(`INSERT INTO %s (ForumId, UserId) VALUES (?, ?);`, dbo.tableNames.ForumSubscriptions)
+	qs = append(qs, q)
+
+	// 13.
+	q = fmt.Sprintf(`DELETE FROM %s WHERE ForumId = ? AND UserId = ?;`, dbo.tableNames.ForumSubscriptions)
+	qs = append(qs, q)
+
+	// 14.
+	q = fmt.Sprintf(`SELECT UserId FROM %s WHERE ForumId = ? ORDER BY UserId;`, dbo.tableNames.ForumSubscriptions)
+	qs = append(qs, q)
+
+	// 15.
+	q = fmt.Sprintf(`SELECT ForumId FROM %s WHERE UserId = ? ORDER BY ForumId;`, dbo.tableNames.ForumSubscriptions)
+	qs = append(qs, q)
+
+	// 16.
+	q = fmt.Sprintf(`SELECT COUNT(Id) FROM %s WHERE SectionId = ? AND UserId = ?;`, dbo.tableNames.SectionSubscriptions)
+	qs = append(qs, q)
+
+	// 17.
+	q = fmt.Sprintf(`INSERT INTO %s (SectionId, UserId) VALUES (?, ?);`, dbo.tableNames.SectionSubscriptions)
+	qs = append(qs, q)
+
+	// 18.
+	q = fmt.Sprintf(`DELETE FROM %s WHERE SectionId = ? AND UserId = ?;`, dbo.tableNames.SectionSubscriptions)
+	qs = append(qs, q)
+
+	// 19.
+	q = fmt.Sprintf(`SELECT UserId FROM %s WHERE SectionId = ? ORDER BY UserId;`, dbo.tableNames.SectionSubscriptions)
+	qs = append(qs, q)
+
+	// 20.
+	q = fmt.Sprintf(`SELECT SectionId FROM %s WHERE UserId = ? ORDER BY SectionId;`, dbo.tableNames.SectionSubscriptions)
+	qs = append(qs, q)
+
+	// 21.
+	q = fmt.Sprintf(`SELECT UserId, DeliveryMode, LastDigestTime FROM %s WHERE UserId = ?;`, dbo.tableNames.DeliveryPreferences)
+	qs = append(qs, q)
+
+	// 22.
+	q = fmt.Sprintf(`INSERT INTO %s (UserId, DeliveryMode, LastDigestTime) VALUES (?, ?, NOW()) ON DUPLICATE KEY UPDATE DeliveryMode = VALUES(DeliveryMode), LastDigestTime = NOW();`, dbo.tableNames.DeliveryPreferences)
+	qs = append(qs, q)
+
+	// 23.
+	q = fmt.Sprintf(`SELECT UserId, DeliveryMode, LastDigestTime FROM %s WHERE DeliveryMode <> ? ORDER BY UserId;`, dbo.tableNames.DeliveryPreferences)
+	qs = append(qs, q)
+
+	// 24.
+	q = fmt.Sprintf(`UPDATE %s SET LastDigestTime = ? WHERE UserId = ?;`, dbo.tableNames.DeliveryPreferences)
+	qs = append(qs, q)
+
+	// 25.
+	q = fmt.Sprintf(`INSERT INTO %s (UserId, ThreadId, ThreadName, ForumId, ForumName) VALUES (?, ?, ?, ?, ?);`, dbo.tableNames.DigestItems)
+	qs = append(qs, q)
+
+	// 26.
+	q = fmt.Sprintf(`SELECT Id, UserId, ThreadId, ThreadName, ForumId, ForumName, TimeOfCreation FROM %s WHERE UserId = ? ORDER BY Id;`, dbo.tableNames.DigestItems)
+	qs = append(qs, q)
+
+	// 27.
+	q = fmt.Sprintf(`DELETE FROM %s WHERE UserId = ? AND Id <= ?;`, dbo.tableNames.DigestItems)
+	qs = append(qs, q)
+
+	// 28.
+	q = fmt.Sprintf(`DELETE FROM %s WHERE UserId = ?;`, dbo.tableNames.DigestItems)
+	qs = append(qs, q)
+
 	return qs
 }
 
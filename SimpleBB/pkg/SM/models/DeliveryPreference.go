@@ -0,0 +1,98 @@
+package models
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/vault-thirteen/SimpleBB/pkg/common/interfaces/base1"
+	cmb "github.com/vault-thirteen/SimpleBB/pkg/common/models/base"
+	"time"
+)
+
+// DeliveryMode is a way in which a user gets notices about new threads in
+// subscribed forums and sections.
+type DeliveryMode = cmb.Count
+
+const (
+	// DeliveryMode_Instant sends a notification per each new thread.
+	DeliveryMode_Instant = 1
+
+	// DeliveryMode_DailyDigest sends a digest e-mail once a day.
+	DeliveryMode_DailyDigest = 2
+
+	// DeliveryMode_WeeklyDigest sends a digest e-mail once a week.
+	DeliveryMode_WeeklyDigest = 3
+
+	DeliveryModeMax = DeliveryMode_WeeklyDigest
+
+	// DeliveryModeDefault is used by users who have not chosen a mode.
+	DeliveryModeDefault = DeliveryMode_Instant
+)
+
+func IsDeliveryModeValid(dm DeliveryMode) bool {
+	return (dm >= DeliveryMode_Instant) && (dm <= DeliveryModeMax)
+}
+
+// GetDigestPeriod returns a period of digests. Zero is returned for modes
+// without digests.
+func GetDigestPeriod(dm DeliveryMode) time.Duration {
+	switch dm {
+	case DeliveryMode_DailyDigest:
+		return 24 * time.Hour
+	case DeliveryMode_WeeklyDigest:
+		return 7 * 24 * time.Hour
+	default:
+		return 0
+	}
+}
+
+// DeliveryPreference is a delivery mode chosen by a user.
+type DeliveryPreference struct {
+	UserId         cmb.Id       `json:"userId"`
+	DeliveryMode   DeliveryMode `json:"deliveryMode"`
+	LastDigestTime time.Time    `json:"lastDigestTime"`
+}
+
+func NewDeliveryPreferenceFromScannableSource(src base.IScannable) (dp *DeliveryPreference, err error) {
+	dp = &DeliveryPreference{}
+
+	err = src.Scan(
+		&dp.UserId,
+		&dp.DeliveryMode,
+		&dp.LastDigestTime,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		} else {
+			return nil, err
+		}
+	}
+
+	return dp, nil
+}
+
+func NewDeliveryPreferenceArrayFromRows(rows base.IScannableSequence) (dps []DeliveryPreference, err error) {
+	dps = []DeliveryPreference{}
+	var dp *DeliveryPreference
+
+	for rows.Next() {
+		dp, err = NewDeliveryPreferenceFromScannableSource(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		dps = append(dps, *dp)
+	}
+
+	return dps, nil
+}
+
+// IsDigestDue checks whether it is time to send a digest.
+func (dp *DeliveryPreference) IsDigestDue(now time.Time) bool {
+	period := GetDigestPeriod(dp.DeliveryMode)
+	if period == 0 {
+		return false
+	}
+
+	return !now.Before(dp.LastDigestTime.Add(period))
+}
@@ -0,0 +1,74 @@
+package models
+
+import (
+	"fmt"
+	cmb "github.com/vault-thirteen/SimpleBB/pkg/common/models/base"
+	"strings"
+)
+
+const (
+	DigestSubjectF_Daily  = "%s: new threads of the day"
+	DigestSubjectF_Weekly = "%s: new threads of the week"
+	DigestIntro           = "New threads in forums and sections which you follow:"
+	DigestForumF          = "Forum \"%s\" (%d):"
+	DigestThreadF         = "  - \"%s\" (%d), %s"
+	DigestTimeFormat      = "2006-01-02 15:04 MST"
+)
+
+// Digest is an e-mail message listing new threads.
+type Digest struct {
+	Subject cmb.Text
+	Text    cmb.Text
+
+	// ID of the last item included into the digest. Items up to this ID are
+	// deleted after the digest is sent.
+	LastItemId cmb.Id
+}
+
+// NewDigest composes a digest of new threads grouped by forums. Forums follow
+// in order of their first new thread, items must be sorted by their IDs.
+// Null is returned when there are no items.
+func NewDigest(siteName cmb.Text, dm DeliveryMode, items []DigestItem) (d *Digest) {
+	if len(items) == 0 {
+		return nil
+	}
+
+	var subjectFormat = DigestSubjectF_Daily
+	if dm == DeliveryMode_WeeklyDigest {
+		subjectFormat = DigestSubjectF_Weekly
+	}
+
+	var forumIds = make([]cmb.Id, 0)
+	var itemsByForum = make(map[cmb.Id][]DigestItem)
+	for _, item := range items {
+		if _, ok := itemsByForum[item.ForumId]; !ok {
+			forumIds = append(forumIds, item.ForumId)
+		}
+		itemsByForum[item.ForumId] = append(itemsByForum[item.ForumId], item)
+	}
+
+	var sb strings.Builder
+	sb.WriteString(DigestIntro)
+	sb.WriteString("\r\n")
+
+	for _, forumId := range forumIds {
+		forumItems := itemsByForum[forumId]
+
+		sb.WriteString("\r\n")
+		sb.WriteString(fmt.Sprintf(DigestForumF, forumItems[0].ForumName, forumId))
+		sb.WriteString("\r\n")
+
+		for _, item := range forumItems {
+			sb.WriteString(fmt.Sprintf(DigestThreadF, item.ThreadName, item.ThreadId, item.TimeOfCreation.UTC().Format(DigestTimeFormat)))
+			sb.WriteString("\r\n")
+		}
+	}
+
+	d = &Digest{
+		Subject:    cmb.Text(fmt.Sprintf(subjectFormat, siteName)),
+		Text:       cmb.Text(sb.String()),
+		LastItemId: items[len(items)-1].Id,
+	}
+
+	return d
+}
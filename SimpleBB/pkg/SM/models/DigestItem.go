@@ -0,0 +1,60 @@
+package models
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/vault-thirteen/SimpleBB/pkg/common/interfaces/base1"
+	cmb "github.com/vault-thirteen/SimpleBB/pkg/common/models/base"
+	"time"
+)
+
+// DigestItem is a new thread waiting to be sent to a user in a digest. Names
+// are stored as they were at the time of creation of the thread.
+type DigestItem struct {
+	Id             cmb.Id    `json:"id"`
+	UserId         cmb.Id    `json:"userId"`
+	ThreadId       cmb.Id    `json:"threadId"`
+	ThreadName     cmb.Text  `json:"threadName"`
+	ForumId        cmb.Id    `json:"forumId"`
+	ForumName      cmb.Text  `json:"forumName"`
+	TimeOfCreation time.Time `json:"timeOfCreation"`
+}
+
+func NewDigestItemFromScannableSource(src base.IScannable) (di *DigestItem, err error) {
+	di = &DigestItem{}
+
+	err = src.Scan(
+		&di.Id,
+		&di.UserId,
+		&di.ThreadId,
+		&di.ThreadName,
+		&di.ForumId,
+		&di.ForumName,
+		&di.TimeOfCreation,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		} else {
+			return nil, err
+		}
+	}
+
+	return di, nil
+}
+
+func NewDigestItemArrayFromRows(rows base.IScannableSequence) (dis []DigestItem, err error) {
+	dis = []DigestItem{}
+	var di *DigestItem
+
+	for rows.Next() {
+		di, err = NewDigestItemFromScannableSource(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		dis = append(dis, *di)
+	}
+
+	return dis, nil
+}
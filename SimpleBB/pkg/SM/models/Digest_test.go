@@ -0,0 +1,55 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	cmb "github.com/vault-thirteen/SimpleBB/pkg/common/models/base"
+	"github.com/vault-thirteen/auxie/tester"
+)
+
+func Test_NewDigest(t *testing.T) {
+	aTest := tester.New(t)
+
+	aTest.MustBeEqual(NewDigest("Site", DeliveryMode_DailyDigest, []DigestItem{}) == nil, true)
+
+	var toc = time.Date(2024, 5, 6, 7, 8, 0, 0, time.UTC)
+	var items = []DigestItem{
+		{Id: 3, ThreadId: 30, ThreadName: "First", ForumId: 2, ForumName: "News", TimeOfCreation: toc},
+		{Id: 5, ThreadId: 31, ThreadName: "Second", ForumId: 1, ForumName: "Chat", TimeOfCreation: toc},
+		{Id: 8, ThreadId: 32, ThreadName: "Third", ForumId: 2, ForumName: "News", TimeOfCreation: toc},
+	}
+
+	d := NewDigest("Site", DeliveryMode_WeeklyDigest, items)
+	aTest.MustBeEqual(d.Subject, cmb.Text("Site: new threads of the week"))
+	aTest.MustBeEqual(d.LastItemId, cmb.Id(8))
+	aTest.MustBeEqual(d.Text, cmb.Text(DigestIntro+"\r\n"+
+		"\r\n"+
+		"Forum \"News\" (2):\r\n"+
+		"  - \"First\" (30), 2024-05-06 07:08 UTC\r\n"+
+		"  - \"Third\" (32), 2024-05-06 07:08 UTC\r\n"+
+		"\r\n"+
+		"Forum \"Chat\" (1):\r\n"+
+		"  - \"Second\" (31), 2024-05-06 07:08 UTC\r\n"))
+
+	d = NewDigest("Site", DeliveryMode_DailyDigest, items[:1])
+	aTest.MustBeEqual(d.Subject, cmb.Text("Site: new threads of the day"))
+	aTest.MustBeEqual(d.LastItemId, cmb.Id(3))
+}
+
+func Test_IsDigestDue(t *testing.T) {
+	aTest := tester.New(t)
+
+	var last = time.Date(2024, 5, 6, 7, 8, 0, 0, time.UTC)
+
+	dp := &DeliveryPreference{DeliveryMode: DeliveryMode_DailyDigest, LastDigestTime: last}
+	aTest.MustBeEqual(dp.IsDigestDue(last.Add(23*time.Hour)), false)
+	aTest.MustBeEqual(dp.IsDigestDue(last.Add(24*time.Hour)), true)
+
+	dp = &DeliveryPreference{DeliveryMode: DeliveryMode_WeeklyDigest, LastDigestTime: last}
+	aTest.MustBeEqual(dp.IsDigestDue(last.Add(6*24*time.Hour)), false)
+	aTest.MustBeEqual(dp.IsDigestDue(last.Add(7*24*time.Hour)), true)
+
+	dp = &DeliveryPreference{DeliveryMode: DeliveryMode_Instant, LastDigestTime: last}
+	aTest.MustBeEqual(dp.IsDigestDue(last.Add(30*24*time.Hour)), false)
+}
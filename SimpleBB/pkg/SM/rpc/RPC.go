@@ -138,6 +138,71 @@ type ClearThreadSubscriptionsSParams struct {
 }
 type ClearThreadSubscriptionsSResult = rpc2.CommonResultWithSuccess
 
+// Forum and section subscriptions.
+
+type AddSelfForumSubscriptionParams struct {
+	rpc2.CommonParams
+	ForumId base2.Id `json:"forumId"`
+}
+type AddSelfForumSubscriptionResult = rpc2.CommonResultWithSuccess
+
+type DeleteSelfForumSubscriptionParams struct {
+	rpc2.CommonParams
+	ForumId base2.Id `json:"forumId"`
+}
+type DeleteSelfForumSubscriptionResult = rpc2.CommonResultWithSuccess
+
+type AddSelfSectionSubscriptionParams struct {
+	rpc2.CommonParams
+	SectionId base2.Id `json:"sectionId"`
+}
+type AddSelfSectionSubscriptionResult = rpc2.CommonResultWithSuccess
+
+type DeleteSelfSectionSubscriptionParams struct {
+	rpc2.CommonParams
+	SectionId base2.Id `json:"sectionId"`
+}
+type DeleteSelfSectionSubscriptionResult = rpc2.CommonResultWithSuccess
+
+type GetSelfForumAndSectionSubscriptionsParams struct {
+	rpc2.CommonParams
+}
+type GetSelfForumAndSectionSubscriptionsResult struct {
+	rpc2.CommonResult
+	UserId     base2.Id   `json:"userId"`
+	ForumIds   []base2.Id `json:"forumIds"`
+	SectionIds []base2.Id `json:"sectionIds"`
+}
+
+type SetSelfDeliveryModeParams struct {
+	rpc2.CommonParams
+	DeliveryMode models.DeliveryMode `json:"deliveryMode"`
+}
+type SetSelfDeliveryModeResult = rpc2.CommonResultWithSuccess
+
+type GetSelfDeliveryModeParams struct {
+	rpc2.CommonParams
+}
+type GetSelfDeliveryModeResult struct {
+	rpc2.CommonResult
+	UserId       base2.Id            `json:"userId"`
+	DeliveryMode models.DeliveryMode `json:"deliveryMode"`
+}
+
+type ProcessNewThreadSParams struct {
+	rpc2.CommonParams
+	rpc2.DKeyParams
+	ThreadId base2.Id `json:"threadId"`
+	AuthorId base2.Id `json:"authorId"`
+}
+type ProcessNewThreadSResult struct {
+	rpc2.CommonResult
+
+	// Subscribers who must be notified about the new thread instantly. Other
+	// subscribers get the thread in their digests.
+	InstantSubscribers []base2.Id `json:"instantSubscribers"`
+}
+
 // Other.
 
 type GetDKeyParams struct {
@@ -152,6 +217,7 @@ type ShowDiagnosticDataParams struct{}
 type ShowDiagnosticDataResult struct {
 	rpc2.CommonResult
 	rpc2.RequestsCount
+	rpc2.ScheduledTasks
 }
 
 type TestParams struct {
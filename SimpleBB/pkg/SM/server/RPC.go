@@ -43,6 +43,14 @@ func (srv *Server) initRpc() (err error) {
 		srv.DeleteSubscription,
 		srv.DeleteSubscriptionS,
 		srv.ClearThreadSubscriptionsS,
+		srv.AddSelfForumSubscription,
+		srv.DeleteSelfForumSubscription,
+		srv.AddSelfSectionSubscription,
+		srv.DeleteSelfSectionSubscription,
+		srv.GetSelfForumAndSectionSubscriptions,
+		srv.SetSelfDeliveryMode,
+		srv.GetSelfDeliveryMode,
+		srv.ProcessNewThreadS,
 		srv.GetDKey,
 		srv.ShowDiagnosticData,
 		srv.Test,
@@ -295,6 +303,136 @@ func (srv *Server) ClearThreadSubscriptionsS(params *json.RawMessage, _ *jrm1.Re
 	return r, nil
 }
 
+// Forum and section subscriptions.
+
+func (srv *Server) AddSelfForumSubscription(params *json.RawMessage, _ *jrm1.ResponseMetaData) (result any, re *jrm1.RpcError) {
+	var p *sm.AddSelfForumSubscriptionParams
+	re = jrm1.ParseParameters(params, &p)
+	if re != nil {
+		return nil, re
+	}
+
+	var r *sm.AddSelfForumSubscriptionResult
+	r, re = srv.addSelfForumSubscription(p)
+	if re != nil {
+		return nil, re
+	}
+
+	return r, nil
+}
+
+func (srv *Server) DeleteSelfForumSubscription(params *json.RawMessage, _ *jrm1.ResponseMetaData) (result any, re *jrm1.RpcError) {
+	var p *sm.DeleteSelfForumSubscriptionParams
+	re = jrm1.ParseParameters(params, &p)
+	if re != nil {
+		return nil, re
+	}
+
+	var r *sm.DeleteSelfForumSubscriptionResult
+	r, re = srv.deleteSelfForumSubscription(p)
+	if re != nil {
+		return nil, re
+	}
+
+	return r, nil
+}
+
+func (srv *Server) AddSelfSectionSubscription(params *json.RawMessage, _ *jrm1.ResponseMetaData) (result any, re *jrm1.RpcError) {
+	var p *sm.AddSelfSectionSubscriptionParams
+	re = jrm1.ParseParameters(params, &p)
+	if re != nil {
+		return nil, re
+	}
+
+	var r *sm.AddSelfSectionSubscriptionResult
+	r, re = srv.addSelfSectionSubscription(p)
+	if re != nil {
+		return nil, re
+	}
+
+	return r, nil
+}
+
+func (srv *Server) DeleteSelfSectionSubscription(params *json.RawMessage, _ *jrm1.ResponseMetaData) (result any, re *jrm1.RpcError) {
+	var p *sm.DeleteSelfSectionSubscriptionParams
+	re = jrm1.ParseParameters(params, &p)
+	if re != nil {
+		return nil, re
+	}
+
+	var r *sm.DeleteSelfSectionSubscriptionResult
+	r, re = srv.deleteSelfSectionSubscription(p)
+	if re != nil {
+		return nil, re
+	}
+
+	return r, nil
+}
+
+func (srv *Server) GetSelfForumAndSectionSubscriptions(params *json.RawMessage, _ *jrm1.ResponseMetaData) (result any, re *jrm1.RpcError) {
+	var p *sm.GetSelfForumAndSectionSubscriptionsParams
+	re = jrm1.ParseParameters(params, &p)
+	if re != nil {
+		return nil, re
+	}
+
+	var r *sm.GetSelfForumAndSectionSubscriptionsResult
+	r, re = srv.getSelfForumAndSectionSubscriptions(p)
+	if re != nil {
+		return nil, re
+	}
+
+	return r, nil
+}
+
+func (srv *Server) SetSelfDeliveryMode(params *json.RawMessage, _ *jrm1.ResponseMetaData) (result any, re *jrm1.RpcError) {
+	var p *sm.SetSelfDeliveryModeParams
+	re = jrm1.ParseParameters(params, &p)
+	if re != nil {
+		return nil, re
+	}
+
+	var r *sm.SetSelfDeliveryModeResult
+	r, re = srv.setSelfDeliveryMode(p)
+	if re != nil {
+		return nil, re
+	}
+
+	return r, nil
+}
+
+func (srv *Server) GetSelfDeliveryMode(params *json.RawMessage, _ *jrm1.ResponseMetaData) (result any, re *jrm1.RpcError) {
+	var p *sm.GetSelfDeliveryModeParams
+	re = jrm1.ParseParameters(params, &p)
+	if re != nil {
+		return nil, re
+	}
+
+	var r *sm.GetSelfDeliveryModeResult
+	r, re = srv.getSelfDeliveryMode(p)
+	if re != nil {
+		return nil, re
+	}
+
+	return r, nil
+}
+
+func (srv *Server) ProcessNewThreadS(params *json.RawMessage, _ *jrm1.ResponseMetaData) (result any, re *jrm1.RpcError) {
+	var p *sm.ProcessNewThreadSParams
+	re = jrm1.ParseParameters(params, &p)
+	if re != nil {
+		return nil, re
+	}
+
+	var r *sm.ProcessNewThreadSResult
+	r, re = srv.processNewThreadS(p)
+	if re != nil {
+		return nil, re
+	}
+
+	return r, nil
+}
+
 // Other.
 
 func (srv *Server) GetDKey(params *json.RawMessage, _ *jrm1.ResponseMetaData) (result any, re *jrm1.RpcError) {
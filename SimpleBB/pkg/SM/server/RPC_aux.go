@@ -11,12 +11,16 @@ import (
 	rpc2 "github.com/vault-thirteen/SimpleBB/pkg/common/models/rpc"
 	server2 "github.com/vault-thirteen/SimpleBB/pkg/common/models/server"
 	"log"
+	"slices"
 	"sync"
 
 	jrm1 "github.com/vault-thirteen/JSON-RPC-M1"
 	ac "github.com/vault-thirteen/SimpleBB/pkg/ACM/client"
 	mc "github.com/vault-thirteen/SimpleBB/pkg/MM/client"
 	sm "github.com/vault-thirteen/SimpleBB/pkg/SM/models"
+	sc "github.com/vault-thirteen/SimpleBB/pkg/SMTP/client"
+	smtp "github.com/vault-thirteen/SimpleBB/pkg/SMTP/rpc"
+	"github.com/vault-thirteen/SimpleBB/pkg/common/models/simple"
 )
 
 // Auxiliary functions used in RPC functions.
@@ -377,3 +381,193 @@ func (srv *Server) isUserSubscribedH(userId base2.Id, threadId base2.Id) (isSubs
 
 	return isSubscribed, nil
 }
+
+// checkIfForumExists checks if the forum exists or not.
+func (srv *Server) checkIfForumExists(forumId base2.Id) (exists base2.Flag, re *jrm1.RpcError) {
+	params := mm.ForumExistsSParams{
+		DKeyParams: rpc2.DKeyParams{
+			// DKey is set during module start-up, so it is non-null.
+			DKey: *srv.dKeyForMM,
+		},
+		ForumId: forumId,
+	}
+	result := new(mm.ForumExistsSResult)
+	var err error
+	re, err = srv.mmServiceClient.MakeRequest(context.Background(), mc.FuncForumExistsS, params, result)
+	if err != nil {
+		srv.logError(err)
+		return false, jrm1.NewRpcErrorByUser(server2.RpcErrorCode_RPCCall, server2.RpcErrorMsg_RPCCall, nil)
+	}
+	if re != nil {
+		return false, re
+	}
+
+	return result.Exists, nil
+}
+
+// checkIfSectionExists checks if the section exists or not.
+func (srv *Server) checkIfSectionExists(sectionId base2.Id) (exists base2.Flag, re *jrm1.RpcError) {
+	params := mm.SectionExistsSParams{
+		DKeyParams: rpc2.DKeyParams{
+			// DKey is set during module start-up, so it is non-null.
+			DKey: *srv.dKeyForMM,
+		},
+		SectionId: sectionId,
+	}
+	result := new(mm.SectionExistsSResult)
+	var err error
+	re, err = srv.mmServiceClient.MakeRequest(context.Background(), mc.FuncSectionExistsS, params, result)
+	if err != nil {
+		srv.logError(err)
+		return false, jrm1.NewRpcErrorByUser(server2.RpcErrorCode_RPCCall, server2.RpcErrorMsg_RPCCall, nil)
+	}
+	if re != nil {
+		return false, re
+	}
+
+	return result.Exists, nil
+}
+
+// getThreadLocation reads names and parents of the thread from Message module.
+func (srv *Server) getThreadLocation(threadId base2.Id) (location *mm.GetThreadLocationSResult, re *jrm1.RpcError) {
+	params := mm.GetThreadLocationSParams{
+		DKeyParams: rpc2.DKeyParams{
+			// DKey is set during module start-up, so it is non-null.
+			DKey: *srv.dKeyForMM,
+		},
+		ThreadId: threadId,
+	}
+	location = new(mm.GetThreadLocationSResult)
+	var err error
+	re, err = srv.mmServiceClient.MakeRequest(context.Background(), mc.FuncGetThreadLocationS, params, location)
+	if err != nil {
+		srv.logError(err)
+		return nil, jrm1.NewRpcErrorByUser(server2.RpcErrorCode_RPCCall, server2.RpcErrorMsg_RPCCall, nil)
+	}
+	if re != nil {
+		return nil, re
+	}
+
+	return location, nil
+}
+
+// getDKeyForACM receives a DKey from Access Control module.
+func (srv *Server) getDKeyForACM() (dKey *base2.Text, re *jrm1.RpcError) {
+	params := am.GetDKeyParams{}
+	result := new(am.GetDKeyResult)
+	var err error
+	re, err = srv.acmServiceClient.MakeRequest(context.Background(), ac.FuncGetDKey, params, result)
+	if err != nil {
+		srv.logError(err)
+		return nil, jrm1.NewRpcErrorByUser(server2.RpcErrorCode_RPCCall, server2.RpcErrorMsg_RPCCall, nil)
+	}
+	if re != nil {
+		return nil, re
+	}
+
+	// DKey must be non-empty.
+	if len(result.DKey) == 0 {
+		return nil, jrm1.NewRpcErrorByUser(server2.RpcErrorCode_ModuleSynchronisation, server2.RpcErrorMsg_ModuleSynchronisation, nil)
+	}
+
+	return &result.DKey, nil
+}
+
+// getUserEmail reads an e-mail address of the user from Access Control module.
+func (srv *Server) getUserEmail(userId base2.Id) (email simple.Email, re *jrm1.RpcError) {
+	params := am.GetUserEmailSParams{
+		DKeyParams: rpc2.DKeyParams{
+			// DKey is set during module start-up, so it is non-null.
+			DKey: *srv.dKeyForACM,
+		},
+		UserId: userId,
+	}
+	result := new(am.GetUserEmailSResult)
+	var err error
+	re, err = srv.acmServiceClient.MakeRequest(context.Background(), ac.FuncGetUserEmailS, params, result)
+	if err != nil {
+		srv.logError(err)
+		return "", jrm1.NewRpcErrorByUser(server2.RpcErrorCode_RPCCall, server2.RpcErrorMsg_RPCCall, nil)
+	}
+	if re != nil {
+		return "", re
+	}
+
+	return result.Email, nil
+}
+
+func (srv *Server) sendEmailMessage(params smtp.SendMessageParams) (re *jrm1.RpcError) {
+	var result = new(smtp.SendMessageResult)
+
+	var err error
+	re, err = srv.smtpServiceClient.MakeRequest(context.Background(), sc.FuncSendMessage, params, result)
+	if err != nil {
+		srv.logError(err)
+		return jrm1.NewRpcErrorByUser(server2.RpcErrorCode_RPCCall, server2.RpcErrorMsg_RPCCall, nil)
+	}
+	if re != nil {
+		return jrm1.NewRpcErrorByUser(RpcErrorCode_SmtpModule, RpcErrorMsg_SmtpModule, nil)
+	}
+
+	return nil
+}
+
+// processNewThreadH is a helper function distributing a new thread among
+// subscribers of its forum and sections.
+func (srv *Server) processNewThreadH(location *mm.GetThreadLocationSResult, authorId base2.Id) (instantSubscribers []base2.Id, re *jrm1.RpcError) {
+	srv.dbo.LockForWriting()
+	defer srv.dbo.UnlockAfterWriting()
+
+	// Collect subscribers.
+	var subscribers []base2.Id
+	var err error
+	subscribers, err = srv.dbo.GetForumSubscribers(location.ForumId)
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+
+	var sectionSubscribers []base2.Id
+	for _, sectionId := range location.SectionIds {
+		sectionSubscribers, err = srv.dbo.GetSectionSubscribers(sectionId)
+		if err != nil {
+			return nil, srv.databaseError(err)
+		}
+
+		subscribers = append(subscribers, sectionSubscribers...)
+	}
+
+	slices.Sort(subscribers)
+	subscribers = slices.Compact(subscribers)
+
+	// Distribute the thread.
+	instantSubscribers = make([]base2.Id, 0, len(subscribers))
+	var dp *sm.DeliveryPreference
+	for _, userId := range subscribers {
+		if userId == authorId {
+			continue
+		}
+
+		dp, err = srv.dbo.GetDeliveryPreference(userId)
+		if err != nil {
+			return nil, srv.databaseError(err)
+		}
+
+		if dp.DeliveryMode == sm.DeliveryMode_Instant {
+			instantSubscribers = append(instantSubscribers, userId)
+			continue
+		}
+
+		err = srv.dbo.InsertDigestItem(&sm.DigestItem{
+			UserId:     userId,
+			ThreadId:   location.ThreadId,
+			ThreadName: location.ThreadName,
+			ForumId:    location.ForumId,
+			ForumName:  location.ForumName,
+		})
+		if err != nil {
+			return nil, srv.databaseError(err)
+		}
+	}
+
+	return instantSubscribers, nil
+}
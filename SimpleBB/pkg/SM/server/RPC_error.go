@@ -15,6 +15,13 @@ const (
 	RpcErrorCode_ThreadDoesNotExist     = 5
 	RpcErrorCode_TestError              = 6
 	RpcErrorCode_PageIsNotSet           = 7
+	RpcErrorCode_ForumIdIsNotSet        = 8
+	RpcErrorCode_SectionIdIsNotSet      = 9
+	RpcErrorCode_ForumDoesNotExist      = 10
+	RpcErrorCode_SectionDoesNotExist    = 11
+	RpcErrorCode_SubscriptionExists     = 12
+	RpcErrorCode_DeliveryModeIsNotValid = 13
+	RpcErrorCode_SmtpModule             = 14
 )
 
 // Messages.
@@ -26,6 +33,13 @@ const (
 	RpcErrorMsg_ThreadDoesNotExist     = "thread does not exist"
 	RpcErrorMsgF_TestError             = "test error: %s"
 	RpcErrorMsg_PageIsNotSet           = "page is not set"
+	RpcErrorMsg_ForumIdIsNotSet        = "forum ID is not set"
+	RpcErrorMsg_SectionIdIsNotSet      = "section ID is not set"
+	RpcErrorMsg_ForumDoesNotExist      = "forum does not exist"
+	RpcErrorMsg_SectionDoesNotExist    = "section does not exist"
+	RpcErrorMsg_SubscriptionExists     = "subscription already exists"
+	RpcErrorMsg_DeliveryModeIsNotValid = "delivery mode is not valid"
+	RpcErrorMsg_SmtpModule             = "SMTP module error"
 )
 
 // Unique HTTP status codes used in the map:
@@ -42,5 +56,12 @@ func GetMapOfHttpStatusCodesByRpcErrorCodes() map[int]int {
 		RpcErrorCode_ThreadDoesNotExist:     http.StatusConflict,
 		RpcErrorCode_TestError:              http.StatusInternalServerError,
 		RpcErrorCode_PageIsNotSet:           http.StatusBadRequest,
+		RpcErrorCode_ForumIdIsNotSet:        http.StatusBadRequest,
+		RpcErrorCode_SectionIdIsNotSet:      http.StatusBadRequest,
+		RpcErrorCode_ForumDoesNotExist:      http.StatusConflict,
+		RpcErrorCode_SectionDoesNotExist:    http.StatusConflict,
+		RpcErrorCode_SubscriptionExists:     http.StatusConflict,
+		RpcErrorCode_DeliveryModeIsNotValid: http.StatusBadRequest,
+		RpcErrorCode_SmtpModule:             http.StatusInternalServerError,
 	}
 }
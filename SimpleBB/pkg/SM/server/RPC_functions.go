@@ -3,6 +3,7 @@ package server
 import (
 	"fmt"
 	am "github.com/vault-thirteen/SimpleBB/pkg/ACM/rpc"
+	mm "github.com/vault-thirteen/SimpleBB/pkg/MM/rpc"
 	rpc2 "github.com/vault-thirteen/SimpleBB/pkg/SM/rpc"
 	base2 "github.com/vault-thirteen/SimpleBB/pkg/common/models/base"
 	rpc3 "github.com/vault-thirteen/SimpleBB/pkg/common/models/rpc"
@@ -600,6 +601,372 @@ func (srv *Server) clearThreadSubscriptionsS(p *rpc2.ClearThreadSubscriptionsSPa
 	return result, nil
 }
 
+// Forum and section subscriptions.
+
+// addSelfForumSubscription subscribes the caller user to new threads of the
+// forum.
+func (srv *Server) addSelfForumSubscription(p *rpc2.AddSelfForumSubscriptionParams) (result *rpc2.AddSelfForumSubscriptionResult, re *jrm1.RpcError) {
+	// Check parameters.
+	if p.ForumId == 0 {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_ForumIdIsNotSet, RpcErrorMsg_ForumIdIsNotSet, nil)
+	}
+
+	var userRoles *am.GetSelfRolesResult
+	userRoles, re = srv.mustBeAnAuthToken(p.Auth)
+	if re != nil {
+		return nil, re
+	}
+
+	// Check permissions.
+	if !userRoles.User.GetUserParameters().GetRoles().IsReader {
+		return nil, jrm1.NewRpcErrorByUser(c.RpcErrorCode_Permission, c.RpcErrorMsg_Permission, nil)
+	}
+
+	// Check existence of the forum.
+	var forumExists base2.Flag
+	forumExists, re = srv.checkIfForumExists(p.ForumId)
+	if re != nil {
+		return nil, re
+	}
+
+	if !forumExists {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_ForumDoesNotExist, RpcErrorMsg_ForumDoesNotExist, nil)
+	}
+
+	srv.dbo.LockForWriting()
+	defer srv.dbo.UnlockAfterWriting()
+
+	var userId = userRoles.User.GetUserParameters().GetId()
+	var n base2.Count
+	var err error
+	n, err = srv.dbo.CountForumSubscription(p.ForumId, userId)
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+
+	if n > 0 {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_SubscriptionExists, RpcErrorMsg_SubscriptionExists, nil)
+	}
+
+	err = srv.dbo.InsertForumSubscription(p.ForumId, userId)
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+
+	result = &rpc2.AddSelfForumSubscriptionResult{
+		Success: rpc3.Success{
+			OK: true,
+		},
+	}
+	return result, nil
+}
+
+// deleteSelfForumSubscription deletes a forum subscription of the caller
+// user. Subscriptions to deleted forums may be deleted too.
+func (srv *Server) deleteSelfForumSubscription(p *rpc2.DeleteSelfForumSubscriptionParams) (result *rpc2.DeleteSelfForumSubscriptionResult, re *jrm1.RpcError) {
+	// Check parameters.
+	if p.ForumId == 0 {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_ForumIdIsNotSet, RpcErrorMsg_ForumIdIsNotSet, nil)
+	}
+
+	var userRoles *am.GetSelfRolesResult
+	userRoles, re = srv.mustBeAnAuthToken(p.Auth)
+	if re != nil {
+		return nil, re
+	}
+
+	// Check permissions.
+	if !userRoles.User.GetUserParameters().GetRoles().IsReader {
+		return nil, jrm1.NewRpcErrorByUser(c.RpcErrorCode_Permission, c.RpcErrorMsg_Permission, nil)
+	}
+
+	srv.dbo.LockForWriting()
+	defer srv.dbo.UnlockAfterWriting()
+
+	var userId = userRoles.User.GetUserParameters().GetId()
+	var n base2.Count
+	var err error
+	n, err = srv.dbo.CountForumSubscription(p.ForumId, userId)
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+
+	if n == 0 {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_SubscriptionIsNotFound, RpcErrorMsg_SubscriptionIsNotFound, nil)
+	}
+
+	err = srv.dbo.DeleteForumSubscription(p.ForumId, userId)
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+
+	result = &rpc2.DeleteSelfForumSubscriptionResult{
+		Success: rpc3.Success{
+			OK: true,
+		},
+	}
+	return result, nil
+}
+
+// addSelfSectionSubscription subscribes the caller user to new threads of all
+// forums in the section and in its sub-sections.
+func (srv *Server) addSelfSectionSubscription(p *rpc2.AddSelfSectionSubscriptionParams) (result *rpc2.AddSelfSectionSubscriptionResult, re *jrm1.RpcError) {
+	// Check parameters.
+	if p.SectionId == 0 {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_SectionIdIsNotSet, RpcErrorMsg_SectionIdIsNotSet, nil)
+	}
+
+	var userRoles *am.GetSelfRolesResult
+	userRoles, re = srv.mustBeAnAuthToken(p.Auth)
+	if re != nil {
+		return nil, re
+	}
+
+	// Check permissions.
+	if !userRoles.User.GetUserParameters().GetRoles().IsReader {
+		return nil, jrm1.NewRpcErrorByUser(c.RpcErrorCode_Permission, c.RpcErrorMsg_Permission, nil)
+	}
+
+	// Check existence of the section.
+	var sectionExists base2.Flag
+	sectionExists, re = srv.checkIfSectionExists(p.SectionId)
+	if re != nil {
+		return nil, re
+	}
+
+	if !sectionExists {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_SectionDoesNotExist, RpcErrorMsg_SectionDoesNotExist, nil)
+	}
+
+	srv.dbo.LockForWriting()
+	defer srv.dbo.UnlockAfterWriting()
+
+	var userId = userRoles.User.GetUserParameters().GetId()
+	var n base2.Count
+	var err error
+	n, err = srv.dbo.CountSectionSubscription(p.SectionId, userId)
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+
+	if n > 0 {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_SubscriptionExists, RpcErrorMsg_SubscriptionExists, nil)
+	}
+
+	err = srv.dbo.InsertSectionSubscription(p.SectionId, userId)
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+
+	result = &rpc2.AddSelfSectionSubscriptionResult{
+		Success: rpc3.Success{
+			OK: true,
+		},
+	}
+	return result, nil
+}
+
+// deleteSelfSectionSubscription deletes a section subscription of the caller
+// user. Subscriptions to deleted sections may be deleted too.
+func (srv *Server) deleteSelfSectionSubscription(p *rpc2.DeleteSelfSectionSubscriptionParams) (result *rpc2.DeleteSelfSectionSubscriptionResult, re *jrm1.RpcError) {
+	// Check parameters.
+	if p.SectionId == 0 {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_SectionIdIsNotSet, RpcErrorMsg_SectionIdIsNotSet, nil)
+	}
+
+	var userRoles *am.GetSelfRolesResult
+	userRoles, re = srv.mustBeAnAuthToken(p.Auth)
+	if re != nil {
+		return nil, re
+	}
+
+	// Check permissions.
+	if !userRoles.User.GetUserParameters().GetRoles().IsReader {
+		return nil, jrm1.NewRpcErrorByUser(c.RpcErrorCode_Permission, c.RpcErrorMsg_Permission, nil)
+	}
+
+	srv.dbo.LockForWriting()
+	defer srv.dbo.UnlockAfterWriting()
+
+	var userId = userRoles.User.GetUserParameters().GetId()
+	var n base2.Count
+	var err error
+	n, err = srv.dbo.CountSectionSubscription(p.SectionId, userId)
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+
+	if n == 0 {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_SubscriptionIsNotFound, RpcErrorMsg_SubscriptionIsNotFound, nil)
+	}
+
+	err = srv.dbo.DeleteSectionSubscription(p.SectionId, userId)
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+
+	result = &rpc2.DeleteSelfSectionSubscriptionResult{
+		Success: rpc3.Success{
+			OK: true,
+		},
+	}
+	return result, nil
+}
+
+// getSelfForumAndSectionSubscriptions reads lists of forums and sections to
+// which the caller user is subscribed.
+func (srv *Server) getSelfForumAndSectionSubscriptions(p *rpc2.GetSelfForumAndSectionSubscriptionsParams) (result *rpc2.GetSelfForumAndSectionSubscriptionsResult, re *jrm1.RpcError) {
+	var userRoles *am.GetSelfRolesResult
+	userRoles, re = srv.mustBeAnAuthToken(p.Auth)
+	if re != nil {
+		return nil, re
+	}
+
+	// Check permissions.
+	if !userRoles.User.GetUserParameters().GetRoles().IsReader {
+		return nil, jrm1.NewRpcErrorByUser(c.RpcErrorCode_Permission, c.RpcErrorMsg_Permission, nil)
+	}
+
+	srv.dbo.LockForReading()
+	defer srv.dbo.UnlockAfterReading()
+
+	result = &rpc2.GetSelfForumAndSectionSubscriptionsResult{
+		UserId: userRoles.User.GetUserParameters().GetId(),
+	}
+
+	var err error
+	result.ForumIds, err = srv.dbo.GetUserForumSubscriptions(result.UserId)
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+
+	result.SectionIds, err = srv.dbo.GetUserSectionSubscriptions(result.UserId)
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+
+	return result, nil
+}
+
+// setSelfDeliveryMode sets the way in which the caller user gets notices about
+// new threads in subscribed forums and sections. Threads waiting for a digest
+// are dropped when digests are turned off.
+func (srv *Server) setSelfDeliveryMode(p *rpc2.SetSelfDeliveryModeParams) (result *rpc2.SetSelfDeliveryModeResult, re *jrm1.RpcError) {
+	// Check parameters.
+	if !sm.IsDeliveryModeValid(p.DeliveryMode) {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_DeliveryModeIsNotValid, RpcErrorMsg_DeliveryModeIsNotValid, nil)
+	}
+
+	var userRoles *am.GetSelfRolesResult
+	userRoles, re = srv.mustBeAnAuthToken(p.Auth)
+	if re != nil {
+		return nil, re
+	}
+
+	// Check permissions.
+	if !userRoles.User.GetUserParameters().GetRoles().IsReader {
+		return nil, jrm1.NewRpcErrorByUser(c.RpcErrorCode_Permission, c.RpcErrorMsg_Permission, nil)
+	}
+
+	srv.dbo.LockForWriting()
+	defer srv.dbo.UnlockAfterWriting()
+
+	var userId = userRoles.User.GetUserParameters().GetId()
+	var err error
+	err = srv.dbo.SetDeliveryMode(userId, p.DeliveryMode)
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+
+	if p.DeliveryMode == sm.DeliveryMode_Instant {
+		err = srv.dbo.DeleteAllDigestItems(userId)
+		if err != nil {
+			return nil, srv.databaseError(err)
+		}
+	}
+
+	result = &rpc2.SetSelfDeliveryModeResult{
+		Success: rpc3.Success{
+			OK: true,
+		},
+	}
+	return result, nil
+}
+
+// getSelfDeliveryMode reads the delivery mode of the caller user.
+func (srv *Server) getSelfDeliveryMode(p *rpc2.GetSelfDeliveryModeParams) (result *rpc2.GetSelfDeliveryModeResult, re *jrm1.RpcError) {
+	var userRoles *am.GetSelfRolesResult
+	userRoles, re = srv.mustBeAnAuthToken(p.Auth)
+	if re != nil {
+		return nil, re
+	}
+
+	// Check permissions.
+	if !userRoles.User.GetUserParameters().GetRoles().IsReader {
+		return nil, jrm1.NewRpcErrorByUser(c.RpcErrorCode_Permission, c.RpcErrorMsg_Permission, nil)
+	}
+
+	srv.dbo.LockForReading()
+	defer srv.dbo.UnlockAfterReading()
+
+	var dp *sm.DeliveryPreference
+	var err error
+	dp, err = srv.dbo.GetDeliveryPreference(userRoles.User.GetUserParameters().GetId())
+	if err != nil {
+		return nil, srv.databaseError(err)
+	}
+
+	result = &rpc2.GetSelfDeliveryModeResult{
+		UserId:       dp.UserId,
+		DeliveryMode: dp.DeliveryMode,
+	}
+
+	return result, nil
+}
+
+// processNewThreadS distributes a new thread among users subscribed to its
+// forum or to sections containing the forum. The thread is queued into
+// digests of users preferring digests, other subscribers are returned to be
+// notified instantly. The author of the thread is skipped. This method is used
+// by the system.
+func (srv *Server) processNewThreadS(p *rpc2.ProcessNewThreadSParams) (result *rpc2.ProcessNewThreadSResult, re *jrm1.RpcError) {
+	// Check parameters.
+	if p.ThreadId == 0 {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_ThreadIdIsNotSet, RpcErrorMsg_ThreadIdIsNotSet, nil)
+	}
+	if p.AuthorId == 0 {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_UserIdIsNotSet, RpcErrorMsg_UserIdIsNotSet, nil)
+	}
+
+	re = srv.mustBeNoAuth(p.Auth)
+	if re != nil {
+		return nil, re
+	}
+
+	// Check the DKey.
+	if !srv.dKeyI.CheckString(p.DKey.ToString()) {
+		return nil, jrm1.NewRpcErrorByUser(c.RpcErrorCode_Permission, c.RpcErrorMsg_Permission, nil)
+	}
+
+	var location *mm.GetThreadLocationSResult
+	location, re = srv.getThreadLocation(p.ThreadId)
+	if re != nil {
+		return nil, re
+	}
+
+	var instantSubscribers []base2.Id
+	instantSubscribers, re = srv.processNewThreadH(location, p.AuthorId)
+	if re != nil {
+		return nil, re
+	}
+
+	result = &rpc2.ProcessNewThreadSResult{
+		InstantSubscribers: instantSubscribers,
+	}
+
+	return result, nil
+}
+
 // Other.
 
 func (srv *Server) getDKey(p *rpc2.GetDKeyParams) (result *rpc2.GetDKeyResult, re *jrm1.RpcError) {
@@ -623,6 +990,9 @@ func (srv *Server) showDiagnosticData() (result *rpc2.ShowDiagnosticDataResult,
 			TotalRequestsCount:      base2.Text(trc),
 			SuccessfulRequestsCount: base2.Text(src),
 		},
+		ScheduledTasks: rpc3.ScheduledTasks{
+			ScheduledTasks: srv.scheduler.GetTaskStatuses(),
+		},
 	}
 
 	return result, nil
@@ -13,7 +13,6 @@ import (
 	cmb "github.com/vault-thirteen/SimpleBB/pkg/common/models/base"
 	server2 "github.com/vault-thirteen/SimpleBB/pkg/common/models/server"
 	cset "github.com/vault-thirteen/SimpleBB/pkg/common/models/settings"
-	"github.com/vault-thirteen/SimpleBB/pkg/common/models/simple"
 	"log"
 	"net"
 	"net/http"
@@ -54,14 +53,16 @@ type Server struct {
 	js *jrm1.Processor
 
 	// Clients for external services.
-	acmServiceClient *cc.Client
-	mmServiceClient  *cc.Client
+	acmServiceClient  *cc.Client
+	mmServiceClient   *cc.Client
+	smtpServiceClient *cc.Client
 
 	// Internal DKeys.
 	dKeyI *dk.DKey
 
 	// External DKeys.
-	dKeyForMM *cmb.Text
+	dKeyForACM *cmb.Text
+	dKeyForMM  *cmb.Text
 
 	// Scheduler.
 	scheduler *cm.Scheduler
@@ -119,7 +120,10 @@ func NewServer(s base.ISettings) (srv *Server, err error) {
 		return nil, err
 	}
 
-	srv.initScheduler()
+	err = srv.initScheduler()
+	if err != nil {
+		return nil, err
+	}
 
 	return srv, nil
 }
@@ -158,7 +162,7 @@ func (srv *Server) Start() (err error) {
 		return err
 	}
 
-	err = srv.checkDatabaseConsistency()
+	err = srv.checkDatabaseConsistency(context.Background())
 	if err != nil {
 		return err
 	}
@@ -307,6 +311,22 @@ func (srv *Server) createClientsForExternalServices() (err error) {
 		}
 	}
 
+	// SMTP module.
+	{
+		var smtpSCS = &cset.ServiceClientSettings{
+			Schema:                      srv.settings.SmtpSettings.Schema,
+			Host:                        srv.settings.SmtpSettings.Host,
+			Port:                        srv.settings.SmtpSettings.Port,
+			Path:                        srv.settings.SmtpSettings.Path,
+			EnableSelfSignedCertificate: srv.settings.SmtpSettings.EnableSelfSignedCertificate,
+		}
+
+		srv.smtpServiceClient, err = cc.NewClientWithSCS(smtpSCS, app.ServiceShortName_SMTP)
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -327,10 +347,35 @@ func (srv *Server) pingClientsForExternalServices() (err error) {
 		}
 	}
 
+	// SMTP module.
+	{
+		err = srv.smtpServiceClient.Ping(true)
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
 func (srv *Server) synchroniseModules(verbose bool) (err error) {
+	// ACM module.
+	{
+		if verbose {
+			fmt.Print(fmt.Sprintf(server2.MsgFSynchronisingWithModule, app.ServiceShortName_ACM))
+		}
+
+		var re *jrm1.RpcError
+		srv.dKeyForACM, re = srv.getDKeyForACM()
+		if re != nil {
+			return re.AsError()
+		}
+
+		if verbose {
+			fmt.Println(server2.MsgOK)
+		}
+	}
+
 	// MM module.
 	{
 		if verbose {
@@ -360,11 +405,18 @@ func (srv *Server) initKeys() (err error) {
 	return nil
 }
 
-func (srv *Server) initScheduler() {
-	funcs3600 := []simple.ScheduledFn{
-		srv.checkDatabaseConsistency,
+func (srv *Server) initScheduler() (err error) {
+	tasks := []cm.Task{
+		{Name: "checkDatabaseConsistency", Schedule: "@every 1h", Fn: srv.checkDatabaseConsistency, Jitter: 5 * time.Minute, Timeout: 30 * time.Minute},
+		{Name: "sendDigests", Schedule: "@every 1h", Fn: srv.sendDigests, Timeout: 30 * time.Minute},
 	}
-	srv.scheduler = cm.NewScheduler(srv, nil, nil, funcs3600)
+
+	srv.scheduler, err = cm.NewScheduler(srv, tasks)
+	if err != nil {
+		return err
+	}
+
+	return nil
 }
 
 func (srv *Server) ReportStart() {
@@ -1,24 +1,32 @@
 package server
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	cmb "github.com/vault-thirteen/SimpleBB/pkg/common/models/base"
 	c "github.com/vault-thirteen/SimpleBB/pkg/common/models/server"
+	"github.com/vault-thirteen/SimpleBB/pkg/common/models/simple"
 	"log"
+	"time"
 
 	"github.com/kr/pretty"
+	jrm1 "github.com/vault-thirteen/JSON-RPC-M1"
 	sm "github.com/vault-thirteen/SimpleBB/pkg/SM/models"
+	smtp "github.com/vault-thirteen/SimpleBB/pkg/SMTP/rpc"
+	ae "github.com/vault-thirteen/auxie/errors"
 )
 
 const (
 	Err_SubscriptionDataIsDamaged     = "subscription data is damaged"
 	ErrF_SubscriptionRecordIsNotFound = "subscription record is not found, record=%v"
+	ErrF_DigestIsNotSent              = "digest is not sent, user=%v: %v"
 )
 
 // checkDatabaseConsistency checks consistency of thread subscription records
 // and user subscription records. This function is used in the scheduler and is
 // also run once during the server's start.
-func (srv *Server) checkDatabaseConsistency() (err error) {
+func (srv *Server) checkDatabaseConsistency(_ context.Context) (err error) {
 	srv.dbo.LockForReading()
 	defer srv.dbo.UnlockAfterReading()
 
@@ -72,3 +80,88 @@ func (srv *Server) checkDatabaseConsistency() (err error) {
 
 	return nil
 }
+
+// sendDigests sends digests of new threads to users who prefer digests and
+// whose digest period has passed. An error with a digest of one user does not
+// stop digests of other users, errors of all users are returned together. This
+// function is used in the scheduler.
+func (srv *Server) sendDigests(ctx context.Context) (err error) {
+	var dps []sm.DeliveryPreference
+	dps, err = srv.getDigestDeliveryPreferencesH()
+	if err != nil {
+		return err
+	}
+
+	var now = time.Now()
+	var derr error
+	for _, dp := range dps {
+		if ctx.Err() != nil {
+			return ae.Combine(err, ctx.Err())
+		}
+
+		if !dp.IsDigestDue(now) {
+			continue
+		}
+
+		derr = srv.sendDigestH(&dp, now)
+		if derr != nil {
+			err = ae.Combine(err, fmt.Errorf(ErrF_DigestIsNotSent, dp.UserId, derr.Error()))
+		}
+	}
+
+	return err
+}
+
+// getDigestDeliveryPreferencesH is a helper function to read preferences of
+// users who prefer digests.
+func (srv *Server) getDigestDeliveryPreferencesH() (dps []sm.DeliveryPreference, err error) {
+	srv.dbo.LockForReading()
+	defer srv.dbo.UnlockAfterReading()
+
+	return srv.dbo.GetDigestDeliveryPreferences()
+}
+
+// sendDigestH composes and sends a digest to a single user. Sent items are
+// deleted. When there are no items, only the time of the digest is updated.
+func (srv *Server) sendDigestH(dp *sm.DeliveryPreference, now time.Time) (err error) {
+	var items []sm.DigestItem
+	items, err = srv.getDigestItemsH(dp.UserId)
+	if err != nil {
+		return err
+	}
+
+	var d = sm.NewDigest(srv.settings.SystemSettings.SiteName, dp.DeliveryMode, items)
+	if d != nil {
+		var email simple.Email
+		var re *jrm1.RpcError
+		email, re = srv.getUserEmail(dp.UserId)
+		if re != nil {
+			return re.AsError()
+		}
+
+		re = srv.sendEmailMessage(smtp.SendMessageParams{Recipient: email, Subject: d.Subject, Message: d.Text})
+		if re != nil {
+			return re.AsError()
+		}
+	}
+
+	srv.dbo.LockForWriting()
+	defer srv.dbo.UnlockAfterWriting()
+
+	if d != nil {
+		err = srv.dbo.DeleteDigestItems(dp.UserId, d.LastItemId)
+		if err != nil {
+			return err
+		}
+	}
+
+	return srv.dbo.SetLastDigestTime(dp.UserId, now)
+}
+
+// getDigestItemsH is a helper function to read digest items of a user.
+func (srv *Server) getDigestItemsH(userId cmb.Id) (items []sm.DigestItem, err error) {
+	srv.dbo.LockForReading()
+	defer srv.dbo.UnlockAfterReading()
+
+	return srv.dbo.GetDigestItems(userId)
+}
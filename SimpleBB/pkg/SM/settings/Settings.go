@@ -25,8 +25,9 @@ type Settings struct {
 	SystemSettings `json:"system"`
 
 	// External services.
-	AcmSettings s.ServiceClientSettings `json:"acm"`
-	MmSettings  s.ServiceClientSettings `json:"mm"`
+	AcmSettings  s.ServiceClientSettings `json:"acm"`
+	MmSettings   s.ServiceClientSettings `json:"mm"`
+	SmtpSettings s.ServiceClientSettings `json:"smtp"`
 }
 
 func NewSettingsFromFile(filePath string, versionInfo *ver.Versioneer) (stn *Settings, err error) {
@@ -94,6 +95,10 @@ func (stn *Settings) Check() (err error) {
 	if err != nil {
 		return s.DetailedScsError(app.ServiceShortName_MM, err)
 	}
+	err = stn.SmtpSettings.Check()
+	if err != nil {
+		return s.DetailedScsError(app.ServiceShortName_SMTP, err)
+	}
 
 	return nil
 }
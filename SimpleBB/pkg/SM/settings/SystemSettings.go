@@ -8,13 +8,17 @@ import (
 
 // SystemSettings are system settings.
 type SystemSettings struct {
+	// Name of the site is used in digest e-mails.
+	SiteName base2.Text `json:"siteName"`
+
 	PageSize    base2.Count `json:"pageSize"`
 	DKeySize    base2.Count `json:"dKeySize"`
 	IsDebugMode base2.Flag  `json:"isDebugMode"`
 }
 
 func (s SystemSettings) Check() (err error) {
-	if (s.PageSize == 0) ||
+	if (len(s.SiteName) == 0) ||
+		(s.PageSize == 0) ||
 		(s.DKeySize == 0) {
 		return errors.New(c.MsgSystemSettingError)
 	}
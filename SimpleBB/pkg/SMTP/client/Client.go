@@ -10,7 +10,9 @@ const (
 	FuncPing = cc.FuncPing
 
 	// Message.
-	FuncSendMessage = "SendMessage"
+	FuncSendMessage        = "SendMessage"
+	FuncListDeadMessages   = "ListDeadMessages"
+	FuncRequeueDeadMessage = "RequeueDeadMessage"
 
 	// Other.
 	FuncShowDiagnosticData = cc.FuncShowDiagnosticData
@@ -1,20 +1,41 @@
 package mailer
 
 import (
+	"crypto/tls"
 	"errors"
-
-	"github.com/valord577/mailx"
+	"net"
+	"net/smtp"
+	"strconv"
+	"time"
 )
 
-// This tiny library uses another SMTP library because the built-in Golang SMTP
-// library is so poor that it hangs infinitely during the connection to an SMTP
-// server.
+// The built-in Golang SMTP library hangs infinitely during the connection to
+// an SMTP server, because it has no timeouts. This is why the connection is
+// made here manually and is limited by a deadline.
+
+const (
+	ErrHostIsEmpty            = "host is empty"
+	ErrPortIsNotSet           = "port is not set"
+	ErrUsernameIsEmpty        = "username is empty"
+	ErrUserAgentIsEmpty       = "user agent is empty"
+	ErrTlsModeIsNotValid      = "TLS mode is not valid"
+	ErrTimeoutIsNotSet        = "timeout is not set"
+	ErrStartTlsIsNotSupported = "STARTTLS is not supported by server"
+)
 
+// Modes of connection security.
 const (
-	ErrHostIsEmpty      = "host is empty"
-	ErrPortIsNotSet     = "port is not set"
-	ErrUsernameIsEmpty  = "username is empty"
-	ErrUserAgentIsEmpty = "user agent is empty"
+	// TlsMode_Implicit uses TLS from the beginning of a connection. This mode
+	// is also known as SMTPS and is usually served on port 465.
+	TlsMode_Implicit = "implicit"
+
+	// TlsMode_StartTls upgrades a plain connection using the STARTTLS
+	// command. This mode is usually served on port 587.
+	TlsMode_StartTls = "starttls"
+
+	// TlsMode_None does not use encryption at all. It must be used only with
+	// local SMTP servers, e.g. in tests.
+	TlsMode_None = "none"
 )
 
 // Mailer is an e-mail sender.
@@ -25,6 +46,8 @@ type Mailer struct {
 	username  string
 	pwd       string
 	userAgent string
+	tlsMode   string
+	timeout   time.Duration
 }
 
 func NewMailer(
@@ -33,6 +56,8 @@ func NewMailer(
 	username string,
 	pwd string,
 	userAgent string,
+	tlsMode string,
+	timeout time.Duration,
 ) (m *Mailer, err error) {
 	if len(host) == 0 {
 		return nil, errors.New(ErrHostIsEmpty)
@@ -46,6 +71,12 @@ func NewMailer(
 	if len(userAgent) == 0 {
 		return nil, errors.New(ErrUserAgentIsEmpty)
 	}
+	if !IsValidTlsMode(tlsMode) {
+		return nil, errors.New(ErrTlsModeIsNotValid)
+	}
+	if timeout <= 0 {
+		return nil, errors.New(ErrTimeoutIsNotSet)
+	}
 
 	return &Mailer{
 		host:      host,
@@ -53,32 +84,139 @@ func NewMailer(
 		username:  username,
 		pwd:       pwd,
 		userAgent: userAgent,
+		tlsMode:   tlsMode,
+		timeout:   timeout,
 	}, nil
 }
 
+func IsValidTlsMode(tlsMode string) bool {
+	switch tlsMode {
+	case TlsMode_Implicit,
+		TlsMode_StartTls,
+		TlsMode_None:
+		return true
+
+	default:
+		return false
+	}
+}
+
+// SendMail sends a plain text message.
 func (m *Mailer) SendMail(
 	recipients []string,
 	subject string,
 	message string,
 ) (err error) {
-	msg := mailx.NewMessage()
-	msg.SetTo(recipients...)
-	msg.SetSubject(subject)
-	msg.SetPlainBody(message)
-	msg.SetUserAgent(m.userAgent)
+	msg := &Message{
+		Recipients: recipients,
+		Subject:    subject,
+		PlainBody:  message,
+	}
+
+	return m.Send(msg)
+}
+
+// Send sends the message. The whole SMTP session must fit into the timeout.
+func (m *Mailer) Send(msg *Message) (err error) {
+	err = msg.Check()
+	if err != nil {
+		return err
+	}
+
+	var data []byte
+	data, err = msg.Compose(m.username, m.userAgent, time.Now())
+	if err != nil {
+		return err
+	}
+
+	var conn net.Conn
+	conn, err = m.dial()
+	if err != nil {
+		return err
+	}
+	// The connection is closed by the QUIT command, closing it again is
+	// needed only when the session is broken.
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	err = conn.SetDeadline(time.Now().Add(m.timeout))
+	if err != nil {
+		return err
+	}
 
-	dialer := mailx.Dialer{
-		Host:         m.host,
-		Port:         int(m.port),
-		Username:     m.username,
-		Password:     m.pwd,
-		SSLOnConnect: true,
+	var c *smtp.Client
+	c, err = smtp.NewClient(conn, m.host)
+	if err != nil {
+		return err
+	}
+
+	if m.tlsMode == TlsMode_StartTls {
+		if ok, _ := c.Extension("STARTTLS"); !ok {
+			return errors.New(ErrStartTlsIsNotSupported)
+		}
+
+		err = c.StartTLS(m.newTlsConfig())
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(m.pwd) > 0 {
+		err = c.Auth(smtp.PlainAuth("", m.username, m.pwd, m.host))
+		if err != nil {
+			return err
+		}
+	}
+
+	err = m.transmit(c, msg.Recipients, data)
+	if err != nil {
+		return err
+	}
+
+	return c.Quit()
+}
+
+func (m *Mailer) dial() (conn net.Conn, err error) {
+	addr := net.JoinHostPort(m.host, strconv.FormatUint(uint64(m.port), 10))
+	dialer := &net.Dialer{Timeout: m.timeout}
+
+	if m.tlsMode == TlsMode_Implicit {
+		return tls.DialWithDialer(dialer, "tcp", addr, m.newTlsConfig())
+	}
+
+	return dialer.Dial("tcp", addr)
+}
+
+func (m *Mailer) newTlsConfig() *tls.Config {
+	return &tls.Config{
+		ServerName: m.host,
+		MinVersion: tls.VersionTLS12,
+	}
+}
+
+func (m *Mailer) transmit(c *smtp.Client, recipients []string, data []byte) (err error) {
+	err = c.Mail(m.username)
+	if err != nil {
+		return err
+	}
+
+	for _, recipient := range recipients {
+		err = c.Rcpt(recipient)
+		if err != nil {
+			return err
+		}
+	}
+
+	w, err := c.Data()
+	if err != nil {
+		return err
 	}
 
-	err = dialer.DialAndSend(msg)
+	_, err = w.Write(data)
 	if err != nil {
 		return err
 	}
 
-	return nil
+	return w.Close()
 }
@@ -0,0 +1,194 @@
+package mailer
+
+import (
+	"bufio"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/vault-thirteen/auxie/tester"
+)
+
+// fakeSmtpServer is a minimal SMTP server which accepts every message.
+type fakeSmtpServer struct {
+	listener   net.Listener
+	extensions []string
+
+	guard      sync.Mutex
+	from       string
+	recipients []string
+	data       string
+	isAuthUsed bool
+}
+
+func newFakeSmtpServer(t *testing.T, extensions ...string) (s *fakeSmtpServer) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s = &fakeSmtpServer{listener: listener, extensions: extensions}
+	go s.serve()
+	t.Cleanup(func() { _ = listener.Close() })
+
+	return s
+}
+
+func (s *fakeSmtpServer) port() uint16 {
+	return uint16(s.listener.Addr().(*net.TCPAddr).Port)
+}
+
+func (s *fakeSmtpServer) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+
+		go s.handle(conn)
+	}
+}
+
+func (s *fakeSmtpServer) handle(conn net.Conn) {
+	defer conn.Close()
+
+	r := bufio.NewReader(conn)
+	reply := func(line string) { _, _ = conn.Write([]byte(line + "\r\n")) }
+
+	reply("220 localhost ESMTP")
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		line = strings.TrimRight(line, "\r\n")
+		cmd := strings.ToUpper(strings.SplitN(line, " ", 2)[0])
+
+		s.guard.Lock()
+		switch cmd {
+		case "EHLO":
+			reply("250-localhost")
+			for _, ext := range s.extensions {
+				reply("250-" + ext)
+			}
+			reply("250 8BITMIME")
+		case "AUTH":
+			s.isAuthUsed = true
+			reply("235 OK")
+		case "MAIL":
+			s.from = addressOf(line)
+			reply("250 OK")
+		case "RCPT":
+			s.recipients = append(s.recipients, addressOf(line))
+			reply("250 OK")
+		case "DATA":
+			reply("354 Go ahead")
+			var sb strings.Builder
+			for {
+				dl, err := r.ReadString('\n')
+				if err != nil {
+					s.guard.Unlock()
+					return
+				}
+				if dl == ".\r\n" {
+					break
+				}
+				sb.WriteString(dl)
+			}
+			s.data = sb.String()
+			reply("250 OK")
+		case "QUIT":
+			reply("221 Bye")
+			s.guard.Unlock()
+			return
+		default:
+			reply("502 Not implemented")
+		}
+		s.guard.Unlock()
+	}
+}
+
+// addressOf takes an address from the 'MAIL FROM:<a> ...' or 'RCPT TO:<a>'
+// command.
+func addressOf(line string) string {
+	return line[strings.Index(line, "<")+1 : strings.Index(line, ">")]
+}
+
+func Test_Mailer_Send(t *testing.T) {
+	aTest := tester.New(t)
+	s := newFakeSmtpServer(t, "AUTH PLAIN")
+
+	m, err := NewMailer("127.0.0.1", s.port(), "robot@example.org", "secret", "Test", TlsMode_None, 5*time.Second)
+	aTest.MustBeNoError(err)
+
+	err = m.Send(&Message{
+		Recipients: []string{"user@example.org"},
+		Subject:    "Verification code",
+		PlainBody:  "Code: 123.",
+		HtmlBody:   "<p>Code: <b>123</b>.</p>",
+	})
+	aTest.MustBeNoError(err)
+
+	s.guard.Lock()
+	defer s.guard.Unlock()
+	aTest.MustBeEqual(s.isAuthUsed, true)
+	aTest.MustBeEqual(s.from, "robot@example.org")
+	aTest.MustBeEqual(s.recipients, []string{"user@example.org"})
+	aTest.MustBeEqual(strings.Contains(s.data, "Content-Type: multipart/alternative;"), true)
+	aTest.MustBeEqual(strings.Contains(s.data, "User-Agent: Test\r\n"), true)
+}
+
+func Test_Mailer_StartTls(t *testing.T) {
+	aTest := tester.New(t)
+
+	// The server does not offer STARTTLS, so the message must not be sent
+	// over a plain connection.
+	s := newFakeSmtpServer(t)
+	m, err := NewMailer("127.0.0.1", s.port(), "robot@example.org", "", "Test", TlsMode_StartTls, 5*time.Second)
+	aTest.MustBeNoError(err)
+
+	err = m.SendMail([]string{"user@example.org"}, "Subject", "Text")
+	aTest.MustBeAnError(err)
+	aTest.MustBeEqual(err.Error(), ErrStartTlsIsNotSupported)
+
+	s.guard.Lock()
+	defer s.guard.Unlock()
+	aTest.MustBeEqual(len(s.recipients), 0)
+}
+
+func Test_Mailer_Timeout(t *testing.T) {
+	aTest := tester.New(t)
+
+	// This server accepts connections but never answers.
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	aTest.MustBeNoError(err)
+	defer listener.Close()
+	go func() {
+		conn, err := listener.Accept()
+		if err == nil {
+			defer conn.Close()
+			time.Sleep(2 * time.Second)
+		}
+	}()
+
+	port := uint16(listener.Addr().(*net.TCPAddr).Port)
+	m, err := NewMailer("127.0.0.1", port, "robot@example.org", "", "Test", TlsMode_None, 100*time.Millisecond)
+	aTest.MustBeNoError(err)
+
+	t1 := time.Now()
+	err = m.SendMail([]string{"user@example.org"}, "Subject", "Text")
+	aTest.MustBeAnError(err)
+	aTest.MustBeEqual(time.Since(t1) < time.Second, true)
+}
+
+func Test_NewMailer(t *testing.T) {
+	aTest := tester.New(t)
+
+	_, err := NewMailer("localhost", 25, "robot", "", "Test", "ssl", time.Second)
+	aTest.MustBeAnError(err)
+
+	_, err = NewMailer("localhost", 25, "robot", "", "Test", TlsMode_StartTls, 0)
+	aTest.MustBeAnError(err)
+}
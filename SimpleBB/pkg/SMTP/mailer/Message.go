@@ -0,0 +1,296 @@
+package mailer
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/textproto"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	ErrRecipientsAreNotSet       = "recipients are not set"
+	ErrSubjectIsEmpty            = "subject is empty"
+	ErrBodyIsEmpty               = "body is empty"
+	ErrHeaderIsNotValid          = "header is not valid"
+	ErrFileNameIsEmpty           = "file name is empty"
+	ErrInlineAttachmentNeedsHtml = "inline attachment requires an HTML body"
+)
+
+const (
+	Charset                    = "utf-8"
+	ContentTypeDefault         = "application/octet-stream"
+	ContentTypeTextPlain       = "text/plain"
+	ContentTypeTextHtml        = "text/html"
+	ContentTransferEncodingQP  = "quoted-printable"
+	ContentTransferEncodingB64 = "base64"
+	DispositionAttachment      = "attachment"
+	DispositionInline          = "inline"
+	MultipartAlternative       = "alternative"
+	MultipartRelated           = "related"
+	MultipartMixed             = "mixed"
+
+	// Base64 lines must not be longer than 76 characters.
+	Base64LineLength = 76
+)
+
+// Message is an e-mail message. At least one of the bodies must be set. When
+// both bodies are set, mail clients choose the one they are able to show.
+type Message struct {
+	Recipients  []string     `json:"recipients"`
+	Subject     string       `json:"subject"`
+	PlainBody   string       `json:"plainBody"`
+	HtmlBody    string       `json:"htmlBody"`
+	Attachments []Attachment `json:"attachments"`
+}
+
+// Attachment is a file attached to an e-mail message.
+type Attachment struct {
+	FileName string `json:"fileName"`
+
+	// When the content type is not set, it is guessed by the file extension.
+	ContentType string `json:"contentType"`
+
+	// Inline attachments are shown inside the HTML body, where they are
+	// referenced by their file names, e.g. '<img src="cid:logo.png">'.
+	IsInline bool `json:"isInline"`
+
+	Data []byte `json:"data"`
+}
+
+// part is a MIME entity which is able to write its body.
+type part struct {
+	header    textproto.MIMEHeader
+	writeBody func(w io.Writer) error
+}
+
+func (m *Message) Check() (err error) {
+	if len(m.Recipients) == 0 {
+		return errors.New(ErrRecipientsAreNotSet)
+	}
+
+	for _, r := range m.Recipients {
+		if len(r) == 0 || strings.ContainsAny(r, "\r\n,") {
+			return errors.New(ErrHeaderIsNotValid)
+		}
+	}
+
+	if len(m.Subject) == 0 {
+		return errors.New(ErrSubjectIsEmpty)
+	}
+	if strings.ContainsAny(m.Subject, "\r\n") {
+		return errors.New(ErrHeaderIsNotValid)
+	}
+
+	if (len(m.PlainBody) == 0) && (len(m.HtmlBody) == 0) {
+		return errors.New(ErrBodyIsEmpty)
+	}
+
+	for _, a := range m.Attachments {
+		if len(a.FileName) == 0 {
+			return errors.New(ErrFileNameIsEmpty)
+		}
+		if strings.ContainsAny(a.FileName+a.ContentType, "\r\n\"<>") {
+			return errors.New(ErrHeaderIsNotValid)
+		}
+		if a.IsInline && (len(m.HtmlBody) == 0) {
+			return errors.New(ErrInlineAttachmentNeedsHtml)
+		}
+	}
+
+	return nil
+}
+
+// Compose creates the raw message data as it is sent to an SMTP server.
+//
+// The structure of a message with all its parts is following:
+//
+//	multipart/mixed
+//	├── multipart/related
+//	│   ├── multipart/alternative
+//	│   │   ├── text/plain
+//	│   │   └── text/html
+//	│   └── inline attachments
+//	└── ordinary attachments
+//
+// Containers which would have a single part are omitted.
+func (m *Message) Compose(from string, userAgent string, date time.Time) (data []byte, err error) {
+	buf := new(bytes.Buffer)
+
+	writeHeader(buf, "From", from)
+	writeHeader(buf, "To", strings.Join(m.Recipients, ", "))
+	writeHeader(buf, "Subject", mime.QEncoding.Encode(Charset, m.Subject))
+	writeHeader(buf, "Date", date.Format(time.RFC1123Z))
+	writeHeader(buf, "MIME-Version", "1.0")
+	writeHeader(buf, "User-Agent", userAgent)
+
+	body := m.bodyPart()
+
+	keys := make([]string, 0, len(body.header))
+	for k := range body.header {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		writeHeader(buf, k, body.header.Get(k))
+	}
+	buf.WriteString("\r\n")
+
+	err = body.writeBody(buf)
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (m *Message) bodyPart() (p *part) {
+	var texts []*part
+	if len(m.PlainBody) > 0 {
+		texts = append(texts, newTextPart(ContentTypeTextPlain, m.PlainBody))
+	}
+	if len(m.HtmlBody) > 0 {
+		texts = append(texts, newTextPart(ContentTypeTextHtml, m.HtmlBody))
+	}
+
+	var inlines, ordinaries []*part
+	for _, a := range m.Attachments {
+		if a.IsInline {
+			inlines = append(inlines, newAttachmentPart(a))
+		} else {
+			ordinaries = append(ordinaries, newAttachmentPart(a))
+		}
+	}
+
+	p = newMultipartIfNeeded(MultipartAlternative, texts)
+	p = newMultipartIfNeeded(MultipartRelated, append([]*part{p}, inlines...))
+	p = newMultipartIfNeeded(MultipartMixed, append([]*part{p}, ordinaries...))
+
+	return p
+}
+
+func writeHeader(w *bytes.Buffer, key string, value string) {
+	w.WriteString(key)
+	w.WriteString(": ")
+	w.WriteString(value)
+	w.WriteString("\r\n")
+}
+
+func newTextPart(contentType string, text string) (p *part) {
+	p = &part{
+		header: textproto.MIMEHeader{},
+		writeBody: func(w io.Writer) (err error) {
+			qpw := quotedprintable.NewWriter(w)
+
+			_, err = qpw.Write([]byte(text))
+			if err != nil {
+				return err
+			}
+
+			return qpw.Close()
+		},
+	}
+
+	p.header.Set("Content-Type", mime.FormatMediaType(contentType, map[string]string{"charset": Charset}))
+	p.header.Set("Content-Transfer-Encoding", ContentTransferEncodingQP)
+
+	return p
+}
+
+func newAttachmentPart(a Attachment) (p *part) {
+	contentType := a.ContentType
+	if len(contentType) == 0 {
+		contentType = mime.TypeByExtension(filepath.Ext(a.FileName))
+	}
+	if len(contentType) == 0 {
+		contentType = ContentTypeDefault
+	}
+
+	disposition := DispositionAttachment
+	if a.IsInline {
+		disposition = DispositionInline
+	}
+
+	p = &part{
+		header:    textproto.MIMEHeader{},
+		writeBody: func(w io.Writer) error { return writeBase64(w, a.Data) },
+	}
+
+	p.header.Set("Content-Type", mime.FormatMediaType(contentType, map[string]string{"name": a.FileName}))
+	p.header.Set("Content-Transfer-Encoding", ContentTransferEncodingB64)
+	p.header.Set("Content-Disposition", mime.FormatMediaType(disposition, map[string]string{"filename": a.FileName}))
+	if a.IsInline {
+		p.header.Set("Content-ID", fmt.Sprintf("<%s>", a.FileName))
+	}
+
+	return p
+}
+
+// newMultipartIfNeeded joins the parts into a multipart container. A single
+// part is returned as is.
+func newMultipartIfNeeded(subtype string, parts []*part) (p *part) {
+	if len(parts) == 1 {
+		return parts[0]
+	}
+
+	boundary := multipart.NewWriter(io.Discard).Boundary()
+
+	p = &part{
+		header: textproto.MIMEHeader{},
+		writeBody: func(w io.Writer) (err error) {
+			mw := multipart.NewWriter(w)
+
+			err = mw.SetBoundary(boundary)
+			if err != nil {
+				return err
+			}
+
+			var pw io.Writer
+			for _, sp := range parts {
+				pw, err = mw.CreatePart(sp.header)
+				if err != nil {
+					return err
+				}
+
+				err = sp.writeBody(pw)
+				if err != nil {
+					return err
+				}
+			}
+
+			return mw.Close()
+		},
+	}
+
+	p.header.Set("Content-Type", mime.FormatMediaType("multipart/"+subtype, map[string]string{"boundary": boundary}))
+
+	return p
+}
+
+func writeBase64(w io.Writer, data []byte) (err error) {
+	s := base64.StdEncoding.EncodeToString(data)
+
+	for len(s) > 0 {
+		n := Base64LineLength
+		if len(s) < n {
+			n = len(s)
+		}
+
+		_, err = io.WriteString(w, s[:n]+"\r\n")
+		if err != nil {
+			return err
+		}
+
+		s = s[n:]
+	}
+
+	return nil
+}
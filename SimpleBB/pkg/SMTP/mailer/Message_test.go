@@ -0,0 +1,131 @@
+package mailer
+
+import (
+	"bytes"
+	"encoding/base64"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/vault-thirteen/auxie/tester"
+)
+
+func Test_Message_Compose(t *testing.T) {
+	aTest := tester.New(t)
+
+	msg := &Message{
+		Recipients: []string{"user@example.org"},
+		Subject:    "Проверка",
+		PlainBody:  "Hello.",
+		HtmlBody:   `<p>Hello.</p><img src="cid:logo.png">`,
+		Attachments: []Attachment{
+			{FileName: "logo.png", IsInline: true, Data: []byte{1, 2, 3}},
+			{FileName: "rules.txt", Data: []byte("Be nice.")},
+		},
+	}
+	aTest.MustBeNoError(msg.Check())
+
+	data, err := msg.Compose("robot@example.org", "Test", time.Now())
+	aTest.MustBeNoError(err)
+
+	m, err := mail.ReadMessage(bytes.NewReader(data))
+	aTest.MustBeNoError(err)
+	subject, err := new(mime.WordDecoder).DecodeHeader(m.Header.Get("Subject"))
+	aTest.MustBeNoError(err)
+	aTest.MustBeEqual(subject, "Проверка")
+
+	// Mixed: related and the ordinary attachment.
+	mixed := readParts(t, m.Header.Get("Content-Type"), m.Body)
+	aTest.MustBeEqual(len(mixed), 2)
+	aTest.MustBeEqual(mixed[1].disposition, "attachment")
+	aTest.MustBeEqual(mixed[1].body, "Be nice.")
+
+	// Related: alternative and the inline image.
+	related := readParts(t, mixed[0].contentType, strings.NewReader(mixed[0].rawBody))
+	aTest.MustBeEqual(len(related), 2)
+	aTest.MustBeEqual(related[1].contentId, "<logo.png>")
+	aTest.MustBeEqual(related[1].body, string([]byte{1, 2, 3}))
+
+	// Alternative: plain text and HTML.
+	alternative := readParts(t, related[0].contentType, strings.NewReader(related[0].rawBody))
+	aTest.MustBeEqual(len(alternative), 2)
+	aTest.MustBeEqual(alternative[0].body, "Hello.")
+	aTest.MustBeEqual(alternative[1].body, `<p>Hello.</p><img src="cid:logo.png">`)
+}
+
+func Test_Message_Check(t *testing.T) {
+	aTest := tester.New(t)
+
+	msg := &Message{Recipients: []string{"user@example.org"}, Subject: "S"}
+	aTest.MustBeAnError(msg.Check())
+
+	msg.PlainBody = "Text"
+	aTest.MustBeNoError(msg.Check())
+
+	msg.Subject = "S\r\nBcc: victim@example.org"
+	aTest.MustBeAnError(msg.Check())
+
+	msg.Subject = "S"
+	msg.Attachments = []Attachment{{FileName: "a.png", IsInline: true}}
+	aTest.MustBeAnError(msg.Check())
+}
+
+type testPart struct {
+	contentType string
+	disposition string
+	contentId   string
+	rawBody     string
+	body        string
+}
+
+func readParts(t *testing.T, contentType string, r io.Reader) (parts []testPart) {
+	_, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mr := multipart.NewReader(r, params["boundary"])
+	for {
+		p, err := mr.NextRawPart()
+		if err == io.EOF {
+			return parts
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		raw, err := io.ReadAll(p)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		tp := testPart{
+			contentType: p.Header.Get("Content-Type"),
+			contentId:   p.Header.Get("Content-ID"),
+			rawBody:     string(raw),
+		}
+		tp.disposition, _, _ = mime.ParseMediaType(p.Header.Get("Content-Disposition"))
+
+		switch p.Header.Get("Content-Transfer-Encoding") {
+		case ContentTransferEncodingB64:
+			b, err := base64.StdEncoding.DecodeString(strings.ReplaceAll(tp.rawBody, "\r\n", ""))
+			if err != nil {
+				t.Fatal(err)
+			}
+			tp.body = string(b)
+		case ContentTransferEncodingQP:
+			b, err := io.ReadAll(quotedprintable.NewReader(strings.NewReader(tp.rawBody)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			tp.body = string(b)
+		}
+
+		parts = append(parts, tp)
+	}
+}
@@ -0,0 +1,525 @@
+package outbox
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/vault-thirteen/SimpleBB/pkg/SMTP/mailer"
+	"github.com/vault-thirteen/SimpleBB/pkg/common/models/avm"
+	server2 "github.com/vault-thirteen/SimpleBB/pkg/common/models/server"
+)
+
+const (
+	ErrFolderIsNotSet        = "folder is not set"
+	ErrSenderIsNotSet        = "sender is not set"
+	ErrMaxAttemptsIsNotSet   = "maximal number of attempts is not set"
+	ErrRetryDelayIsNotValid  = "retry delay is not valid"
+	ErrCheckIntervalIsNotSet = "check interval is not set"
+	ErrItemIdIsNotValid      = "item ID is not valid"
+	ErrItemIsNotFound        = "item is not found"
+)
+
+const (
+	// Names of sub-folders. Messages waiting for delivery are stored in the
+	// queue folder, messages which could not be delivered are stored in the
+	// dead-letter folder.
+	QueueFolderName = "queue"
+	DeadFolderName  = "dead"
+
+	ItemFileExt  = ".json"
+	TempFileExt  = ".tmp"
+	FolderMode   = 0700
+	ItemFileMode = 0600
+
+	// Size of random part of an item ID in bytes.
+	ItemIdRandomPartSize = 4
+)
+
+// ISender is an object which delivers messages, i.e. a mailer.
+type ISender interface {
+	Send(msg *mailer.Message) (err error)
+}
+
+// Item is a message stored in the outbox.
+type Item struct {
+	Id              string          `json:"id"`
+	Message         *mailer.Message `json:"message"`
+	CreationTime    time.Time       `json:"creationTime"`
+	AttemptsCount   int             `json:"attemptsCount"`
+	NextAttemptTime time.Time       `json:"nextAttemptTime"`
+	LastError       string          `json:"lastError"`
+}
+
+// Outbox is a durable queue of e-mail messages. Every message is stored on
+// disk before it is sent, so that messages survive restarts of the server and
+// failures of an SMTP relay. Failed deliveries are retried with an exponential
+// back-off. After the last attempt a message is moved to the dead-letter
+// folder, where it stays until an administrator re-queues it.
+type Outbox struct {
+	ssp    *avm.SSP
+	wg     *sync.WaitGroup
+	guard  sync.Mutex
+	sender ISender
+
+	queueFolder string
+	deadFolder  string
+
+	maxAttempts   int
+	retryDelayMin time.Duration
+	retryDelayMax time.Duration
+	checkInterval time.Duration
+
+	wakeUp chan bool
+	stop   chan bool
+}
+
+func NewOutbox(
+	folder string,
+	sender ISender,
+	maxAttempts int,
+	retryDelayMin time.Duration,
+	retryDelayMax time.Duration,
+	checkInterval time.Duration,
+) (ob *Outbox, err error) {
+	if len(folder) == 0 {
+		return nil, errors.New(ErrFolderIsNotSet)
+	}
+	if sender == nil {
+		return nil, errors.New(ErrSenderIsNotSet)
+	}
+	if maxAttempts <= 0 {
+		return nil, errors.New(ErrMaxAttemptsIsNotSet)
+	}
+	if (retryDelayMin <= 0) || (retryDelayMax < retryDelayMin) {
+		return nil, errors.New(ErrRetryDelayIsNotValid)
+	}
+	if checkInterval <= 0 {
+		return nil, errors.New(ErrCheckIntervalIsNotSet)
+	}
+
+	ob = &Outbox{
+		ssp:           avm.NewSSP(),
+		wg:            new(sync.WaitGroup),
+		sender:        sender,
+		queueFolder:   filepath.Join(folder, QueueFolderName),
+		deadFolder:    filepath.Join(folder, DeadFolderName),
+		maxAttempts:   maxAttempts,
+		retryDelayMin: retryDelayMin,
+		retryDelayMax: retryDelayMax,
+		checkInterval: checkInterval,
+		wakeUp:        make(chan bool, 1),
+		stop:          make(chan bool),
+	}
+
+	for _, f := range []string{ob.queueFolder, ob.deadFolder} {
+		err = os.MkdirAll(f, FolderMode)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return ob, nil
+}
+
+// Start starts the outbox. Messages left in the queue by a previous run are
+// sent again.
+func (ob *Outbox) Start() (err error) {
+	ob.ssp.Lock()
+	defer ob.ssp.Unlock()
+
+	err = ob.ssp.BeginStart()
+	if err != nil {
+		return err
+	}
+
+	ob.wg.Add(1)
+	go ob.run()
+
+	ob.ssp.CompleteStart()
+
+	return nil
+}
+
+// run is the main work loop of the outbox.
+func (ob *Outbox) run() {
+	defer ob.wg.Done()
+
+	ticker := time.NewTicker(ob.checkInterval)
+	defer ticker.Stop()
+
+	for {
+		ob.processQueue()
+
+		select {
+		case <-ob.stop:
+			log.Println(server2.MsgOutboxHasStopped)
+			return
+		case <-ob.wakeUp:
+		case <-ticker.C:
+		}
+	}
+}
+
+// Stop stops the outbox. Messages which have not been sent stay on disk.
+func (ob *Outbox) Stop() (err error) {
+	ob.ssp.Lock()
+	defer ob.ssp.Unlock()
+
+	err = ob.ssp.BeginStop()
+	if err != nil {
+		return err
+	}
+
+	close(ob.stop)
+	ob.wg.Wait()
+
+	ob.ssp.CompleteStop()
+
+	return nil
+}
+
+// Enqueue stores the message in the queue and returns its ID. When this
+// method returns without an error, the message is not lost even if the
+// server is stopped.
+func (ob *Outbox) Enqueue(msg *mailer.Message) (id string, err error) {
+	err = msg.Check()
+	if err != nil {
+		return "", err
+	}
+
+	id, err = newItemId()
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+	item := &Item{
+		Id:              id,
+		Message:         msg,
+		CreationTime:    now,
+		NextAttemptTime: now,
+	}
+
+	ob.guard.Lock()
+	err = saveItem(ob.queueFolder, item)
+	ob.guard.Unlock()
+	if err != nil {
+		return "", err
+	}
+
+	// Wake the worker up without waiting for the next check.
+	select {
+	case ob.wakeUp <- true:
+	default:
+	}
+
+	return id, nil
+}
+
+// ListDeadItems returns messages which could not be delivered.
+func (ob *Outbox) ListDeadItems() (items []*Item, err error) {
+	ob.guard.Lock()
+	defer ob.guard.Unlock()
+
+	var ids []string
+	ids, err = listItemIds(ob.deadFolder)
+	if err != nil {
+		return nil, err
+	}
+
+	items = make([]*Item, 0, len(ids))
+	var item *Item
+	for _, id := range ids {
+		item, err = loadItem(ob.deadFolder, id)
+		if err != nil {
+			// Broken files are listed too, so that they are not forgotten.
+			item = &Item{Id: id, LastError: err.Error()}
+		}
+
+		items = append(items, item)
+	}
+
+	return items, nil
+}
+
+// RequeueDeadItem moves a dead message back to the queue, so that it is sent
+// again with a fresh number of attempts.
+func (ob *Outbox) RequeueDeadItem(id string) (err error) {
+	if !isValidItemId(id) {
+		return errors.New(ErrItemIdIsNotValid)
+	}
+
+	ob.guard.Lock()
+	defer ob.guard.Unlock()
+
+	var item *Item
+	item, err = loadItem(ob.deadFolder, id)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return errors.New(ErrItemIsNotFound)
+		}
+		return err
+	}
+
+	item.AttemptsCount = 0
+	item.NextAttemptTime = time.Now()
+
+	err = saveItem(ob.queueFolder, item)
+	if err != nil {
+		return err
+	}
+
+	err = os.Remove(itemFilePath(ob.deadFolder, id))
+	if err != nil {
+		return err
+	}
+
+	select {
+	case ob.wakeUp <- true:
+	default:
+	}
+
+	return nil
+}
+
+// CountItems counts queued and dead messages.
+func (ob *Outbox) CountItems() (queued int, dead int, err error) {
+	ob.guard.Lock()
+	defer ob.guard.Unlock()
+
+	var ids []string
+	ids, err = listItemIds(ob.queueFolder)
+	if err != nil {
+		return 0, 0, err
+	}
+	queued = len(ids)
+
+	ids, err = listItemIds(ob.deadFolder)
+	if err != nil {
+		return 0, 0, err
+	}
+	dead = len(ids)
+
+	return queued, dead, nil
+}
+
+// processQueue tries to send all the messages whose time has come. Messages
+// are processed in the order of their creation.
+func (ob *Outbox) processQueue() {
+	ob.guard.Lock()
+	ids, err := listItemIds(ob.queueFolder)
+	ob.guard.Unlock()
+	if err != nil {
+		log.Println(err)
+		return
+	}
+
+	for _, id := range ids {
+		select {
+		case <-ob.stop:
+			return
+		default:
+		}
+
+		ob.processItem(id)
+	}
+}
+
+func (ob *Outbox) processItem(id string) {
+	ob.guard.Lock()
+	item, err := loadItem(ob.queueFolder, id)
+	ob.guard.Unlock()
+	if err != nil {
+		// A broken file must not block the queue.
+		log.Println(err)
+		ob.moveBrokenItem(id)
+		return
+	}
+
+	if item.NextAttemptTime.After(time.Now()) {
+		return
+	}
+
+	// Sending is done without the lock, since it may take a lot of time.
+	err = ob.sender.Send(item.Message)
+
+	ob.guard.Lock()
+	defer ob.guard.Unlock()
+
+	if err == nil {
+		err = os.Remove(itemFilePath(ob.queueFolder, id))
+		if err != nil {
+			log.Println(err)
+		}
+		return
+	}
+
+	item.AttemptsCount++
+	item.LastError = err.Error()
+
+	if item.AttemptsCount < ob.maxAttempts {
+		item.NextAttemptTime = time.Now().Add(ob.retryDelay(item.AttemptsCount))
+
+		err = saveItem(ob.queueFolder, item)
+		if err != nil {
+			log.Println(err)
+		}
+		return
+	}
+
+	log.Println(fmt.Sprintf(server2.MsgFMessageIsDead, id, item.LastError))
+
+	err = saveItem(ob.deadFolder, item)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+
+	err = os.Remove(itemFilePath(ob.queueFolder, id))
+	if err != nil {
+		log.Println(err)
+	}
+}
+
+// moveBrokenItem moves a file which can not be read to the dead-letter
+// folder, where it may be inspected manually.
+func (ob *Outbox) moveBrokenItem(id string) {
+	ob.guard.Lock()
+	defer ob.guard.Unlock()
+
+	err := os.Rename(itemFilePath(ob.queueFolder, id), itemFilePath(ob.deadFolder, id))
+	if err != nil {
+		log.Println(err)
+	}
+}
+
+// retryDelay returns a delay before the next attempt. The delay is doubled
+// after each failed attempt until it reaches the maximum.
+func (ob *Outbox) retryDelay(attemptsCount int) (delay time.Duration) {
+	delay = ob.retryDelayMin
+
+	for i := 1; i < attemptsCount; i++ {
+		delay = delay * 2
+		if delay >= ob.retryDelayMax {
+			return ob.retryDelayMax
+		}
+	}
+
+	return delay
+}
+
+// newItemId creates a unique ID. IDs start with the creation time, so that
+// sorted IDs follow the order of creation.
+func newItemId() (id string, err error) {
+	buf := make([]byte, ItemIdRandomPartSize)
+
+	_, err = rand.Read(buf)
+	if err != nil {
+		return "", err
+	}
+
+	return strconv.FormatInt(time.Now().UnixNano(), 10) + "-" + hex.EncodeToString(buf), nil
+}
+
+// isValidItemId checks the ID received from outside, so that it can not be
+// used to access files outside the outbox.
+func isValidItemId(id string) bool {
+	if len(id) == 0 {
+		return false
+	}
+
+	for _, r := range id {
+		if !(((r >= '0') && (r <= '9')) || ((r >= 'a') && (r <= 'f')) || (r == '-')) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func itemFilePath(folder string, id string) string {
+	return filepath.Join(folder, id+ItemFileExt)
+}
+
+func listItemIds(folder string) (ids []string, err error) {
+	var entries []os.DirEntry
+	entries, err = os.ReadDir(folder)
+	if err != nil {
+		return nil, err
+	}
+
+	ids = make([]string, 0, len(entries))
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ItemFileExt) {
+			continue
+		}
+
+		ids = append(ids, strings.TrimSuffix(e.Name(), ItemFileExt))
+	}
+
+	sort.Strings(ids)
+
+	return ids, nil
+}
+
+func loadItem(folder string, id string) (item *Item, err error) {
+	var buf []byte
+	buf, err = os.ReadFile(itemFilePath(folder, id))
+	if err != nil {
+		return nil, err
+	}
+
+	item = &Item{}
+	err = json.Unmarshal(buf, item)
+	if err != nil {
+		return nil, err
+	}
+
+	return item, nil
+}
+
+// saveItem writes the item into a temporary file which then replaces the
+// item's file, so that a crash never leaves a half-written item.
+func saveItem(folder string, item *Item) (err error) {
+	var buf []byte
+	buf, err = json.Marshal(item)
+	if err != nil {
+		return err
+	}
+
+	filePath := itemFilePath(folder, item.Id)
+	tmpFilePath := filePath + TempFileExt
+
+	var f *os.File
+	f, err = os.OpenFile(tmpFilePath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, ItemFileMode)
+	if err != nil {
+		return err
+	}
+
+	_, err = f.Write(buf)
+	if err == nil {
+		err = f.Sync()
+	}
+
+	cerr := f.Close()
+	if err == nil {
+		err = cerr
+	}
+	if err != nil {
+		_ = os.Remove(tmpFilePath)
+		return err
+	}
+
+	return os.Rename(tmpFilePath, filePath)
+}
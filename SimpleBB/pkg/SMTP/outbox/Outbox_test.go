@@ -0,0 +1,139 @@
+package outbox
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/vault-thirteen/SimpleBB/pkg/SMTP/mailer"
+	"github.com/vault-thirteen/auxie/tester"
+)
+
+// fakeSender fails the specified number of times and then succeeds.
+type fakeSender struct {
+	guard      sync.Mutex
+	failsCount int
+	attempts   int
+	sent       []*mailer.Message
+}
+
+func (s *fakeSender) Send(msg *mailer.Message) (err error) {
+	s.guard.Lock()
+	defer s.guard.Unlock()
+
+	s.attempts++
+	if s.attempts <= s.failsCount {
+		return errors.New("relay is not available")
+	}
+
+	s.sent = append(s.sent, msg)
+	return nil
+}
+
+func (s *fakeSender) sentCount() int {
+	s.guard.Lock()
+	defer s.guard.Unlock()
+
+	return len(s.sent)
+}
+
+func newTestMessage() *mailer.Message {
+	return &mailer.Message{
+		Recipients: []string{"user@example.org"},
+		Subject:    "Code",
+		PlainBody:  "123",
+	}
+}
+
+func waitFor(t *testing.T, condition func() bool) {
+	deadline := time.Now().Add(5 * time.Second)
+	for !condition() {
+		if time.Now().After(deadline) {
+			t.Fatal("timeout")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func Test_Outbox_Retry(t *testing.T) {
+	aTest := tester.New(t)
+	sender := &fakeSender{failsCount: 2}
+
+	ob, err := NewOutbox(t.TempDir(), sender, 5, 10*time.Millisecond, 20*time.Millisecond, 5*time.Millisecond)
+	aTest.MustBeNoError(err)
+	aTest.MustBeNoError(ob.Start())
+	defer ob.Stop()
+
+	_, err = ob.Enqueue(newTestMessage())
+	aTest.MustBeNoError(err)
+
+	waitFor(t, func() bool { return sender.sentCount() == 1 })
+
+	queued, dead, err := ob.CountItems()
+	aTest.MustBeNoError(err)
+	aTest.MustBeEqual(queued, 0)
+	aTest.MustBeEqual(dead, 0)
+}
+
+func Test_Outbox_DeadLetters(t *testing.T) {
+	aTest := tester.New(t)
+	sender := &fakeSender{failsCount: 3}
+
+	ob, err := NewOutbox(t.TempDir(), sender, 3, time.Millisecond, time.Millisecond, 5*time.Millisecond)
+	aTest.MustBeNoError(err)
+	aTest.MustBeNoError(ob.Start())
+	defer ob.Stop()
+
+	id, err := ob.Enqueue(newTestMessage())
+	aTest.MustBeNoError(err)
+
+	var items []*Item
+	waitFor(t, func() bool {
+		items, err = ob.ListDeadItems()
+		return (err == nil) && (len(items) == 1)
+	})
+	aTest.MustBeEqual(items[0].Id, id)
+	aTest.MustBeEqual(items[0].AttemptsCount, 3)
+	aTest.MustBeEqual(items[0].LastError, "relay is not available")
+
+	// The relay is back.
+	aTest.MustBeNoError(ob.RequeueDeadItem(id))
+	waitFor(t, func() bool { return sender.sentCount() == 1 })
+
+	aTest.MustBeAnError(ob.RequeueDeadItem(id))
+	aTest.MustBeAnError(ob.RequeueDeadItem("../queue/x"))
+}
+
+func Test_Outbox_Persistence(t *testing.T) {
+	aTest := tester.New(t)
+	folder := t.TempDir()
+
+	// Messages are stored even when the outbox is not running.
+	ob, err := NewOutbox(folder, &fakeSender{}, 3, time.Millisecond, time.Millisecond, time.Hour)
+	aTest.MustBeNoError(err)
+	_, err = ob.Enqueue(newTestMessage())
+	aTest.MustBeNoError(err)
+	_, err = ob.Enqueue(newTestMessage())
+	aTest.MustBeNoError(err)
+
+	// Another instance sends them on start.
+	sender := &fakeSender{}
+	ob, err = NewOutbox(folder, sender, 3, time.Millisecond, time.Millisecond, time.Hour)
+	aTest.MustBeNoError(err)
+	aTest.MustBeNoError(ob.Start())
+	waitFor(t, func() bool { return sender.sentCount() == 2 })
+	aTest.MustBeNoError(ob.Stop())
+}
+
+func Test_Outbox_RetryDelay(t *testing.T) {
+	aTest := tester.New(t)
+
+	ob, err := NewOutbox(t.TempDir(), &fakeSender{}, 10, time.Second, 5*time.Second, time.Second)
+	aTest.MustBeNoError(err)
+	aTest.MustBeEqual(ob.retryDelay(1), time.Second)
+	aTest.MustBeEqual(ob.retryDelay(2), 2*time.Second)
+	aTest.MustBeEqual(ob.retryDelay(3), 4*time.Second)
+	aTest.MustBeEqual(ob.retryDelay(4), 5*time.Second)
+	aTest.MustBeEqual(ob.retryDelay(100), 5*time.Second)
+}
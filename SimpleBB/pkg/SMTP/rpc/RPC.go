@@ -1,6 +1,8 @@
 package rpc
 
 import (
+	"github.com/vault-thirteen/SimpleBB/pkg/SMTP/mailer"
+	"github.com/vault-thirteen/SimpleBB/pkg/SMTP/outbox"
 	cmb "github.com/vault-thirteen/SimpleBB/pkg/common/models/base"
 	rpc2 "github.com/vault-thirteen/SimpleBB/pkg/common/models/rpc"
 	cm "github.com/vault-thirteen/SimpleBB/pkg/common/models/simple"
@@ -16,11 +18,31 @@ type PingResult = rpc2.PingResult
 type SendMessageParams struct {
 	Recipient cm.Email `json:"recipient"`
 	Subject   cmb.Text `json:"subject"`
-	Message   cmb.Text `json:"message"`
+
+	// Plain text and HTML versions of the message. At least one of them must
+	// be set.
+	Message     cmb.Text `json:"message"`
+	HtmlMessage cmb.Text `json:"htmlMessage"`
+
+	Attachments []mailer.Attachment `json:"attachments"`
 }
 type SendMessageResult struct {
 	rpc2.CommonResult
+
+	// ID of the message in the outbox. The message is sent in background.
+	MessageId cmb.Text `json:"messageId"`
+}
+
+type ListDeadMessagesParams struct{}
+type ListDeadMessagesResult struct {
+	rpc2.CommonResult
+	Messages []*outbox.Item `json:"messages"`
+}
+
+type RequeueDeadMessageParams struct {
+	MessageId cmb.Text `json:"messageId"`
 }
+type RequeueDeadMessageResult = rpc2.CommonResultWithSuccess
 
 // Other.
 
@@ -28,4 +50,6 @@ type ShowDiagnosticDataParams struct{}
 type ShowDiagnosticDataResult struct {
 	rpc2.CommonResult
 	rpc2.RequestsCount
+	QueuedMessagesCount cmb.Count `json:"queuedMessagesCount"`
+	DeadMessagesCount   cmb.Count `json:"deadMessagesCount"`
 }
@@ -30,6 +30,8 @@ func (srv *Server) initRpc() (err error) {
 	fns := []jrm1.RpcFunction{
 		srv.Ping,
 		srv.SendMessage,
+		srv.ListDeadMessages,
+		srv.RequeueDeadMessage,
 		srv.ShowDiagnosticData,
 	}
 
@@ -72,6 +74,38 @@ func (srv *Server) SendMessage(params *json.RawMessage, _ *jrm1.ResponseMetaData
 	return r, nil
 }
 
+func (srv *Server) ListDeadMessages(params *json.RawMessage, _ *jrm1.ResponseMetaData) (result any, re *jrm1.RpcError) {
+	var p *sm.ListDeadMessagesParams
+	re = jrm1.ParseParameters(params, &p)
+	if re != nil {
+		return nil, re
+	}
+
+	var r *sm.ListDeadMessagesResult
+	r, re = srv.listDeadMessages(p)
+	if re != nil {
+		return nil, re
+	}
+
+	return r, nil
+}
+
+func (srv *Server) RequeueDeadMessage(params *json.RawMessage, _ *jrm1.ResponseMetaData) (result any, re *jrm1.RpcError) {
+	var p *sm.RequeueDeadMessageParams
+	re = jrm1.ParseParameters(params, &p)
+	if re != nil {
+		return nil, re
+	}
+
+	var r *sm.RequeueDeadMessageResult
+	r, re = srv.requeueDeadMessage(p)
+	if re != nil {
+		return nil, re
+	}
+
+	return r, nil
+}
+
 // Other.
 
 func (srv *Server) ShowDiagnosticData(params *json.RawMessage, _ *jrm1.ResponseMetaData) (result any, re *jrm1.RpcError) {
@@ -10,6 +10,10 @@ const (
 	RpcErrorCode_RecipientIsNotSet = 2
 	RpcErrorCode_SubjectIsNotSet   = 3
 	RpcErrorCode_MessageIsNotSet   = 4
+	RpcErrorCode_MessageIsInvalid  = 5
+	RpcErrorCode_OutboxError       = 6
+	RpcErrorCode_MessageIdIsNotSet = 7
+	RpcErrorCode_MessageIsNotFound = 8
 )
 
 // Messages.
@@ -18,4 +22,8 @@ const (
 	RpcErrorMsg_RecipientIsNotSet = "recipient is not set"
 	RpcErrorMsg_SubjectIsNotSet   = "subject is not set"
 	RpcErrorMsg_MessageIsNotSet   = "message is not set"
+	RpcErrorMsgF_MessageIsInvalid = "message is invalid: %s"
+	RpcErrorMsgF_OutboxError      = "outbox error: %s"
+	RpcErrorMsg_MessageIdIsNotSet = "message ID is not set"
+	RpcErrorMsg_MessageIsNotFound = "message is not found"
 )
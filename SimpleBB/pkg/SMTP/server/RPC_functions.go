@@ -3,6 +3,8 @@ package server
 import (
 	"fmt"
 	jrm1 "github.com/vault-thirteen/JSON-RPC-M1"
+	"github.com/vault-thirteen/SimpleBB/pkg/SMTP/mailer"
+	"github.com/vault-thirteen/SimpleBB/pkg/SMTP/outbox"
 	sm "github.com/vault-thirteen/SimpleBB/pkg/SMTP/rpc"
 	cmb "github.com/vault-thirteen/SimpleBB/pkg/common/models/base"
 	cmr "github.com/vault-thirteen/SimpleBB/pkg/common/models/rpc"
@@ -18,20 +20,74 @@ func (srv *Server) sendMessage(p *sm.SendMessageParams) (result *sm.SendMessageR
 	if len(p.Subject) == 0 {
 		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_SubjectIsNotSet, RpcErrorMsg_SubjectIsNotSet, nil)
 	}
-	if len(p.Message) == 0 {
+	if (len(p.Message) == 0) && (len(p.HtmlMessage) == 0) {
 		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_MessageIsNotSet, RpcErrorMsg_MessageIsNotSet, nil)
 	}
 
-	srv.mailerGuard.Lock()
-	defer srv.mailerGuard.Unlock()
+	msg := &mailer.Message{
+		Recipients:  []string{p.Recipient.ToString()},
+		Subject:     p.Subject.ToString(),
+		PlainBody:   p.Message.ToString(),
+		HtmlBody:    p.HtmlMessage.ToString(),
+		Attachments: p.Attachments,
+	}
+
+	err := msg.Check()
+	if err != nil {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_MessageIsInvalid, fmt.Sprintf(RpcErrorMsgF_MessageIsInvalid, err.Error()), nil)
+	}
+
+	// The message is stored in the outbox and is sent in background, so that
+	// a temporary failure of the SMTP server does not lose it.
+	var messageId string
+	messageId, err = srv.outbox.Enqueue(msg)
+	if err != nil {
+		srv.logError(err)
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_OutboxError, fmt.Sprintf(RpcErrorMsgF_OutboxError, err.Error()), err)
+	}
+
+	result = &sm.SendMessageResult{
+		MessageId: cmb.Text(messageId),
+	}
+
+	return result, nil
+}
+
+func (srv *Server) listDeadMessages(_ *sm.ListDeadMessagesParams) (result *sm.ListDeadMessagesResult, re *jrm1.RpcError) {
+	items, err := srv.outbox.ListDeadItems()
+	if err != nil {
+		srv.logError(err)
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_OutboxError, fmt.Sprintf(RpcErrorMsgF_OutboxError, err.Error()), err)
+	}
+
+	result = &sm.ListDeadMessagesResult{
+		Messages: items,
+	}
+
+	return result, nil
+}
+
+func (srv *Server) requeueDeadMessage(p *sm.RequeueDeadMessageParams) (result *sm.RequeueDeadMessageResult, re *jrm1.RpcError) {
+	// Check parameters.
+	if len(p.MessageId) == 0 {
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_MessageIdIsNotSet, RpcErrorMsg_MessageIdIsNotSet, nil)
+	}
 
-	err := srv.mailer.SendMail([]string{p.Recipient.ToString()}, p.Subject.ToString(), p.Message.ToString())
+	err := srv.outbox.RequeueDeadItem(p.MessageId.ToString())
 	if err != nil {
+		if (err.Error() == outbox.ErrItemIsNotFound) || (err.Error() == outbox.ErrItemIdIsNotValid) {
+			return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_MessageIsNotFound, RpcErrorMsg_MessageIsNotFound, nil)
+		}
+
 		srv.logError(err)
-		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_MailerError, fmt.Sprintf(RpcErrorMsgF_MailerError, err.Error()), err)
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_OutboxError, fmt.Sprintf(RpcErrorMsgF_OutboxError, err.Error()), err)
 	}
 
-	result = &sm.SendMessageResult{}
+	result = &sm.RequeueDeadMessageResult{
+		Success: cmr.Success{
+			OK: true,
+		},
+	}
 
 	return result, nil
 }
@@ -39,11 +95,19 @@ func (srv *Server) sendMessage(p *sm.SendMessageParams) (result *sm.SendMessageR
 func (srv *Server) showDiagnosticData() (result *sm.ShowDiagnosticDataResult, re *jrm1.RpcError) {
 	trc, src := srv.js.GetRequestsCount()
 
+	queued, dead, err := srv.outbox.CountItems()
+	if err != nil {
+		srv.logError(err)
+		return nil, jrm1.NewRpcErrorByUser(RpcErrorCode_OutboxError, fmt.Sprintf(RpcErrorMsgF_OutboxError, err.Error()), err)
+	}
+
 	result = &sm.ShowDiagnosticDataResult{
 		RequestsCount: cmr.RequestsCount{
 			TotalRequestsCount:      cmb.Text(trc),
 			SuccessfulRequestsCount: cmb.Text(src),
 		},
+		QueuedMessagesCount: cmb.Count(queued),
+		DeadMessagesCount:   cmb.Count(dead),
 	}
 
 	return result, nil
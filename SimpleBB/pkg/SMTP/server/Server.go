@@ -16,6 +16,7 @@ import (
 
 	jrm1 "github.com/vault-thirteen/JSON-RPC-M1"
 	mailer "github.com/vault-thirteen/SimpleBB/pkg/SMTP/mailer"
+	"github.com/vault-thirteen/SimpleBB/pkg/SMTP/outbox"
 	ss "github.com/vault-thirteen/SimpleBB/pkg/SMTP/settings"
 )
 
@@ -37,9 +38,9 @@ type Server struct {
 	httpErrors  chan error
 	ssp         *avm.SSP
 
-	// Mailer.
-	mailer      *mailer.Mailer
-	mailerGuard sync.Mutex
+	// Mailer and the queue of messages waiting for it.
+	mailer *mailer.Mailer
+	outbox *outbox.Outbox
 
 	// JSON-RPC server.
 	js *jrm1.Processor
@@ -75,6 +76,11 @@ func NewServer(s base.ISettings) (srv *Server, err error) {
 		return nil, err
 	}
 
+	err = srv.initOutbox()
+	if err != nil {
+		return nil, err
+	}
+
 	// HTTP Server.
 	srv.httpServer = &http.Server{
 		Addr:    srv.listenDsn,
@@ -101,6 +107,11 @@ func (srv *Server) Start() (err error) {
 		return err
 	}
 
+	err = srv.outbox.Start()
+	if err != nil {
+		return err
+	}
+
 	srv.startHttpServer()
 
 	srv.subRoutines.Add(1)
@@ -131,6 +142,11 @@ func (srv *Server) Stop() (err error) {
 
 	close(srv.httpErrors)
 
+	err = srv.outbox.Stop()
+	if err != nil {
+		return err
+	}
+
 	srv.subRoutines.Wait()
 
 	srv.ssp.CompleteStop()
@@ -179,6 +195,26 @@ func (srv *Server) initMailer() (err error) {
 		srv.settings.SmtpSettings.User,
 		srv.settings.SmtpSettings.Password,
 		srv.settings.SmtpSettings.UserAgent,
+		srv.settings.SmtpSettings.TlsMode,
+		time.Duration(srv.settings.SmtpSettings.TimeoutSec)*time.Second,
+	)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (srv *Server) initOutbox() (err error) {
+	obs := srv.settings.OutboxSettings
+
+	srv.outbox, err = outbox.NewOutbox(
+		obs.Folder,
+		srv.mailer,
+		obs.MaxAttempts,
+		time.Duration(obs.RetryDelayMinSec)*time.Second,
+		time.Duration(obs.RetryDelayMaxSec)*time.Second,
+		time.Duration(obs.CheckIntervalSec)*time.Second,
 	)
 	if err != nil {
 		return err
@@ -0,0 +1,37 @@
+package settings
+
+import (
+	"errors"
+	c "github.com/vault-thirteen/SimpleBB/pkg/common/models/server"
+)
+
+// OutboxSettings are parameters of the outbox, i.e. of the queue of messages
+// waiting for delivery.
+type OutboxSettings struct {
+	// Folder where messages are stored.
+	Folder string `json:"folder"`
+
+	// Number of delivery attempts after which a message is moved to the list
+	// of dead letters.
+	MaxAttempts int `json:"maxAttempts"`
+
+	// Delay before the second attempt. It is doubled after each failure until
+	// it reaches the maximal delay.
+	RetryDelayMinSec uint `json:"retryDelayMinSec"`
+	RetryDelayMaxSec uint `json:"retryDelayMaxSec"`
+
+	// Interval of checking the queue for messages to be retried.
+	CheckIntervalSec uint `json:"checkIntervalSec"`
+}
+
+func (s OutboxSettings) Check() (err error) {
+	if (len(s.Folder) == 0) ||
+		(s.MaxAttempts <= 0) ||
+		(s.RetryDelayMinSec == 0) ||
+		(s.RetryDelayMaxSec < s.RetryDelayMinSec) ||
+		(s.CheckIntervalSec == 0) {
+		return errors.New(c.MsgOutboxSettingError)
+	}
+
+	return nil
+}
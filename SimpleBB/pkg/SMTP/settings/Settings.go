@@ -22,6 +22,7 @@ type Settings struct {
 	HttpSettings   `json:"http"`
 	SystemSettings `json:"system"`
 	SmtpSettings   `json:"smtp"`
+	OutboxSettings `json:"outbox"`
 }
 
 func NewSettingsFromFile(filePath string, versionInfo *ver.Versioneer) (stn *Settings, err error) {
@@ -80,6 +81,12 @@ func (stn *Settings) Check() (err error) {
 		return err
 	}
 
+	// Outbox.
+	err = stn.OutboxSettings.Check()
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -2,6 +2,7 @@ package settings
 
 import (
 	"errors"
+	"github.com/vault-thirteen/SimpleBB/pkg/SMTP/mailer"
 	c "github.com/vault-thirteen/SimpleBB/pkg/common/models/server"
 )
 
@@ -13,13 +14,23 @@ type SmtpSettings struct {
 	User      string `json:"user"`
 	Password  string `json:"password"`
 	UserAgent string `json:"userAgent"`
+
+	// Security of the connection: 'implicit' for TLS from the beginning of a
+	// connection, 'starttls' for an upgrade of a plain connection, 'none' for
+	// local servers only.
+	TlsMode string `json:"tlsMode"`
+
+	// Time limit of a whole SMTP session.
+	TimeoutSec uint `json:"timeoutSec"`
 }
 
 func (s SmtpSettings) Check() (err error) {
 	if (len(s.Host) == 0) ||
 		(s.Port == 0) ||
 		(len(s.User) == 0) ||
-		(len(s.UserAgent) == 0) {
+		(len(s.UserAgent) == 0) ||
+		(!mailer.IsValidTlsMode(s.TlsMode)) ||
+		(s.TimeoutSec == 0) {
 		return errors.New(c.MsgSmtpSettingError)
 	}
 
@@ -16,6 +16,8 @@ type IForum interface {
 	GetNamePtr() (name *cms.Name)
 	GetThreadsPtr() (threads **ul.UidList)
 	GetThreads() (threads *ul.UidList)
+	GetIsReadOnlyPtr() (isReadOnly *cmb.Flag)
+	GetIsReadOnly() (isReadOnly cmb.Flag)
 	GetEventDataPtr() base2.IEventData
 	SetEventData(ed base2.IEventData)
 	SetThreads(threads *ul.UidList)
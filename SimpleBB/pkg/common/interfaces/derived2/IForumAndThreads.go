@@ -10,4 +10,6 @@ type IForumAndThreads interface {
 	SetThreads(threads []IThread)
 	GetPageData() (pageData *cmr.PageData)
 	SetPageData(pageData *cmr.PageData)
+	GetAnnouncements() (announcements []IThread)
+	SetAnnouncements(announcements []IThread)
 }
@@ -15,8 +15,10 @@ type IMessage interface {
 	GetThreadId() (threadId cmb.Id)
 	GetTextPtr() (text *cmb.Text)
 	GetTextChecksumPtr() (textChecksum *[]byte)
+	GetTextHtmlPtr() (textHtml *cmb.Text)
 	GetEventDataPtr() base2.IEventData
 	GetEventData() base2.IEventData
 	SetEventData(ed base2.IEventData)
 	SetText(text cmb.Text)
+	SetTextHtml(textHtml cmb.Text)
 }
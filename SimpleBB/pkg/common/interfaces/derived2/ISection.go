@@ -19,6 +19,8 @@ type ISection interface {
 	GetChildrenPtr() (children **ul.UidList)
 	GetChildren() (children *ul.UidList)
 	GetNamePtr() (name *cms.Name)
+	GetIsPrivatePtr() (isPrivate *cmb.Flag)
+	GetIsPrivate() (isPrivate cmb.Flag)
 	GetEventDataPtr() base2.IEventData
 	SetEventData(base2.IEventData)
 	SetChildren(children *ul.UidList)
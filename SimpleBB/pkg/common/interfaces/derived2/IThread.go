@@ -15,6 +15,12 @@ type IThread interface {
 	GetNamePtr() (name *cms.Name)
 	GetMessagesPtr() (messages **ul.UidList)
 	GetMessages() (messages *ul.UidList)
+	GetIsLockedPtr() (isLocked *cmb.Flag)
+	GetIsLocked() (isLocked cmb.Flag)
+	GetIsPinnedPtr() (isPinned *cmb.Flag)
+	GetIsPinned() (isPinned cmb.Flag)
+	GetIsAnnouncementPtr() (isAnnouncement *cmb.Flag)
+	GetIsAnnouncement() (isAnnouncement cmb.Flag)
 	GetEventDataPtr() base2.IEventData
 	SetEventData(ed base2.IEventData)
 	SetMessages(messages *ul.UidList)
@@ -0,0 +1,225 @@
+package al
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	cmi "github.com/vault-thirteen/SimpleBB/pkg/common/interfaces/base1"
+	cmb "github.com/vault-thirteen/SimpleBB/pkg/common/models/base"
+	"net"
+	"time"
+)
+
+// Action is a privileged action recorded in the audit log.
+type Action = cmb.Count
+
+const (
+	// Actions of the ACM module.
+	Action_SetUserRoleAuthor   = 1
+	Action_SetUserRoleWriter   = 2
+	Action_SetUserRoleReader   = 3
+	Action_GrantPermission     = 4
+	Action_RevokePermission    = 5
+	Action_BanUser             = 6
+	Action_UnbanUser           = 7
+	Action_ApproveRegistration = 8
+	Action_RejectRegistration  = 9
+	Action_ResetUserTotp       = 10
+	Action_LogUserOut          = 11
+
+	// Actions of the MM module.
+	Action_AddSection                = 12
+	Action_ChangeSectionName         = 13
+	Action_ChangeSectionParent       = 14
+	Action_ChangeSectionPrivacy      = 15
+	Action_MoveSectionUp             = 16
+	Action_MoveSectionDown           = 17
+	Action_DeleteSection             = 18
+	Action_AddForum                  = 19
+	Action_ChangeForumName           = 20
+	Action_ChangeForumSection        = 21
+	Action_ChangeForumReadOnly       = 22
+	Action_MoveForumUp               = 23
+	Action_MoveForumDown             = 24
+	Action_DeleteForum               = 25
+	Action_ChangeThreadForum         = 26
+	Action_DeleteThread              = 27
+	Action_RestoreFromTrash          = 28
+	Action_RepairDatabaseConsistency = 29
+	Action_LockThread                = 30
+	Action_UnlockThread              = 31
+	Action_ChangeThreadPinned        = 32
+	Action_ChangeThreadAnnouncement  = 33
+	Action_ResolveMessageReports     = 34
+
+	ActionMax = Action_ResolveMessageReports
+)
+
+// TargetType is a type of object affected by an action.
+type TargetType = cmb.Count
+
+const (
+	// TargetType_Board is the whole board. Target ID of the board is zero.
+	TargetType_Board = 1
+
+	TargetType_User         = 2
+	TargetType_Registration = 3
+	TargetType_Section      = 4
+	TargetType_Forum        = 5
+	TargetType_Thread       = 6
+	TargetType_TrashItem    = 7
+	TargetType_Message      = 8
+
+	TargetTypeMax = TargetType_Message
+)
+
+// ValueMaxLength is the maximal length of a value in bytes.
+const ValueMaxLength = 255
+
+// Record is a record of the audit log.
+type Record struct {
+	Id          cmb.Id     `json:"id"`
+	Time        time.Time  `json:"time"`
+	ActorUserId cmb.Id     `json:"actorUserId"`
+	Action      Action     `json:"action"`
+	TargetType  TargetType `json:"targetType"`
+	TargetId    cmb.Id     `json:"targetId"`
+
+	// Values of the changed property before and after the action. Values are
+	// not set when an action does not change a single property.
+	ValueBefore *cmb.Text `json:"valueBefore,omitempty"`
+	ValueAfter  *cmb.Text `json:"valueAfter,omitempty"`
+
+	// IP address of the actor. B = Byte array.
+	ActorIPAB net.IP `json:"actorIPA"`
+}
+
+// Filter selects records of the audit log. Fields which are not set are not
+// used.
+type Filter struct {
+	ActorUserId *cmb.Id     `json:"actorUserId"`
+	Action      *Action     `json:"action"`
+	TargetType  *TargetType `json:"targetType"`
+	TargetId    *cmb.Id     `json:"targetId"`
+	FromTime    *time.Time  `json:"fromTime"`
+	ToTime      *time.Time  `json:"toTime"`
+}
+
+func IsActionValid(a Action) bool {
+	return (a >= Action_SetUserRoleAuthor) && (a <= ActionMax)
+}
+
+func IsTargetTypeValid(tt TargetType) bool {
+	return (tt >= TargetType_Board) && (tt <= TargetTypeMax)
+}
+
+// IsTargetValid checks the type of target and its ID. The board has no ID,
+// while other targets must have it.
+func IsTargetValid(tt TargetType, targetId cmb.Id) bool {
+	if !IsTargetTypeValid(tt) {
+		return false
+	}
+
+	if tt == TargetType_Board {
+		return targetId == 0
+	}
+
+	return targetId > 0
+}
+
+// IsValueValid checks length of a value. A value which is not set is valid.
+func IsValueValid(v *cmb.Text) bool {
+	return (v == nil) || (len(*v) <= ValueMaxLength)
+}
+
+// IsValid checks the filter. Target ID may be used only together with the
+// type of target.
+func (f *Filter) IsValid() bool {
+	if (f.Action != nil) && !IsActionValid(*f.Action) {
+		return false
+	}
+
+	if f.TargetType != nil {
+		if !IsTargetTypeValid(*f.TargetType) {
+			return false
+		}
+	} else if f.TargetId != nil {
+		return false
+	}
+
+	if (f.FromTime != nil) && (f.ToTime != nil) && f.FromTime.After(*f.ToTime) {
+		return false
+	}
+
+	return true
+}
+
+// TextValue makes a value of a text property.
+func TextValue(s cmb.Text) *cmb.Text {
+	return &s
+}
+
+// FlagValue makes a value of a boolean property.
+func FlagValue(f cmb.Flag) *cmb.Text {
+	v := cmb.Text(f.ToString())
+	return &v
+}
+
+// IdValue makes a value of a property referencing an object.
+func IdValue(id cmb.Id) *cmb.Text {
+	v := cmb.Text(id.ToString())
+	return &v
+}
+
+// GrantValue makes a value describing a permission in a scope.
+func GrantValue(permission cmb.Count, scopeType cmb.Count, scopeId cmb.Id) *cmb.Text {
+	v := cmb.Text(fmt.Sprintf("permission=%d, scopeType=%d, scopeId=%d", permission, scopeType, scopeId))
+	return &v
+}
+
+// DecisionValue makes a value describing a decision of a moderator.
+func DecisionValue(action cmb.Count, decisionId cmb.Id) *cmb.Text {
+	v := cmb.Text(fmt.Sprintf("action=%d, decisionId=%d", action, decisionId))
+	return &v
+}
+
+func NewRecordFromScannableSource(src cmi.IScannable) (r *Record, err error) {
+	r = &Record{}
+
+	err = src.Scan(
+		&r.Id,
+		&r.Time,
+		&r.ActorUserId,
+		&r.ActorIPAB,
+		&r.Action,
+		&r.TargetType,
+		&r.TargetId,
+		&r.ValueBefore,
+		&r.ValueAfter,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		} else {
+			return nil, err
+		}
+	}
+
+	return r, nil
+}
+
+func NewRecordArrayFromRows(rows cmi.IScannableSequence) (records []*Record, err error) {
+	records = []*Record{}
+	var r *Record
+
+	for rows.Next() {
+		r, err = NewRecordFromScannableSource(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		records = append(records, r)
+	}
+
+	return records, nil
+}
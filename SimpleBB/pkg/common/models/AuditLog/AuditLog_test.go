@@ -0,0 +1,57 @@
+package al
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	cmb "github.com/vault-thirteen/SimpleBB/pkg/common/models/base"
+	"github.com/vault-thirteen/auxie/tester"
+)
+
+func Test_IsTargetValid(t *testing.T) {
+	aTest := tester.New(t)
+
+	aTest.MustBeEqual(IsTargetValid(TargetType_Board, 0), true)
+	aTest.MustBeEqual(IsTargetValid(TargetType_Board, 1), false)
+	aTest.MustBeEqual(IsTargetValid(TargetType_User, 1), true)
+	aTest.MustBeEqual(IsTargetValid(TargetType_User, 0), false)
+	aTest.MustBeEqual(IsTargetValid(0, 1), false)
+	aTest.MustBeEqual(IsTargetValid(TargetTypeMax+1, 1), false)
+}
+
+func Test_IsValueValid(t *testing.T) {
+	aTest := tester.New(t)
+
+	aTest.MustBeEqual(IsValueValid(nil), true)
+	aTest.MustBeEqual(IsValueValid(TextValue(cmb.Text(strings.Repeat("x", ValueMaxLength)))), true)
+	aTest.MustBeEqual(IsValueValid(TextValue(cmb.Text(strings.Repeat("x", ValueMaxLength+1)))), false)
+}
+
+func Test_Filter_IsValid(t *testing.T) {
+	aTest := tester.New(t)
+
+	var action Action = Action_BanUser
+	var badAction Action = ActionMax + 1
+	var targetType TargetType = TargetType_User
+	var targetId cmb.Id = 5
+	var t1 = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	var t2 = t1.Add(time.Hour)
+
+	aTest.MustBeEqual((&Filter{}).IsValid(), true)
+	aTest.MustBeEqual((&Filter{Action: &action}).IsValid(), true)
+	aTest.MustBeEqual((&Filter{Action: &badAction}).IsValid(), false)
+	aTest.MustBeEqual((&Filter{TargetType: &targetType, TargetId: &targetId}).IsValid(), true)
+	aTest.MustBeEqual((&Filter{TargetId: &targetId}).IsValid(), false)
+	aTest.MustBeEqual((&Filter{FromTime: &t1, ToTime: &t2}).IsValid(), true)
+	aTest.MustBeEqual((&Filter{FromTime: &t2, ToTime: &t1}).IsValid(), false)
+}
+
+func Test_Values(t *testing.T) {
+	aTest := tester.New(t)
+
+	aTest.MustBeEqual(*FlagValue(true), cmb.Text("true"))
+	aTest.MustBeEqual(*IdValue(42), cmb.Text("42"))
+	aTest.MustBeEqual(*GrantValue(4, 3, 10), cmb.Text("permission=4, scopeType=3, scopeId=10"))
+	aTest.MustBeEqual(*DecisionValue(2, 7), cmb.Text("action=2, decisionId=7"))
+}
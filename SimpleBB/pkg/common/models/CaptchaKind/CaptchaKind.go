@@ -0,0 +1,30 @@
+package ck
+
+import (
+	cmb "github.com/vault-thirteen/SimpleBB/pkg/common/models/base"
+)
+
+// CaptchaKind is a kind of captcha task. Kinds other than the image help
+// users who can not see the image.
+type CaptchaKind = cmb.Count
+
+const (
+	// CaptchaKind_Image is a picture with a number drawn on it.
+	CaptchaKind_Image = 1
+
+	// CaptchaKind_Question is a text question with a text answer.
+	CaptchaKind_Question = 2
+
+	// CaptchaKind_ProofOfWork is a computational challenge solved by the
+	// client's software without the user's attention.
+	CaptchaKind_ProofOfWork = 3
+
+	CaptchaKindMax = CaptchaKind_ProofOfWork
+
+	// CaptchaKind_Default is used when the kind is not set.
+	CaptchaKind_Default = CaptchaKind_Image
+)
+
+func IsCaptchaKindValid(k CaptchaKind) bool {
+	return (k >= CaptchaKind_Image) && (k <= CaptchaKindMax)
+}
@@ -0,0 +1,123 @@
+package perm
+
+import (
+	"database/sql"
+	"errors"
+	cmi "github.com/vault-thirteen/SimpleBB/pkg/common/interfaces/base1"
+	cmb "github.com/vault-thirteen/SimpleBB/pkg/common/models/base"
+	"time"
+)
+
+// Permission is an action which a user may perform in a scope.
+type Permission = cmb.Count
+
+const (
+	// Permission_Read allows to read sections, forums, threads and messages.
+	Permission_Read = 1
+
+	// Permission_Write allows to add messages and to edit own messages.
+	Permission_Write = 2
+
+	// Permission_CreateThread allows to create new threads.
+	Permission_CreateThread = 3
+
+	// Permission_Moderate allows to edit messages of other users and to see
+	// revisions of messages. This permission includes all other permissions.
+	Permission_Moderate = 4
+
+	PermissionMax = Permission_Moderate
+)
+
+// ScopeType is a type of place where a permission is granted.
+type ScopeType = cmb.Count
+
+const (
+	// ScopeType_Global is the whole board. Scope ID of a global grant is zero.
+	ScopeType_Global = 1
+
+	// ScopeType_Section is a section with all its sub-sections and forums.
+	ScopeType_Section = 2
+
+	// ScopeType_Forum is a single forum.
+	ScopeType_Forum = 3
+
+	ScopeTypeMax = ScopeType_Forum
+)
+
+// Grant is a permission given to a user in a scope.
+type Grant struct {
+	Id             cmb.Id     `json:"id"`
+	UserId         cmb.Id     `json:"userId"`
+	Permission     Permission `json:"permission"`
+	ScopeType      ScopeType  `json:"scopeType"`
+	ScopeId        cmb.Id     `json:"scopeId"`
+	GrantorUserId  cmb.Id     `json:"grantorUserId"`
+	TimeOfCreation time.Time  `json:"timeOfCreation"`
+}
+
+func IsPermissionValid(p Permission) bool {
+	return (p >= Permission_Read) && (p <= PermissionMax)
+}
+
+func IsScopeTypeValid(st ScopeType) bool {
+	return (st >= ScopeType_Global) && (st <= ScopeTypeMax)
+}
+
+// IsScopeValid checks the type of scope and its ID. Global scope has no ID,
+// while other scopes must have it.
+func IsScopeValid(st ScopeType, scopeId cmb.Id) bool {
+	if !IsScopeTypeValid(st) {
+		return false
+	}
+
+	if st == ScopeType_Global {
+		return scopeId == 0
+	}
+
+	return scopeId > 0
+}
+
+func NewGrantFromScannableSource(src cmi.IScannable) (g *Grant, err error) {
+	g = &Grant{}
+
+	err = src.Scan(
+		&g.Id,
+		&g.UserId,
+		&g.Permission,
+		&g.ScopeType,
+		&g.ScopeId,
+		&g.GrantorUserId,
+		&g.TimeOfCreation,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		} else {
+			return nil, err
+		}
+	}
+
+	return g, nil
+}
+
+func NewGrantArrayFromRows(rows cmi.IScannableSequence) (grants []*Grant, err error) {
+	grants = []*Grant{}
+	var g *Grant
+
+	for rows.Next() {
+		g, err = NewGrantFromScannableSource(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		grants = append(grants, g)
+	}
+
+	return grants, nil
+}
+
+// IsGrantedGlobally checks whether the permission is granted on the whole
+// board. Grants of sections and forums are not taken into account.
+func IsGrantedGlobally(grants []*Grant, p Permission) bool {
+	return IsGranted(grants, p, &Scope{})
+}
@@ -0,0 +1,94 @@
+package perm
+
+import (
+	cmb "github.com/vault-thirteen/SimpleBB/pkg/common/models/base"
+)
+
+// ScopeSection is a section where a permission is checked.
+type ScopeSection struct {
+	Id        cmb.Id
+	IsPrivate cmb.Flag
+}
+
+// Scope is a place where a permission is checked. It is either a forum or a
+// section. Sections are listed from the nearest one to the root section, i.e.
+// for a forum the first section is the section of the forum.
+type Scope struct {
+	// Forum is not set when the scope is a section.
+	ForumId         *cmb.Id
+	IsForumReadOnly cmb.Flag
+
+	Sections []ScopeSection
+}
+
+// IsGranted checks whether the permission is granted in the scope.
+//
+// Grants of the forum, of all its sections and global grants are taken into
+// account with the following exceptions.
+//
+//  1. A private section hides everything outside of it. Only grants of the
+//     private section and of places inside it are used, e.g. a global
+//     permission to read does not allow to read a private section.
+//
+//  2. Writing into a read-only forum and creating threads in it are allowed
+//     only by grants of the forum itself.
+//
+//  3. Permission to moderate includes all other permissions.
+func IsGranted(grants []*Grant, p Permission, scope *Scope) bool {
+	if scope == nil {
+		return false
+	}
+
+	for _, g := range grants {
+		if (g.Permission != p) && (g.Permission != Permission_Moderate) {
+			continue
+		}
+
+		if scope.isCoveredBy(g, p) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (s *Scope) isCoveredBy(g *Grant, p Permission) bool {
+	switch g.ScopeType {
+	case ScopeType_Forum:
+		return (s.ForumId != nil) && (*s.ForumId == g.ScopeId)
+
+	case ScopeType_Section, ScopeType_Global:
+		if s.isForumReadOnlyFor(g, p) {
+			return false
+		}
+
+		for _, section := range s.Sections {
+			if (g.ScopeType == ScopeType_Section) && (section.Id == g.ScopeId) {
+				return true
+			}
+
+			if section.IsPrivate {
+				return false
+			}
+		}
+
+		return g.ScopeType == ScopeType_Global
+
+	default:
+		return false
+	}
+}
+
+// isForumReadOnlyFor checks whether the read-only flag of a forum forbids to
+// use a grant which is not a grant of the forum itself.
+func (s *Scope) isForumReadOnlyFor(g *Grant, p Permission) bool {
+	if (s.ForumId == nil) || !s.IsForumReadOnly.AsBool() {
+		return false
+	}
+
+	if g.Permission == Permission_Moderate {
+		return false
+	}
+
+	return (p == Permission_Write) || (p == Permission_CreateThread)
+}
@@ -0,0 +1,88 @@
+package perm
+
+import (
+	"testing"
+
+	cmb "github.com/vault-thirteen/SimpleBB/pkg/common/models/base"
+	"github.com/vault-thirteen/auxie/tester"
+)
+
+func newForumScope(forumId cmb.Id, isReadOnly bool, sections ...ScopeSection) *Scope {
+	return &Scope{
+		ForumId:         &forumId,
+		IsForumReadOnly: cmb.Flag(isReadOnly),
+		Sections:        sections,
+	}
+}
+
+func Test_IsGranted(t *testing.T) {
+	aTest := tester.New(t)
+
+	var reader = []*Grant{
+		{Permission: Permission_Read, ScopeType: ScopeType_Global},
+		{Permission: Permission_Write, ScopeType: ScopeType_Global},
+	}
+
+	// Forum 10 is in section 2, which is in the root section 1.
+	var public = newForumScope(10, false, ScopeSection{Id: 2}, ScopeSection{Id: 1})
+	aTest.MustBeEqual(IsGranted(reader, Permission_Read, public), true)
+	aTest.MustBeEqual(IsGranted(reader, Permission_Write, public), true)
+	aTest.MustBeEqual(IsGranted(reader, Permission_CreateThread, public), false)
+	aTest.MustBeEqual(IsGranted(reader, Permission_Read, nil), false)
+
+	// Global grants do not work in a private section.
+	var private = newForumScope(10, false, ScopeSection{Id: 2, IsPrivate: true}, ScopeSection{Id: 1})
+	aTest.MustBeEqual(IsGranted(reader, Permission_Read, private), false)
+
+	var member = []*Grant{
+		{Permission: Permission_Read, ScopeType: ScopeType_Section, ScopeId: 2},
+	}
+	aTest.MustBeEqual(IsGranted(member, Permission_Read, private), true)
+	aTest.MustBeEqual(IsGranted(member, Permission_Read, &Scope{Sections: []ScopeSection{{Id: 2, IsPrivate: true}}}), true)
+	aTest.MustBeEqual(IsGranted(member, Permission_Read, &Scope{Sections: []ScopeSection{{Id: 1}}}), false)
+
+	// Grants of the root section do not work inside a private sub-section.
+	var rootMember = []*Grant{
+		{Permission: Permission_Read, ScopeType: ScopeType_Section, ScopeId: 1},
+	}
+	aTest.MustBeEqual(IsGranted(rootMember, Permission_Read, public), true)
+	aTest.MustBeEqual(IsGranted(rootMember, Permission_Read, private), false)
+
+	// Only grants of a read-only forum allow to write into it.
+	var readOnly = newForumScope(10, true, ScopeSection{Id: 2}, ScopeSection{Id: 1})
+	aTest.MustBeEqual(IsGranted(reader, Permission_Read, readOnly), true)
+	aTest.MustBeEqual(IsGranted(reader, Permission_Write, readOnly), false)
+
+	var announcer = []*Grant{
+		{Permission: Permission_Write, ScopeType: ScopeType_Forum, ScopeId: 10},
+	}
+	aTest.MustBeEqual(IsGranted(announcer, Permission_Write, readOnly), true)
+	aTest.MustBeEqual(IsGranted(announcer, Permission_Write, newForumScope(11, true, ScopeSection{Id: 2})), false)
+
+	// Moderators of a forum have all permissions in it.
+	var moderator = []*Grant{
+		{Permission: Permission_Moderate, ScopeType: ScopeType_Forum, ScopeId: 10},
+	}
+	aTest.MustBeEqual(IsGranted(moderator, Permission_Moderate, readOnly), true)
+	aTest.MustBeEqual(IsGranted(moderator, Permission_Write, readOnly), true)
+	aTest.MustBeEqual(IsGranted(moderator, Permission_Read, private), true)
+	aTest.MustBeEqual(IsGranted(moderator, Permission_Moderate, newForumScope(11, false, ScopeSection{Id: 2})), false)
+	aTest.MustBeEqual(IsGranted(moderator, Permission_Read, &Scope{Sections: []ScopeSection{{Id: 2}}}), false)
+
+	var sectionModerator = []*Grant{
+		{Permission: Permission_Moderate, ScopeType: ScopeType_Section, ScopeId: 2},
+	}
+	aTest.MustBeEqual(IsGranted(sectionModerator, Permission_Write, readOnly), true)
+	aTest.MustBeEqual(IsGranted(sectionModerator, Permission_Moderate, private), true)
+}
+
+func Test_IsScopeValid(t *testing.T) {
+	aTest := tester.New(t)
+
+	aTest.MustBeEqual(IsScopeValid(ScopeType_Global, 0), true)
+	aTest.MustBeEqual(IsScopeValid(ScopeType_Global, 1), false)
+	aTest.MustBeEqual(IsScopeValid(ScopeType_Section, 1), true)
+	aTest.MustBeEqual(IsScopeValid(ScopeType_Forum, 0), false)
+	aTest.MustBeEqual(IsScopeValid(0, 0), false)
+	aTest.MustBeEqual(IsScopeValid(ScopeTypeMax+1, 1), false)
+}
@@ -0,0 +1,200 @@
+package sch
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	CronFieldsCount = 5
+
+	// CronSearchYears limits the search of the next run. Expressions like
+	// '0 0 30 2 *' never match.
+	CronSearchYears = 5
+)
+
+const (
+	ErrF_CronFieldsCount = "cron expression must have %d fields: %s"
+	ErrF_CronField       = "cron field is not valid: %s"
+)
+
+// cronField describes limits of a field of a cron expression.
+type cronField struct {
+	min int
+	max int
+}
+
+var (
+	cronFieldMinute     = cronField{min: 0, max: 59}
+	cronFieldHour       = cronField{min: 0, max: 23}
+	cronFieldDayOfMonth = cronField{min: 1, max: 31}
+	cronFieldMonth      = cronField{min: 1, max: 12}
+
+	// Both 0 and 7 are Sunday.
+	cronFieldDayOfWeek = cronField{min: 0, max: 7}
+)
+
+// CronSchedule is a schedule described by a standard cron expression with
+// five fields: minute, hour, day of month, month and day of week. Each field
+// is a list of values, ranges ('1-5') and steps ('*/15', '10-40/10').
+//
+// As in the classic cron, when both day of month and day of week are
+// restricted, a day matching any of them is used.
+type CronSchedule struct {
+	expression string
+
+	minutes     uint64
+	hours       uint64
+	daysOfMonth uint64
+	months      uint64
+	daysOfWeek  uint64
+
+	isAnyDayOfMonth bool
+	isAnyDayOfWeek  bool
+}
+
+func ParseCronExpression(expr string) (cs *CronSchedule, err error) {
+	fields := strings.Fields(expr)
+	if len(fields) != CronFieldsCount {
+		return nil, fmt.Errorf(ErrF_CronFieldsCount, CronFieldsCount, expr)
+	}
+
+	cs = &CronSchedule{
+		expression:      strings.Join(fields, " "),
+		isAnyDayOfMonth: fields[2] == "*",
+		isAnyDayOfWeek:  fields[4] == "*",
+	}
+
+	var masks = []*uint64{&cs.minutes, &cs.hours, &cs.daysOfMonth, &cs.months, &cs.daysOfWeek}
+	var limits = []cronField{cronFieldMinute, cronFieldHour, cronFieldDayOfMonth, cronFieldMonth, cronFieldDayOfWeek}
+	for i, field := range fields {
+		*masks[i], err = parseCronField(field, limits[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Sunday may be written as 7.
+	if cs.daysOfWeek&(1<<7) != 0 {
+		cs.daysOfWeek = (cs.daysOfWeek | 1) &^ (1 << 7)
+	}
+
+	return cs, nil
+}
+
+// parseCronField converts a field into a bit mask of allowed values.
+func parseCronField(field string, limits cronField) (mask uint64, err error) {
+	for _, part := range strings.Split(field, ",") {
+		var first, last, step int
+		first, last, step, err = parseCronFieldPart(part, limits)
+		if err != nil {
+			return 0, err
+		}
+
+		for v := first; v <= last; v += step {
+			mask |= 1 << uint(v)
+		}
+	}
+
+	return mask, nil
+}
+
+func parseCronFieldPart(part string, limits cronField) (first int, last int, step int, err error) {
+	step = 1
+	rangePart, stepPart, hasStep := strings.Cut(part, "/")
+	if hasStep {
+		step, err = strconv.Atoi(stepPart)
+		if (err != nil) || (step <= 0) {
+			return 0, 0, 0, fmt.Errorf(ErrF_CronField, part)
+		}
+	}
+
+	switch {
+	case rangePart == "*":
+		first, last = limits.min, limits.max
+
+	case strings.Contains(rangePart, "-"):
+		a, b, _ := strings.Cut(rangePart, "-")
+		first, err = strconv.Atoi(a)
+		if err != nil {
+			return 0, 0, 0, fmt.Errorf(ErrF_CronField, part)
+		}
+		last, err = strconv.Atoi(b)
+		if err != nil {
+			return 0, 0, 0, fmt.Errorf(ErrF_CronField, part)
+		}
+
+	default:
+		first, err = strconv.Atoi(rangePart)
+		if err != nil {
+			return 0, 0, 0, fmt.Errorf(ErrF_CronField, part)
+		}
+
+		// A single value with a step, e.g. '5/15', starts a range.
+		last = first
+		if hasStep {
+			last = limits.max
+		}
+	}
+
+	if (first < limits.min) || (last > limits.max) || (first > last) {
+		return 0, 0, 0, fmt.Errorf(ErrF_CronField, part)
+	}
+
+	return first, last, step, nil
+}
+
+func (cs *CronSchedule) Next(t time.Time) time.Time {
+	loc := t.Location()
+
+	// Runs happen at the start of a minute.
+	t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute()+1, 0, 0, loc)
+	limit := t.AddDate(CronSearchYears, 0, 0)
+
+	for t.Before(limit) {
+		if !hasBit(cs.months, int(t.Month())) {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+			continue
+		}
+
+		if !cs.matchesDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+			continue
+		}
+
+		if !hasBit(cs.hours, t.Hour()) {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+			continue
+		}
+
+		if !hasBit(cs.minutes, t.Minute()) {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute()+1, 0, 0, loc)
+			continue
+		}
+
+		return t
+	}
+
+	return time.Time{}
+}
+
+func (cs *CronSchedule) matchesDay(t time.Time) bool {
+	dom := hasBit(cs.daysOfMonth, t.Day())
+	dow := hasBit(cs.daysOfWeek, int(t.Weekday()))
+
+	if cs.isAnyDayOfMonth || cs.isAnyDayOfWeek {
+		return dom && dow
+	}
+
+	return dom || dow
+}
+
+func (cs *CronSchedule) String() string {
+	return cs.expression
+}
+
+func hasBit(mask uint64, n int) bool {
+	return mask&(1<<uint(n)) != 0
+}
@@ -0,0 +1,51 @@
+package sch
+
+import (
+	"testing"
+	"time"
+
+	"github.com/vault-thirteen/auxie/tester"
+)
+
+func Test_ParseSchedule(t *testing.T) {
+	aTest := tester.New(t)
+	t0 := time.Date(2024, time.February, 28, 23, 59, 30, 0, time.UTC)
+
+	type TestData struct {
+		schedule string
+		next     time.Time
+	}
+
+	var tests = []TestData{
+		{"* * * * *", time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC)},
+		{"5/15 3 * * *", time.Date(2024, time.February, 29, 3, 5, 0, 0, time.UTC)},
+		{"30 4 1,15 * *", time.Date(2024, time.March, 1, 4, 30, 0, 0, time.UTC)},
+		{"0 0 * * 7", time.Date(2024, time.March, 3, 0, 0, 0, 0, time.UTC)},
+		{"0 12 * * 1-5", time.Date(2024, time.February, 29, 12, 0, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC)},
+		{"0 0 31 * *", time.Date(2024, time.March, 31, 0, 0, 0, 0, time.UTC)},
+		// Day of month OR day of week.
+		{"0 0 10 * 6", time.Date(2024, time.March, 2, 0, 0, 0, 0, time.UTC)},
+		{"@hourly", time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC)},
+		{"@monthly", time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)},
+		{"@every 90s", t0.Add(90 * time.Second)},
+	}
+
+	for _, test := range tests {
+		schedule, err := ParseSchedule(test.schedule)
+		aTest.MustBeNoError(err)
+		aTest.MustBeEqual(schedule.Next(t0), test.next)
+	}
+
+	// Expression which never matches.
+	schedule, err := ParseSchedule("0 0 30 2 *")
+	aTest.MustBeNoError(err)
+	aTest.MustBeEqual(schedule.Next(t0).IsZero(), true)
+
+	// Bad schedules.
+	for _, s := range []string{"", "* * * *", "60 * * * *", "* * 0 * *", "5-1 * * * *", "*/0 * * * *", "a * * * *", "@often", "@every -1s", "@every x"} {
+		_, err = ParseSchedule(s)
+		aTest.MustBeAnError(err)
+	}
+}
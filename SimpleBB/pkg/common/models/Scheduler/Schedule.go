@@ -0,0 +1,93 @@
+package sch
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	ScheduleEveryPrefix = "@every "
+)
+
+const (
+	ErrScheduleIsEmpty = "schedule is empty"
+	ErrF_Interval      = "interval is not valid: %s"
+	ErrF_Descriptor    = "unknown descriptor: %s"
+)
+
+// Schedule calculates times of task runs.
+type Schedule interface {
+	// Next returns time of the first run after the specified time. Zero time
+	// is returned when there are no more runs.
+	Next(t time.Time) time.Time
+
+	String() string
+}
+
+// Descriptors are short names of popular cron expressions.
+var descriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// ParseSchedule parses a schedule which is either a cron expression, e.g.
+// '*/5 * * * *', a descriptor, e.g. '@daily', or an interval, e.g.
+// '@every 90s'.
+func ParseSchedule(s string) (schedule Schedule, err error) {
+	s = strings.TrimSpace(s)
+	if len(s) == 0 {
+		return nil, errors.New(ErrScheduleIsEmpty)
+	}
+
+	if strings.HasPrefix(s, ScheduleEveryPrefix) {
+		var interval time.Duration
+		interval, err = time.ParseDuration(strings.TrimSpace(strings.TrimPrefix(s, ScheduleEveryPrefix)))
+		if err != nil {
+			return nil, err
+		}
+
+		if interval <= 0 {
+			return nil, fmt.Errorf(ErrF_Interval, s)
+		}
+
+		return NewIntervalSchedule(interval), nil
+	}
+
+	if strings.HasPrefix(s, "@") {
+		expr, ok := descriptors[s]
+		if !ok {
+			return nil, fmt.Errorf(ErrF_Descriptor, s)
+		}
+
+		return ParseCronExpression(expr)
+	}
+
+	return ParseCronExpression(s)
+}
+
+// IntervalSchedule runs a task with a fixed interval between the starts of
+// runs.
+type IntervalSchedule struct {
+	Interval time.Duration
+}
+
+func NewIntervalSchedule(interval time.Duration) (is *IntervalSchedule) {
+	return &IntervalSchedule{
+		Interval: interval,
+	}
+}
+
+func (is *IntervalSchedule) Next(t time.Time) time.Time {
+	return t.Add(is.Interval)
+}
+
+func (is *IntervalSchedule) String() string {
+	return ScheduleEveryPrefix + is.Interval.String()
+}
@@ -1,85 +1,167 @@
 package sch
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	cmi "github.com/vault-thirteen/SimpleBB/pkg/common/interfaces/base1"
 	c "github.com/vault-thirteen/SimpleBB/pkg/common/models/server"
-	"github.com/vault-thirteen/SimpleBB/pkg/common/models/simple"
 	"log"
+	"sync"
 	"time"
 )
 
+const (
+	// StopCheckPeriod is the maximal time between checks of the server's stop
+	// flag.
+	StopCheckPeriod = time.Second
+
+	ErrF_Task = "scheduled task '%s' error: %s"
+)
+
+// Scheduler runs tasks according to their schedules. Each task runs in its own
+// goroutine, so a slow task does not delay other tasks. A task is never run
+// in parallel with itself: while a run is in progress, next runs of the task
+// are skipped. Tasks receive a context which is cancelled when the scheduler
+// stops, so that the stop is not delayed by long runs.
 type Scheduler struct {
-	srv       cmi.IServer
-	funcs60   []simple.ScheduledFn
-	funcs600  []simple.ScheduledFn
-	funcs3600 []simple.ScheduledFn
+	srv   cmi.IServer
+	tasks []*scheduledTask
+
+	// Runs in progress.
+	runs *sync.WaitGroup
+
+	// Context of all runs.
+	ctx    context.Context
+	cancel context.CancelFunc
 }
 
-func NewScheduler(srv cmi.IServer, funcs60 []simple.ScheduledFn, funcs600 []simple.ScheduledFn, funcs3600 []simple.ScheduledFn) (s *Scheduler) {
+func NewScheduler(srv cmi.IServer, tasks []Task) (s *Scheduler, err error) {
 	s = &Scheduler{
-		srv:       srv,
-		funcs60:   funcs60,
-		funcs600:  funcs600,
-		funcs3600: funcs3600,
+		srv:   srv,
+		tasks: make([]*scheduledTask, 0, len(tasks)),
+		runs:  new(sync.WaitGroup),
 	}
+	s.ctx, s.cancel = context.WithCancel(context.Background())
 
-	return s
+	var st *scheduledTask
+	for _, t := range tasks {
+		st, err = newScheduledTask(t)
+		if err != nil {
+			return nil, err
+		}
+
+		s.tasks = append(s.tasks, st)
+	}
+
+	return s, nil
 }
 
 func (s *Scheduler) Run() {
 	subRoutinesWG := s.srv.GetSubRoutinesWG()
 	defer subRoutinesWG.Done()
 
-	// Time counter.
-	// It counts seconds and resets every 24 hours.
-	var tc uint = 1
-	const SecondsInDay = 86400 // 60*60*24.
-	var err error
+	now := time.Now()
+	for _, st := range s.tasks {
+		st.planNextRun(now)
+	}
 
 	for {
 		if s.srv.GetMustStopAB().Load() {
 			break
 		}
 
-		// Periodical tasks (every minute).
-		if tc%60 == 0 {
-			for _, fn := range s.funcs60 {
-				err = fn()
-				if err != nil {
-					s.log(err)
-				}
+		now = time.Now()
+		for _, st := range s.tasks {
+			if st.isDue(now) {
+				s.startTask(st, now)
 			}
+		}
 
-			// Periodical tasks (every 10 minutes).
-			if tc%600 == 0 {
-				for _, fn := range s.funcs600 {
-					err = fn()
-					if err != nil {
-						s.log(err)
-					}
-				}
-
-				// Periodical tasks (every hour).
-				if tc%3600 == 0 {
-					for _, fn := range s.funcs3600 {
-						err = fn()
-						if err != nil {
-							s.log(err)
-						}
-					}
-				}
-			}
+		time.Sleep(s.getTimeToWait(time.Now()))
+	}
+
+	// Tasks may use resources which are released after the scheduler stops.
+	s.cancel()
+	s.runs.Wait()
+
+	s.log(c.MsgSchedulerHasStopped)
+}
+
+// GetTaskStatuses returns states of all the tasks.
+func (s *Scheduler) GetTaskStatuses() (statuses []TaskStatus) {
+	statuses = make([]TaskStatus, 0, len(s.tasks))
+	for _, st := range s.tasks {
+		statuses = append(statuses, st.getStatus())
+	}
+
+	return statuses
+}
+
+// startTask starts a run of the task unless the previous run is in progress.
+func (s *Scheduler) startTask(st *scheduledTask, now time.Time) {
+	st.planNextRun(now)
+
+	if !st.tryBeginRun(now) {
+		return
+	}
+
+	s.runs.Add(1)
+	go s.runTask(st)
+}
+
+func (s *Scheduler) runTask(st *scheduledTask) {
+	defer s.runs.Done()
+
+	start := time.Now()
+	defer func() {
+		st.endRun(time.Since(start))
+	}()
+
+	ctx := s.ctx
+	if st.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, st.Timeout)
+		defer cancel()
+	}
+
+	err := st.call(ctx)
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		err = fmt.Errorf(ErrF_TaskTimeout, st.Timeout)
+	}
+
+	s.saveResult(st, err)
+}
+
+func (s *Scheduler) saveResult(st *scheduledTask, err error) {
+	st.setResult(err)
+
+	if err != nil {
+		s.log(fmt.Sprintf(ErrF_Task, st.Name, err.Error()))
+	}
+}
+
+// getTimeToWait returns time until the nearest run, but not more than the
+// period of checks of the stop flag.
+func (s *Scheduler) getTimeToWait(now time.Time) (d time.Duration) {
+	d = StopCheckPeriod
+
+	for _, st := range s.tasks {
+		next := st.nextRunTime()
+		if next.IsZero() {
+			continue
 		}
 
-		// Next tick.
-		if tc == SecondsInDay {
-			tc = 0
+		if next.Sub(now) < d {
+			d = next.Sub(now)
 		}
-		tc++
-		time.Sleep(time.Second)
 	}
 
-	s.log(c.MsgSchedulerHasStopped)
+	if d < 0 {
+		d = 0
+	}
+
+	return d
 }
 
 func (s *Scheduler) log(v ...any) {
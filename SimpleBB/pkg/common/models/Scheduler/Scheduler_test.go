@@ -0,0 +1,94 @@
+package sch
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	cmi "github.com/vault-thirteen/SimpleBB/pkg/common/interfaces/base1"
+	"github.com/vault-thirteen/auxie/tester"
+)
+
+type testServer struct {
+	cmi.IServer
+	wg       *sync.WaitGroup
+	mustStop *atomic.Bool
+}
+
+func (ts *testServer) GetSubRoutinesWG() *sync.WaitGroup { return ts.wg }
+func (ts *testServer) GetMustStopAB() *atomic.Bool       { return ts.mustStop }
+
+func Test_Scheduler(t *testing.T) {
+	aTest := tester.New(t)
+	srv := &testServer{wg: new(sync.WaitGroup), mustStop: new(atomic.Bool)}
+
+	var slowRuns, fastRuns, hungRuns atomic.Int32
+	tasks := []Task{
+		{
+			Name:     "slow",
+			Schedule: "@every 10ms",
+			Fn: func(ctx context.Context) error {
+				slowRuns.Add(1)
+				<-ctx.Done()
+				return ctx.Err()
+			},
+		},
+		{
+			Name:     "fast",
+			Schedule: "@every 10ms",
+			Fn: func(_ context.Context) error {
+				fastRuns.Add(1)
+				return errors.New("fast")
+			},
+		},
+		{
+			Name:     "hung",
+			Schedule: "@every 10ms",
+			Fn: func(ctx context.Context) error {
+				hungRuns.Add(1)
+				<-ctx.Done()
+				return ctx.Err()
+			},
+			Timeout: 20 * time.Millisecond,
+		},
+	}
+
+	s, err := NewScheduler(srv, tasks)
+	aTest.MustBeNoError(err)
+
+	srv.wg.Add(1)
+	go s.Run()
+	time.Sleep(200 * time.Millisecond)
+
+	// The slow task does not block other tasks and its runs do not pile up.
+	aTest.MustBeEqual(slowRuns.Load(), int32(1))
+	aTest.MustBeEqual(fastRuns.Load() > 5, true)
+
+	statuses := s.GetTaskStatuses()
+	aTest.MustBeEqual(statuses[0].IsRunning, true)
+	aTest.MustBeEqual(statuses[0].SkippedRunsCount > 0, true)
+	aTest.MustBeEqual(statuses[1].LastError, "fast")
+
+	// The hung task is stopped by its timeout and is run again.
+	aTest.MustBeEqual(hungRuns.Load() > 1, true)
+	aTest.MustBeEqual(statuses[2].LastError, "task has exceeded its timeout of 20ms")
+
+	// Stop of the scheduler stops the slow task.
+	srv.mustStop.Store(true)
+	srv.wg.Wait()
+
+	statuses = s.GetTaskStatuses()
+	aTest.MustBeEqual(statuses[0].IsRunning, false)
+	aTest.MustBeEqual(statuses[0].LastError, context.Canceled.Error())
+	aTest.MustBeEqual(statuses[1].ErrorsCount, statuses[1].RunsCount)
+	aTest.MustBeEqual(statuses[2].IsRunning, false)
+
+	// Bad tasks.
+	_, err = NewScheduler(srv, []Task{{Name: "x", Schedule: "@daily"}})
+	aTest.MustBeAnError(err)
+	_, err = NewScheduler(srv, []Task{{Name: "x", Schedule: "0 0 30 2 *", Fn: func(_ context.Context) error { return nil }}})
+	aTest.MustBeAnError(err)
+}
@@ -0,0 +1,170 @@
+package sch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/vault-thirteen/SimpleBB/pkg/common/models/simple"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+const (
+	ErrTaskNameIsNotSet     = "task name is not set"
+	ErrF_TaskFunctionIsNull = "task function is null: %s"
+	ErrF_TaskNeverRuns      = "task never runs: %s"
+	ErrF_TaskTimeout        = "task has exceeded its timeout of %v"
+	ErrF_TaskPanic          = "task has panicked: %v"
+)
+
+// Task is a function which is run by a scheduler periodically.
+type Task struct {
+	Name string
+
+	// Schedule is a cron expression, a descriptor or an interval, see the
+	// ParseSchedule function.
+	Schedule string
+
+	Fn simple.ScheduledFn
+
+	// Jitter is the maximal random delay added to each run. It spreads runs
+	// of tasks having the same schedule.
+	Jitter time.Duration
+
+	// Timeout is the maximal duration of a run. When it is exceeded, the
+	// context of the run is cancelled and the run is reported as failed. The
+	// task must stop when its context is done. Zero timeout means no timeout.
+	Timeout time.Duration
+}
+
+// scheduledTask is a task with its state.
+type scheduledTask struct {
+	Task
+	schedule Schedule
+
+	// Guard of the status.
+	lock   sync.Mutex
+	status TaskStatus
+}
+
+func newScheduledTask(t Task) (st *scheduledTask, err error) {
+	if len(t.Name) == 0 {
+		return nil, errors.New(ErrTaskNameIsNotSet)
+	}
+
+	if t.Fn == nil {
+		return nil, fmt.Errorf(ErrF_TaskFunctionIsNull, t.Name)
+	}
+
+	st = &scheduledTask{
+		Task: t,
+	}
+
+	st.schedule, err = ParseSchedule(t.Schedule)
+	if err != nil {
+		return nil, err
+	}
+
+	if st.schedule.Next(time.Now()).IsZero() {
+		return nil, fmt.Errorf(ErrF_TaskNeverRuns, t.Name)
+	}
+
+	st.status = TaskStatus{
+		Name:     t.Name,
+		Schedule: st.schedule.String(),
+	}
+
+	return st, nil
+}
+
+// planNextRun sets time of the next run after the specified time.
+func (st *scheduledTask) planNextRun(t time.Time) {
+	next := st.schedule.Next(t)
+	if !next.IsZero() && (st.Jitter > 0) {
+		next = next.Add(time.Duration(rand.Int63n(int64(st.Jitter))))
+	}
+
+	st.lock.Lock()
+	defer st.lock.Unlock()
+
+	st.status.NextRunTime = next
+}
+
+// isDue checks whether the task must be started at the specified time.
+func (st *scheduledTask) isDue(t time.Time) bool {
+	st.lock.Lock()
+	defer st.lock.Unlock()
+
+	return !st.status.NextRunTime.IsZero() && !t.Before(st.status.NextRunTime)
+}
+
+func (st *scheduledTask) nextRunTime() time.Time {
+	st.lock.Lock()
+	defer st.lock.Unlock()
+
+	return st.status.NextRunTime
+}
+
+// tryBeginRun marks the task as running. If the previous run is not finished
+// yet, the run is skipped and false is returned.
+func (st *scheduledTask) tryBeginRun(t time.Time) (ok bool) {
+	st.lock.Lock()
+	defer st.lock.Unlock()
+
+	if st.status.IsRunning {
+		st.status.SkippedRunsCount++
+		return false
+	}
+
+	st.status.IsRunning = true
+	st.status.LastRunTime = &t
+	st.status.RunsCount++
+	return true
+}
+
+// setResult saves a result of a run.
+func (st *scheduledTask) setResult(err error) {
+	st.lock.Lock()
+	defer st.lock.Unlock()
+
+	if err != nil {
+		st.status.LastError = err.Error()
+		st.status.ErrorsCount++
+	} else {
+		st.status.LastError = ""
+	}
+}
+
+func (st *scheduledTask) endRun(duration time.Duration) {
+	st.lock.Lock()
+	defer st.lock.Unlock()
+
+	st.status.IsRunning = false
+	st.status.LastRunDuration = duration.String()
+}
+
+func (st *scheduledTask) getStatus() TaskStatus {
+	st.lock.Lock()
+	defer st.lock.Unlock()
+
+	status := st.status
+	if status.LastRunTime != nil {
+		lrt := *status.LastRunTime
+		status.LastRunTime = &lrt
+	}
+
+	return status
+}
+
+// call runs the task function converting a panic into an error.
+func (st *scheduledTask) call(ctx context.Context) (err error) {
+	defer func() {
+		x := recover()
+		if x != nil {
+			err = fmt.Errorf(ErrF_TaskPanic, x)
+		}
+	}()
+
+	return st.Fn(ctx)
+}
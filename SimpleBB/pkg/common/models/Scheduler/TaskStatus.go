@@ -0,0 +1,24 @@
+package sch
+
+import (
+	cmb "github.com/vault-thirteen/SimpleBB/pkg/common/models/base"
+	"time"
+)
+
+// TaskStatus shows the state of a scheduled task.
+type TaskStatus struct {
+	Name     string `json:"name"`
+	Schedule string `json:"schedule"`
+
+	IsRunning       bool       `json:"isRunning"`
+	LastRunTime     *time.Time `json:"lastRunTime"`
+	LastRunDuration string     `json:"lastRunDuration"`
+	NextRunTime     time.Time  `json:"nextRunTime"`
+
+	// Error of the last run. It is empty when the last run was successful.
+	LastError string `json:"lastError,omitempty"`
+
+	RunsCount        cmb.Count `json:"runsCount"`
+	ErrorsCount      cmb.Count `json:"errorsCount"`
+	SkippedRunsCount cmb.Count `json:"skippedRunsCount"`
+}
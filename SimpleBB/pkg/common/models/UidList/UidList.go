@@ -199,6 +199,28 @@ func (ul *UidList) RemoveItemAtPos(pos simple.Index) (err error) {
 	return nil
 }
 
+// InsertItemAtPos inserts a new identifier at position shifting the items.
+// Position equal to list's size adds the item to the end of the list.
+func (ul *UidList) InsertItemAtPos(uid base2.Id, pos simple.Index) (err error) {
+	if ul.HasItem(uid) {
+		return fmt.Errorf(ErrF_DuplicateUid, uid)
+	}
+
+	if (pos < 0) || (pos.AsInt() > len(*ul)) {
+		return errors.New(Err_Position)
+	}
+
+	// Add an empty item.
+	*ul = append(*ul, 0)
+
+	// Shift elements.
+	copy((*ul)[pos+1:], (*ul)[pos:])
+
+	// Set the new item.
+	(*ul)[pos] = uid
+	return nil
+}
+
 // removeItemAtPos removes the existing item at position.
 func (ul *UidList) removeItemAtPos(pos simple.Index, lastIndex simple.Index) {
 	if pos != lastIndex {
@@ -270,6 +292,35 @@ func (ul *UidList) MoveItemDown(uid base2.Id) (err error) {
 	return nil
 }
 
+// PutItemsOnTop returns a copy of the list where the specified identifiers go
+// first. Relative order of items is kept in both parts of the list.
+// Identifiers which are not in the list are ignored.
+func (ul *UidList) PutItemsOnTop(uids []base2.Id) (ulot *UidList) {
+	x := make(UidList, 0, ul.Size())
+	if ul == nil {
+		return &x
+	}
+
+	var isOnTop = make(map[base2.Id]bool, len(uids))
+	for _, uid := range uids {
+		isOnTop[uid] = true
+	}
+
+	for _, uid := range *ul {
+		if isOnTop[uid] {
+			x = append(x, uid)
+		}
+	}
+
+	for _, uid := range *ul {
+		if !isOnTop[uid] {
+			x = append(x, uid)
+		}
+	}
+
+	return &x
+}
+
 // Scan method provides compatibility with SQL JSON data type.
 func (ul *UidList) Scan(src any) (err error) {
 	if ul == nil {
@@ -294,6 +294,43 @@ func Test_RemoveItemAtPos(t *testing.T) {
 	aTest.MustBeEqual(*ul, UidList([]base2.Id{1, 3}))
 }
 
+func Test_InsertItemAtPos(t *testing.T) {
+	aTest := tester.New(t)
+	var ul *UidList
+	var err error
+
+	// Test #1. Empty list.
+	ul = New()
+	err = ul.InsertItemAtPos(1, 0)
+	aTest.MustBeNoError(err)
+	aTest.MustBeEqual(*ul, UidList([]base2.Id{1}))
+
+	// Test #2. Position is negative or too far away.
+	ul, err = NewFromArray([]base2.Id{1, 2, 3})
+	aTest.MustBeNoError(err)
+	err = ul.InsertItemAtPos(4, -1)
+	aTest.MustBeAnError(err)
+	err = ul.InsertItemAtPos(4, 4)
+	aTest.MustBeAnError(err)
+	aTest.MustBeEqual(*ul, UidList([]base2.Id{1, 2, 3}))
+
+	// Test #3. Duplicate item.
+	err = ul.InsertItemAtPos(2, 0)
+	aTest.MustBeAnError(err)
+	aTest.MustBeEqual(*ul, UidList([]base2.Id{1, 2, 3}))
+
+	// Test #4. Beginning, middle and end of the list.
+	err = ul.InsertItemAtPos(4, 0)
+	aTest.MustBeNoError(err)
+	aTest.MustBeEqual(*ul, UidList([]base2.Id{4, 1, 2, 3}))
+	err = ul.InsertItemAtPos(5, 2)
+	aTest.MustBeNoError(err)
+	aTest.MustBeEqual(*ul, UidList([]base2.Id{4, 1, 5, 2, 3}))
+	err = ul.InsertItemAtPos(6, 5)
+	aTest.MustBeNoError(err)
+	aTest.MustBeEqual(*ul, UidList([]base2.Id{4, 1, 5, 2, 3, 6}))
+}
+
 func Test_removeItemAtPos(t *testing.T) {
 	aTest := tester.New(t)
 	var ul *UidList
@@ -459,6 +496,25 @@ func Test_MoveItemDown(t *testing.T) {
 	aTest.MustBeAnError(err)
 }
 
+func Test_PutItemsOnTop(t *testing.T) {
+	aTest := tester.New(t)
+	var ul *UidList
+
+	// Test #1. Null list.
+	aTest.MustBeEqual([]base2.Id(*ul.PutItemsOnTop([]base2.Id{1})), []base2.Id{})
+
+	// Test #2. Nothing is put on top.
+	ul = &UidList{1, 2, 3}
+	aTest.MustBeEqual([]base2.Id(*ul.PutItemsOnTop([]base2.Id{})), []base2.Id{1, 2, 3})
+
+	// Test #3. Order of items is kept in both parts.
+	ul = &UidList{1, 2, 3, 4, 5}
+	aTest.MustBeEqual([]base2.Id(*ul.PutItemsOnTop([]base2.Id{4, 2, 9})), []base2.Id{2, 4, 1, 3, 5})
+
+	// Test #4. Original list is not changed.
+	aTest.MustBeEqual([]base2.Id(*ul), []base2.Id{1, 2, 3, 4, 5})
+}
+
 func Test_Scan(t *testing.T) {
 	aTest := tester.New(t)
 	var ul *UidList
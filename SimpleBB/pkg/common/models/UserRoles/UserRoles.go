@@ -4,25 +4,28 @@ import (
 	"database/sql"
 	"errors"
 	cmi "github.com/vault-thirteen/SimpleBB/pkg/common/interfaces/base1"
+	"github.com/vault-thirteen/SimpleBB/pkg/common/models/Permission"
 	cmb "github.com/vault-thirteen/SimpleBB/pkg/common/models/base"
 )
 
+// UserRoles are roles and permissions of a user.
+//
+// Roles of administrator and moderator are set in settings. Other roles are
+// derived from global grants of permissions and are kept for compatibility.
 type UserRoles struct {
-	IsAdministrator cmb.Flag `json:"isAdministrator"`
-	IsModerator     cmb.Flag `json:"isModerator"`
-	IsAuthor        cmb.Flag `json:"isAuthor"`
-	IsWriter        cmb.Flag `json:"isWriter"`
-	IsReader        cmb.Flag `json:"isReader"`
-	CanLogIn        cmb.Flag `json:"canLogIn"`
+	IsAdministrator cmb.Flag      `json:"isAdministrator"`
+	IsModerator     cmb.Flag      `json:"isModerator"`
+	IsAuthor        cmb.Flag      `json:"isAuthor"`
+	IsWriter        cmb.Flag      `json:"isWriter"`
+	IsReader        cmb.Flag      `json:"isReader"`
+	CanLogIn        cmb.Flag      `json:"canLogIn"`
+	Grants          []*perm.Grant `json:"grants,omitempty"`
 }
 
 func NewUserRolesFromScannableSource(src cmi.IScannable) (ur *UserRoles, err error) {
 	ur = &UserRoles{}
 
 	err = src.Scan(
-		&ur.IsAuthor,
-		&ur.IsWriter,
-		&ur.IsReader,
 		&ur.CanLogIn,
 	)
 	if err != nil {
@@ -35,3 +38,22 @@ func NewUserRolesFromScannableSource(src cmi.IScannable) (ur *UserRoles, err err
 
 	return ur, nil
 }
+
+// SetGrants sets grants of permissions and derives roles from them.
+func (ur *UserRoles) SetGrants(grants []*perm.Grant) {
+	ur.Grants = grants
+	ur.IsAuthor = cmb.Flag(perm.IsGrantedGlobally(grants, perm.Permission_CreateThread))
+	ur.IsWriter = cmb.Flag(perm.IsGrantedGlobally(grants, perm.Permission_Write))
+	ur.IsReader = cmb.Flag(perm.IsGrantedGlobally(grants, perm.Permission_Read))
+}
+
+// IsGranted checks whether the user has the permission in the scope.
+// Administrators have all permissions, moderators from settings may moderate
+// everything.
+func (ur *UserRoles) IsGranted(p perm.Permission, scope *perm.Scope) bool {
+	if ur.IsAdministrator || ur.IsModerator {
+		return true
+	}
+
+	return perm.IsGranted(ur.Grants, p, scope)
+}
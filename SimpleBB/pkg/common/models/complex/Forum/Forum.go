@@ -25,6 +25,10 @@ type forum struct {
 	// List of identifiers of threads of this forum.
 	Threads *ul.UidList `json:"threads"`
 
+	// Only users having permissions in the forum itself may write into a
+	// read-only forum.
+	IsReadOnly cmb.Flag `json:"isReadOnly"`
+
 	// Forum meta-data.
 	base2.IEventData
 }
@@ -45,6 +49,7 @@ func NewForumFromScannableSource(src base.IScannable) (forum derived2.IForum, er
 		forum.GetSectionIdPtr(),
 		forum.GetNamePtr(),
 		x, //&forum.Threads,
+		forum.GetIsReadOnlyPtr(),
 		eventData.GetCreatorUserIdPtr(),
 		eventData.GetCreatorTimePtr(),
 		eventData.GetEditorUserIdPtr(),
@@ -80,14 +85,16 @@ func NewForumArrayFromRows(rows base.IScannableSequence) (forums []derived2.IFor
 }
 
 // Emulated class members.
-func (f *forum) GetIdPtr() (id *cmb.Id)                { return &f.Id }
-func (f *forum) GetId() (id cmb.Id)                    { return f.Id }
-func (f *forum) GetSectionIdPtr() (sectionId *cmb.Id)  { return &f.SectionId }
-func (f *forum) GetSectionId() (sectionId cmb.Id)      { return f.SectionId }
-func (f *forum) GetNamePtr() (name *cms.Name)          { return &f.Name }
-func (f *forum) GetThreadsPtr() (threads **ul.UidList) { return &f.Threads }
-func (f *forum) GetThreads() (threads *ul.UidList)     { return f.Threads }
-func (f *forum) GetEventDataPtr() base2.IEventData     { return f.IEventData }
+func (f *forum) GetIdPtr() (id *cmb.Id)                   { return &f.Id }
+func (f *forum) GetId() (id cmb.Id)                       { return f.Id }
+func (f *forum) GetSectionIdPtr() (sectionId *cmb.Id)     { return &f.SectionId }
+func (f *forum) GetSectionId() (sectionId cmb.Id)         { return f.SectionId }
+func (f *forum) GetNamePtr() (name *cms.Name)             { return &f.Name }
+func (f *forum) GetThreadsPtr() (threads **ul.UidList)    { return &f.Threads }
+func (f *forum) GetThreads() (threads *ul.UidList)        { return f.Threads }
+func (f *forum) GetIsReadOnlyPtr() (isReadOnly *cmb.Flag) { return &f.IsReadOnly }
+func (f *forum) GetIsReadOnly() (isReadOnly cmb.Flag)     { return f.IsReadOnly }
+func (f *forum) GetEventDataPtr() base2.IEventData        { return f.IEventData }
 func (f *forum) SetEventData(ed base2.IEventData) {
 	f.IEventData = ed
 }
@@ -10,6 +10,10 @@ type forumAndThreads struct {
 	Forum    derived2.IForum    `json:"forum"`
 	Threads  []derived2.IThread `json:"threads"`
 	PageData *cmr.PageData      `json:"pageData,omitempty"`
+
+	// Announcements made in other forums of the section. They are not
+	// counted in page data.
+	Announcements []derived2.IThread `json:"announcements"`
 }
 
 func NewForumAndThreads() (fat derived2.IForumAndThreads) {
@@ -17,6 +21,8 @@ func NewForumAndThreads() (fat derived2.IForumAndThreads) {
 		Forum:    f.NewForum(),
 		Threads:  []derived2.IThread{},
 		PageData: &cmr.PageData{},
+
+		Announcements: []derived2.IThread{},
 	}
 }
 
@@ -27,3 +33,9 @@ func (fat *forumAndThreads) GetThreads() (threads []derived2.IThread) { return f
 func (fat *forumAndThreads) SetThreads(threads []derived2.IThread)    { fat.Threads = threads }
 func (fat *forumAndThreads) GetPageData() (pageData *cmr.PageData)    { return fat.PageData }
 func (fat *forumAndThreads) SetPageData(pageData *cmr.PageData)       { fat.PageData = pageData }
+func (fat *forumAndThreads) GetAnnouncements() (announcements []derived2.IThread) {
+	return fat.Announcements
+}
+func (fat *forumAndThreads) SetAnnouncements(announcements []derived2.IThread) {
+	fat.Announcements = announcements
+}
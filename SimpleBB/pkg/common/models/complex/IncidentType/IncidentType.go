@@ -22,8 +22,9 @@ const (
 	IncidentType_FakeIPA                         = 10
 	IncidentType_ReadingNotificationOfOtherUsers = 11
 	IncidentType_WrongDKey                       = 12
+	IncidentType_PasswordResetHacking            = 13
 
-	IncidentTypeMax = IncidentType_WrongDKey
+	IncidentTypeMax = IncidentType_PasswordResetHacking
 )
 
 func NewIncidentType() derived1.IIncidentType {
@@ -24,6 +24,9 @@ type message struct {
 	// Check sum of the Text field.
 	TextChecksum []byte `json:"textChecksum"`
 
+	// The Text field rendered as sanitised HTML.
+	TextHtml cmb.Text `json:"textHtml"`
+
 	// Message meta-data.
 	base2.IEventData
 }
@@ -43,6 +46,7 @@ func NewMessageFromScannableSource(src base.IScannable) (msg derived2.IMessage,
 		msg.GetThreadIdPtr(),
 		msg.GetTextPtr(),
 		msg.GetTextChecksumPtr(),
+		msg.GetTextHtmlPtr(),
 		eventData.GetCreatorUserIdPtr(),
 		eventData.GetCreatorTimePtr(),
 		eventData.GetEditorUserIdPtr(),
@@ -90,9 +94,11 @@ func (m *message) GetThreadIdPtr() (threadId *cmb.Id)         { return &m.Thread
 func (m *message) GetThreadId() (threadId cmb.Id)             { return m.ThreadId }
 func (m *message) GetTextPtr() (text *cmb.Text)               { return &m.Text }
 func (m *message) GetTextChecksumPtr() (textChecksum *[]byte) { return &m.TextChecksum }
+func (m *message) GetTextHtmlPtr() (textHtml *cmb.Text)       { return &m.TextHtml }
 func (m *message) GetEventDataPtr() base2.IEventData          { return m.IEventData }
 func (m *message) GetEventData() base2.IEventData             { return m.IEventData }
 func (m *message) SetEventData(ed base2.IEventData) {
 	m.IEventData = ed
 }
-func (m *message) SetText(text cmb.Text) { m.Text = text }
+func (m *message) SetText(text cmb.Text)         { m.Text = text }
+func (m *message) SetTextHtml(textHtml cmb.Text) { m.TextHtml = textHtml }
@@ -33,6 +33,9 @@ type section struct {
 	// Name of this section.
 	Name cms.Name `json:"name"`
 
+	// Private section is visible only to users having permissions in it.
+	IsPrivate cmb.Flag `json:"isPrivate"`
+
 	// Section meta-data.
 	base2.IEventData
 }
@@ -55,6 +58,7 @@ func NewSectionFromScannableSource(src base.IScannable) (sec derived2.ISection,
 		sec.GetChildTypePtr(),
 		x, //&sec.Children,
 		sec.GetNamePtr(),
+		sec.GetIsPrivatePtr(),
 		eventData.GetCreatorUserIdPtr(),
 		eventData.GetCreatorTimePtr(),
 		eventData.GetEditorUserIdPtr(),
@@ -99,6 +103,8 @@ func (s *section) GetChildType() (childType derived1.ISectionChildType)    { ret
 func (s *section) GetChildrenPtr() (children **ul.UidList)                 { return &s.Children }
 func (s *section) GetChildren() (children *ul.UidList)                     { return s.Children }
 func (s *section) GetNamePtr() (name *cms.Name)                            { return &s.Name }
+func (s *section) GetIsPrivatePtr() (isPrivate *cmb.Flag)                  { return &s.IsPrivate }
+func (s *section) GetIsPrivate() (isPrivate cmb.Flag)                      { return s.IsPrivate }
 func (s *section) GetEventDataPtr() base2.IEventData                       { return s.IEventData }
 func (s *section) SetEventData(ed base2.IEventData) {
 	s.IEventData = ed
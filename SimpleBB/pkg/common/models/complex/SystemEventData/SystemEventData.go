@@ -120,6 +120,33 @@ func (sed *systemEventData) CheckParameters() (ok bool, err error) {
 		req.IsMessageIdRequired = true
 		req.IsCreatorRequired = true
 
+	case set.SystemEventType_MessageReaction:
+		// TMUC.
+		req.IsMessageIdRequired = true
+		req.IsCreatorRequired = true
+
+	case set.SystemEventType_ThreadPollClosed:
+		// T. Polls are closed by the system, not by a user.
+		req.IsUserIdRequired = false
+
+	case set.SystemEventType_MessageMention:
+		// TMUC. The mentioned user is passed as the creator.
+		req.IsMessageIdRequired = true
+		req.IsCreatorRequired = true
+
+	case set.SystemEventType_ForumNewThread:
+		// Default requirements are used (TU).
+
+	case set.SystemEventType_MessageReportResolved:
+		// TMUC. The moderator is passed as the user, the reporter is passed
+		// as the creator.
+		req.IsMessageIdRequired = true
+		req.IsCreatorRequired = true
+
+	case set.SystemEventType_ThreadLock,
+		set.SystemEventType_ThreadUnlock:
+		// Default requirements are used (TU).
+
 	default:
 		return false, fmt.Errorf(ErrSystemEventType)
 	}
@@ -10,17 +10,24 @@ import (
 type systemEventType base.IEnum
 
 const (
-	SystemEventType_ThreadParentChange    = 1 // -> Users subscribed to the thread.
-	SystemEventType_ThreadNameChange      = 2 // -> Users subscribed to the thread.
-	SystemEventType_ThreadDeletion        = 3 // -> Users subscribed to the thread.
-	SystemEventType_ThreadNewMessage      = 4 // -> Users subscribed to the thread.
-	SystemEventType_ThreadMessageEdit     = 5 // -> Users subscribed to the thread.
-	SystemEventType_ThreadMessageDeletion = 6 // -> Users subscribed to the thread.
-	SystemEventType_MessageTextEdit       = 7 // -> Author of the message.
-	SystemEventType_MessageParentChange   = 8 // -> Author of the message.
-	SystemEventType_MessageDeletion       = 9 // -> Author of the message.
+	SystemEventType_ThreadParentChange    = 1  // -> Users subscribed to the thread.
+	SystemEventType_ThreadNameChange      = 2  // -> Users subscribed to the thread.
+	SystemEventType_ThreadDeletion        = 3  // -> Users subscribed to the thread.
+	SystemEventType_ThreadNewMessage      = 4  // -> Users subscribed to the thread.
+	SystemEventType_ThreadMessageEdit     = 5  // -> Users subscribed to the thread.
+	SystemEventType_ThreadMessageDeletion = 6  // -> Users subscribed to the thread.
+	SystemEventType_MessageTextEdit       = 7  // -> Author of the message.
+	SystemEventType_MessageParentChange   = 8  // -> Author of the message.
+	SystemEventType_MessageDeletion       = 9  // -> Author of the message.
+	SystemEventType_MessageReaction       = 10 // -> Author of the message.
+	SystemEventType_ThreadPollClosed      = 11 // -> Users subscribed to the thread.
+	SystemEventType_MessageMention        = 12 // -> Mentioned user.
+	SystemEventType_ForumNewThread        = 13 // -> Users subscribed to the forum or its sections.
+	SystemEventType_MessageReportResolved = 14 // -> Reporter of the message.
+	SystemEventType_ThreadLock            = 15 // -> Users subscribed to the thread.
+	SystemEventType_ThreadUnlock          = 16 // -> Users subscribed to the thread.
 
-	SystemEventTypeMax = SystemEventType_MessageDeletion
+	SystemEventTypeMax = SystemEventType_ThreadUnlock
 )
 
 func NewSystemEventType() derived1.ISystemEventType {
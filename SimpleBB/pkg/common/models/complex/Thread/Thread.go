@@ -25,6 +25,16 @@ type thread struct {
 	// List of identifiers of messages of this thread.
 	Messages *ul.UidList `json:"messages"`
 
+	// New messages can not be added into a locked thread by users who are
+	// not moderators.
+	IsLocked cmb.Flag `json:"isLocked"`
+
+	// Pinned threads are shown above other threads of the forum.
+	IsPinned cmb.Flag `json:"isPinned"`
+
+	// Announcements are shown in all forums of the section.
+	IsAnnouncement cmb.Flag `json:"isAnnouncement"`
+
 	// Thread meta-data.
 	base2.IEventData
 }
@@ -45,6 +55,9 @@ func NewThreadFromScannableSource(src base.IScannable) (t derived2.IThread, err
 		t.GetForumIdPtr(),
 		t.GetNamePtr(),
 		x, //&t.Messages,
+		t.GetIsLockedPtr(),
+		t.GetIsPinnedPtr(),
+		t.GetIsAnnouncementPtr(),
 		eventData.GetCreatorUserIdPtr(),
 		eventData.GetCreatorTimePtr(),
 		eventData.GetEditorUserIdPtr(),
@@ -80,13 +93,19 @@ func NewThreadArrayFromRows(rows base.IScannableSequence) (ts []derived2.IThread
 }
 
 // Emulated class members.
-func (t *thread) GetIdPtr() (id *cmb.Id)                  { return &t.Id }
-func (t *thread) GetForumIdPtr() (forumId *cmb.Id)        { return &t.ForumId }
-func (t *thread) GetForumId() (forumId cmb.Id)            { return t.ForumId }
-func (t *thread) GetNamePtr() (name *cms.Name)            { return &t.Name }
-func (t *thread) GetMessagesPtr() (messages **ul.UidList) { return &t.Messages }
-func (t *thread) GetMessages() (messages *ul.UidList)     { return t.Messages }
-func (t *thread) GetEventDataPtr() base2.IEventData       { return t.IEventData }
+func (t *thread) GetIdPtr() (id *cmb.Id)                           { return &t.Id }
+func (t *thread) GetForumIdPtr() (forumId *cmb.Id)                 { return &t.ForumId }
+func (t *thread) GetForumId() (forumId cmb.Id)                     { return t.ForumId }
+func (t *thread) GetNamePtr() (name *cms.Name)                     { return &t.Name }
+func (t *thread) GetMessagesPtr() (messages **ul.UidList)          { return &t.Messages }
+func (t *thread) GetMessages() (messages *ul.UidList)              { return t.Messages }
+func (t *thread) GetIsLockedPtr() (isLocked *cmb.Flag)             { return &t.IsLocked }
+func (t *thread) GetIsLocked() (isLocked cmb.Flag)                 { return t.IsLocked }
+func (t *thread) GetIsPinnedPtr() (isPinned *cmb.Flag)             { return &t.IsPinned }
+func (t *thread) GetIsPinned() (isPinned cmb.Flag)                 { return t.IsPinned }
+func (t *thread) GetIsAnnouncementPtr() (isAnnouncement *cmb.Flag) { return &t.IsAnnouncement }
+func (t *thread) GetIsAnnouncement() (isAnnouncement cmb.Flag)     { return t.IsAnnouncement }
+func (t *thread) GetEventDataPtr() base2.IEventData                { return t.IEventData }
 func (t *thread) SetEventData(ed base2.IEventData) {
 	t.IEventData = ed
 }
@@ -42,9 +42,6 @@ func NewUserParametersFromScannableSource(src cmi.IScannable) (up base2.IUserPar
 		up.GetNamePtr(),
 		up.GetApprovalTimePtr(),
 		up.GetRegTimePtr(),
-		&roles.IsAuthor,
-		&roles.IsWriter,
-		&roles.IsReader,
 		&roles.CanLogIn,
 		up.GetLastBadLogInTimePtr(),
 		up.GetBanTimePtr(),
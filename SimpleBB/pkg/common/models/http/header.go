@@ -13,3 +13,28 @@ const (
 	ContentType_PNG        = mime.TypeImagePng
 	ContentType_Wasm       = mime.TypeApplicationWasm
 )
+
+// Content type of server-sent events. It is not listed in the MIME package.
+const ContentType_EventStream = "text/event-stream"
+
+// HTTP headers used by server-sent events.
+const (
+	HttpHeaderCacheControl = "Cache-Control"
+	HttpHeaderLastEventId  = "Last-Event-ID"
+
+	CacheControl_NoCache = "no-cache"
+)
+
+// HTTP header used by responses to rate-limited requests.
+const HttpHeaderRetryAfter = "Retry-After"
+
+// HTTP headers used by downloads of attached files.
+const (
+	HttpHeaderContentDisposition  = "Content-Disposition"
+	HttpHeaderXContentTypeOptions = "X-Content-Type-Options"
+
+	ContentDisposition_Attachment = "attachment"
+	ContentDisposition_Inline     = "inline"
+	ContentTypeOptions_NoSniff    = "nosniff"
+	CacheControl_Private          = "private"
+)
@@ -0,0 +1,114 @@
+package net
+
+import (
+	"errors"
+	cm "github.com/vault-thirteen/SimpleBB/pkg/common/models/simple"
+	"net"
+	"strings"
+)
+
+const (
+	ErrCidrParseError       = "CIDR parse error"
+	ErrForwardedListIsEmpty = "list of forwarded addresses is empty"
+)
+
+const (
+	IPv4BitsCount = 8 * net.IPv4len
+	IPv6BitsCount = 8 * net.IPv6len
+)
+
+// ParseCidr parses a range of IP addresses in the CIDR notation. A single IP
+// address is also accepted, it is a range of one address. The returned range
+// is canonical, i.e. its address is the first address of the range.
+func ParseCidr(s string) (ipNet *net.IPNet, err error) {
+	if !strings.Contains(s, "/") {
+		var ipa net.IP
+		ipa, err = ParseIPA(cm.IPAS(s))
+		if err != nil {
+			return nil, errors.New(ErrCidrParseError)
+		}
+
+		return GetSubnet(ipa, IPv4BitsCount, IPv6BitsCount), nil
+	}
+
+	_, ipNet, err = net.ParseCIDR(s)
+	if err != nil {
+		return nil, errors.New(ErrCidrParseError)
+	}
+
+	return ipNet, nil
+}
+
+// GetSubnet returns a subnet of the IP address. Prefix length is selected
+// according to the version of the IP address.
+func GetSubnet(ipa net.IP, ipv4PrefixLength int, ipv6PrefixLength int) (ipNet *net.IPNet) {
+	ipv4 := ipa.To4()
+	if ipv4 != nil {
+		mask := net.CIDRMask(ipv4PrefixLength, IPv4BitsCount)
+		return &net.IPNet{IP: ipv4.Mask(mask), Mask: mask}
+	}
+
+	mask := net.CIDRMask(ipv6PrefixLength, IPv6BitsCount)
+	return &net.IPNet{IP: ipa.To16().Mask(mask), Mask: mask}
+}
+
+// GetIPRange returns the first and the last addresses of a range. Both
+// addresses are 16 bytes long, the same way as IP addresses are stored in the
+// database, so that they can be compared as byte arrays.
+func GetIPRange(ipNet *net.IPNet) (first net.IP, last net.IP) {
+	first = ipNet.IP.Mask(ipNet.Mask).To16()
+	last = make(net.IP, net.IPv6len)
+	copy(last, first)
+
+	// Mask of an IPv4 range covers only the last four bytes.
+	offset := net.IPv6len - len(ipNet.Mask)
+	for i, m := range ipNet.Mask {
+		last[offset+i] |= ^m
+	}
+
+	return first, last
+}
+
+// IsIPAInList checks whether the IP address belongs to any of the ranges.
+func IsIPAInList(ipa net.IP, list []*net.IPNet) bool {
+	for _, ipNet := range list {
+		if ipNet.Contains(ipa) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// GetClientIPAFromForwardedList searches for the client's IP address in the
+// list of addresses set by proxies, such as the 'X-Forwarded-For' HTTP
+// header. Each proxy appends the address of its peer to the end of the list,
+// so the list is read from right to left and trusted proxies are skipped. The
+// first address which does not belong to a trusted proxy is the client's
+// address, while the rest of the list may be forged by the client.
+func GetClientIPAFromForwardedList(list string, trustedProxies []*net.IPNet) (ipa net.IP, err error) {
+	parts := strings.Split(list, ",")
+
+	for i := len(parts) - 1; i >= 0; i-- {
+		part := strings.TrimSpace(parts[i])
+		if len(part) == 0 {
+			continue
+		}
+
+		ipa, err = ParseIPA(cm.IPAS(part))
+		if err != nil {
+			return nil, err
+		}
+
+		if !IsIPAInList(ipa, trustedProxies) {
+			return ipa, nil
+		}
+	}
+
+	// All the addresses are trusted.
+	if ipa == nil {
+		return nil, errors.New(ErrForwardedListIsEmpty)
+	}
+
+	return ipa, nil
+}
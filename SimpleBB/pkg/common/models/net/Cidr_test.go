@@ -0,0 +1,74 @@
+package net
+
+import (
+	"net"
+	"testing"
+
+	"github.com/vault-thirteen/auxie/tester"
+)
+
+func Test_ParseCidr(t *testing.T) {
+	aTest := tester.New(t)
+
+	ipNet, err := ParseCidr("192.168.1.77/24")
+	aTest.MustBeNoError(err)
+	aTest.MustBeEqual(ipNet.String(), "192.168.1.0/24")
+
+	ipNet, err = ParseCidr("192.168.1.77")
+	aTest.MustBeNoError(err)
+	aTest.MustBeEqual(ipNet.String(), "192.168.1.77/32")
+
+	ipNet, err = ParseCidr("2001:db8::1")
+	aTest.MustBeNoError(err)
+	aTest.MustBeEqual(ipNet.String(), "2001:db8::1/128")
+
+	_, err = ParseCidr("192.168.1.0/33")
+	aTest.MustBeAnError(err)
+	_, err = ParseCidr("example.org")
+	aTest.MustBeAnError(err)
+}
+
+func Test_GetSubnet(t *testing.T) {
+	aTest := tester.New(t)
+
+	aTest.MustBeEqual(GetSubnet(net.ParseIP("10.1.2.3"), 24, 64).String(), "10.1.2.0/24")
+	aTest.MustBeEqual(GetSubnet(net.ParseIP("2001:db8:1:2:3:4:5:6"), 24, 64).String(), "2001:db8:1:2::/64")
+}
+
+func Test_GetIPRange(t *testing.T) {
+	aTest := tester.New(t)
+
+	ipNet, err := ParseCidr("10.1.2.0/23")
+	aTest.MustBeNoError(err)
+	first, last := GetIPRange(ipNet)
+	aTest.MustBeEqual(first, net.ParseIP("10.1.2.0"))
+	aTest.MustBeEqual(last, net.ParseIP("10.1.3.255"))
+
+	ipNet, err = ParseCidr("2001:db8::/64")
+	aTest.MustBeNoError(err)
+	first, last = GetIPRange(ipNet)
+	aTest.MustBeEqual(first, net.ParseIP("2001:db8::"))
+	aTest.MustBeEqual(last, net.ParseIP("2001:db8::ffff:ffff:ffff:ffff"))
+}
+
+func Test_GetClientIPAFromForwardedList(t *testing.T) {
+	aTest := tester.New(t)
+
+	proxy, err := ParseCidr("10.0.0.0/8")
+	aTest.MustBeNoError(err)
+	var trustedProxies = []*net.IPNet{proxy}
+
+	// The left-most address is forged by the client.
+	ipa, err := GetClientIPAFromForwardedList("1.1.1.1, 2.2.2.2, 10.0.0.5", trustedProxies)
+	aTest.MustBeNoError(err)
+	aTest.MustBeEqual(ipa.String(), "2.2.2.2")
+
+	ipa, err = GetClientIPAFromForwardedList("10.0.0.7,10.0.0.5", trustedProxies)
+	aTest.MustBeNoError(err)
+	aTest.MustBeEqual(ipa.String(), "10.0.0.7")
+
+	_, err = GetClientIPAFromForwardedList(" , ", trustedProxies)
+	aTest.MustBeAnError(err)
+	_, err = GetClientIPAFromForwardedList("1.1.1.1, junk", trustedProxies)
+	aTest.MustBeAnError(err)
+}
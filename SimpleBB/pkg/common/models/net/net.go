@@ -26,15 +26,25 @@ func ParseIPA(s cm.IPAS) (ipa net.IP, err error) {
 
 // SplitHostPort splits address into host and port and returns an error on
 // error.While Go language does not have this very basic function, we are
-// re-inventing the wheel again and again.
+// re-inventing the wheel again and again. IPv6 hosts must be enclosed in
+// square brackets, e.g. '[::1]:443'.
 func SplitHostPort(addr string) (host, port string, err error) {
-	parts := strings.Split(addr, ":")
+	i := strings.LastIndex(addr, ":")
+	if (i <= 0) || (i == len(addr)-1) {
+		return "", "", errors.New(ErrNotEnoughDataInAddress)
+	}
+
+	host, port = addr[:i], addr[i+1:]
+
+	if strings.HasPrefix(host, "[") && strings.HasSuffix(host, "]") {
+		return host[1 : len(host)-1], port, nil
+	}
 
-	if len(parts) != 2 {
+	if strings.Contains(host, ":") {
 		return "", "", errors.New(ErrNotEnoughDataInAddress)
 	}
 
-	return parts[0], parts[1], nil
+	return host, port, nil
 }
 
 // SplitUrlPath splits an URL path into non-empty parts. This method is opposed
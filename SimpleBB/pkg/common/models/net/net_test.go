@@ -17,3 +17,24 @@ func Test_SplitUrlPath(t *testing.T) {
 	aTest.MustBeEqual(SplitUrlPath("a/b"), []string{"a", "b"})
 	aTest.MustBeEqual(SplitUrlPath("a///b"), []string{"a", "b"})
 }
+
+func Test_SplitHostPort(t *testing.T) {
+	aTest := tester.New(t)
+
+	host, port, err := SplitHostPort("127.0.0.1:443")
+	aTest.MustBeNoError(err)
+	aTest.MustBeEqual(host, "127.0.0.1")
+	aTest.MustBeEqual(port, "443")
+
+	host, port, err = SplitHostPort("[2001:db8::1]:8080")
+	aTest.MustBeNoError(err)
+	aTest.MustBeEqual(host, "2001:db8::1")
+	aTest.MustBeEqual(port, "8080")
+
+	_, _, err = SplitHostPort("2001:db8::1")
+	aTest.MustBeAnError(err)
+	_, _, err = SplitHostPort("127.0.0.1")
+	aTest.MustBeAnError(err)
+	_, _, err = SplitHostPort("127.0.0.1:")
+	aTest.MustBeAnError(err)
+}
@@ -0,0 +1,9 @@
+package rpc
+
+import (
+	sch "github.com/vault-thirteen/SimpleBB/pkg/common/models/Scheduler"
+)
+
+type ScheduledTasks struct {
+	ScheduledTasks []sch.TaskStatus `json:"scheduledTasks"`
+}
@@ -16,6 +16,7 @@ const (
 	RpcErrorCode_Password                 = 1024 * 128
 	RpcErrorCode_ModuleSynchronisation    = 1024 * 256
 	RpcErrorCode_SystemEvent              = 1024 * 512
+	RpcErrorCode_RateLimit                = 1024 * 1024
 )
 
 // Messages.
@@ -30,11 +31,13 @@ const (
 	RpcErrorMsg_Password                 = "password error"
 	RpcErrorMsg_ModuleSynchronisation    = "module synchronisation error"
 	RpcErrorMsg_SystemEvent              = "system event error"
+	RpcErrorMsg_RateLimit                = "rate limit is exceeded"
 )
 
 // Unique HTTP status codes used in the map:
 // - 403 (Forbidden);
 // - 404 (Not found);
+// - 429 (Too many requests);
 // - 500 (Internal server error).
 func GetMapOfHttpStatusCodesByRpcErrorCodes() map[int]int {
 	return map[int]int{
@@ -48,5 +51,6 @@ func GetMapOfHttpStatusCodesByRpcErrorCodes() map[int]int {
 		RpcErrorCode_Password:                 http.StatusForbidden,
 		RpcErrorCode_ModuleSynchronisation:    http.StatusInternalServerError,
 		RpcErrorCode_SystemEvent:              http.StatusInternalServerError,
+		RpcErrorCode_RateLimit:                http.StatusTooManyRequests,
 	}
 }
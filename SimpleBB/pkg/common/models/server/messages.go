@@ -25,10 +25,16 @@ const (
 	MsgIncidentManagerHasStopped        = "Incident manager has stopped"
 	MsgIncidentsTableIsEnabled          = "Incidents table is enabled"
 	MsgIncidentsTableIsDisabled         = "Incidents table is disabled"
+	MsgNotificationPublisherHasStopped  = "Notification publisher has stopped"
+	MsgNotificationPushIsEnabled        = "Notification push is enabled"
+	MsgNotificationPushIsDisabled       = "Notification push is disabled"
+	MsgOutboxHasStopped                 = "Outbox has stopped"
 	MsgFirewallIsEnabled                = "Firewall is enabled"
 	MsgFirewallIsDisabled               = "Firewall is disabled"
 	MsgPingAttempt                      = "."
 	MsgDatabaseConsistencyCheck         = "Database consistency check ..."
+	MsgRenderingMessagesWithoutHtml     = "Rendering messages without HTML ..."
+	MsgIndexingMessagesAndThreads       = "Indexing messages and threads for search ..."
 )
 
 // Error messages (simple).
@@ -37,10 +43,13 @@ const (
 	MsgServerError                    = "Server error: "
 	MsgSystemSettingError             = "Error in system setting"
 	MsgSmtpSettingError               = "Error in SMTP module setting"
+	MsgOutboxSettingError             = "Error in outbox setting"
+	MsgAttachmentSettingError         = "Error in attachment setting"
 	MsgMessageSettingError            = "Error in message setting"
 	MsgCaptchaServiceSettingError     = "Error in captcha service setting"
 	MsgCaptchaImageServerSettingError = "Error in captcha image server setting"
 	MsgJwtSettingError                = "Error in JWT setting"
+	MsgNotificationQueueIsFull        = "Notification queue is full"
 )
 
 // Templates for messages and errors.
@@ -51,4 +60,5 @@ const (
 	MsgFModuleIsBroken             = "%s module is broken"
 	MsgFServiceClientSettingsError = "%s service client settings error: %s"
 	MsgFSynchronisingWithModule    = "Synchronising with %s module ..."
+	MsgFMessageIsDead              = "Message %s is moved to dead letters: %s"
 )
@@ -0,0 +1,7 @@
+package simple
+
+import (
+	cmb "github.com/vault-thirteen/SimpleBB/pkg/common/models/base"
+)
+
+type RecoveryCode = cmb.Text
@@ -1,3 +1,5 @@
 package simple
 
-type ScheduledFn = func() error
+import "context"
+
+type ScheduledFn = func(ctx context.Context) error
@@ -0,0 +1,32 @@
+-- Migration of fixed user roles to permissions.
+--
+-- Roles of users were stored as columns of the Users table. Now they are
+-- global grants in the Permissions table:
+--  * IsReader -> Read (1),
+--  * IsWriter -> Write (2),
+--  * IsAuthor -> CreateThread (3).
+-- Scope type of a global grant is 1 and its scope ID is 0.
+--
+-- Start the ACM service once before running this script, so that the
+-- Permissions table is created. Table names are shown without a prefix, add
+-- the prefix from the settings if it is used, e.g. 'v1_Users'.
+
+INSERT IGNORE INTO Permissions (UserId, Permission, ScopeType, ScopeId)
+SELECT Id, 1, 1, 0
+FROM Users
+WHERE IsReader IS TRUE;
+
+INSERT IGNORE INTO Permissions (UserId, Permission, ScopeType, ScopeId)
+SELECT Id, 2, 1, 0
+FROM Users
+WHERE IsWriter IS TRUE;
+
+INSERT IGNORE INTO Permissions (UserId, Permission, ScopeType, ScopeId)
+SELECT Id, 3, 1, 0
+FROM Users
+WHERE IsAuthor IS TRUE;
+
+ALTER TABLE Users
+    DROP COLUMN IsAuthor,
+    DROP COLUMN IsWriter,
+    DROP COLUMN IsReader;
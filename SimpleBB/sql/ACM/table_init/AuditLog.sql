@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS AuditLog
+(
+    Id          bigint AUTO_INCREMENT NOT NULL,
+    Time        datetime              NOT NULL DEFAULT NOW(),
+    ActorUserId bigint                NOT NULL,
+    ActorIPAB   binary(16)                     DEFAULT NULL,
+    Action      tinyint               NOT NULL,
+    TargetType  tinyint               NOT NULL,
+    TargetId    bigint                NOT NULL DEFAULT 0,
+    ValueBefore varchar(255)                   DEFAULT NULL,
+    ValueAfter  varchar(255)                   DEFAULT NULL,
+    PRIMARY KEY (Id),
+    INDEX idx_Time USING BTREE (Time),
+    INDEX idx_ActorUserId USING BTREE (ActorUserId),
+    INDEX idx_Action USING BTREE (Action),
+    INDEX idx_TargetType_TargetId USING BTREE (TargetType, TargetId)
+);
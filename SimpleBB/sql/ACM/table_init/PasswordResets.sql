@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS PasswordResets
+(
+    Id                  bigint AUTO_INCREMENT NOT NULL,
+    UserId              bigint                NOT NULL,
+    TimeOfCreation      datetime              NOT NULL DEFAULT NOW(),
+    RequestId           varchar(255)          NOT NULL,
+    UserIPAB            binary(16)            NOT NULL,
+    CaptchaId           varchar(255)          NOT NULL,
+    IsVerifiedByCaptcha boolean               NOT NULL DEFAULT FALSE,
+    VerificationCode    varchar(255)                   DEFAULT NULL,
+    IsEmailSent         boolean               NOT NULL DEFAULT FALSE,
+    IsVerifiedByEmail   boolean               NOT NULL DEFAULT FALSE,
+    PRIMARY KEY (Id),
+    INDEX idx_UserId USING BTREE (UserId),
+    INDEX idx_TimeOfCreation USING BTREE (TimeOfCreation),
+    INDEX idx_RequestId USING BTREE (RequestId),
+    INDEX idx_IsVerifiedByCaptcha USING BTREE (IsVerifiedByCaptcha),
+    INDEX idx_VerificationCode USING BTREE (VerificationCode),
+    INDEX idx_IsEmailSent USING BTREE (IsEmailSent),
+    INDEX idx_IsVerifiedByEmail USING BTREE (IsVerifiedByEmail)
+);
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS Permissions
+(
+    Id             bigint AUTO_INCREMENT NOT NULL,
+    UserId         bigint                NOT NULL,
+    Permission     tinyint               NOT NULL,
+    ScopeType      tinyint               NOT NULL,
+    ScopeId        bigint                NOT NULL DEFAULT 0,
+    GrantorUserId  bigint                NOT NULL DEFAULT 0,
+    TimeOfCreation datetime              NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (Id),
+    UNIQUE INDEX idx_UserId_Permission_ScopeType_ScopeId USING BTREE (UserId, Permission, ScopeType, ScopeId),
+    INDEX idx_ScopeType_ScopeId USING BTREE (ScopeType, ScopeId)
+);
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS TotpRecoveryCodes
+(
+    Id       bigint AUTO_INCREMENT NOT NULL,
+    UserId   bigint                NOT NULL,
+    CodeHash char(64)              NOT NULL,
+    PRIMARY KEY (Id),
+    INDEX idx_UserId USING BTREE (UserId),
+    UNIQUE INDEX idx_UserId_CodeHash USING BTREE (UserId, CodeHash)
+);
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS TotpSecrets
+(
+    UserId         bigint        NOT NULL,
+    Secret         varbinary(64) NOT NULL,
+    IsEnabled      boolean       NOT NULL DEFAULT FALSE,
+    TimeOfCreation datetime      NOT NULL DEFAULT NOW(),
+    LastUsedStep   bigint        NOT NULL DEFAULT 0,
+    PRIMARY KEY (UserId),
+    INDEX idx_IsEnabled USING BTREE (IsEnabled)
+);
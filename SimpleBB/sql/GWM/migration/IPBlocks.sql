@@ -0,0 +1,25 @@
+-- Migration of blocked IP addresses to blocked IP address ranges.
+--
+-- Blocks of single IP addresses were stored in the IPAddresses table, where
+-- the Time column was the expiration time of a block. Now blocks are ranges of
+-- addresses in the IPBlocks table. Active blocks are moved into blocks of a
+-- single address, i.e. '/32' ranges for IPv4 and '/128' ranges for IPv6, the
+-- expired ones are dropped.
+--
+-- Start the GWM service once before running this script, so that the IPBlocks
+-- table is created. Table names are shown without a prefix, add the prefix
+-- from the settings if it is used, e.g. 'v1_IPBlocks'.
+
+INSERT IGNORE INTO IPBlocks (Cidr, FirstIPAB, LastIPAB, ExpirationTime)
+SELECT IF(IS_IPV4_MAPPED(UserIPAB),
+          CONCAT(INET6_NTOA(SUBSTRING(UserIPAB, 13, 4)), '/32'),
+          CONCAT(INET6_NTOA(UserIPAB), '/128')),
+       UserIPAB,
+       UserIPAB,
+       MAX(Time)
+FROM IPAddresses
+WHERE (UserIPAB IS NOT NULL)
+  AND (Time > Now())
+GROUP BY UserIPAB;
+
+DROP TABLE IPAddresses;
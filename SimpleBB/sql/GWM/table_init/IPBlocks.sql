@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS IPBlocks
+(
+    -- Temporary block of a range of IP addresses --
+    Id             bigint AUTO_INCREMENT NOT NULL,
+    Cidr           varchar(64)           NOT NULL,
+    FirstIPAB      binary(16)            NOT NULL,
+    LastIPAB       binary(16)            NOT NULL,
+    TimeOfCreation datetime              NOT NULL DEFAULT NOW(),
+    ExpirationTime datetime              NOT NULL,
+    PRIMARY KEY (Id),
+    UNIQUE INDEX idx_Cidr USING BTREE (Cidr),
+    INDEX idx_FirstIPAB_LastIPAB USING BTREE (FirstIPAB, LastIPAB),
+    INDEX idx_ExpirationTime USING BTREE (ExpirationTime)
+);
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS IPListEntries
+(
+    -- Permanent entry of the allow-list or the deny-list --
+    Id             bigint AUTO_INCREMENT NOT NULL,
+    ListType       tinyint               NOT NULL,
+    Cidr           varchar(64)           NOT NULL,
+    FirstIPAB      binary(16)            NOT NULL,
+    LastIPAB       binary(16)            NOT NULL,
+    Comment        varchar(255)          NOT NULL,
+    TimeOfCreation datetime              NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (Id),
+    UNIQUE INDEX idx_ListType_Cidr USING BTREE (ListType, Cidr),
+    INDEX idx_FirstIPAB_LastIPAB USING BTREE (FirstIPAB, LastIPAB)
+);
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS SubnetOffences
+(
+    -- Number of recent blocks of addresses in a subnet --
+    Subnet          varchar(64) NOT NULL,
+    OffencesCount   int         NOT NULL DEFAULT 1,
+    LastOffenceTime datetime    NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (Subnet),
+    INDEX idx_LastOffenceTime USING BTREE (LastOffenceTime)
+);
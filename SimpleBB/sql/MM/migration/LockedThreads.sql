@@ -0,0 +1,10 @@
+-- Migration adding locked threads.
+--
+-- Table names are shown without a prefix, add the prefix from the settings if
+-- it is used, e.g. 'v1_Threads'.
+
+ALTER TABLE Threads
+    ADD COLUMN IsLocked boolean NOT NULL DEFAULT FALSE AFTER Messages;
+
+ALTER TABLE DeletedThreads
+    ADD COLUMN IsLocked boolean NOT NULL DEFAULT FALSE AFTER Messages;
@@ -0,0 +1,10 @@
+-- Migration adding rendered HTML of messages.
+--
+-- Table names are shown without a prefix, add the prefix from the settings if
+-- it is used, e.g. 'v1_Messages'.
+--
+-- Existing messages get an empty HTML. The Message module renders such
+-- messages when it starts.
+
+ALTER TABLE Messages
+    ADD COLUMN TextHtml mediumtext NOT NULL AFTER TextChecksum;
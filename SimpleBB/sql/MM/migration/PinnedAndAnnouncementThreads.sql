@@ -0,0 +1,13 @@
+-- Migration adding pinned threads and announcements.
+--
+-- Table names are shown without a prefix, add the prefix from the settings if
+-- it is used, e.g. 'v1_Threads'.
+
+ALTER TABLE Threads
+    ADD COLUMN IsPinned boolean NOT NULL DEFAULT FALSE AFTER IsLocked,
+    ADD COLUMN IsAnnouncement boolean NOT NULL DEFAULT FALSE AFTER IsPinned,
+    ADD INDEX idx_ForumId USING BTREE (ForumId);
+
+ALTER TABLE DeletedThreads
+    ADD COLUMN IsPinned boolean NOT NULL DEFAULT FALSE AFTER IsLocked,
+    ADD COLUMN IsAnnouncement boolean NOT NULL DEFAULT FALSE AFTER IsPinned;
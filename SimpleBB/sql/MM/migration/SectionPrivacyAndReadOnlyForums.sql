@@ -0,0 +1,10 @@
+-- Migration adding private sections and read-only forums.
+--
+-- Table names are shown without a prefix, add the prefix from the settings if
+-- it is used, e.g. 'v1_Sections'.
+
+ALTER TABLE Sections
+    ADD COLUMN IsPrivate boolean NOT NULL DEFAULT FALSE AFTER Name;
+
+ALTER TABLE Forums
+    ADD COLUMN IsReadOnly boolean NOT NULL DEFAULT FALSE AFTER Threads;
@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS Attachments
+(
+    Id            bigint AUTO_INCREMENT NOT NULL,
+    MessageId     bigint                NOT NULL,
+    Name          varchar(255)          NOT NULL,
+    ContentType   varchar(255)          NOT NULL,
+    Size          bigint                NOT NULL,
+
+    -- Hashes of the file and of its thumbnail in the blob store --
+    BlobHash      char(64)              NOT NULL,
+    ThumbnailHash char(64),
+    ToC           datetime              NOT NULL,
+
+    PRIMARY KEY (Id),
+    INDEX idx_MessageId USING BTREE (MessageId),
+    INDEX idx_BlobHash USING BTREE (BlobHash),
+    INDEX idx_ThumbnailHash USING BTREE (ThumbnailHash)
+);
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS Blobs
+(
+    -- SHA-256 hash of contents, i.e. the address in the blob store --
+    Hash char(64) NOT NULL,
+    Size bigint   NOT NULL,
+    ToC  datetime NOT NULL,
+
+    PRIMARY KEY (Hash)
+);
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS ConversationMembers
+(
+    ConversationId    bigint NOT NULL,
+    UserId            bigint NOT NULL,
+
+    -- Messages up to this one are read by the member --
+    LastReadMessageId bigint NOT NULL DEFAULT 0,
+
+    PRIMARY KEY (ConversationId, UserId),
+    INDEX idx_UserId USING BTREE (UserId)
+);
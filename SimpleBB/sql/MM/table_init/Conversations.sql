@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS Conversations
+(
+    Id              bigint AUTO_INCREMENT NOT NULL,
+    Subject         varchar(255)          NOT NULL,
+
+    -- Time of the latest message, it is used to sort the inbox --
+    LastMessageTime datetime              NOT NULL,
+
+    -- Meta data --
+    CreatorUserId   bigint                NOT NULL,
+    CreatorTime     datetime              NOT NULL,
+
+    PRIMARY KEY (Id)
+);
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS DeletedForums
+(
+    Id            bigint       NOT NULL,
+    SectionId     bigint       NOT NULL,
+    Name          varchar(255) NOT NULL,
+    Threads       json,
+    IsReadOnly    boolean      NOT NULL DEFAULT FALSE,
+
+    -- Meta data --
+    CreatorUserId bigint       NOT NULL,
+    CreatorTime   datetime     NOT NULL,
+    EditorUserId  bigint,
+    EditorTime    datetime,
+
+    PRIMARY KEY (Id)
+);
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS DeletedMessages
+(
+    Id            bigint         NOT NULL,
+    ThreadId      bigint         NOT NULL,
+    Text          varchar(16368) NOT NULL,
+    TextChecksum  varbinary(4)   NOT NULL,
+    TextHtml      mediumtext     NOT NULL,
+
+    -- Meta data --
+    CreatorUserId bigint         NOT NULL,
+    CreatorTime   datetime       NOT NULL,
+    EditorUserId  bigint,
+    EditorTime    datetime,
+
+    PRIMARY KEY (Id)
+);
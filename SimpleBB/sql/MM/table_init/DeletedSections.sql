@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS DeletedSections
+(
+    Id            bigint       NOT NULL,
+    Parent        bigint,
+    ChildType     tinyint DEFAULT 3,
+    Children      json,
+    Name          varchar(255) NOT NULL,
+    IsPrivate     boolean      NOT NULL DEFAULT FALSE,
+
+    -- Meta data --
+    CreatorUserId bigint       NOT NULL,
+    CreatorTime   datetime     NOT NULL,
+    EditorUserId  bigint,
+    EditorTime    datetime,
+
+    PRIMARY KEY (Id)
+);
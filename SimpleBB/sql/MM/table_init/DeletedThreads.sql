@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS DeletedThreads
+(
+    Id             bigint       NOT NULL,
+    ForumId        bigint       NOT NULL,
+    Name           varchar(255) NOT NULL,
+    Messages       json,
+    IsLocked       boolean      NOT NULL DEFAULT FALSE,
+    IsPinned       boolean      NOT NULL DEFAULT FALSE,
+    IsAnnouncement boolean      NOT NULL DEFAULT FALSE,
+
+    -- Meta data --
+    CreatorUserId  bigint       NOT NULL,
+    CreatorTime    datetime     NOT NULL,
+    EditorUserId   bigint,
+    EditorTime     datetime,
+
+    PRIMARY KEY (Id)
+);
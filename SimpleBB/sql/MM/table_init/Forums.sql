@@ -4,6 +4,7 @@ CREATE TABLE IF NOT EXISTS Forums
     SectionId     bigint                NOT NULL,
     Name          varchar(255)          NOT NULL,
     Threads       json,
+    IsReadOnly    boolean               NOT NULL DEFAULT FALSE,
 
     -- Meta data --
     CreatorUserId bigint                NOT NULL,
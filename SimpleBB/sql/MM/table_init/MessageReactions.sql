@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS MessageReactions
+(
+    MessageId    bigint                                        NOT NULL,
+    UserId       bigint                                        NOT NULL,
+
+    -- Binary collation is used while many emojis are equal in other ones --
+    Emoji        varchar(32) CHARACTER SET utf8mb4 COLLATE utf8mb4_bin NOT NULL,
+
+    -- Author of the message --
+    AuthorUserId bigint                                        NOT NULL,
+
+    -- Weight of the reaction at the moment of its creation --
+    Weight       int                                           NOT NULL,
+    ToC          datetime                                      NOT NULL,
+
+    PRIMARY KEY (MessageId, UserId, Emoji)
+);
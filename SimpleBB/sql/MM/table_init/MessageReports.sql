@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS MessageReports
+(
+    Id             bigint AUTO_INCREMENT NOT NULL,
+    MessageId      bigint                NOT NULL,
+    ThreadId       bigint                NOT NULL,
+
+    -- Author of the message --
+    AuthorUserId   bigint                NOT NULL,
+
+    ReporterUserId bigint                NOT NULL,
+    Reason         tinyint               NOT NULL,
+    Comment        varchar(255)          NOT NULL,
+    ToC            datetime              NOT NULL,
+
+    -- Decision of a moderator, NULL while the report is open --
+    DecisionId     bigint,
+
+    PRIMARY KEY (Id),
+    INDEX idx_MessageId USING BTREE (MessageId),
+    INDEX idx_DecisionId USING BTREE (DecisionId)
+);
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS MessageRevisions
+(
+    Id           bigint AUTO_INCREMENT NOT NULL,
+    MessageId    bigint                NOT NULL,
+    Text         varchar(16368)        NOT NULL,
+    TextChecksum varbinary(4)          NOT NULL,
+
+    -- Author of this revision of the text --
+    EditorUserId bigint                NOT NULL,
+    EditorTime   datetime              NOT NULL,
+
+    PRIMARY KEY (Id),
+    INDEX idx_MessageId USING BTREE (MessageId)
+);
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS MessageWords
+(
+    -- Binary collation is used while words differing in accents are equal in
+    -- other ones and a message may contain both of them --
+    Word      varchar(64) CHARACTER SET utf8mb4 COLLATE utf8mb4_bin NOT NULL,
+    MessageId bigint                                                NOT NULL,
+    Frequency int                                                   NOT NULL,
+
+    PRIMARY KEY (Word, MessageId),
+    INDEX idx_MessageId USING BTREE (MessageId)
+);
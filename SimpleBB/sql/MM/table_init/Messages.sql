@@ -4,6 +4,7 @@ CREATE TABLE IF NOT EXISTS Messages
     ThreadId      bigint                NOT NULL, -- 8B --
     Text          varchar(16368)        NOT NULL,
     TextChecksum  varbinary(4)          NOT NULL, -- 4B --
+    TextHtml      mediumtext            NOT NULL,
 
     -- Meta data --
     CreatorUserId bigint                NOT NULL, -- 8B --
@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS ModerationDecisions
+(
+    Id              bigint AUTO_INCREMENT NOT NULL,
+    MessageId       bigint                NOT NULL,
+    ThreadId        bigint                NOT NULL,
+
+    -- Author of the message --
+    AuthorUserId    bigint                NOT NULL,
+
+    Action          tinyint               NOT NULL,
+    Comment         varchar(255)          NOT NULL,
+
+    -- Number of reports resolved by the decision --
+    ReportsCount    int                   NOT NULL,
+
+    ModeratorUserId bigint                NOT NULL,
+    ToC             datetime              NOT NULL,
+
+    PRIMARY KEY (Id),
+    INDEX idx_ToC USING BTREE (ToC)
+);
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS PollOptions
+(
+    Id     bigint AUTO_INCREMENT NOT NULL,
+    PollId bigint                NOT NULL,
+    Text   varchar(255)          NOT NULL,
+
+    PRIMARY KEY (Id),
+    INDEX idx_PollId USING BTREE (PollId)
+);
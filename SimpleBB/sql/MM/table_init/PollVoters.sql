@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS PollVoters
+(
+    -- A user may vote only once in each poll --
+    PollId bigint   NOT NULL,
+    UserId bigint   NOT NULL,
+    ToC    datetime NOT NULL,
+
+    -- Time of the last change of the vote --
+    ToE    datetime,
+
+    PRIMARY KEY (PollId, UserId)
+);
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS PollVotes
+(
+    -- Options chosen by a voter --
+    PollId   bigint NOT NULL,
+    UserId   bigint NOT NULL,
+    OptionId bigint NOT NULL,
+
+    PRIMARY KEY (PollId, UserId, OptionId),
+    INDEX idx_OptionId USING BTREE (OptionId)
+);
@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS Polls
+(
+    Id               bigint AUTO_INCREMENT NOT NULL,
+    ThreadId         bigint                NOT NULL,
+    Question         varchar(255)          NOT NULL,
+    IsMultipleChoice boolean               NOT NULL DEFAULT FALSE,
+    AreResultsHidden boolean               NOT NULL DEFAULT FALSE,
+    ClosingTime      datetime,
+    IsClosed         boolean               NOT NULL DEFAULT FALSE,
+    ToC              datetime              NOT NULL,
+
+    PRIMARY KEY (Id),
+    UNIQUE INDEX idx_ThreadId USING BTREE (ThreadId),
+    INDEX idx_IsClosed_ClosingTime USING BTREE (IsClosed, ClosingTime)
+);
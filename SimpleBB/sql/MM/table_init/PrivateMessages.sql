@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS PrivateMessages
+(
+    Id             bigint AUTO_INCREMENT NOT NULL,
+    ConversationId bigint                NOT NULL,
+    Text           varchar(16368)        NOT NULL,
+
+    -- Meta data --
+    CreatorUserId  bigint                NOT NULL,
+    CreatorTime    datetime              NOT NULL,
+
+    PRIMARY KEY (Id),
+    INDEX idx_ConversationId USING BTREE (ConversationId)
+);
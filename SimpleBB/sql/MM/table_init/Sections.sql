@@ -5,6 +5,7 @@ CREATE TABLE IF NOT EXISTS Sections
     ChildType     tinyint DEFAULT 3,
     Children      json,
     Name          varchar(255)          NOT NULL,
+    IsPrivate     boolean               NOT NULL DEFAULT FALSE,
 
     -- Meta data --
     CreatorUserId bigint                NOT NULL,
@@ -1,16 +1,20 @@
 CREATE TABLE IF NOT EXISTS Threads
 (
-    Id            bigint AUTO_INCREMENT NOT NULL,
-    ForumId       bigint                NOT NULL,
-    Name          varchar(255)          NOT NULL,
-    Messages      json,
+    Id             bigint AUTO_INCREMENT NOT NULL,
+    ForumId        bigint                NOT NULL,
+    Name           varchar(255)          NOT NULL,
+    Messages       json,
+    IsLocked       boolean               NOT NULL DEFAULT FALSE,
+    IsPinned       boolean               NOT NULL DEFAULT FALSE,
+    IsAnnouncement boolean               NOT NULL DEFAULT FALSE,
 
     -- Meta data --
-    CreatorUserId bigint                NOT NULL,
-    CreatorTime   datetime              NOT NULL,
-    EditorUserId  bigint,
-    EditorTime    datetime,
+    CreatorUserId  bigint                NOT NULL,
+    CreatorTime    datetime              NOT NULL,
+    EditorUserId   bigint,
+    EditorTime     datetime,
 
-    PRIMARY KEY (Id)
+    PRIMARY KEY (Id),
+    INDEX idx_ForumId USING BTREE (ForumId)
     /* TODO: Indices */
 );
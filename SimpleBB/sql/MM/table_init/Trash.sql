@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS Trash
+(
+    Id         bigint AUTO_INCREMENT NOT NULL,
+    ObjectType tinyint               NOT NULL,
+    ObjectId   bigint                NOT NULL,
+    Name       varchar(255)          NOT NULL,
+
+    -- Place of the object in its parent's list --
+    ParentId   bigint,
+    Position   int                   NOT NULL,
+    PreviousId bigint,
+
+    DeletedBy  bigint                NOT NULL,
+    DeletedAt  datetime              NOT NULL,
+
+    PRIMARY KEY (Id),
+    UNIQUE INDEX idx_Object USING BTREE (ObjectType, ObjectId),
+    INDEX idx_DeletedAt USING BTREE (DeletedAt)
+);
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS UserBlocks
+(
+    -- User who blocks private messages of another user --
+    UserId        bigint   NOT NULL,
+    BlockedUserId bigint   NOT NULL,
+    ToC           datetime NOT NULL,
+
+    PRIMARY KEY (UserId, BlockedUserId)
+);
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS UserReputations
+(
+    UserId     bigint   NOT NULL,
+
+    -- Sum of weights of reactions received by the user --
+    Reputation bigint   NOT NULL,
+    ToU        datetime NOT NULL,
+
+    PRIMARY KEY (UserId)
+);
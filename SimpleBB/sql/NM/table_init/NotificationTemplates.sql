@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS NotificationTemplates
+(
+    Id              bigint AUTO_INCREMENT NOT NULL,
+    Name            varchar(255)          NOT NULL,
+    SystemEventType tinyint unsigned      NOT NULL,
+    Language        varchar(16)           NOT NULL,
+    FormatString    text                  NOT NULL,
+
+    PRIMARY KEY (Id),
+    UNIQUE INDEX idx_SystemEventType_Language USING BTREE (SystemEventType, Language)
+);
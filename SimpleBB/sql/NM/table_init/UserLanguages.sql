@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS UserLanguages
+(
+    UserId   bigint      NOT NULL,
+    Language varchar(16) NOT NULL,
+
+    PRIMARY KEY (UserId)
+);
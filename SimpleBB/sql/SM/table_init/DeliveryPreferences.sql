@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS DeliveryPreferences
+(
+    Id             bigint AUTO_INCREMENT NOT NULL,
+    UserId         bigint                NOT NULL,
+    DeliveryMode   tinyint               NOT NULL,
+    LastDigestTime datetime              NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (Id),
+    UNIQUE INDEX idx_UserId USING BTREE (UserId),
+    INDEX idx_DeliveryMode USING BTREE (DeliveryMode)
+);
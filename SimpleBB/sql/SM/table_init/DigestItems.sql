@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS DigestItems
+(
+    Id             bigint AUTO_INCREMENT NOT NULL,
+    UserId         bigint                NOT NULL,
+    ThreadId       bigint                NOT NULL,
+    ThreadName     varchar(255)          NOT NULL,
+    ForumId        bigint                NOT NULL,
+    ForumName      varchar(255)          NOT NULL,
+    TimeOfCreation datetime              NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (Id),
+    INDEX idx_UserId USING BTREE (UserId)
+);
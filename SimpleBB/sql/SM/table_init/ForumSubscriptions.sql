@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS ForumSubscriptions
+(
+    Id      bigint AUTO_INCREMENT NOT NULL,
+    ForumId bigint                NOT NULL,
+    UserId  bigint                NOT NULL,
+
+    PRIMARY KEY (Id),
+    UNIQUE INDEX idx_ForumId_UserId USING BTREE (ForumId, UserId),
+    INDEX idx_UserId USING BTREE (UserId)
+);
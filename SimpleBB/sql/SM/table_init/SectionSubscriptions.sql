@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS SectionSubscriptions
+(
+    Id        bigint AUTO_INCREMENT NOT NULL,
+    SectionId bigint                NOT NULL,
+    UserId    bigint                NOT NULL,
+
+    PRIMARY KEY (Id),
+    UNIQUE INDEX idx_SectionId_UserId USING BTREE (SectionId, UserId),
+    INDEX idx_UserId USING BTREE (UserId)
+);
@@ -0,0 +1,808 @@
+package main
+
+import (
+	"bufio"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	ul "github.com/vault-thirteen/SimpleBB/pkg/common/models/UidList"
+	"github.com/vault-thirteen/SimpleBB/pkg/common/models/app"
+	cmb "github.com/vault-thirteen/SimpleBB/pkg/common/models/base"
+	ae "github.com/vault-thirteen/auxie/errors"
+)
+
+// Archive is a text file of JSON lines. The first line is a header, every
+// other line is a row of a table. Rows keep their identifiers, so lists of
+// identifiers stored in rows stay valid after import.
+const (
+	ArchiveFormat  = "SimpleBB"
+	ArchiveVersion = 4
+
+	// ArchiveLineMaxSize is the maximal size of a line in bytes. Messages are
+	// stored together with their HTML, so lines may be long.
+	ArchiveLineMaxSize = 64 * 1024 * 1024
+)
+
+const (
+	ErrArchiveIsEmpty     = "archive is empty"
+	ErrFArchiveFormat     = "unsupported archive format: %v, version %v"
+	ErrFUnknownTable      = "unknown table: %v"
+	ErrFTableIsNotEmpty   = "table is not empty: %v"
+	ErrFColumnIsNotSet    = "column is not set: %v.%v"
+	ErrFColumnValue       = "bad value of column %v.%v: %v"
+	ErrFUnknownColumnKind = "unknown kind of column: %v"
+	ErrFLine              = "line %v: %w"
+)
+
+// IDatabase is a database of a module.
+type IDatabase interface {
+	DB() *sql.DB
+	PrefixTableName(tableName string) (tableNameFull string)
+}
+
+// ArchiveHeader is the first line of an archive.
+type ArchiveHeader struct {
+	Format    string    `json:"format"`
+	Version   int       `json:"version"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// ArchiveRecord is a row of a table.
+type ArchiveRecord struct {
+	Table string                     `json:"table"`
+	Row   map[string]json.RawMessage `json:"row"`
+}
+
+// ColumnKind is a type of value stored in a column.
+type ColumnKind byte
+
+const (
+	// ColumnKind_Int is an integer or a boolean value.
+	ColumnKind_Int = ColumnKind(1)
+
+	// ColumnKind_Text is a text.
+	ColumnKind_Text = ColumnKind(2)
+
+	// ColumnKind_Time is a date and time.
+	ColumnKind_Time = ColumnKind(3)
+
+	// ColumnKind_Binary is an array of bytes, it is archived as Base64.
+	ColumnKind_Binary = ColumnKind(4)
+
+	// ColumnKind_UidList is a JSON list of identifiers.
+	ColumnKind_UidList = ColumnKind(5)
+)
+
+type Column struct {
+	Name string
+	Kind ColumnKind
+}
+
+// Table is an archived table of a module's database.
+type Table struct {
+	Module  string
+	Name    string
+	Columns []Column
+	OrderBy string
+
+	// Values of columns which are not archived. These values are set when
+	// rows are imported.
+	Defaults map[string]any
+}
+
+// FullName is a name of the table used in archives.
+func (t *Table) FullName() string {
+	return t.Module + "." + t.Name
+}
+
+// Tables lists the archived tables. Secrets of users are not archived, users
+// must reset their passwords after import. Index of search words is archived
+// to keep search working. Attachments are archived as records only, contents
+// of their files are kept in the blob store of the Message module, and its
+// folder must be copied together with the archive. The trash, reports of
+// messages with moderation decisions and the audit log are not archived.
+var Tables = []Table{
+	// ACM.
+	{
+		Module: app.ServiceShortName_ACM,
+		Name:   "Users",
+		Columns: []Column{
+			{"Id", ColumnKind_Int},
+			{"PreRegTime", ColumnKind_Time},
+			{"Email", ColumnKind_Text},
+			{"Name", ColumnKind_Text},
+			{"ApprovalTime", ColumnKind_Time},
+			{"RegTime", ColumnKind_Time},
+			{"CanLogIn", ColumnKind_Int},
+			{"LastBadLogInTime", ColumnKind_Time},
+			{"BanTime", ColumnKind_Time},
+			{"LastBadActionTime", ColumnKind_Time},
+		},
+		OrderBy:  "Id",
+		Defaults: map[string]any{"Password": []byte{}},
+	},
+	{
+		Module: app.ServiceShortName_ACM,
+		Name:   "Permissions",
+		Columns: []Column{
+			{"Id", ColumnKind_Int},
+			{"UserId", ColumnKind_Int},
+			{"Permission", ColumnKind_Int},
+			{"ScopeType", ColumnKind_Int},
+			{"ScopeId", ColumnKind_Int},
+			{"GrantorUserId", ColumnKind_Int},
+			{"TimeOfCreation", ColumnKind_Time},
+		},
+		OrderBy: "Id",
+	},
+
+	// MM.
+	{
+		Module: app.ServiceShortName_MM,
+		Name:   "Sections",
+		Columns: []Column{
+			{"Id", ColumnKind_Int},
+			{"Parent", ColumnKind_Int},
+			{"ChildType", ColumnKind_Int},
+			{"Children", ColumnKind_UidList},
+			{"Name", ColumnKind_Text},
+			{"IsPrivate", ColumnKind_Int},
+			{"CreatorUserId", ColumnKind_Int},
+			{"CreatorTime", ColumnKind_Time},
+			{"EditorUserId", ColumnKind_Int},
+			{"EditorTime", ColumnKind_Time},
+		},
+		OrderBy: "Id",
+	},
+	{
+		Module: app.ServiceShortName_MM,
+		Name:   "Forums",
+		Columns: []Column{
+			{"Id", ColumnKind_Int},
+			{"SectionId", ColumnKind_Int},
+			{"Name", ColumnKind_Text},
+			{"Threads", ColumnKind_UidList},
+			{"IsReadOnly", ColumnKind_Int},
+			{"CreatorUserId", ColumnKind_Int},
+			{"CreatorTime", ColumnKind_Time},
+			{"EditorUserId", ColumnKind_Int},
+			{"EditorTime", ColumnKind_Time},
+		},
+		OrderBy: "Id",
+	},
+	{
+		Module: app.ServiceShortName_MM,
+		Name:   "Threads",
+		Columns: []Column{
+			{"Id", ColumnKind_Int},
+			{"ForumId", ColumnKind_Int},
+			{"Name", ColumnKind_Text},
+			{"Messages", ColumnKind_UidList},
+			{"IsLocked", ColumnKind_Int},
+			{"IsPinned", ColumnKind_Int},
+			{"IsAnnouncement", ColumnKind_Int},
+			{"CreatorUserId", ColumnKind_Int},
+			{"CreatorTime", ColumnKind_Time},
+			{"EditorUserId", ColumnKind_Int},
+			{"EditorTime", ColumnKind_Time},
+		},
+		OrderBy: "Id",
+	},
+	{
+		Module: app.ServiceShortName_MM,
+		Name:   "Messages",
+		Columns: []Column{
+			{"Id", ColumnKind_Int},
+			{"ThreadId", ColumnKind_Int},
+			{"Text", ColumnKind_Text},
+			{"TextChecksum", ColumnKind_Binary},
+			{"TextHtml", ColumnKind_Text},
+			{"CreatorUserId", ColumnKind_Int},
+			{"CreatorTime", ColumnKind_Time},
+			{"EditorUserId", ColumnKind_Int},
+			{"EditorTime", ColumnKind_Time},
+		},
+		OrderBy: "Id",
+	},
+	{
+		Module: app.ServiceShortName_MM,
+		Name:   "ThreadWords",
+		Columns: []Column{
+			{"Word", ColumnKind_Text},
+			{"ThreadId", ColumnKind_Int},
+			{"Frequency", ColumnKind_Int},
+		},
+		OrderBy: "ThreadId, Word",
+	},
+	{
+		Module: app.ServiceShortName_MM,
+		Name:   "MessageWords",
+		Columns: []Column{
+			{"Word", ColumnKind_Text},
+			{"MessageId", ColumnKind_Int},
+			{"Frequency", ColumnKind_Int},
+		},
+		OrderBy: "MessageId, Word",
+	},
+	{
+		Module: app.ServiceShortName_MM,
+		Name:   "MessageRevisions",
+		Columns: []Column{
+			{"Id", ColumnKind_Int},
+			{"MessageId", ColumnKind_Int},
+			{"Text", ColumnKind_Text},
+			{"TextChecksum", ColumnKind_Binary},
+			{"EditorUserId", ColumnKind_Int},
+			{"EditorTime", ColumnKind_Time},
+		},
+		OrderBy: "Id",
+	},
+	{
+		Module: app.ServiceShortName_MM,
+		Name:   "Blobs",
+		Columns: []Column{
+			{"Hash", ColumnKind_Text},
+			{"Size", ColumnKind_Int},
+			{"ToC", ColumnKind_Time},
+		},
+		OrderBy: "Hash",
+	},
+	{
+		Module: app.ServiceShortName_MM,
+		Name:   "Attachments",
+		Columns: []Column{
+			{"Id", ColumnKind_Int},
+			{"MessageId", ColumnKind_Int},
+			{"Name", ColumnKind_Text},
+			{"ContentType", ColumnKind_Text},
+			{"Size", ColumnKind_Int},
+			{"BlobHash", ColumnKind_Text},
+			{"ThumbnailHash", ColumnKind_Text},
+			{"ToC", ColumnKind_Time},
+		},
+		OrderBy: "Id",
+	},
+	{
+		Module: app.ServiceShortName_MM,
+		Name:   "Polls",
+		Columns: []Column{
+			{"Id", ColumnKind_Int},
+			{"ThreadId", ColumnKind_Int},
+			{"Question", ColumnKind_Text},
+			{"IsMultipleChoice", ColumnKind_Int},
+			{"AreResultsHidden", ColumnKind_Int},
+			{"ClosingTime", ColumnKind_Time},
+			{"IsClosed", ColumnKind_Int},
+			{"ToC", ColumnKind_Time},
+		},
+		OrderBy: "Id",
+	},
+	{
+		Module: app.ServiceShortName_MM,
+		Name:   "PollOptions",
+		Columns: []Column{
+			{"Id", ColumnKind_Int},
+			{"PollId", ColumnKind_Int},
+			{"Text", ColumnKind_Text},
+		},
+		OrderBy: "Id",
+	},
+	{
+		Module: app.ServiceShortName_MM,
+		Name:   "PollVoters",
+		Columns: []Column{
+			{"PollId", ColumnKind_Int},
+			{"UserId", ColumnKind_Int},
+			{"ToC", ColumnKind_Time},
+			{"ToE", ColumnKind_Time},
+		},
+		OrderBy: "PollId, UserId",
+	},
+	{
+		Module: app.ServiceShortName_MM,
+		Name:   "PollVotes",
+		Columns: []Column{
+			{"PollId", ColumnKind_Int},
+			{"UserId", ColumnKind_Int},
+			{"OptionId", ColumnKind_Int},
+		},
+		OrderBy: "PollId, UserId, OptionId",
+	},
+	{
+		Module: app.ServiceShortName_MM,
+		Name:   "MessageReactions",
+		Columns: []Column{
+			{"MessageId", ColumnKind_Int},
+			{"UserId", ColumnKind_Int},
+			{"Emoji", ColumnKind_Text},
+			{"AuthorUserId", ColumnKind_Int},
+			{"Weight", ColumnKind_Int},
+			{"ToC", ColumnKind_Time},
+		},
+		OrderBy: "MessageId, UserId, Emoji",
+	},
+	{
+		Module: app.ServiceShortName_MM,
+		Name:   "UserReputations",
+		Columns: []Column{
+			{"UserId", ColumnKind_Int},
+			{"Reputation", ColumnKind_Int},
+			{"ToU", ColumnKind_Time},
+		},
+		OrderBy: "UserId",
+	},
+	{
+		Module: app.ServiceShortName_MM,
+		Name:   "Conversations",
+		Columns: []Column{
+			{"Id", ColumnKind_Int},
+			{"Subject", ColumnKind_Text},
+			{"LastMessageTime", ColumnKind_Time},
+			{"CreatorUserId", ColumnKind_Int},
+			{"CreatorTime", ColumnKind_Time},
+		},
+		OrderBy: "Id",
+	},
+	{
+		Module: app.ServiceShortName_MM,
+		Name:   "ConversationMembers",
+		Columns: []Column{
+			{"ConversationId", ColumnKind_Int},
+			{"UserId", ColumnKind_Int},
+			{"LastReadMessageId", ColumnKind_Int},
+		},
+		OrderBy: "ConversationId, UserId",
+	},
+	{
+		Module: app.ServiceShortName_MM,
+		Name:   "PrivateMessages",
+		Columns: []Column{
+			{"Id", ColumnKind_Int},
+			{"ConversationId", ColumnKind_Int},
+			{"Text", ColumnKind_Text},
+			{"CreatorUserId", ColumnKind_Int},
+			{"CreatorTime", ColumnKind_Time},
+		},
+		OrderBy: "Id",
+	},
+	{
+		Module: app.ServiceShortName_MM,
+		Name:   "UserBlocks",
+		Columns: []Column{
+			{"UserId", ColumnKind_Int},
+			{"BlockedUserId", ColumnKind_Int},
+			{"ToC", ColumnKind_Time},
+		},
+		OrderBy: "UserId, BlockedUserId",
+	},
+
+	// NM.
+	{
+		Module: app.ServiceShortName_NM,
+		Name:   "Notifications",
+		Columns: []Column{
+			{"Id", ColumnKind_Int},
+			{"UserId", ColumnKind_Int},
+			{"Text", ColumnKind_Text},
+			{"ToC", ColumnKind_Time},
+			{"IsRead", ColumnKind_Int},
+			{"ToR", ColumnKind_Time},
+		},
+		OrderBy: "Id",
+	},
+
+	// SM.
+	{
+		Module: app.ServiceShortName_SM,
+		Name:   "ThreadSubscriptions",
+		Columns: []Column{
+			{"Id", ColumnKind_Int},
+			{"ThreadId", ColumnKind_Int},
+			{"Users", ColumnKind_UidList},
+		},
+		OrderBy: "Id",
+	},
+	{
+		Module: app.ServiceShortName_SM,
+		Name:   "UserSubscriptions",
+		Columns: []Column{
+			{"Id", ColumnKind_Int},
+			{"UserId", ColumnKind_Int},
+			{"Threads", ColumnKind_UidList},
+		},
+		OrderBy: "Id",
+	},
+	{
+		Module: app.ServiceShortName_SM,
+		Name:   "ForumSubscriptions",
+		Columns: []Column{
+			{"Id", ColumnKind_Int},
+			{"ForumId", ColumnKind_Int},
+			{"UserId", ColumnKind_Int},
+		},
+		OrderBy: "Id",
+	},
+	{
+		Module: app.ServiceShortName_SM,
+		Name:   "SectionSubscriptions",
+		Columns: []Column{
+			{"Id", ColumnKind_Int},
+			{"SectionId", ColumnKind_Int},
+			{"UserId", ColumnKind_Int},
+		},
+		OrderBy: "Id",
+	},
+	{
+		Module: app.ServiceShortName_SM,
+		Name:   "DeliveryPreferences",
+		Columns: []Column{
+			{"Id", ColumnKind_Int},
+			{"UserId", ColumnKind_Int},
+			{"DeliveryMode", ColumnKind_Int},
+			{"LastDigestTime", ColumnKind_Time},
+		},
+		OrderBy: "Id",
+	},
+	{
+		Module: app.ServiceShortName_SM,
+		Name:   "DigestItems",
+		Columns: []Column{
+			{"Id", ColumnKind_Int},
+			{"UserId", ColumnKind_Int},
+			{"ThreadId", ColumnKind_Int},
+			{"ThreadName", ColumnKind_Text},
+			{"ForumId", ColumnKind_Int},
+			{"ForumName", ColumnKind_Text},
+			{"TimeOfCreation", ColumnKind_Time},
+		},
+		OrderBy: "Id",
+	},
+}
+
+// exportBoard writes all archived tables into an archive.
+func exportBoard(databases map[string]IDatabase, w io.Writer) (n int, err error) {
+	bw := bufio.NewWriter(w)
+	enc := json.NewEncoder(bw)
+	enc.SetEscapeHTML(false)
+
+	err = enc.Encode(ArchiveHeader{
+		Format:    ArchiveFormat,
+		Version:   ArchiveVersion,
+		CreatedAt: time.Now().UTC(),
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	var k int
+	for _, table := range Tables {
+		k, err = exportTable(databases[table.Module], &table, enc)
+		if err != nil {
+			return n, err
+		}
+
+		n += k
+	}
+
+	return n, bw.Flush()
+}
+
+func exportTable(dbo IDatabase, table *Table, enc *json.Encoder) (n int, err error) {
+	columnNames := make([]string, 0, len(table.Columns))
+	for _, column := range table.Columns {
+		columnNames = append(columnNames, column.Name)
+	}
+
+	query := fmt.Sprintf(`SELECT %s FROM %s ORDER BY %s;`, strings.Join(columnNames, ", "), dbo.PrefixTableName(table.Name), table.OrderBy)
+
+	var rows *sql.Rows
+	rows, err = dbo.DB().Query(query)
+	if err != nil {
+		return 0, err
+	}
+
+	defer func() {
+		derr := rows.Close()
+		if derr != nil {
+			err = ae.Combine(err, derr)
+		}
+	}()
+
+	var values = make([]any, len(table.Columns))
+	for i, column := range table.Columns {
+		values[i], err = newColumnDestination(column.Kind)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	var record = ArchiveRecord{Table: table.FullName()}
+	for rows.Next() {
+		err = rows.Scan(values...)
+		if err != nil {
+			return n, err
+		}
+
+		record.Row = make(map[string]json.RawMessage, len(table.Columns))
+		for i, column := range table.Columns {
+			record.Row[column.Name], err = encodeColumnValue(values[i])
+			if err != nil {
+				return n, fmt.Errorf(ErrFColumnValue, table.FullName(), column.Name, err)
+			}
+		}
+
+		err = enc.Encode(record)
+		if err != nil {
+			return n, err
+		}
+
+		n++
+	}
+
+	return n, rows.Err()
+}
+
+func newColumnDestination(kind ColumnKind) (dst any, err error) {
+	switch kind {
+	case ColumnKind_Int:
+		return new(sql.NullInt64), nil
+	case ColumnKind_Text:
+		return new(sql.NullString), nil
+	case ColumnKind_Time:
+		return new(sql.NullTime), nil
+	case ColumnKind_Binary:
+		return new([]byte), nil
+	case ColumnKind_UidList:
+		return new(ul.UidList), nil
+	default:
+		return nil, fmt.Errorf(ErrFUnknownColumnKind, kind)
+	}
+}
+
+// encodeColumnValue converts a scanned value into JSON. NULL becomes null.
+func encodeColumnValue(src any) (value json.RawMessage, err error) {
+	var v any
+	switch x := src.(type) {
+	case *sql.NullInt64:
+		if x.Valid {
+			v = x.Int64
+		}
+	case *sql.NullString:
+		if x.Valid {
+			v = x.String
+		}
+	case *sql.NullTime:
+		if x.Valid {
+			v = x.Time.UTC()
+		}
+	case *[]byte:
+		if *x != nil {
+			v = *x
+		}
+	case *ul.UidList:
+		// Scanner of the list keeps the previous value on NULL.
+		if *x != nil {
+			err = x.CheckIntegrity()
+			if err != nil {
+				return nil, err
+			}
+			v = *x
+			*x = nil
+		}
+	}
+
+	return json.Marshal(v)
+}
+
+// importBoard reads an archive and inserts its rows into the databases. All
+// archived tables must be empty.
+func importBoard(databases map[string]IDatabase, r io.Reader) (n int, err error) {
+	var tables = make(map[string]*Table, len(Tables))
+	for i := range Tables {
+		tables[Tables[i].FullName()] = &Tables[i]
+
+		err = mustBeEmptyTable(databases[Tables[i].Module], &Tables[i])
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), ArchiveLineMaxSize)
+
+	if !scanner.Scan() {
+		if scanner.Err() != nil {
+			return 0, scanner.Err()
+		}
+		return 0, errors.New(ErrArchiveIsEmpty)
+	}
+
+	var header ArchiveHeader
+	err = json.Unmarshal(scanner.Bytes(), &header)
+	if err != nil {
+		return 0, fmt.Errorf(ErrFLine, 1, err)
+	}
+
+	if (header.Format != ArchiveFormat) || (header.Version != ArchiveVersion) {
+		return 0, fmt.Errorf(ErrFArchiveFormat, header.Format, header.Version)
+	}
+
+	var statements = make(map[string]*sql.Stmt)
+	defer func() {
+		for _, st := range statements {
+			derr := st.Close()
+			if derr != nil {
+				err = ae.Combine(err, derr)
+			}
+		}
+	}()
+
+	var lineNumber = 1
+	var record ArchiveRecord
+	var table *Table
+	var args []any
+	var ok bool
+	for scanner.Scan() {
+		lineNumber++
+
+		record = ArchiveRecord{}
+		err = json.Unmarshal(scanner.Bytes(), &record)
+		if err != nil {
+			return n, fmt.Errorf(ErrFLine, lineNumber, err)
+		}
+
+		table, ok = tables[record.Table]
+		if !ok {
+			return n, fmt.Errorf(ErrFLine, lineNumber, fmt.Errorf(ErrFUnknownTable, record.Table))
+		}
+
+		args, err = table.decodeRow(record.Row)
+		if err != nil {
+			return n, fmt.Errorf(ErrFLine, lineNumber, err)
+		}
+
+		st := statements[record.Table]
+		if st == nil {
+			dbo := databases[table.Module]
+			st, err = dbo.DB().Prepare(table.insertQuery(dbo))
+			if err != nil {
+				return n, err
+			}
+
+			statements[record.Table] = st
+		}
+
+		_, err = st.Exec(args...)
+		if err != nil {
+			return n, fmt.Errorf(ErrFLine, lineNumber, err)
+		}
+
+		n++
+	}
+
+	return n, scanner.Err()
+}
+
+func mustBeEmptyTable(dbo IDatabase, table *Table) (err error) {
+	query := fmt.Sprintf(`SELECT COUNT(*) FROM %s;`, dbo.PrefixTableName(table.Name))
+
+	var n int
+	err = dbo.DB().QueryRow(query).Scan(&n)
+	if err != nil {
+		return err
+	}
+
+	if n > 0 {
+		return fmt.Errorf(ErrFTableIsNotEmpty, table.FullName())
+	}
+
+	return nil
+}
+
+// insertQuery makes a query inserting a row with archived columns and default
+// values of other columns.
+func (t *Table) insertQuery(dbo IDatabase) string {
+	columnNames := make([]string, 0, len(t.Columns)+len(t.Defaults))
+	for _, column := range t.Columns {
+		columnNames = append(columnNames, column.Name)
+	}
+	for _, name := range t.defaultColumnNames() {
+		columnNames = append(columnNames, name)
+	}
+
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(columnNames)), ", ")
+
+	return fmt.Sprintf(`INSERT INTO %s (%s) VALUES (%s);`, dbo.PrefixTableName(t.Name), strings.Join(columnNames, ", "), placeholders)
+}
+
+// defaultColumnNames lists columns having default values in a stable order.
+func (t *Table) defaultColumnNames() (names []string) {
+	names = make([]string, 0, len(t.Defaults))
+	for name := range t.Defaults {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// decodeRow converts an archived row into arguments of the insert query.
+func (t *Table) decodeRow(row map[string]json.RawMessage) (args []any, err error) {
+	args = make([]any, 0, len(t.Columns)+len(t.Defaults))
+
+	var raw json.RawMessage
+	var ok bool
+	var arg any
+	for _, column := range t.Columns {
+		raw, ok = row[column.Name]
+		if !ok {
+			return nil, fmt.Errorf(ErrFColumnIsNotSet, t.FullName(), column.Name)
+		}
+
+		arg, err = decodeColumnValue(column.Kind, raw)
+		if err != nil {
+			return nil, fmt.Errorf(ErrFColumnValue, t.FullName(), column.Name, err)
+		}
+
+		args = append(args, arg)
+	}
+
+	for _, name := range t.defaultColumnNames() {
+		args = append(args, t.Defaults[name])
+	}
+
+	return args, nil
+}
+
+// decodeColumnValue converts JSON into a value of a column. null becomes
+// NULL.
+func decodeColumnValue(kind ColumnKind, raw json.RawMessage) (value any, err error) {
+	if string(raw) == "null" {
+		return nil, nil
+	}
+
+	switch kind {
+	case ColumnKind_Int:
+		var x int64
+		err = json.Unmarshal(raw, &x)
+		return x, err
+
+	case ColumnKind_Text:
+		var x string
+		err = json.Unmarshal(raw, &x)
+		return x, err
+
+	case ColumnKind_Time:
+		var x time.Time
+		err = json.Unmarshal(raw, &x)
+		return x, err
+
+	case ColumnKind_Binary:
+		var x []byte
+		err = json.Unmarshal(raw, &x)
+		return x, err
+
+	case ColumnKind_UidList:
+		var x []cmb.Id
+		err = json.Unmarshal(raw, &x)
+		if err != nil {
+			return nil, err
+		}
+
+		var list *ul.UidList
+		list, err = ul.NewFromArray(x)
+		if err != nil {
+			return nil, err
+		}
+
+		return list, nil
+
+	default:
+		return nil, fmt.Errorf(ErrFUnknownColumnKind, kind)
+	}
+}
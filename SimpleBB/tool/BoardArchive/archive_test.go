@@ -0,0 +1,259 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/vault-thirteen/SimpleBB/pkg/common/models/app"
+	"github.com/vault-thirteen/auxie/tester"
+)
+
+// memStore is an in-memory database understanding only the queries made by
+// the archive.
+type memStore struct {
+	guard  sync.Mutex
+	tables map[string][]map[string]driver.Value
+}
+
+var (
+	reCount  = regexp.MustCompile(`^SELECT COUNT\(\*\) FROM (\w+);$`)
+	reSelect = regexp.MustCompile(`^SELECT (.+) FROM (\w+) ORDER BY .+;$`)
+	reInsert = regexp.MustCompile(`^INSERT INTO (\w+) \((.+)\) VALUES \(.+\);$`)
+)
+
+func newMemStore() *memStore {
+	return &memStore{tables: make(map[string][]map[string]driver.Value)}
+}
+
+func (s *memStore) Connect(_ context.Context) (driver.Conn, error) { return &memConn{s: s}, nil }
+func (s *memStore) Driver() driver.Driver                          { return memDriver{} }
+
+type memDriver struct{}
+
+func (memDriver) Open(_ string) (driver.Conn, error) { return nil, errors.New("use a connector") }
+
+type memConn struct{ s *memStore }
+
+func (c *memConn) Prepare(query string) (driver.Stmt, error) {
+	return &memStmt{s: c.s, query: query}, nil
+}
+func (c *memConn) Close() error { return nil }
+func (c *memConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("transactions are not supported")
+}
+
+type memStmt struct {
+	s     *memStore
+	query string
+}
+
+func (st *memStmt) Close() error  { return nil }
+func (st *memStmt) NumInput() int { return -1 }
+
+func (st *memStmt) Exec(args []driver.Value) (driver.Result, error) {
+	m := reInsert.FindStringSubmatch(st.query)
+	if m == nil {
+		return nil, fmt.Errorf("unsupported query: %v", st.query)
+	}
+
+	columns := strings.Split(m[2], ", ")
+	if len(columns) != len(args) {
+		return nil, fmt.Errorf("%v columns, %v arguments", len(columns), len(args))
+	}
+
+	row := make(map[string]driver.Value, len(columns))
+	for i, column := range columns {
+		row[column] = args[i]
+	}
+
+	st.s.guard.Lock()
+	defer st.s.guard.Unlock()
+	st.s.tables[m[1]] = append(st.s.tables[m[1]], row)
+
+	return driver.RowsAffected(1), nil
+}
+
+func (st *memStmt) Query(_ []driver.Value) (driver.Rows, error) {
+	st.s.guard.Lock()
+	defer st.s.guard.Unlock()
+
+	if m := reCount.FindStringSubmatch(st.query); m != nil {
+		return &memRows{columns: []string{"COUNT(*)"}, values: [][]driver.Value{{int64(len(st.s.tables[m[1]]))}}}, nil
+	}
+
+	m := reSelect.FindStringSubmatch(st.query)
+	if m == nil {
+		return nil, fmt.Errorf("unsupported query: %v", st.query)
+	}
+
+	rows := &memRows{columns: strings.Split(m[1], ", ")}
+	for _, row := range st.s.tables[m[2]] {
+		values := make([]driver.Value, 0, len(rows.columns))
+		for _, column := range rows.columns {
+			values = append(values, row[column])
+		}
+		rows.values = append(rows.values, values)
+	}
+
+	return rows, nil
+}
+
+type memRows struct {
+	columns []string
+	values  [][]driver.Value
+}
+
+func (r *memRows) Columns() []string { return r.columns }
+func (r *memRows) Close() error      { return nil }
+
+func (r *memRows) Next(dest []driver.Value) error {
+	if len(r.values) == 0 {
+		return io.EOF
+	}
+
+	copy(dest, r.values[0])
+	r.values = r.values[1:]
+	return nil
+}
+
+// memDatabase is a database of a module kept in memory.
+type memDatabase struct {
+	store  *memStore
+	db     *sql.DB
+	prefix string
+}
+
+func newMemDatabase(prefix string) *memDatabase {
+	store := newMemStore()
+	return &memDatabase{store: store, db: sql.OpenDB(store), prefix: prefix}
+}
+
+func (d *memDatabase) DB() *sql.DB { return d.db }
+
+func (d *memDatabase) PrefixTableName(tableName string) (tableNameFull string) {
+	return d.prefix + tableName
+}
+
+func (d *memDatabase) insert(tableName string, row map[string]driver.Value) {
+	d.store.tables[d.PrefixTableName(tableName)] = append(d.store.tables[d.PrefixTableName(tableName)], row)
+}
+
+func newMemDatabases(prefix string) (dbs map[string]*memDatabase, databases map[string]IDatabase) {
+	dbs = make(map[string]*memDatabase)
+	databases = make(map[string]IDatabase)
+	for _, module := range []string{app.ServiceShortName_ACM, app.ServiceShortName_MM, app.ServiceShortName_NM, app.ServiceShortName_SM} {
+		dbs[module] = newMemDatabase(prefix)
+		databases[module] = dbs[module]
+	}
+	return dbs, databases
+}
+
+func Test_ExportImport(t *testing.T) {
+	aTest := tester.New(t)
+
+	t1 := time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC)
+	t2 := time.Date(2024, 5, 7, 10, 0, 0, 0, time.UTC)
+
+	src, srcDatabases := newMemDatabases("v1_")
+	acm, mm, nm, sm := src[app.ServiceShortName_ACM], src[app.ServiceShortName_MM], src[app.ServiceShortName_NM], src[app.ServiceShortName_SM]
+
+	acm.insert("Users", map[string]driver.Value{"Id": int64(1), "PreRegTime": t1, "Email": "a@example.org", "Name": "Alice", "ApprovalTime": t1, "RegTime": t1, "CanLogIn": int64(1), "LastBadLogInTime": nil, "BanTime": nil, "LastBadActionTime": nil, "Password": []byte("secret")})
+	acm.insert("Users", map[string]driver.Value{"Id": int64(2), "PreRegTime": t1, "Email": "b@example.org", "Name": "Bob", "ApprovalTime": t2, "RegTime": t2, "CanLogIn": int64(0), "LastBadLogInTime": t2, "BanTime": t2, "LastBadActionTime": nil, "Password": []byte("secret")})
+	acm.insert("Permissions", map[string]driver.Value{"Id": int64(1), "UserId": int64(1), "Permission": int64(1), "ScopeType": int64(1), "ScopeId": int64(0), "GrantorUserId": int64(0), "TimeOfCreation": t1})
+	acm.insert("Permissions", map[string]driver.Value{"Id": int64(2), "UserId": int64(2), "Permission": int64(3), "ScopeType": int64(2), "ScopeId": int64(1), "GrantorUserId": int64(1), "TimeOfCreation": t2})
+	mm.insert("Sections", map[string]driver.Value{"Id": int64(1), "Parent": nil, "ChildType": int64(2), "Children": []byte("[1]"), "Name": "Root", "IsPrivate": int64(0), "CreatorUserId": int64(1), "CreatorTime": t1, "EditorUserId": nil, "EditorTime": nil})
+	mm.insert("Forums", map[string]driver.Value{"Id": int64(1), "SectionId": int64(1), "Name": "Forum", "Threads": []byte("[1]"), "IsReadOnly": int64(0), "CreatorUserId": int64(1), "CreatorTime": t1, "EditorUserId": nil, "EditorTime": nil})
+	mm.insert("Threads", map[string]driver.Value{"Id": int64(1), "ForumId": int64(1), "Name": "Thread", "Messages": []byte("[1,2]"), "IsLocked": int64(0), "IsPinned": int64(1), "IsAnnouncement": int64(0), "CreatorUserId": int64(1), "CreatorTime": t1, "EditorUserId": int64(2), "EditorTime": t2})
+	mm.insert("Messages", map[string]driver.Value{"Id": int64(1), "ThreadId": int64(1), "Text": "Hello, <b>World</b>!", "TextChecksum": []byte{0x01, 0xFF}, "TextHtml": "<p>Hello, &lt;b&gt;World&lt;/b&gt;!</p>", "CreatorUserId": int64(1), "CreatorTime": t1, "EditorUserId": nil, "EditorTime": nil})
+	mm.insert("Messages", map[string]driver.Value{"Id": int64(2), "ThreadId": int64(1), "Text": "Привет", "TextChecksum": []byte{0x02}, "TextHtml": "<p>Привет</p>", "CreatorUserId": int64(2), "CreatorTime": t2, "EditorUserId": nil, "EditorTime": nil})
+	mm.insert("MessageWords", map[string]driver.Value{"Word": "hello", "MessageId": int64(1), "Frequency": int64(1)})
+	mm.insert("MessageRevisions", map[string]driver.Value{"Id": int64(1), "MessageId": int64(1), "Text": "Hello", "TextChecksum": []byte{0x03}, "EditorUserId": int64(1), "EditorTime": t1})
+	mm.insert("Blobs", map[string]driver.Value{"Hash": "ab01", "Size": int64(3), "ToC": t1})
+	mm.insert("Attachments", map[string]driver.Value{"Id": int64(1), "MessageId": int64(1), "Name": "a.txt", "ContentType": "text/plain", "Size": int64(3), "BlobHash": "ab01", "ThumbnailHash": nil, "ToC": t1})
+	mm.insert("Polls", map[string]driver.Value{"Id": int64(1), "ThreadId": int64(1), "Question": "Why?", "IsMultipleChoice": int64(0), "AreResultsHidden": int64(1), "ClosingTime": nil, "IsClosed": int64(0), "ToC": t1})
+	mm.insert("PollOptions", map[string]driver.Value{"Id": int64(1), "PollId": int64(1), "Text": "Because"})
+	mm.insert("PollVoters", map[string]driver.Value{"PollId": int64(1), "UserId": int64(2), "ToC": t2, "ToE": nil})
+	mm.insert("PollVotes", map[string]driver.Value{"PollId": int64(1), "UserId": int64(2), "OptionId": int64(1)})
+	mm.insert("MessageReactions", map[string]driver.Value{"MessageId": int64(1), "UserId": int64(2), "Emoji": "👍", "AuthorUserId": int64(1), "Weight": int64(1), "ToC": t2})
+	mm.insert("UserReputations", map[string]driver.Value{"UserId": int64(1), "Reputation": int64(1), "ToU": t2})
+	mm.insert("Conversations", map[string]driver.Value{"Id": int64(1), "Subject": "Hi", "LastMessageTime": t2, "CreatorUserId": int64(1), "CreatorTime": t1})
+	mm.insert("ConversationMembers", map[string]driver.Value{"ConversationId": int64(1), "UserId": int64(1), "LastReadMessageId": int64(1)})
+	mm.insert("ConversationMembers", map[string]driver.Value{"ConversationId": int64(1), "UserId": int64(2), "LastReadMessageId": int64(0)})
+	mm.insert("PrivateMessages", map[string]driver.Value{"Id": int64(1), "ConversationId": int64(1), "Text": "Hi, Bob", "CreatorUserId": int64(1), "CreatorTime": t1})
+	mm.insert("UserBlocks", map[string]driver.Value{"UserId": int64(2), "BlockedUserId": int64(1), "ToC": t2})
+	nm.insert("Notifications", map[string]driver.Value{"Id": int64(1), "UserId": int64(2), "Text": "Reply", "ToC": t2, "IsRead": int64(1), "ToR": t2})
+	sm.insert("UserSubscriptions", map[string]driver.Value{"Id": int64(1), "UserId": int64(2), "Threads": []byte("[1]")})
+
+	var buf bytes.Buffer
+	n, err := exportBoard(srcDatabases, &buf)
+	aTest.MustBeNoError(err)
+	aTest.MustBeEqual(n, 26)
+	aTest.MustBeEqual(strings.Contains(buf.String(), "secret"), false)
+
+	archive := buf.Bytes()
+
+	var dst map[string]*memDatabase
+	var dstDatabases map[string]IDatabase
+	dst, dstDatabases = newMemDatabases("")
+	n, err = importBoard(dstDatabases, bytes.NewReader(archive))
+	aTest.MustBeNoError(err)
+	aTest.MustBeEqual(n, 26)
+
+	// Archived columns are restored as they were, secrets get default values.
+	for _, table := range Tables {
+		srcRows := src[table.Module].store.tables[src[table.Module].PrefixTableName(table.Name)]
+		dstRows := dst[table.Module].store.tables[table.Name]
+		aTest.MustBeEqual(len(dstRows), len(srcRows))
+
+		for i := range srcRows {
+			for _, column := range table.Columns {
+				aTest.MustBeEqual(normaliseValue(dstRows[i][column.Name]), normaliseValue(srcRows[i][column.Name]))
+			}
+			for name, value := range table.Defaults {
+				aTest.MustBeEqual(dstRows[i][name], value)
+			}
+		}
+	}
+
+	// Tables must be empty.
+	_, err = importBoard(dstDatabases, bytes.NewReader(archive))
+	aTest.MustBeAnError(err)
+}
+
+func Test_importBoard_Version(t *testing.T) {
+	aTest := tester.New(t)
+
+	_, databases := newMemDatabases("")
+
+	_, err := importBoard(databases, strings.NewReader(""))
+	aTest.MustBeAnError(err)
+
+	_, err = importBoard(databases, strings.NewReader(fmt.Sprintf(`{"format":"SimpleBB","version":%v}`+"\n", ArchiveVersion+1)))
+	aTest.MustBeAnError(err)
+
+	_, err = importBoard(databases, strings.NewReader(fmt.Sprintf(`{"format":"SimpleBB","version":%v}`+"\n"+`{"table":"ACM.Secrets","row":{}}`+"\n", ArchiveVersion)))
+	aTest.MustBeAnError(err)
+}
+
+// normaliseValue makes values of the source and the restored databases
+// comparable.
+func normaliseValue(v driver.Value) any {
+	switch x := v.(type) {
+	case time.Time:
+		return x.UTC().Format(time.RFC3339Nano)
+	case []byte:
+		return string(x)
+	default:
+		return v
+	}
+}
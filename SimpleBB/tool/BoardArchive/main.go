@@ -0,0 +1,193 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+
+	as "github.com/vault-thirteen/SimpleBB/pkg/ACM/settings"
+	ms "github.com/vault-thirteen/SimpleBB/pkg/MM/settings"
+	ns "github.com/vault-thirteen/SimpleBB/pkg/NM/settings"
+	ss "github.com/vault-thirteen/SimpleBB/pkg/SM/settings"
+	"github.com/vault-thirteen/SimpleBB/pkg/common/models/app"
+	cdbo "github.com/vault-thirteen/SimpleBB/pkg/common/models/dbo"
+	cs "github.com/vault-thirteen/SimpleBB/pkg/common/models/settings"
+	ae "github.com/vault-thirteen/auxie/errors"
+)
+
+const (
+	ActionExport = "export"
+	ActionImport = "import"
+)
+
+const (
+	ErrActionIsNotSet      = "action is not set"
+	ErrArchiveFileIsNotSet = "archive file is not set"
+	ErrFUnknownAction      = "unknown action: %v"
+)
+
+const (
+	MsgFExported = "Exported %v rows into %v."
+	MsgFImported = "Imported %v rows from %v."
+)
+
+// The tool exports the board into an archive and imports it back. Settings
+// of modules are used to connect to their databases, thus the tool is started
+// from the folder where the modules are installed.
+func main() {
+	action, archiveFile, configFolder, err := receiveArguments()
+	mustBeNoError(err)
+
+	var databases map[string]*cdbo.DatabaseObject
+	databases, err = connectToDatabases(configFolder)
+	mustBeNoError(err)
+
+	defer func() {
+		for _, dbo := range databases {
+			derr := dbo.Fin()
+			if derr != nil {
+				log.Println(derr.Error())
+			}
+		}
+	}()
+
+	var archived = make(map[string]IDatabase, len(databases))
+	for module, dbo := range databases {
+		archived[module] = dbo
+	}
+
+	var n int
+	switch action {
+	case ActionExport:
+		n, err = exportToFile(archived, archiveFile)
+		mustBeNoError(err)
+		fmt.Println(fmt.Sprintf(MsgFExported, n, archiveFile))
+
+	case ActionImport:
+		n, err = importFromFile(archived, archiveFile)
+		mustBeNoError(err)
+		fmt.Println(fmt.Sprintf(MsgFImported, n, archiveFile))
+	}
+}
+
+func mustBeNoError(err error) {
+	if err != nil {
+		log.Fatalln(err.Error())
+	}
+}
+
+func receiveArguments() (action string, archiveFile string, configFolder string, err error) {
+	flag.StringVar(&action, "a", "", "action: export or import")
+	flag.StringVar(&archiveFile, "f", "", "path to archive file")
+	flag.StringVar(&configFolder, "c", ".", "path to folder with settings of modules")
+	flag.Parse()
+
+	switch action {
+	case ActionExport, ActionImport:
+	case "":
+		return "", "", "", errors.New(ErrActionIsNotSet)
+	default:
+		return "", "", "", fmt.Errorf(ErrFUnknownAction, action)
+	}
+
+	if len(archiveFile) == 0 {
+		return "", "", "", errors.New(ErrArchiveFileIsNotSet)
+	}
+
+	return action, archiveFile, configFolder, nil
+}
+
+// connectToDatabases connects to databases of modules which store the
+// archived data. Missing tables are created.
+func connectToDatabases(configFolder string) (databases map[string]*cdbo.DatabaseObject, err error) {
+	var dbSettings = make(map[string]cs.DbSettings)
+
+	var acmSettings *as.Settings
+	acmSettings, err = as.NewSettingsFromFile(filepath.Join(configFolder, app.ConfigurationFilePathDefault_ACM), nil)
+	if err != nil {
+		return nil, err
+	}
+	dbSettings[app.ServiceShortName_ACM] = acmSettings.DbSettings
+
+	var mmSettings *ms.Settings
+	mmSettings, err = ms.NewSettingsFromFile(filepath.Join(configFolder, app.ConfigurationFilePathDefault_MM), nil)
+	if err != nil {
+		return nil, err
+	}
+	dbSettings[app.ServiceShortName_MM] = mmSettings.DbSettings
+
+	var nmSettings *ns.Settings
+	nmSettings, err = ns.NewSettingsFromFile(filepath.Join(configFolder, app.ConfigurationFilePathDefault_NM), nil)
+	if err != nil {
+		return nil, err
+	}
+	dbSettings[app.ServiceShortName_NM] = nmSettings.DbSettings
+
+	var smSettings *ss.Settings
+	smSettings, err = ss.NewSettingsFromFile(filepath.Join(configFolder, app.ConfigurationFilePathDefault_SM), nil)
+	if err != nil {
+		return nil, err
+	}
+	dbSettings[app.ServiceShortName_SM] = smSettings.DbSettings
+
+	databases = make(map[string]*cdbo.DatabaseObject)
+	for module, settings := range dbSettings {
+		// Time columns are archived as time values.
+		params := make(map[string]string)
+		for k, v := range settings.Params {
+			params[k] = v
+		}
+		params["parseTime"] = "true"
+		settings.Params = params
+
+		dbo := cdbo.NewDatabaseObject(settings)
+		err = dbo.Init([]string{})
+		if err != nil {
+			for _, x := range databases {
+				err = ae.Combine(err, x.Fin())
+			}
+			return nil, err
+		}
+
+		databases[module] = dbo
+	}
+
+	return databases, nil
+}
+
+func exportToFile(databases map[string]IDatabase, filePath string) (n int, err error) {
+	var f *os.File
+	f, err = os.Create(filePath)
+	if err != nil {
+		return 0, err
+	}
+
+	defer func() {
+		derr := f.Close()
+		if derr != nil {
+			err = ae.Combine(err, derr)
+		}
+	}()
+
+	return exportBoard(databases, f)
+}
+
+func importFromFile(databases map[string]IDatabase, filePath string) (n int, err error) {
+	var f *os.File
+	f, err = os.Open(filePath)
+	if err != nil {
+		return 0, err
+	}
+
+	defer func() {
+		derr := f.Close()
+		if derr != nil {
+			err = ae.Combine(err, derr)
+		}
+	}()
+
+	return importBoard(databases, f)
+}
@@ -7,6 +7,8 @@ import (
 	cmb "github.com/vault-thirteen/SimpleBB/pkg/common/models/base"
 	"github.com/vault-thirteen/SimpleBB/pkg/common/models/simple"
 	"log"
+	"math"
+	"time"
 
 	"github.com/vault-thirteen/SimpleBB/pkg/ACM/km"
 )
@@ -14,15 +16,19 @@ import (
 const (
 	ErrUserIdIsNotSet    = "user ID is not set"
 	ErrSessionIdIsNotSet = "session ID is not set"
-	ErrKeyFileIsNotSet   = "key file is not set"
+	ErrKeyRingIsNotSet   = "key ring folder is not set"
 )
 
+// The tool must never delete keys of the key ring, thus retired keys are
+// kept forever.
+const KeyRetentionPeriod = time.Duration(math.MaxInt64)
+
 func main() {
-	userId, sessionId, privateKeyFilePath, publicKeyFilePath, signingMethod, err := receiveArguments()
+	userId, sessionId, keyRingFolder, signingMethod, err := receiveArguments()
 	mustBeNoError(err)
 
 	var keyMaker *km.KeyMaker
-	keyMaker, err = km.New(signingMethod, privateKeyFilePath, publicKeyFilePath)
+	keyMaker, err = km.New(signingMethod, keyRingFolder, 0, KeyRetentionPeriod, "")
 	mustBeNoError(err)
 
 	var ts simple.WebTokenString
@@ -43,38 +49,31 @@ func mustBeNoError(err error) {
 	}
 }
 
-func receiveArguments() (userId cmb.Id, sessionId cmb.Id, privateKeyFilePath simple.Path, publicKeyFilePath simple.Path, signingMethod string, err error) {
+func receiveArguments() (userId cmb.Id, sessionId cmb.Id, keyRingFolder simple.Path, signingMethod string, err error) {
 	var userIdInt int
 	flag.IntVar(&userIdInt, "uid", 0, "user ID")
 	var sessionIdInt int
 	flag.IntVar(&sessionIdInt, "sid", 0, "session ID")
-	var privateKeyFilePathStr string
-	flag.StringVar(&privateKeyFilePathStr, "private_key", "", "path to private key file using PEM format")
-	var publicKeyFilePathStr string
-	flag.StringVar(&publicKeyFilePathStr, "public_key", "", "path to public key file using PEM format")
+	var keyRingFolderStr string
+	flag.StringVar(&keyRingFolderStr, "key_ring", "", "path to folder of key ring")
 	flag.StringVar(&signingMethod, "method", "", "signing method")
 	flag.Parse()
 
 	userId = cmb.Id(userIdInt)
 	sessionId = cmb.Id(sessionIdInt)
-	privateKeyFilePath = simple.Path(privateKeyFilePathStr)
-	publicKeyFilePath = simple.Path(publicKeyFilePathStr)
+	keyRingFolder = simple.Path(keyRingFolderStr)
 
 	if userId == 0 {
-		return 0, 0, "", "", "", errors.New(ErrUserIdIsNotSet)
+		return 0, 0, "", "", errors.New(ErrUserIdIsNotSet)
 	}
 
 	if sessionId == 0 {
-		return 0, 0, "", "", "", errors.New(ErrSessionIdIsNotSet)
-	}
-
-	if len(privateKeyFilePath) == 0 {
-		return 0, 0, "", "", "", errors.New(ErrKeyFileIsNotSet)
+		return 0, 0, "", "", errors.New(ErrSessionIdIsNotSet)
 	}
 
-	if len(publicKeyFilePath) == 0 {
-		return 0, 0, "", "", "", errors.New(ErrKeyFileIsNotSet)
+	if len(keyRingFolder) == 0 {
+		return 0, 0, "", "", errors.New(ErrKeyRingIsNotSet)
 	}
 
-	return userId, sessionId, privateKeyFilePath, publicKeyFilePath, signingMethod, nil
+	return userId, sessionId, keyRingFolder, signingMethod, nil
 }